@@ -0,0 +1,125 @@
+// Command importusers imports the users in a CSV or NDJSON file, as POST /users/import does,
+// and prints the report as JSON. It exits with 1 if any row failed.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/domainservice"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/env"
+	"github.com/toshiykst/go-layerd-architecture/app/handler"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/userimport"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func main() {
+	var (
+		file      = flag.String("file", "", "the file to import, read from stdin if empty")
+		format    = flag.String("format", "", "csv or ndjson, inferred from the extension of the file if empty")
+		mode      = flag.String("mode", string(dto.ImportModeCreate), "create or upsert")
+		dryRun    = flag.Bool("dry-run", false, "report the results without importing the users")
+		batchSize = flag.Int("batch-size", handler.DefaultImportBatchSize, "the number of the rows committed in a transaction")
+		actor     = flag.String("actor", "importusers", "who performs the import, recorded in the audit log")
+	)
+	flag.Parse()
+
+	f, err := formatOf(*file, *format)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	r := os.Stdin
+	if *file != "" {
+		r, err = os.Open(*file)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		defer r.Close()
+	}
+	rows, err := userimport.Decode(r, f)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	c, err := env.NewConfig()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	p, err := c.Policy()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	idg, err := c.IDGenerator()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	db := database.NewDBRepository(context.Background(), database.Config{
+		User:     c.DBUser,
+		Password: c.DBPassword,
+		Host:     c.DBHost,
+		DBName:   c.DBName,
+		Debug:    c.DBDebug,
+	})
+
+	uc := usecase.NewUserUsecase(
+		db,
+		factory.NewUserFactory(p.User, idg),
+		domainservice.NewUserService(db),
+		domainservice.NewGroupService(db),
+		factory.NewAuditEventFactory(idg),
+		factory.NewOutboxMessageFactory(idg),
+		factory.NewWebhookDeliveryFactory(idg),
+		p,
+		usecase.ClockFunc(time.Now),
+	)
+
+	out, err := uc.ImportUsers(&dto.ImportUsersInput{
+		Meta:      dto.Meta{Actor: *actor},
+		Rows:      rows,
+		Mode:      dto.ImportMode(*mode),
+		DryRun:    *dryRun,
+		BatchSize: *batchSize,
+	})
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	res := &handler.ImportUsersResponse{
+		DryRun:  *dryRun,
+		Summary: response.ToImportSummaryFromDTO(out.Results),
+		Results: response.ToImportUserResultsFromDTO(out.Results),
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(res); err != nil {
+		log.Fatal(err.Error())
+	}
+	if res.Summary.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// formatOf returns the format given by the flag, or the one of the extension of the file.
+func formatOf(file, format string) (userimport.Format, error) {
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(file), ".")
+	}
+	switch f := userimport.Format(strings.ToLower(format)); f {
+	case userimport.FormatCSV, userimport.FormatNDJSON:
+		return f, nil
+	}
+	return "", fmt.Errorf("format must be csv or ndjson: %q", format)
+}
@@ -2,17 +2,26 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"net"
+	"time"
 
 	"github.com/labstack/echo"
 	"github.com/labstack/echo/middleware"
 
 	"github.com/toshiykst/go-layerd-architecture/app/domain/domainservice"
 	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
 	"github.com/toshiykst/go-layerd-architecture/app/env"
 	"github.com/toshiykst/go-layerd-architecture/app/handler"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/grpchandler"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/openapi"
 	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/publisher"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/webhook"
 	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/worker"
 )
 
 func main() {
@@ -22,6 +31,14 @@ func main() {
 	if err != nil {
 		log.Fatal(err.Error())
 	}
+	policy, err := c.Policy()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	idg, err := c.IDGenerator()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 
 	db := database.NewDBRepository(ctx, database.Config{
 		User:     c.DBUser,
@@ -31,33 +48,132 @@ func main() {
 		Debug:    c.DBDebug,
 	})
 
-	uf := factory.NewUserFactory()
-	gf := factory.NewGroupFactory()
+	uf := factory.NewUserFactory(policy.User, idg)
+	gf := factory.NewGroupFactory(policy.Group, idg)
+	gif := factory.NewGroupInvitationFactory(c.GroupInvitationTTL, idg)
+	gjf := factory.NewGroupJoinRequestFactory(idg)
+	af := factory.NewAuditEventFactory(idg)
+	of := factory.NewOutboxMessageFactory(idg)
+	wsf := factory.NewWebhookSubscriptionFactory(idg)
+	wdf := factory.NewWebhookDeliveryFactory(idg)
 
 	us := domainservice.NewUserService(db)
 	gs := domainservice.NewGroupService(db)
 
-	uuc := usecase.NewUserUsecase(db, uf, us, gs)
+	clock := usecase.ClockFunc(time.Now)
+
+	uuc := usecase.NewUserUsecase(db, uf, us, gs, af, of, wdf, policy, clock)
 	uh := handler.NewUserHandler(uuc)
 
-	guc := usecase.NewGroupUsecase(db, gf, gs, us)
+	guc := usecase.NewGroupUsecase(db, gf, gs, us, af, of, wdf, policy, clock)
 	gh := handler.NewGroupHandler(guc)
 
+	giuc := usecase.NewGroupInvitationUsecase(db, gif, af, of, wdf, policy, clock)
+	gih := handler.NewGroupInvitationHandler(giuc)
+
+	gjuc := usecase.NewGroupJoinUsecase(db, gjf, af, of, wdf, policy, clock)
+	gjh := handler.NewGroupJoinHandler(gjuc)
+
+	buc := usecase.NewBatchUsecase(db, func(r repository.Repository) usecase.BatchUsecases {
+		us := domainservice.NewUserService(r)
+		gs := domainservice.NewGroupService(r)
+		return usecase.BatchUsecases{
+			User:  usecase.NewUserUsecase(r, uf, us, gs, af, of, wdf, policy, clock),
+			Group: usecase.NewGroupUsecase(r, gf, gs, us, af, of, wdf, policy, clock),
+		}
+	})
+	bh := handler.NewBatchHandler(buc)
+
+	qh, err := handler.NewGraphQLHandler(uuc, guc, handler.GraphQLLimits{
+		MaxDepth:      c.GraphQLMaxDepth,
+		MaxComplexity: c.GraphQLMaxComplexity,
+	})
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	auc := usecase.NewAuditEventUsecase(db)
+	ah := handler.NewAuditEventHandler(auc)
+
+	wuc := usecase.NewWebhookUsecase(db, wsf, webhook.NewSender(nil), clock)
+	wh := handler.NewWebhookHandler(wuc)
+
+	sh := handler.NewAttributeSchemaHandler(usecase.NewAttributeSchemaUsecase(db))
+
+	iuc := usecase.NewIdempotencyUsecase(db, c.IdempotencyKeyTTL, clock)
+	ih := handler.NewIdempotencyHandler(iuc)
+
+	oh, err := openapi.NewHandler(openapi.Operations)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
 	e := echo.New()
+
+	bus := publisher.NewBus()
+	p, err := newPublisher(c, bus, e)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	ouc := usecase.NewOutboxUsecase(db, p, clock)
+	go worker.NewOutboxRelay(ouc, c.OutboxRelayInterval, c.OutboxBatchSize).Run(ctx)
+	go worker.NewOutboxPurger(ouc, c.OutboxPurgeInterval, c.OutboxRetention).Run(ctx)
+	go worker.NewWebhookDispatcher(wuc, c.WebhookDispatchInterval, c.WebhookBatchSize).Run(ctx)
+	go worker.NewIdempotencyKeyPurger(iuc, c.IdempotencyKeyPurgeInterval).Run(ctx)
+	go worker.NewGroupInvitationSweeper(giuc, c.GroupInvitationSweepInterval).Run(ctx)
+
+	evuc := usecase.NewEventUsecase(db, bus)
+	evh := handler.NewEventHandler(evuc)
+
+	e.Use(middleware.RequestID())
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
+	e.Use(openapi.NewValidator(openapi.Operations).Middleware)
+	e.Use(ih.Middleware)
 
-	e.POST("/users", uh.CreateUser)
-	e.GET("/users/:id", uh.GetUser)
-	e.GET("/users", uh.GetUsers)
-	e.PUT("/users/:id", uh.UpdateUser)
-	e.DELETE("/users/:id", uh.DeleteUser)
+	registerRoutes(e, handlers{
+		user:       uh,
+		group:      gh,
+		invitation: gih,
+		join:       gjh,
+		graphQL:    qh,
+		auditEvent: ah,
+		event:      evh,
+		webhook:    wh,
+		attribute:  sh,
+		batch:      bh,
+	})
+	registerDocRoutes(e, oh)
 
-	e.POST("/groups", gh.CreateGroup)
-	e.GET("/groups/:id", gh.GetGroup)
-	e.GET("/groups", gh.GetGroups)
-	e.PUT("/groups/:id", gh.UpdateGroup)
-	e.DELETE("/groups/:id", gh.DeleteGroup)
+	go func() {
+		lis, err := net.Listen("tcp", fmt.Sprintf(":%d", c.GRPCPort))
+		if err != nil {
+			e.Logger.Fatal(err)
+		}
+		e.Logger.Fatal(grpchandler.NewServer(uuc, guc).Serve(lis))
+	}()
 
 	e.Logger.Fatal(e.Start(":8080"))
 }
+
+func newPublisher(c *env.Config, bus *publisher.Bus, e *echo.Echo) (usecase.Publisher, error) {
+	// The bus is always published to, since the event stream is fed by it.
+	ps := publisher.Multi{bus}
+	for _, name := range c.OutboxPublishers {
+		switch name {
+		case "bus":
+		case "log":
+			ps = append(ps, publisher.NewLog(e.Logger))
+		case "file":
+			ps = append(ps, publisher.NewFile(c.OutboxFilePath))
+		case "webhook":
+			if c.OutboxWebhookURL == "" {
+				return nil, fmt.Errorf("OUTBOX_WEBHOOK_URL is required for the webhook publisher")
+			}
+			ps = append(ps, publisher.NewWebhook(c.OutboxWebhookURL, nil))
+		default:
+			return nil, fmt.Errorf("unknown outbox publisher %q", name)
+		}
+	}
+	return ps, nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/openapi"
+)
+
+type handlers struct {
+	user       *handler.UserHandler
+	group      *handler.GroupHandler
+	invitation *handler.GroupInvitationHandler
+	join       *handler.GroupJoinHandler
+	graphQL    *handler.GraphQLHandler
+	auditEvent *handler.AuditEventHandler
+	event      *handler.EventHandler
+	webhook    *handler.WebhookHandler
+	attribute  *handler.AttributeSchemaHandler
+	batch      *handler.BatchHandler
+}
+
+// registerRoutes registers the routes of the HTTP API, each of which is documented in openapi.Operations.
+func registerRoutes(e *echo.Echo, h handlers) {
+	e.POST("/users", h.user.CreateUser)
+	e.GET("/users/:id", h.user.GetUser)
+	e.GET("/users", h.user.GetUsers)
+	e.PUT("/users/:id", h.user.UpdateUser)
+	e.PATCH("/users/:id", h.user.PatchUser)
+	e.DELETE("/users/:id", h.user.DeleteUser)
+	e.POST("/users/:id/activate", h.user.ActivateUser)
+	e.POST("/users/:id/suspend", h.user.SuspendUser)
+	e.POST("/users/:id/reactivate", h.user.ReactivateUser)
+	e.POST("/users/:id/deactivate", h.user.DeactivateUser)
+	e.POST("/users/import", h.user.ImportUsers)
+	e.GET("/users/export", h.user.ExportUsers)
+	e.GET("/users/:id/groups", h.group.GetUserGroups)
+
+	e.POST("/groups", h.group.CreateGroup)
+	e.GET("/groups/:id", h.group.GetGroup)
+	e.GET("/groups", h.group.GetGroups)
+	e.PUT("/groups/:id", h.group.UpdateGroup)
+	e.PATCH("/groups/:id", h.group.PatchGroup)
+	e.DELETE("/groups/:id", h.group.DeleteGroup)
+	e.GET("/groups/export", h.group.ExportGroups)
+	e.PUT("/groups/:id/labels", h.group.PutGroupLabels)
+	e.GET("/groups/:id/waitlist", h.group.GetGroupWaitlist)
+	e.PUT("/groups/:id/waitlist", h.group.ReorderGroupWaitlist)
+	e.DELETE("/groups/:id/waitlist/:userId", h.group.LeaveGroupWaitlist)
+	e.POST("/groups/:id/subgroups", h.group.AddSubgroup)
+	e.GET("/groups/:id/subgroups", h.group.GetSubgroups)
+	e.DELETE("/groups/:id/subgroups/:subgroupId", h.group.RemoveSubgroup)
+	e.GET("/groups/:id/members", h.group.GetGroupMembers)
+
+	e.POST("/groups/:id/invitations", h.invitation.InviteToGroup)
+	e.GET("/groups/:id/invitations", h.invitation.GetGroupInvitations)
+	e.GET("/users/:id/invitations", h.invitation.GetUserInvitations)
+	e.POST("/invitations/:id/accept", h.invitation.AcceptGroupInvitation)
+	e.POST("/invitations/:id/decline", h.invitation.DeclineGroupInvitation)
+	e.POST("/invitations/:id/revoke", h.invitation.RevokeGroupInvitation)
+
+	e.POST("/groups/:id/join", h.join.JoinGroup)
+	e.GET("/groups/:id/join-requests", h.join.GetGroupJoinRequests)
+	e.POST("/groups/:id/join-requests/:requestId/approve", h.join.ApproveGroupJoinRequest)
+	e.POST("/groups/:id/join-requests/:requestId/reject", h.join.RejectGroupJoinRequest)
+
+	e.POST("/batch", h.batch.RunBatch)
+
+	e.POST("/graphql", h.graphQL.Query)
+
+	e.GET("/audit-events", h.auditEvent.GetAuditEvents)
+
+	e.GET("/events/stream", h.event.StreamEvents)
+
+	e.GET("/attribute-schemas", h.attribute.GetAttributeSchemas)
+	e.PUT("/attribute-schemas/:target/:key", h.attribute.PutAttributeSchema)
+	e.DELETE("/attribute-schemas/:target/:key", h.attribute.DeleteAttributeSchema)
+
+	e.POST("/webhooks", h.webhook.CreateWebhookSubscription)
+	e.GET("/webhooks/:id", h.webhook.GetWebhookSubscription)
+	e.GET("/webhooks", h.webhook.GetWebhookSubscriptions)
+	e.PUT("/webhooks/:id", h.webhook.UpdateWebhookSubscription)
+	e.DELETE("/webhooks/:id", h.webhook.DeleteWebhookSubscription)
+	e.GET("/webhooks/:id/deliveries", h.webhook.GetWebhookDeliveries)
+	e.POST("/webhooks/:id/deliveries/:deliveryId/redeliver", h.webhook.RedeliverWebhook)
+}
+
+// registerDocRoutes registers the routes serving the document of the HTTP API.
+func registerDocRoutes(e *echo.Echo, h *openapi.Handler) {
+	e.GET(openapi.SpecPath, h.GetSpec)
+	e.GET("/swagger", h.SwaggerUI)
+	e.GET("/swagger/*", h.SwaggerUI)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/openapi"
+)
+
+func TestRegisterRoutes(t *testing.T) {
+	e := echo.New()
+	registerRoutes(e, handlers{})
+
+	var got []string
+	for _, r := range e.Routes() {
+		got = append(got, r.Method+" "+r.Path)
+	}
+	var want []string
+	for _, op := range openapi.Operations {
+		want = append(want, op.Method+" "+op.Path)
+	}
+	sort.Strings(got)
+	sort.Strings(want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf(
+			"the routes differ from openapi.Operations; document the route there\ndiffers: (-got +want)\n%s",
+			diff,
+		)
+	}
+}
@@ -8,6 +8,7 @@ import (
 type UserService interface {
 	Exists(uID model.UserID) (bool, error)
 	ExistsAll(uIDs []model.UserID) (bool, error)
+	CanAllJoinGroups(uIDs []model.UserID) (bool, error)
 }
 
 type userService struct {
@@ -43,3 +44,26 @@ func (us *userService) ExistsAll(uIDs []model.UserID) (bool, error) {
 
 	return true, nil
 }
+
+// CanAllJoinGroups returns true if all the users are in a status which can join groups.
+// The users which do not exist are ignored.
+func (us *userService) CanAllJoinGroups(uIDs []model.UserID) (bool, error) {
+	if len(uIDs) == 0 {
+		return true, nil
+	}
+
+	users, err := us.r.User().List(repository.UserListFilter{
+		UserIDs: uIDs,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, u := range users {
+		if !u.CanJoinGroups() {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
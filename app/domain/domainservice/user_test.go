@@ -113,3 +113,52 @@ func TestUserService_ExistsAll(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_CanAllJoinGroups(t *testing.T) {
+	tests := []struct {
+		name string
+		uIDs []model.UserID
+		want bool
+	}{
+		{
+			name: "Returns true if all the users are active or invited",
+			uIDs: []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+			want: true,
+		},
+		{
+			name: "Returns false if some user is suspended",
+			uIDs: []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_3"},
+			want: false,
+		},
+		{
+			name: "Returns false if some user is deactivated",
+			uIDs: []model.UserID{"TEST_USER_ID_4"},
+			want: false,
+		},
+		{
+			name: "Returns true if no users are given",
+			uIDs: nil,
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := memory.NewStore()
+			s.AddUsers(
+				model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+				model.MustNewUserWithStatus("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2", model.UserStatusInvited),
+				model.MustNewUserWithStatus("TEST_USER_ID_3", "TEST_USER_NAME_3", "TEST_USER_EMAIL_3", model.UserStatusSuspended),
+				model.MustNewUserWithStatus("TEST_USER_ID_4", "TEST_USER_NAME_4", "TEST_USER_EMAIL_4", model.UserStatusDeactivated),
+			)
+
+			us := domainservice.NewUserService(memory.NewMemoryRepository(s))
+			got, err := us.CanAllJoinGroups(tt.uIDs)
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("us.CanAllJoinGroups(%s)=%t, nil; want %t, nil", tt.uIDs, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+//go:generate mockgen -source=$GOFILE -package=mock$GOPACKAGE -destination=../../mock/domain/$GOPACKAGE/$GOFILE
+package factory
+
+import (
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+type AuditEventFactory interface {
+	Create(
+		actor, requestID string,
+		action model.AuditAction,
+		target model.AuditTarget,
+		changes []model.AuditChange,
+		occurredAt time.Time,
+	) (*model.AuditEvent, error)
+}
+
+type auditEventFactory struct {
+	idg IDGenerator
+}
+
+func NewAuditEventFactory(idg IDGenerator) AuditEventFactory {
+	return &auditEventFactory{idg: idg}
+}
+
+func (f auditEventFactory) Create(
+	actor, requestID string,
+	action model.AuditAction,
+	target model.AuditTarget,
+	changes []model.AuditChange,
+	occurredAt time.Time,
+) (*model.AuditEvent, error) {
+	id, err := f.idg.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := model.NewAuditEvent(
+		model.AuditEventID(id),
+		actor,
+		action,
+		target,
+		changes,
+		requestID,
+		occurredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
@@ -0,0 +1,95 @@
+package factory_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestAuditEventFactory_Create(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	type args struct {
+		actor     string
+		requestID string
+		action    model.AuditAction
+		target    model.AuditTarget
+		changes   []model.AuditChange
+	}
+	tests := []struct {
+		name    string
+		args    args
+		idg     factory.IDGenerator
+		wantID  model.AuditEventID
+		wantErr error
+	}{
+		{
+			name: "Returns audit event",
+			args: args{
+				actor:     "TEST_ACTOR",
+				requestID: "TEST_REQUEST_ID",
+				action:    model.AuditActionCreateUser,
+				target:    model.NewUserAuditTarget("TEST_USER_ID"),
+				changes:   []model.AuditChange{{Field: "name", After: "TEST_USER_NAME"}},
+			},
+			idg:     factory.NewSequenceIDGenerator("TEST_AUDIT_EVENT_ID_"),
+			wantID:  "TEST_AUDIT_EVENT_ID_1",
+			wantErr: nil,
+		},
+		{
+			name: "Error generating id",
+			args: args{
+				action: model.AuditActionCreateUser,
+				target: model.NewUserAuditTarget("TEST_USER_ID"),
+			},
+			idg:     factory.NewUUIDv4Generator(strings.NewReader("0")),
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name: "Error invalid audit event input",
+			args: args{
+				action: model.AuditActionCreateUser,
+				target: model.NewUserAuditTarget(""),
+			},
+			idg:     factory.NewSequenceIDGenerator("TEST_AUDIT_EVENT_ID_"),
+			wantErr: model.ErrInvalidAuditEvent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := factory.NewAuditEventFactory(tt.idg)
+			got, err := f.Create(
+				tt.args.actor, tt.args.requestID, tt.args.action, tt.args.target, tt.args.changes, occurredAt,
+			)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatalf("f.Create(%v)=_, nil; want _, %v", tt.args, tt.wantErr)
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("f.Create(%v)=_, %v; want _, %v", tt.args, err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("want no error, but has error %v", err)
+				}
+				if got.ID() != tt.wantID {
+					t.Errorf("f.Create(%v).ID()=%s; want %s", tt.args, got.ID(), tt.wantID)
+				}
+				if got.Actor() != tt.args.actor ||
+					got.RequestID() != tt.args.requestID ||
+					got.Action() != tt.args.action ||
+					got.Target() != tt.args.target {
+					t.Errorf("f.Create(%v)=%v; want the event built from the args", tt.args, got)
+				}
+				if !got.OccurredAt().Equal(occurredAt) {
+					t.Errorf("f.Create(%v).OccurredAt()=%v; want %v", tt.args, got.OccurredAt(), occurredAt)
+				}
+			}
+		})
+	}
+}
@@ -2,8 +2,6 @@
 package factory
 
 import (
-	"github.com/google/uuid"
-
 	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
 )
 
@@ -11,22 +9,28 @@ type GroupFactory interface {
 	Create(name string, uIDs []model.UserID) (*model.Group, error)
 }
 
-type groupFactory struct{}
+type groupFactory struct {
+	p   model.GroupPolicy
+	idg IDGenerator
+}
 
-func NewGroupFactory() GroupFactory {
-	return &groupFactory{}
+func NewGroupFactory(p model.GroupPolicy, idg IDGenerator) GroupFactory {
+	return &groupFactory{p: p, idg: idg}
 }
 
 func (uf groupFactory) Create(name string, uIDs []model.UserID) (*model.Group, error) {
-	generated, err := uuid.NewRandom()
+	id, err := uf.idg.Generate()
 	if err != nil {
 		return nil, err
 	}
 
-	g, err := model.NewGroup(model.GroupID(generated.String()), name, uIDs)
+	g, err := model.NewGroup(model.GroupID(id), name, uIDs)
 	if err != nil {
 		return nil, err
 	}
+	if err := uf.p.Validate(g); err != nil {
+		return nil, err
+	}
 
 	return g, nil
 }
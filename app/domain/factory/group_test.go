@@ -7,7 +7,6 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
-	"github.com/google/uuid"
 
 	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
 	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
@@ -21,7 +20,7 @@ func TestGroupFactory_Create(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
-		setup   func()
+		idg     factory.IDGenerator
 		want    *model.Group
 		wantErr error
 	}{
@@ -35,11 +34,9 @@ func TestGroupFactory_Create(t *testing.T) {
 					"TEST_USER_ID_3",
 				},
 			},
-			setup: func() {
-				uuid.SetRand(strings.NewReader("abcdefgh12345678"))
-			},
+			idg: factory.NewSequenceIDGenerator("TEST_GROUP_ID_"),
 			want: model.MustNewGroup(
-				"61626364-6566-4768-b132-333435363738",
+				"TEST_GROUP_ID_1",
 				"TEST_GROUP_NAME",
 				[]model.UserID{
 					"TEST_USER_ID_1",
@@ -50,14 +47,12 @@ func TestGroupFactory_Create(t *testing.T) {
 			wantErr: nil,
 		},
 		{
-			name: "Error creating uuid",
+			name: "Error generating id",
 			args: args{
 				name: "TEST_GROUP_NAME",
 				uIDs: []model.UserID{},
 			},
-			setup: func() {
-				uuid.SetRand(strings.NewReader("0"))
-			},
+			idg:     factory.NewUUIDv4Generator(strings.NewReader("0")),
 			want:    nil,
 			wantErr: io.ErrUnexpectedEOF,
 		},
@@ -67,9 +62,7 @@ func TestGroupFactory_Create(t *testing.T) {
 				name: "TEST_GROUP_NAME_XXXXXXXXXXXXXXXXXXXXXXXX",
 				uIDs: []model.UserID{},
 			},
-			setup: func() {
-				uuid.SetRand(strings.NewReader("abcdefgh12345678"))
-			},
+			idg:     factory.NewSequenceIDGenerator("TEST_GROUP_ID_"),
 			want:    nil,
 			wantErr: model.ErrInvalidGroup,
 		},
@@ -77,8 +70,7 @@ func TestGroupFactory_Create(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.setup()
-			f := factory.NewGroupFactory()
+			f := factory.NewGroupFactory(model.DefaultPolicy().Group, tt.idg)
 			got, err := f.Create(tt.args.name, tt.args.uIDs)
 			if tt.wantErr != nil {
 				if err == nil {
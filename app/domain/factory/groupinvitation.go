@@ -0,0 +1,50 @@
+//go:generate mockgen -source=$GOFILE -package=mock$GOPACKAGE -destination=../../mock/domain/$GOPACKAGE/$GOFILE
+package factory
+
+import (
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+type GroupInvitationFactory interface {
+	// Create returns a pending invitation to the group created at the time,
+	// which expires after the time to live of the factory.
+	Create(
+		gID model.GroupID,
+		inviter string,
+		invitee model.GroupInvitee,
+		createdAt time.Time,
+	) (*model.GroupInvitation, error)
+}
+
+type groupInvitationFactory struct {
+	ttl time.Duration
+	idg IDGenerator
+}
+
+func NewGroupInvitationFactory(ttl time.Duration, idg IDGenerator) GroupInvitationFactory {
+	return &groupInvitationFactory{ttl: ttl, idg: idg}
+}
+
+func (f groupInvitationFactory) Create(
+	gID model.GroupID,
+	inviter string,
+	invitee model.GroupInvitee,
+	createdAt time.Time,
+) (*model.GroupInvitation, error) {
+	id, err := f.idg.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewGroupInvitation(
+		model.GroupInvitationID(id),
+		gID,
+		inviter,
+		invitee,
+		createdAt,
+		createdAt.Add(f.ttl),
+		model.GroupInvitationState{},
+	)
+}
@@ -0,0 +1,74 @@
+package factory_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestGroupInvitationFactory_Create(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name    string
+		invitee model.GroupInvitee
+		idg     factory.IDGenerator
+		wantID  model.GroupInvitationID
+		wantErr error
+	}{
+		{
+			name:    "Returns a pending group invitation",
+			invitee: model.GroupInvitee{UserID: "TEST_USER_ID"},
+			idg:     factory.NewSequenceIDGenerator("TEST_GROUP_INVITATION_ID_"),
+			wantID:  "TEST_GROUP_INVITATION_ID_1",
+			wantErr: nil,
+		},
+		{
+			name:    "Error generating id",
+			invitee: model.GroupInvitee{UserID: "TEST_USER_ID"},
+			idg:     factory.NewUUIDv4Generator(strings.NewReader("0")),
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:    "Error invalid group invitation",
+			invitee: model.GroupInvitee{},
+			idg:     factory.NewSequenceIDGenerator("TEST_GROUP_INVITATION_ID_"),
+			wantErr: model.ErrInvalidGroupInvitation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := factory.NewGroupInvitationFactory(time.Hour, tt.idg)
+			got, err := f.Create("TEST_GROUP_ID", "TEST_ACTOR", tt.invitee, createdAt)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatalf("f.Create(%v)=_, nil; want _, %v", tt.invitee, tt.wantErr)
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("f.Create(%v)=_, %v; want _, %v", tt.invitee, err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("want no error, but has error %v", err)
+				}
+				if got.ID() != tt.wantID {
+					t.Errorf("got.ID()=%s; want %s", got.ID(), tt.wantID)
+				}
+				if got.State().Status != model.GroupInvitationStatusPending {
+					t.Errorf("got.State().Status=%s; want %s", got.State().Status, model.GroupInvitationStatusPending)
+				}
+				if !got.CreatedAt().Equal(createdAt) {
+					t.Errorf("got.CreatedAt()=%v; want %v", got.CreatedAt(), createdAt)
+				}
+				if want := createdAt.Add(time.Hour); !got.ExpiresAt().Equal(want) {
+					t.Errorf("got.ExpiresAt()=%v; want %v", got.ExpiresAt(), want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+//go:generate mockgen -source=$GOFILE -package=mock$GOPACKAGE -destination=../../mock/domain/$GOPACKAGE/$GOFILE
+package factory
+
+import (
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+type GroupJoinRequestFactory interface {
+	// Create returns a pending request of the user to join the group, which is created at the time.
+	Create(gID model.GroupID, uID model.UserID, createdAt time.Time) (*model.GroupJoinRequest, error)
+}
+
+type groupJoinRequestFactory struct {
+	idg IDGenerator
+}
+
+func NewGroupJoinRequestFactory(idg IDGenerator) GroupJoinRequestFactory {
+	return &groupJoinRequestFactory{idg: idg}
+}
+
+func (f groupJoinRequestFactory) Create(
+	gID model.GroupID,
+	uID model.UserID,
+	createdAt time.Time,
+) (*model.GroupJoinRequest, error) {
+	id, err := f.idg.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewGroupJoinRequest(
+		model.GroupJoinRequestID(id),
+		gID,
+		uID,
+		createdAt,
+		model.GroupJoinRequestState{},
+	)
+}
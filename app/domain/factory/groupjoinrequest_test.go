@@ -0,0 +1,71 @@
+package factory_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestGroupJoinRequestFactory_Create(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name    string
+		uID     model.UserID
+		idg     factory.IDGenerator
+		wantID  model.GroupJoinRequestID
+		wantErr error
+	}{
+		{
+			name:    "Returns a pending group join request",
+			uID:     "TEST_USER_ID",
+			idg:     factory.NewSequenceIDGenerator("TEST_GROUP_JOIN_REQUEST_ID_"),
+			wantID:  "TEST_GROUP_JOIN_REQUEST_ID_1",
+			wantErr: nil,
+		},
+		{
+			name:    "Error generating id",
+			uID:     "TEST_USER_ID",
+			idg:     factory.NewUUIDv4Generator(strings.NewReader("0")),
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:    "Error invalid group join request",
+			uID:     "",
+			idg:     factory.NewSequenceIDGenerator("TEST_GROUP_JOIN_REQUEST_ID_"),
+			wantErr: model.ErrInvalidGroupJoinRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := factory.NewGroupJoinRequestFactory(tt.idg)
+			got, err := f.Create("TEST_GROUP_ID", tt.uID, createdAt)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatalf("f.Create(TEST_GROUP_ID, %s)=_, nil; want _, %v", tt.uID, tt.wantErr)
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("f.Create(TEST_GROUP_ID, %s)=_, %v; want _, %v", tt.uID, err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("want no error, but has error %v", err)
+				}
+				if got.ID() != tt.wantID {
+					t.Errorf("got.ID()=%s; want %s", got.ID(), tt.wantID)
+				}
+				if got.State().Status != model.GroupJoinRequestStatusPending {
+					t.Errorf("got.State().Status=%s; want %s", got.State().Status, model.GroupJoinRequestStatusPending)
+				}
+				if !got.CreatedAt().Equal(createdAt) {
+					t.Errorf("got.CreatedAt()=%v; want %v", got.CreatedAt(), createdAt)
+				}
+			}
+		})
+	}
+}
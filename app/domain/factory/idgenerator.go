@@ -0,0 +1,134 @@
+//go:generate mockgen -source=$GOFILE -package=mock$GOPACKAGE -destination=../../mock/domain/$GOPACKAGE/$GOFILE
+package factory
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IDGenerator generates the IDs of the aggregates such as the users and the groups.
+type IDGenerator interface {
+	Generate() (string, error)
+}
+
+// IDStrategy names an IDGenerator the deployment can select.
+type IDStrategy string
+
+const (
+	IDStrategyUUIDv4 IDStrategy = "uuidv4"
+	IDStrategyUUIDv7 IDStrategy = "uuidv7"
+	IDStrategyULID   IDStrategy = "ulid"
+)
+
+var ErrUnknownIDStrategy = errors.New("unknown id strategy")
+
+// NewIDGenerator returns the generator of the strategy reading the system clock and crypto/rand.
+func NewIDGenerator(s IDStrategy) (IDGenerator, error) {
+	switch s {
+	case IDStrategyUUIDv4:
+		return NewUUIDv4Generator(rand.Reader), nil
+	case IDStrategyUUIDv7:
+		return NewUUIDv7Generator(rand.Reader), nil
+	case IDStrategyULID:
+		return NewULIDGenerator(time.Now, rand.Reader), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownIDStrategy, s)
+	}
+}
+
+type uuidv4Generator struct {
+	r io.Reader
+}
+
+// NewUUIDv4Generator returns a generator of random UUIDs reading the randomness from r.
+func NewUUIDv4Generator(r io.Reader) IDGenerator {
+	return &uuidv4Generator{r: r}
+}
+
+func (g *uuidv4Generator) Generate() (string, error) {
+	id, err := uuid.NewRandomFromReader(g.r)
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+type uuidv7Generator struct {
+	r io.Reader
+}
+
+// NewUUIDv7Generator returns a generator of time-ordered UUIDs reading the randomness from r.
+// The IDs sort by the time they are generated, which keeps the inserts into a clustered
+// primary key such as the one of InnoDB appending to the index.
+func NewUUIDv7Generator(r io.Reader) IDGenerator {
+	return &uuidv7Generator{r: r}
+}
+
+func (g *uuidv7Generator) Generate() (string, error) {
+	id, err := uuid.NewV7FromReader(g.r)
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// crockford is the Crockford's Base32 alphabet ULIDs are encoded in.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+type ulidGenerator struct {
+	now func() time.Time
+	r   io.Reader
+}
+
+// NewULIDGenerator returns a generator of ULIDs, which are 48 bits of the milliseconds
+// since the Unix epoch followed by 80 random bits read from r. The IDs sort by
+// the millisecond they are generated in.
+func NewULIDGenerator(now func() time.Time, r io.Reader) IDGenerator {
+	return &ulidGenerator{now: now, r: r}
+}
+
+func (g *ulidGenerator) Generate() (string, error) {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(g.now().UnixMilli())<<16)
+	if _, err := io.ReadFull(g.r, b[6:]); err != nil {
+		return "", err
+	}
+
+	// 128 bits are encoded to 26 characters of 5 bits with 2 leading zero bits.
+	hi := binary.BigEndian.Uint64(b[:8])
+	lo := binary.BigEndian.Uint64(b[8:])
+	var s [26]byte
+	for i := len(s) - 1; i >= 0; i-- {
+		s[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(s[:]), nil
+}
+
+type sequenceIDGenerator struct {
+	mu     sync.Mutex
+	prefix string
+	n      int
+}
+
+// NewSequenceIDGenerator returns a deterministic generator of the prefix followed by 1, 2, 3 and so on,
+// which is safe for concurrent use and meant for the tests.
+func NewSequenceIDGenerator(prefix string) IDGenerator {
+	return &sequenceIDGenerator{prefix: prefix}
+}
+
+func (g *sequenceIDGenerator) Generate() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.n++
+	return g.prefix + strconv.Itoa(g.n), nil
+}
@@ -0,0 +1,196 @@
+package factory_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+)
+
+var (
+	uuidv4Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	uuidv7Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	ulidPattern   = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+)
+
+func TestNewIDGenerator(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       factory.IDStrategy
+		pattern *regexp.Regexp
+		wantErr error
+	}{
+		{
+			name:    "Returns UUIDv4 generator",
+			s:       factory.IDStrategyUUIDv4,
+			pattern: uuidv4Pattern,
+		},
+		{
+			name:    "Returns UUIDv7 generator",
+			s:       factory.IDStrategyUUIDv7,
+			pattern: uuidv7Pattern,
+		},
+		{
+			name:    "Returns ULID generator",
+			s:       factory.IDStrategyULID,
+			pattern: ulidPattern,
+		},
+		{
+			name:    "Error unknown strategy",
+			s:       "snowflake",
+			wantErr: factory.ErrUnknownIDStrategy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := factory.NewIDGenerator(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewIDGenerator(%q)=_, %v; want _, %v", tt.s, err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			id, err := g.Generate()
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if !tt.pattern.MatchString(id) {
+				t.Errorf("g.Generate()=%q, nil; want an id matching %s", id, tt.pattern)
+			}
+		})
+	}
+}
+
+func TestUUIDv4Generator_Generate(t *testing.T) {
+	g := factory.NewUUIDv4Generator(strings.NewReader("abcdefgh12345678"))
+	got, err := g.Generate()
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	if want := "61626364-6566-4768-b132-333435363738"; got != want {
+		t.Errorf("g.Generate()=%q, nil; want %q, nil", got, want)
+	}
+
+	if _, err := g.Generate(); !errors.Is(err, io.EOF) {
+		t.Errorf("g.Generate()=_, %v; want _, %v", err, io.EOF)
+	}
+}
+
+func TestUUIDv7Generator_Generate(t *testing.T) {
+	g := factory.NewUUIDv7Generator(bytes.NewReader(make([]byte, 16*100)))
+	prev := ""
+	for i := 0; i < 100; i++ {
+		got, err := g.Generate()
+		if err != nil {
+			t.Fatalf("want no error, but has error %v", err)
+		}
+		if !uuidv7Pattern.MatchString(got) {
+			t.Fatalf("g.Generate()=%q, nil; want a UUIDv7", got)
+		}
+		if got <= prev {
+			t.Fatalf("g.Generate()=%q, nil; want an id after %q", got, prev)
+		}
+		prev = got
+	}
+}
+
+func TestULIDGenerator_Generate(t *testing.T) {
+	tests := []struct {
+		name    string
+		now     time.Time
+		r       io.Reader
+		want    string
+		wantErr error
+	}{
+		{
+			name: "Returns ULID of the epoch",
+			now:  time.UnixMilli(0),
+			r:    bytes.NewReader(make([]byte, 10)),
+			want: "00000000000000000000000000",
+		},
+		{
+			name: "Returns ULID of the time and the randomness",
+			now:  time.UnixMilli(1469918176385),
+			r:    bytes.NewReader(bytes.Repeat([]byte{0xff}, 10)),
+			want: "01ARYZ6S41ZZZZZZZZZZZZZZZZ",
+		},
+		{
+			name:    "Error reading randomness",
+			now:     time.UnixMilli(0),
+			r:       strings.NewReader("0"),
+			wantErr: io.ErrUnexpectedEOF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := factory.NewULIDGenerator(func() time.Time { return tt.now }, tt.r)
+			got, err := g.Generate()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("g.Generate()=_, %v; want _, %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("g.Generate()=%q, nil; want %q, nil", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestULIDGenerator_Generate_Ordered(t *testing.T) {
+	at := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := factory.NewULIDGenerator(func() time.Time {
+		at = at.Add(time.Millisecond)
+		return at
+	}, bytes.NewReader(bytes.Repeat([]byte{0xff, 0x00}, 500)))
+
+	prev := ""
+	for i := 0; i < 100; i++ {
+		got, err := g.Generate()
+		if err != nil {
+			t.Fatalf("want no error, but has error %v", err)
+		}
+		if got <= prev {
+			t.Fatalf("g.Generate()=%q, nil; want an id after %q", got, prev)
+		}
+		prev = got
+	}
+}
+
+func TestSequenceIDGenerator_Generate(t *testing.T) {
+	g := factory.NewSequenceIDGenerator("TEST_ID_")
+
+	const n = 100
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		ids = map[string]bool{}
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, err := g.Generate()
+			if err != nil {
+				t.Errorf("want no error, but has error %v", err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			ids[id] = true
+		}()
+	}
+	wg.Wait()
+
+	if len(ids) != n {
+		t.Fatalf("generated %d distinct ids; want %d", len(ids), n)
+	}
+	if !ids["TEST_ID_1"] || !ids["TEST_ID_100"] {
+		t.Errorf("generated %v; want TEST_ID_1 to TEST_ID_100", ids)
+	}
+}
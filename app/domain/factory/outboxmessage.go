@@ -0,0 +1,52 @@
+//go:generate mockgen -source=$GOFILE -package=mock$GOPACKAGE -destination=../../mock/domain/$GOPACKAGE/$GOFILE
+package factory
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+type OutboxMessageFactory interface {
+	// Create returns a message for each of the events, which occurred at the time.
+	Create(es []model.DomainEvent, occurredAt time.Time) (model.OutboxMessages, error)
+}
+
+type outboxMessageFactory struct {
+	idg IDGenerator
+}
+
+func NewOutboxMessageFactory(idg IDGenerator) OutboxMessageFactory {
+	return &outboxMessageFactory{idg: idg}
+}
+
+func (f outboxMessageFactory) Create(es []model.DomainEvent, occurredAt time.Time) (model.OutboxMessages, error) {
+	ms := make(model.OutboxMessages, len(es))
+	for i, e := range es {
+		id, err := f.idg.Generate()
+		if err != nil {
+			return nil, err
+		}
+
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return nil, err
+		}
+
+		m, err := model.NewOutboxMessage(
+			model.OutboxMessageID(id),
+			e.EventType(),
+			e.AggregateType(),
+			e.AggregateID(),
+			payload,
+			occurredAt,
+			model.OutboxDelivery{},
+		)
+		if err != nil {
+			return nil, err
+		}
+		ms[i] = m
+	}
+	return ms, nil
+}
@@ -0,0 +1,86 @@
+package factory_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestOutboxMessageFactory_Create(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name        string
+		es          []model.DomainEvent
+		idg         factory.IDGenerator
+		wantID      model.OutboxMessageID
+		wantPayload string
+		wantErr     error
+	}{
+		{
+			name: "Returns outbox messages",
+			es: []model.DomainEvent{
+				model.UserDeleted{UserID: "TEST_USER_ID"},
+			},
+			idg:         factory.NewSequenceIDGenerator("TEST_OUTBOX_MESSAGE_ID_"),
+			wantID:      "TEST_OUTBOX_MESSAGE_ID_1",
+			wantPayload: `{"userId":"TEST_USER_ID"}`,
+			wantErr:     nil,
+		},
+		{
+			name: "Error generating id",
+			es: []model.DomainEvent{
+				model.UserDeleted{UserID: "TEST_USER_ID"},
+			},
+			idg:     factory.NewUUIDv4Generator(strings.NewReader("0")),
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name: "Error invalid outbox message",
+			es: []model.DomainEvent{
+				model.UserDeleted{UserID: ""},
+			},
+			idg:     factory.NewSequenceIDGenerator("TEST_OUTBOX_MESSAGE_ID_"),
+			wantErr: model.ErrInvalidOutboxMessage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := factory.NewOutboxMessageFactory(tt.idg)
+			got, err := f.Create(tt.es, occurredAt)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatalf("f.Create(%v)=_, nil; want _, %v", tt.es, tt.wantErr)
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("f.Create(%v)=_, %v; want _, %v", tt.es, err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("want no error, but has error %v", err)
+				}
+				if len(got) != len(tt.es) {
+					t.Fatalf("len(f.Create(%v))=%d; want %d", tt.es, len(got), len(tt.es))
+				}
+				m := got[0]
+				if m.ID() != tt.wantID {
+					t.Errorf("m.ID()=%s; want %s", m.ID(), tt.wantID)
+				}
+				if m.EventType() != tt.es[0].EventType() || m.AggregateID() != tt.es[0].AggregateID() {
+					t.Errorf("f.Create(%v)=%v; want the message built from the event", tt.es, m)
+				}
+				if string(m.Payload()) != tt.wantPayload {
+					t.Errorf("m.Payload()=%s; want %s", m.Payload(), tt.wantPayload)
+				}
+				if !m.OccurredAt().Equal(occurredAt) {
+					t.Errorf("m.OccurredAt()=%v; want %v", m.OccurredAt(), occurredAt)
+				}
+			}
+		})
+	}
+}
@@ -2,8 +2,6 @@
 package factory
 
 import (
-	"github.com/google/uuid"
-
 	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
 )
 
@@ -11,22 +9,28 @@ type UserFactory interface {
 	Create(name, email string) (*model.User, error)
 }
 
-type userFactory struct{}
+type userFactory struct {
+	p   model.UserPolicy
+	idg IDGenerator
+}
 
-func NewUserFactory() UserFactory {
-	return &userFactory{}
+func NewUserFactory(p model.UserPolicy, idg IDGenerator) UserFactory {
+	return &userFactory{p: p, idg: idg}
 }
 
 func (uf userFactory) Create(name, email string) (*model.User, error) {
-	generated, err := uuid.NewRandom()
+	id, err := uf.idg.Generate()
 	if err != nil {
 		return nil, err
 	}
 
-	u, err := model.NewUser(model.UserID(generated.String()), name, email)
+	u, err := model.NewUser(model.UserID(id), name, email)
 	if err != nil {
 		return nil, err
 	}
+	if err := uf.p.ValidateName(u.Name()); err != nil {
+		return nil, err
+	}
 
 	return u, nil
 }
@@ -7,7 +7,6 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
-	"github.com/google/uuid"
 
 	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
 	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
@@ -21,7 +20,7 @@ func TestUserFactory_Create(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
-		setup   func()
+		idg     factory.IDGenerator
 		want    *model.User
 		wantErr error
 	}{
@@ -31,25 +30,21 @@ func TestUserFactory_Create(t *testing.T) {
 				name:  "TEST_GROUP_NAME",
 				email: "TEST_USER_EMAIL",
 			},
-			setup: func() {
-				uuid.SetRand(strings.NewReader("abcdefgh12345678"))
-			},
+			idg: factory.NewSequenceIDGenerator("TEST_USER_ID_"),
 			want: model.MustNewUser(
-				"61626364-6566-4768-b132-333435363738",
+				"TEST_USER_ID_1",
 				"TEST_GROUP_NAME",
 				"TEST_USER_EMAIL",
 			),
 			wantErr: nil,
 		},
 		{
-			name: "Error creating uuid",
+			name: "Error generating id",
 			args: args{
 				name:  "TEST_USER_NAME",
 				email: "TEST_USER_EMAIL",
 			},
-			setup: func() {
-				uuid.SetRand(strings.NewReader("0"))
-			},
+			idg:     factory.NewUUIDv4Generator(strings.NewReader("0")),
 			want:    nil,
 			wantErr: io.ErrUnexpectedEOF,
 		},
@@ -59,9 +54,7 @@ func TestUserFactory_Create(t *testing.T) {
 				name:  "TEST_USER_NAME_XXXXXXXXXXXXXXXXXXXXXXXXX",
 				email: "TEST_USER_EMAIL",
 			},
-			setup: func() {
-				uuid.SetRand(strings.NewReader("abcdefgh12345678"))
-			},
+			idg:     factory.NewSequenceIDGenerator("TEST_USER_ID_"),
 			want:    nil,
 			wantErr: model.ErrInvalidUser,
 		},
@@ -69,8 +62,7 @@ func TestUserFactory_Create(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.setup()
-			f := factory.NewUserFactory()
+			f := factory.NewUserFactory(model.DefaultPolicy().User, tt.idg)
 			got, err := f.Create(tt.args.name, tt.args.email)
 			if tt.wantErr != nil {
 				if err == nil {
@@ -0,0 +1,54 @@
+//go:generate mockgen -source=$GOFILE -package=mock$GOPACKAGE -destination=../../mock/domain/$GOPACKAGE/$GOFILE
+package factory
+
+import (
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+type WebhookDeliveryFactory interface {
+	// Create returns a delivery for every pair of a message and a subscription subscribing to its event type,
+	// which is available from the time the message occurred.
+	Create(ss model.WebhookSubscriptions, ms model.OutboxMessages) (model.WebhookDeliveries, error)
+}
+
+type webhookDeliveryFactory struct {
+	idg IDGenerator
+}
+
+func NewWebhookDeliveryFactory(idg IDGenerator) WebhookDeliveryFactory {
+	return &webhookDeliveryFactory{idg: idg}
+}
+
+func (f webhookDeliveryFactory) Create(
+	ss model.WebhookSubscriptions,
+	ms model.OutboxMessages,
+) (model.WebhookDeliveries, error) {
+	var ds model.WebhookDeliveries
+	for _, m := range ms {
+		for _, s := range ss {
+			if !s.Subscribes(m.EventType()) {
+				continue
+			}
+
+			id, err := f.idg.Generate()
+			if err != nil {
+				return nil, err
+			}
+
+			d, err := model.NewWebhookDelivery(
+				model.WebhookDeliveryID(id),
+				s.ID(),
+				m.ID(),
+				m.EventType(),
+				m.Payload(),
+				m.OccurredAt(),
+				model.WebhookDeliveryState{NextAttemptAt: m.OccurredAt()},
+			)
+			if err != nil {
+				return nil, err
+			}
+			ds = append(ds, d)
+		}
+	}
+	return ds, nil
+}
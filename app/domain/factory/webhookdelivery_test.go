@@ -0,0 +1,138 @@
+package factory_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestWebhookDeliveryFactory_Create(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	ss := model.WebhookSubscriptions{
+		model.MustNewWebhookSubscription(
+			"TEST_WEBHOOK_SUBSCRIPTION_ID_1",
+			"https://example.com/hooks/1",
+			[]model.DomainEventType{model.DomainEventTypeUserCreated, model.DomainEventTypeGroupDeleted},
+			"TEST_SECRET",
+		),
+		model.MustNewWebhookSubscription(
+			"TEST_WEBHOOK_SUBSCRIPTION_ID_2",
+			"https://example.com/hooks/2",
+			[]model.DomainEventType{model.DomainEventTypeGroupDeleted},
+			"TEST_SECRET",
+		),
+	}
+	ms := model.OutboxMessages{
+		model.MustNewOutboxMessage(
+			"TEST_EVENT_ID_1",
+			model.DomainEventTypeUserCreated,
+			model.AggregateTypeUser,
+			"TEST_USER_ID",
+			[]byte(`{"userId":"TEST_USER_ID"}`),
+			occurredAt,
+			model.OutboxDelivery{},
+		),
+		model.MustNewOutboxMessage(
+			"TEST_EVENT_ID_2",
+			model.DomainEventTypeGroupDeleted,
+			model.AggregateTypeGroup,
+			"TEST_GROUP_ID",
+			[]byte(`{"groupId":"TEST_GROUP_ID"}`),
+			occurredAt,
+			model.OutboxDelivery{},
+		),
+	}
+
+	type pair struct {
+		subscriptionID model.WebhookSubscriptionID
+		eventID        model.OutboxMessageID
+	}
+	tests := []struct {
+		name      string
+		ss        model.WebhookSubscriptions
+		ms        model.OutboxMessages
+		idg       factory.IDGenerator
+		wantPairs []pair
+		wantErr   error
+	}{
+		{
+			name: "Returns a delivery for every subscription subscribing to a message",
+			ss:   ss,
+			ms:   ms,
+			idg:  factory.NewSequenceIDGenerator("TEST_WEBHOOK_DELIVERY_ID_"),
+			wantPairs: []pair{
+				{"TEST_WEBHOOK_SUBSCRIPTION_ID_1", "TEST_EVENT_ID_1"},
+				{"TEST_WEBHOOK_SUBSCRIPTION_ID_1", "TEST_EVENT_ID_2"},
+				{"TEST_WEBHOOK_SUBSCRIPTION_ID_2", "TEST_EVENT_ID_2"},
+			},
+		},
+		{
+			name: "Returns no delivery without subscriptions",
+			ms:   ms,
+			idg:  factory.NewSequenceIDGenerator("TEST_WEBHOOK_DELIVERY_ID_"),
+		},
+		{
+			name:    "Error generating id",
+			ss:      ss,
+			ms:      ms,
+			idg:     factory.NewUUIDv4Generator(strings.NewReader("0")),
+			wantErr: io.ErrUnexpectedEOF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := factory.NewWebhookDeliveryFactory(tt.idg)
+			got, err := f.Create(tt.ss, tt.ms)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatalf("f.Create(%v, %v)=_, nil; want _, %v", tt.ss, tt.ms, tt.wantErr)
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("f.Create(%v, %v)=_, %v; want _, %v", tt.ss, tt.ms, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+
+			gotPairs := map[pair]bool{}
+			for _, d := range got {
+				gotPairs[pair{d.SubscriptionID(), d.EventID()}] = true
+				if d.ID() == "" {
+					t.Errorf("d.ID() is empty; want a generated id")
+				}
+				st := d.State()
+				if st.Status != model.WebhookDeliveryStatusPending || st.Attempts != 0 {
+					t.Errorf("d.State()=%v; want a pending delivery without attempts", st)
+				}
+				if !st.NextAttemptAt.Equal(occurredAt) {
+					t.Errorf("d.State().NextAttemptAt=%v; want the time the message occurred %v", st.NextAttemptAt, occurredAt)
+				}
+			}
+			if len(got) != len(tt.wantPairs) {
+				t.Fatalf("len(f.Create(%v, %v))=%d; want %d", tt.ss, tt.ms, len(got), len(tt.wantPairs))
+			}
+			for _, p := range tt.wantPairs {
+				if !gotPairs[p] {
+					t.Errorf("f.Create(%v, %v) has no delivery of %v", tt.ss, tt.ms, p)
+				}
+			}
+			for _, d := range got {
+				for _, m := range tt.ms {
+					if m.ID() == d.EventID() &&
+						(d.EventType() != m.EventType() || string(d.Payload()) != string(m.Payload()) ||
+							!d.OccurredAt().Equal(m.OccurredAt())) {
+						t.Errorf("d=%v; want the delivery of the message %v", d, m)
+					}
+				}
+			}
+		})
+	}
+}
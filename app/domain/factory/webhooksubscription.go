@@ -0,0 +1,36 @@
+//go:generate mockgen -source=$GOFILE -package=mock$GOPACKAGE -destination=../../mock/domain/$GOPACKAGE/$GOFILE
+package factory
+
+import (
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+type WebhookSubscriptionFactory interface {
+	Create(url string, eventTypes []model.DomainEventType, secret string) (*model.WebhookSubscription, error)
+}
+
+type webhookSubscriptionFactory struct {
+	idg IDGenerator
+}
+
+func NewWebhookSubscriptionFactory(idg IDGenerator) WebhookSubscriptionFactory {
+	return &webhookSubscriptionFactory{idg: idg}
+}
+
+func (f webhookSubscriptionFactory) Create(
+	url string,
+	eventTypes []model.DomainEventType,
+	secret string,
+) (*model.WebhookSubscription, error) {
+	id, err := f.idg.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := model.NewWebhookSubscription(model.WebhookSubscriptionID(id), url, eventTypes, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
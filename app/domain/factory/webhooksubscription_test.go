@@ -0,0 +1,92 @@
+package factory_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestWebhookSubscriptionFactory_Create(t *testing.T) {
+	type args struct {
+		url        string
+		eventTypes []model.DomainEventType
+		secret     string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		idg     factory.IDGenerator
+		want    *model.WebhookSubscription
+		wantErr error
+	}{
+		{
+			name: "Returns webhook subscription",
+			args: args{
+				url:        "https://example.com/hooks",
+				eventTypes: []model.DomainEventType{model.DomainEventTypeUserCreated},
+				secret:     "TEST_SECRET",
+			},
+			idg: factory.NewSequenceIDGenerator("TEST_WEBHOOK_SUBSCRIPTION_ID_"),
+			want: model.MustNewWebhookSubscription(
+				"TEST_WEBHOOK_SUBSCRIPTION_ID_1",
+				"https://example.com/hooks",
+				[]model.DomainEventType{model.DomainEventTypeUserCreated},
+				"TEST_SECRET",
+			),
+			wantErr: nil,
+		},
+		{
+			name: "Error generating id",
+			args: args{
+				url:        "https://example.com/hooks",
+				eventTypes: []model.DomainEventType{model.DomainEventTypeUserCreated},
+				secret:     "TEST_SECRET",
+			},
+			idg:     factory.NewUUIDv4Generator(strings.NewReader("0")),
+			want:    nil,
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name: "Error invalid webhook subscription input",
+			args: args{
+				url:        "example.com/hooks",
+				eventTypes: []model.DomainEventType{model.DomainEventTypeUserCreated},
+				secret:     "TEST_SECRET",
+			},
+			idg:     factory.NewSequenceIDGenerator("TEST_WEBHOOK_SUBSCRIPTION_ID_"),
+			want:    nil,
+			wantErr: model.ErrInvalidWebhookSubscription,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := factory.NewWebhookSubscriptionFactory(tt.idg)
+			got, err := f.Create(tt.args.url, tt.args.eventTypes, tt.args.secret)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatalf("f.Create(%v)=_, nil; want _, %v", tt.args, tt.wantErr)
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("f.Create(%v)=_, %v; want _, %v", tt.args, err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("want no error, but has error %v", err)
+				}
+				if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(model.WebhookSubscription{})); diff != "" {
+					t.Errorf(
+						"f.Create(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+						tt.args, got, tt.want, diff,
+					)
+				}
+			}
+		})
+	}
+}
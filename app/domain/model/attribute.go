@@ -0,0 +1,319 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var (
+	ErrInvalidAttributeSchema = errors.New("invalid attribute schema")
+	ErrInvalidAttributes      = errors.New("invalid attributes")
+)
+
+// MaxAttributeKeyLength is the limit of the key of an attribute.
+const MaxAttributeKeyLength = 64
+
+// attributeKeyPattern is the form of the key of an attribute, which is safe to use in a JSON path as it is.
+var attributeKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// IsValidAttributeKey reports whether the key may name an attribute.
+func IsValidAttributeKey(key string) bool {
+	return len(key) <= MaxAttributeKeyLength && attributeKeyPattern.MatchString(key)
+}
+
+// AttributeTarget is the kind of the resource whose custom attributes a schema defines.
+type AttributeTarget string
+
+const (
+	AttributeTargetUser  AttributeTarget = "USER"
+	AttributeTargetGroup AttributeTarget = "GROUP"
+)
+
+func (t AttributeTarget) IsValid() bool {
+	return t == AttributeTargetUser || t == AttributeTargetGroup
+}
+
+// AttributeType is the type of the value of an attribute as decoded from JSON.
+type AttributeType string
+
+const (
+	AttributeTypeString  AttributeType = "STRING"
+	AttributeTypeNumber  AttributeType = "NUMBER"
+	AttributeTypeBoolean AttributeType = "BOOLEAN"
+)
+
+func (t AttributeType) IsValid() bool {
+	switch t {
+	case AttributeTypeString, AttributeTypeNumber, AttributeTypeBoolean:
+		return true
+	}
+	return false
+}
+
+// AttributeSchema is a custom attribute of the users or the groups which an admin defines.
+// The value of the attribute must be of the type and, if given, one of the enum and match the pattern.
+type AttributeSchema struct {
+	target   AttributeTarget
+	key      string
+	typ      AttributeType
+	required bool
+	enum     []string
+	pattern  *regexp.Regexp
+}
+
+// NewAttributeSchema returns the schema of the attribute. The enum is of the values in their string form, which
+// a string or a number attribute may have, and the pattern is a regular expression only a string attribute may have.
+func NewAttributeSchema(
+	target AttributeTarget,
+	key string,
+	typ AttributeType,
+	required bool,
+	enum []string,
+	pattern string,
+) (*AttributeSchema, error) {
+	if !target.IsValid() {
+		return nil, fmt.Errorf("unknown attribute target %q: %w", target, ErrInvalidAttributeSchema)
+	}
+	if !IsValidAttributeKey(key) {
+		return nil, fmt.Errorf("attribute key must be alphanumeric and at most %d characters: %w", MaxAttributeKeyLength, ErrInvalidAttributeSchema)
+	}
+	if !typ.IsValid() {
+		return nil, fmt.Errorf("unknown attribute type %q: %w", typ, ErrInvalidAttributeSchema)
+	}
+
+	if len(enum) > 0 {
+		switch typ {
+		case AttributeTypeString:
+		case AttributeTypeNumber:
+			for _, v := range enum {
+				if _, err := strconv.ParseFloat(v, 64); err != nil {
+					return nil, fmt.Errorf("enum value %q must be a number: %w", v, ErrInvalidAttributeSchema)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("a %s attribute cannot have an enum: %w", typ, ErrInvalidAttributeSchema)
+		}
+	}
+
+	var re *regexp.Regexp
+	if pattern != "" {
+		if typ != AttributeTypeString {
+			return nil, fmt.Errorf("a %s attribute cannot have a pattern: %w", typ, ErrInvalidAttributeSchema)
+		}
+		var err error
+		if re, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid attribute pattern: %v: %w", err, ErrInvalidAttributeSchema)
+		}
+	}
+
+	return &AttributeSchema{
+		target:   target,
+		key:      key,
+		typ:      typ,
+		required: required,
+		enum:     enum,
+		pattern:  re,
+	}, nil
+}
+
+func MustNewAttributeSchema(
+	target AttributeTarget,
+	key string,
+	typ AttributeType,
+	required bool,
+	enum []string,
+	pattern string,
+) *AttributeSchema {
+	s, err := NewAttributeSchema(target, key, typ, required, enum, pattern)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (s *AttributeSchema) Target() AttributeTarget {
+	if s == nil {
+		return ""
+	}
+	return s.target
+}
+
+func (s *AttributeSchema) Key() string {
+	if s == nil {
+		return ""
+	}
+	return s.key
+}
+
+func (s *AttributeSchema) Type() AttributeType {
+	if s == nil {
+		return ""
+	}
+	return s.typ
+}
+
+func (s *AttributeSchema) Required() bool {
+	if s == nil {
+		return false
+	}
+	return s.required
+}
+
+func (s *AttributeSchema) Enum() []string {
+	if s == nil {
+		return nil
+	}
+	return s.enum
+}
+
+func (s *AttributeSchema) Pattern() string {
+	if s == nil || s.pattern == nil {
+		return ""
+	}
+	return s.pattern.String()
+}
+
+// validate reports an error if the value is not allowed by the schema.
+func (s *AttributeSchema) validate(v any) error {
+	switch s.typ {
+	case AttributeTypeString:
+		str, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("attribute %q must be a string: %w", s.key, ErrInvalidAttributes)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(str) {
+			return fmt.Errorf("attribute %q must match %s: %w", s.key, s.pattern, ErrInvalidAttributes)
+		}
+	case AttributeTypeNumber:
+		switch v.(type) {
+		case float64, int:
+		default:
+			return fmt.Errorf("attribute %q must be a number: %w", s.key, ErrInvalidAttributes)
+		}
+	case AttributeTypeBoolean:
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("attribute %q must be a boolean: %w", s.key, ErrInvalidAttributes)
+		}
+	}
+
+	if len(s.enum) > 0 && !s.inEnum(v) {
+		return fmt.Errorf("attribute %q must be one of %v: %w", s.key, s.enum, ErrInvalidAttributes)
+	}
+	return nil
+}
+
+func (s *AttributeSchema) inEnum(v any) bool {
+	for _, e := range s.enum {
+		if s.typ == AttributeTypeNumber {
+			f, _ := strconv.ParseFloat(e, 64)
+			if FormatAttributeValue(f) == FormatAttributeValue(v) {
+				return true
+			}
+			continue
+		}
+		if e == FormatAttributeValue(v) {
+			return true
+		}
+	}
+	return false
+}
+
+type AttributeSchemas []*AttributeSchema
+
+// Find returns the schema of the attribute of the key, or nil if there is none.
+func (ss AttributeSchemas) Find(key string) *AttributeSchema {
+	for _, s := range ss {
+		if s.Key() == key {
+			return s
+		}
+	}
+	return nil
+}
+
+// Validate reports an error if the attributes have a key no schema defines, lack a required one or have a value
+// the schema does not allow. The schemas are of the target of the attributes.
+func (ss AttributeSchemas) Validate(attrs Attributes) error {
+	for _, k := range attrs.Keys() {
+		s := ss.Find(k)
+		if s == nil {
+			return fmt.Errorf("unknown attribute %q: %w", k, ErrInvalidAttributes)
+		}
+		if err := s.validate(attrs[k]); err != nil {
+			return err
+		}
+	}
+	for _, s := range ss {
+		if _, ok := attrs[s.key]; s.required && !ok {
+			return fmt.Errorf("attribute %q is required: %w", s.key, ErrInvalidAttributes)
+		}
+	}
+	return nil
+}
+
+// Attributes are the values of the custom attributes of a user or a group by their keys.
+// A value is a string, a float64 or a bool as decoded from JSON.
+type Attributes map[string]any
+
+// Keys returns the keys of the attributes in order.
+func (a Attributes) Keys() []string {
+	keys := make([]string, 0, len(a))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Matches reports whether the attributes have all the values, which are compared in their string form.
+func (a Attributes) Matches(values map[string]string) bool {
+	for k, want := range values {
+		v, ok := a[k]
+		if !ok || FormatAttributeValue(v) != want {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the attributes as a JSON object, or empty if there are none.
+func (a Attributes) String() string {
+	if len(a) == 0 {
+		return ""
+	}
+	b, err := json.Marshal(map[string]any(a))
+	if err != nil {
+		return fmt.Sprint(map[string]any(a))
+	}
+	return string(b)
+}
+
+// clone copies the attributes, returning nil if there are none.
+func (a Attributes) clone() Attributes {
+	if len(a) == 0 {
+		return nil
+	}
+	c := make(Attributes, len(a))
+	for k, v := range a {
+		c[k] = v
+	}
+	return c
+}
+
+// FormatAttributeValue returns the string form of the value of an attribute, in which it is filtered by.
+func FormatAttributeValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,183 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestNewAttributeSchema(t *testing.T) {
+	type args struct {
+		target   model.AttributeTarget
+		key      string
+		typ      model.AttributeType
+		required bool
+		enum     []string
+		pattern  string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "Returns string attribute schema",
+			args: args{
+				target:   model.AttributeTargetUser,
+				key:      "department",
+				typ:      model.AttributeTypeString,
+				required: true,
+				enum:     []string{"sales", "engineering"},
+				pattern:  "^[a-z]+$",
+			},
+		},
+		{
+			name: "Returns number attribute schema",
+			args: args{target: model.AttributeTargetGroup, key: "cost_center", typ: model.AttributeTypeNumber, enum: []string{"100", "200.5"}},
+		},
+		{
+			name:    "Error unknown target",
+			args:    args{target: "TEAM", key: "department", typ: model.AttributeTypeString},
+			wantErr: model.ErrInvalidAttributeSchema,
+		},
+		{
+			name:    "Error invalid key",
+			args:    args{target: model.AttributeTargetUser, key: "slack.handle", typ: model.AttributeTypeString},
+			wantErr: model.ErrInvalidAttributeSchema,
+		},
+		{
+			name:    "Error unknown type",
+			args:    args{target: model.AttributeTargetUser, key: "department", typ: "DATE"},
+			wantErr: model.ErrInvalidAttributeSchema,
+		},
+		{
+			name:    "Error enum of a number attribute which is not a number",
+			args:    args{target: model.AttributeTargetUser, key: "level", typ: model.AttributeTypeNumber, enum: []string{"high"}},
+			wantErr: model.ErrInvalidAttributeSchema,
+		},
+		{
+			name:    "Error enum of a boolean attribute",
+			args:    args{target: model.AttributeTargetUser, key: "remote", typ: model.AttributeTypeBoolean, enum: []string{"true"}},
+			wantErr: model.ErrInvalidAttributeSchema,
+		},
+		{
+			name:    "Error pattern of a number attribute",
+			args:    args{target: model.AttributeTargetUser, key: "level", typ: model.AttributeTypeNumber, pattern: "^[0-9]$"},
+			wantErr: model.ErrInvalidAttributeSchema,
+		},
+		{
+			name:    "Error invalid pattern",
+			args:    args{target: model.AttributeTargetUser, key: "slack", typ: model.AttributeTypeString, pattern: "^[a-z"},
+			wantErr: model.ErrInvalidAttributeSchema,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := model.NewAttributeSchema(tt.args.target, tt.args.key, tt.args.typ, tt.args.required, tt.args.enum, tt.args.pattern)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("model.NewAttributeSchema(%v)=_, %v; want _, %v", tt.args, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if got.Key() != tt.args.key || got.Type() != tt.args.typ || got.Pattern() != tt.args.pattern {
+				t.Errorf("model.NewAttributeSchema(%v)=%v, nil; want the schema of the args", tt.args, got)
+			}
+		})
+	}
+}
+
+func TestAttributeSchemas_Validate(t *testing.T) {
+	ss := model.AttributeSchemas{
+		model.MustNewAttributeSchema(model.AttributeTargetUser, "department", model.AttributeTypeString, true, []string{"sales", "engineering"}, ""),
+		model.MustNewAttributeSchema(model.AttributeTargetUser, "slack", model.AttributeTypeString, false, nil, "^@[a-z]+$"),
+		model.MustNewAttributeSchema(model.AttributeTargetUser, "level", model.AttributeTypeNumber, false, []string{"1", "2"}, ""),
+		model.MustNewAttributeSchema(model.AttributeTargetUser, "remote", model.AttributeTypeBoolean, false, nil, ""),
+	}
+
+	tests := []struct {
+		name    string
+		attrs   model.Attributes
+		wantErr error
+	}{
+		{
+			name:  "Accepts the attributes",
+			attrs: model.Attributes{"department": "sales", "slack": "@alice", "level": 2.0, "remote": true},
+		},
+		{
+			name:  "Accepts the attributes without the optional ones",
+			attrs: model.Attributes{"department": "engineering"},
+		},
+		{
+			name:    "Error lacking the required attribute",
+			attrs:   model.Attributes{"slack": "@alice"},
+			wantErr: model.ErrInvalidAttributes,
+		},
+		{
+			name:    "Error unknown attribute",
+			attrs:   model.Attributes{"department": "sales", "title": "manager"},
+			wantErr: model.ErrInvalidAttributes,
+		},
+		{
+			name:    "Error value of another type",
+			attrs:   model.Attributes{"department": "sales", "remote": "yes"},
+			wantErr: model.ErrInvalidAttributes,
+		},
+		{
+			name:    "Error null value",
+			attrs:   model.Attributes{"department": nil},
+			wantErr: model.ErrInvalidAttributes,
+		},
+		{
+			name:    "Error value not in the enum",
+			attrs:   model.Attributes{"department": "legal"},
+			wantErr: model.ErrInvalidAttributes,
+		},
+		{
+			name:    "Error number not in the enum",
+			attrs:   model.Attributes{"department": "sales", "level": 3.0},
+			wantErr: model.ErrInvalidAttributes,
+		},
+		{
+			name:    "Error value not matching the pattern",
+			attrs:   model.Attributes{"department": "sales", "slack": "alice"},
+			wantErr: model.ErrInvalidAttributes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ss.Validate(tt.attrs); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ss.Validate(%v)=%v; want %v", tt.attrs, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAttributes_Matches(t *testing.T) {
+	attrs := model.Attributes{"department": "sales", "level": 2.0, "remote": true}
+
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   bool
+	}{
+		{name: "Matches no values", values: nil, want: true},
+		{name: "Matches the values in their string form", values: map[string]string{"department": "sales", "level": "2", "remote": "true"}, want: true},
+		{name: "Does not match another value", values: map[string]string{"department": "engineering"}, want: false},
+		{name: "Does not match a missing attribute", values: map[string]string{"slack": "@alice"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := attrs.Matches(tt.values); got != tt.want {
+				t.Errorf("attrs.Matches(%v)=%t; want %t", tt.values, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidAuditEvent = errors.New("invalid audit event")
+)
+
+type AuditEventID string
+
+type AuditAction string
+
+const (
+	AuditActionCreateUser  AuditAction = "CREATE_USER"
+	AuditActionUpdateUser  AuditAction = "UPDATE_USER"
+	AuditActionDeleteUser  AuditAction = "DELETE_USER"
+	AuditActionCreateGroup AuditAction = "CREATE_GROUP"
+	AuditActionUpdateGroup AuditAction = "UPDATE_GROUP"
+	AuditActionDeleteGroup AuditAction = "DELETE_GROUP"
+)
+
+type AuditTargetType string
+
+const (
+	AuditTargetTypeUser  AuditTargetType = "USER"
+	AuditTargetTypeGroup AuditTargetType = "GROUP"
+)
+
+func (t AuditTargetType) IsValid() bool {
+	switch t {
+	case AuditTargetTypeUser, AuditTargetTypeGroup:
+		return true
+	}
+	return false
+}
+
+// AuditTarget identifies the resource changed by an audited action.
+type AuditTarget struct {
+	Type AuditTargetType
+	ID   string
+}
+
+func NewUserAuditTarget(uID UserID) AuditTarget {
+	return AuditTarget{Type: AuditTargetTypeUser, ID: string(uID)}
+}
+
+func NewGroupAuditTarget(gID GroupID) AuditTarget {
+	return AuditTarget{Type: AuditTargetTypeGroup, ID: string(gID)}
+}
+
+// AuditChange is the before and after value of a field changed by an audited action.
+// Before is empty on creation and After is empty on deletion.
+type AuditChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+type AuditEvent struct {
+	id         AuditEventID
+	actor      string
+	action     AuditAction
+	target     AuditTarget
+	changes    []AuditChange
+	requestID  string
+	occurredAt time.Time
+}
+
+func NewAuditEvent(
+	id AuditEventID,
+	actor string,
+	action AuditAction,
+	target AuditTarget,
+	changes []AuditChange,
+	requestID string,
+	occurredAt time.Time,
+) (*AuditEvent, error) {
+	if id == "" {
+		return nil, fmt.Errorf("audit event id must not empty: %w", ErrInvalidAuditEvent)
+	}
+
+	if action == "" {
+		return nil, fmt.Errorf("audit event action must not empty: %w", ErrInvalidAuditEvent)
+	}
+
+	if !target.Type.IsValid() {
+		return nil, fmt.Errorf("invalid audit target type %q: %w", target.Type, ErrInvalidAuditEvent)
+	}
+	if target.ID == "" {
+		return nil, fmt.Errorf("audit target id must not empty: %w", ErrInvalidAuditEvent)
+	}
+
+	if occurredAt.IsZero() {
+		return nil, fmt.Errorf("audit event occurred at must not zero: %w", ErrInvalidAuditEvent)
+	}
+
+	return &AuditEvent{
+		id:         id,
+		actor:      actor,
+		action:     action,
+		target:     target,
+		changes:    changes,
+		requestID:  requestID,
+		occurredAt: occurredAt,
+	}, nil
+}
+
+func MustNewAuditEvent(
+	id AuditEventID,
+	actor string,
+	action AuditAction,
+	target AuditTarget,
+	changes []AuditChange,
+	requestID string,
+	occurredAt time.Time,
+) *AuditEvent {
+	e, err := NewAuditEvent(id, actor, action, target, changes, requestID, occurredAt)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+func (e *AuditEvent) ID() AuditEventID {
+	if e == nil {
+		return ""
+	}
+	return e.id
+}
+
+func (e *AuditEvent) Actor() string {
+	if e == nil {
+		return ""
+	}
+	return e.actor
+}
+
+func (e *AuditEvent) Action() AuditAction {
+	if e == nil {
+		return ""
+	}
+	return e.action
+}
+
+func (e *AuditEvent) Target() AuditTarget {
+	if e == nil {
+		return AuditTarget{}
+	}
+	return e.target
+}
+
+func (e *AuditEvent) Changes() []AuditChange {
+	if e == nil {
+		return nil
+	}
+	return e.changes
+}
+
+func (e *AuditEvent) RequestID() string {
+	if e == nil {
+		return ""
+	}
+	return e.requestID
+}
+
+func (e *AuditEvent) OccurredAt() time.Time {
+	if e == nil {
+		return time.Time{}
+	}
+	return e.occurredAt
+}
+
+type AuditEvents []*AuditEvent
+
+// DiffUser returns the fields that differ between before and after.
+// A nil before describes a creation and a nil after describes a deletion.
+func DiffUser(before, after *User) []AuditChange {
+	var changes []AuditChange
+	changes = appendChange(changes, "name", before.Name(), after.Name())
+	changes = appendChange(changes, "email", before.Email(), after.Email())
+	changes = appendChange(changes, "status", string(before.Status()), string(after.Status()))
+	changes = appendChange(changes, "attributes", before.Attributes().String(), after.Attributes().String())
+	return changes
+}
+
+// DiffGroup returns the fields that differ between before and after.
+// A nil before describes a creation and a nil after describes a deletion.
+func DiffGroup(before, after *Group) []AuditChange {
+	var changes []AuditChange
+	changes = appendChange(changes, "name", before.Name(), after.Name())
+	changes = appendChange(changes, "userIds", joinUserIDs(before.UserIDs()), joinUserIDs(after.UserIDs()))
+	changes = appendChange(changes, "joinPolicy", string(before.JoinPolicy()), string(after.JoinPolicy()))
+	changes = appendChange(changes, "attributes", before.Attributes().String(), after.Attributes().String())
+	changes = appendChange(changes, "labels", before.Labels().String(), after.Labels().String())
+	return changes
+}
+
+func appendChange(changes []AuditChange, field, before, after string) []AuditChange {
+	if before == after {
+		return changes
+	}
+	return append(changes, AuditChange{Field: field, Before: before, After: after})
+}
+
+func joinUserIDs(uIDs []UserID) string {
+	ss := make([]string, len(uIDs))
+	for i, uID := range uIDs {
+		ss[i] = string(uID)
+	}
+	return strings.Join(ss, ",")
+}
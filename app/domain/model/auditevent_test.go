@@ -0,0 +1,301 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestNewAuditEvent(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	type args struct {
+		id         model.AuditEventID
+		actor      string
+		action     model.AuditAction
+		target     model.AuditTarget
+		changes    []model.AuditChange
+		requestID  string
+		occurredAt time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *model.AuditEvent
+		wantErr error
+	}{
+		{
+			name: "Returns audit event",
+			args: args{
+				id:         "TEST_AUDIT_EVENT_ID",
+				actor:      "TEST_ACTOR",
+				action:     model.AuditActionCreateUser,
+				target:     model.NewUserAuditTarget("TEST_USER_ID"),
+				changes:    []model.AuditChange{{Field: "name", After: "TEST_USER_NAME"}},
+				requestID:  "TEST_REQUEST_ID",
+				occurredAt: occurredAt,
+			},
+			want: model.MustNewAuditEvent(
+				"TEST_AUDIT_EVENT_ID",
+				"TEST_ACTOR",
+				model.AuditActionCreateUser,
+				model.NewUserAuditTarget("TEST_USER_ID"),
+				[]model.AuditChange{{Field: "name", After: "TEST_USER_NAME"}},
+				"TEST_REQUEST_ID",
+				occurredAt,
+			),
+			wantErr: nil,
+		},
+		{
+			name: "Returns audit event without actor",
+			args: args{
+				id:         "TEST_AUDIT_EVENT_ID",
+				action:     model.AuditActionDeleteGroup,
+				target:     model.NewGroupAuditTarget("TEST_GROUP_ID"),
+				occurredAt: occurredAt,
+			},
+			want: model.MustNewAuditEvent(
+				"TEST_AUDIT_EVENT_ID",
+				"",
+				model.AuditActionDeleteGroup,
+				model.NewGroupAuditTarget("TEST_GROUP_ID"),
+				nil,
+				"",
+				occurredAt,
+			),
+			wantErr: nil,
+		},
+		{
+			name: "Error empty audit event id",
+			args: args{
+				id:         "",
+				action:     model.AuditActionCreateUser,
+				target:     model.NewUserAuditTarget("TEST_USER_ID"),
+				occurredAt: occurredAt,
+			},
+			want:    nil,
+			wantErr: model.ErrInvalidAuditEvent,
+		},
+		{
+			name: "Error empty action",
+			args: args{
+				id:         "TEST_AUDIT_EVENT_ID",
+				action:     "",
+				target:     model.NewUserAuditTarget("TEST_USER_ID"),
+				occurredAt: occurredAt,
+			},
+			want:    nil,
+			wantErr: model.ErrInvalidAuditEvent,
+		},
+		{
+			name: "Error invalid target type",
+			args: args{
+				id:         "TEST_AUDIT_EVENT_ID",
+				action:     model.AuditActionCreateUser,
+				target:     model.AuditTarget{Type: "UNKNOWN", ID: "TEST_USER_ID"},
+				occurredAt: occurredAt,
+			},
+			want:    nil,
+			wantErr: model.ErrInvalidAuditEvent,
+		},
+		{
+			name: "Error empty target id",
+			args: args{
+				id:         "TEST_AUDIT_EVENT_ID",
+				action:     model.AuditActionCreateUser,
+				target:     model.NewUserAuditTarget(""),
+				occurredAt: occurredAt,
+			},
+			want:    nil,
+			wantErr: model.ErrInvalidAuditEvent,
+		},
+		{
+			name: "Error zero occurred at",
+			args: args{
+				id:     "TEST_AUDIT_EVENT_ID",
+				action: model.AuditActionCreateUser,
+				target: model.NewUserAuditTarget("TEST_USER_ID"),
+			},
+			want:    nil,
+			wantErr: model.ErrInvalidAuditEvent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := model.NewAuditEvent(
+				tt.args.id,
+				tt.args.actor,
+				tt.args.action,
+				tt.args.target,
+				tt.args.changes,
+				tt.args.requestID,
+				tt.args.occurredAt,
+			)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("want an error, but has no error")
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("model.NewAuditEvent(%v)=_, %v; want _, %v", tt.args, err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("want no error, but has error %v", err)
+				}
+				if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(model.AuditEvent{})); diff != "" {
+					t.Errorf(
+						"model.NewAuditEvent(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+						tt.args, got, tt.want, diff,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestDiffUser(t *testing.T) {
+	type args struct {
+		before *model.User
+		after  *model.User
+	}
+	tests := []struct {
+		name string
+		args args
+		want []model.AuditChange
+	}{
+		{
+			name: "Returns all fields on creation",
+			args: args{
+				before: nil,
+				after:  model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"),
+			},
+			want: []model.AuditChange{
+				{Field: "name", Before: "", After: "TEST_USER_NAME"},
+				{Field: "email", Before: "", After: "TEST_USER_EMAIL"},
+				{Field: "status", Before: "", After: "ACTIVE"},
+			},
+		},
+		{
+			name: "Returns changed fields only on update",
+			args: args{
+				before: model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"),
+				after:  model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL_UPDATED"),
+			},
+			want: []model.AuditChange{
+				{Field: "email", Before: "TEST_USER_EMAIL", After: "TEST_USER_EMAIL_UPDATED"},
+			},
+		},
+		{
+			name: "Returns the attributes as JSON on update",
+			args: args{
+				before: model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"),
+				after: func() *model.User {
+					u := model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL")
+					u.ChangeAttributes(model.Attributes{"level": 2.0, "department": "sales"})
+					return u
+				}(),
+			},
+			want: []model.AuditChange{
+				{Field: "attributes", Before: "", After: `{"department":"sales","level":2}`},
+			},
+		},
+		{
+			name: "Returns all fields on deletion",
+			args: args{
+				before: model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"),
+				after:  nil,
+			},
+			want: []model.AuditChange{
+				{Field: "name", Before: "TEST_USER_NAME", After: ""},
+				{Field: "email", Before: "TEST_USER_EMAIL", After: ""},
+				{Field: "status", Before: "ACTIVE", After: ""},
+			},
+		},
+		{
+			name: "Returns nil if nothing changed",
+			args: args{
+				before: model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"),
+				after:  model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"),
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := model.DiffUser(tt.args.before, tt.args.after)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(
+					"model.DiffUser(%v, %v)=%v; want %v\ndiffers: (-got +want)\n%s",
+					tt.args.before, tt.args.after, got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
+
+func TestDiffGroup(t *testing.T) {
+	type args struct {
+		before *model.Group
+		after  *model.Group
+	}
+	tests := []struct {
+		name string
+		args args
+		want []model.AuditChange
+	}{
+		{
+			name: "Returns all fields on creation",
+			args: args{
+				before: nil,
+				after: model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+				),
+			},
+			want: []model.AuditChange{
+				{Field: "name", Before: "", After: "TEST_GROUP_NAME"},
+				{Field: "userIds", Before: "", After: "TEST_USER_ID_1,TEST_USER_ID_2"},
+				{Field: "joinPolicy", Before: "", After: "OPEN"},
+			},
+		},
+		{
+			name: "Returns changed fields only on update",
+			args: args{
+				before: model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", []model.UserID{"TEST_USER_ID_1"}),
+				after:  model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME_UPDATED", []model.UserID{"TEST_USER_ID_1"}),
+			},
+			want: []model.AuditChange{
+				{Field: "name", Before: "TEST_GROUP_NAME", After: "TEST_GROUP_NAME_UPDATED"},
+			},
+		},
+		{
+			name: "Returns all fields on deletion",
+			args: args{
+				before: model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", []model.UserID{}),
+				after:  nil,
+			},
+			want: []model.AuditChange{
+				{Field: "name", Before: "TEST_GROUP_NAME", After: ""},
+				{Field: "joinPolicy", Before: "OPEN", After: ""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := model.DiffGroup(tt.args.before, tt.args.after)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(
+					"model.DiffGroup(%v, %v)=%v; want %v\ndiffers: (-got +want)\n%s",
+					tt.args.before, tt.args.after, got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package model
+
+type DomainEventType string
+
+const (
+	DomainEventTypeUserCreated            DomainEventType = "UserCreated"
+	DomainEventTypeUserUpdated            DomainEventType = "UserUpdated"
+	DomainEventTypeUserDeleted            DomainEventType = "UserDeleted"
+	DomainEventTypeUserStatusChanged      DomainEventType = "UserStatusChanged"
+	DomainEventTypeGroupCreated           DomainEventType = "GroupCreated"
+	DomainEventTypeGroupUpdated           DomainEventType = "GroupUpdated"
+	DomainEventTypeGroupMembershipChanged DomainEventType = "GroupMembershipChanged"
+	DomainEventTypeGroupWaitlistPromoted  DomainEventType = "GroupWaitlistPromoted"
+	DomainEventTypeGroupDeleted           DomainEventType = "GroupDeleted"
+)
+
+func (t DomainEventType) IsValid() bool {
+	switch t {
+	case DomainEventTypeUserCreated,
+		DomainEventTypeUserUpdated,
+		DomainEventTypeUserDeleted,
+		DomainEventTypeUserStatusChanged,
+		DomainEventTypeGroupCreated,
+		DomainEventTypeGroupUpdated,
+		DomainEventTypeGroupMembershipChanged,
+		DomainEventTypeGroupWaitlistPromoted,
+		DomainEventTypeGroupDeleted:
+		return true
+	}
+	return false
+}
+
+type AggregateType string
+
+const (
+	AggregateTypeUser  AggregateType = "USER"
+	AggregateTypeGroup AggregateType = "GROUP"
+)
+
+// DomainEvent is something that happened to an aggregate which other parts of the system may react to.
+type DomainEvent interface {
+	EventType() DomainEventType
+	AggregateType() AggregateType
+	AggregateID() string
+}
+
+type UserCreated struct {
+	UserID UserID `json:"userId"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+}
+
+func (e UserCreated) EventType() DomainEventType   { return DomainEventTypeUserCreated }
+func (e UserCreated) AggregateType() AggregateType { return AggregateTypeUser }
+func (e UserCreated) AggregateID() string          { return string(e.UserID) }
+
+type UserUpdated struct {
+	UserID UserID `json:"userId"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+}
+
+func (e UserUpdated) EventType() DomainEventType   { return DomainEventTypeUserUpdated }
+func (e UserUpdated) AggregateType() AggregateType { return AggregateTypeUser }
+func (e UserUpdated) AggregateID() string          { return string(e.UserID) }
+
+type UserDeleted struct {
+	UserID UserID `json:"userId"`
+}
+
+func (e UserDeleted) EventType() DomainEventType   { return DomainEventTypeUserDeleted }
+func (e UserDeleted) AggregateType() AggregateType { return AggregateTypeUser }
+func (e UserDeleted) AggregateID() string          { return string(e.UserID) }
+
+// UserStatusChanged is recorded when the user moves to another status in its lifecycle.
+type UserStatusChanged struct {
+	UserID UserID     `json:"userId"`
+	Status UserStatus `json:"status"`
+}
+
+func (e UserStatusChanged) EventType() DomainEventType   { return DomainEventTypeUserStatusChanged }
+func (e UserStatusChanged) AggregateType() AggregateType { return AggregateTypeUser }
+func (e UserStatusChanged) AggregateID() string          { return string(e.UserID) }
+
+type GroupCreated struct {
+	GroupID GroupID  `json:"groupId"`
+	Name    string   `json:"name"`
+	UserIDs []UserID `json:"userIds"`
+}
+
+func (e GroupCreated) EventType() DomainEventType   { return DomainEventTypeGroupCreated }
+func (e GroupCreated) AggregateType() AggregateType { return AggregateTypeGroup }
+func (e GroupCreated) AggregateID() string          { return string(e.GroupID) }
+
+type GroupUpdated struct {
+	GroupID GroupID `json:"groupId"`
+	Name    string  `json:"name"`
+}
+
+func (e GroupUpdated) EventType() DomainEventType   { return DomainEventTypeGroupUpdated }
+func (e GroupUpdated) AggregateType() AggregateType { return AggregateTypeGroup }
+func (e GroupUpdated) AggregateID() string          { return string(e.GroupID) }
+
+type GroupMembershipChanged struct {
+	GroupID        GroupID  `json:"groupId"`
+	AddedUserIDs   []UserID `json:"addedUserIds"`
+	RemovedUserIDs []UserID `json:"removedUserIds"`
+}
+
+func (e GroupMembershipChanged) EventType() DomainEventType {
+	return DomainEventTypeGroupMembershipChanged
+}
+func (e GroupMembershipChanged) AggregateType() AggregateType { return AggregateTypeGroup }
+func (e GroupMembershipChanged) AggregateID() string          { return string(e.GroupID) }
+
+// GroupWaitlistPromoted is recorded when waitlisted users are promoted to members as the group has room.
+type GroupWaitlistPromoted struct {
+	GroupID GroupID  `json:"groupId"`
+	UserIDs []UserID `json:"userIds"`
+}
+
+func (e GroupWaitlistPromoted) EventType() DomainEventType {
+	return DomainEventTypeGroupWaitlistPromoted
+}
+func (e GroupWaitlistPromoted) AggregateType() AggregateType { return AggregateTypeGroup }
+func (e GroupWaitlistPromoted) AggregateID() string          { return string(e.GroupID) }
+
+type GroupDeleted struct {
+	GroupID GroupID `json:"groupId"`
+}
+
+func (e GroupDeleted) EventType() DomainEventType   { return DomainEventTypeGroupDeleted }
+func (e GroupDeleted) AggregateType() AggregateType { return AggregateTypeGroup }
+func (e GroupDeleted) AggregateID() string          { return string(e.GroupID) }
+
+// events holds the domain events recorded by an aggregate until they are pulled.
+type events []DomainEvent
+
+func (es *events) record(e DomainEvent) {
+	*es = append(*es, e)
+}
+
+func (es *events) pull() []DomainEvent {
+	pulled := *es
+	*es = nil
+	return pulled
+}
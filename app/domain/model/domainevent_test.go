@@ -0,0 +1,107 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestUser_PullEvents(t *testing.T) {
+	u := model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL")
+	u.RecordCreated()
+	u.RecordDeleted()
+
+	want := []model.DomainEvent{
+		model.UserCreated{UserID: "TEST_USER_ID", Name: "TEST_USER_NAME", Email: "TEST_USER_EMAIL"},
+		model.UserDeleted{UserID: "TEST_USER_ID"},
+	}
+	if diff := cmp.Diff(u.PullEvents(), want); diff != "" {
+		t.Errorf("u.PullEvents() differs: (-got +want)\n%s", diff)
+	}
+	if got := u.PullEvents(); got != nil {
+		t.Errorf("u.PullEvents()=%v; want nil once pulled", got)
+	}
+}
+
+func TestGroup_AddUsers(t *testing.T) {
+	tests := []struct {
+		name       string
+		g          *model.Group
+		uIDs       []model.UserID
+		wantIDs    []model.UserID
+		wantEvents []model.DomainEvent
+		wantErr    error
+	}{
+		{
+			name:    "Adds the users who are not members yet",
+			g:       model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", []model.UserID{"TEST_USER_ID_1"}),
+			uIDs:    []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2", "TEST_USER_ID_2"},
+			wantIDs: []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+			wantEvents: []model.DomainEvent{
+				model.GroupMembershipChanged{
+					GroupID:      "TEST_GROUP_ID",
+					AddedUserIDs: []model.UserID{"TEST_USER_ID_2"},
+				},
+			},
+		},
+		{
+			name:       "Records nothing if every user is a member",
+			g:          model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", []model.UserID{"TEST_USER_ID_1"}),
+			uIDs:       []model.UserID{"TEST_USER_ID_1"},
+			wantIDs:    []model.UserID{"TEST_USER_ID_1"},
+			wantEvents: nil,
+		},
+		{
+			name: "Error too many users",
+			g: model.MustNewGroup(
+				"TEST_GROUP_ID",
+				"TEST_GROUP_NAME",
+				[]model.UserID{"1", "2", "3", "4", "5"},
+			),
+			uIDs:    []model.UserID{"6"},
+			wantErr: model.ErrInvalidGroup,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.g.AddUsers(model.DefaultPolicy().Group, tt.uIDs)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("g.AddUsers(%v)=%v; want %v", tt.uIDs, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if diff := cmp.Diff(tt.g.UserIDs(), tt.wantIDs); diff != "" {
+				t.Errorf("g.UserIDs() differs: (-got +want)\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.g.PullEvents(), tt.wantEvents); diff != "" {
+				t.Errorf("g.PullEvents() differs: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGroup_RemoveUsers(t *testing.T) {
+	g := model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"})
+	g.RemoveUsers([]model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_3"})
+
+	if diff := cmp.Diff(g.UserIDs(), []model.UserID{"TEST_USER_ID_2"}); diff != "" {
+		t.Errorf("g.UserIDs() differs: (-got +want)\n%s", diff)
+	}
+	want := []model.DomainEvent{
+		model.GroupMembershipChanged{
+			GroupID:        "TEST_GROUP_ID",
+			RemovedUserIDs: []model.UserID{"TEST_USER_ID_1"},
+		},
+	}
+	if diff := cmp.Diff(g.PullEvents(), want); diff != "" {
+		t.Errorf("g.PullEvents() differs: (-got +want)\n%s", diff)
+	}
+}
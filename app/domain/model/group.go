@@ -7,19 +7,48 @@ import (
 
 var (
 	ErrInvalidGroup = errors.New("invalid group")
+	// ErrGroupJoinNotAllowed is returned when a user joins a group whose join policy does not allow it.
+	ErrGroupJoinNotAllowed = errors.New("group join not allowed")
 )
 
+// MaxGroupNameLength is the limit of the name of a group which the storage allows, and the API schema also declares.
+// GroupPolicy limits the name further.
+const MaxGroupNameLength = 255
+
+type GroupID string
+
+// GroupJoinPolicy is how a user joins a group by themselves.
+type GroupJoinPolicy string
+
 const (
-	maxGroupNameLength = 30
-	maxGroupUserCount  = 5
+	// GroupJoinPolicyOpen lets a user join the group immediately.
+	GroupJoinPolicyOpen GroupJoinPolicy = "OPEN"
+	// GroupJoinPolicyApproval lets a user request to join the group, which is approved or rejected.
+	GroupJoinPolicyApproval GroupJoinPolicy = "APPROVAL"
+	// GroupJoinPolicyInviteOnly lets a user join the group only by an invitation.
+	GroupJoinPolicyInviteOnly GroupJoinPolicy = "INVITE_ONLY"
 )
 
-type GroupID string
+// DefaultGroupJoinPolicy is the join policy of a group which is not given one.
+const DefaultGroupJoinPolicy = GroupJoinPolicyOpen
+
+func (p GroupJoinPolicy) IsValid() bool {
+	switch p {
+	case GroupJoinPolicyOpen, GroupJoinPolicyApproval, GroupJoinPolicyInviteOnly:
+		return true
+	}
+	return false
+}
 
 type Group struct {
-	id      GroupID
-	name    string
-	userIDs []UserID
+	id         GroupID
+	name       string
+	userIDs    []UserID
+	joinPolicy GroupJoinPolicy
+	attributes Attributes
+	labels     Labels
+	timestamps Timestamps
+	events     events
 }
 
 func NewGroup(id GroupID, name string, uIDs []UserID) (*Group, error) {
@@ -30,18 +59,15 @@ func NewGroup(id GroupID, name string, uIDs []UserID) (*Group, error) {
 	if name == "" {
 		return nil, fmt.Errorf("group name must not empty: %w", ErrInvalidGroup)
 	}
-	if len(name) > maxGroupNameLength {
+	if len(name) > MaxGroupNameLength {
 		return nil, fmt.Errorf("exceeds the max group name length: %w", ErrInvalidGroup)
 	}
 
-	if len(uIDs) > maxGroupUserCount {
-		return nil, fmt.Errorf("exceeds the max group users: %w", ErrInvalidGroup)
-	}
-
 	return &Group{
-		id:      id,
-		name:    name,
-		userIDs: uIDs,
+		id:         id,
+		name:       name,
+		userIDs:    uIDs,
+		joinPolicy: DefaultGroupJoinPolicy,
 	}, nil
 }
 
@@ -74,14 +100,167 @@ func (g *Group) UserIDs() []UserID {
 	return g.userIDs
 }
 
-func (g *Group) IsMaxUsers() bool {
+func (g *Group) JoinPolicy() GroupJoinPolicy {
 	if g == nil {
-		return false
+		return ""
+	}
+	return g.joinPolicy
+}
+
+// ChangeJoinPolicy changes how a user joins the group. The pending join requests are kept, which
+// the join policy decides how to respond to.
+func (g *Group) ChangeJoinPolicy(p GroupJoinPolicy) error {
+	if !p.IsValid() {
+		return fmt.Errorf("unknown group join policy %q: %w", p, ErrInvalidGroup)
+	}
+	g.joinPolicy = p
+	return nil
+}
+
+// Attributes returns a copy of the custom attributes of the group.
+func (g *Group) Attributes() Attributes {
+	if g == nil {
+		return nil
+	}
+	return g.attributes.clone()
+}
+
+// ChangeAttributes replaces the custom attributes of the group, which the schemas of the groups validate beforehand.
+func (g *Group) ChangeAttributes(attrs Attributes) {
+	g.attributes = attrs.clone()
+}
+
+// Labels returns a copy of the labels of the group.
+func (g *Group) Labels() Labels {
+	if g == nil {
+		return nil
 	}
-	if len(g.userIDs) < maxGroupUserCount {
+	return g.labels.clone()
+}
+
+// ChangeLabels replaces the labels of the group.
+func (g *Group) ChangeLabels(ls Labels) error {
+	if err := ls.Validate(); err != nil {
+		return fmt.Errorf("%v: %w", err, ErrInvalidGroup)
+	}
+	g.labels = ls.clone()
+	return nil
+}
+
+// Timestamps returns when and by whom the group was created and last updated.
+func (g *Group) Timestamps() Timestamps {
+	if g == nil {
+		return Timestamps{}
+	}
+	return g.timestamps
+}
+
+// ChangeTimestamps replaces when and by whom the group was created and last updated, e.g. as stored.
+func (g *Group) ChangeTimestamps(ts Timestamps) {
+	g.timestamps = ts
+}
+
+// RequiresJoinApproval reports whether the user must request to join the group instead of joining it immediately.
+// It returns ErrGroupJoinNotAllowed if the user cannot join the group by themselves.
+func (g *Group) RequiresJoinApproval() (bool, error) {
+	switch g.JoinPolicy() {
+	case GroupJoinPolicyOpen:
+		return false, nil
+	case GroupJoinPolicyApproval:
+		return true, nil
+	}
+	return false, fmt.Errorf("the group is %s: %w", g.JoinPolicy(), ErrGroupJoinNotAllowed)
+}
+
+// AddUsers adds the users to the group up to the capacity of the policy, and records the membership change.
+// The users who already belong to the group are ignored.
+func (g *Group) AddUsers(p GroupPolicy, uIDs []UserID) error {
+	var added []UserID
+	for _, uID := range uIDs {
+		if !g.HasUser(uID) && !containsUserID(added, uID) {
+			added = append(added, uID)
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	if len(g.userIDs)+len(added) > p.MaxUsers {
+		return fmt.Errorf("exceeds the max group users: %w", ErrInvalidGroup)
+	}
+
+	g.userIDs = append(g.userIDs, added...)
+	g.events.record(GroupMembershipChanged{GroupID: g.id, AddedUserIDs: added})
+	return nil
+}
+
+// RemoveUsers removes the users from the group and records the membership change.
+// The users who do not belong to the group are ignored.
+func (g *Group) RemoveUsers(uIDs []UserID) {
+	var (
+		remained []UserID
+		removed  []UserID
+	)
+	for _, guID := range g.userIDs {
+		if containsUserID(uIDs, guID) {
+			removed = append(removed, guID)
+		} else {
+			remained = append(remained, guID)
+		}
+	}
+	if len(removed) == 0 {
+		return
+	}
+
+	g.userIDs = remained
+	g.events.record(GroupMembershipChanged{GroupID: g.id, RemovedUserIDs: removed})
+}
+
+func (g *Group) HasUser(uID UserID) bool {
+	if g == nil {
 		return false
 	}
-	return true
+	return containsUserID(g.userIDs, uID)
+}
+
+// SearchRank ranks the group matching the normalized query by its name, lower for more relevant.
+// It reports false if the group does not match the query.
+func (g *Group) SearchRank(q string) (int, bool) {
+	if g == nil {
+		return 0, false
+	}
+	return searchRank(q, g.name)
+}
+
+// RecordCreated records that the group has been created.
+func (g *Group) RecordCreated() {
+	g.events.record(GroupCreated{GroupID: g.id, Name: g.name, UserIDs: g.userIDs})
+}
+
+// RecordUpdated records that the group has been updated.
+func (g *Group) RecordUpdated() {
+	g.events.record(GroupUpdated{GroupID: g.id, Name: g.name})
+}
+
+// RecordDeleted records that the group has been deleted.
+func (g *Group) RecordDeleted() {
+	g.events.record(GroupDeleted{GroupID: g.id})
+}
+
+// PullEvents returns the recorded domain events and clears them.
+func (g *Group) PullEvents() []DomainEvent {
+	if g == nil {
+		return nil
+	}
+	return g.events.pull()
+}
+
+func containsUserID(uIDs []UserID, uID UserID) bool {
+	for _, v := range uIDs {
+		if v == uID {
+			return true
+		}
+	}
+	return false
 }
 
 type Groups []*Group
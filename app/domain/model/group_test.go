@@ -96,23 +96,6 @@ func TestNewGroup(t *testing.T) {
 			want:    nil,
 			wantErr: model.ErrInvalidGroup,
 		},
-		{
-			name: "Error exceeds the max group users",
-			args: args{
-				id:   "TEST_GROUP_ID",
-				name: "TEST_GROUP_NAME",
-				uIDs: []model.UserID{
-					"TEST_USER_ID_1",
-					"TEST_USER_ID_2",
-					"TEST_USER_ID_3",
-					"TEST_USER_ID_4",
-					"TEST_USER_ID_5",
-					"TEST_USER_ID_6",
-				},
-			},
-			want:    nil,
-			wantErr: model.ErrInvalidGroup,
-		},
 	}
 
 	for _, tt := range tests {
@@ -237,56 +220,6 @@ func TestGroup_UserIDs(t *testing.T) {
 	}
 }
 
-func TestGroup_IsMaxUser(t *testing.T) {
-	tests := []struct {
-		name  string
-		group *model.Group
-		want  bool
-	}{
-		{
-			name: "Returns true if the number of group users is max",
-			group: model.MustNewGroup(
-				"TEST_GROUP_ID",
-				"TEST_GROUP_NAME",
-				[]model.UserID{
-					"TEST_USER_ID_1",
-					"TEST_USER_ID_2",
-					"TEST_USER_ID_3",
-					"TEST_USER_ID_4",
-					"TEST_USER_ID_5",
-				},
-			),
-			want: true,
-		},
-		{
-			name: "Returns false if the number of group users is less than max",
-			group: model.MustNewGroup(
-				"TEST_GROUP_ID",
-				"TEST_GROUP_NAME",
-				[]model.UserID{
-					"TEST_USER_ID_1",
-					"TEST_USER_ID_2",
-					"TEST_USER_ID_3",
-				},
-			),
-			want: false,
-		},
-		{
-			name:  "Receiver is nil",
-			group: nil,
-			want:  false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := tt.group.IsMaxUsers(); got != tt.want {
-				t.Errorf("group.IsMaxUsers()=%t; want %v, receiver=%v", got, tt.want, tt.group)
-			}
-		})
-	}
-}
-
 func TestGroups_IDs(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -409,3 +342,62 @@ func TestGroups_UserIDs(t *testing.T) {
 		})
 	}
 }
+
+func TestGroup_RequiresJoinApproval(t *testing.T) {
+	tests := []struct {
+		name       string
+		joinPolicy model.GroupJoinPolicy
+		want       bool
+		wantErr    error
+	}{
+		{
+			name:       "Returns false for an open group",
+			joinPolicy: model.GroupJoinPolicyOpen,
+			want:       false,
+		},
+		{
+			name:       "Returns true for an approval-only group",
+			joinPolicy: model.GroupJoinPolicyApproval,
+			want:       true,
+		},
+		{
+			name:       "Error the group is invite-only",
+			joinPolicy: model.GroupJoinPolicyInviteOnly,
+			wantErr:    model.ErrGroupJoinNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", nil)
+			if err := g.ChangeJoinPolicy(tt.joinPolicy); err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+
+			got, err := g.RequiresJoinApproval()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("g.RequiresJoinApproval()=_, %v; want _, %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("g.RequiresJoinApproval()=%t, _; want %t, _", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroup_ChangeJoinPolicy(t *testing.T) {
+	g := model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", nil)
+	if got := g.JoinPolicy(); got != model.DefaultGroupJoinPolicy {
+		t.Errorf("g.JoinPolicy()=%s; want %s", got, model.DefaultGroupJoinPolicy)
+	}
+
+	if err := g.ChangeJoinPolicy("UNKNOWN"); !errors.Is(err, model.ErrInvalidGroup) {
+		t.Fatalf("g.ChangeJoinPolicy(UNKNOWN)=%v; want %v", err, model.ErrInvalidGroup)
+	}
+	if err := g.ChangeJoinPolicy(model.GroupJoinPolicyApproval); err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	if got := g.JoinPolicy(); got != model.GroupJoinPolicyApproval {
+		t.Errorf("g.JoinPolicy()=%s; want %s", got, model.GroupJoinPolicyApproval)
+	}
+}
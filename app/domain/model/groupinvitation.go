@@ -0,0 +1,230 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidGroupInvitation = errors.New("invalid group invitation")
+	// ErrGroupInvitationNotPending is returned when an invitation is responded to after it has been accepted,
+	// declined, revoked or has expired.
+	ErrGroupInvitationNotPending = errors.New("group invitation is not pending")
+)
+
+type GroupInvitationID string
+
+type GroupInvitationStatus string
+
+const (
+	GroupInvitationStatusPending  GroupInvitationStatus = "PENDING"
+	GroupInvitationStatusAccepted GroupInvitationStatus = "ACCEPTED"
+	GroupInvitationStatusDeclined GroupInvitationStatus = "DECLINED"
+	GroupInvitationStatusRevoked  GroupInvitationStatus = "REVOKED"
+	GroupInvitationStatusExpired  GroupInvitationStatus = "EXPIRED"
+)
+
+func (s GroupInvitationStatus) IsValid() bool {
+	switch s {
+	case GroupInvitationStatusPending,
+		GroupInvitationStatusAccepted,
+		GroupInvitationStatusDeclined,
+		GroupInvitationStatusRevoked,
+		GroupInvitationStatusExpired:
+		return true
+	}
+	return false
+}
+
+// GroupInvitee is who a group invitation is for, either a user or the email of someone who may not be a user yet.
+type GroupInvitee struct {
+	UserID UserID
+	Email  string
+}
+
+// GroupInvitationState is the bookkeeping of the response to a group invitation.
+type GroupInvitationState struct {
+	Status      GroupInvitationStatus
+	RespondedAt time.Time
+}
+
+// GroupInvitation invites a user to join a group, which the user accepts or declines until it expires.
+type GroupInvitation struct {
+	id        GroupInvitationID
+	groupID   GroupID
+	inviter   string
+	invitee   GroupInvitee
+	createdAt time.Time
+	expiresAt time.Time
+	state     GroupInvitationState
+}
+
+func NewGroupInvitation(
+	id GroupInvitationID,
+	gID GroupID,
+	inviter string,
+	invitee GroupInvitee,
+	createdAt time.Time,
+	expiresAt time.Time,
+	state GroupInvitationState,
+) (*GroupInvitation, error) {
+	if id == "" {
+		return nil, fmt.Errorf("group invitation id must not empty: %w", ErrInvalidGroupInvitation)
+	}
+
+	if gID == "" {
+		return nil, fmt.Errorf("group invitation group id must not empty: %w", ErrInvalidGroupInvitation)
+	}
+
+	if (invitee.UserID == "") == (invitee.Email == "") {
+		return nil, fmt.Errorf("group invitation must be for either a user or an email: %w", ErrInvalidGroupInvitation)
+	}
+
+	if !expiresAt.After(createdAt) {
+		return nil, fmt.Errorf("group invitation must expire after it is created: %w", ErrInvalidGroupInvitation)
+	}
+
+	if state.Status == "" {
+		state.Status = GroupInvitationStatusPending
+	}
+	if !state.Status.IsValid() {
+		return nil, fmt.Errorf("unknown group invitation status %q: %w", state.Status, ErrInvalidGroupInvitation)
+	}
+
+	return &GroupInvitation{
+		id:        id,
+		groupID:   gID,
+		inviter:   inviter,
+		invitee:   invitee,
+		createdAt: createdAt,
+		expiresAt: expiresAt,
+		state:     state,
+	}, nil
+}
+
+func MustNewGroupInvitation(
+	id GroupInvitationID,
+	gID GroupID,
+	inviter string,
+	invitee GroupInvitee,
+	createdAt time.Time,
+	expiresAt time.Time,
+	state GroupInvitationState,
+) *GroupInvitation {
+	inv, err := NewGroupInvitation(id, gID, inviter, invitee, createdAt, expiresAt, state)
+	if err != nil {
+		panic(err)
+	}
+	return inv
+}
+
+func (inv *GroupInvitation) ID() GroupInvitationID {
+	if inv == nil {
+		return ""
+	}
+	return inv.id
+}
+
+func (inv *GroupInvitation) GroupID() GroupID {
+	if inv == nil {
+		return ""
+	}
+	return inv.groupID
+}
+
+// Inviter is the actor who invited the invitee.
+func (inv *GroupInvitation) Inviter() string {
+	if inv == nil {
+		return ""
+	}
+	return inv.inviter
+}
+
+func (inv *GroupInvitation) Invitee() GroupInvitee {
+	if inv == nil {
+		return GroupInvitee{}
+	}
+	return inv.invitee
+}
+
+func (inv *GroupInvitation) CreatedAt() time.Time {
+	if inv == nil {
+		return time.Time{}
+	}
+	return inv.createdAt
+}
+
+func (inv *GroupInvitation) ExpiresAt() time.Time {
+	if inv == nil {
+		return time.Time{}
+	}
+	return inv.expiresAt
+}
+
+func (inv *GroupInvitation) State() GroupInvitationState {
+	if inv == nil {
+		return GroupInvitationState{}
+	}
+	return inv.state
+}
+
+// IsExpired reports whether the invitation has expired at the time, even if it is not marked as expired yet.
+func (inv *GroupInvitation) IsExpired(at time.Time) bool {
+	if inv == nil {
+		return false
+	}
+	return !at.Before(inv.expiresAt)
+}
+
+// IsPending reports whether the invitation can still be responded to at the time.
+func (inv *GroupInvitation) IsPending(at time.Time) bool {
+	if inv == nil {
+		return false
+	}
+	return inv.state.Status == GroupInvitationStatusPending && !inv.IsExpired(at)
+}
+
+// IsFor reports whether the invitation is for the user of the id and the email.
+// The email of an invitation is compared case-insensitively.
+func (inv *GroupInvitation) IsFor(uID UserID, email string) bool {
+	if inv == nil {
+		return false
+	}
+	if inv.invitee.UserID != "" {
+		return inv.invitee.UserID == uID
+	}
+	return strings.EqualFold(inv.invitee.Email, email)
+}
+
+// Accept accepts the invitation by the user of the id and the email, who must be the invitee.
+func (inv *GroupInvitation) Accept(uID UserID, email string, at time.Time) error {
+	if !inv.IsFor(uID, email) {
+		return fmt.Errorf("the user %s is not the invitee: %w", uID, ErrInvalidGroupInvitation)
+	}
+	return inv.respond(GroupInvitationStatusAccepted, at)
+}
+
+func (inv *GroupInvitation) Decline(at time.Time) error {
+	return inv.respond(GroupInvitationStatusDeclined, at)
+}
+
+// Revoke withdraws the invitation before the invitee responds to it.
+func (inv *GroupInvitation) Revoke(at time.Time) error {
+	return inv.respond(GroupInvitationStatusRevoked, at)
+}
+
+func (inv *GroupInvitation) respond(status GroupInvitationStatus, at time.Time) error {
+	if inv.state.Status != GroupInvitationStatusPending {
+		return fmt.Errorf("the invitation is %s: %w", inv.state.Status, ErrGroupInvitationNotPending)
+	}
+	if inv.IsExpired(at) {
+		return fmt.Errorf("the invitation is %s: %w", GroupInvitationStatusExpired, ErrGroupInvitationNotPending)
+	}
+
+	inv.state = GroupInvitationState{Status: status, RespondedAt: at}
+	return nil
+}
+
+type GroupInvitations []*GroupInvitation
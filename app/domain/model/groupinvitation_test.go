@@ -0,0 +1,202 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestNewGroupInvitation(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name       string
+		id         model.GroupInvitationID
+		gID        model.GroupID
+		invitee    model.GroupInvitee
+		expiresAt  time.Time
+		state      model.GroupInvitationState
+		wantStatus model.GroupInvitationStatus
+		wantErr    error
+	}{
+		{
+			name:       "Returns a pending invitation for a user",
+			id:         "TEST_GROUP_INVITATION_ID",
+			gID:        "TEST_GROUP_ID",
+			invitee:    model.GroupInvitee{UserID: "TEST_USER_ID"},
+			expiresAt:  createdAt.Add(time.Hour),
+			wantStatus: model.GroupInvitationStatusPending,
+		},
+		{
+			name:       "Returns an accepted invitation for an email",
+			id:         "TEST_GROUP_INVITATION_ID",
+			gID:        "TEST_GROUP_ID",
+			invitee:    model.GroupInvitee{Email: "test@example.com"},
+			expiresAt:  createdAt.Add(time.Hour),
+			state:      model.GroupInvitationState{Status: model.GroupInvitationStatusAccepted},
+			wantStatus: model.GroupInvitationStatusAccepted,
+		},
+		{
+			name:      "Error the id is empty",
+			gID:       "TEST_GROUP_ID",
+			invitee:   model.GroupInvitee{UserID: "TEST_USER_ID"},
+			expiresAt: createdAt.Add(time.Hour),
+			wantErr:   model.ErrInvalidGroupInvitation,
+		},
+		{
+			name:      "Error the group id is empty",
+			id:        "TEST_GROUP_INVITATION_ID",
+			invitee:   model.GroupInvitee{UserID: "TEST_USER_ID"},
+			expiresAt: createdAt.Add(time.Hour),
+			wantErr:   model.ErrInvalidGroupInvitation,
+		},
+		{
+			name:      "Error the invitee is empty",
+			id:        "TEST_GROUP_INVITATION_ID",
+			gID:       "TEST_GROUP_ID",
+			expiresAt: createdAt.Add(time.Hour),
+			wantErr:   model.ErrInvalidGroupInvitation,
+		},
+		{
+			name:      "Error the invitee is both a user and an email",
+			id:        "TEST_GROUP_INVITATION_ID",
+			gID:       "TEST_GROUP_ID",
+			invitee:   model.GroupInvitee{UserID: "TEST_USER_ID", Email: "test@example.com"},
+			expiresAt: createdAt.Add(time.Hour),
+			wantErr:   model.ErrInvalidGroupInvitation,
+		},
+		{
+			name:      "Error the invitation expires when it is created",
+			id:        "TEST_GROUP_INVITATION_ID",
+			gID:       "TEST_GROUP_ID",
+			invitee:   model.GroupInvitee{UserID: "TEST_USER_ID"},
+			expiresAt: createdAt,
+			wantErr:   model.ErrInvalidGroupInvitation,
+		},
+		{
+			name:      "Error the status is unknown",
+			id:        "TEST_GROUP_INVITATION_ID",
+			gID:       "TEST_GROUP_ID",
+			invitee:   model.GroupInvitee{UserID: "TEST_USER_ID"},
+			expiresAt: createdAt.Add(time.Hour),
+			state:     model.GroupInvitationState{Status: "UNKNOWN"},
+			wantErr:   model.ErrInvalidGroupInvitation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := model.NewGroupInvitation(
+				tt.id, tt.gID, "TEST_ACTOR", tt.invitee, createdAt, tt.expiresAt, tt.state,
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("model.NewGroupInvitation(%s, %s, ...)=_, %v; want _, %v", tt.id, tt.gID, err, tt.wantErr)
+			}
+			if err == nil && got.State().Status != tt.wantStatus {
+				t.Errorf("got.State().Status=%s; want %s", got.State().Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestGroupInvitation_Accept(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expiresAt := createdAt.Add(time.Hour)
+	tests := []struct {
+		name    string
+		invitee model.GroupInvitee
+		state   model.GroupInvitationState
+		uID     model.UserID
+		email   string
+		at      time.Time
+		wantErr error
+	}{
+		{
+			name:    "Accepts the invitation for the user",
+			invitee: model.GroupInvitee{UserID: "TEST_USER_ID"},
+			uID:     "TEST_USER_ID",
+			email:   "test@example.com",
+			at:      createdAt,
+		},
+		{
+			name:    "Accepts the invitation for the email case-insensitively",
+			invitee: model.GroupInvitee{Email: "Test@Example.com"},
+			uID:     "TEST_USER_ID",
+			email:   "test@example.com",
+			at:      createdAt,
+		},
+		{
+			name:    "Error the user is not the invitee",
+			invitee: model.GroupInvitee{UserID: "TEST_USER_ID"},
+			uID:     "TEST_OTHER_USER_ID",
+			email:   "test@example.com",
+			at:      createdAt,
+			wantErr: model.ErrInvalidGroupInvitation,
+		},
+		{
+			name:    "Error the invitation has been declined",
+			invitee: model.GroupInvitee{UserID: "TEST_USER_ID"},
+			state:   model.GroupInvitationState{Status: model.GroupInvitationStatusDeclined},
+			uID:     "TEST_USER_ID",
+			at:      createdAt,
+			wantErr: model.ErrGroupInvitationNotPending,
+		},
+		{
+			name:    "Error the invitation has expired",
+			invitee: model.GroupInvitee{UserID: "TEST_USER_ID"},
+			uID:     "TEST_USER_ID",
+			at:      expiresAt,
+			wantErr: model.ErrGroupInvitationNotPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := model.MustNewGroupInvitation(
+				"TEST_GROUP_INVITATION_ID", "TEST_GROUP_ID", "TEST_ACTOR", tt.invitee, createdAt, expiresAt, tt.state,
+			)
+			before := inv.State()
+
+			err := inv.Accept(tt.uID, tt.email, tt.at)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("inv.Accept(%s, %s, %v)=%v; want %v", tt.uID, tt.email, tt.at, err, tt.wantErr)
+			}
+
+			want := before
+			if err == nil {
+				want = model.GroupInvitationState{Status: model.GroupInvitationStatusAccepted, RespondedAt: tt.at}
+			}
+			if got := inv.State(); got != want {
+				t.Errorf("inv.State()=%v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestGroupInvitation_Revoke(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	inv := model.MustNewGroupInvitation(
+		"TEST_GROUP_INVITATION_ID",
+		"TEST_GROUP_ID",
+		"TEST_ACTOR",
+		model.GroupInvitee{UserID: "TEST_USER_ID"},
+		createdAt,
+		createdAt.Add(time.Hour),
+		model.GroupInvitationState{},
+	)
+
+	if err := inv.Revoke(createdAt); err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	if got := inv.State().Status; got != model.GroupInvitationStatusRevoked {
+		t.Errorf("inv.State().Status=%s; want %s", got, model.GroupInvitationStatusRevoked)
+	}
+	if inv.IsPending(createdAt) {
+		t.Errorf("inv.IsPending(%v)=true; want false", createdAt)
+	}
+
+	if err := inv.Decline(createdAt); !errors.Is(err, model.ErrGroupInvitationNotPending) {
+		t.Errorf("inv.Decline(%v)=%v; want %v", createdAt, err, model.ErrGroupInvitationNotPending)
+	}
+}
@@ -0,0 +1,177 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidGroupJoinRequest = errors.New("invalid group join request")
+	// ErrGroupJoinRequestNotPending is returned when a join request is responded to after it has been approved or rejected.
+	ErrGroupJoinRequestNotPending = errors.New("group join request is not pending")
+)
+
+type GroupJoinRequestID string
+
+type GroupJoinRequestStatus string
+
+const (
+	GroupJoinRequestStatusPending   GroupJoinRequestStatus = "PENDING"
+	GroupJoinRequestStatusApproved  GroupJoinRequestStatus = "APPROVED"
+	GroupJoinRequestStatusRejected  GroupJoinRequestStatus = "REJECTED"
+	GroupJoinRequestStatusCancelled GroupJoinRequestStatus = "CANCELLED"
+)
+
+func (s GroupJoinRequestStatus) IsValid() bool {
+	switch s {
+	case GroupJoinRequestStatusPending,
+		GroupJoinRequestStatusApproved,
+		GroupJoinRequestStatusRejected,
+		GroupJoinRequestStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// GroupJoinRequestState is the bookkeeping of the response to a group join request.
+type GroupJoinRequestState struct {
+	Status      GroupJoinRequestStatus
+	RespondedAt time.Time
+	// RespondedBy is the actor who approved or rejected the request.
+	RespondedBy string
+}
+
+// GroupJoinRequest requests a user to join a group whose join policy requires an approval.
+type GroupJoinRequest struct {
+	id        GroupJoinRequestID
+	groupID   GroupID
+	userID    UserID
+	createdAt time.Time
+	state     GroupJoinRequestState
+}
+
+func NewGroupJoinRequest(
+	id GroupJoinRequestID,
+	gID GroupID,
+	uID UserID,
+	createdAt time.Time,
+	state GroupJoinRequestState,
+) (*GroupJoinRequest, error) {
+	if id == "" {
+		return nil, fmt.Errorf("group join request id must not empty: %w", ErrInvalidGroupJoinRequest)
+	}
+
+	if gID == "" {
+		return nil, fmt.Errorf("group join request group id must not empty: %w", ErrInvalidGroupJoinRequest)
+	}
+
+	if uID == "" {
+		return nil, fmt.Errorf("group join request user id must not empty: %w", ErrInvalidGroupJoinRequest)
+	}
+
+	if state.Status == "" {
+		state.Status = GroupJoinRequestStatusPending
+	}
+	if !state.Status.IsValid() {
+		return nil, fmt.Errorf("unknown group join request status %q: %w", state.Status, ErrInvalidGroupJoinRequest)
+	}
+
+	return &GroupJoinRequest{
+		id:        id,
+		groupID:   gID,
+		userID:    uID,
+		createdAt: createdAt,
+		state:     state,
+	}, nil
+}
+
+func MustNewGroupJoinRequest(
+	id GroupJoinRequestID,
+	gID GroupID,
+	uID UserID,
+	createdAt time.Time,
+	state GroupJoinRequestState,
+) *GroupJoinRequest {
+	jr, err := NewGroupJoinRequest(id, gID, uID, createdAt, state)
+	if err != nil {
+		panic(err)
+	}
+	return jr
+}
+
+func (jr *GroupJoinRequest) ID() GroupJoinRequestID {
+	if jr == nil {
+		return ""
+	}
+	return jr.id
+}
+
+func (jr *GroupJoinRequest) GroupID() GroupID {
+	if jr == nil {
+		return ""
+	}
+	return jr.groupID
+}
+
+func (jr *GroupJoinRequest) UserID() UserID {
+	if jr == nil {
+		return ""
+	}
+	return jr.userID
+}
+
+func (jr *GroupJoinRequest) CreatedAt() time.Time {
+	if jr == nil {
+		return time.Time{}
+	}
+	return jr.createdAt
+}
+
+func (jr *GroupJoinRequest) State() GroupJoinRequestState {
+	if jr == nil {
+		return GroupJoinRequestState{}
+	}
+	return jr.state
+}
+
+func (jr *GroupJoinRequest) IsPending() bool {
+	if jr == nil {
+		return false
+	}
+	return jr.state.Status == GroupJoinRequestStatusPending
+}
+
+// Approve approves the request to join the group by the actor.
+// A request to a group which has become invite-only since it was made cannot be approved, only rejected.
+func (jr *GroupJoinRequest) Approve(g *Group, by string, at time.Time) error {
+	if g.ID() != jr.groupID {
+		return fmt.Errorf("the request is not to the group %s: %w", g.ID(), ErrInvalidGroupJoinRequest)
+	}
+	if g.JoinPolicy() == GroupJoinPolicyInviteOnly {
+		return fmt.Errorf("the group is %s: %w", g.JoinPolicy(), ErrGroupJoinNotAllowed)
+	}
+	return jr.respond(GroupJoinRequestStatusApproved, by, at)
+}
+
+// Reject rejects the request to join the group by the actor, which any join policy allows.
+func (jr *GroupJoinRequest) Reject(by string, at time.Time) error {
+	return jr.respond(GroupJoinRequestStatusRejected, by, at)
+}
+
+// Cancel withdraws the request on behalf of the actor before it is responded to, e.g. as the user leaves all the
+// groups.
+func (jr *GroupJoinRequest) Cancel(by string, at time.Time) error {
+	return jr.respond(GroupJoinRequestStatusCancelled, by, at)
+}
+
+func (jr *GroupJoinRequest) respond(status GroupJoinRequestStatus, by string, at time.Time) error {
+	if jr.state.Status != GroupJoinRequestStatusPending {
+		return fmt.Errorf("the join request is %s: %w", jr.state.Status, ErrGroupJoinRequestNotPending)
+	}
+
+	jr.state = GroupJoinRequestState{Status: status, RespondedAt: at, RespondedBy: by}
+	return nil
+}
+
+type GroupJoinRequests []*GroupJoinRequest
@@ -0,0 +1,169 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestNewGroupJoinRequest(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name       string
+		id         model.GroupJoinRequestID
+		gID        model.GroupID
+		uID        model.UserID
+		state      model.GroupJoinRequestState
+		wantStatus model.GroupJoinRequestStatus
+		wantErr    error
+	}{
+		{
+			name:       "Returns a pending join request",
+			id:         "TEST_GROUP_JOIN_REQUEST_ID",
+			gID:        "TEST_GROUP_ID",
+			uID:        "TEST_USER_ID",
+			wantStatus: model.GroupJoinRequestStatusPending,
+		},
+		{
+			name:       "Returns a rejected join request",
+			id:         "TEST_GROUP_JOIN_REQUEST_ID",
+			gID:        "TEST_GROUP_ID",
+			uID:        "TEST_USER_ID",
+			state:      model.GroupJoinRequestState{Status: model.GroupJoinRequestStatusRejected},
+			wantStatus: model.GroupJoinRequestStatusRejected,
+		},
+		{
+			name:    "Error the id is empty",
+			gID:     "TEST_GROUP_ID",
+			uID:     "TEST_USER_ID",
+			wantErr: model.ErrInvalidGroupJoinRequest,
+		},
+		{
+			name:    "Error the group id is empty",
+			id:      "TEST_GROUP_JOIN_REQUEST_ID",
+			uID:     "TEST_USER_ID",
+			wantErr: model.ErrInvalidGroupJoinRequest,
+		},
+		{
+			name:    "Error the user id is empty",
+			id:      "TEST_GROUP_JOIN_REQUEST_ID",
+			gID:     "TEST_GROUP_ID",
+			wantErr: model.ErrInvalidGroupJoinRequest,
+		},
+		{
+			name:    "Error the status is unknown",
+			id:      "TEST_GROUP_JOIN_REQUEST_ID",
+			gID:     "TEST_GROUP_ID",
+			uID:     "TEST_USER_ID",
+			state:   model.GroupJoinRequestState{Status: "UNKNOWN"},
+			wantErr: model.ErrInvalidGroupJoinRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := model.NewGroupJoinRequest(tt.id, tt.gID, tt.uID, createdAt, tt.state)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("model.NewGroupJoinRequest(%s, %s, %s, ...)=_, %v; want _, %v", tt.id, tt.gID, tt.uID, err, tt.wantErr)
+			}
+			if err == nil && got.State().Status != tt.wantStatus {
+				t.Errorf("got.State().Status=%s; want %s", got.State().Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestGroupJoinRequest_Approve(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	at := createdAt.Add(time.Hour)
+	tests := []struct {
+		name       string
+		gID        model.GroupID
+		joinPolicy model.GroupJoinPolicy
+		state      model.GroupJoinRequestState
+		wantErr    error
+	}{
+		{
+			name:       "Approves the request to an approval-only group",
+			gID:        "TEST_GROUP_ID",
+			joinPolicy: model.GroupJoinPolicyApproval,
+		},
+		{
+			name:       "Approves the request to a group which has become open",
+			gID:        "TEST_GROUP_ID",
+			joinPolicy: model.GroupJoinPolicyOpen,
+		},
+		{
+			name:       "Error the group has become invite-only",
+			gID:        "TEST_GROUP_ID",
+			joinPolicy: model.GroupJoinPolicyInviteOnly,
+			wantErr:    model.ErrGroupJoinNotAllowed,
+		},
+		{
+			name:       "Error the request is not to the group",
+			gID:        "TEST_OTHER_GROUP_ID",
+			joinPolicy: model.GroupJoinPolicyApproval,
+			wantErr:    model.ErrInvalidGroupJoinRequest,
+		},
+		{
+			name:       "Error the request has been rejected",
+			gID:        "TEST_GROUP_ID",
+			joinPolicy: model.GroupJoinPolicyApproval,
+			state:      model.GroupJoinRequestState{Status: model.GroupJoinRequestStatusRejected},
+			wantErr:    model.ErrGroupJoinRequestNotPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jr := model.MustNewGroupJoinRequest(
+				"TEST_GROUP_JOIN_REQUEST_ID", "TEST_GROUP_ID", "TEST_USER_ID", createdAt, tt.state,
+			)
+			g := model.MustNewGroup(tt.gID, "TEST_GROUP_NAME", nil)
+			if err := g.ChangeJoinPolicy(tt.joinPolicy); err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			before := jr.State()
+
+			err := jr.Approve(g, "TEST_ACTOR", at)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("jr.Approve(%s, TEST_ACTOR, %v)=%v; want %v", g.ID(), at, err, tt.wantErr)
+			}
+
+			want := before
+			if err == nil {
+				want = model.GroupJoinRequestState{
+					Status:      model.GroupJoinRequestStatusApproved,
+					RespondedAt: at,
+					RespondedBy: "TEST_ACTOR",
+				}
+			}
+			if got := jr.State(); got != want {
+				t.Errorf("jr.State()=%v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestGroupJoinRequest_Reject(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	jr := model.MustNewGroupJoinRequest(
+		"TEST_GROUP_JOIN_REQUEST_ID", "TEST_GROUP_ID", "TEST_USER_ID", createdAt, model.GroupJoinRequestState{},
+	)
+
+	if err := jr.Reject("TEST_ACTOR", createdAt); err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	if got := jr.State().Status; got != model.GroupJoinRequestStatusRejected {
+		t.Errorf("jr.State().Status=%s; want %s", got, model.GroupJoinRequestStatusRejected)
+	}
+	if jr.IsPending() {
+		t.Error("jr.IsPending()=true; want false")
+	}
+
+	if err := jr.Reject("TEST_ACTOR", createdAt); !errors.Is(err, model.ErrGroupJoinRequestNotPending) {
+		t.Errorf("jr.Reject(TEST_ACTOR, %v)=%v; want %v", createdAt, err, model.ErrGroupJoinRequestNotPending)
+	}
+}
@@ -0,0 +1,149 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidGroupWaitlist = errors.New("invalid group waitlist")
+)
+
+// GroupWaitlist is the users waiting in order for a place in a group at capacity.
+type GroupWaitlist struct {
+	groupID GroupID
+	userIDs []UserID
+}
+
+func NewGroupWaitlist(gID GroupID, uIDs []UserID) (*GroupWaitlist, error) {
+	if gID == "" {
+		return nil, fmt.Errorf("group id must not empty: %w", ErrInvalidGroupWaitlist)
+	}
+	for i, uID := range uIDs {
+		if uID == "" {
+			return nil, fmt.Errorf("user id must not empty: %w", ErrInvalidGroupWaitlist)
+		}
+		if containsUserID(uIDs[:i], uID) {
+			return nil, fmt.Errorf("user %s is waitlisted twice: %w", uID, ErrInvalidGroupWaitlist)
+		}
+	}
+
+	return &GroupWaitlist{
+		groupID: gID,
+		userIDs: uIDs,
+	}, nil
+}
+
+func MustNewGroupWaitlist(gID GroupID, uIDs []UserID) *GroupWaitlist {
+	w, err := NewGroupWaitlist(gID, uIDs)
+	if err != nil {
+		panic(err)
+	}
+	return w
+}
+
+func (w *GroupWaitlist) GroupID() GroupID {
+	if w == nil {
+		return ""
+	}
+	return w.groupID
+}
+
+// UserIDs returns the waitlisted users, the next one to be promoted first.
+func (w *GroupWaitlist) UserIDs() []UserID {
+	if w == nil {
+		return nil
+	}
+	return w.userIDs
+}
+
+func (w *GroupWaitlist) Has(uID UserID) bool {
+	if w == nil {
+		return false
+	}
+	return containsUserID(w.userIDs, uID)
+}
+
+// Add puts the users at the end of the waitlist in order. The users already waitlisted are ignored.
+func (w *GroupWaitlist) Add(uIDs []UserID) {
+	for _, uID := range uIDs {
+		if !w.Has(uID) {
+			w.userIDs = append(w.userIDs, uID)
+		}
+	}
+}
+
+// Remove takes the users off the waitlist. The users who are not waitlisted are ignored.
+func (w *GroupWaitlist) Remove(uIDs []UserID) {
+	var remained []UserID
+	for _, uID := range w.userIDs {
+		if !containsUserID(uIDs, uID) {
+			remained = append(remained, uID)
+		}
+	}
+	w.userIDs = remained
+}
+
+// Reorder orders the waitlist as the users, which must be exactly the waitlisted users.
+func (w *GroupWaitlist) Reorder(uIDs []UserID) error {
+	if len(uIDs) != len(w.userIDs) {
+		return fmt.Errorf("the order must have every waitlisted user once: %w", ErrInvalidGroupWaitlist)
+	}
+	for i, uID := range uIDs {
+		if !w.Has(uID) || containsUserID(uIDs[:i], uID) {
+			return fmt.Errorf("the order must have every waitlisted user once: %w", ErrInvalidGroupWaitlist)
+		}
+	}
+	w.userIDs = append([]UserID{}, uIDs...)
+	return nil
+}
+
+// AddUsersOrWaitlist adds the users to the group up to the capacity of the policy, and puts the rest at the end of
+// the waitlist in order. The added users are taken off the waitlist. It returns the users put on the waitlist.
+func (g *Group) AddUsersOrWaitlist(p GroupPolicy, w *GroupWaitlist, uIDs []UserID) ([]UserID, error) {
+	var (
+		added      []UserID
+		waitlisted []UserID
+	)
+	for _, uID := range uIDs {
+		if g.HasUser(uID) || containsUserID(added, uID) || containsUserID(waitlisted, uID) {
+			continue
+		}
+		if len(g.userIDs)+len(added) < p.MaxUsers {
+			added = append(added, uID)
+		} else if !w.Has(uID) {
+			waitlisted = append(waitlisted, uID)
+		}
+	}
+
+	if err := g.AddUsers(p, added); err != nil {
+		return nil, err
+	}
+	w.Remove(added)
+	w.Add(waitlisted)
+	return waitlisted, nil
+}
+
+// PromoteWaitlisted adds the waitlisted users to the group in order while it has room under the capacity of
+// the policy, takes them off the waitlist and records the promotion. It returns the promoted users.
+func (g *Group) PromoteWaitlisted(p GroupPolicy, w *GroupWaitlist) ([]UserID, error) {
+	var promoted []UserID
+	for _, uID := range w.UserIDs() {
+		if len(g.userIDs)+len(promoted) >= p.MaxUsers {
+			break
+		}
+		if !g.HasUser(uID) {
+			promoted = append(promoted, uID)
+		}
+	}
+	if len(promoted) == 0 {
+		return nil, nil
+	}
+
+	if err := g.AddUsers(p, promoted); err != nil {
+		return nil, err
+	}
+	w.Remove(promoted)
+	g.events.record(GroupWaitlistPromoted{GroupID: g.id, UserIDs: promoted})
+	return promoted, nil
+}
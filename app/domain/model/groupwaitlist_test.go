@@ -0,0 +1,225 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestNewGroupWaitlist(t *testing.T) {
+	tests := []struct {
+		name    string
+		gID     model.GroupID
+		uIDs    []model.UserID
+		wantErr error
+	}{
+		{
+			name: "Returns a waitlist",
+			gID:  "TEST_GROUP_ID",
+			uIDs: []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+		},
+		{
+			name: "Returns an empty waitlist",
+			gID:  "TEST_GROUP_ID",
+		},
+		{
+			name:    "Error the group id is empty",
+			gID:     "",
+			wantErr: model.ErrInvalidGroupWaitlist,
+		},
+		{
+			name:    "Error a user is waitlisted twice",
+			gID:     "TEST_GROUP_ID",
+			uIDs:    []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_1"},
+			wantErr: model.ErrInvalidGroupWaitlist,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := model.NewGroupWaitlist(tt.gID, tt.uIDs); !errors.Is(err, tt.wantErr) {
+				t.Errorf("model.NewGroupWaitlist(%s, %v)=_, %v; want _, %v", tt.gID, tt.uIDs, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGroupWaitlist_Reorder(t *testing.T) {
+	tests := []struct {
+		name    string
+		uIDs    []model.UserID
+		want    []model.UserID
+		wantErr error
+	}{
+		{
+			name: "Reorders the waitlist",
+			uIDs: []model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_1"},
+			want: []model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_1"},
+		},
+		{
+			name:    "Error the order misses a waitlisted user",
+			uIDs:    []model.UserID{"TEST_USER_ID_2"},
+			want:    []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+			wantErr: model.ErrInvalidGroupWaitlist,
+		},
+		{
+			name:    "Error the order has a user twice",
+			uIDs:    []model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_2"},
+			want:    []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+			wantErr: model.ErrInvalidGroupWaitlist,
+		},
+		{
+			name:    "Error the order has a user who is not waitlisted",
+			uIDs:    []model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_3"},
+			want:    []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+			wantErr: model.ErrInvalidGroupWaitlist,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := model.MustNewGroupWaitlist("TEST_GROUP_ID", []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"})
+
+			if err := w.Reorder(tt.uIDs); !errors.Is(err, tt.wantErr) {
+				t.Errorf("w.Reorder(%v)=%v; want %v", tt.uIDs, err, tt.wantErr)
+			}
+			if diff := cmp.Diff(w.UserIDs(), tt.want); diff != "" {
+				t.Errorf("w.UserIDs()=%v; want %v\ndiffers: (-got +want)\n%s", w.UserIDs(), tt.want, diff)
+			}
+		})
+	}
+}
+
+func TestGroup_AddUsersOrWaitlist(t *testing.T) {
+	p := model.DefaultPolicy().Group
+	p.MaxUsers = 2
+
+	tests := []struct {
+		name           string
+		uIDs           []model.UserID
+		wantUserIDs    []model.UserID
+		wantWaitlisted []model.UserID
+		wantWaitlist   []model.UserID
+	}{
+		{
+			name:         "Adds the users up to the capacity",
+			uIDs:         []model.UserID{"TEST_USER_ID_2"},
+			wantUserIDs:  []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+			wantWaitlist: []model.UserID{"TEST_USER_ID_9"},
+		},
+		{
+			name:           "Waitlists the users exceeding the capacity after the waitlisted ones",
+			uIDs:           []model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_3", "TEST_USER_ID_4"},
+			wantUserIDs:    []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+			wantWaitlisted: []model.UserID{"TEST_USER_ID_3", "TEST_USER_ID_4"},
+			wantWaitlist:   []model.UserID{"TEST_USER_ID_9", "TEST_USER_ID_3", "TEST_USER_ID_4"},
+		},
+		{
+			name:         "Takes the added users off the waitlist",
+			uIDs:         []model.UserID{"TEST_USER_ID_9"},
+			wantUserIDs:  []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_9"},
+			wantWaitlist: nil,
+		},
+		{
+			name:         "Ignores the users who already belong to the group",
+			uIDs:         []model.UserID{"TEST_USER_ID_1"},
+			wantUserIDs:  []model.UserID{"TEST_USER_ID_1"},
+			wantWaitlist: []model.UserID{"TEST_USER_ID_9"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", []model.UserID{"TEST_USER_ID_1"})
+			w := model.MustNewGroupWaitlist("TEST_GROUP_ID", []model.UserID{"TEST_USER_ID_9"})
+
+			got, err := g.AddUsersOrWaitlist(p, w, tt.uIDs)
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.wantWaitlisted); diff != "" {
+				t.Errorf(
+					"g.AddUsersOrWaitlist(_, _, %v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+					tt.uIDs, got, tt.wantWaitlisted, diff,
+				)
+			}
+			if diff := cmp.Diff(g.UserIDs(), tt.wantUserIDs); diff != "" {
+				t.Errorf("g.UserIDs()=%v; want %v\ndiffers: (-got +want)\n%s", g.UserIDs(), tt.wantUserIDs, diff)
+			}
+			if diff := cmp.Diff(w.UserIDs(), tt.wantWaitlist); diff != "" {
+				t.Errorf("w.UserIDs()=%v; want %v\ndiffers: (-got +want)\n%s", w.UserIDs(), tt.wantWaitlist, diff)
+			}
+		})
+	}
+}
+
+func TestGroup_PromoteWaitlisted(t *testing.T) {
+	p := model.DefaultPolicy().Group
+	p.MaxUsers = 3
+
+	tests := []struct {
+		name         string
+		groupUserIDs []model.UserID
+		want         []model.UserID
+		wantUserIDs  []model.UserID
+		wantWaitlist []model.UserID
+		wantEvents   []model.DomainEvent
+	}{
+		{
+			name:         "Promotes the waitlisted users in order while the group has room",
+			groupUserIDs: []model.UserID{"TEST_USER_ID_1"},
+			want:         []model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_3"},
+			wantUserIDs:  []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2", "TEST_USER_ID_3"},
+			wantWaitlist: []model.UserID{"TEST_USER_ID_4"},
+			wantEvents: []model.DomainEvent{
+				model.GroupMembershipChanged{
+					GroupID:      "TEST_GROUP_ID",
+					AddedUserIDs: []model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_3"},
+				},
+				model.GroupWaitlistPromoted{
+					GroupID: "TEST_GROUP_ID",
+					UserIDs: []model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_3"},
+				},
+			},
+		},
+		{
+			name:         "Promotes nobody if the group is at capacity",
+			groupUserIDs: []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_5", "TEST_USER_ID_6"},
+			want:         nil,
+			wantUserIDs:  []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_5", "TEST_USER_ID_6"},
+			wantWaitlist: []model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_3", "TEST_USER_ID_4"},
+			wantEvents:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", tt.groupUserIDs)
+			w := model.MustNewGroupWaitlist(
+				"TEST_GROUP_ID",
+				[]model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_3", "TEST_USER_ID_4"},
+			)
+
+			got, err := g.PromoteWaitlisted(p, w)
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("g.PromoteWaitlisted(_, _)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s", got, tt.want, diff)
+			}
+			if diff := cmp.Diff(g.UserIDs(), tt.wantUserIDs); diff != "" {
+				t.Errorf("g.UserIDs()=%v; want %v\ndiffers: (-got +want)\n%s", g.UserIDs(), tt.wantUserIDs, diff)
+			}
+			if diff := cmp.Diff(w.UserIDs(), tt.wantWaitlist); diff != "" {
+				t.Errorf("w.UserIDs()=%v; want %v\ndiffers: (-got +want)\n%s", w.UserIDs(), tt.wantWaitlist, diff)
+			}
+			gotEvents := g.PullEvents()
+			if diff := cmp.Diff(gotEvents, tt.wantEvents); diff != "" {
+				t.Errorf("g.PullEvents()=%v; want %v\ndiffers: (-got +want)\n%s", gotEvents, tt.wantEvents, diff)
+			}
+		})
+	}
+}
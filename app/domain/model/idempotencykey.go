@@ -0,0 +1,130 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidIdempotencyKey = errors.New("invalid idempotency key")
+)
+
+// MaxIdempotencyKeyLength is the maximum length of an idempotency key.
+const MaxIdempotencyKeyLength = 255
+
+// IdempotentResponse is the response to the first request with an idempotency key, which is replayed on its retries.
+type IdempotentResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// IdempotencyKey is a key a client sends with a request so that its retries are not performed twice.
+// It is in progress until the response to the request is recorded.
+type IdempotencyKey struct {
+	key         string
+	fingerprint string
+	response    IdempotentResponse
+	createdAt   time.Time
+	expiresAt   time.Time
+}
+
+func NewIdempotencyKey(
+	key string,
+	fingerprint string,
+	response IdempotentResponse,
+	createdAt time.Time,
+	expiresAt time.Time,
+) (*IdempotencyKey, error) {
+	if key == "" {
+		return nil, fmt.Errorf("idempotency key must not empty: %w", ErrInvalidIdempotencyKey)
+	}
+	if len(key) > MaxIdempotencyKeyLength {
+		return nil, fmt.Errorf(
+			"idempotency key must be at most %d characters: %w", MaxIdempotencyKeyLength, ErrInvalidIdempotencyKey,
+		)
+	}
+
+	if fingerprint == "" {
+		return nil, fmt.Errorf("idempotency key fingerprint must not empty: %w", ErrInvalidIdempotencyKey)
+	}
+
+	if !expiresAt.After(createdAt) {
+		return nil, fmt.Errorf("idempotency key must expire after it is created: %w", ErrInvalidIdempotencyKey)
+	}
+
+	return &IdempotencyKey{
+		key:         key,
+		fingerprint: fingerprint,
+		response:    response,
+		createdAt:   createdAt,
+		expiresAt:   expiresAt,
+	}, nil
+}
+
+func MustNewIdempotencyKey(
+	key string,
+	fingerprint string,
+	response IdempotentResponse,
+	createdAt time.Time,
+	expiresAt time.Time,
+) *IdempotencyKey {
+	k, err := NewIdempotencyKey(key, fingerprint, response, createdAt, expiresAt)
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+func (k *IdempotencyKey) Key() string {
+	if k == nil {
+		return ""
+	}
+	return k.key
+}
+
+// Fingerprint identifies the request made with the key.
+func (k *IdempotencyKey) Fingerprint() string {
+	if k == nil {
+		return ""
+	}
+	return k.fingerprint
+}
+
+func (k *IdempotencyKey) Response() IdempotentResponse {
+	if k == nil {
+		return IdempotentResponse{}
+	}
+	return k.response
+}
+
+func (k *IdempotencyKey) CreatedAt() time.Time {
+	if k == nil {
+		return time.Time{}
+	}
+	return k.createdAt
+}
+
+func (k *IdempotencyKey) ExpiresAt() time.Time {
+	if k == nil {
+		return time.Time{}
+	}
+	return k.expiresAt
+}
+
+// IsCompleted reports whether the response to the request has been recorded.
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k != nil && k.response.StatusCode != 0
+}
+
+func (k *IdempotencyKey) IsExpired(now time.Time) bool {
+	return k != nil && !now.Before(k.expiresAt)
+}
+
+// Complete records the response to the request, which is replayed on its retries.
+func (k *IdempotencyKey) Complete(res IdempotentResponse) {
+	k.response = res
+}
+
+type IdempotencyKeys []*IdempotencyKey
@@ -0,0 +1,142 @@
+package model_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestNewIdempotencyKey(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expiresAt := createdAt.Add(24 * time.Hour)
+	type args struct {
+		key         string
+		fingerprint string
+		createdAt   time.Time
+		expiresAt   time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "Returns idempotency key",
+			args: args{
+				key:         "TEST_IDEMPOTENCY_KEY",
+				fingerprint: "TEST_FINGERPRINT",
+				createdAt:   createdAt,
+				expiresAt:   expiresAt,
+			},
+		},
+		{
+			name: "Error empty key",
+			args: args{
+				fingerprint: "TEST_FINGERPRINT",
+				createdAt:   createdAt,
+				expiresAt:   expiresAt,
+			},
+			wantErr: model.ErrInvalidIdempotencyKey,
+		},
+		{
+			name: "Error too long key",
+			args: args{
+				key:         strings.Repeat("k", model.MaxIdempotencyKeyLength+1),
+				fingerprint: "TEST_FINGERPRINT",
+				createdAt:   createdAt,
+				expiresAt:   expiresAt,
+			},
+			wantErr: model.ErrInvalidIdempotencyKey,
+		},
+		{
+			name: "Error empty fingerprint",
+			args: args{
+				key:       "TEST_IDEMPOTENCY_KEY",
+				createdAt: createdAt,
+				expiresAt: expiresAt,
+			},
+			wantErr: model.ErrInvalidIdempotencyKey,
+		},
+		{
+			name: "Error expires when created",
+			args: args{
+				key:         "TEST_IDEMPOTENCY_KEY",
+				fingerprint: "TEST_FINGERPRINT",
+				createdAt:   createdAt,
+				expiresAt:   createdAt,
+			},
+			wantErr: model.ErrInvalidIdempotencyKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := model.NewIdempotencyKey(
+				tt.args.key, tt.args.fingerprint, model.IdempotentResponse{}, tt.args.createdAt, tt.args.expiresAt,
+			)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("NewIdempotencyKey(%v)=_, %v; want _, %v", tt.args, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if got.Key() != tt.args.key {
+				t.Errorf("k.Key()=%s; want %s", got.Key(), tt.args.key)
+			}
+			if got.IsCompleted() {
+				t.Error("k.IsCompleted()=true; want false")
+			}
+		})
+	}
+}
+
+func TestIdempotencyKey_Complete(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	k := model.MustNewIdempotencyKey(
+		"TEST_IDEMPOTENCY_KEY", "TEST_FINGERPRINT", model.IdempotentResponse{}, createdAt, createdAt.Add(time.Hour),
+	)
+	res := model.IdempotentResponse{StatusCode: 201, ContentType: "application/json", Body: []byte(`{}`)}
+
+	k.Complete(res)
+
+	if !k.IsCompleted() {
+		t.Error("k.IsCompleted()=false; want true")
+	}
+	if diff := cmp.Diff(k.Response(), res); diff != "" {
+		t.Errorf("k.Response()=%v; want %v\ndiffers: (-got +want)\n%s", k.Response(), res, diff)
+	}
+}
+
+func TestIdempotencyKey_IsExpired(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expiresAt := createdAt.Add(time.Hour)
+	k := model.MustNewIdempotencyKey(
+		"TEST_IDEMPOTENCY_KEY", "TEST_FINGERPRINT", model.IdempotentResponse{}, createdAt, expiresAt,
+	)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{name: "Not expired before it expires", now: expiresAt.Add(-time.Nanosecond), want: false},
+		{name: "Expired when it expires", now: expiresAt, want: true},
+		{name: "Expired after it expires", now: expiresAt.Add(time.Second), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := k.IsExpired(tt.now); got != tt.want {
+				t.Errorf("k.IsExpired(%v)=%t; want %t", tt.now, got, tt.want)
+			}
+		})
+	}
+}
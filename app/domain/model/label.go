@@ -0,0 +1,256 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrInvalidLabels        = errors.New("invalid labels")
+	ErrInvalidLabelSelector = errors.New("invalid label selector")
+)
+
+const (
+	// MaxLabels is the limit of the number of the labels of a group.
+	MaxLabels = 64
+	// MaxLabelNameLength is the limit of the name of a label key, which follows the prefix if any.
+	MaxLabelNameLength = 63
+	// MaxLabelPrefixLength is the limit of the prefix of a label key, which is a DNS subdomain.
+	MaxLabelPrefixLength = 253
+	// MaxLabelValueLength is the limit of the value of a label.
+	MaxLabelValueLength = 63
+)
+
+var (
+	labelNamePattern   = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	labelPrefixPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// ValidateLabelKey reports an error unless the key is a name, optionally prefixed with a DNS subdomain and a slash,
+// e.g. team or example.com/team.
+func ValidateLabelKey(key string) error {
+	name := key
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		prefix := key[:i]
+		if len(prefix) > MaxLabelPrefixLength || !labelPrefixPattern.MatchString(prefix) {
+			return fmt.Errorf("label key prefix must be a DNS subdomain: %q: %w", key, ErrInvalidLabels)
+		}
+		name = key[i+1:]
+	}
+	if len(name) > MaxLabelNameLength || !labelNamePattern.MatchString(name) {
+		return fmt.Errorf(
+			"label key name must be alphanumeric with -, _ or . inside and at most %d characters: %q: %w",
+			MaxLabelNameLength, key, ErrInvalidLabels,
+		)
+	}
+	return nil
+}
+
+// ValidateLabelValue reports an error unless the value is empty or alphanumeric with -, _ or . inside.
+func ValidateLabelValue(v string) error {
+	if v == "" {
+		return nil
+	}
+	if len(v) > MaxLabelValueLength || !labelNamePattern.MatchString(v) {
+		return fmt.Errorf(
+			"label value must be alphanumeric with -, _ or . inside and at most %d characters: %q: %w",
+			MaxLabelValueLength, v, ErrInvalidLabels,
+		)
+	}
+	return nil
+}
+
+// Labels are the key/value pairs which classify a group, e.g. team=payments.
+type Labels map[string]string
+
+// Validate reports an error if there are too many labels or a key or a value is invalid.
+func (ls Labels) Validate() error {
+	if len(ls) > MaxLabels {
+		return fmt.Errorf("exceeds the max labels %d: %w", MaxLabels, ErrInvalidLabels)
+	}
+	for _, k := range ls.Keys() {
+		if err := ValidateLabelKey(k); err != nil {
+			return err
+		}
+		if err := ValidateLabelValue(ls[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Keys returns the keys of the labels in order.
+func (ls Labels) Keys() []string {
+	keys := make([]string, 0, len(ls))
+	for k := range ls {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// String returns the labels as comma-separated key=value pairs in the order of the keys.
+func (ls Labels) String() string {
+	pairs := make([]string, 0, len(ls))
+	for _, k := range ls.Keys() {
+		pairs = append(pairs, k+"="+ls[k])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// clone copies the labels, returning nil if there are none.
+func (ls Labels) clone() Labels {
+	if len(ls) == 0 {
+		return nil
+	}
+	c := make(Labels, len(ls))
+	for k, v := range ls {
+		c[k] = v
+	}
+	return c
+}
+
+// LabelOperator is how a requirement of a label selector compares the label of its key.
+type LabelOperator string
+
+const (
+	LabelOperatorEquals       LabelOperator = "="
+	LabelOperatorNotEquals    LabelOperator = "!="
+	LabelOperatorIn           LabelOperator = "in"
+	LabelOperatorNotIn        LabelOperator = "notin"
+	LabelOperatorExists       LabelOperator = "exists"
+	LabelOperatorDoesNotExist LabelOperator = "!"
+)
+
+// LabelRequirement is a condition on the label of the key. Equals and NotEquals have a value, In and NotIn have
+// one or more values, and Exists and DoesNotExist have none.
+type LabelRequirement struct {
+	Key      string
+	Operator LabelOperator
+	Values   []string
+}
+
+// Negated reports whether the requirement keeps the labels without the key or its values, such as NotEquals,
+// NotIn and DoesNotExist, which are matched by the labels lacking the key.
+func (r LabelRequirement) Negated() bool {
+	switch r.Operator {
+	case LabelOperatorNotEquals, LabelOperatorNotIn, LabelOperatorDoesNotExist:
+		return true
+	}
+	return false
+}
+
+// Matches reports whether the labels meet the requirement.
+func (r LabelRequirement) Matches(ls Labels) bool {
+	v, ok := ls[r.Key]
+	found := ok
+	if ok && len(r.Values) > 0 {
+		found = false
+		for _, want := range r.Values {
+			if v == want {
+				found = true
+				break
+			}
+		}
+	}
+	return found != r.Negated()
+}
+
+// LabelSelector keeps the labels which meet all the requirements.
+type LabelSelector []LabelRequirement
+
+var labelSetRequirementPattern = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+
+// ParseLabelSelector parses comma-separated requirements: key=value or key==value, key!=value,
+// key in (v1,v2), key notin (v1,v2), key for existence and !key for non-existence. An empty selector is nil.
+func ParseLabelSelector(s string) (LabelSelector, error) {
+	var sel LabelSelector
+	for _, term := range splitLabelSelector(s) {
+		if term = strings.TrimSpace(term); term == "" {
+			continue
+		}
+		r, err := parseLabelRequirement(term)
+		if err != nil {
+			return nil, err
+		}
+		if err := ValidateLabelKey(r.Key); err != nil {
+			return nil, fmt.Errorf("%v: %w", err, ErrInvalidLabelSelector)
+		}
+		for _, v := range r.Values {
+			if err := ValidateLabelValue(v); err != nil {
+				return nil, fmt.Errorf("%v: %w", err, ErrInvalidLabelSelector)
+			}
+		}
+		sel = append(sel, r)
+	}
+	return sel, nil
+}
+
+// splitLabelSelector splits the selector by the commas outside the parentheses of the sets of values.
+func splitLabelSelector(s string) []string {
+	var (
+		terms []string
+		depth int
+		start int
+	)
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				terms = append(terms, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(terms, s[start:])
+}
+
+func parseLabelRequirement(term string) (LabelRequirement, error) {
+	if m := labelSetRequirementPattern.FindStringSubmatch(term); m != nil {
+		var values []string
+		for _, v := range strings.Split(m[3], ",") {
+			values = append(values, strings.TrimSpace(v))
+		}
+		if len(values) == 1 && values[0] == "" {
+			return LabelRequirement{}, fmt.Errorf("%s of %s must have values: %w", m[2], m[1], ErrInvalidLabelSelector)
+		}
+		return LabelRequirement{Key: m[1], Operator: LabelOperator(m[2]), Values: values}, nil
+	}
+	if strings.HasPrefix(term, "!") && !strings.Contains(term, "=") {
+		return LabelRequirement{Key: strings.TrimSpace(term[1:]), Operator: LabelOperatorDoesNotExist}, nil
+	}
+	for _, op := range []string{"!=", "==", "="} {
+		if k, v, ok := strings.Cut(term, op); ok {
+			o := LabelOperatorEquals
+			if op == "!=" {
+				o = LabelOperatorNotEquals
+			}
+			return LabelRequirement{
+				Key:      strings.TrimSpace(k),
+				Operator: o,
+				Values:   []string{strings.TrimSpace(v)},
+			}, nil
+		}
+	}
+	if strings.ContainsAny(term, " ()") {
+		return LabelRequirement{}, fmt.Errorf("unknown requirement %q: %w", term, ErrInvalidLabelSelector)
+	}
+	return LabelRequirement{Key: term, Operator: LabelOperatorExists}, nil
+}
+
+// Matches reports whether the labels meet all the requirements of the selector.
+func (sel LabelSelector) Matches(ls Labels) bool {
+	for _, r := range sel {
+		if !r.Matches(ls) {
+			return false
+		}
+	}
+	return true
+}
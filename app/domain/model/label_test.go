@@ -0,0 +1,172 @@
+package model_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestLabels_Validate(t *testing.T) {
+	tooMany := model.Labels{}
+	for i := 0; i <= model.MaxLabels; i++ {
+		tooMany["key"+strings.Repeat("a", i)] = "value"
+	}
+
+	tests := []struct {
+		name    string
+		ls      model.Labels
+		wantErr error
+	}{
+		{
+			name: "Returns no error for valid labels",
+			ls:   model.Labels{"team": "payments", "example.com/env": "prod", "tier": ""},
+		},
+		{
+			name: "Returns no error for no labels",
+			ls:   nil,
+		},
+		{
+			name:    "Returns error if there are too many labels",
+			ls:      tooMany,
+			wantErr: model.ErrInvalidLabels,
+		},
+		{
+			name:    "Returns error if the name of a key is invalid",
+			ls:      model.Labels{"-team": "payments"},
+			wantErr: model.ErrInvalidLabels,
+		},
+		{
+			name:    "Returns error if the name of a key is too long",
+			ls:      model.Labels{strings.Repeat("a", model.MaxLabelNameLength+1): "payments"},
+			wantErr: model.ErrInvalidLabels,
+		},
+		{
+			name:    "Returns error if the prefix of a key is not a DNS subdomain",
+			ls:      model.Labels{"Example_com/team": "payments"},
+			wantErr: model.ErrInvalidLabels,
+		},
+		{
+			name:    "Returns error if a value is invalid",
+			ls:      model.Labels{"team": "pay ments"},
+			wantErr: model.ErrInvalidLabels,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.ls.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ls.Validate()=%v; want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseLabelSelector(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    model.LabelSelector
+		wantErr error
+	}{
+		{
+			name: "Parses equality requirements",
+			s:    "team=payments, env==prod,tier!=1",
+			want: model.LabelSelector{
+				{Key: "team", Operator: model.LabelOperatorEquals, Values: []string{"payments"}},
+				{Key: "env", Operator: model.LabelOperatorEquals, Values: []string{"prod"}},
+				{Key: "tier", Operator: model.LabelOperatorNotEquals, Values: []string{"1"}},
+			},
+		},
+		{
+			name: "Parses set requirements",
+			s:    "team in (payments, search),example.com/env notin (dev)",
+			want: model.LabelSelector{
+				{Key: "team", Operator: model.LabelOperatorIn, Values: []string{"payments", "search"}},
+				{Key: "example.com/env", Operator: model.LabelOperatorNotIn, Values: []string{"dev"}},
+			},
+		},
+		{
+			name: "Parses existence requirements",
+			s:    "team,!env",
+			want: model.LabelSelector{
+				{Key: "team", Operator: model.LabelOperatorExists},
+				{Key: "env", Operator: model.LabelOperatorDoesNotExist},
+			},
+		},
+		{
+			name: "Returns nil for an empty selector",
+			s:    "",
+			want: nil,
+		},
+		{
+			name:    "Returns error if a set has no values",
+			s:       "team in ()",
+			wantErr: model.ErrInvalidLabelSelector,
+		},
+		{
+			name:    "Returns error if a set has no parentheses",
+			s:       "team in payments",
+			wantErr: model.ErrInvalidLabelSelector,
+		},
+		{
+			name:    "Returns error if a key is invalid",
+			s:       "-team=payments",
+			wantErr: model.ErrInvalidLabelSelector,
+		},
+		{
+			name:    "Returns error if a value is invalid",
+			s:       "team=pay/ments",
+			wantErr: model.ErrInvalidLabelSelector,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := model.ParseLabelSelector(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseLabelSelector(%q)=_, %v; want _, %v", tt.s, err, tt.wantErr)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("ParseLabelSelector(%q)=%v; want %v\ndiffers: (-got +want)\n%s", tt.s, got, tt.want, diff)
+			}
+		})
+	}
+}
+
+func TestLabelSelector_Matches(t *testing.T) {
+	ls := model.Labels{"team": "payments", "env": "prod"}
+
+	tests := []struct {
+		name string
+		s    string
+		want bool
+	}{
+		{name: "Matches equal labels", s: "team=payments,env=prod", want: true},
+		{name: "Does not match a different value", s: "team=search", want: false},
+		{name: "Matches a different value with not equals", s: "env!=staging", want: true},
+		{name: "Matches a missing key with not equals", s: "tier!=1", want: true},
+		{name: "Matches a value in the set", s: "team in (search,payments)", want: true},
+		{name: "Does not match a value not in the set", s: "team in (search)", want: false},
+		{name: "Does not match a value in the set with notin", s: "team notin (payments)", want: false},
+		{name: "Matches a missing key with notin", s: "tier notin (1)", want: true},
+		{name: "Matches an existing key", s: "env", want: true},
+		{name: "Does not match an existing key with !", s: "!env", want: false},
+		{name: "Matches with an empty selector", s: "", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel, err := model.ParseLabelSelector(tt.s)
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if got := sel.Matches(ls); got != tt.want {
+				t.Errorf("sel.Matches(%v)=%t; want %t", ls, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidOutboxMessage = errors.New("invalid outbox message")
+)
+
+type OutboxMessageID string
+
+// OutboxDelivery is the bookkeeping of the attempts to publish an outbox message.
+type OutboxDelivery struct {
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	PublishedAt   time.Time
+}
+
+// OutboxMessage is a domain event stored with the change that raised it, waiting to be published.
+type OutboxMessage struct {
+	id            OutboxMessageID
+	eventType     DomainEventType
+	aggregateType AggregateType
+	aggregateID   string
+	payload       []byte
+	occurredAt    time.Time
+	delivery      OutboxDelivery
+}
+
+func NewOutboxMessage(
+	id OutboxMessageID,
+	eventType DomainEventType,
+	aggregateType AggregateType,
+	aggregateID string,
+	payload []byte,
+	occurredAt time.Time,
+	delivery OutboxDelivery,
+) (*OutboxMessage, error) {
+	if id == "" {
+		return nil, fmt.Errorf("outbox message id must not empty: %w", ErrInvalidOutboxMessage)
+	}
+
+	if eventType == "" {
+		return nil, fmt.Errorf("outbox message event type must not empty: %w", ErrInvalidOutboxMessage)
+	}
+
+	if aggregateType == "" || aggregateID == "" {
+		return nil, fmt.Errorf("outbox message aggregate must not empty: %w", ErrInvalidOutboxMessage)
+	}
+
+	if occurredAt.IsZero() {
+		return nil, fmt.Errorf("outbox message occurred at must not zero: %w", ErrInvalidOutboxMessage)
+	}
+
+	if delivery.Attempts < 0 {
+		return nil, fmt.Errorf("outbox message attempts must not negative: %w", ErrInvalidOutboxMessage)
+	}
+
+	if delivery.NextAttemptAt.IsZero() {
+		delivery.NextAttemptAt = occurredAt
+	}
+
+	return &OutboxMessage{
+		id:            id,
+		eventType:     eventType,
+		aggregateType: aggregateType,
+		aggregateID:   aggregateID,
+		payload:       payload,
+		occurredAt:    occurredAt,
+		delivery:      delivery,
+	}, nil
+}
+
+func MustNewOutboxMessage(
+	id OutboxMessageID,
+	eventType DomainEventType,
+	aggregateType AggregateType,
+	aggregateID string,
+	payload []byte,
+	occurredAt time.Time,
+	delivery OutboxDelivery,
+) *OutboxMessage {
+	m, err := NewOutboxMessage(id, eventType, aggregateType, aggregateID, payload, occurredAt, delivery)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func (m *OutboxMessage) ID() OutboxMessageID {
+	if m == nil {
+		return ""
+	}
+	return m.id
+}
+
+func (m *OutboxMessage) EventType() DomainEventType {
+	if m == nil {
+		return ""
+	}
+	return m.eventType
+}
+
+func (m *OutboxMessage) AggregateType() AggregateType {
+	if m == nil {
+		return ""
+	}
+	return m.aggregateType
+}
+
+func (m *OutboxMessage) AggregateID() string {
+	if m == nil {
+		return ""
+	}
+	return m.aggregateID
+}
+
+// Payload returns the JSON encoded domain event.
+func (m *OutboxMessage) Payload() []byte {
+	if m == nil {
+		return nil
+	}
+	return m.payload
+}
+
+func (m *OutboxMessage) OccurredAt() time.Time {
+	if m == nil {
+		return time.Time{}
+	}
+	return m.occurredAt
+}
+
+func (m *OutboxMessage) Delivery() OutboxDelivery {
+	if m == nil {
+		return OutboxDelivery{}
+	}
+	return m.delivery
+}
+
+func (m *OutboxMessage) IsPublished() bool {
+	if m == nil {
+		return false
+	}
+	return !m.delivery.PublishedAt.IsZero()
+}
+
+// MarkPublished records a successful attempt.
+func (m *OutboxMessage) MarkPublished(at time.Time) {
+	m.delivery.Attempts++
+	m.delivery.LastError = ""
+	m.delivery.PublishedAt = at
+}
+
+// MarkFailed records a failed attempt and when the message should be retried.
+func (m *OutboxMessage) MarkFailed(err error, retryAt time.Time) {
+	m.delivery.Attempts++
+	m.delivery.LastError = err.Error()
+	m.delivery.NextAttemptAt = retryAt
+}
+
+type OutboxMessages []*OutboxMessage
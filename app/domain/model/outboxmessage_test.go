@@ -0,0 +1,170 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestNewOutboxMessage(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	type args struct {
+		id            model.OutboxMessageID
+		eventType     model.DomainEventType
+		aggregateType model.AggregateType
+		aggregateID   string
+		payload       []byte
+		occurredAt    time.Time
+		delivery      model.OutboxDelivery
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *model.OutboxMessage
+		wantErr error
+	}{
+		{
+			name: "Returns outbox message available at the time it occurred",
+			args: args{
+				id:            "TEST_OUTBOX_MESSAGE_ID",
+				eventType:     model.DomainEventTypeUserCreated,
+				aggregateType: model.AggregateTypeUser,
+				aggregateID:   "TEST_USER_ID",
+				payload:       []byte(`{}`),
+				occurredAt:    occurredAt,
+			},
+			want: model.MustNewOutboxMessage(
+				"TEST_OUTBOX_MESSAGE_ID",
+				model.DomainEventTypeUserCreated,
+				model.AggregateTypeUser,
+				"TEST_USER_ID",
+				[]byte(`{}`),
+				occurredAt,
+				model.OutboxDelivery{NextAttemptAt: occurredAt},
+			),
+			wantErr: nil,
+		},
+		{
+			name: "Error empty outbox message id",
+			args: args{
+				eventType:     model.DomainEventTypeUserCreated,
+				aggregateType: model.AggregateTypeUser,
+				aggregateID:   "TEST_USER_ID",
+				occurredAt:    occurredAt,
+			},
+			wantErr: model.ErrInvalidOutboxMessage,
+		},
+		{
+			name: "Error empty event type",
+			args: args{
+				id:            "TEST_OUTBOX_MESSAGE_ID",
+				aggregateType: model.AggregateTypeUser,
+				aggregateID:   "TEST_USER_ID",
+				occurredAt:    occurredAt,
+			},
+			wantErr: model.ErrInvalidOutboxMessage,
+		},
+		{
+			name: "Error empty aggregate id",
+			args: args{
+				id:            "TEST_OUTBOX_MESSAGE_ID",
+				eventType:     model.DomainEventTypeUserCreated,
+				aggregateType: model.AggregateTypeUser,
+				occurredAt:    occurredAt,
+			},
+			wantErr: model.ErrInvalidOutboxMessage,
+		},
+		{
+			name: "Error zero occurred at",
+			args: args{
+				id:            "TEST_OUTBOX_MESSAGE_ID",
+				eventType:     model.DomainEventTypeUserCreated,
+				aggregateType: model.AggregateTypeUser,
+				aggregateID:   "TEST_USER_ID",
+			},
+			wantErr: model.ErrInvalidOutboxMessage,
+		},
+		{
+			name: "Error negative attempts",
+			args: args{
+				id:            "TEST_OUTBOX_MESSAGE_ID",
+				eventType:     model.DomainEventTypeUserCreated,
+				aggregateType: model.AggregateTypeUser,
+				aggregateID:   "TEST_USER_ID",
+				occurredAt:    occurredAt,
+				delivery:      model.OutboxDelivery{Attempts: -1},
+			},
+			wantErr: model.ErrInvalidOutboxMessage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := model.NewOutboxMessage(
+				tt.args.id,
+				tt.args.eventType,
+				tt.args.aggregateType,
+				tt.args.aggregateID,
+				tt.args.payload,
+				tt.args.occurredAt,
+				tt.args.delivery,
+			)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("want an error, but has no error")
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("model.NewOutboxMessage(%v)=_, %v; want _, %v", tt.args, err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("want no error, but has error %v", err)
+				}
+				if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(model.OutboxMessage{})); diff != "" {
+					t.Errorf(
+						"model.NewOutboxMessage(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+						tt.args, got, tt.want, diff,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestOutboxMessage_MarkFailedAndPublished(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	retryAt := occurredAt.Add(time.Second)
+	publishedAt := occurredAt.Add(2 * time.Second)
+
+	m := model.MustNewOutboxMessage(
+		"TEST_OUTBOX_MESSAGE_ID",
+		model.DomainEventTypeUserCreated,
+		model.AggregateTypeUser,
+		"TEST_USER_ID",
+		nil,
+		occurredAt,
+		model.OutboxDelivery{},
+	)
+
+	m.MarkFailed(errors.New("an error occurred"), retryAt)
+	want := model.OutboxDelivery{Attempts: 1, LastError: "an error occurred", NextAttemptAt: retryAt}
+	if diff := cmp.Diff(m.Delivery(), want); diff != "" {
+		t.Errorf("m.Delivery()=%v; want %v\ndiffers: (-got +want)\n%s", m.Delivery(), want, diff)
+	}
+	if m.IsPublished() {
+		t.Error("m.IsPublished()=true; want false")
+	}
+
+	m.MarkPublished(publishedAt)
+	want = model.OutboxDelivery{Attempts: 2, NextAttemptAt: retryAt, PublishedAt: publishedAt}
+	if diff := cmp.Diff(m.Delivery(), want); diff != "" {
+		t.Errorf("m.Delivery()=%v; want %v\ndiffers: (-got +want)\n%s", m.Delivery(), want, diff)
+	}
+	if !m.IsPublished() {
+		t.Error("m.IsPublished()=false; want true")
+	}
+}
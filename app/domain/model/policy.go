@@ -0,0 +1,199 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// The defaults of the policies, which a deployment may override.
+const (
+	DefaultMaxUserNameLength  = 30
+	DefaultMaxGroupNameLength = 30
+	DefaultMaxGroupUsers      = 5
+)
+
+// NamePolicy is the rules of a name.
+type NamePolicy struct {
+	// MaxLength is the limit of the length of a name.
+	MaxLength int
+	// Pattern is the pattern which a name must match entirely, any name if nil.
+	Pattern *regexp.Regexp
+	// Reserved are the names which cannot be taken, compared case-insensitively.
+	Reserved []string
+}
+
+// NewNamePolicy returns the policy of a name up to the max length the storage allows.
+// An empty pattern allows any characters.
+func NewNamePolicy(maxLength, storageMaxLength int, pattern string, reserved []string) (NamePolicy, error) {
+	if maxLength <= 0 || maxLength > storageMaxLength {
+		return NamePolicy{}, fmt.Errorf("max name length must be between 1 and %d", storageMaxLength)
+	}
+	p := NamePolicy{MaxLength: maxLength, Reserved: reserved}
+	if pattern != "" {
+		re, err := regexp.Compile(`^(?:` + pattern + `)$`)
+		if err != nil {
+			return NamePolicy{}, fmt.Errorf("invalid name pattern: %w", err)
+		}
+		p.Pattern = re
+	}
+	return p, nil
+}
+
+// validate validates the name of the kind, wrapping the error of the violation.
+func (p NamePolicy) validate(kind, name string, err error) error {
+	if len(name) > p.MaxLength {
+		return fmt.Errorf("exceeds the max %s name length: %w", kind, err)
+	}
+	if p.Pattern != nil && !p.Pattern.MatchString(name) {
+		return fmt.Errorf("%s name contains disallowed characters: %w", kind, err)
+	}
+	for _, r := range p.Reserved {
+		if strings.EqualFold(name, r) {
+			return fmt.Errorf("%s name %q is reserved: %w", kind, name, err)
+		}
+	}
+	return nil
+}
+
+// UserPolicy is the rules of the users of a deployment.
+type UserPolicy struct {
+	Name NamePolicy
+}
+
+// ValidateName validates the name of a user. A violation is ErrInvalidUser.
+func (p UserPolicy) ValidateName(name string) error {
+	return p.Name.validate("user", name, ErrInvalidUser)
+}
+
+// GroupPolicy is the rules of the groups of a deployment.
+type GroupPolicy struct {
+	Name NamePolicy
+	// MaxUsers is the capacity of a group.
+	MaxUsers int
+}
+
+// NewGroupPolicy returns the policy of the groups with the capacity.
+func NewGroupPolicy(name NamePolicy, maxUsers int) (GroupPolicy, error) {
+	if maxUsers <= 0 {
+		return GroupPolicy{}, fmt.Errorf("max group users must be positive")
+	}
+	return GroupPolicy{Name: name, MaxUsers: maxUsers}, nil
+}
+
+// ValidateName validates the name of a group. A violation is ErrInvalidGroup.
+func (p GroupPolicy) ValidateName(name string) error {
+	return p.Name.validate("group", name, ErrInvalidGroup)
+}
+
+// Validate validates the name and the number of the users of a new group. A violation is ErrInvalidGroup.
+func (p GroupPolicy) Validate(g *Group) error {
+	if err := p.ValidateName(g.Name()); err != nil {
+		return err
+	}
+	if len(g.UserIDs()) > p.MaxUsers {
+		return fmt.Errorf("exceeds the max group users: %w", ErrInvalidGroup)
+	}
+	return nil
+}
+
+// IsFull reports whether the group is at capacity, which no more user can be added to.
+func (p GroupPolicy) IsFull(g *Group) bool {
+	return len(g.UserIDs()) >= p.MaxUsers
+}
+
+// The label keys of a group which select the policy overrides applying to it.
+const (
+	GroupTypeLabelKey = "type"
+	TenantLabelKey    = "tenant"
+)
+
+// PolicyScope is the group type and the tenant a policy override applies to. An empty field matches any.
+type PolicyScope struct {
+	GroupType string
+	Tenant    string
+}
+
+// GroupPolicyScope returns the scope of the group, which is of its type and tenant labels.
+func GroupPolicyScope(g *Group) PolicyScope {
+	if g == nil {
+		return PolicyScope{}
+	}
+	ls := g.Labels()
+	return PolicyScope{GroupType: ls[GroupTypeLabelKey], Tenant: ls[TenantLabelKey]}
+}
+
+// Policy is the rules of the users and the groups of a deployment.
+type Policy struct {
+	User  UserPolicy
+	Group GroupPolicy
+	// GroupOverrides are the group policies of the scopes, which override Group for the groups in them.
+	// The users belong to no scope, so User applies to all of them.
+	GroupOverrides map[PolicyScope]GroupPolicy
+	// Admins are the users who manage all the groups without belonging to them.
+	Admins []UserID
+}
+
+// CanManageGroup reports whether the user manages the group, e.g. responds to the requests to join it,
+// which its members and the admins do.
+func (p Policy) CanManageGroup(g *Group, uID UserID) bool {
+	if g == nil || uID == "" {
+		return false
+	}
+	if g.HasUser(uID) {
+		return true
+	}
+	for _, a := range p.Admins {
+		if a == uID {
+			return true
+		}
+	}
+	return false
+}
+
+// GroupPolicyOf returns the group policy of the scope, which is the override of both its tenant and its group type,
+// of its tenant, of its group type or else the default, the most specific first.
+func (p Policy) GroupPolicyOf(s PolicyScope) GroupPolicy {
+	for _, k := range []PolicyScope{
+		s,
+		{Tenant: s.Tenant},
+		{GroupType: s.GroupType},
+	} {
+		if k == (PolicyScope{}) {
+			continue
+		}
+		if gp, ok := p.GroupOverrides[k]; ok {
+			return gp
+		}
+	}
+	return p.Group
+}
+
+// GroupPolicyFor returns the group policy of the scope of the group.
+func (p Policy) GroupPolicyFor(g *Group) GroupPolicy {
+	return p.GroupPolicyOf(GroupPolicyScope(g))
+}
+
+// MinGroupUsers returns the smallest capacity among the group policies, which a group is at least at to be full.
+func (p Policy) MinGroupUsers() int {
+	n := p.Group.MaxUsers
+	for _, gp := range p.GroupOverrides {
+		if gp.MaxUsers < n {
+			n = gp.MaxUsers
+		}
+	}
+	return n
+}
+
+// DefaultPolicy returns the policy of a deployment which overrides none of the defaults.
+func DefaultPolicy() Policy {
+	return Policy{
+		User: UserPolicy{
+			Name: NamePolicy{MaxLength: DefaultMaxUserNameLength},
+		},
+		Group: GroupPolicy{
+			Name:     NamePolicy{MaxLength: DefaultMaxGroupNameLength},
+			MaxUsers: DefaultMaxGroupUsers,
+		},
+	}
+}
@@ -0,0 +1,277 @@
+package model_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestNewNamePolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxLength int
+		pattern   string
+		wantErr   bool
+	}{
+		{
+			name:      "Returns a policy",
+			maxLength: 50,
+			pattern:   `[a-z ]+`,
+		},
+		{
+			name:      "Returns a policy up to the max length of the storage",
+			maxLength: model.MaxGroupNameLength,
+		},
+		{
+			name:      "Error no max length",
+			maxLength: 0,
+			wantErr:   true,
+		},
+		{
+			name:      "Error exceeds the max length of the storage",
+			maxLength: model.MaxGroupNameLength + 1,
+			wantErr:   true,
+		},
+		{
+			name:      "Error invalid pattern",
+			maxLength: 30,
+			pattern:   `[a-z`,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := model.NewNamePolicy(tt.maxLength, model.MaxGroupNameLength, tt.pattern, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("model.NewNamePolicy(%d, _, %q, nil)=_, %v; want error %t", tt.maxLength, tt.pattern, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserPolicy_ValidateName(t *testing.T) {
+	n, err := model.NewNamePolicy(10, model.MaxUserNameLength, `[A-Za-z ]+`, []string{"Admin"})
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	p := model.UserPolicy{Name: n}
+
+	tests := []struct {
+		name     string
+		userName string
+		wantErr  error
+	}{
+		{
+			name:     "Allows the name",
+			userName: "John Smith",
+			wantErr:  nil,
+		},
+		{
+			name:     "Error exceeds the max length",
+			userName: strings.Repeat("x", 11),
+			wantErr:  model.ErrInvalidUser,
+		},
+		{
+			name:     "Error contains disallowed characters",
+			userName: "John_Smith",
+			wantErr:  model.ErrInvalidUser,
+		},
+		{
+			name:     "Error the name is reserved, case-insensitively",
+			userName: "ADMIN",
+			wantErr:  model.ErrInvalidUser,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := p.ValidateName(tt.userName); !errors.Is(err, tt.wantErr) {
+				t.Errorf("p.ValidateName(%q)=%v; want %v", tt.userName, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGroupPolicy_Validate(t *testing.T) {
+	p, err := model.NewGroupPolicy(model.NamePolicy{MaxLength: 10, Reserved: []string{"everyone"}}, 2)
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		group   *model.Group
+		wantErr error
+	}{
+		{
+			name:    "Allows the group",
+			group:   model.MustNewGroup("TEST_GROUP_ID", "Team", []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"}),
+			wantErr: nil,
+		},
+		{
+			name:    "Error exceeds the max name length",
+			group:   model.MustNewGroup("TEST_GROUP_ID", strings.Repeat("x", 11), nil),
+			wantErr: model.ErrInvalidGroup,
+		},
+		{
+			name:    "Error the name is reserved",
+			group:   model.MustNewGroup("TEST_GROUP_ID", "Everyone", nil),
+			wantErr: model.ErrInvalidGroup,
+		},
+		{
+			name: "Error exceeds the max group users",
+			group: model.MustNewGroup(
+				"TEST_GROUP_ID",
+				"Team",
+				[]model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2", "TEST_USER_ID_3"},
+			),
+			wantErr: model.ErrInvalidGroup,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := p.Validate(tt.group); !errors.Is(err, tt.wantErr) {
+				t.Errorf("p.Validate(%v)=%v; want %v", tt.group, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewGroupPolicy(t *testing.T) {
+	if _, err := model.NewGroupPolicy(model.NamePolicy{MaxLength: 30}, 0); err == nil {
+		t.Error("model.NewGroupPolicy(_, 0)=_, nil; want an error")
+	}
+}
+
+func TestGroupPolicy_IsFull(t *testing.T) {
+	p := model.DefaultPolicy().Group
+
+	tests := []struct {
+		name  string
+		group *model.Group
+		want  bool
+	}{
+		{
+			name: "Returns true if the number of group users is max",
+			group: model.MustNewGroup(
+				"TEST_GROUP_ID",
+				"TEST_GROUP_NAME",
+				[]model.UserID{
+					"TEST_USER_ID_1",
+					"TEST_USER_ID_2",
+					"TEST_USER_ID_3",
+					"TEST_USER_ID_4",
+					"TEST_USER_ID_5",
+				},
+			),
+			want: true,
+		},
+		{
+			name: "Returns false if the number of group users is less than max",
+			group: model.MustNewGroup(
+				"TEST_GROUP_ID",
+				"TEST_GROUP_NAME",
+				[]model.UserID{
+					"TEST_USER_ID_1",
+					"TEST_USER_ID_2",
+					"TEST_USER_ID_3",
+				},
+			),
+			want: false,
+		},
+		{
+			name:  "Group is nil",
+			group: nil,
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.IsFull(tt.group); got != tt.want {
+				t.Errorf("p.IsFull(%v)=%t; want %v", tt.group, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicy_GroupPolicyOf(t *testing.T) {
+	byType := model.GroupPolicy{Name: model.NamePolicy{MaxLength: 30}, MaxUsers: 10}
+	byTenant := model.GroupPolicy{Name: model.NamePolicy{MaxLength: 30}, MaxUsers: 20}
+	byBoth := model.GroupPolicy{Name: model.NamePolicy{MaxLength: 30}, MaxUsers: 30}
+	p := model.DefaultPolicy()
+	p.GroupOverrides = map[model.PolicyScope]model.GroupPolicy{
+		{GroupType: "team"}:                 byType,
+		{Tenant: "acme"}:                    byTenant,
+		{GroupType: "team", Tenant: "acme"}: byBoth,
+	}
+
+	tests := []struct {
+		name string
+		s    model.PolicyScope
+		want model.GroupPolicy
+	}{
+		{
+			name: "Returns the override of the type and the tenant",
+			s:    model.PolicyScope{GroupType: "team", Tenant: "acme"},
+			want: byBoth,
+		},
+		{
+			name: "Returns the override of the tenant before the one of the type",
+			s:    model.PolicyScope{GroupType: "project", Tenant: "acme"},
+			want: byTenant,
+		},
+		{
+			name: "Returns the override of the type",
+			s:    model.PolicyScope{GroupType: "team", Tenant: "other"},
+			want: byType,
+		},
+		{
+			name: "Returns the default of no override",
+			s:    model.PolicyScope{GroupType: "project", Tenant: "other"},
+			want: p.Group,
+		},
+		{
+			name: "Returns the default of no scope",
+			s:    model.PolicyScope{},
+			want: p.Group,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.GroupPolicyOf(tt.s); got.MaxUsers != tt.want.MaxUsers {
+				t.Errorf("p.GroupPolicyOf(%v).MaxUsers=%d; want %d", tt.s, got.MaxUsers, tt.want.MaxUsers)
+			}
+		})
+	}
+}
+
+func TestGroupPolicyScope(t *testing.T) {
+	g := model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", nil)
+	if err := g.ChangeLabels(model.Labels{"type": "team", "tenant": "acme", "env": "prod"}); err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	want := model.PolicyScope{GroupType: "team", Tenant: "acme"}
+	if got := model.GroupPolicyScope(g); got != want {
+		t.Errorf("model.GroupPolicyScope(%v)=%v; want %v", g, got, want)
+	}
+}
+
+func TestPolicy_MinGroupUsers(t *testing.T) {
+	p := model.DefaultPolicy()
+	if got := p.MinGroupUsers(); got != model.DefaultMaxGroupUsers {
+		t.Errorf("p.MinGroupUsers()=%d; want %d", got, model.DefaultMaxGroupUsers)
+	}
+	p.GroupOverrides = map[model.PolicyScope]model.GroupPolicy{
+		{GroupType: "pair"}: {MaxUsers: 2},
+		{Tenant: "acme"}:    {MaxUsers: 50},
+	}
+	if got := p.MinGroupUsers(); got != 2 {
+		t.Errorf("p.MinGroupUsers()=%d; want %d", got, 2)
+	}
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"strings"
+)
+
+// MaxSearchQueryLength is the maximum length of a search query.
+const MaxSearchQueryLength = 100
+
+// NormalizeSearchQuery normalizes a search query, which is matched case-insensitively.
+func NormalizeSearchQuery(q string) string {
+	return strings.ToLower(strings.TrimSpace(q))
+}
+
+// TextRange is a range of a text in runes, from Start inclusive to End exclusive.
+type TextRange struct {
+	Start int
+	End   int
+}
+
+// MatchRanges returns the ranges of the text which match the normalized query, case-insensitively.
+func MatchRanges(q, text string) []TextRange {
+	if q == "" {
+		return nil
+	}
+	// Lowering a text does not change the number of its runes, so the ranges are of the text as well.
+	runes := []rune(strings.ToLower(text))
+	qRunes := []rune(q)
+
+	var result []TextRange
+	for i := 0; i+len(qRunes) <= len(runes); {
+		if string(runes[i:i+len(qRunes)]) == q {
+			result = append(result, TextRange{Start: i, End: i + len(qRunes)})
+			i += len(qRunes)
+			continue
+		}
+		i++
+	}
+	return result
+}
+
+// searchRank ranks the fields matching the normalized query, lower for more relevant: any field equal to
+// the query ranks before any field prefixed with it, which ranks before any field containing it.
+// Among the fields matching alike, the earlier field ranks first.
+func searchRank(q string, fields ...string) (int, bool) {
+	lowers := make([]string, len(fields))
+	for i, f := range fields {
+		lowers[i] = strings.ToLower(f)
+	}
+	for level, match := range []func(s, q string) bool{
+		func(s, q string) bool { return s == q },
+		strings.HasPrefix,
+		strings.Contains,
+	} {
+		for i, f := range lowers {
+			if match(f, q) {
+				return level*len(fields) + i, true
+			}
+		}
+	}
+	return 0, false
+}
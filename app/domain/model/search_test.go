@@ -0,0 +1,120 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestMatchRanges(t *testing.T) {
+	tests := []struct {
+		name string
+		q    string
+		text string
+		want []model.TextRange
+	}{
+		{
+			name: "Returns the ranges of the matches case-insensitively",
+			q:    "an",
+			text: "Ann Banana",
+			want: []model.TextRange{{Start: 0, End: 2}, {Start: 5, End: 7}, {Start: 7, End: 9}},
+		},
+		{
+			name: "Returns the ranges in runes",
+			q:    "ö",
+			text: "Jörg Ö",
+			want: []model.TextRange{{Start: 1, End: 2}, {Start: 5, End: 6}},
+		},
+		{
+			name: "Returns nil without matches",
+			q:    "bob",
+			text: "Ann",
+			want: nil,
+		},
+		{
+			name: "Returns nil for an empty query",
+			q:    "",
+			text: "Ann",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := model.MatchRanges(tt.q, tt.text)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("MatchRanges(%q, %q)=%v; want %v\ndiffers: (-got +want)\n%s", tt.q, tt.text, got, tt.want, diff)
+			}
+		})
+	}
+}
+
+func TestUser_SearchRank(t *testing.T) {
+	tests := []struct {
+		name   string
+		user   *model.User
+		q      string
+		want   int
+		wantOK bool
+	}{
+		{
+			name:   "Ranks the exact name first",
+			user:   model.MustNewUser("TEST_USER_ID", "Ann", "bob@example.com"),
+			q:      "ann",
+			want:   0,
+			wantOK: true,
+		},
+		{
+			name:   "Ranks the exact email",
+			user:   model.MustNewUser("TEST_USER_ID", "Ann", "bob@example.com"),
+			q:      "bob@example.com",
+			want:   1,
+			wantOK: true,
+		},
+		{
+			name:   "Ranks the name prefix",
+			user:   model.MustNewUser("TEST_USER_ID", "Anna", "anna@example.com"),
+			q:      "ann",
+			want:   2,
+			wantOK: true,
+		},
+		{
+			name:   "Ranks the email prefix",
+			user:   model.MustNewUser("TEST_USER_ID", "Bob", "bobby@example.com"),
+			q:      "bobby",
+			want:   3,
+			wantOK: true,
+		},
+		{
+			name:   "Ranks the name substring",
+			user:   model.MustNewUser("TEST_USER_ID", "Joanne", "jo@example.com"),
+			q:      "ann",
+			want:   4,
+			wantOK: true,
+		},
+		{
+			name:   "Ranks the email substring",
+			user:   model.MustNewUser("TEST_USER_ID", "Jo", "jo@example.com"),
+			q:      "example",
+			want:   5,
+			wantOK: true,
+		},
+		{
+			name:   "Does not match",
+			user:   model.MustNewUser("TEST_USER_ID", "Jo", "jo@example.com"),
+			q:      "ann",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.user.SearchRank(tt.q)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("u.SearchRank(%q)=%d, %t; want %d, %t", tt.q, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
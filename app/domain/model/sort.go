@@ -0,0 +1,69 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidSortOrder = errors.New("invalid sort order")
+
+// SortField is a field of the timestamps the users or the groups are sorted by.
+type SortField string
+
+const (
+	SortFieldCreatedAt SortField = "createdAt"
+	SortFieldUpdatedAt SortField = "updatedAt"
+)
+
+func (f SortField) IsValid() bool {
+	switch f {
+	case SortFieldCreatedAt, SortFieldUpdatedAt:
+		return true
+	}
+	return false
+}
+
+// SortOrder orders the users or the groups by the field, in descending order if Desc, and then by id.
+// The zero value leaves the order to the listing.
+type SortOrder struct {
+	Field SortField
+	Desc  bool
+}
+
+// ParseSortOrder parses the field to sort by, prefixed with - for descending order, e.g. -createdAt.
+// An empty sort order is the zero value.
+func ParseSortOrder(s string) (SortOrder, error) {
+	if s == "" {
+		return SortOrder{}, nil
+	}
+	o := SortOrder{Field: SortField(strings.TrimPrefix(s, "-")), Desc: strings.HasPrefix(s, "-")}
+	if !o.Field.IsValid() {
+		return SortOrder{}, fmt.Errorf("unknown sort field %q: %w", o.Field, ErrInvalidSortOrder)
+	}
+	return o, nil
+}
+
+func (o SortOrder) IsZero() bool {
+	return o.Field == ""
+}
+
+// Compare returns a negative number if a comes before b in the order, a positive number if after, and zero if
+// they tie, which the ids break.
+func (o SortOrder) Compare(a, b Timestamps) int {
+	ta, tb := a.CreatedAt, b.CreatedAt
+	if o.Field == SortFieldUpdatedAt {
+		ta, tb = a.UpdatedAt, b.UpdatedAt
+	}
+	c := 0
+	switch {
+	case ta.Before(tb):
+		c = -1
+	case ta.After(tb):
+		c = 1
+	}
+	if o.Desc {
+		return -c
+	}
+	return c
+}
@@ -0,0 +1,93 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestParseSortOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    model.SortOrder
+		wantErr error
+	}{
+		{
+			name: "Parses an ascending order",
+			s:    "createdAt",
+			want: model.SortOrder{Field: model.SortFieldCreatedAt},
+		},
+		{
+			name: "Parses a descending order",
+			s:    "-updatedAt",
+			want: model.SortOrder{Field: model.SortFieldUpdatedAt, Desc: true},
+		},
+		{
+			name: "Returns the zero value for an empty order",
+			s:    "",
+			want: model.SortOrder{},
+		},
+		{
+			name:    "Returns error if the field is unknown",
+			s:       "-name",
+			wantErr: model.ErrInvalidSortOrder,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := model.ParseSortOrder(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseSortOrder(%q)=_, %v; want _, %v", tt.s, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSortOrder(%q)=%v; want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortOrder_Compare(t *testing.T) {
+	earlier := model.NewTimestamps("TEST_ACTOR", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	later := earlier.Updated("TEST_ACTOR", time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name string
+		o    model.SortOrder
+		a, b model.Timestamps
+		want int
+	}{
+		{
+			name: "Orders by the updated time",
+			o:    model.SortOrder{Field: model.SortFieldUpdatedAt},
+			a:    earlier,
+			b:    later,
+			want: -1,
+		},
+		{
+			name: "Orders by the updated time in descending order",
+			o:    model.SortOrder{Field: model.SortFieldUpdatedAt, Desc: true},
+			a:    earlier,
+			b:    later,
+			want: 1,
+		},
+		{
+			name: "Ties if the created times are the same",
+			o:    model.SortOrder{Field: model.SortFieldCreatedAt},
+			a:    earlier,
+			b:    later,
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.o.Compare(tt.a, tt.b); got != tt.want {
+				t.Errorf("o.Compare(%v, %v)=%d; want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	ErrInvalidSubgroup = errors.New("invalid subgroup")
+	ErrGroupCycle      = errors.New("group cycle")
+)
+
+// Subgroup is a group nested in a parent group, whose members are the members of the parent transitively.
+type Subgroup struct {
+	parentID GroupID
+	childID  GroupID
+}
+
+func NewSubgroup(parentID, childID GroupID) (*Subgroup, error) {
+	if parentID == "" {
+		return nil, fmt.Errorf("parent group id must not empty: %w", ErrInvalidSubgroup)
+	}
+	if childID == "" {
+		return nil, fmt.Errorf("child group id must not empty: %w", ErrInvalidSubgroup)
+	}
+	if parentID == childID {
+		return nil, fmt.Errorf("group %s must not be nested in itself: %w", parentID, ErrGroupCycle)
+	}
+
+	return &Subgroup{
+		parentID: parentID,
+		childID:  childID,
+	}, nil
+}
+
+func MustNewSubgroup(parentID, childID GroupID) *Subgroup {
+	s, err := NewSubgroup(parentID, childID)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// NestGroup nests the child group in the parent group, unless the child is the parent or one of its ancestors,
+// which would make a cycle.
+func NestGroup(parentID, childID GroupID, parentAncestorIDs []GroupID) (*Subgroup, error) {
+	for _, gID := range parentAncestorIDs {
+		if gID == childID {
+			return nil, fmt.Errorf("group %s is an ancestor of group %s: %w", childID, parentID, ErrGroupCycle)
+		}
+	}
+	return NewSubgroup(parentID, childID)
+}
+
+func (s *Subgroup) ParentID() GroupID {
+	if s == nil {
+		return ""
+	}
+	return s.parentID
+}
+
+func (s *Subgroup) ChildID() GroupID {
+	if s == nil {
+		return ""
+	}
+	return s.childID
+}
+
+type Subgroups []*Subgroup
+
+// ChildIDs returns the ids of the child groups in order.
+func (ss Subgroups) ChildIDs() []GroupID {
+	gIDs := make([]GroupID, len(ss))
+	for i, s := range ss {
+		gIDs[i] = s.ChildID()
+	}
+	return gIDs
+}
+
+// DescendantIDs returns the ids of the groups nested in the group directly or transitively, sorted by id.
+func (ss Subgroups) DescendantIDs(gID GroupID) []GroupID {
+	return ss.walk([]GroupID{gID}, func(s *Subgroup) (GroupID, GroupID) {
+		return s.ParentID(), s.ChildID()
+	})
+}
+
+// AncestorIDs returns the ids of the groups which any of the groups is nested in directly or transitively,
+// sorted by id.
+func (ss Subgroups) AncestorIDs(gIDs []GroupID) []GroupID {
+	return ss.walk(gIDs, func(s *Subgroup) (GroupID, GroupID) {
+		return s.ChildID(), s.ParentID()
+	})
+}
+
+// walk returns the ids of the groups reached from the groups through the edges, excluding the groups themselves
+// unless they are reached again. It visits each group once, so that it ends even if the subgroups have a cycle.
+func (ss Subgroups) walk(from []GroupID, edge func(s *Subgroup) (GroupID, GroupID)) []GroupID {
+	next := make(map[GroupID][]GroupID)
+	for _, s := range ss {
+		src, dst := edge(s)
+		next[src] = append(next[src], dst)
+	}
+
+	reached := make(map[GroupID]bool)
+	queue := append([]GroupID{}, from...)
+	for len(queue) > 0 {
+		gID := queue[0]
+		queue = queue[1:]
+		for _, n := range next[gID] {
+			if !reached[n] {
+				reached[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+
+	result := make([]GroupID, 0, len(reached))
+	for gID := range reached {
+		result = append(result, gID)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
@@ -0,0 +1,165 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestNewSubgroup(t *testing.T) {
+	tests := []struct {
+		name     string
+		parentID model.GroupID
+		childID  model.GroupID
+		wantErr  error
+	}{
+		{
+			name:     "Returns a subgroup",
+			parentID: "TEST_GROUP_ID_1",
+			childID:  "TEST_GROUP_ID_2",
+		},
+		{
+			name:     "Error the parent group id is empty",
+			parentID: "",
+			childID:  "TEST_GROUP_ID_2",
+			wantErr:  model.ErrInvalidSubgroup,
+		},
+		{
+			name:     "Error the child group id is empty",
+			parentID: "TEST_GROUP_ID_1",
+			childID:  "",
+			wantErr:  model.ErrInvalidSubgroup,
+		},
+		{
+			name:     "Error the group is nested in itself",
+			parentID: "TEST_GROUP_ID_1",
+			childID:  "TEST_GROUP_ID_1",
+			wantErr:  model.ErrGroupCycle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := model.NewSubgroup(tt.parentID, tt.childID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("model.NewSubgroup(%s, %s)=_, %v; want _, %v", tt.parentID, tt.childID, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNestGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		childID     model.GroupID
+		ancestorIDs []model.GroupID
+		wantErr     error
+	}{
+		{
+			name:        "Nests the group",
+			childID:     "TEST_GROUP_ID_2",
+			ancestorIDs: []model.GroupID{"TEST_GROUP_ID_3"},
+		},
+		{
+			name:    "Error the child is the parent",
+			childID: "TEST_GROUP_ID_1",
+			wantErr: model.ErrGroupCycle,
+		},
+		{
+			name:        "Error the child is an ancestor of the parent",
+			childID:     "TEST_GROUP_ID_3",
+			ancestorIDs: []model.GroupID{"TEST_GROUP_ID_2", "TEST_GROUP_ID_3"},
+			wantErr:     model.ErrGroupCycle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := model.NestGroup("TEST_GROUP_ID_1", tt.childID, tt.ancestorIDs)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("model.NestGroup(TEST_GROUP_ID_1, %s, %v)=_, %v; want _, %v", tt.childID, tt.ancestorIDs, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if s.ParentID() != "TEST_GROUP_ID_1" || s.ChildID() != tt.childID {
+				t.Errorf("s=%s/%s; want TEST_GROUP_ID_1/%s", s.ParentID(), s.ChildID(), tt.childID)
+			}
+		})
+	}
+}
+
+func TestSubgroups_DescendantIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		ss   model.Subgroups
+		gID  model.GroupID
+		want []model.GroupID
+	}{
+		{
+			name: "Returns the groups nested directly and transitively",
+			ss: model.Subgroups{
+				model.MustNewSubgroup("TEST_GROUP_ID_1", "TEST_GROUP_ID_3"),
+				model.MustNewSubgroup("TEST_GROUP_ID_3", "TEST_GROUP_ID_2"),
+				model.MustNewSubgroup("TEST_GROUP_ID_4", "TEST_GROUP_ID_5"),
+			},
+			gID:  "TEST_GROUP_ID_1",
+			want: []model.GroupID{"TEST_GROUP_ID_2", "TEST_GROUP_ID_3"},
+		},
+		{
+			name: "Returns a group nested through several paths once",
+			ss: model.Subgroups{
+				model.MustNewSubgroup("TEST_GROUP_ID_1", "TEST_GROUP_ID_2"),
+				model.MustNewSubgroup("TEST_GROUP_ID_1", "TEST_GROUP_ID_3"),
+				model.MustNewSubgroup("TEST_GROUP_ID_2", "TEST_GROUP_ID_4"),
+				model.MustNewSubgroup("TEST_GROUP_ID_3", "TEST_GROUP_ID_4"),
+			},
+			gID:  "TEST_GROUP_ID_1",
+			want: []model.GroupID{"TEST_GROUP_ID_2", "TEST_GROUP_ID_3", "TEST_GROUP_ID_4"},
+		},
+		{
+			name: "Ends even if the subgroups have a cycle",
+			ss: model.Subgroups{
+				model.MustNewSubgroup("TEST_GROUP_ID_1", "TEST_GROUP_ID_2"),
+				model.MustNewSubgroup("TEST_GROUP_ID_2", "TEST_GROUP_ID_1"),
+			},
+			gID:  "TEST_GROUP_ID_1",
+			want: []model.GroupID{"TEST_GROUP_ID_1", "TEST_GROUP_ID_2"},
+		},
+		{
+			name: "Returns no groups if no group is nested",
+			ss: model.Subgroups{
+				model.MustNewSubgroup("TEST_GROUP_ID_2", "TEST_GROUP_ID_1"),
+			},
+			gID:  "TEST_GROUP_ID_1",
+			want: []model.GroupID{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.ss.DescendantIDs(tt.gID)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("ss.DescendantIDs(%s)=%v; want %v\ndiffers: (-got +want)\n%s", tt.gID, got, tt.want, diff)
+			}
+		})
+	}
+}
+
+func TestSubgroups_AncestorIDs(t *testing.T) {
+	ss := model.Subgroups{
+		model.MustNewSubgroup("TEST_GROUP_ID_1", "TEST_GROUP_ID_2"),
+		model.MustNewSubgroup("TEST_GROUP_ID_2", "TEST_GROUP_ID_3"),
+		model.MustNewSubgroup("TEST_GROUP_ID_4", "TEST_GROUP_ID_5"),
+		model.MustNewSubgroup("TEST_GROUP_ID_6", "TEST_GROUP_ID_1"),
+	}
+	gIDs := []model.GroupID{"TEST_GROUP_ID_3", "TEST_GROUP_ID_5"}
+	want := []model.GroupID{"TEST_GROUP_ID_1", "TEST_GROUP_ID_2", "TEST_GROUP_ID_4", "TEST_GROUP_ID_6"}
+
+	got := ss.AncestorIDs(gIDs)
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("ss.AncestorIDs(%v)=%v; want %v\ndiffers: (-got +want)\n%s", gIDs, got, want, diff)
+	}
+}
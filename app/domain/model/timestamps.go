@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// Timestamps are when and by whom a user or a group was created and last updated. The actors are the ones of the
+// requests, which are empty for the requests without one.
+type Timestamps struct {
+	CreatedAt time.Time
+	CreatedBy string
+	UpdatedAt time.Time
+	UpdatedBy string
+}
+
+// NewTimestamps returns the timestamps of what the actor creates at the time, which is also the last update of it.
+func NewTimestamps(actor string, at time.Time) Timestamps {
+	return Timestamps{
+		CreatedAt: at,
+		CreatedBy: actor,
+		UpdatedAt: at,
+		UpdatedBy: actor,
+	}
+}
+
+// Updated returns the timestamps updated by the actor at the time, keeping when and by whom it was created.
+func (ts Timestamps) Updated(actor string, at time.Time) Timestamps {
+	ts.UpdatedAt = at
+	ts.UpdatedBy = actor
+	return ts
+}
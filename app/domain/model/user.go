@@ -6,23 +6,61 @@ import (
 )
 
 var (
-	ErrInvalidUser = errors.New("invalid user")
+	ErrInvalidUser          = errors.New("invalid user")
+	ErrUserStatusTransition = errors.New("invalid user status transition")
 )
 
+// The limits of the fields of a user which the storage allows, and the API schema also declares.
+// UserPolicy limits the name further.
 const (
-	maxUserNameLength  = 30
-	maxUserEmailLength = 254
+	MaxUserNameLength  = 255
+	MaxUserEmailLength = 254
 )
 
 type UserID string
 
+// UserStatus is the state of a user in its lifecycle, which changes only through the transitions of User.
+type UserStatus string
+
+const (
+	UserStatusInvited     UserStatus = "INVITED"
+	UserStatusActive      UserStatus = "ACTIVE"
+	UserStatusSuspended   UserStatus = "SUSPENDED"
+	UserStatusDeactivated UserStatus = "DEACTIVATED"
+)
+
+// DefaultUserStatus is the status of a user created without one.
+const DefaultUserStatus = UserStatusActive
+
+func (s UserStatus) IsValid() bool {
+	switch s {
+	case UserStatusInvited, UserStatusActive, UserStatusSuspended, UserStatusDeactivated:
+		return true
+	}
+	return false
+}
+
+// IsInitial reports whether a user may be created in the status.
+func (s UserStatus) IsInitial() bool {
+	return s == UserStatusInvited || s == UserStatusActive
+}
+
 type User struct {
-	id    UserID
-	name  string
-	email string
+	id         UserID
+	name       string
+	email      string
+	status     UserStatus
+	attributes Attributes
+	timestamps Timestamps
+	events     events
 }
 
 func NewUser(id UserID, name, email string) (*User, error) {
+	return NewUserWithStatus(id, name, email, DefaultUserStatus)
+}
+
+// NewUserWithStatus returns the user in the status, e.g. as stored, without the guard of the transitions.
+func NewUserWithStatus(id UserID, name, email string, status UserStatus) (*User, error) {
 	if id == "" {
 		return nil, fmt.Errorf("user id must not empty: %w", ErrInvalidUser)
 	}
@@ -30,21 +68,26 @@ func NewUser(id UserID, name, email string) (*User, error) {
 	if name == "" {
 		return nil, fmt.Errorf("user name must not empty: %w", ErrInvalidUser)
 	}
-	if len(name) > maxUserNameLength {
+	if len(name) > MaxUserNameLength {
 		return nil, fmt.Errorf("exceeds the max user name length: %w", ErrInvalidUser)
 	}
 
 	if email == "" {
 		return nil, fmt.Errorf("user email must not empty: %w", ErrInvalidUser)
 	}
-	if len(email) > maxUserEmailLength {
-		return nil, fmt.Errorf("exceeds the max user name length: %w", ErrInvalidUser)
+	if len(email) > MaxUserEmailLength {
+		return nil, fmt.Errorf("exceeds the max user email length: %w", ErrInvalidUser)
+	}
+
+	if !status.IsValid() {
+		return nil, fmt.Errorf("invalid user status %q: %w", status, ErrInvalidUser)
 	}
 
 	return &User{
-		id:    id,
-		name:  name,
-		email: email,
+		id:     id,
+		name:   name,
+		email:  email,
+		status: status,
 	}, nil
 }
 
@@ -56,6 +99,14 @@ func MustNewUser(id UserID, name, email string) *User {
 	return u
 }
 
+func MustNewUserWithStatus(id UserID, name, email string, status UserStatus) *User {
+	u, err := NewUserWithStatus(id, name, email, status)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
 func (u *User) ID() UserID {
 	if u == nil {
 		return ""
@@ -77,6 +128,110 @@ func (u *User) Email() string {
 	return u.email
 }
 
+func (u *User) Status() UserStatus {
+	if u == nil {
+		return ""
+	}
+	return u.status
+}
+
+// Attributes returns a copy of the custom attributes of the user.
+func (u *User) Attributes() Attributes {
+	if u == nil {
+		return nil
+	}
+	return u.attributes.clone()
+}
+
+// ChangeAttributes replaces the custom attributes of the user, which the schemas of the users validate beforehand.
+func (u *User) ChangeAttributes(attrs Attributes) {
+	u.attributes = attrs.clone()
+}
+
+// CanJoinGroups reports whether the user may become a member of a group, which a suspended or deactivated user
+// may not.
+func (u *User) CanJoinGroups() bool {
+	return u.Status() == UserStatusActive || u.Status() == UserStatusInvited
+}
+
+// Activate activates the invited user.
+func (u *User) Activate() error {
+	return u.transition(UserStatusActive, UserStatusInvited)
+}
+
+// Suspend suspends the active user, who keeps the memberships but may join no more groups until reactivated.
+func (u *User) Suspend() error {
+	return u.transition(UserStatusSuspended, UserStatusActive)
+}
+
+// Reactivate activates the suspended or deactivated user again.
+func (u *User) Reactivate() error {
+	return u.transition(UserStatusActive, UserStatusSuspended, UserStatusDeactivated)
+}
+
+// Deactivate deactivates the user, who is kept but may join no groups until reactivated.
+func (u *User) Deactivate() error {
+	return u.transition(UserStatusDeactivated, UserStatusInvited, UserStatusActive, UserStatusSuspended)
+}
+
+// transition changes the status of the user to the status if the user is in one of the statuses from,
+// and records the change.
+func (u *User) transition(to UserStatus, from ...UserStatus) error {
+	for _, s := range from {
+		if u.status == s {
+			u.status = to
+			u.events.record(UserStatusChanged{UserID: u.id, Status: to})
+			return nil
+		}
+	}
+	return fmt.Errorf("user %s cannot change from %s to %s: %w", u.id, u.status, to, ErrUserStatusTransition)
+}
+
+// SearchRank ranks the user matching the normalized query by its name or email, lower for more relevant.
+// It reports false if the user does not match the query.
+func (u *User) SearchRank(q string) (int, bool) {
+	if u == nil {
+		return 0, false
+	}
+	return searchRank(q, u.name, u.email)
+}
+
+// Timestamps returns when and by whom the user was created and last updated.
+func (u *User) Timestamps() Timestamps {
+	if u == nil {
+		return Timestamps{}
+	}
+	return u.timestamps
+}
+
+// ChangeTimestamps replaces when and by whom the user was created and last updated, e.g. as stored.
+func (u *User) ChangeTimestamps(ts Timestamps) {
+	u.timestamps = ts
+}
+
+// RecordCreated records that the user has been created.
+func (u *User) RecordCreated() {
+	u.events.record(UserCreated{UserID: u.id, Name: u.name, Email: u.email})
+}
+
+// RecordUpdated records that the user has been updated.
+func (u *User) RecordUpdated() {
+	u.events.record(UserUpdated{UserID: u.id, Name: u.name, Email: u.email})
+}
+
+// RecordDeleted records that the user has been deleted.
+func (u *User) RecordDeleted() {
+	u.events.record(UserDeleted{UserID: u.id})
+}
+
+// PullEvents returns the recorded domain events and clears them.
+func (u *User) PullEvents() []DomainEvent {
+	if u == nil {
+		return nil
+	}
+	return u.events.pull()
+}
+
 type Users []*User
 
 func (us Users) ByUserID() map[UserID]*User {
@@ -28,9 +28,10 @@ func TestNewUser(t *testing.T) {
 				email: "TEST_USER_EMAIL",
 			},
 			want: &User{
-				id:    "TEST_USER_ID",
-				name:  "TEST_USER_NAME",
-				email: "TEST_USER_EMAIL",
+				id:     "TEST_USER_ID",
+				name:   "TEST_USER_NAME",
+				email:  "TEST_USER_EMAIL",
+				status: UserStatusActive,
 			},
 			wantErr: nil,
 		},
@@ -58,7 +59,7 @@ func TestNewUser(t *testing.T) {
 			name: "Error exceeds the max user name length",
 			args: args{
 				id:    "TEST_USER_ID",
-				name:  strings.Repeat("x", maxUserNameLength+1),
+				name:  strings.Repeat("x", MaxUserNameLength+1),
 				email: "TEST_USER_EMAIL",
 			},
 			want:    nil,
@@ -79,7 +80,7 @@ func TestNewUser(t *testing.T) {
 			args: args{
 				id:    "TEST_USER_ID",
 				name:  "TEST_USER_NAME",
-				email: strings.Repeat("x", maxUserEmailLength+1),
+				email: strings.Repeat("x", MaxUserEmailLength+1),
 			},
 			want:    nil,
 			wantErr: ErrInvalidUser,
@@ -252,3 +253,157 @@ func TestUsers_ByUserID(t *testing.T) {
 		})
 	}
 }
+
+func TestNewUserWithStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  UserStatus
+		wantErr error
+	}{
+		{
+			name:   "Returns a suspended user",
+			status: UserStatusSuspended,
+		},
+		{
+			name:    "Error unknown status",
+			status:  "UNKNOWN",
+			wantErr: ErrInvalidUser,
+		},
+		{
+			name:    "Error empty status",
+			status:  "",
+			wantErr: ErrInvalidUser,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := NewUserWithStatus("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL", tt.status)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewUserWithStatus(_, _, _, %s)=_, %v; want _, %v", tt.status, err, tt.wantErr)
+			}
+			if err == nil && u.Status() != tt.status {
+				t.Errorf("u.Status()=%s; want %s", u.Status(), tt.status)
+			}
+		})
+	}
+}
+
+func TestUser_transitions(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     UserStatus
+		transition func(u *User) error
+		want       UserStatus
+		wantErr    error
+	}{
+		{
+			name:       "Activates an invited user",
+			status:     UserStatusInvited,
+			transition: (*User).Activate,
+			want:       UserStatusActive,
+		},
+		{
+			name:       "Error activating an active user",
+			status:     UserStatusActive,
+			transition: (*User).Activate,
+			want:       UserStatusActive,
+			wantErr:    ErrUserStatusTransition,
+		},
+		{
+			name:       "Suspends an active user",
+			status:     UserStatusActive,
+			transition: (*User).Suspend,
+			want:       UserStatusSuspended,
+		},
+		{
+			name:       "Error suspending an invited user",
+			status:     UserStatusInvited,
+			transition: (*User).Suspend,
+			want:       UserStatusInvited,
+			wantErr:    ErrUserStatusTransition,
+		},
+		{
+			name:       "Error suspending a deactivated user",
+			status:     UserStatusDeactivated,
+			transition: (*User).Suspend,
+			want:       UserStatusDeactivated,
+			wantErr:    ErrUserStatusTransition,
+		},
+		{
+			name:       "Reactivates a suspended user",
+			status:     UserStatusSuspended,
+			transition: (*User).Reactivate,
+			want:       UserStatusActive,
+		},
+		{
+			name:       "Reactivates a deactivated user",
+			status:     UserStatusDeactivated,
+			transition: (*User).Reactivate,
+			want:       UserStatusActive,
+		},
+		{
+			name:       "Error reactivating an active user",
+			status:     UserStatusActive,
+			transition: (*User).Reactivate,
+			want:       UserStatusActive,
+			wantErr:    ErrUserStatusTransition,
+		},
+		{
+			name:       "Deactivates a suspended user",
+			status:     UserStatusSuspended,
+			transition: (*User).Deactivate,
+			want:       UserStatusDeactivated,
+		},
+		{
+			name:       "Error deactivating a deactivated user",
+			status:     UserStatusDeactivated,
+			transition: (*User).Deactivate,
+			want:       UserStatusDeactivated,
+			wantErr:    ErrUserStatusTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := MustNewUserWithStatus("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL", tt.status)
+
+			err := tt.transition(u)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("transition=%v; want %v", err, tt.wantErr)
+			}
+			if u.Status() != tt.want {
+				t.Errorf("u.Status()=%s; want %s", u.Status(), tt.want)
+			}
+
+			var want []DomainEvent
+			if tt.wantErr == nil {
+				want = []DomainEvent{UserStatusChanged{UserID: "TEST_USER_ID", Status: tt.want}}
+			}
+			if diff := cmp.Diff(u.PullEvents(), want); diff != "" {
+				t.Errorf("u.PullEvents() differs: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUser_CanJoinGroups(t *testing.T) {
+	tests := []struct {
+		status UserStatus
+		want   bool
+	}{
+		{status: UserStatusInvited, want: true},
+		{status: UserStatusActive, want: true},
+		{status: UserStatusSuspended, want: false},
+		{status: UserStatusDeactivated, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			u := MustNewUserWithStatus("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL", tt.status)
+			if got := u.CanJoinGroups(); got != tt.want {
+				t.Errorf("u.CanJoinGroups()=%t; want %t", got, tt.want)
+			}
+		})
+	}
+}
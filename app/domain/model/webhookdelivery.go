@@ -0,0 +1,202 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidWebhookDelivery = errors.New("invalid webhook delivery")
+)
+
+type WebhookDeliveryID string
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	WebhookDeliveryStatusDead      WebhookDeliveryStatus = "DEAD"
+)
+
+func (s WebhookDeliveryStatus) IsValid() bool {
+	switch s {
+	case WebhookDeliveryStatusPending, WebhookDeliveryStatusSucceeded, WebhookDeliveryStatusDead:
+		return true
+	}
+	return false
+}
+
+// WebhookDeliveryState is the bookkeeping of the attempts to deliver a webhook.
+type WebhookDeliveryState struct {
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	LastStatusCode int
+	LastError      string
+	NextAttemptAt  time.Time
+	DeliveredAt    time.Time
+}
+
+// WebhookDelivery is a domain event to be sent to a webhook subscription.
+type WebhookDelivery struct {
+	id             WebhookDeliveryID
+	subscriptionID WebhookSubscriptionID
+	eventID        OutboxMessageID
+	eventType      DomainEventType
+	payload        []byte
+	occurredAt     time.Time
+	state          WebhookDeliveryState
+}
+
+func NewWebhookDelivery(
+	id WebhookDeliveryID,
+	subscriptionID WebhookSubscriptionID,
+	eventID OutboxMessageID,
+	eventType DomainEventType,
+	payload []byte,
+	occurredAt time.Time,
+	state WebhookDeliveryState,
+) (*WebhookDelivery, error) {
+	if id == "" {
+		return nil, fmt.Errorf("webhook delivery id must not empty: %w", ErrInvalidWebhookDelivery)
+	}
+
+	if subscriptionID == "" {
+		return nil, fmt.Errorf("webhook delivery subscription id must not empty: %w", ErrInvalidWebhookDelivery)
+	}
+
+	if eventID == "" || eventType == "" {
+		return nil, fmt.Errorf("webhook delivery event must not empty: %w", ErrInvalidWebhookDelivery)
+	}
+
+	if occurredAt.IsZero() {
+		return nil, fmt.Errorf("webhook delivery occurred at must not zero: %w", ErrInvalidWebhookDelivery)
+	}
+
+	if state.Status == "" {
+		state.Status = WebhookDeliveryStatusPending
+	}
+	if !state.Status.IsValid() {
+		return nil, fmt.Errorf("unknown webhook delivery status %q: %w", state.Status, ErrInvalidWebhookDelivery)
+	}
+
+	if state.Attempts < 0 {
+		return nil, fmt.Errorf("webhook delivery attempts must not negative: %w", ErrInvalidWebhookDelivery)
+	}
+
+	if state.NextAttemptAt.IsZero() {
+		state.NextAttemptAt = occurredAt
+	}
+
+	return &WebhookDelivery{
+		id:             id,
+		subscriptionID: subscriptionID,
+		eventID:        eventID,
+		eventType:      eventType,
+		payload:        payload,
+		occurredAt:     occurredAt,
+		state:          state,
+	}, nil
+}
+
+func MustNewWebhookDelivery(
+	id WebhookDeliveryID,
+	subscriptionID WebhookSubscriptionID,
+	eventID OutboxMessageID,
+	eventType DomainEventType,
+	payload []byte,
+	occurredAt time.Time,
+	state WebhookDeliveryState,
+) *WebhookDelivery {
+	d, err := NewWebhookDelivery(id, subscriptionID, eventID, eventType, payload, occurredAt, state)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (d *WebhookDelivery) ID() WebhookDeliveryID {
+	if d == nil {
+		return ""
+	}
+	return d.id
+}
+
+func (d *WebhookDelivery) SubscriptionID() WebhookSubscriptionID {
+	if d == nil {
+		return ""
+	}
+	return d.subscriptionID
+}
+
+// EventID is the id of the outbox message the delivery was made from,
+// which lets receivers deduplicate retried deliveries.
+func (d *WebhookDelivery) EventID() OutboxMessageID {
+	if d == nil {
+		return ""
+	}
+	return d.eventID
+}
+
+func (d *WebhookDelivery) EventType() DomainEventType {
+	if d == nil {
+		return ""
+	}
+	return d.eventType
+}
+
+// Payload returns the JSON encoded domain event.
+func (d *WebhookDelivery) Payload() []byte {
+	if d == nil {
+		return nil
+	}
+	return d.payload
+}
+
+func (d *WebhookDelivery) OccurredAt() time.Time {
+	if d == nil {
+		return time.Time{}
+	}
+	return d.occurredAt
+}
+
+func (d *WebhookDelivery) State() WebhookDeliveryState {
+	if d == nil {
+		return WebhookDeliveryState{}
+	}
+	return d.state
+}
+
+// MarkSucceeded records a successful attempt.
+func (d *WebhookDelivery) MarkSucceeded(statusCode int, at time.Time) {
+	d.state.Status = WebhookDeliveryStatusSucceeded
+	d.state.Attempts++
+	d.state.LastStatusCode = statusCode
+	d.state.LastError = ""
+	d.state.DeliveredAt = at
+}
+
+// MarkFailed records a failed attempt and when the delivery should be retried.
+func (d *WebhookDelivery) MarkFailed(statusCode int, err error, retryAt time.Time) {
+	d.state.Attempts++
+	d.state.LastStatusCode = statusCode
+	d.state.LastError = err.Error()
+	d.state.NextAttemptAt = retryAt
+}
+
+// MarkDead records a failed attempt after which the delivery is not retried anymore.
+func (d *WebhookDelivery) MarkDead(statusCode int, err error) {
+	d.state.Status = WebhookDeliveryStatusDead
+	d.state.Attempts++
+	d.state.LastStatusCode = statusCode
+	d.state.LastError = err.Error()
+}
+
+// Redeliver makes the delivery pending again so that it is sent at the given time.
+func (d *WebhookDelivery) Redeliver(at time.Time) {
+	d.state.Status = WebhookDeliveryStatusPending
+	d.state.NextAttemptAt = at
+}
+
+type WebhookDeliveries []*WebhookDelivery
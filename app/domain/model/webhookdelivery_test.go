@@ -0,0 +1,258 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestNewWebhookDelivery(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	type args struct {
+		id             model.WebhookDeliveryID
+		subscriptionID model.WebhookSubscriptionID
+		eventID        model.OutboxMessageID
+		eventType      model.DomainEventType
+		payload        []byte
+		occurredAt     time.Time
+		state          model.WebhookDeliveryState
+	}
+	valid := args{
+		id:             "TEST_WEBHOOK_DELIVERY_ID",
+		subscriptionID: "TEST_WEBHOOK_SUBSCRIPTION_ID",
+		eventID:        "TEST_EVENT_ID",
+		eventType:      model.DomainEventTypeUserCreated,
+		payload:        []byte(`{}`),
+		occurredAt:     occurredAt,
+	}
+	with := func(f func(a *args)) args {
+		a := valid
+		f(&a)
+		return a
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantState model.WebhookDeliveryState
+		wantErr   error
+	}{
+		{
+			name: "Returns pending webhook delivery available at the time it occurred",
+			args: valid,
+			wantState: model.WebhookDeliveryState{
+				Status:        model.WebhookDeliveryStatusPending,
+				NextAttemptAt: occurredAt,
+			},
+		},
+		{
+			name: "Returns webhook delivery of the state",
+			args: with(func(a *args) {
+				a.state = model.WebhookDeliveryState{
+					Status:         model.WebhookDeliveryStatusDead,
+					Attempts:       8,
+					LastStatusCode: 500,
+					LastError:      "an error occurred",
+					NextAttemptAt:  occurredAt.Add(time.Hour),
+				}
+			}),
+			wantState: model.WebhookDeliveryState{
+				Status:         model.WebhookDeliveryStatusDead,
+				Attempts:       8,
+				LastStatusCode: 500,
+				LastError:      "an error occurred",
+				NextAttemptAt:  occurredAt.Add(time.Hour),
+			},
+		},
+		{
+			name:    "Error empty webhook delivery id",
+			args:    with(func(a *args) { a.id = "" }),
+			wantErr: model.ErrInvalidWebhookDelivery,
+		},
+		{
+			name:    "Error empty subscription id",
+			args:    with(func(a *args) { a.subscriptionID = "" }),
+			wantErr: model.ErrInvalidWebhookDelivery,
+		},
+		{
+			name:    "Error empty event id",
+			args:    with(func(a *args) { a.eventID = "" }),
+			wantErr: model.ErrInvalidWebhookDelivery,
+		},
+		{
+			name:    "Error empty event type",
+			args:    with(func(a *args) { a.eventType = "" }),
+			wantErr: model.ErrInvalidWebhookDelivery,
+		},
+		{
+			name:    "Error zero occurred at",
+			args:    with(func(a *args) { a.occurredAt = time.Time{} }),
+			wantErr: model.ErrInvalidWebhookDelivery,
+		},
+		{
+			name:    "Error unknown status",
+			args:    with(func(a *args) { a.state.Status = "UNKNOWN" }),
+			wantErr: model.ErrInvalidWebhookDelivery,
+		},
+		{
+			name:    "Error negative attempts",
+			args:    with(func(a *args) { a.state.Attempts = -1 }),
+			wantErr: model.ErrInvalidWebhookDelivery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := model.NewWebhookDelivery(
+				tt.args.id,
+				tt.args.subscriptionID,
+				tt.args.eventID,
+				tt.args.eventType,
+				tt.args.payload,
+				tt.args.occurredAt,
+				tt.args.state,
+			)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("want an error, but has no error")
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("model.NewWebhookDelivery(%v)=_, %v; want _, %v", tt.args, err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("want no error, but has error %v", err)
+				}
+				if got.ID() != tt.args.id || got.SubscriptionID() != tt.args.subscriptionID ||
+					got.EventID() != tt.args.eventID || got.EventType() != tt.args.eventType ||
+					string(got.Payload()) != string(tt.args.payload) || !got.OccurredAt().Equal(tt.args.occurredAt) {
+					t.Errorf("model.NewWebhookDelivery(%v)=%v, nil; want the delivery of the args", tt.args, got)
+				}
+				if diff := cmp.Diff(got.State(), tt.wantState); diff != "" {
+					t.Errorf("got.State()=%v; want %v\ndiffers: (-got +want)\n%s", got.State(), tt.wantState, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookDelivery_Transitions(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	retryAt := occurredAt.Add(time.Second)
+	deliveredAt := occurredAt.Add(2 * time.Second)
+	redeliverAt := occurredAt.Add(3 * time.Second)
+
+	tests := []struct {
+		name  string
+		state model.WebhookDeliveryState
+		mark  func(d *model.WebhookDelivery)
+		want  model.WebhookDeliveryState
+	}{
+		{
+			name: "MarkFailed schedules a retry and keeps the delivery pending",
+			mark: func(d *model.WebhookDelivery) {
+				d.MarkFailed(500, errors.New("an error occurred"), retryAt)
+			},
+			want: model.WebhookDeliveryState{
+				Status:         model.WebhookDeliveryStatusPending,
+				Attempts:       1,
+				LastStatusCode: 500,
+				LastError:      "an error occurred",
+				NextAttemptAt:  retryAt,
+			},
+		},
+		{
+			name: "MarkSucceeded records the delivery and clears the last error",
+			state: model.WebhookDeliveryState{
+				Attempts:       1,
+				LastStatusCode: 500,
+				LastError:      "an error occurred",
+				NextAttemptAt:  retryAt,
+			},
+			mark: func(d *model.WebhookDelivery) {
+				d.MarkSucceeded(200, deliveredAt)
+			},
+			want: model.WebhookDeliveryState{
+				Status:         model.WebhookDeliveryStatusSucceeded,
+				Attempts:       2,
+				LastStatusCode: 200,
+				NextAttemptAt:  retryAt,
+				DeliveredAt:    deliveredAt,
+			},
+		},
+		{
+			name:  "MarkDead dead-letters the delivery",
+			state: model.WebhookDeliveryState{Attempts: 7, NextAttemptAt: retryAt},
+			mark: func(d *model.WebhookDelivery) {
+				d.MarkDead(0, errors.New("connection refused"))
+			},
+			want: model.WebhookDeliveryState{
+				Status:        model.WebhookDeliveryStatusDead,
+				Attempts:      8,
+				LastError:     "connection refused",
+				NextAttemptAt: retryAt,
+			},
+		},
+		{
+			name: "Redeliver makes a dead delivery pending again and keeps its attempts",
+			state: model.WebhookDeliveryState{
+				Status:         model.WebhookDeliveryStatusDead,
+				Attempts:       8,
+				LastStatusCode: 500,
+				LastError:      "an error occurred",
+				NextAttemptAt:  retryAt,
+			},
+			mark: func(d *model.WebhookDelivery) {
+				d.Redeliver(redeliverAt)
+			},
+			want: model.WebhookDeliveryState{
+				Status:         model.WebhookDeliveryStatusPending,
+				Attempts:       8,
+				LastStatusCode: 500,
+				LastError:      "an error occurred",
+				NextAttemptAt:  redeliverAt,
+			},
+		},
+		{
+			name: "Redeliver makes a succeeded delivery pending again",
+			state: model.WebhookDeliveryState{
+				Status:         model.WebhookDeliveryStatusSucceeded,
+				Attempts:       1,
+				LastStatusCode: 200,
+				NextAttemptAt:  occurredAt,
+				DeliveredAt:    deliveredAt,
+			},
+			mark: func(d *model.WebhookDelivery) {
+				d.Redeliver(redeliverAt)
+			},
+			want: model.WebhookDeliveryState{
+				Status:         model.WebhookDeliveryStatusPending,
+				Attempts:       1,
+				LastStatusCode: 200,
+				NextAttemptAt:  redeliverAt,
+				DeliveredAt:    deliveredAt,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := model.MustNewWebhookDelivery(
+				"TEST_WEBHOOK_DELIVERY_ID",
+				"TEST_WEBHOOK_SUBSCRIPTION_ID",
+				"TEST_EVENT_ID",
+				model.DomainEventTypeUserCreated,
+				[]byte(`{}`),
+				occurredAt,
+				tt.state,
+			)
+			tt.mark(d)
+			if diff := cmp.Diff(d.State(), tt.want); diff != "" {
+				t.Errorf("d.State()=%v; want %v\ndiffers: (-got +want)\n%s", d.State(), tt.want, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+var (
+	ErrInvalidWebhookSubscription = errors.New("invalid webhook subscription")
+)
+
+type WebhookSubscriptionID string
+
+// WebhookSubscription is an endpoint of a downstream system which is notified of the domain events it subscribes to.
+type WebhookSubscription struct {
+	id         WebhookSubscriptionID
+	url        string
+	eventTypes []DomainEventType
+	secret     string
+}
+
+func NewWebhookSubscription(
+	id WebhookSubscriptionID,
+	rawURL string,
+	eventTypes []DomainEventType,
+	secret string,
+) (*WebhookSubscription, error) {
+	if id == "" {
+		return nil, fmt.Errorf("webhook subscription id must not empty: %w", ErrInvalidWebhookSubscription)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("webhook subscription url must be an absolute http(s) url: %w", ErrInvalidWebhookSubscription)
+	}
+
+	if len(eventTypes) == 0 {
+		return nil, fmt.Errorf("webhook subscription event types must not empty: %w", ErrInvalidWebhookSubscription)
+	}
+	for _, t := range eventTypes {
+		if !t.IsValid() {
+			return nil, fmt.Errorf("unknown event type %q: %w", t, ErrInvalidWebhookSubscription)
+		}
+	}
+
+	if secret == "" {
+		return nil, fmt.Errorf("webhook subscription secret must not empty: %w", ErrInvalidWebhookSubscription)
+	}
+
+	return &WebhookSubscription{
+		id:         id,
+		url:        rawURL,
+		eventTypes: eventTypes,
+		secret:     secret,
+	}, nil
+}
+
+func MustNewWebhookSubscription(
+	id WebhookSubscriptionID,
+	rawURL string,
+	eventTypes []DomainEventType,
+	secret string,
+) *WebhookSubscription {
+	s, err := NewWebhookSubscription(id, rawURL, eventTypes, secret)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (s *WebhookSubscription) ID() WebhookSubscriptionID {
+	if s == nil {
+		return ""
+	}
+	return s.id
+}
+
+func (s *WebhookSubscription) URL() string {
+	if s == nil {
+		return ""
+	}
+	return s.url
+}
+
+func (s *WebhookSubscription) EventTypes() []DomainEventType {
+	if s == nil {
+		return nil
+	}
+	return s.eventTypes
+}
+
+// Secret is the key used to sign the deliveries.
+func (s *WebhookSubscription) Secret() string {
+	if s == nil {
+		return ""
+	}
+	return s.secret
+}
+
+func (s *WebhookSubscription) Subscribes(t DomainEventType) bool {
+	if s == nil {
+		return false
+	}
+	for _, et := range s.eventTypes {
+		if et == t {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookSubscriptions []*WebhookSubscription
@@ -0,0 +1,113 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+func TestNewWebhookSubscription(t *testing.T) {
+	type args struct {
+		id         model.WebhookSubscriptionID
+		url        string
+		eventTypes []model.DomainEventType
+		secret     string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *model.WebhookSubscription
+		wantErr error
+	}{
+		{
+			name: "Returns webhook subscription",
+			args: args{
+				id:         "TEST_WEBHOOK_SUBSCRIPTION_ID",
+				url:        "https://example.com/hooks",
+				eventTypes: []model.DomainEventType{model.DomainEventTypeUserCreated},
+				secret:     "TEST_SECRET",
+			},
+			want: model.MustNewWebhookSubscription(
+				"TEST_WEBHOOK_SUBSCRIPTION_ID",
+				"https://example.com/hooks",
+				[]model.DomainEventType{model.DomainEventTypeUserCreated},
+				"TEST_SECRET",
+			),
+			wantErr: nil,
+		},
+		{
+			name: "Error empty webhook subscription id",
+			args: args{
+				url:        "https://example.com/hooks",
+				eventTypes: []model.DomainEventType{model.DomainEventTypeUserCreated},
+				secret:     "TEST_SECRET",
+			},
+			wantErr: model.ErrInvalidWebhookSubscription,
+		},
+		{
+			name: "Error relative url",
+			args: args{
+				id:         "TEST_WEBHOOK_SUBSCRIPTION_ID",
+				url:        "/hooks",
+				eventTypes: []model.DomainEventType{model.DomainEventTypeUserCreated},
+				secret:     "TEST_SECRET",
+			},
+			wantErr: model.ErrInvalidWebhookSubscription,
+		},
+		{
+			name: "Error empty event types",
+			args: args{
+				id:     "TEST_WEBHOOK_SUBSCRIPTION_ID",
+				url:    "https://example.com/hooks",
+				secret: "TEST_SECRET",
+			},
+			wantErr: model.ErrInvalidWebhookSubscription,
+		},
+		{
+			name: "Error unknown event type",
+			args: args{
+				id:         "TEST_WEBHOOK_SUBSCRIPTION_ID",
+				url:        "https://example.com/hooks",
+				eventTypes: []model.DomainEventType{"UserRenamed"},
+				secret:     "TEST_SECRET",
+			},
+			wantErr: model.ErrInvalidWebhookSubscription,
+		},
+		{
+			name: "Error empty secret",
+			args: args{
+				id:         "TEST_WEBHOOK_SUBSCRIPTION_ID",
+				url:        "https://example.com/hooks",
+				eventTypes: []model.DomainEventType{model.DomainEventTypeUserCreated},
+			},
+			wantErr: model.ErrInvalidWebhookSubscription,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := model.NewWebhookSubscription(tt.args.id, tt.args.url, tt.args.eventTypes, tt.args.secret)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("want an error, but has no error")
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("model.NewWebhookSubscription(%v)=_, %v; want _, %v", tt.args, err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("want no error, but has error %v", err)
+				}
+				if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(model.WebhookSubscription{})); diff != "" {
+					t.Errorf(
+						"model.NewWebhookSubscription(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+						tt.args, got, tt.want, diff,
+					)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package repository
+
+import "github.com/toshiykst/go-layerd-architecture/app/domain/model"
+
+type AttributeSchemaListFilter struct {
+	// Target keeps the schemas of the attributes of the target.
+	Target model.AttributeTarget
+}
+
+// AttributeSchemaRepositoryQuery is interface for query methods of attribute schema.
+type AttributeSchemaRepositoryQuery interface {
+	Find(target model.AttributeTarget, key string) (*model.AttributeSchema, error)
+	// List lists the schemas in the order of the target and the key.
+	List(f AttributeSchemaListFilter) (model.AttributeSchemas, error)
+}
+
+// AttributeSchemaRepositoryCommand is interface for query and command methods of attribute schema.
+type AttributeSchemaRepositoryCommand interface {
+	AttributeSchemaRepositoryQuery
+	// Save creates the schema, or replaces the one of the same target and key.
+	Save(s *model.AttributeSchema) error
+	Delete(target model.AttributeTarget, key string) error
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+// AuditEventListFilter narrows audit events down. Zero values are ignored.
+// From is inclusive and To is exclusive.
+type AuditEventListFilter struct {
+	Actor      string
+	TargetType model.AuditTargetType
+	TargetID   string
+	From       time.Time
+	To         time.Time
+}
+
+// AuditEventRepositoryQuery is interface for query methods of audit event.
+type AuditEventRepositoryQuery interface {
+	List(f AuditEventListFilter) (model.AuditEvents, error)
+}
+
+// AuditEventRepositoryCommand is interface for query and command methods of audit event.
+// Audit events are append-only, so it has no methods to update or delete them.
+type AuditEventRepositoryCommand interface {
+	AuditEventRepositoryQuery
+	Create(e *model.AuditEvent) (*model.AuditEvent, error)
+}
@@ -5,7 +5,27 @@ import (
 )
 
 type GroupListFilter struct {
-	UserIDs []model.UserID
+	GroupIDs []model.GroupID
+	UserIDs  []model.UserID
+	// AllUserIDs keeps the groups which every one of the users belongs to.
+	AllUserIDs []model.UserID
+	// NoUsers keeps the groups which no user belongs to.
+	NoUsers bool
+	// MinUsers keeps the groups with at least the number of users, e.g. the capacity for the groups at capacity.
+	MinUsers int
+	// Attributes keeps the groups whose custom attributes have all the values, compared in their string form.
+	Attributes map[string]string
+	// LabelSelector keeps the groups whose labels meet all the requirements of it.
+	LabelSelector model.LabelSelector
+	// Query keeps the groups whose name contains the normalized query, case-insensitively, and orders them
+	// by their search rank and then by id.
+	Query string
+	// Sort orders the groups by their timestamps and then by id, taking precedence over the other orders.
+	Sort model.SortOrder
+	// AfterID keeps the groups whose id is greater than it, to page through the groups in the order of id.
+	AfterID model.GroupID
+	// Limit lists at most the number of the groups in the order of id.
+	Limit int
 }
 
 // GroupRepositoryQuery is interface for query methods of group.
@@ -17,10 +37,16 @@ type GroupRepositoryQuery interface {
 // GroupRepositoryCommand is interface for query and command methods of group.
 type GroupRepositoryCommand interface {
 	GroupRepositoryQuery
+	// FindForUpdate finds the group as Find does, locking it until the transaction ends, so that the changes
+	// of the group and its waitlist read from it run one after another. It must be called before any other read
+	// of the transaction, so that the reads see the changes committed before the lock is taken.
+	FindForUpdate(gID model.GroupID) (*model.Group, error)
 	Create(g *model.Group) (*model.Group, error)
 	Update(g *model.Group) error
 	Delete(g *model.Group) error
 	AddUsers(gID model.GroupID, uIDs []model.UserID) error
 	RemoveUsers(gID model.GroupID, uIDs []model.UserID) error
 	RemoveUsersFromAll(uIDs []model.UserID) error
+	// ReplaceLabels replaces all the labels of the group with the labels.
+	ReplaceLabels(gID model.GroupID, ls model.Labels) error
 }
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+// GroupInvitationListFilter narrows group invitations down. Zero values are ignored.
+type GroupInvitationListFilter struct {
+	GroupID model.GroupID
+	// InviteeUserID and InviteeEmail match the invitations for either of them.
+	// The email is compared case-insensitively.
+	InviteeUserID model.UserID
+	InviteeEmail  string
+	Status        model.GroupInvitationStatus
+	ExpiresAfter  time.Time
+}
+
+// GroupInvitationRepositoryQuery is interface for query methods of group invitation.
+type GroupInvitationRepositoryQuery interface {
+	Find(id model.GroupInvitationID) (*model.GroupInvitation, error)
+	List(f GroupInvitationListFilter) (model.GroupInvitations, error)
+}
+
+// GroupInvitationRepositoryCommand is interface for query and command methods of group invitation.
+type GroupInvitationRepositoryCommand interface {
+	GroupInvitationRepositoryQuery
+	Create(inv *model.GroupInvitation) error
+	Update(inv *model.GroupInvitation) error
+	// ExpirePending marks the pending invitations which have expired at the time as expired,
+	// and returns the number of them.
+	ExpirePending(at time.Time) (int, error)
+	DeleteByGroupID(gID model.GroupID) error
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+// GroupJoinRequestListFilter narrows group join requests down. Zero values are ignored.
+type GroupJoinRequestListFilter struct {
+	GroupID model.GroupID
+	UserID  model.UserID
+	Status  model.GroupJoinRequestStatus
+}
+
+// GroupJoinRequestRepositoryQuery is interface for query methods of group join request.
+type GroupJoinRequestRepositoryQuery interface {
+	Find(id model.GroupJoinRequestID) (*model.GroupJoinRequest, error)
+	List(f GroupJoinRequestListFilter) (model.GroupJoinRequests, error)
+}
+
+// GroupJoinRequestRepositoryCommand is interface for query and command methods of group join request.
+type GroupJoinRequestRepositoryCommand interface {
+	GroupJoinRequestRepositoryQuery
+	Create(jr *model.GroupJoinRequest) error
+	Update(jr *model.GroupJoinRequest) error
+	DeleteByGroupID(gID model.GroupID) error
+}
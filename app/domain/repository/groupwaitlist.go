@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+// GroupWaitlistRepositoryQuery is interface for query methods of group waitlist.
+type GroupWaitlistRepositoryQuery interface {
+	// Find returns the waitlist of the group, which is empty if no user is waitlisted.
+	Find(gID model.GroupID) (*model.GroupWaitlist, error)
+}
+
+// GroupWaitlistRepositoryCommand is interface for query and command methods of group waitlist.
+type GroupWaitlistRepositoryCommand interface {
+	GroupWaitlistRepositoryQuery
+	// Save replaces the waitlisted users of the group with the ones of the waitlist in order.
+	Save(w *model.GroupWaitlist) error
+	Delete(gID model.GroupID) error
+	RemoveUsersFromAll(uIDs []model.UserID) error
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+// ErrIdempotencyKeyExists is returned by Create if the key has been created, e.g. by a concurrent request.
+var ErrIdempotencyKeyExists = errors.New("idempotency key exists")
+
+// IdempotencyKeyRepositoryQuery is interface for query methods of idempotency key.
+type IdempotencyKeyRepositoryQuery interface {
+	Find(key string) (*model.IdempotencyKey, error)
+}
+
+// IdempotencyKeyRepositoryCommand is interface for query and command methods of idempotency key.
+type IdempotencyKeyRepositoryCommand interface {
+	IdempotencyKeyRepositoryQuery
+	// Create returns ErrIdempotencyKeyExists if the key exists.
+	Create(k *model.IdempotencyKey) error
+	Update(k *model.IdempotencyKey) error
+	Delete(key string) error
+	// DeleteExpired deletes the keys expired at the time, and returns the number of them.
+	DeleteExpired(at time.Time) (int, error)
+}
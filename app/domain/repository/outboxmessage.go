@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+// OutboxMessageListFilter narrows outbox messages down. Zero values are ignored.
+type OutboxMessageListFilter struct {
+	// Unpublished keeps the messages which have not been published yet.
+	Unpublished bool
+	// AvailableAt keeps the messages whose next attempt is due at the time.
+	AvailableAt time.Time
+	// Sequenced keeps the messages which have been sequenced for publishing, listing them in that order.
+	Sequenced bool
+	// AfterID keeps the messages which were sequenced after the message of the id, as Sequenced does.
+	// Nothing is kept if the message does not exist or has not been sequenced.
+	AfterID       model.OutboxMessageID
+	AggregateType model.AggregateType
+	AggregateID   string
+	Limit         int
+}
+
+// OutboxMessageRepositoryQuery is interface for query methods of outbox message.
+// Messages are listed in the order they were recorded, rather than by the time they occurred,
+// which ties among the messages of a transaction. The order is taken before the commit,
+// so a message may become visible after the ones recorded later; a reader resuming after a message
+// lists the messages in the order they were sequenced, which is taken after the commit.
+type OutboxMessageRepositoryQuery interface {
+	List(f OutboxMessageListFilter) (model.OutboxMessages, error)
+}
+
+// OutboxMessageRepositoryCommand is interface for query and command methods of outbox message.
+type OutboxMessageRepositoryCommand interface {
+	OutboxMessageRepositoryQuery
+	Create(ms model.OutboxMessages) error
+	Update(m *model.OutboxMessage) error
+	// Sequence gives the messages the next publish sequences in order, keeping the ones already given.
+	// The sequences are given one transaction at a time, so they follow the order of the commits.
+	Sequence(ms model.OutboxMessages) error
+	// DeletePublished deletes the messages published at or before the time, and returns the number of them.
+	DeletePublished(at time.Time) (int, error)
+}
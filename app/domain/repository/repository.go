@@ -5,9 +5,81 @@ type Repository interface {
 
 	User() UserRepositoryQuery
 	Group() GroupRepositoryQuery
+	GroupWaitlist() GroupWaitlistRepositoryQuery
+	GroupInvitation() GroupInvitationRepositoryQuery
+	GroupJoinRequest() GroupJoinRequestRepositoryQuery
+	Subgroup() SubgroupRepositoryQuery
+	AuditEvent() AuditEventRepositoryQuery
+	OutboxMessage() OutboxMessageRepositoryQuery
+	WebhookSubscription() WebhookSubscriptionRepositoryQuery
+	WebhookDelivery() WebhookDeliveryRepositoryQuery
+	IdempotencyKey() IdempotencyKeyRepositoryQuery
+	AttributeSchema() AttributeSchemaRepositoryQuery
 }
 
 type Transaction interface {
 	User() UserRepositoryCommand
 	Group() GroupRepositoryCommand
+	GroupWaitlist() GroupWaitlistRepositoryCommand
+	GroupInvitation() GroupInvitationRepositoryCommand
+	GroupJoinRequest() GroupJoinRequestRepositoryCommand
+	Subgroup() SubgroupRepositoryCommand
+	AuditEvent() AuditEventRepositoryCommand
+	OutboxMessage() OutboxMessageRepositoryCommand
+	WebhookSubscription() WebhookSubscriptionRepositoryCommand
+	WebhookDelivery() WebhookDeliveryRepositoryCommand
+	IdempotencyKey() IdempotencyKeyRepositoryCommand
+	AttributeSchema() AttributeSchemaRepositoryCommand
+}
+
+// InTransaction returns a repository which queries and commands in the transaction.
+// Its RunTransaction runs in the transaction as well, so that the usecases built on it compose
+// into the transaction, which is committed or rolled back as a whole.
+func InTransaction(tx Transaction) Repository {
+	return &txRepository{tx: tx}
+}
+
+type txRepository struct {
+	tx Transaction
+}
+
+func (r *txRepository) RunTransaction(f func(Transaction) error) error {
+	return f(r.tx)
+}
+
+func (r *txRepository) User() UserRepositoryQuery {
+	return r.tx.User()
+}
+func (r *txRepository) Group() GroupRepositoryQuery {
+	return r.tx.Group()
+}
+func (r *txRepository) GroupWaitlist() GroupWaitlistRepositoryQuery {
+	return r.tx.GroupWaitlist()
+}
+func (r *txRepository) GroupInvitation() GroupInvitationRepositoryQuery {
+	return r.tx.GroupInvitation()
+}
+func (r *txRepository) GroupJoinRequest() GroupJoinRequestRepositoryQuery {
+	return r.tx.GroupJoinRequest()
+}
+func (r *txRepository) Subgroup() SubgroupRepositoryQuery {
+	return r.tx.Subgroup()
+}
+func (r *txRepository) AuditEvent() AuditEventRepositoryQuery {
+	return r.tx.AuditEvent()
+}
+func (r *txRepository) OutboxMessage() OutboxMessageRepositoryQuery {
+	return r.tx.OutboxMessage()
+}
+func (r *txRepository) WebhookSubscription() WebhookSubscriptionRepositoryQuery {
+	return r.tx.WebhookSubscription()
+}
+func (r *txRepository) WebhookDelivery() WebhookDeliveryRepositoryQuery {
+	return r.tx.WebhookDelivery()
+}
+func (r *txRepository) IdempotencyKey() IdempotencyKeyRepositoryQuery {
+	return r.tx.IdempotencyKey()
+}
+func (r *txRepository) AttributeSchema() AttributeSchemaRepositoryQuery {
+	return r.tx.AttributeSchema()
 }
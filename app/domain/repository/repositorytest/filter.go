@@ -0,0 +1,292 @@
+package repositorytest
+
+import (
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+)
+
+// filterFixtures creates the users and the groups of the filter tests in the transaction.
+// The first group has the first two users, the second one the first and the third users, the third one no users
+// and the fourth one the first five users. The last user belongs to no group and is suspended.
+// The second and the third users and the third group have custom attributes, and the first, the second and the fourth
+// groups have labels. The users and the groups are created a minute apart in order within the last hour, and the
+// first user is updated last.
+func filterFixtures(tx repository.Transaction) (model.Users, model.Groups, error) {
+	users := model.Users{
+		model.MustNewUser("TEST_FILTER_USER_1", "Filter 1", "filter1@example.com"),
+		model.MustNewUser("TEST_FILTER_USER_2", "Filter 2", "filter2@example.com"),
+		model.MustNewUser("TEST_FILTER_USER_3", "Filter 3", "filter3@example.com"),
+		model.MustNewUser("TEST_FILTER_USER_4", "Filter 4", "filter4@example.com"),
+		model.MustNewUser("TEST_FILTER_USER_5", "Filter 5", "filter5@example.com"),
+		model.MustNewUserWithStatus("TEST_FILTER_USER_6", "Filter 6", "filter6@example.com", model.UserStatusSuspended),
+	}
+	groups := model.Groups{
+		model.MustNewGroup("TEST_FILTER_GROUP_1", "Filter 1", []model.UserID{users[0].ID(), users[1].ID()}),
+		model.MustNewGroup("TEST_FILTER_GROUP_2", "Filter 2", []model.UserID{users[0].ID(), users[2].ID()}),
+		model.MustNewGroup("TEST_FILTER_GROUP_3", "Filter 3", nil),
+		model.MustNewGroup("TEST_FILTER_GROUP_4", "Filter 4", []model.UserID{
+			users[0].ID(), users[1].ID(), users[2].ID(), users[3].ID(), users[4].ID(),
+		}),
+	}
+	users[1].ChangeAttributes(model.Attributes{"department": "sales", "level": 2.0})
+	users[2].ChangeAttributes(model.Attributes{"department": "engineering", "remote": true})
+	groups[2].ChangeAttributes(model.Attributes{"costCenter": "CC-1"})
+	for i, ls := range map[int]model.Labels{
+		0: {"team": "payments", "env": "prod"},
+		1: {"team": "search", "env": "staging"},
+		3: {"team": "payments"},
+	} {
+		if err := groups[i].ChangeLabels(ls); err != nil {
+			return nil, nil, err
+		}
+	}
+	now := time.Now().Truncate(time.Second)
+	for i, u := range users {
+		u.ChangeTimestamps(model.NewTimestamps("", now.Add(time.Duration(i-len(users))*time.Minute)))
+	}
+	users[0].ChangeTimestamps(users[0].Timestamps().Updated("", now))
+	for i, g := range groups {
+		g.ChangeTimestamps(model.NewTimestamps("", now.Add(time.Duration(i-len(groups))*time.Minute)))
+	}
+	for _, u := range users {
+		if _, err := tx.User().Create(u); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, g := range groups {
+		if _, err := tx.Group().Create(g); err != nil {
+			return nil, nil, err
+		}
+	}
+	return users, groups, nil
+}
+
+// RunUserFilterTests tests the filters of the users by their groups, their status, their attributes and their creation.
+// The users of the tests are created in a transaction which is rolled back.
+func RunUserFilterTests(t *testing.T, r repository.Repository) {
+	t.Helper()
+
+	now := time.Now()
+	tests := []struct {
+		name   string
+		filter repository.UserListFilter
+		// ordered keeps the order of the users listed, which are sorted by id otherwise.
+		ordered bool
+		want    []model.UserID
+	}{
+		{
+			name:   "Keeps the users in no group",
+			filter: repository.UserListFilter{NoGroup: true},
+			want:   []model.UserID{"TEST_FILTER_USER_6"},
+		},
+		{
+			name:   "Keeps the users in the status",
+			filter: repository.UserListFilter{Status: model.UserStatusSuspended},
+			want:   []model.UserID{"TEST_FILTER_USER_6"},
+		},
+		{
+			name:   "Keeps no users if no user is in the status",
+			filter: repository.UserListFilter{Status: model.UserStatusDeactivated},
+			want:   nil,
+		},
+		{
+			name:   "Keeps the users with all the attribute values",
+			filter: repository.UserListFilter{Attributes: map[string]string{"department": "sales", "level": "2"}},
+			want:   []model.UserID{"TEST_FILTER_USER_2"},
+		},
+		{
+			name:   "Keeps the users with the boolean attribute value",
+			filter: repository.UserListFilter{Attributes: map[string]string{"remote": "true"}},
+			want:   []model.UserID{"TEST_FILTER_USER_3"},
+		},
+		{
+			name:   "Keeps no users if no user has the attribute value",
+			filter: repository.UserListFilter{Attributes: map[string]string{"department": "sales", "level": "3"}},
+			want:   nil,
+		},
+		{
+			name:   "Keeps the users created after the time",
+			filter: repository.UserListFilter{CreatedAfter: now.Add(-time.Hour)},
+			want: []model.UserID{
+				"TEST_FILTER_USER_1",
+				"TEST_FILTER_USER_2",
+				"TEST_FILTER_USER_3",
+				"TEST_FILTER_USER_4",
+				"TEST_FILTER_USER_5",
+				"TEST_FILTER_USER_6",
+			},
+		},
+		{
+			name:   "Drops the users created before the time",
+			filter: repository.UserListFilter{CreatedAfter: now.Add(time.Hour)},
+			want:   nil,
+		},
+		{
+			name:    "Sorts the users by the time they were updated in descending order",
+			filter:  repository.UserListFilter{Sort: model.SortOrder{Field: model.SortFieldUpdatedAt, Desc: true}},
+			ordered: true,
+			want: []model.UserID{
+				"TEST_FILTER_USER_1",
+				"TEST_FILTER_USER_6",
+				"TEST_FILTER_USER_5",
+				"TEST_FILTER_USER_4",
+				"TEST_FILTER_USER_3",
+				"TEST_FILTER_USER_2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []model.UserID
+			err := r.RunTransaction(func(tx repository.Transaction) error {
+				users, _, err := filterFixtures(tx)
+				if err != nil {
+					return err
+				}
+				us, err := tx.User().List(tt.filter)
+				if err != nil {
+					return err
+				}
+				got = fixtureUserIDs(us, users)
+				if !tt.ordered {
+					sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+				}
+				return errRollback
+			})
+			if !errors.Is(err, errRollback) {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("r.User().List(%+v)=%v; want %v\ndiffers: (-got +want)\n%s", tt.filter, got, tt.want, diff)
+			}
+		})
+	}
+}
+
+// RunGroupFilterTests tests the filters of the groups by their members, their attributes and their labels.
+// The groups of the tests are created in a transaction which is rolled back.
+func RunGroupFilterTests(t *testing.T, r repository.Repository) {
+	t.Helper()
+
+	tests := []struct {
+		name   string
+		filter repository.GroupListFilter
+		// ordered keeps the order of the groups listed, which are sorted by id otherwise.
+		ordered bool
+		want    []model.GroupID
+	}{
+		{
+			name:   "Keeps the groups which all the users belong to",
+			filter: repository.GroupListFilter{AllUserIDs: []model.UserID{"TEST_FILTER_USER_1", "TEST_FILTER_USER_2"}},
+			want:   []model.GroupID{"TEST_FILTER_GROUP_1", "TEST_FILTER_GROUP_4"},
+		},
+		{
+			name:   "Counts a user given twice once",
+			filter: repository.GroupListFilter{AllUserIDs: []model.UserID{"TEST_FILTER_USER_3", "TEST_FILTER_USER_3"}},
+			want:   []model.GroupID{"TEST_FILTER_GROUP_2", "TEST_FILTER_GROUP_4"},
+		},
+		{
+			name:   "Keeps the groups with the attribute value",
+			filter: repository.GroupListFilter{Attributes: map[string]string{"costCenter": "CC-1"}},
+			want:   []model.GroupID{"TEST_FILTER_GROUP_3"},
+		},
+		{
+			name:   "Keeps the groups with no users",
+			filter: repository.GroupListFilter{NoUsers: true},
+			want:   []model.GroupID{"TEST_FILTER_GROUP_3"},
+		},
+		{
+			name:   "Keeps the groups with at least the number of users",
+			filter: repository.GroupListFilter{MinUsers: 5},
+			want:   []model.GroupID{"TEST_FILTER_GROUP_4"},
+		},
+		{
+			name:   "Keeps the groups with the label value",
+			filter: repository.GroupListFilter{LabelSelector: mustParseLabelSelector("team=payments")},
+			want:   []model.GroupID{"TEST_FILTER_GROUP_1", "TEST_FILTER_GROUP_4"},
+		},
+		{
+			name:   "Keeps the groups without the label value, including the ones without the label",
+			filter: repository.GroupListFilter{LabelSelector: mustParseLabelSelector("env!=prod")},
+			want:   []model.GroupID{"TEST_FILTER_GROUP_2", "TEST_FILTER_GROUP_3", "TEST_FILTER_GROUP_4"},
+		},
+		{
+			name:   "Keeps the groups with one of the label values and the label",
+			filter: repository.GroupListFilter{LabelSelector: mustParseLabelSelector("team in (payments, search),env")},
+			want:   []model.GroupID{"TEST_FILTER_GROUP_1", "TEST_FILTER_GROUP_2"},
+		},
+		{
+			name:   "Keeps the groups with none of the label values",
+			filter: repository.GroupListFilter{LabelSelector: mustParseLabelSelector("team notin (payments)")},
+			want:   []model.GroupID{"TEST_FILTER_GROUP_2", "TEST_FILTER_GROUP_3"},
+		},
+		{
+			name:   "Keeps the groups without the label",
+			filter: repository.GroupListFilter{LabelSelector: mustParseLabelSelector("!env")},
+			want:   []model.GroupID{"TEST_FILTER_GROUP_3", "TEST_FILTER_GROUP_4"},
+		},
+		{
+			name: "Sorts the groups by the time they were created",
+			filter: repository.GroupListFilter{
+				LabelSelector: mustParseLabelSelector("team"),
+				Sort:          model.SortOrder{Field: model.SortFieldCreatedAt},
+			},
+			ordered: true,
+			want:    []model.GroupID{"TEST_FILTER_GROUP_1", "TEST_FILTER_GROUP_2", "TEST_FILTER_GROUP_4"},
+		},
+		{
+			name:    "Sorts the groups by the time they were created in descending order",
+			filter:  repository.GroupListFilter{Sort: model.SortOrder{Field: model.SortFieldCreatedAt, Desc: true}},
+			ordered: true,
+			want: []model.GroupID{
+				"TEST_FILTER_GROUP_4",
+				"TEST_FILTER_GROUP_3",
+				"TEST_FILTER_GROUP_2",
+				"TEST_FILTER_GROUP_1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []model.GroupID
+			err := r.RunTransaction(func(tx repository.Transaction) error {
+				_, groups, err := filterFixtures(tx)
+				if err != nil {
+					return err
+				}
+				gs, err := tx.Group().List(tt.filter)
+				if err != nil {
+					return err
+				}
+				got = fixtureGroupIDs(gs, groups)
+				if !tt.ordered {
+					sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+				}
+				return errRollback
+			})
+			if !errors.Is(err, errRollback) {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("r.Group().List(%+v)=%v; want %v\ndiffers: (-got +want)\n%s", tt.filter, got, tt.want, diff)
+			}
+		})
+	}
+}
+
+func mustParseLabelSelector(s string) model.LabelSelector {
+	sel, err := model.ParseLabelSelector(s)
+	if err != nil {
+		panic(err)
+	}
+	return sel
+}
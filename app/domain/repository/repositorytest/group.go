@@ -0,0 +1,101 @@
+package repositorytest
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+)
+
+// RunGroupMembershipTests tests that removing users from all the groups and deleting a group change every group
+// they concern and leave the others as they are.
+// The users and the groups of the tests are created in a transaction which is rolled back.
+func RunGroupMembershipTests(t *testing.T, r repository.Repository) {
+	t.Helper()
+
+	users := model.Users{
+		model.MustNewUser("TEST_MEMBERSHIP_USER_1", "Membership 1", "membership1@example.com"),
+		model.MustNewUser("TEST_MEMBERSHIP_USER_2", "Membership 2", "membership2@example.com"),
+		model.MustNewUser("TEST_MEMBERSHIP_USER_3", "Membership 3", "membership3@example.com"),
+	}
+	groups := func() model.Groups {
+		return model.Groups{
+			model.MustNewGroup("TEST_MEMBERSHIP_GROUP_1", "Membership 1", []model.UserID{users[0].ID(), users[1].ID()}),
+			model.MustNewGroup("TEST_MEMBERSHIP_GROUP_2", "Membership 2", []model.UserID{users[0].ID(), users[2].ID()}),
+			model.MustNewGroup("TEST_MEMBERSHIP_GROUP_3", "Membership 3", []model.UserID{users[0].ID()}),
+		}
+	}
+	gIDs := []model.GroupID{"TEST_MEMBERSHIP_GROUP_1", "TEST_MEMBERSHIP_GROUP_2", "TEST_MEMBERSHIP_GROUP_3"}
+
+	tests := []struct {
+		name   string
+		change func(tx repository.Transaction, gs model.Groups) error
+		want   map[model.GroupID][]model.UserID
+	}{
+		{
+			name: "Removes the users from every group",
+			change: func(tx repository.Transaction, _ model.Groups) error {
+				return tx.Group().RemoveUsersFromAll([]model.UserID{users[0].ID()})
+			},
+			want: map[model.GroupID][]model.UserID{
+				"TEST_MEMBERSHIP_GROUP_1": {users[1].ID()},
+				"TEST_MEMBERSHIP_GROUP_2": {users[2].ID()},
+				"TEST_MEMBERSHIP_GROUP_3": {},
+			},
+		},
+		{
+			name: "Deletes the group and keeps the others",
+			change: func(tx repository.Transaction, gs model.Groups) error {
+				return tx.Group().Delete(gs[1])
+			},
+			want: map[model.GroupID][]model.UserID{
+				"TEST_MEMBERSHIP_GROUP_1": {users[0].ID(), users[1].ID()},
+				"TEST_MEMBERSHIP_GROUP_3": {users[0].ID()},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[model.GroupID][]model.UserID{}
+			err := r.RunTransaction(func(tx repository.Transaction) error {
+				for _, u := range users {
+					if _, err := tx.User().Create(u); err != nil {
+						return err
+					}
+				}
+				gs := groups()
+				for _, g := range gs {
+					if _, err := tx.Group().Create(g); err != nil {
+						return err
+					}
+				}
+
+				if err := tt.change(tx, gs); err != nil {
+					return err
+				}
+
+				listed, err := tx.Group().List(repository.GroupListFilter{GroupIDs: gIDs})
+				if err != nil {
+					return err
+				}
+				for _, g := range listed {
+					uIDs := append([]model.UserID{}, g.UserIDs()...)
+					sort.Slice(uIDs, func(i, j int) bool { return uIDs[i] < uIDs[j] })
+					got[g.ID()] = uIDs
+				}
+				return errRollback
+			})
+			if !errors.Is(err, errRollback) {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("r.Group().List()=%v; want %v\ndiffers: (-got +want)\n%s", got, tt.want, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package repositorytest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+)
+
+// RunOutboxMessageOrderTests tests that the outbox messages are listed in the order they were recorded
+// even if they occurred at the same time, and resumed after a message in the order they were sequenced.
+// The messages of the tests are created in a transaction which is rolled back.
+func RunOutboxMessageOrderTests(t *testing.T, r repository.Repository) {
+	t.Helper()
+
+	occurredAt := time.Now().Truncate(time.Second)
+	// The ids descend so that ordering by the id instead of the sequence fails the tests.
+	batches := []model.OutboxMessages{
+		{newOrderTestMessage("TEST_ORDER_MESSAGE_4", occurredAt)},
+		{
+			newOrderTestMessage("TEST_ORDER_MESSAGE_3", occurredAt),
+			newOrderTestMessage("TEST_ORDER_MESSAGE_2", occurredAt),
+		},
+		// A message committed later may have been stamped earlier.
+		{newOrderTestMessage("TEST_ORDER_MESSAGE_1", occurredAt.Add(-time.Second))},
+	}
+	// A message recorded earlier may be committed, and so sequenced, later.
+	// TEST_ORDER_MESSAGE_3 is sequenced twice, keeping the first sequence, and TEST_ORDER_MESSAGE_4 is not.
+	sequences := []model.OutboxMessages{
+		{batches[1][1], batches[2][0]},
+		{batches[1][0], batches[1][1]},
+	}
+	tests := []struct {
+		name      string
+		sequenced bool
+		afterID   model.OutboxMessageID
+		want      []model.OutboxMessageID
+	}{
+		{
+			name: "Lists the messages in the order they were recorded",
+			want: []model.OutboxMessageID{
+				"TEST_ORDER_MESSAGE_4",
+				"TEST_ORDER_MESSAGE_3",
+				"TEST_ORDER_MESSAGE_2",
+				"TEST_ORDER_MESSAGE_1",
+			},
+		},
+		{
+			name:      "Lists the sequenced messages in the order they were sequenced",
+			sequenced: true,
+			want: []model.OutboxMessageID{
+				"TEST_ORDER_MESSAGE_2",
+				"TEST_ORDER_MESSAGE_1",
+				"TEST_ORDER_MESSAGE_3",
+			},
+		},
+		{
+			name:    "Lists the messages sequenced after the message",
+			afterID: "TEST_ORDER_MESSAGE_2",
+			want:    []model.OutboxMessageID{"TEST_ORDER_MESSAGE_1", "TEST_ORDER_MESSAGE_3"},
+		},
+		{
+			name:    "Lists no messages after a message which has not been sequenced",
+			afterID: "TEST_ORDER_MESSAGE_4",
+			want:    nil,
+		},
+		{
+			name:    "Lists no messages after an unknown message",
+			afterID: "TEST_ORDER_MESSAGE_UNKNOWN",
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []model.OutboxMessageID
+			err := r.RunTransaction(func(tx repository.Transaction) error {
+				for _, ms := range batches {
+					if err := tx.OutboxMessage().Create(ms); err != nil {
+						return err
+					}
+				}
+				for _, ms := range sequences {
+					if err := tx.OutboxMessage().Sequence(ms); err != nil {
+						return err
+					}
+				}
+				ms, err := tx.OutboxMessage().List(repository.OutboxMessageListFilter{
+					Sequenced:     tt.sequenced,
+					AfterID:       tt.afterID,
+					AggregateType: model.AggregateTypeUser,
+					AggregateID:   "TEST_ORDER_USER",
+				})
+				if err != nil {
+					return err
+				}
+				for _, m := range ms {
+					got = append(got, m.ID())
+				}
+				return errRollback
+			})
+			if !errors.Is(err, errRollback) {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(
+					"r.OutboxMessage().List(%t, %q)=%v; want %v\ndiffers: (-got +want)\n%s",
+					tt.sequenced, tt.afterID, got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
+
+func newOrderTestMessage(id model.OutboxMessageID, occurredAt time.Time) *model.OutboxMessage {
+	return model.MustNewOutboxMessage(
+		id,
+		model.DomainEventTypeUserUpdated,
+		model.AggregateTypeUser,
+		"TEST_ORDER_USER",
+		[]byte(`{"userId":"TEST_ORDER_USER"}`),
+		occurredAt,
+		model.OutboxDelivery{},
+	)
+}
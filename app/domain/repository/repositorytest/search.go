@@ -0,0 +1,183 @@
+// Package repositorytest provides the tests every implementation of the repositories must pass,
+// so that the implementations agree on the semantics.
+package repositorytest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+)
+
+// errRollback rolls the transaction of a test back, so that a test leaves no data behind.
+var errRollback = errors.New("rollback")
+
+// RunUserSearchTests tests the search of the users by UserListFilter.Query.
+// The users of the tests are created in a transaction which is rolled back.
+func RunUserSearchTests(t *testing.T, r repository.Repository) {
+	t.Helper()
+
+	users := model.Users{
+		model.MustNewUser("TEST_SEARCH_USER_1", "Ann Searchtest", "ann@example.com"),
+		model.MustNewUser("TEST_SEARCH_USER_2", "Searchtest Bob", "bob@example.com"),
+		model.MustNewUser("TEST_SEARCH_USER_3", "Carol", "searchtest.carol@example.com"),
+		model.MustNewUser("TEST_SEARCH_USER_4", "Dave", "dave@searchtest.example.com"),
+		model.MustNewUser("TEST_SEARCH_USER_5", "SearchTest", "eve@example.com"),
+		model.MustNewUser("TEST_SEARCH_USER_6", "Frank", "frank@example.com"),
+		model.MustNewUser("TEST_SEARCH_USER_7", "Searchtëst Grace", "grace@example.com"),
+	}
+	for _, u := range users {
+		u.ChangeTimestamps(model.NewTimestamps("", time.Now().Truncate(time.Second)))
+	}
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []model.UserID
+	}{
+		{
+			name:  "Orders the users by exact, prefix and substring matches of name and then email",
+			query: "searchtest",
+			want: []model.UserID{
+				"TEST_SEARCH_USER_5",
+				"TEST_SEARCH_USER_2",
+				"TEST_SEARCH_USER_3",
+				"TEST_SEARCH_USER_1",
+				"TEST_SEARCH_USER_4",
+			},
+		},
+		{
+			name:  "Limits the most relevant users",
+			query: "searchtest",
+			limit: 2,
+			want:  []model.UserID{"TEST_SEARCH_USER_5", "TEST_SEARCH_USER_2"},
+		},
+		{
+			name:  "Matches the email exactly",
+			query: "searchtest.carol@example.com",
+			want:  []model.UserID{"TEST_SEARCH_USER_3"},
+		},
+		{
+			name:  "Matches the accents but not the case of the normalized query",
+			query: "searchtëst",
+			want:  []model.UserID{"TEST_SEARCH_USER_7"},
+		},
+		{
+			name:  "Matches a wildcard of LIKE literally",
+			query: "searchtest_",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []model.UserID
+			err := r.RunTransaction(func(tx repository.Transaction) error {
+				for _, u := range users {
+					if _, err := tx.User().Create(u); err != nil {
+						return err
+					}
+				}
+				us, err := tx.User().List(repository.UserListFilter{Query: tt.query, Limit: tt.limit})
+				if err != nil {
+					return err
+				}
+				got = fixtureUserIDs(us, users)
+				return errRollback
+			})
+			if !errors.Is(err, errRollback) {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("r.User().List(%q)=%v; want %v\ndiffers: (-got +want)\n%s", tt.query, got, tt.want, diff)
+			}
+		})
+	}
+}
+
+// RunGroupSearchTests tests the search of the groups by GroupListFilter.Query.
+// The groups of the tests are created in a transaction which is rolled back.
+func RunGroupSearchTests(t *testing.T, r repository.Repository) {
+	t.Helper()
+
+	groups := model.Groups{
+		model.MustNewGroup("TEST_SEARCH_GROUP_1", "Searchtest Team", nil),
+		model.MustNewGroup("TEST_SEARCH_GROUP_2", "The Searchtest", nil),
+		model.MustNewGroup("TEST_SEARCH_GROUP_3", "SEARCHTEST", nil),
+		model.MustNewGroup("TEST_SEARCH_GROUP_4", "Other", nil),
+	}
+	for _, g := range groups {
+		g.ChangeTimestamps(model.NewTimestamps("", time.Now().Truncate(time.Second)))
+	}
+	tests := []struct {
+		name  string
+		query string
+		want  []model.GroupID
+	}{
+		{
+			name:  "Orders the groups by exact, prefix and substring matches of name",
+			query: "searchtest",
+			want:  []model.GroupID{"TEST_SEARCH_GROUP_3", "TEST_SEARCH_GROUP_1", "TEST_SEARCH_GROUP_2"},
+		},
+		{
+			name:  "Matches a wildcard of LIKE literally",
+			query: "search%team",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []model.GroupID
+			err := r.RunTransaction(func(tx repository.Transaction) error {
+				for _, g := range groups {
+					if _, err := tx.Group().Create(g); err != nil {
+						return err
+					}
+				}
+				gs, err := tx.Group().List(repository.GroupListFilter{Query: tt.query})
+				if err != nil {
+					return err
+				}
+				got = fixtureGroupIDs(gs, groups)
+				return errRollback
+			})
+			if !errors.Is(err, errRollback) {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("r.Group().List(%q)=%v; want %v\ndiffers: (-got +want)\n%s", tt.query, got, tt.want, diff)
+			}
+		})
+	}
+}
+
+// fixtureUserIDs returns the ids of the users which are the fixtures, ignoring the other users in the repository.
+func fixtureUserIDs(us, fixtures model.Users) []model.UserID {
+	var result []model.UserID
+	for _, u := range us {
+		for _, fu := range fixtures {
+			if u.ID() == fu.ID() {
+				result = append(result, u.ID())
+			}
+		}
+	}
+	return result
+}
+
+// fixtureGroupIDs returns the ids of the groups which are the fixtures, ignoring the other groups in the repository.
+func fixtureGroupIDs(gs, fixtures model.Groups) []model.GroupID {
+	var result []model.GroupID
+	for _, g := range gs {
+		for _, fg := range fixtures {
+			if g.ID() == fg.ID() {
+				result = append(result, g.ID())
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+// SubgroupListFilter narrows subgroups down. Zero values are ignored.
+type SubgroupListFilter struct {
+	ParentID model.GroupID
+	ChildID  model.GroupID
+}
+
+// SubgroupRepositoryQuery is interface for query methods of subgroup.
+type SubgroupRepositoryQuery interface {
+	List(f SubgroupListFilter) (model.Subgroups, error)
+	// ListDescendantIDs lists the ids of the groups nested in the group directly or transitively, sorted by id.
+	ListDescendantIDs(gID model.GroupID) ([]model.GroupID, error)
+	// ListAncestorIDs lists the ids of the groups which any of the groups is nested in directly or transitively,
+	// sorted by id.
+	ListAncestorIDs(gIDs []model.GroupID) ([]model.GroupID, error)
+}
+
+// SubgroupRepositoryCommand is interface for query and command methods of subgroup.
+type SubgroupRepositoryCommand interface {
+	SubgroupRepositoryQuery
+	// ListAncestorIDsForUpdate lists the ids as ListAncestorIDs does, locking the subgroups it reads until
+	// the transaction ends, so that nesting any of the groups or their ancestors concurrently waits for it.
+	ListAncestorIDsForUpdate(gIDs []model.GroupID) ([]model.GroupID, error)
+	Create(s *model.Subgroup) error
+	Delete(s *model.Subgroup) error
+	// DeleteByGroupID deletes the subgroups which the group is the parent or the child of.
+	DeleteByGroupID(gID model.GroupID) error
+}
@@ -1,11 +1,33 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
 )
 
 type UserListFilter struct {
 	UserIDs []model.UserID
+	Emails  []string
+	// GroupIDs keeps the users who belong to any of the groups.
+	GroupIDs []model.GroupID
+	// Status keeps the users in the status.
+	Status model.UserStatus
+	// Attributes keeps the users whose custom attributes have all the values, compared in their string form.
+	Attributes map[string]string
+	// NoGroup keeps the users who belong to no group.
+	NoGroup bool
+	// CreatedAfter keeps the users created after it.
+	CreatedAfter time.Time
+	// Query keeps the users whose name or email contains the normalized query, case-insensitively, and orders them
+	// by their search rank and then by id.
+	Query string
+	// Sort orders the users by their timestamps and then by id, taking precedence over the other orders.
+	Sort model.SortOrder
+	// AfterID keeps the users whose id is greater than it, to page through the users in the order of id.
+	AfterID model.UserID
+	// Limit lists at most the number of the users in the order of id.
+	Limit int
 }
 
 // UserRepositoryQuery is interface for query methods of user.
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+// WebhookDeliveryListFilter narrows webhook deliveries down. Zero values are ignored.
+type WebhookDeliveryListFilter struct {
+	SubscriptionID model.WebhookSubscriptionID
+	Status         model.WebhookDeliveryStatus
+	AvailableAt    time.Time
+	Limit          int
+}
+
+// WebhookDeliveryRepositoryQuery is interface for query methods of webhook delivery.
+type WebhookDeliveryRepositoryQuery interface {
+	Find(id model.WebhookDeliveryID) (*model.WebhookDelivery, error)
+	List(f WebhookDeliveryListFilter) (model.WebhookDeliveries, error)
+}
+
+// WebhookDeliveryRepositoryCommand is interface for query and command methods of webhook delivery.
+type WebhookDeliveryRepositoryCommand interface {
+	WebhookDeliveryRepositoryQuery
+	Create(ds model.WebhookDeliveries) error
+	Update(d *model.WebhookDelivery) error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import "github.com/toshiykst/go-layerd-architecture/app/domain/model"
+
+// WebhookSubscriptionRepositoryQuery is interface for query methods of webhook subscription.
+type WebhookSubscriptionRepositoryQuery interface {
+	Find(id model.WebhookSubscriptionID) (*model.WebhookSubscription, error)
+	List() (model.WebhookSubscriptions, error)
+}
+
+// WebhookSubscriptionRepositoryCommand is interface for query and command methods of webhook subscription.
+type WebhookSubscriptionRepositoryCommand interface {
+	WebhookSubscriptionRepositoryQuery
+	Create(s *model.WebhookSubscription) (*model.WebhookSubscription, error)
+	Update(s *model.WebhookSubscription) error
+	// Delete deletes the subscription together with its deliveries.
+	Delete(id model.WebhookSubscriptionID) error
+}
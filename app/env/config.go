@@ -1,7 +1,14 @@
 package env
 
 import (
+	"encoding/json"
+	"fmt"
+	"time"
+
 	"github.com/kelseyhightower/envconfig"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
 )
 
 type Config struct {
@@ -10,6 +17,44 @@ type Config struct {
 	DBUser     string `envconfig:"MYSQL_USER"`
 	DBPassword string `envconfig:"MYSQL_PASSWORD"`
 	DBDebug    bool   `envconfig:"MYSQL_DEBUG"`
+
+	GRPCPort int `envconfig:"GRPC_PORT" default:"9090"`
+
+	GraphQLMaxDepth      int `envconfig:"GRAPHQL_MAX_DEPTH" default:"10"`
+	GraphQLMaxComplexity int `envconfig:"GRAPHQL_MAX_COMPLEXITY" default:"10000"`
+
+	OutboxPublishers    []string      `envconfig:"OUTBOX_PUBLISHERS" default:"log"`
+	OutboxFilePath      string        `envconfig:"OUTBOX_FILE_PATH" default:"outbox.ndjson"`
+	OutboxWebhookURL    string        `envconfig:"OUTBOX_WEBHOOK_URL"`
+	OutboxRelayInterval time.Duration `envconfig:"OUTBOX_RELAY_INTERVAL" default:"1s"`
+	OutboxBatchSize     int           `envconfig:"OUTBOX_BATCH_SIZE" default:"100"`
+	// OutboxRetention is how long the published messages are kept for the event streams to resume after them.
+	OutboxRetention     time.Duration `envconfig:"OUTBOX_RETENTION" default:"168h"`
+	OutboxPurgeInterval time.Duration `envconfig:"OUTBOX_PURGE_INTERVAL" default:"1h"`
+
+	WebhookDispatchInterval time.Duration `envconfig:"WEBHOOK_DISPATCH_INTERVAL" default:"1s"`
+	WebhookBatchSize        int           `envconfig:"WEBHOOK_BATCH_SIZE" default:"100"`
+
+	IdempotencyKeyTTL           time.Duration `envconfig:"IDEMPOTENCY_KEY_TTL" default:"24h"`
+	IdempotencyKeyPurgeInterval time.Duration `envconfig:"IDEMPOTENCY_KEY_PURGE_INTERVAL" default:"1h"`
+
+	GroupInvitationTTL           time.Duration `envconfig:"GROUP_INVITATION_TTL" default:"168h"`
+	GroupInvitationSweepInterval time.Duration `envconfig:"GROUP_INVITATION_SWEEP_INTERVAL" default:"1m"`
+
+	UserNameMaxLength  int      `envconfig:"USER_NAME_MAX_LENGTH" default:"30"`
+	UserNamePattern    string   `envconfig:"USER_NAME_PATTERN"`
+	UserReservedNames  []string `envconfig:"USER_RESERVED_NAMES"`
+	GroupNameMaxLength int      `envconfig:"GROUP_NAME_MAX_LENGTH" default:"30"`
+	GroupNamePattern   string   `envconfig:"GROUP_NAME_PATTERN"`
+	GroupReservedNames []string `envconfig:"GROUP_RESERVED_NAMES"`
+	GroupMaxUsers      int      `envconfig:"GROUP_MAX_USERS" default:"5"`
+	// GroupPolicyOverrides is a JSON array of the group policies of a group type, a tenant or both, e.g.
+	// [{"type":"team","tenant":"acme","max_users":50}]. The fields left out take the values above.
+	GroupPolicyOverrides string `envconfig:"GROUP_POLICY_OVERRIDES"`
+	// AdminUserIDs are the users who manage all the groups without belonging to them.
+	AdminUserIDs []string `envconfig:"ADMIN_USER_IDS"`
+
+	IDStrategy string `envconfig:"ID_STRATEGY" default:"uuidv4"`
 }
 
 func NewConfig() (*Config, error) {
@@ -19,3 +64,97 @@ func NewConfig() (*Config, error) {
 	}
 	return &v, nil
 }
+
+// Policy returns the policy of the users and the groups the deployment configures.
+func (c *Config) Policy() (model.Policy, error) {
+	un, err := model.NewNamePolicy(c.UserNameMaxLength, model.MaxUserNameLength, c.UserNamePattern, c.UserReservedNames)
+	if err != nil {
+		return model.Policy{}, fmt.Errorf("user policy: %w", err)
+	}
+	gn, err := model.NewNamePolicy(c.GroupNameMaxLength, model.MaxGroupNameLength, c.GroupNamePattern, c.GroupReservedNames)
+	if err != nil {
+		return model.Policy{}, fmt.Errorf("group policy: %w", err)
+	}
+	gp, err := model.NewGroupPolicy(gn, c.GroupMaxUsers)
+	if err != nil {
+		return model.Policy{}, fmt.Errorf("group policy: %w", err)
+	}
+	overrides, err := c.groupPolicyOverrides()
+	if err != nil {
+		return model.Policy{}, fmt.Errorf("group policy overrides: %w", err)
+	}
+	admins := make([]model.UserID, len(c.AdminUserIDs))
+	for i, id := range c.AdminUserIDs {
+		admins[i] = model.UserID(id)
+	}
+	return model.Policy{
+		User:           model.UserPolicy{Name: un},
+		Group:          gp,
+		GroupOverrides: overrides,
+		Admins:         admins,
+	}, nil
+}
+
+// groupPolicyOverride is an element of GROUP_POLICY_OVERRIDES.
+type groupPolicyOverride struct {
+	GroupType     string   `json:"type"`
+	Tenant        string   `json:"tenant"`
+	NameMaxLength int      `json:"name_max_length"`
+	NamePattern   *string  `json:"name_pattern"`
+	ReservedNames []string `json:"reserved_names"`
+	MaxUsers      int      `json:"max_users"`
+}
+
+func (c *Config) groupPolicyOverrides() (map[model.PolicyScope]model.GroupPolicy, error) {
+	if c.GroupPolicyOverrides == "" {
+		return nil, nil
+	}
+	var entries []groupPolicyOverride
+	if err := json.Unmarshal([]byte(c.GroupPolicyOverrides), &entries); err != nil {
+		return nil, err
+	}
+
+	overrides := make(map[model.PolicyScope]model.GroupPolicy, len(entries))
+	for _, o := range entries {
+		s := model.PolicyScope{GroupType: o.GroupType, Tenant: o.Tenant}
+		if s == (model.PolicyScope{}) {
+			return nil, fmt.Errorf("an override must have a type or a tenant")
+		}
+		if _, ok := overrides[s]; ok {
+			return nil, fmt.Errorf("duplicate override of type %q and tenant %q", s.GroupType, s.Tenant)
+		}
+
+		maxLength, pattern, reserved, maxUsers := c.GroupNameMaxLength, c.GroupNamePattern, c.GroupReservedNames, c.GroupMaxUsers
+		if o.NameMaxLength != 0 {
+			maxLength = o.NameMaxLength
+		}
+		if o.NamePattern != nil {
+			pattern = *o.NamePattern
+		}
+		if o.ReservedNames != nil {
+			reserved = o.ReservedNames
+		}
+		if o.MaxUsers != 0 {
+			maxUsers = o.MaxUsers
+		}
+		n, err := model.NewNamePolicy(maxLength, model.MaxGroupNameLength, pattern, reserved)
+		if err != nil {
+			return nil, fmt.Errorf("type %q and tenant %q: %w", s.GroupType, s.Tenant, err)
+		}
+		gp, err := model.NewGroupPolicy(n, maxUsers)
+		if err != nil {
+			return nil, fmt.Errorf("type %q and tenant %q: %w", s.GroupType, s.Tenant, err)
+		}
+		overrides[s] = gp
+	}
+	return overrides, nil
+}
+
+// IDGenerator returns the generator of the IDs of the aggregates the deployment configures.
+func (c *Config) IDGenerator() (factory.IDGenerator, error) {
+	g, err := factory.NewIDGenerator(factory.IDStrategy(c.IDStrategy))
+	if err != nil {
+		return nil, fmt.Errorf("id generator: %w", err)
+	}
+	return g, nil
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+type AttributeSchemaHandler struct {
+	uc usecase.AttributeSchemaUsecase
+}
+
+func NewAttributeSchemaHandler(uc usecase.AttributeSchemaUsecase) *AttributeSchemaHandler {
+	return &AttributeSchemaHandler{uc: uc}
+}
+
+type (
+	GetAttributeSchemasResponse struct {
+		AttributeSchemas []response.AttributeSchema `json:"attributeSchemas"`
+	}
+)
+
+// GetAttributeSchemas lists the schemas of the custom attributes, narrowed down to the ones of USER or GROUP with
+// the query parameter target.
+func (h *AttributeSchemaHandler) GetAttributeSchemas(c echo.Context) error {
+	out, err := h.uc.GetAttributeSchemas(&dto.GetAttributeSchemasInput{
+		Target: c.QueryParam("target"),
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidAttributeSchemaInput) {
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.OK(c, &GetAttributeSchemasResponse{
+		AttributeSchemas: response.ToAttributeSchemasFromDTO(out.AttributeSchemas),
+	})
+}
+
+type (
+	PutAttributeSchemaRequest struct {
+		// Type is STRING, NUMBER or BOOLEAN.
+		Type     string `json:"type"`
+		Required bool   `json:"required,omitempty"`
+		// Enum is of the values a string or a number attribute may have in their string form.
+		Enum []string `json:"enum,omitempty"`
+		// Pattern is the regular expression a string attribute must match.
+		Pattern string `json:"pattern,omitempty"`
+	}
+
+	PutAttributeSchemaResponse struct {
+		AttributeSchema response.AttributeSchema `json:"attributeSchema"`
+	}
+)
+
+// PutAttributeSchema creates the schema of the custom attribute of the target and the key, or replaces it.
+func (h *AttributeSchemaHandler) PutAttributeSchema(c echo.Context) error {
+	req := &PutAttributeSchemaRequest{}
+	if err := c.Bind(req); err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	in := &dto.PutAttributeSchemaInput{
+		Target:   c.Param("target"),
+		Key:      c.Param("key"),
+		Type:     req.Type,
+		Required: req.Required,
+		Enum:     req.Enum,
+		Pattern:  req.Pattern,
+	}
+	out, err := h.uc.PutAttributeSchema(in)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidAttributeSchemaInput) {
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.OK(c, &PutAttributeSchemaResponse{
+		AttributeSchema: response.ToAttributeSchemaFromDTO(out.AttributeSchema),
+	})
+}
+
+func (h *AttributeSchemaHandler) DeleteAttributeSchema(c echo.Context) error {
+	in := &dto.DeleteAttributeSchemaInput{
+		Target: c.Param("target"),
+		Key:    c.Param("key"),
+	}
+
+	if _, err := h.uc.DeleteAttributeSchema(in); err != nil {
+		if errors.Is(err, usecase.ErrAttributeSchemaNotFound) {
+			return response.Error(c, response.ErrorCodeAttributeSchemaNotFound, http.StatusNotFound, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.NoContent(c)
+}
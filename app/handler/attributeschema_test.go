@@ -0,0 +1,263 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/labstack/echo"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	mockusecase "github.com/toshiykst/go-layerd-architecture/app/mock/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestAttributeSchemaHandler_PutAttributeSchema(t *testing.T) {
+	tests := []struct {
+		name                      string
+		target                    string
+		key                       string
+		req                       *handler.PutAttributeSchemaRequest
+		newAttributeSchemaUsecase func(ctrl *gomock.Controller) usecase.AttributeSchemaUsecase
+		wantStatus                int
+		wantRes                   *handler.PutAttributeSchemaResponse
+		wantErrRes                *response.ErrorResponse
+	}{
+		{
+			name:   "Puts an attribute schema and returns the attribute schema response",
+			target: "USER",
+			key:    "department",
+			req: &handler.PutAttributeSchemaRequest{
+				Type:     "STRING",
+				Required: true,
+				Enum:     []string{"sales", "engineering"},
+			},
+			newAttributeSchemaUsecase: func(ctrl *gomock.Controller) usecase.AttributeSchemaUsecase {
+				uc := mockusecase.NewMockAttributeSchemaUsecase(ctrl)
+				uc.EXPECT().
+					PutAttributeSchema(&dto.PutAttributeSchemaInput{
+						Target:   "USER",
+						Key:      "department",
+						Type:     "STRING",
+						Required: true,
+						Enum:     []string{"sales", "engineering"},
+					}).
+					Return(&dto.PutAttributeSchemaOutput{
+						AttributeSchema: dto.AttributeSchema{
+							Target:   "USER",
+							Key:      "department",
+							Type:     "STRING",
+							Required: true,
+							Enum:     []string{"sales", "engineering"},
+						},
+					}, nil)
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes: &handler.PutAttributeSchemaResponse{
+				AttributeSchema: response.AttributeSchema{
+					Target:   "USER",
+					Key:      "department",
+					Type:     "STRING",
+					Required: true,
+					Enum:     []string{"sales", "engineering"},
+				},
+			},
+		},
+		{
+			name:   "Returns invalid arguments error response when the input is invalid",
+			target: "USER",
+			key:    "department",
+			req:    &handler.PutAttributeSchemaRequest{Type: "DATE"},
+			newAttributeSchemaUsecase: func(ctrl *gomock.Controller) usecase.AttributeSchemaUsecase {
+				uc := mockusecase.NewMockAttributeSchemaUsecase(ctrl)
+				uc.EXPECT().
+					PutAttributeSchema(gomock.Any()).
+					Return(nil, usecase.ErrInvalidAttributeSchemaInput)
+				return uc
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: usecase.ErrInvalidAttributeSchemaInput.Error(),
+			},
+		},
+		{
+			name:   "Returns internal server error response",
+			target: "USER",
+			key:    "department",
+			req:    &handler.PutAttributeSchemaRequest{Type: "STRING"},
+			newAttributeSchemaUsecase: func(ctrl *gomock.Controller) usecase.AttributeSchemaUsecase {
+				uc := mockusecase.NewMockAttributeSchemaUsecase(ctrl)
+				uc.EXPECT().
+					PutAttributeSchema(gomock.Any()).
+					Return(nil, errors.New("an error occurred"))
+				return uc
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInternalServerError,
+				Status:  http.StatusInternalServerError,
+				Message: "an error occurred",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqJson, _ := json.Marshal(tt.req)
+
+			req := httptest.NewRequest(http.MethodPut, "https://example.com:8080", bytes.NewBuffer(reqJson))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetPath("/attribute-schemas/:target/:key")
+			c.SetParamNames("target", "key")
+			c.SetParamValues(tt.target, tt.key)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			uc := tt.newAttributeSchemaUsecase(ctrl)
+
+			h := handler.NewAttributeSchemaHandler(uc)
+
+			err := h.PutAttributeSchema(c)
+			if err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			res := rec.Result()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", res.StatusCode, tt.wantStatus)
+			}
+
+			resBody, err := io.ReadAll(rec.Body)
+			if err != nil {
+				t.Fatalf("Failed to read body: %s", err.Error())
+			}
+			defer func(Body io.ReadCloser) {
+				if err := Body.Close(); err != nil {
+					t.Fatalf("Failed to close body: %s", err.Error())
+				}
+			}(res.Body)
+
+			if tt.wantRes != nil {
+				var got *handler.PutAttributeSchemaResponse
+				_ = json.Unmarshal(resBody, &got)
+				if diff := cmp.Diff(got, tt.wantRes); diff != "" {
+					t.Errorf(
+						"response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantRes, diff,
+					)
+				}
+			}
+
+			if tt.wantErrRes != nil {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(resBody, &got)
+				if diff := cmp.Diff(got, tt.wantErrRes); diff != "" {
+					t.Errorf(
+						"error response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantErrRes, diff,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestAttributeSchemaHandler_DeleteAttributeSchema(t *testing.T) {
+	tests := []struct {
+		name                      string
+		target                    string
+		key                       string
+		newAttributeSchemaUsecase func(ctrl *gomock.Controller) usecase.AttributeSchemaUsecase
+		wantStatus                int
+		wantErrRes                *response.ErrorResponse
+	}{
+		{
+			name:   "Deletes an attribute schema",
+			target: "GROUP",
+			key:    "costCenter",
+			newAttributeSchemaUsecase: func(ctrl *gomock.Controller) usecase.AttributeSchemaUsecase {
+				uc := mockusecase.NewMockAttributeSchemaUsecase(ctrl)
+				uc.EXPECT().
+					DeleteAttributeSchema(&dto.DeleteAttributeSchemaInput{Target: "GROUP", Key: "costCenter"}).
+					Return(&dto.DeleteAttributeSchemaOutput{}, nil)
+				return uc
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:   "Returns attribute schema not found error response",
+			target: "GROUP",
+			key:    "costCenter",
+			newAttributeSchemaUsecase: func(ctrl *gomock.Controller) usecase.AttributeSchemaUsecase {
+				uc := mockusecase.NewMockAttributeSchemaUsecase(ctrl)
+				uc.EXPECT().
+					DeleteAttributeSchema(gomock.Any()).
+					Return(nil, usecase.ErrAttributeSchemaNotFound)
+				return uc
+			},
+			wantStatus: http.StatusNotFound,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeAttributeSchemaNotFound,
+				Status:  http.StatusNotFound,
+				Message: usecase.ErrAttributeSchemaNotFound.Error(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "https://example.com:8080", nil)
+
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetPath("/attribute-schemas/:target/:key")
+			c.SetParamNames("target", "key")
+			c.SetParamValues(tt.target, tt.key)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			uc := tt.newAttributeSchemaUsecase(ctrl)
+
+			h := handler.NewAttributeSchemaHandler(uc)
+
+			err := h.DeleteAttributeSchema(c)
+			if err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			res := rec.Result()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", res.StatusCode, tt.wantStatus)
+			}
+
+			if tt.wantErrRes != nil {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if diff := cmp.Diff(got, tt.wantErrRes); diff != "" {
+					t.Errorf(
+						"error response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantErrRes, diff,
+					)
+				}
+			}
+		})
+	}
+}
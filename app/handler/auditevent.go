@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+type AuditEventHandler struct {
+	uc usecase.AuditEventUsecase
+}
+
+func NewAuditEventHandler(uc usecase.AuditEventUsecase) *AuditEventHandler {
+	return &AuditEventHandler{uc: uc}
+}
+
+type GetAuditEventsResponse struct {
+	AuditEvents []response.AuditEvent `json:"auditEvents"`
+}
+
+func (h *AuditEventHandler) GetAuditEvents(c echo.Context) error {
+	from, err := parseTimeParam(c, "from")
+	if err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+	to, err := parseTimeParam(c, "to")
+	if err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	in := &dto.GetAuditEventsInput{
+		Actor:      c.QueryParam("actor"),
+		TargetType: c.QueryParam("targetType"),
+		TargetID:   c.QueryParam("targetId"),
+		From:       from,
+		To:         to,
+	}
+
+	out, err := h.uc.GetAuditEvents(in)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidAuditEventInput) {
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.OK(c, &GetAuditEventsResponse{
+		AuditEvents: response.ToAuditEventsFromDTO(out.AuditEvents),
+	})
+}
@@ -0,0 +1,195 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/labstack/echo"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	mockusecase "github.com/toshiykst/go-layerd-architecture/app/mock/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestAuditEventHandler_GetAuditEvents(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name                 string
+		query                string
+		newAuditEventUsecase func(ctrl *gomock.Controller) usecase.AuditEventUsecase
+		wantStatus           int
+		wantRes              *handler.GetAuditEventsResponse
+		wantErrRes           *response.ErrorResponse
+	}{
+		{
+			name:  "Returns the audit events response",
+			query: "?actor=TEST_ACTOR&targetType=USER&targetId=TEST_USER_ID&from=2023-01-01T00:00:00Z&to=2023-01-03T00:00:00Z",
+			newAuditEventUsecase: func(ctrl *gomock.Controller) usecase.AuditEventUsecase {
+				uc := mockusecase.NewMockAuditEventUsecase(ctrl)
+				uc.EXPECT().
+					GetAuditEvents(&dto.GetAuditEventsInput{
+						Actor:      "TEST_ACTOR",
+						TargetType: "USER",
+						TargetID:   "TEST_USER_ID",
+						From:       time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+						To:         time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+					}).
+					Return(&dto.GetAuditEventsOutput{
+						AuditEvents: []dto.AuditEvent{
+							{
+								AuditEventID: "TEST_AUDIT_EVENT_ID",
+								Actor:        "TEST_ACTOR",
+								Action:       "UPDATE_USER",
+								TargetType:   "USER",
+								TargetID:     "TEST_USER_ID",
+								Changes: []dto.AuditChange{
+									{Field: "name", Before: "TEST_USER_NAME", After: "TEST_USER_NAME_UPDATED"},
+								},
+								RequestID:  "TEST_REQUEST_ID",
+								OccurredAt: occurredAt,
+							},
+						},
+					}, nil)
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes: &handler.GetAuditEventsResponse{
+				AuditEvents: []response.AuditEvent{
+					{
+						AuditEventID: "TEST_AUDIT_EVENT_ID",
+						Actor:        "TEST_ACTOR",
+						Action:       "UPDATE_USER",
+						TargetType:   "USER",
+						TargetID:     "TEST_USER_ID",
+						Changes: []response.AuditChange{
+							{Field: "name", Before: "TEST_USER_NAME", After: "TEST_USER_NAME_UPDATED"},
+						},
+						RequestID:  "TEST_REQUEST_ID",
+						OccurredAt: occurredAt,
+					},
+				},
+			},
+		},
+		{
+			name:  "Returns invalid arguments error response when the time is malformed",
+			query: "?from=yesterday",
+			newAuditEventUsecase: func(ctrl *gomock.Controller) usecase.AuditEventUsecase {
+				return mockusecase.NewMockAuditEventUsecase(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: `from must be RFC 3339 time: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`,
+			},
+		},
+		{
+			name:  "Returns invalid arguments error response when the input is invalid",
+			query: "?targetType=UNKNOWN",
+			newAuditEventUsecase: func(ctrl *gomock.Controller) usecase.AuditEventUsecase {
+				uc := mockusecase.NewMockAuditEventUsecase(ctrl)
+				uc.EXPECT().
+					GetAuditEvents(gomock.Any()).
+					Return(nil, usecase.ErrInvalidAuditEventInput)
+				return uc
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: usecase.ErrInvalidAuditEventInput.Error(),
+			},
+		},
+		{
+			name:  "Returns internal server error response",
+			query: "",
+			newAuditEventUsecase: func(ctrl *gomock.Controller) usecase.AuditEventUsecase {
+				uc := mockusecase.NewMockAuditEventUsecase(ctrl)
+				uc.EXPECT().
+					GetAuditEvents(gomock.Any()).
+					Return(nil, errors.New("an error occurred"))
+				return uc
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInternalServerError,
+				Status:  http.StatusInternalServerError,
+				Message: "an error occurred",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(
+				http.MethodGet,
+				"https://example.com:8080/audit-events"+tt.query,
+				nil,
+			)
+
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			uc := tt.newAuditEventUsecase(ctrl)
+
+			h := handler.NewAuditEventHandler(uc)
+
+			err := h.GetAuditEvents(c)
+			if err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			res := rec.Result()
+
+			wantStatusCode := tt.wantStatus
+			gotStatusCode := res.StatusCode
+			if gotStatusCode != wantStatusCode {
+				t.Errorf("statusCode got = %d, want = %d", gotStatusCode, wantStatusCode)
+			}
+
+			resBody, err := io.ReadAll(rec.Body)
+			if err != nil {
+				t.Fatalf("Failed to read body: %s", err.Error())
+			}
+			defer func(Body io.ReadCloser) {
+				if err := Body.Close(); err != nil {
+					t.Fatalf("Failed to close body: %s", err.Error())
+				}
+			}(res.Body)
+
+			if tt.wantRes != nil {
+				var got *handler.GetAuditEventsResponse
+				_ = json.Unmarshal(resBody, &got)
+				if diff := cmp.Diff(got, tt.wantRes); diff != "" {
+					t.Errorf(
+						"response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantRes, diff,
+					)
+				}
+			}
+
+			if tt.wantErrRes != nil {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(resBody, &got)
+				if diff := cmp.Diff(got, tt.wantErrRes); diff != "" {
+					t.Errorf(
+						"error response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantErrRes, diff,
+					)
+				}
+			}
+		})
+	}
+}
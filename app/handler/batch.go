@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+type BatchHandler struct {
+	uc usecase.BatchUsecase
+}
+
+func NewBatchHandler(uc usecase.BatchUsecase) *BatchHandler {
+	return &BatchHandler{uc: uc}
+}
+
+type (
+	BatchRequest struct {
+		Operations []BatchOperationRequest `json:"operations"`
+	}
+
+	// BatchOperationRequest is an operation of a batch. A string "$ref:<id>.<field>" in the args is replaced with
+	// the field of the result of the earlier operation of the id, e.g. "$ref:op1.userId".
+	BatchOperationRequest struct {
+		ID   string          `json:"id"`
+		Op   string          `json:"op"`
+		Args json.RawMessage `json:"args,omitempty"`
+	}
+
+	BatchResponse struct {
+		Results []BatchOperationResult `json:"results"`
+	}
+
+	BatchOperationResult struct {
+		ID     string         `json:"id"`
+		Op     string         `json:"op"`
+		Result map[string]any `json:"result"`
+	}
+)
+
+// RunBatch runs the operations in a transaction. The error of the operation which failed the batch is responded
+// as the operation responds it, with the index and the id of the operation in the message.
+func (h *BatchHandler) RunBatch(c echo.Context) error {
+	req := &BatchRequest{}
+	if err := c.Bind(req); err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	in := &dto.RunBatchInput{
+		Meta:       newMeta(c),
+		Operations: make([]dto.BatchOperation, len(req.Operations)),
+	}
+	for i, op := range req.Operations {
+		in.Operations[i] = dto.BatchOperation{
+			ID:   op.ID,
+			Type: dto.BatchOperationType(op.Op),
+			Args: op.Args,
+		}
+	}
+
+	out, err := h.uc.RunBatch(in)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return response.Error(c, response.ErrorCodeUserNotFound, http.StatusNotFound, err)
+		case errors.Is(err, usecase.ErrGroupNotFound):
+			return response.Error(c, response.ErrorCodeGroupNotFound, http.StatusNotFound, err)
+		case errors.Is(err, usecase.ErrInvalidBatchInput),
+			errors.Is(err, usecase.ErrInvalidUserInput),
+			errors.Is(err, usecase.ErrInvalidGroupInput),
+			errors.Is(err, usecase.ErrInvalidUserIDs):
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		case errors.Is(err, usecase.ErrUserInactive):
+			return response.Error(c, response.ErrorCodeUserInactive, http.StatusConflict, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	res := &BatchResponse{Results: make([]BatchOperationResult, len(out.Results))}
+	for i, r := range out.Results {
+		res.Results[i] = BatchOperationResult{
+			ID:     r.ID,
+			Op:     string(r.Type),
+			Result: r.Result,
+		}
+	}
+	return response.OK(c, res)
+}
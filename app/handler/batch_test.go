@@ -0,0 +1,182 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/labstack/echo"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	mockusecase "github.com/toshiykst/go-layerd-architecture/app/mock/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestBatchHandler_RunBatch(t *testing.T) {
+	tests := []struct {
+		name            string
+		reqBody         string
+		newBatchUsecase func(ctrl *gomock.Controller) usecase.BatchUsecase
+		wantStatus      int
+		wantRes         *handler.BatchResponse
+		wantErrRes      *response.ErrorResponse
+	}{
+		{
+			name: "Run a batch",
+			reqBody: `{"operations":[` +
+				`{"id":"u1","op":"createUser","args":{"name":"TEST_USER_NAME","email":"TEST_USER_EMAIL"}},` +
+				`{"id":"g1","op":"createGroup","args":{"name":"TEST_GROUP_NAME","userIds":["$ref:u1.userId"]}}]}`,
+			newBatchUsecase: func(ctrl *gomock.Controller) usecase.BatchUsecase {
+				uc := mockusecase.NewMockBatchUsecase(ctrl)
+				uc.EXPECT().
+					RunBatch(gomock.Any()).
+					DoAndReturn(func(in *dto.RunBatchInput) (*dto.RunBatchOutput, error) {
+						want := &dto.RunBatchInput{
+							Operations: []dto.BatchOperation{
+								{
+									ID:   "u1",
+									Type: dto.BatchOperationCreateUser,
+									Args: []byte(`{"name":"TEST_USER_NAME","email":"TEST_USER_EMAIL"}`),
+								},
+								{
+									ID:   "g1",
+									Type: dto.BatchOperationCreateGroup,
+									Args: []byte(`{"name":"TEST_GROUP_NAME","userIds":["$ref:u1.userId"]}`),
+								},
+							},
+						}
+						if diff := cmp.Diff(in, want); diff != "" {
+							t.Errorf("uc.RunBatch(%v); want %v\ndiffers: (-got +want)\n%s", in, want, diff)
+						}
+						return &dto.RunBatchOutput{
+							Results: []dto.BatchResult{
+								{
+									ID:     "u1",
+									Type:   dto.BatchOperationCreateUser,
+									Result: map[string]any{"userId": "TEST_USER_ID"},
+								},
+								{
+									ID:     "g1",
+									Type:   dto.BatchOperationCreateGroup,
+									Result: map[string]any{"groupId": "TEST_GROUP_ID"},
+								},
+							},
+						}, nil
+					})
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes: &handler.BatchResponse{
+				Results: []handler.BatchOperationResult{
+					{ID: "u1", Op: "createUser", Result: map[string]any{"userId": "TEST_USER_ID"}},
+					{ID: "g1", Op: "createGroup", Result: map[string]any{"groupId": "TEST_GROUP_ID"}},
+				},
+			},
+		},
+		{
+			name:    "Returns invalid arguments error response when an operation is invalid",
+			reqBody: `{"operations":[{"id":"u1","op":"createUser","args":{"name":""}}]}`,
+			newBatchUsecase: func(ctrl *gomock.Controller) usecase.BatchUsecase {
+				uc := mockusecase.NewMockBatchUsecase(ctrl)
+				uc.EXPECT().
+					RunBatch(gomock.Any()).
+					Return(nil, &usecase.BatchOperationError{Index: 0, ID: "u1", Err: usecase.ErrInvalidUserInput})
+				return uc
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: "operation 0 (u1): invalid user input",
+			},
+		},
+		{
+			name:    "Returns group not found error response",
+			reqBody: `{"operations":[{"id":"g1","op":"deleteGroup","args":{"groupId":"TEST_GROUP_ID"}}]}`,
+			newBatchUsecase: func(ctrl *gomock.Controller) usecase.BatchUsecase {
+				uc := mockusecase.NewMockBatchUsecase(ctrl)
+				uc.EXPECT().
+					RunBatch(gomock.Any()).
+					Return(nil, &usecase.BatchOperationError{Index: 0, ID: "g1", Err: usecase.ErrGroupNotFound})
+				return uc
+			},
+			wantStatus: http.StatusNotFound,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeGroupNotFound,
+				Status:  http.StatusNotFound,
+				Message: "operation 0 (g1): group not found",
+			},
+		},
+		{
+			name:    "Returns internal server error response",
+			reqBody: `{"operations":[{"id":"u1","op":"deleteUser","args":{"userId":"TEST_USER_ID"}}]}`,
+			newBatchUsecase: func(ctrl *gomock.Controller) usecase.BatchUsecase {
+				uc := mockusecase.NewMockBatchUsecase(ctrl)
+				uc.EXPECT().
+					RunBatch(gomock.Any()).
+					Return(nil, errors.New("an error occurred"))
+				return uc
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInternalServerError,
+				Status:  http.StatusInternalServerError,
+				Message: "an error occurred",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "https://example.com:8080/batch", strings.NewReader(tt.reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			uc := tt.newBatchUsecase(ctrl)
+
+			h := handler.NewBatchHandler(uc)
+
+			if err := h.RunBatch(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
+			}
+
+			if tt.wantRes != nil {
+				var got *handler.BatchResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if diff := cmp.Diff(got, tt.wantRes); diff != "" {
+					t.Errorf(
+						"response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantRes, diff,
+					)
+				}
+			}
+
+			if tt.wantErrRes != nil {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if diff := cmp.Diff(got, tt.wantErrRes); diff != "" {
+					t.Errorf(
+						"error response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantErrRes, diff,
+					)
+				}
+			}
+		})
+	}
+}
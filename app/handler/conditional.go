@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo"
+)
+
+// notModified sets the Last-Modified header to the time the resource was last updated at, and reports whether the
+// resource is not modified since the If-Modified-Since header of the request. A resource never updated is always
+// modified.
+func notModified(c echo.Context, updatedAt time.Time) bool {
+	if updatedAt.IsZero() {
+		return false
+	}
+	c.Response().Header().Set(echo.HeaderLastModified, updatedAt.UTC().Format(http.TimeFormat))
+
+	since, err := http.ParseTime(c.Request().Header.Get(echo.HeaderIfModifiedSince))
+	if err != nil {
+		return false
+	}
+	// HTTP dates are in seconds.
+	return !updatedAt.Truncate(time.Second).After(since)
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+const (
+	HeaderLastEventID = "Last-Event-ID"
+
+	eventReplayPageSize    = 100
+	eventHeartbeatInterval = 15 * time.Second
+)
+
+type EventHandler struct {
+	uc usecase.EventUsecase
+}
+
+func NewEventHandler(uc usecase.EventUsecase) *EventHandler {
+	return &EventHandler{uc: uc}
+}
+
+// StreamEvents streams the user and group events as Server-Sent Events.
+// A client reconnecting with Last-Event-ID first receives the events it missed,
+// unless the event of the id is older than the outbox retention.
+// A client which cannot keep up is disconnected, so that it reconnects and catches up from the database.
+// Events are delivered at least once: an event is published again when the outbox relay retries it,
+// so a client should skip the ids it has seen.
+func (h *EventHandler) StreamEvents(c echo.Context) error {
+	resourceType := c.QueryParam("resourceType")
+	resourceID := c.QueryParam("resourceId")
+
+	// Subscribe before replaying so that no event committed in between is missed.
+	sub, err := h.uc.SubscribeEvents(&dto.SubscribeEventsInput{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidEventInput) {
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+	defer sub.Unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	replayed := map[string]bool{}
+	lastEventID := c.Request().Header.Get(HeaderLastEventID)
+	for lastEventID != "" {
+		out, err := h.uc.GetEvents(&dto.GetEventsInput{
+			AfterEventID: lastEventID,
+			ResourceType: resourceType,
+			ResourceID:   resourceID,
+			Limit:        eventReplayPageSize,
+		})
+		if err != nil {
+			// The response has already started, so the client only sees the stream end and reconnects.
+			c.Logger().Errorf("failed to replay events: %v", err)
+			return nil
+		}
+		for _, e := range out.Events {
+			if err := writeEvent(res, e); err != nil {
+				return nil
+			}
+			replayed[e.EventID] = true
+			lastEventID = e.EventID
+		}
+		if len(out.Events) < eventReplayPageSize {
+			break
+		}
+	}
+	res.Flush()
+
+	t := time.NewTicker(eventHeartbeatInterval)
+	defer t.Stop()
+
+	ctx := c.Request().Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sub.Dropped:
+			return nil
+		case e := <-sub.Events:
+			if replayed[e.EventID] {
+				continue
+			}
+			if err := writeEvent(res, e); err != nil {
+				return nil
+			}
+			res.Flush()
+		case <-t.C:
+			if _, err := io.WriteString(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+func writeEvent(w io.Writer, e dto.Event) error {
+	data, err := json.Marshal(response.ToEventFromDTO(e))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.EventID, e.EventType, data)
+	return err
+}
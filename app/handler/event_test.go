@@ -0,0 +1,155 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler"
+	mockusecase "github.com/toshiykst/go-layerd-architecture/app/mock/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestEventHandler_StreamEvents(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	newEvent := func(id string) dto.Event {
+		return dto.Event{
+			EventID:      id,
+			EventType:    "USER_UPDATED",
+			ResourceType: "USER",
+			ResourceID:   "TEST_USER_ID",
+			OccurredAt:   occurredAt,
+			Payload:      []byte(`{"name":"TEST_USER_NAME"}`),
+		}
+	}
+	frame := func(id string) string {
+		return "id: " + id + "\nevent: USER_UPDATED\n" +
+			`data: {"eventId":"` + id + `","eventType":"USER_UPDATED","resourceType":"USER",` +
+			`"resourceId":"TEST_USER_ID","occurredAt":"2023-01-02T03:04:05Z","data":{"name":"TEST_USER_NAME"}}` +
+			"\n\n"
+	}
+
+	t.Run("Replays the missed events and streams the new ones", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		events := make(chan dto.Event, 2)
+		// TEST_EVENT_ID_2 is both replayed and received live, and must be sent only once.
+		events <- newEvent("TEST_EVENT_ID_2")
+		events <- newEvent("TEST_EVENT_ID_3")
+
+		unsubscribed := false
+		uc := mockusecase.NewMockEventUsecase(ctrl)
+		uc.EXPECT().
+			SubscribeEvents(&dto.SubscribeEventsInput{ResourceType: "USER", ResourceID: "TEST_USER_ID"}).
+			Return(&dto.SubscribeEventsOutput{
+				Events:      events,
+				Dropped:     make(chan struct{}),
+				Unsubscribe: func() { unsubscribed = true },
+			}, nil)
+		uc.EXPECT().
+			GetEvents(&dto.GetEventsInput{
+				AfterEventID: "TEST_EVENT_ID_1",
+				ResourceType: "USER",
+				ResourceID:   "TEST_USER_ID",
+				Limit:        100,
+			}).
+			Return(&dto.GetEventsOutput{Events: []dto.Event{newEvent("TEST_EVENT_ID_2")}}, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/events/stream?resourceType=USER&resourceId=TEST_USER_ID", nil)
+		req.Header.Set(handler.HeaderLastEventID, "TEST_EVENT_ID_1")
+		req = req.WithContext(ctx)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		done := make(chan error)
+		go func() {
+			done <- handler.NewEventHandler(uc).StreamEvents(c)
+		}()
+
+		// Wait until the handler has consumed every live event before ending the request.
+		deadline := time.Now().Add(time.Second)
+		for len(events) > 0 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+
+		if err := <-done; err != nil {
+			t.Fatalf("want no error, but has error %v", err)
+		}
+		if rec.Code != http.StatusOK {
+			t.Errorf("rec.Code=%d; want %d", rec.Code, http.StatusOK)
+		}
+		if got := rec.Header().Get(echo.HeaderContentType); got != "text/event-stream" {
+			t.Errorf("rec.Header().Get(%s)=%s; want text/event-stream", echo.HeaderContentType, got)
+		}
+		want := frame("TEST_EVENT_ID_2") + frame("TEST_EVENT_ID_3")
+		if got := rec.Body.String(); got != want {
+			t.Errorf("rec.Body=%q; want %q", got, want)
+		}
+		if !unsubscribed {
+			t.Error("want the subscription to be released")
+		}
+	})
+
+	t.Run("Ends the stream when the subscriber is dropped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		dropped := make(chan struct{})
+		close(dropped)
+		uc := mockusecase.NewMockEventUsecase(ctrl)
+		uc.EXPECT().
+			SubscribeEvents(&dto.SubscribeEventsInput{}).
+			Return(&dto.SubscribeEventsOutput{
+				Events:      make(chan dto.Event),
+				Dropped:     dropped,
+				Unsubscribe: func() {},
+			}, nil)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/events/stream", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		if err := handler.NewEventHandler(uc).StreamEvents(c); err != nil {
+			t.Fatalf("want no error, but has error %v", err)
+		}
+		if rec.Body.Len() != 0 {
+			t.Errorf("rec.Body=%q; want empty", rec.Body.String())
+		}
+	})
+
+	t.Run("Returns 400 when the resource type is unknown", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uc := mockusecase.NewMockEventUsecase(ctrl)
+		uc.EXPECT().
+			SubscribeEvents(&dto.SubscribeEventsInput{ResourceType: "UNKNOWN"}).
+			Return(nil, usecase.ErrInvalidEventInput)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/events/stream?resourceType=UNKNOWN", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		if err := handler.NewEventHandler(uc).StreamEvents(c); err != nil {
+			t.Fatalf("want no error, but has error %v", err)
+		}
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("rec.Code=%d; want %d", rec.Code, http.StatusBadRequest)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/export"
+)
+
+// ExportChunkSize is the number of the users or groups read from the usecase and flushed to the client at once.
+const ExportChunkSize = 500
+
+// exportStream streams an export in the response, compressed in gzip if the client accepts it.
+type exportStream struct {
+	export.Writer
+	res *echo.Response
+	gz  *gzip.Writer
+}
+
+// startExport writes the header of the response of an export, which is named the name in the Content-Disposition.
+func startExport(c echo.Context, f export.Format, name string, header []string) (*exportStream, error) {
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, export.ContentTypes[f])
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.%s"`, name, f))
+	res.Header().Add(echo.HeaderVary, echo.HeaderAcceptEncoding)
+
+	s := &exportStream{res: res}
+	var w io.Writer = res
+	if acceptsGzip(c.Request().Header.Get(echo.HeaderAcceptEncoding)) {
+		res.Header().Set(echo.HeaderContentEncoding, "gzip")
+		s.gz = gzip.NewWriter(res)
+		w = s.gz
+	}
+	res.WriteHeader(http.StatusOK)
+
+	ew, err := export.NewWriter(w, f, header)
+	if err != nil {
+		return nil, err
+	}
+	s.Writer = ew
+	return s, nil
+}
+
+// Flush sends the records written so far to the client.
+func (s *exportStream) Flush() error {
+	if err := s.Writer.Flush(); err != nil {
+		return err
+	}
+	if s.gz != nil {
+		if err := s.gz.Flush(); err != nil {
+			return err
+		}
+	}
+	s.res.Flush()
+	return nil
+}
+
+// Close ends the export. It is not called when the export fails, so that a client sees the output incomplete.
+func (s *exportStream) Close() error {
+	if err := s.Writer.Close(); err != nil {
+		return err
+	}
+	if s.gz != nil {
+		return s.gz.Close()
+	}
+	return nil
+}
+
+func acceptsGzip(acceptEncoding string) bool {
+	for _, e := range strings.Split(acceptEncoding, ",") {
+		coding, params, err := mime.ParseMediaType(strings.TrimSpace(e))
+		if err != nil || coding != "gzip" {
+			continue
+		}
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+			return false
+		}
+		return true
+	}
+	return false
+}
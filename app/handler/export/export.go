@@ -0,0 +1,164 @@
+// Package export writes the records of an export, which are streamed in chunks, in the formats the HTTP API serves.
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+)
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// Format is the format of an export.
+type Format string
+
+const (
+	// FormatCSV is CSV with a header.
+	FormatCSV Format = "csv"
+	// FormatNDJSON is newline delimited JSON objects.
+	FormatNDJSON Format = "ndjson"
+	// FormatJSON is a JSON array of objects.
+	FormatJSON Format = "json"
+)
+
+// ContentTypes are the content types of the formats.
+var ContentTypes = map[Format]string{
+	FormatCSV:    "text/csv",
+	FormatNDJSON: "application/x-ndjson",
+	FormatJSON:   "application/json",
+}
+
+// Negotiate returns the format named by the format parameter if given, otherwise the first format the Accept header
+// accepts. JSON is the default when the header accepts any or none of the formats.
+func Negotiate(format, accept string) (Format, error) {
+	if format != "" {
+		f := Format(strings.ToLower(format))
+		if _, ok := ContentTypes[f]; !ok {
+			return "", fmt.Errorf("format must be csv, ndjson or json: %q: %w", format, ErrUnknownFormat)
+		}
+		return f, nil
+	}
+
+	for _, r := range strings.Split(accept, ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(r))
+		if err != nil {
+			continue
+		}
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+			continue
+		}
+		for f, ct := range ContentTypes {
+			if ct == mt {
+				return f, nil
+			}
+		}
+	}
+	return FormatJSON, nil
+}
+
+// Writer writes the records of an export.
+type Writer interface {
+	// Write writes a record, of which the value is written in JSON and NDJSON, and the row in CSV.
+	Write(v any, row []string) error
+	// Flush writes the buffered records to the underlying writer.
+	Flush() error
+	// Close ends the export and flushes it. It does not close the underlying writer.
+	Close() error
+}
+
+// NewWriter returns a writer of the format. The header is the columns of the rows in CSV.
+func NewWriter(w io.Writer, f Format, header []string) (Writer, error) {
+	switch f {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(header); err != nil {
+			return nil, err
+		}
+		return &csvWriter{w: cw}, nil
+	case FormatNDJSON:
+		bw := bufio.NewWriter(w)
+		return &ndjsonWriter{w: bw, enc: json.NewEncoder(bw)}, nil
+	case FormatJSON:
+		return &jsonWriter{w: bufio.NewWriter(w)}, nil
+	}
+	return nil, fmt.Errorf("%q: %w", f, ErrUnknownFormat)
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (w *csvWriter) Write(_ any, row []string) error {
+	return w.w.Write(row)
+}
+
+func (w *csvWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *csvWriter) Close() error {
+	return w.Flush()
+}
+
+type ndjsonWriter struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func (w *ndjsonWriter) Write(v any, _ []string) error {
+	return w.enc.Encode(v)
+}
+
+func (w *ndjsonWriter) Flush() error {
+	return w.w.Flush()
+}
+
+func (w *ndjsonWriter) Close() error {
+	return w.Flush()
+}
+
+// jsonWriter writes the records as the elements of an array, which is left unclosed if the export fails halfway
+// so that the output is not mistaken for a complete one.
+type jsonWriter struct {
+	w       *bufio.Writer
+	written bool
+}
+
+func (w *jsonWriter) Write(v any, _ []string) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	sep := ","
+	if !w.written {
+		sep = "["
+		w.written = true
+	}
+	if _, err := w.w.WriteString(sep); err != nil {
+		return err
+	}
+	_, err = w.w.Write(b)
+	return err
+}
+
+func (w *jsonWriter) Flush() error {
+	return w.w.Flush()
+}
+
+func (w *jsonWriter) Close() error {
+	end := "]"
+	if !w.written {
+		end = "[]"
+	}
+	if _, err := w.w.WriteString(end + "\n"); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -0,0 +1,140 @@
+package export_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/export"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		accept  string
+		want    export.Format
+		wantErr error
+	}{
+		{
+			name:   "Returns the format of the parameter over the Accept header",
+			format: "CSV",
+			accept: "application/x-ndjson",
+			want:   export.FormatCSV,
+		},
+		{
+			name:   "Returns the first format the Accept header accepts",
+			accept: "text/html, text/csv;q=0, application/x-ndjson;q=0.5, application/json",
+			want:   export.FormatNDJSON,
+		},
+		{
+			name:   "Returns JSON if the Accept header accepts any",
+			accept: "*/*",
+			want:   export.FormatJSON,
+		},
+		{
+			name:   "Returns JSON without the Accept header",
+			accept: "",
+			want:   export.FormatJSON,
+		},
+		{
+			name:    "Returns error if the format is unknown",
+			format:  "xml",
+			wantErr: export.ErrUnknownFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := export.Negotiate(tt.format, tt.accept)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("export.Negotiate(%q, %q)=_, %v; want _, %v", tt.format, tt.accept, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("export.Negotiate(%q, %q)=%q, nil; want %q, nil", tt.format, tt.accept, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriter(t *testing.T) {
+	type record struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	records := []record{
+		{ID: "TEST_ID_1", Name: "TEST_NAME_1"},
+		{ID: "TEST_ID_2", Name: "TEST_NAME, 2"},
+	}
+
+	tests := []struct {
+		name    string
+		format  export.Format
+		records []record
+		want    string
+	}{
+		{
+			name:    "Writes CSV",
+			format:  export.FormatCSV,
+			records: records,
+			want:    "id,name\nTEST_ID_1,TEST_NAME_1\nTEST_ID_2,\"TEST_NAME, 2\"\n",
+		},
+		{
+			name:    "Writes the header of CSV without records",
+			format:  export.FormatCSV,
+			records: nil,
+			want:    "id,name\n",
+		},
+		{
+			name:    "Writes NDJSON",
+			format:  export.FormatNDJSON,
+			records: records,
+			want: `{"id":"TEST_ID_1","name":"TEST_NAME_1"}` + "\n" +
+				`{"id":"TEST_ID_2","name":"TEST_NAME, 2"}` + "\n",
+		},
+		{
+			name:    "Writes JSON",
+			format:  export.FormatJSON,
+			records: records,
+			want:    `[{"id":"TEST_ID_1","name":"TEST_NAME_1"},{"id":"TEST_ID_2","name":"TEST_NAME, 2"}]` + "\n",
+		},
+		{
+			name:    "Writes an empty array of JSON without records",
+			format:  export.FormatJSON,
+			records: nil,
+			want:    "[]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := export.NewWriter(&buf, tt.format, []string{"id", "name"})
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			for i, r := range tt.records {
+				if err := w.Write(r, []string{r.ID, r.Name}); err != nil {
+					t.Fatalf("want no err, but has error %v", err)
+				}
+				// Flushing between the records does not change the output.
+				if i == 0 {
+					if err := w.Flush(); err != nil {
+						t.Fatalf("want no err, but has error %v", err)
+					}
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("output=%q; want %q", got, tt.want)
+			}
+		})
+	}
+}
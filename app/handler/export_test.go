@@ -0,0 +1,27 @@
+package handler_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http/httptest"
+	"testing"
+)
+
+// readExport reads the body of the response of an export, decompressing it if it is compressed in gzip.
+func readExport(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	var r io.Reader = rec.Body
+	if rec.Header().Get("Content-Encoding") == "gzip" {
+		gr, err := gzip.NewReader(rec.Body)
+		if err != nil {
+			t.Fatalf("Failed to read gzip: %s", err.Error())
+		}
+		r = gr
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Failed to read body: %s", err.Error())
+	}
+	return string(b)
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+)
+
+type GraphQLHandler struct {
+	guc    usecase.GroupUsecase
+	schema graphql.Schema
+	limits GraphQLLimits
+}
+
+func NewGraphQLHandler(uuc usecase.UserUsecase, guc usecase.GroupUsecase, limits GraphQLLimits) (*GraphQLHandler, error) {
+	schema, err := newGraphQLSchema(uuc, guc)
+	if err != nil {
+		return nil, err
+	}
+	return &GraphQLHandler{guc: guc, schema: schema, limits: limits}, nil
+}
+
+type GraphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+func (h *GraphQLHandler) Query(c echo.Context) error {
+	req := &GraphQLRequest{}
+	if err := c.Bind(req); err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	// A query which cannot be parsed is left to the execution, which reports the syntax error.
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(req.Query)})})
+	if err == nil {
+		depth, complexity := measureQuery(h.schema, doc, req.OperationName)
+		if h.limits.MaxDepth > 0 && depth > h.limits.MaxDepth {
+			return c.JSON(http.StatusBadRequest, newGraphQLErrorResult(
+				"the query depth %d exceeds the limit %d", depth, h.limits.MaxDepth,
+			))
+		}
+		if h.limits.MaxComplexity > 0 && complexity > h.limits.MaxComplexity {
+			return c.JSON(http.StatusBadRequest, newGraphQLErrorResult(
+				"the query complexity %d exceeds the limit %d", complexity, h.limits.MaxComplexity,
+			))
+		}
+	}
+
+	ctx := withGraphQLContext(c.Request().Context(), &graphQLContext{
+		meta:    newMeta(c),
+		loaders: newGraphQLLoaders(h.guc),
+	})
+	res := graphql.Do(graphql.Params{
+		Schema:         h.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        ctx,
+	})
+
+	return response.OK(c, res)
+}
+
+func newGraphQLErrorResult(format string, args ...any) *graphql.Result {
+	err := gqlerrors.NewFormattedError(fmt.Sprintf(format, args...))
+	err.Extensions = map[string]any{"code": response.ErrorCodeInvalidArguments}
+	return &graphql.Result{Errors: []gqlerrors.FormattedError{err}}
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// graphQLListCost is the factor multiplying the complexity of the fields selected under a list,
+// since they are resolved once per item.
+const graphQLListCost = 10
+
+// GraphQLLimits limits the queries accepted by the GraphQL endpoint. Zero values are ignored.
+type GraphQLLimits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// queryMeasurer measures the depth and complexity of an operation against the schema.
+// Introspection fields are not counted.
+type queryMeasurer struct {
+	schema    graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+}
+
+// measureQuery returns the depth and complexity of the operation of the document.
+// It returns zeros when the operation is not found, which is reported by the execution.
+func measureQuery(schema graphql.Schema, doc *ast.Document, operationName string) (depth, complexity int) {
+	m := &queryMeasurer{
+		schema:    schema,
+		fragments: map[string]*ast.FragmentDefinition{},
+	}
+
+	var op *ast.OperationDefinition
+	for _, d := range doc.Definitions {
+		switch d := d.(type) {
+		case *ast.FragmentDefinition:
+			m.fragments[d.Name.Value] = d
+		case *ast.OperationDefinition:
+			if operationName == "" || (d.Name != nil && d.Name.Value == operationName) {
+				op = d
+			}
+		}
+	}
+	if op == nil {
+		return 0, 0
+	}
+
+	var root graphql.Type = schema.QueryType()
+	if op.Operation == ast.OperationTypeMutation {
+		root = schema.MutationType()
+	}
+	return m.measure(root, op.SelectionSet, map[string]bool{})
+}
+
+func (m *queryMeasurer) measure(t graphql.Type, ss *ast.SelectionSet, spread map[string]bool) (depth, complexity int) {
+	if ss == nil {
+		return 0, 0
+	}
+
+	obj, _ := t.(*graphql.Object)
+	for _, s := range ss.Selections {
+		switch s := s.(type) {
+		case *ast.Field:
+			name := s.Name.Value
+			if strings.HasPrefix(name, "__") {
+				continue
+			}
+
+			var ft graphql.Type
+			if obj != nil {
+				if fd, ok := obj.Fields()[name]; ok {
+					ft = fd.Type
+				}
+			}
+			named, isList := unwrapGraphQLType(ft)
+
+			d, c := m.measure(named, s.SelectionSet, spread)
+			if isList {
+				c *= graphQLListCost
+			}
+			depth = maxInt(depth, d+1)
+			complexity += c + 1
+		case *ast.InlineFragment:
+			ct := t
+			if s.TypeCondition != nil {
+				ct = m.schema.Type(s.TypeCondition.Name.Value)
+			}
+			d, c := m.measure(ct, s.SelectionSet, spread)
+			depth = maxInt(depth, d)
+			complexity += c
+		case *ast.FragmentSpread:
+			name := s.Name.Value
+			f, ok := m.fragments[name]
+			// A cyclic spread is invalid and reported by the validation.
+			if !ok || spread[name] {
+				continue
+			}
+			spread[name] = true
+			d, c := m.measure(m.schema.Type(f.TypeCondition.Name.Value), f.SelectionSet, spread)
+			delete(spread, name)
+			depth = maxInt(depth, d)
+			complexity += c
+		}
+	}
+	return depth, complexity
+}
+
+func unwrapGraphQLType(t graphql.Type) (named graphql.Type, isList bool) {
+	for {
+		switch v := t.(type) {
+		case *graphql.NonNull:
+			t = v.OfType
+		case *graphql.List:
+			isList = true
+			t = v.OfType
+		default:
+			return t, isList
+		}
+	}
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// batchLoader collects the keys requested while resolving a level of the query
+// and loads all of them in one call when the first result is needed.
+// The GraphQL executor resolves fields serially, so the loader is not safe for concurrent use.
+type batchLoader[K comparable, V any] struct {
+	load    func(keys []K) (map[K]V, error)
+	pending []K
+	results map[K]V
+	errs    map[K]error
+}
+
+func newBatchLoader[K comparable, V any](load func(keys []K) (map[K]V, error)) *batchLoader[K, V] {
+	return &batchLoader[K, V]{
+		load:    load,
+		results: map[K]V{},
+		errs:    map[K]error{},
+	}
+}
+
+// Load registers the key and returns a thunk resolving its value.
+func (l *batchLoader[K, V]) Load(key K) func() (V, error) {
+	if !l.has(key) {
+		l.pending = append(l.pending, key)
+	}
+	return func() (V, error) {
+		if len(l.pending) > 0 {
+			l.flush()
+		}
+		return l.results[key], l.errs[key]
+	}
+}
+
+func (l *batchLoader[K, V]) has(key K) bool {
+	if _, ok := l.results[key]; ok {
+		return true
+	}
+	if _, ok := l.errs[key]; ok {
+		return true
+	}
+	for _, k := range l.pending {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *batchLoader[K, V]) flush() {
+	keys := l.pending
+	l.pending = nil
+
+	vs, err := l.load(keys)
+	for _, k := range keys {
+		if err != nil {
+			l.errs[k] = err
+			continue
+		}
+		l.results[k] = vs[k]
+	}
+}
+
+// graphQLLoaders are the loaders of a GraphQL request.
+type graphQLLoaders struct {
+	groupsByUserID *batchLoader[string, []dto.Group]
+}
+
+func newGraphQLLoaders(guc usecase.GroupUsecase) *graphQLLoaders {
+	return &graphQLLoaders{
+		groupsByUserID: newBatchLoader(func(uIDs []string) (map[string][]dto.Group, error) {
+			out, err := guc.GetGroups(&dto.GetGroupsInput{UserIDs: uIDs})
+			if err != nil {
+				return nil, err
+			}
+
+			gsByUID := make(map[string][]dto.Group, len(uIDs))
+			for _, uID := range uIDs {
+				gsByUID[uID] = []dto.Group{}
+			}
+			for _, g := range out.Groups {
+				for _, u := range g.Users {
+					if gs, ok := gsByUID[u.UserID]; ok {
+						gsByUID[u.UserID] = append(gs, g)
+					}
+				}
+			}
+			return gsByUID, nil
+		}),
+	}
+}
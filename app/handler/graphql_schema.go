@@ -0,0 +1,375 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/graphql-go/graphql"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+type graphQLContextKey struct{}
+
+// graphQLContext carries the request scoped values to the resolvers.
+type graphQLContext struct {
+	meta    dto.Meta
+	loaders *graphQLLoaders
+}
+
+func withGraphQLContext(ctx context.Context, gc *graphQLContext) context.Context {
+	return context.WithValue(ctx, graphQLContextKey{}, gc)
+}
+
+func graphQLContextFrom(ctx context.Context) *graphQLContext {
+	gc, _ := ctx.Value(graphQLContextKey{}).(*graphQLContext)
+	return gc
+}
+
+// graphQLError is a resolver error carrying the error code as its extension.
+type graphQLError struct {
+	code response.ErrorCode
+	err  error
+}
+
+func (e *graphQLError) Error() string {
+	return e.err.Error()
+}
+
+func (e *graphQLError) Extensions() map[string]any {
+	return map[string]any{"code": e.code}
+}
+
+func toGraphQLError(err error) error {
+	code := response.ErrorCodeInternalServerError
+	switch {
+	case errors.Is(err, usecase.ErrUserNotFound):
+		code = response.ErrorCodeUserNotFound
+	case errors.Is(err, usecase.ErrGroupNotFound):
+		code = response.ErrorCodeGroupNotFound
+	case errors.Is(err, usecase.ErrInvalidUserInput),
+		errors.Is(err, usecase.ErrInvalidGroupInput),
+		errors.Is(err, usecase.ErrInvalidUserIDs):
+		code = response.ErrorCodeInvalidArguments
+	case errors.Is(err, usecase.ErrUserInactive):
+		code = response.ErrorCodeUserInactive
+	}
+	return &graphQLError{code: code, err: err}
+}
+
+func newGraphQLSchema(uuc usecase.UserUsecase, guc usecase.GroupUsecase) (graphql.Schema, error) {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(dto.User).UserID, nil
+				},
+			},
+			"name": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(dto.User).Name, nil
+				},
+			},
+			"email": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(dto.User).Email, nil
+				},
+			},
+		},
+	})
+
+	groupType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Group",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(dto.Group).GroupID, nil
+				},
+			},
+			"name": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(dto.Group).Name, nil
+				},
+			},
+			"users": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userType))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(dto.Group).Users, nil
+				},
+			},
+		},
+	})
+
+	// The groups of users are loaded in a batch for all the users of a query level.
+	userType.AddFieldConfig("groups", &graphql.Field{
+		Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(groupType))),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			thunk := graphQLContextFrom(p.Context).loaders.groupsByUserID.Load(p.Source.(dto.User).UserID)
+			return func() (any, error) {
+				gs, err := thunk()
+				if err != nil {
+					return nil, toGraphQLError(err)
+				}
+				return gs, nil
+			}, nil
+		},
+	})
+
+	idList := graphql.NewList(graphql.NewNonNull(graphql.ID))
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"user": &graphql.Field{
+				Type: userType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					out, err := uuc.GetUser(&dto.GetUserInput{
+						UserID: p.Args["id"].(string),
+					})
+					if err != nil {
+						return nil, toGraphQLError(err)
+					}
+					return out.User, nil
+				},
+			},
+			"users": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userType))),
+				Args: graphql.FieldConfigArgument{
+					"ids": &graphql.ArgumentConfig{Type: idList},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					out, err := uuc.GetUsers(&dto.GetUsersInput{
+						UserIDs: graphQLStrings(p.Args["ids"]),
+					})
+					if err != nil {
+						return nil, toGraphQLError(err)
+					}
+					return out.Users, nil
+				},
+			},
+			"group": &graphql.Field{
+				Type: groupType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					out, err := guc.GetGroup(&dto.GetGroupInput{
+						GroupID: p.Args["id"].(string),
+					})
+					if err != nil {
+						return nil, toGraphQLError(err)
+					}
+					return out.Group, nil
+				},
+			},
+			"groups": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(groupType))),
+				Args: graphql.FieldConfigArgument{
+					"userIds": &graphql.ArgumentConfig{Type: idList},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					out, err := guc.GetGroups(&dto.GetGroupsInput{
+						UserIDs: graphQLStrings(p.Args["userIds"]),
+					})
+					if err != nil {
+						return nil, toGraphQLError(err)
+					}
+					return out.Groups, nil
+				},
+			},
+		},
+	})
+
+	mutationType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createUser": &graphql.Field{
+				Type: graphql.NewNonNull(userType),
+				Args: graphql.FieldConfigArgument{
+					"name":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"email": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					out, err := uuc.CreateUser(&dto.CreateUserInput{
+						Meta:  graphQLContextFrom(p.Context).meta,
+						Name:  p.Args["name"].(string),
+						Email: p.Args["email"].(string),
+					})
+					if err != nil {
+						return nil, toGraphQLError(err)
+					}
+					return out.User, nil
+				},
+			},
+			"updateUser": &graphql.Field{
+				Type: graphql.NewNonNull(userType),
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"name":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"email": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					uID := p.Args["id"].(string)
+					if _, err := uuc.UpdateUser(&dto.UpdateUserInput{
+						Meta:   graphQLContextFrom(p.Context).meta,
+						UserID: uID,
+						Name:   p.Args["name"].(string),
+						Email:  p.Args["email"].(string),
+					}); err != nil {
+						return nil, toGraphQLError(err)
+					}
+					return getGraphQLUser(uuc, uID)
+				},
+			},
+			"deleteUser": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					uID := p.Args["id"].(string)
+					if _, err := uuc.DeleteUser(&dto.DeleteUserInput{
+						Meta:   graphQLContextFrom(p.Context).meta,
+						UserID: uID,
+					}); err != nil {
+						return nil, toGraphQLError(err)
+					}
+					return uID, nil
+				},
+			},
+			"createGroup": &graphql.Field{
+				Type: graphql.NewNonNull(groupType),
+				Args: graphql.FieldConfigArgument{
+					"name":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"userIds": &graphql.ArgumentConfig{Type: idList},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					out, err := guc.CreateGroup(&dto.CreateGroupInput{
+						Meta:    graphQLContextFrom(p.Context).meta,
+						Name:    p.Args["name"].(string),
+						UserIDs: graphQLStrings(p.Args["userIds"]),
+					})
+					if err != nil {
+						return nil, toGraphQLError(err)
+					}
+					return out.Group, nil
+				},
+			},
+			"updateGroup": &graphql.Field{
+				Type: graphql.NewNonNull(groupType),
+				Args: graphql.FieldConfigArgument{
+					"id":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					gID := p.Args["id"].(string)
+					if _, err := guc.UpdateGroup(&dto.UpdateGroupInput{
+						Meta:    graphQLContextFrom(p.Context).meta,
+						GroupID: gID,
+						Name:    p.Args["name"].(string),
+					}); err != nil {
+						return nil, toGraphQLError(err)
+					}
+					return getGraphQLGroup(guc, gID)
+				},
+			},
+			"deleteGroup": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					gID := p.Args["id"].(string)
+					if _, err := guc.DeleteGroup(&dto.DeleteGroupInput{
+						Meta:    graphQLContextFrom(p.Context).meta,
+						GroupID: gID,
+					}); err != nil {
+						return nil, toGraphQLError(err)
+					}
+					return gID, nil
+				},
+			},
+			"addGroupUsers": &graphql.Field{
+				Type: graphql.NewNonNull(groupType),
+				Args: graphql.FieldConfigArgument{
+					"id":      &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"userIds": &graphql.ArgumentConfig{Type: graphql.NewNonNull(idList)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					gID := p.Args["id"].(string)
+					if _, err := guc.AddGroupUsers(&dto.AddGroupUsersInput{
+						Meta:    graphQLContextFrom(p.Context).meta,
+						GroupID: gID,
+						UserIDs: graphQLStrings(p.Args["userIds"]),
+					}); err != nil {
+						return nil, toGraphQLError(err)
+					}
+					return getGraphQLGroup(guc, gID)
+				},
+			},
+			"removeGroupUsers": &graphql.Field{
+				Type: graphql.NewNonNull(groupType),
+				Args: graphql.FieldConfigArgument{
+					"id":      &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"userIds": &graphql.ArgumentConfig{Type: graphql.NewNonNull(idList)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					gID := p.Args["id"].(string)
+					if _, err := guc.RemoveGroupUsers(&dto.RemoveGroupUsersInput{
+						Meta:    graphQLContextFrom(p.Context).meta,
+						GroupID: gID,
+						UserIDs: graphQLStrings(p.Args["userIds"]),
+					}); err != nil {
+						return nil, toGraphQLError(err)
+					}
+					return getGraphQLGroup(guc, gID)
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    queryType,
+		Mutation: mutationType,
+	})
+}
+
+func getGraphQLUser(uc usecase.UserUsecase, uID string) (any, error) {
+	out, err := uc.GetUser(&dto.GetUserInput{UserID: uID})
+	if err != nil {
+		return nil, toGraphQLError(err)
+	}
+	return out.User, nil
+}
+
+func getGraphQLGroup(uc usecase.GroupUsecase, gID string) (any, error) {
+	out, err := uc.GetGroup(&dto.GetGroupInput{GroupID: gID})
+	if err != nil {
+		return nil, toGraphQLError(err)
+	}
+	return out.Group, nil
+}
+
+// graphQLStrings converts the list argument to strings. It returns nil when the argument is omitted.
+func graphQLStrings(v any) []string {
+	vs, ok := v.([]any)
+	if !ok {
+		return nil
+	}
+	ss := make([]string, len(vs))
+	for i, v := range vs {
+		ss[i], _ = v.(string)
+	}
+	return ss
+}
@@ -0,0 +1,202 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/labstack/echo"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler"
+	mockusecase "github.com/toshiykst/go-layerd-architecture/app/mock/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestGraphQLHandler_Query(t *testing.T) {
+	user1 := dto.User{UserID: "TEST_USER_ID_1", Name: "TEST_USER_NAME_1", Email: "TEST_USER_EMAIL_1"}
+	user2 := dto.User{UserID: "TEST_USER_ID_2", Name: "TEST_USER_NAME_2", Email: "TEST_USER_EMAIL_2"}
+	group1 := dto.Group{GroupID: "TEST_GROUP_ID_1", Name: "TEST_GROUP_NAME_1", Users: []dto.User{user1}}
+	group2 := dto.Group{GroupID: "TEST_GROUP_ID_2", Name: "TEST_GROUP_NAME_2", Users: []dto.User{user1, user2}}
+
+	tests := []struct {
+		name            string
+		body            string
+		limits          handler.GraphQLLimits
+		newUserUsecase  func(ctrl *gomock.Controller) usecase.UserUsecase
+		newGroupUsecase func(ctrl *gomock.Controller) usecase.GroupUsecase
+		wantStatus      int
+		wantRes         string
+	}{
+		{
+			name: "Loads the groups of all the users of the groups in one batch",
+			body: `{"query":"{ groups { id users { id groups { id } } } }"}`,
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				return mockusecase.NewMockUserUsecase(ctrl)
+			},
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					GetGroups(&dto.GetGroupsInput{}).
+					Return(&dto.GetGroupsOutput{Groups: []dto.Group{group1, group2}}, nil).
+					Times(1)
+				uc.EXPECT().
+					GetGroups(gomock.Any()).
+					DoAndReturn(func(in *dto.GetGroupsInput) (*dto.GetGroupsOutput, error) {
+						got := append([]string{}, in.UserIDs...)
+						sort.Strings(got)
+						if diff := cmp.Diff(got, []string{"TEST_USER_ID_1", "TEST_USER_ID_2"}); diff != "" {
+							t.Errorf("uc.GetGroups() user ids=%v\ndiffers: (-got +want)\n%s", in.UserIDs, diff)
+						}
+						return &dto.GetGroupsOutput{Groups: []dto.Group{group1, group2}}, nil
+					}).
+					Times(1)
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":{"groups":[
+				{"id":"TEST_GROUP_ID_1","users":[
+					{"id":"TEST_USER_ID_1","groups":[{"id":"TEST_GROUP_ID_1"},{"id":"TEST_GROUP_ID_2"}]}
+				]},
+				{"id":"TEST_GROUP_ID_2","users":[
+					{"id":"TEST_USER_ID_1","groups":[{"id":"TEST_GROUP_ID_1"},{"id":"TEST_GROUP_ID_2"}]},
+					{"id":"TEST_USER_ID_2","groups":[{"id":"TEST_GROUP_ID_2"}]}
+				]}
+			]}}`,
+		},
+		{
+			name: "Creates a user as the actor",
+			body: `{"query":"mutation($name: String!) { createUser(name: $name, email: \"TEST_USER_EMAIL_1\") { id name } }",` +
+				`"variables":{"name":"TEST_USER_NAME_1"}}`,
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					CreateUser(&dto.CreateUserInput{
+						Meta:  dto.Meta{Actor: "TEST_ACTOR"},
+						Name:  "TEST_USER_NAME_1",
+						Email: "TEST_USER_EMAIL_1",
+					}).
+					Return(&dto.CreateUserOutput{User: user1}, nil)
+				return uc
+			},
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				return mockusecase.NewMockGroupUsecase(ctrl)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"data":{"createUser":{"id":"TEST_USER_ID_1","name":"TEST_USER_NAME_1"}}}`,
+		},
+		{
+			name: "Returns the error code of the usecase error",
+			body: `{"query":"{ user(id: \"TEST_USER_ID_1\") { id } }"}`,
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					GetUser(&dto.GetUserInput{UserID: "TEST_USER_ID_1"}).
+					Return(nil, usecase.ErrUserNotFound)
+				return uc
+			},
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				return mockusecase.NewMockGroupUsecase(ctrl)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":{"user":null},"errors":[{"message":"user not found",` +
+				`"locations":[{"line":1,"column":3}],"path":["user"],"extensions":{"code":"USER_NOT_FOUND"}}]}`,
+		},
+		{
+			name:   "Rejects a query deeper than the limit",
+			body:   `{"query":"{ groups { users { groups { id } } } }"}`,
+			limits: handler.GraphQLLimits{MaxDepth: 3},
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				return mockusecase.NewMockUserUsecase(ctrl)
+			},
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				return mockusecase.NewMockGroupUsecase(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"data":null,"errors":[{"message":"the query depth 4 exceeds the limit 3",` +
+				`"locations":[],"extensions":{"code":"INVALID_ARGUMENTS"}}]}`,
+		},
+		{
+			name:   "Rejects a query more complex than the limit",
+			body:   `{"query":"query Q { ...F } fragment F on Query { groups { id users { id } } }"}`,
+			limits: handler.GraphQLLimits{MaxComplexity: 100},
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				return mockusecase.NewMockUserUsecase(ctrl)
+			},
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				return mockusecase.NewMockGroupUsecase(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"data":null,"errors":[{"message":"the query complexity 121 exceeds the limit 100",` +
+				`"locations":[],"extensions":{"code":"INVALID_ARGUMENTS"}}]}`,
+		},
+		{
+			name:   "Does not count introspection against the limits",
+			body:   `{"query":"{ __schema { queryType { fields { type { ofType { ofType { name } } } } } } }"}`,
+			limits: handler.GraphQLLimits{MaxDepth: 1, MaxComplexity: 1},
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				return mockusecase.NewMockUserUsecase(ctrl)
+			},
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				return mockusecase.NewMockGroupUsecase(ctrl)
+			},
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(handler.HeaderXActorID, "TEST_ACTOR")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			h, err := handler.NewGraphQLHandler(tt.newUserUsecase(ctrl), tt.newGroupUsecase(ctrl), tt.limits)
+			if err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if err := h.Query(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			res := rec.Result()
+			defer func(Body io.ReadCloser) {
+				if err := Body.Close(); err != nil {
+					t.Fatalf("Failed to close body: %s", err.Error())
+				}
+			}(res.Body)
+
+			if res.StatusCode != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", res.StatusCode, tt.wantStatus)
+			}
+
+			if tt.wantRes != "" {
+				var got, want any
+				if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+					t.Fatalf("Failed to unmarshal body: %s", err.Error())
+				}
+				if err := json.Unmarshal([]byte(tt.wantRes), &want); err != nil {
+					t.Fatalf("Failed to unmarshal want: %s", err.Error())
+				}
+				if diff := cmp.Diff(got, want); diff != "" {
+					t.Errorf(
+						"response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, want, diff,
+					)
+				}
+			}
+		})
+	}
+}
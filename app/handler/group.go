@@ -2,10 +2,13 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo"
 
+	"github.com/toshiykst/go-layerd-architecture/app/handler/export"
 	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
 	"github.com/toshiykst/go-layerd-architecture/app/usecase"
 	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
@@ -22,7 +25,11 @@ func NewGroupHandler(uc usecase.GroupUsecase) *GroupHandler {
 type (
 	CreateGroupRequest struct {
 		Name    string   `json:"name"`
-		UserIDs []string `json:"userIds"`
+		UserIDs []string `json:"userIds,omitempty"`
+		// JoinPolicy is OPEN, APPROVAL or INVITE_ONLY, and OPEN if it is empty.
+		JoinPolicy string `json:"joinPolicy,omitempty"`
+		// Attributes are the values of the custom attributes of the group by their keys.
+		Attributes map[string]any `json:"attributes,omitempty"`
 	}
 
 	CreateGroupResponse struct {
@@ -37,8 +44,11 @@ func (h *GroupHandler) CreateGroup(c echo.Context) error {
 	}
 
 	in := &dto.CreateGroupInput{
-		Name:    req.Name,
-		UserIDs: req.UserIDs,
+		Meta:       newMeta(c),
+		Name:       req.Name,
+		UserIDs:    req.UserIDs,
+		JoinPolicy: req.JoinPolicy,
+		Attributes: req.Attributes,
 	}
 	out, err := h.uc.CreateGroup(in)
 	if err != nil {
@@ -48,23 +58,23 @@ func (h *GroupHandler) CreateGroup(c echo.Context) error {
 		if errors.Is(err, usecase.ErrInvalidUserIDs) {
 			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
 		}
+		if errors.Is(err, usecase.ErrUserInactive) {
+			return response.Error(c, response.ErrorCodeUserInactive, http.StatusConflict, err)
+		}
 		return response.ErrorInternal(c, err)
 	}
 
-	us := make([]response.User, len(out.Group.Users))
-	for i, u := range out.Group.Users {
-		us[i] = response.User{
-			UserID: u.UserID,
-			Name:   u.Name,
-			Email:  u.Email,
-		}
-	}
+	us := response.ToUsersFromDTO(out.Group.Users)
 
 	return response.Created(c, &CreateGroupResponse{
 		Group: response.Group{
-			GroupID: out.Group.GroupID,
-			Name:    out.Group.Name,
-			Users:   us,
+			GroupID:    out.Group.GroupID,
+			Name:       out.Group.Name,
+			JoinPolicy: out.Group.JoinPolicy,
+			Attributes: out.Group.Attributes,
+			Labels:     out.Group.Labels,
+			Users:      us,
+			Timestamps: response.ToTimestampsFromDTO(out.Group.Timestamps),
 		},
 	})
 }
@@ -87,43 +97,90 @@ func (h *GroupHandler) GetGroup(c echo.Context) error {
 			return response.ErrorInternal(c, err)
 		}
 	}
+	if notModified(c, out.Group.UpdatedAt) {
+		return response.NotModified(c)
+	}
 
 	return response.OK(c, &GetGroupResponse{
 		Group: response.Group{
-			GroupID: out.Group.GroupID,
-			Name:    out.Group.Name,
-			Users:   response.ToUsersFromDTO(out.Group.Users),
+			GroupID:    out.Group.GroupID,
+			Name:       out.Group.Name,
+			JoinPolicy: out.Group.JoinPolicy,
+			Attributes: out.Group.Attributes,
+			Labels:     out.Group.Labels,
+			Users:      response.ToUsersFromDTO(out.Group.Users),
+			Timestamps: response.ToTimestampsFromDTO(out.Group.Timestamps),
 		},
 	})
 }
 
 type GetGroupsResponse struct {
 	Groups []response.Group `json:"groups"`
+	// Highlights are the matches of the query in the groups by group id, when searched with q.
+	Highlights map[string][]response.Highlight `json:"highlights,omitempty"`
 }
 
+// GetGroups lists the groups, or searches them by name with the query parameter q, ordered by relevance.
+// The groups are narrowed down to the ones all of the comma-separated allUserIds belong to, to the ones with no users
+// with noUsers, to the ones at capacity with full, to the ones with all the comma-separated key:value pairs of
+// attributes, and to the ones whose labels meet the label selector, e.g. team in (payments,search),env!=prod.
+// They are ordered by createdAt or updatedAt with sort, descending if prefixed with "-", instead.
 func (h *GroupHandler) GetGroups(c echo.Context) error {
-	out, err := h.uc.GetGroups(nil)
+	noUsers, err := parseBoolParam(c, "noUsers")
+	if err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+	full, err := parseBoolParam(c, "full")
+	if err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+	attributes, err := parseAttributesParam(c, "attributes")
 	if err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	out, err := h.uc.GetGroups(&dto.GetGroupsInput{
+		Query:         c.QueryParam("q"),
+		AllUserIDs:    listParam(c, "allUserIds"),
+		NoUsers:       noUsers,
+		Full:          full,
+		Attributes:    attributes,
+		LabelSelector: c.QueryParam("selector"),
+		Sort:          c.QueryParam("sort"),
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidGroupInput) {
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		}
 		return response.ErrorInternal(c, err)
 	}
 
 	gs := make([]response.Group, len(out.Groups))
 	for i, g := range out.Groups {
 		gs[i] = response.Group{
-			GroupID: g.GroupID,
-			Name:    g.Name,
-			Users:   response.ToUsersFromDTO(g.Users),
+			GroupID:    g.GroupID,
+			Name:       g.Name,
+			JoinPolicy: g.JoinPolicy,
+			Attributes: g.Attributes,
+			Labels:     g.Labels,
+			Users:      response.ToUsersFromDTO(g.Users),
+			Timestamps: response.ToTimestampsFromDTO(g.Timestamps),
 		}
 	}
 
 	return response.OK(c, &GetGroupsResponse{
-		Groups: gs,
+		Groups:     gs,
+		Highlights: response.ToHighlightsFromDTO(out.Highlights),
 	})
 }
 
 type (
 	UpdateGroupRequest struct {
 		Name string `json:"name"`
+		// JoinPolicy is OPEN, APPROVAL or INVITE_ONLY, and kept as it is if it is empty.
+		JoinPolicy string `json:"joinPolicy,omitempty"`
+		// Attributes replace the custom attributes of the group, which are kept as they are if it is omitted.
+		Attributes map[string]any `json:"attributes,omitempty"`
 	}
 )
 
@@ -136,8 +193,11 @@ func (h *GroupHandler) UpdateGroup(c echo.Context) error {
 	}
 
 	in := &dto.UpdateGroupInput{
-		GroupID: gID,
-		Name:    req.Name,
+		Meta:       newMeta(c),
+		GroupID:    gID,
+		Name:       req.Name,
+		JoinPolicy: req.JoinPolicy,
+		Attributes: req.Attributes,
 	}
 
 	_, err := h.uc.UpdateGroup(in)
@@ -155,10 +215,57 @@ func (h *GroupHandler) UpdateGroup(c echo.Context) error {
 	return response.NoContent(c)
 }
 
+type (
+	// PatchGroupRequest is the JSON Merge Patch of a group.
+	PatchGroupRequest struct {
+		Name       *string        `json:"name,omitempty"`
+		JoinPolicy *string        `json:"joinPolicy,omitempty"`
+		UserIDs    []string       `json:"userIds,omitempty"`
+		Attributes map[string]any `json:"attributes,omitempty"`
+	}
+)
+
+// PatchGroup patches the group with a JSON Merge Patch or a JSON Patch, which may change its members.
+func (h *GroupHandler) PatchGroup(c echo.Context) error {
+	pt, patch, err := bindPatch(c)
+	if err != nil {
+		if errors.Is(err, errUnsupportedPatchType) {
+			return response.Error(c, response.ErrorCodeUnsupportedMediaType, http.StatusUnsupportedMediaType, err)
+		}
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	in := &dto.PatchGroupInput{
+		Meta:      newMeta(c),
+		GroupID:   c.Param("id"),
+		PatchType: pt,
+		Patch:     patch,
+	}
+
+	_, err = h.uc.PatchGroup(in)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidPatch) ||
+			errors.Is(err, usecase.ErrInvalidGroupInput) ||
+			errors.Is(err, usecase.ErrInvalidUserIDs) {
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		}
+		if errors.Is(err, usecase.ErrGroupNotFound) {
+			return response.Error(c, response.ErrorCodeGroupNotFound, http.StatusNotFound, err)
+		}
+		if errors.Is(err, usecase.ErrUserInactive) {
+			return response.Error(c, response.ErrorCodeUserInactive, http.StatusConflict, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.NoContent(c)
+}
+
 func (h *GroupHandler) DeleteGroup(c echo.Context) error {
 	gID := c.Param("id")
 
 	in := &dto.DeleteGroupInput{
+		Meta:    newMeta(c),
 		GroupID: gID,
 	}
 
@@ -173,3 +280,91 @@ func (h *GroupHandler) DeleteGroup(c echo.Context) error {
 
 	return response.NoContent(c)
 }
+
+// The membership query parameter of ExportGroups.
+const (
+	// ExportMembershipNested exports a group with its users, whose ids are joined with spaces in CSV.
+	ExportMembershipNested = "nested"
+	// ExportMembershipFlat exports a pair of a group and a user for each user of each group.
+	ExportMembershipFlat = "flat"
+)
+
+// ExportGroups streams all the groups in CSV, NDJSON or JSON, chosen by the format query parameter or the Accept
+// header. The membership query parameter chooses whether the users are nested in the groups or flattened.
+func (h *GroupHandler) ExportGroups(c echo.Context) error {
+	f, err := export.Negotiate(c.QueryParam("format"), c.Request().Header.Get(echo.HeaderAccept))
+	if err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+	membership := c.QueryParam("membership")
+	if membership == "" {
+		membership = ExportMembershipNested
+	}
+	if membership != ExportMembershipNested && membership != ExportMembershipFlat {
+		return response.Error(
+			c, response.ErrorCodeInvalidArguments, http.StatusBadRequest,
+			fmt.Errorf("membership must be nested or flat: %q", membership),
+		)
+	}
+
+	in := &dto.ExportGroupsInput{Limit: ExportChunkSize}
+	out, err := h.uc.ExportGroups(in)
+	if err != nil {
+		return response.ErrorInternal(c, err)
+	}
+
+	name, header := "groups", []string{"groupId", "name", "userIds"}
+	if membership == ExportMembershipFlat {
+		name, header = "group-memberships", []string{"groupId", "userId"}
+	}
+	// The response is committed from here, so an error only aborts the stream.
+	s, err := startExport(c, f, name, header)
+	if err != nil {
+		return err
+	}
+	for {
+		for _, g := range out.Groups {
+			if err := writeExportGroup(s, g, membership); err != nil {
+				return err
+			}
+		}
+		if out.NextAfterGroupID == "" {
+			return s.Close()
+		}
+		if err := s.Flush(); err != nil {
+			return err
+		}
+
+		in.AfterGroupID = out.NextAfterGroupID
+		if out, err = h.uc.ExportGroups(in); err != nil {
+			return err
+		}
+	}
+}
+
+func writeExportGroup(s *exportStream, g dto.Group, membership string) error {
+	if membership == ExportMembershipFlat {
+		for _, u := range g.Users {
+			m := response.GroupMembership{GroupID: g.GroupID, UserID: u.UserID}
+			if err := s.Write(m, []string{m.GroupID, m.UserID}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	rg := response.Group{
+		GroupID:    g.GroupID,
+		Name:       g.Name,
+		JoinPolicy: g.JoinPolicy,
+		Attributes: g.Attributes,
+		Labels:     g.Labels,
+		Users:      response.ToUsersFromDTO(g.Users),
+		Timestamps: response.ToTimestampsFromDTO(g.Timestamps),
+	}
+	uIDs := make([]string, len(rg.Users))
+	for i, u := range rg.Users {
+		uIDs[i] = u.UserID
+	}
+	return s.Write(rg, []string{rg.GroupID, rg.Name, strings.Join(uIDs, " ")})
+}
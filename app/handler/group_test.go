@@ -8,7 +8,9 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/labstack/echo"
@@ -450,6 +452,7 @@ func TestGroupHandler_GetGroup(t *testing.T) {
 func TestGroupHandler_GetGroups(t *testing.T) {
 	tests := []struct {
 		name            string
+		query           string
 		newGroupUsecase func(ctrl *gomock.Controller) usecase.GroupUsecase
 		wantStatus      int
 		wantRes         *handler.GetGroupsResponse
@@ -572,6 +575,75 @@ func TestGroupHandler_GetGroups(t *testing.T) {
 			},
 			wantErrRes: nil,
 		},
+		{
+			name:  "Returns groups searched by the query with highlights",
+			query: "?q=team",
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					GetGroups(&dto.GetGroupsInput{Query: "team"}).
+					Return(&dto.GetGroupsOutput{
+						Groups: []dto.Group{
+							{
+								GroupID: "TEST_GROUP_ID_1",
+								Name:    "Team",
+								Users:   []dto.User{},
+							},
+						},
+						Highlights: map[string][]dto.Highlight{
+							"TEST_GROUP_ID_1": {{Field: "name", Start: 0, End: 4}},
+						},
+					}, nil)
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes: &handler.GetGroupsResponse{
+				Groups: []response.Group{
+					{
+						GroupID: "TEST_GROUP_ID_1",
+						Name:    "Team",
+						Users:   []response.User{},
+					},
+				},
+				Highlights: map[string][]response.Highlight{
+					"TEST_GROUP_ID_1": {{Field: "name", Start: 0, End: 4}},
+				},
+			},
+			wantErrRes: nil,
+		},
+		{
+			name:  "Returns groups filtered by the query parameters",
+			query: "?allUserIds=TEST_USER_ID_1,TEST_USER_ID_2&noUsers=false&full=true",
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					GetGroups(&dto.GetGroupsInput{
+						AllUserIDs: []string{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+						Full:       true,
+					}).
+					Return(&dto.GetGroupsOutput{Groups: []dto.Group{}}, nil)
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes: &handler.GetGroupsResponse{
+				Groups: []response.Group{},
+			},
+			wantErrRes: nil,
+		},
+		{
+			name:  "Returns invalid arguments error response if full is not a boolean",
+			query: "?full=yes",
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				return mockusecase.NewMockGroupUsecase(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    nil,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: `full must be a boolean: strconv.ParseBool: parsing "yes": invalid syntax`,
+			},
+		},
 		{
 			name: "Returns internal server error response",
 			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
@@ -594,7 +666,7 @@ func TestGroupHandler_GetGroups(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			req := httptest.NewRequest(
 				http.MethodGet,
-				"https://example.com:8080/groups",
+				"https://example.com:8080/groups"+tt.query,
 				nil,
 			)
 			req.Header.Set("Content-Type", "application/json")
@@ -817,6 +889,177 @@ func TestGroupHandler_UpdateGroup(t *testing.T) {
 	}
 }
 
+func TestGroupHandler_PatchGroup(t *testing.T) {
+	tests := []struct {
+		name            string
+		id              string
+		contentType     string
+		patch           string
+		newGroupUsecase func(ctrl *gomock.Controller) usecase.GroupUsecase
+		wantStatus      int
+		wantErrRes      *response.ErrorResponse
+	}{
+		{
+			name:        "Patch a group",
+			id:          "TEST_GROUP_ID",
+			contentType: string(dto.PatchTypeJSONPatch) + "; charset=utf-8",
+			patch:       `[{"op":"add","path":"/userIds/-","value":"TEST_USER_ID"}]`,
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					PatchGroup(gomock.Any()).
+					DoAndReturn(func(in *dto.PatchGroupInput) (*dto.PatchGroupOutput, error) {
+						want := &dto.PatchGroupInput{
+							GroupID:   "TEST_GROUP_ID",
+							PatchType: dto.PatchTypeJSONPatch,
+							Patch:     []byte(`[{"op":"add","path":"/userIds/-","value":"TEST_USER_ID"}]`),
+						}
+						if diff := cmp.Diff(in, want); diff != "" {
+							t.Errorf("uc.PatchGroup(%v); want %v\ndiffers: (-got +want)\n%s", in, want, diff)
+						}
+						return &dto.PatchGroupOutput{}, nil
+					})
+				return uc
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:        "Returns unsupported media type error response",
+			id:          "TEST_GROUP_ID",
+			contentType: "application/json",
+			patch:       `[{"op":"add","path":"/userIds/-","value":"TEST_USER_ID"}]`,
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				return mockusecase.NewMockGroupUsecase(ctrl)
+			},
+			wantStatus: http.StatusUnsupportedMediaType,
+			wantErrRes: &response.ErrorResponse{
+				Code:   response.ErrorCodeUnsupportedMediaType,
+				Status: http.StatusUnsupportedMediaType,
+				Message: `content type "application/json" must be application/merge-patch+json ` +
+					`or application/json-patch+json: unsupported patch type`,
+			},
+		},
+		{
+			name:        "Returns invalid arguments error response when the patch is invalid",
+			id:          "TEST_GROUP_ID",
+			contentType: string(dto.PatchTypeJSONPatch),
+			patch:       `[{"op":"add","path":"/userIds/-","value":"TEST_USER_ID"}]`,
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					PatchGroup(gomock.Any()).
+					Return(nil, usecase.ErrInvalidPatch)
+				return uc
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: usecase.ErrInvalidPatch.Error(),
+			},
+		},
+		{
+			name:        "Returns invalid arguments error response when the patched group is invalid",
+			id:          "TEST_GROUP_ID",
+			contentType: string(dto.PatchTypeJSONPatch),
+			patch:       `[{"op":"add","path":"/userIds/-","value":"TEST_USER_ID"}]`,
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					PatchGroup(gomock.Any()).
+					Return(nil, usecase.ErrInvalidGroupInput)
+				return uc
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: usecase.ErrInvalidGroupInput.Error(),
+			},
+		},
+		{
+			name:        "Returns group not found error response",
+			id:          "TEST_GROUP_ID",
+			contentType: string(dto.PatchTypeJSONPatch),
+			patch:       `[{"op":"add","path":"/userIds/-","value":"TEST_USER_ID"}]`,
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					PatchGroup(gomock.Any()).
+					Return(nil, usecase.ErrGroupNotFound)
+				return uc
+			},
+			wantStatus: http.StatusNotFound,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeGroupNotFound,
+				Status:  http.StatusNotFound,
+				Message: usecase.ErrGroupNotFound.Error(),
+			},
+		},
+		{
+			name:        "Returns internal server error response",
+			id:          "TEST_GROUP_ID",
+			contentType: string(dto.PatchTypeJSONPatch),
+			patch:       `[{"op":"add","path":"/userIds/-","value":"TEST_USER_ID"}]`,
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					PatchGroup(gomock.Any()).
+					Return(nil, errors.New("an error occurred"))
+				return uc
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInternalServerError,
+				Status:  http.StatusInternalServerError,
+				Message: "an error occurred",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(
+				http.MethodPatch,
+				fmt.Sprintf("https://example.com:8080/groups/%s", tt.id),
+				strings.NewReader(tt.patch),
+			)
+			req.Header.Set("Content-Type", tt.contentType)
+
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			uc := tt.newGroupUsecase(ctrl)
+
+			h := handler.NewGroupHandler(uc)
+
+			if err := h.PatchGroup(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
+			}
+
+			if tt.wantErrRes != nil {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if diff := cmp.Diff(got, tt.wantErrRes); diff != "" {
+					t.Errorf(
+						"error response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantErrRes, diff,
+					)
+				}
+			}
+		})
+	}
+}
+
 func TestGroupHandler_DeleteGroup(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -931,3 +1174,154 @@ func TestGroupHandler_DeleteGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestGroupHandler_ExportGroups(t *testing.T) {
+	ts := dto.Timestamps{
+		CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	tsJSON := `"createdAt":"2023-01-01T00:00:00Z","updatedAt":"2023-01-02T00:00:00Z"`
+	chunks := func(ctrl *gomock.Controller) usecase.GroupUsecase {
+		uc := mockusecase.NewMockGroupUsecase(ctrl)
+		gomock.InOrder(
+			uc.EXPECT().
+				ExportGroups(&dto.ExportGroupsInput{Limit: handler.ExportChunkSize}).
+				Return(&dto.ExportGroupsOutput{
+					Groups: []dto.Group{
+						{
+							GroupID:    "TEST_GROUP_ID_1",
+							Name:       "TEST_GROUP_NAME_1",
+							JoinPolicy: "OPEN",
+							Users: []dto.User{
+								{UserID: "TEST_USER_ID_1", Name: "TEST_USER_NAME_1", Email: "TEST_USER_EMAIL_1", Status: "ACTIVE", Timestamps: ts},
+								{UserID: "TEST_USER_ID_2", Name: "TEST_USER_NAME_2", Email: "TEST_USER_EMAIL_2", Status: "ACTIVE", Timestamps: ts},
+							},
+							Timestamps: ts,
+						},
+					},
+					NextAfterGroupID: "TEST_GROUP_ID_1",
+				}, nil),
+			uc.EXPECT().
+				ExportGroups(&dto.ExportGroupsInput{AfterGroupID: "TEST_GROUP_ID_1", Limit: handler.ExportChunkSize}).
+				Return(&dto.ExportGroupsOutput{
+					Groups: []dto.Group{
+						{
+							GroupID:    "TEST_GROUP_ID_2",
+							Name:       "TEST_GROUP_NAME_2",
+							JoinPolicy: "APPROVAL",
+							Users:      []dto.User{},
+							Timestamps: ts,
+						},
+					},
+				}, nil),
+		)
+		return uc
+	}
+
+	tests := []struct {
+		name            string
+		query           string
+		newGroupUsecase func(ctrl *gomock.Controller) usecase.GroupUsecase
+		wantStatus      int
+		wantBody        string
+		wantErrRes      *response.ErrorResponse
+	}{
+		{
+			name:            "Export groups with their users",
+			query:           "?format=ndjson",
+			newGroupUsecase: chunks,
+			wantStatus:      http.StatusOK,
+			wantBody: `{"groupId":"TEST_GROUP_ID_1","name":"TEST_GROUP_NAME_1","joinPolicy":"OPEN","users":[` +
+				`{"userId":"TEST_USER_ID_1","name":"TEST_USER_NAME_1","email":"TEST_USER_EMAIL_1","status":"ACTIVE",` + tsJSON + `},` +
+				`{"userId":"TEST_USER_ID_2","name":"TEST_USER_NAME_2","email":"TEST_USER_EMAIL_2","status":"ACTIVE",` + tsJSON + `}],` +
+				tsJSON + `}` + "\n" +
+				`{"groupId":"TEST_GROUP_ID_2","name":"TEST_GROUP_NAME_2","joinPolicy":"APPROVAL","users":[],` + tsJSON + `}` + "\n",
+		},
+		{
+			name:            "Export groups with the ids of their users in CSV",
+			query:           "?format=csv",
+			newGroupUsecase: chunks,
+			wantStatus:      http.StatusOK,
+			wantBody: "groupId,name,userIds\n" +
+				"TEST_GROUP_ID_1,TEST_GROUP_NAME_1,TEST_USER_ID_1 TEST_USER_ID_2\n" +
+				"TEST_GROUP_ID_2,TEST_GROUP_NAME_2,\n",
+		},
+		{
+			name:            "Export the flattened membership of groups",
+			query:           "?format=csv&membership=flat",
+			newGroupUsecase: chunks,
+			wantStatus:      http.StatusOK,
+			wantBody: "groupId,userId\n" +
+				"TEST_GROUP_ID_1,TEST_USER_ID_1\n" +
+				"TEST_GROUP_ID_1,TEST_USER_ID_2\n",
+		},
+		{
+			name:  "Returns invalid arguments error response when the membership is unknown",
+			query: "?membership=TEST",
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				return mockusecase.NewMockGroupUsecase(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: `membership must be nested or flat: "TEST"`,
+			},
+		},
+		{
+			name: "Returns internal server error response",
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					ExportGroups(gomock.Any()).
+					Return(nil, errors.New("an error occurred"))
+				return uc
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInternalServerError,
+				Status:  http.StatusInternalServerError,
+				Message: "an error occurred",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "https://example.com:8080/groups/export"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			uc := tt.newGroupUsecase(ctrl)
+
+			h := handler.NewGroupHandler(uc)
+
+			if err := h.ExportGroups(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
+			}
+
+			if tt.wantErrRes != nil {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if diff := cmp.Diff(got, tt.wantErrRes); diff != "" {
+					t.Errorf(
+						"error response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantErrRes, diff,
+					)
+				}
+				return
+			}
+
+			if got := readExport(t, rec); got != tt.wantBody {
+				t.Errorf("response body: got = %q, want = %q", got, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+type GroupInvitationHandler struct {
+	uc usecase.GroupInvitationUsecase
+}
+
+func NewGroupInvitationHandler(uc usecase.GroupInvitationUsecase) *GroupInvitationHandler {
+	return &GroupInvitationHandler{uc: uc}
+}
+
+type (
+	InviteToGroupRequest struct {
+		// Either UserID or Email is the invitee.
+		UserID string `json:"userId"`
+		Email  string `json:"email"`
+	}
+
+	InviteToGroupResponse struct {
+		GroupInvitation response.GroupInvitation `json:"groupInvitation"`
+	}
+)
+
+// InviteToGroup invites a user or an email to the group on behalf of the actor of the request.
+func (h *GroupInvitationHandler) InviteToGroup(c echo.Context) error {
+	req := &InviteToGroupRequest{}
+	if err := c.Bind(req); err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	in := &dto.InviteToGroupInput{
+		Meta:    newMeta(c),
+		GroupID: c.Param("id"),
+		UserID:  req.UserID,
+		Email:   req.Email,
+	}
+
+	out, err := h.uc.InviteToGroup(in)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidGroupInvitationInput) {
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		}
+		if errors.Is(err, usecase.ErrGroupNotFound) {
+			return response.Error(c, response.ErrorCodeGroupNotFound, http.StatusNotFound, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.Created(c, &InviteToGroupResponse{
+		GroupInvitation: response.ToGroupInvitationFromDTO(out.GroupInvitation),
+	})
+}
+
+type GetGroupInvitationsResponse struct {
+	GroupInvitations []response.GroupInvitation `json:"groupInvitations"`
+}
+
+// GetGroupInvitations returns the pending invitations to the group.
+func (h *GroupInvitationHandler) GetGroupInvitations(c echo.Context) error {
+	in := &dto.GetGroupInvitationsInput{
+		GroupID: c.Param("id"),
+	}
+
+	out, err := h.uc.GetGroupInvitations(in)
+	if err != nil {
+		if errors.Is(err, usecase.ErrGroupNotFound) {
+			return response.Error(c, response.ErrorCodeGroupNotFound, http.StatusNotFound, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.OK(c, &GetGroupInvitationsResponse{
+		GroupInvitations: response.ToGroupInvitationsFromDTO(out.GroupInvitations),
+	})
+}
+
+type GetUserInvitationsResponse struct {
+	GroupInvitations []response.GroupInvitation `json:"groupInvitations"`
+}
+
+// GetUserInvitations returns the pending invitations for the user or the email of the user.
+func (h *GroupInvitationHandler) GetUserInvitations(c echo.Context) error {
+	in := &dto.GetUserInvitationsInput{
+		UserID: c.Param("id"),
+	}
+
+	out, err := h.uc.GetUserInvitations(in)
+	if err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			return response.Error(c, response.ErrorCodeUserNotFound, http.StatusNotFound, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.OK(c, &GetUserInvitationsResponse{
+		GroupInvitations: response.ToGroupInvitationsFromDTO(out.GroupInvitations),
+	})
+}
+
+type (
+	AcceptGroupInvitationRequest struct {
+		UserID string `json:"userId"`
+	}
+
+	AcceptGroupInvitationResponse struct {
+		// Waitlisted is true if the group is at capacity and the user is waitlisted instead of joining it.
+		Waitlisted bool `json:"waitlisted"`
+	}
+)
+
+// AcceptGroupInvitation adds the user of the request, who must be the invitee, to the group of the invitation.
+func (h *GroupInvitationHandler) AcceptGroupInvitation(c echo.Context) error {
+	req := &AcceptGroupInvitationRequest{}
+	if err := c.Bind(req); err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	in := &dto.AcceptGroupInvitationInput{
+		Meta:              newMeta(c),
+		GroupInvitationID: c.Param("id"),
+		UserID:            req.UserID,
+	}
+
+	out, err := h.uc.AcceptGroupInvitation(in)
+	if err != nil {
+		return h.respondError(c, err)
+	}
+
+	return response.OK(c, &AcceptGroupInvitationResponse{
+		Waitlisted: out.Waitlisted,
+	})
+}
+
+func (h *GroupInvitationHandler) DeclineGroupInvitation(c echo.Context) error {
+	in := &dto.DeclineGroupInvitationInput{
+		GroupInvitationID: c.Param("id"),
+	}
+
+	if _, err := h.uc.DeclineGroupInvitation(in); err != nil {
+		return h.respondError(c, err)
+	}
+
+	return response.NoContent(c)
+}
+
+func (h *GroupInvitationHandler) RevokeGroupInvitation(c echo.Context) error {
+	in := &dto.RevokeGroupInvitationInput{
+		GroupInvitationID: c.Param("id"),
+	}
+
+	if _, err := h.uc.RevokeGroupInvitation(in); err != nil {
+		return h.respondError(c, err)
+	}
+
+	return response.NoContent(c)
+}
+
+// respondError responds with the error of responding to an invitation.
+func (h *GroupInvitationHandler) respondError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidGroupInvitationInput), errors.Is(err, usecase.ErrInvalidGroupInput):
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	case errors.Is(err, usecase.ErrGroupInvitationNotFound):
+		return response.Error(c, response.ErrorCodeGroupInvitationNotFound, http.StatusNotFound, err)
+	case errors.Is(err, usecase.ErrUserNotFound):
+		return response.Error(c, response.ErrorCodeUserNotFound, http.StatusNotFound, err)
+	case errors.Is(err, usecase.ErrGroupNotFound):
+		return response.Error(c, response.ErrorCodeGroupNotFound, http.StatusNotFound, err)
+	case errors.Is(err, usecase.ErrGroupInvitationNotPending):
+		return response.Error(c, response.ErrorCodeGroupInvitationNotPending, http.StatusConflict, err)
+	case errors.Is(err, usecase.ErrUserInactive):
+		return response.Error(c, response.ErrorCodeUserInactive, http.StatusConflict, err)
+	}
+	return response.ErrorInternal(c, err)
+}
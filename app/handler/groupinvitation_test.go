@@ -0,0 +1,333 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/labstack/echo"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	mockusecase "github.com/toshiykst/go-layerd-architecture/app/mock/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestGroupInvitationHandler_InviteToGroup(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expiresAt := createdAt.Add(time.Hour)
+	tests := []struct {
+		name        string
+		body        string
+		newUsecase  func(ctrl *gomock.Controller) usecase.GroupInvitationUsecase
+		wantStatus  int
+		wantRes     *handler.InviteToGroupResponse
+		wantErrCode response.ErrorCode
+	}{
+		{
+			name: "Invites the user to the group",
+			body: `{"userId":"TEST_USER_ID"}`,
+			newUsecase: func(ctrl *gomock.Controller) usecase.GroupInvitationUsecase {
+				uc := mockusecase.NewMockGroupInvitationUsecase(ctrl)
+				uc.EXPECT().
+					InviteToGroup(&dto.InviteToGroupInput{
+						Meta:    dto.Meta{Actor: "TEST_ACTOR"},
+						GroupID: "TEST_GROUP_ID",
+						UserID:  "TEST_USER_ID",
+					}).
+					Return(&dto.InviteToGroupOutput{
+						GroupInvitation: dto.GroupInvitation{
+							GroupInvitationID: "TEST_GROUP_INVITATION_ID",
+							GroupID:           "TEST_GROUP_ID",
+							Inviter:           "TEST_ACTOR",
+							InviteeUserID:     "TEST_USER_ID",
+							Status:            "PENDING",
+							CreatedAt:         createdAt,
+							ExpiresAt:         expiresAt,
+						},
+					}, nil)
+				return uc
+			},
+			wantStatus: http.StatusCreated,
+			wantRes: &handler.InviteToGroupResponse{
+				GroupInvitation: response.GroupInvitation{
+					GroupInvitationID: "TEST_GROUP_INVITATION_ID",
+					GroupID:           "TEST_GROUP_ID",
+					Inviter:           "TEST_ACTOR",
+					InviteeUserID:     "TEST_USER_ID",
+					Status:            "PENDING",
+					CreatedAt:         createdAt,
+					ExpiresAt:         expiresAt,
+				},
+			},
+		},
+		{
+			name: "Returns invalid arguments error response if the invitation is invalid",
+			body: `{}`,
+			newUsecase: func(ctrl *gomock.Controller) usecase.GroupInvitationUsecase {
+				uc := mockusecase.NewMockGroupInvitationUsecase(ctrl)
+				uc.EXPECT().
+					InviteToGroup(gomock.Any()).
+					Return(nil, usecase.ErrInvalidGroupInvitationInput)
+				return uc
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErrCode: response.ErrorCodeInvalidArguments,
+		},
+		{
+			name: "Returns group not found error response",
+			body: `{"userId":"TEST_USER_ID"}`,
+			newUsecase: func(ctrl *gomock.Controller) usecase.GroupInvitationUsecase {
+				uc := mockusecase.NewMockGroupInvitationUsecase(ctrl)
+				uc.EXPECT().
+					InviteToGroup(gomock.Any()).
+					Return(nil, usecase.ErrGroupNotFound)
+				return uc
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErrCode: response.ErrorCodeGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"https://example.com:8080/groups/TEST_GROUP_ID/invitations",
+				bytes.NewBufferString(tt.body),
+			)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(handler.HeaderXActorID, "TEST_ACTOR")
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("TEST_GROUP_ID")
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			h := handler.NewGroupInvitationHandler(tt.newUsecase(ctrl))
+
+			if err := h.InviteToGroup(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantRes != nil {
+				var got *handler.InviteToGroupResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if diff := cmp.Diff(got, tt.wantRes); diff != "" {
+					t.Errorf(
+						"response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantRes, diff,
+					)
+				}
+			}
+			if tt.wantErrCode != "" {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if got == nil || got.Code != tt.wantErrCode {
+					t.Errorf("error response body: got = %v, want code = %s", got, tt.wantErrCode)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupInvitationHandler_AcceptGroupInvitation(t *testing.T) {
+	tests := []struct {
+		name        string
+		newUsecase  func(ctrl *gomock.Controller) usecase.GroupInvitationUsecase
+		wantStatus  int
+		wantRes     *handler.AcceptGroupInvitationResponse
+		wantErrCode response.ErrorCode
+	}{
+		{
+			name: "Accepts the invitation",
+			newUsecase: func(ctrl *gomock.Controller) usecase.GroupInvitationUsecase {
+				uc := mockusecase.NewMockGroupInvitationUsecase(ctrl)
+				uc.EXPECT().
+					AcceptGroupInvitation(&dto.AcceptGroupInvitationInput{
+						GroupInvitationID: "TEST_GROUP_INVITATION_ID",
+						UserID:            "TEST_USER_ID",
+					}).
+					Return(&dto.AcceptGroupInvitationOutput{Waitlisted: true}, nil)
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    &handler.AcceptGroupInvitationResponse{Waitlisted: true},
+		},
+		{
+			name: "Returns group invitation not found error response",
+			newUsecase: func(ctrl *gomock.Controller) usecase.GroupInvitationUsecase {
+				uc := mockusecase.NewMockGroupInvitationUsecase(ctrl)
+				uc.EXPECT().
+					AcceptGroupInvitation(gomock.Any()).
+					Return(nil, usecase.ErrGroupInvitationNotFound)
+				return uc
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErrCode: response.ErrorCodeGroupInvitationNotFound,
+		},
+		{
+			name: "Returns group invitation not pending error response",
+			newUsecase: func(ctrl *gomock.Controller) usecase.GroupInvitationUsecase {
+				uc := mockusecase.NewMockGroupInvitationUsecase(ctrl)
+				uc.EXPECT().
+					AcceptGroupInvitation(gomock.Any()).
+					Return(nil, usecase.ErrGroupInvitationNotPending)
+				return uc
+			},
+			wantStatus:  http.StatusConflict,
+			wantErrCode: response.ErrorCodeGroupInvitationNotPending,
+		},
+		{
+			name: "Returns invalid arguments error response if the user is not the invitee",
+			newUsecase: func(ctrl *gomock.Controller) usecase.GroupInvitationUsecase {
+				uc := mockusecase.NewMockGroupInvitationUsecase(ctrl)
+				uc.EXPECT().
+					AcceptGroupInvitation(gomock.Any()).
+					Return(nil, usecase.ErrInvalidGroupInvitationInput)
+				return uc
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErrCode: response.ErrorCodeInvalidArguments,
+		},
+		{
+			name: "Returns internal server error response",
+			newUsecase: func(ctrl *gomock.Controller) usecase.GroupInvitationUsecase {
+				uc := mockusecase.NewMockGroupInvitationUsecase(ctrl)
+				uc.EXPECT().
+					AcceptGroupInvitation(gomock.Any()).
+					Return(nil, errors.New("an error occurred"))
+				return uc
+			},
+			wantStatus:  http.StatusInternalServerError,
+			wantErrCode: response.ErrorCodeInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"https://example.com:8080/invitations/TEST_GROUP_INVITATION_ID/accept",
+				bytes.NewBufferString(`{"userId":"TEST_USER_ID"}`),
+			)
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("TEST_GROUP_INVITATION_ID")
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			h := handler.NewGroupInvitationHandler(tt.newUsecase(ctrl))
+
+			if err := h.AcceptGroupInvitation(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantRes != nil {
+				var got *handler.AcceptGroupInvitationResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if diff := cmp.Diff(got, tt.wantRes); diff != "" {
+					t.Errorf(
+						"response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantRes, diff,
+					)
+				}
+			}
+			if tt.wantErrCode != "" {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if got == nil || got.Code != tt.wantErrCode {
+					t.Errorf("error response body: got = %v, want code = %s", got, tt.wantErrCode)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupInvitationHandler_DeclineGroupInvitation(t *testing.T) {
+	tests := []struct {
+		name        string
+		newUsecase  func(ctrl *gomock.Controller) usecase.GroupInvitationUsecase
+		wantStatus  int
+		wantErrCode response.ErrorCode
+	}{
+		{
+			name: "Declines the invitation",
+			newUsecase: func(ctrl *gomock.Controller) usecase.GroupInvitationUsecase {
+				uc := mockusecase.NewMockGroupInvitationUsecase(ctrl)
+				uc.EXPECT().
+					DeclineGroupInvitation(&dto.DeclineGroupInvitationInput{GroupInvitationID: "TEST_GROUP_INVITATION_ID"}).
+					Return(&dto.DeclineGroupInvitationOutput{}, nil)
+				return uc
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "Returns group invitation not pending error response",
+			newUsecase: func(ctrl *gomock.Controller) usecase.GroupInvitationUsecase {
+				uc := mockusecase.NewMockGroupInvitationUsecase(ctrl)
+				uc.EXPECT().
+					DeclineGroupInvitation(gomock.Any()).
+					Return(nil, usecase.ErrGroupInvitationNotPending)
+				return uc
+			},
+			wantStatus:  http.StatusConflict,
+			wantErrCode: response.ErrorCodeGroupInvitationNotPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"https://example.com:8080/invitations/TEST_GROUP_INVITATION_ID/decline",
+				nil,
+			)
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("TEST_GROUP_INVITATION_ID")
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			h := handler.NewGroupInvitationHandler(tt.newUsecase(ctrl))
+
+			if err := h.DeclineGroupInvitation(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantErrCode != "" {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if got == nil || got.Code != tt.wantErrCode {
+					t.Errorf("error response body: got = %v, want code = %s", got, tt.wantErrCode)
+				}
+			}
+		})
+	}
+}
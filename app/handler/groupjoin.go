@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+type GroupJoinHandler struct {
+	uc usecase.GroupJoinUsecase
+}
+
+func NewGroupJoinHandler(uc usecase.GroupJoinUsecase) *GroupJoinHandler {
+	return &GroupJoinHandler{uc: uc}
+}
+
+type (
+	JoinGroupRequest struct {
+		UserID string `json:"userId"`
+	}
+
+	JoinGroupResponse struct {
+		// GroupJoinRequest is the pending request to join the group if its join policy requires an approval.
+		GroupJoinRequest *response.GroupJoinRequest `json:"groupJoinRequest,omitempty"`
+		// Waitlisted is true if the group is at capacity and the user is waitlisted instead of joining it.
+		Waitlisted bool `json:"waitlisted"`
+	}
+)
+
+// JoinGroup adds the user of the request to an open group, or requests the user to join an approval-only one.
+func (h *GroupJoinHandler) JoinGroup(c echo.Context) error {
+	req := &JoinGroupRequest{}
+	if err := c.Bind(req); err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	in := &dto.JoinGroupInput{
+		Meta:    newMeta(c),
+		GroupID: c.Param("id"),
+		UserID:  req.UserID,
+	}
+
+	out, err := h.uc.JoinGroup(in)
+	if err != nil {
+		return h.respondError(c, err)
+	}
+
+	res := &JoinGroupResponse{
+		Waitlisted: out.Waitlisted,
+	}
+	if out.GroupJoinRequest != nil {
+		jr := response.ToGroupJoinRequestFromDTO(*out.GroupJoinRequest)
+		res.GroupJoinRequest = &jr
+	}
+	return response.OK(c, res)
+}
+
+type GetGroupJoinRequestsResponse struct {
+	GroupJoinRequests []response.GroupJoinRequest `json:"groupJoinRequests"`
+}
+
+// GetGroupJoinRequests returns the pending requests to join the group.
+func (h *GroupJoinHandler) GetGroupJoinRequests(c echo.Context) error {
+	in := &dto.GetGroupJoinRequestsInput{
+		GroupID: c.Param("id"),
+	}
+
+	out, err := h.uc.GetGroupJoinRequests(in)
+	if err != nil {
+		if errors.Is(err, usecase.ErrGroupNotFound) {
+			return response.Error(c, response.ErrorCodeGroupNotFound, http.StatusNotFound, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.OK(c, &GetGroupJoinRequestsResponse{
+		GroupJoinRequests: response.ToGroupJoinRequestsFromDTO(out.GroupJoinRequests),
+	})
+}
+
+type ApproveGroupJoinRequestResponse struct {
+	// Waitlisted is true if the group is at capacity and the user is waitlisted instead of joining it.
+	Waitlisted bool `json:"waitlisted"`
+}
+
+// ApproveGroupJoinRequest approves the request on behalf of the actor of the request, and adds the user to the group.
+func (h *GroupJoinHandler) ApproveGroupJoinRequest(c echo.Context) error {
+	in := &dto.ApproveGroupJoinRequestInput{
+		Meta:               newMeta(c),
+		GroupID:            c.Param("id"),
+		GroupJoinRequestID: c.Param("requestId"),
+	}
+
+	out, err := h.uc.ApproveGroupJoinRequest(in)
+	if err != nil {
+		return h.respondError(c, err)
+	}
+
+	return response.OK(c, &ApproveGroupJoinRequestResponse{
+		Waitlisted: out.Waitlisted,
+	})
+}
+
+// RejectGroupJoinRequest rejects the request on behalf of the actor of the request.
+func (h *GroupJoinHandler) RejectGroupJoinRequest(c echo.Context) error {
+	in := &dto.RejectGroupJoinRequestInput{
+		Meta:               newMeta(c),
+		GroupID:            c.Param("id"),
+		GroupJoinRequestID: c.Param("requestId"),
+	}
+
+	if _, err := h.uc.RejectGroupJoinRequest(in); err != nil {
+		return h.respondError(c, err)
+	}
+
+	return response.NoContent(c)
+}
+
+// respondError responds with the error of joining a group or responding to a join request.
+func (h *GroupJoinHandler) respondError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidGroupJoinRequestInput), errors.Is(err, usecase.ErrInvalidGroupInput):
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	case errors.Is(err, usecase.ErrGroupJoinNotAllowed):
+		return response.Error(c, response.ErrorCodeGroupJoinNotAllowed, http.StatusForbidden, err)
+	case errors.Is(err, usecase.ErrGroupJoinRequestForbidden):
+		return response.Error(c, response.ErrorCodeGroupJoinRequestForbidden, http.StatusForbidden, err)
+	case errors.Is(err, usecase.ErrGroupJoinRequestNotFound):
+		return response.Error(c, response.ErrorCodeGroupJoinRequestNotFound, http.StatusNotFound, err)
+	case errors.Is(err, usecase.ErrUserNotFound):
+		return response.Error(c, response.ErrorCodeUserNotFound, http.StatusNotFound, err)
+	case errors.Is(err, usecase.ErrGroupNotFound):
+		return response.Error(c, response.ErrorCodeGroupNotFound, http.StatusNotFound, err)
+	case errors.Is(err, usecase.ErrGroupJoinRequestNotPending):
+		return response.Error(c, response.ErrorCodeGroupJoinRequestNotPending, http.StatusConflict, err)
+	case errors.Is(err, usecase.ErrUserInactive):
+		return response.Error(c, response.ErrorCodeUserInactive, http.StatusConflict, err)
+	}
+	return response.ErrorInternal(c, err)
+}
@@ -0,0 +1,268 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/labstack/echo"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	mockusecase "github.com/toshiykst/go-layerd-architecture/app/mock/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestGroupJoinHandler_JoinGroup(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name        string
+		newUsecase  func(ctrl *gomock.Controller) usecase.GroupJoinUsecase
+		wantStatus  int
+		wantRes     *handler.JoinGroupResponse
+		wantErrCode response.ErrorCode
+	}{
+		{
+			name: "Joins the open group",
+			newUsecase: func(ctrl *gomock.Controller) usecase.GroupJoinUsecase {
+				uc := mockusecase.NewMockGroupJoinUsecase(ctrl)
+				uc.EXPECT().
+					JoinGroup(&dto.JoinGroupInput{
+						Meta:    dto.Meta{Actor: "TEST_ACTOR"},
+						GroupID: "TEST_GROUP_ID",
+						UserID:  "TEST_USER_ID",
+					}).
+					Return(&dto.JoinGroupOutput{}, nil)
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    &handler.JoinGroupResponse{},
+		},
+		{
+			name: "Requests to join the approval-only group",
+			newUsecase: func(ctrl *gomock.Controller) usecase.GroupJoinUsecase {
+				uc := mockusecase.NewMockGroupJoinUsecase(ctrl)
+				uc.EXPECT().
+					JoinGroup(gomock.Any()).
+					Return(&dto.JoinGroupOutput{
+						GroupJoinRequest: &dto.GroupJoinRequest{
+							GroupJoinRequestID: "TEST_GROUP_JOIN_REQUEST_ID",
+							GroupID:            "TEST_GROUP_ID",
+							UserID:             "TEST_USER_ID",
+							Status:             "PENDING",
+							CreatedAt:          createdAt,
+						},
+					}, nil)
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes: &handler.JoinGroupResponse{
+				GroupJoinRequest: &response.GroupJoinRequest{
+					GroupJoinRequestID: "TEST_GROUP_JOIN_REQUEST_ID",
+					GroupID:            "TEST_GROUP_ID",
+					UserID:             "TEST_USER_ID",
+					Status:             "PENDING",
+					CreatedAt:          createdAt,
+				},
+			},
+		},
+		{
+			name: "Returns group join not allowed error response",
+			newUsecase: func(ctrl *gomock.Controller) usecase.GroupJoinUsecase {
+				uc := mockusecase.NewMockGroupJoinUsecase(ctrl)
+				uc.EXPECT().
+					JoinGroup(gomock.Any()).
+					Return(nil, usecase.ErrGroupJoinNotAllowed)
+				return uc
+			},
+			wantStatus:  http.StatusForbidden,
+			wantErrCode: response.ErrorCodeGroupJoinNotAllowed,
+		},
+		{
+			name: "Returns invalid arguments error response if the user has a pending request",
+			newUsecase: func(ctrl *gomock.Controller) usecase.GroupJoinUsecase {
+				uc := mockusecase.NewMockGroupJoinUsecase(ctrl)
+				uc.EXPECT().
+					JoinGroup(gomock.Any()).
+					Return(nil, usecase.ErrInvalidGroupJoinRequestInput)
+				return uc
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErrCode: response.ErrorCodeInvalidArguments,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"https://example.com:8080/groups/TEST_GROUP_ID/join",
+				bytes.NewBufferString(`{"userId":"TEST_USER_ID"}`),
+			)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(handler.HeaderXActorID, "TEST_ACTOR")
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("TEST_GROUP_ID")
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			h := handler.NewGroupJoinHandler(tt.newUsecase(ctrl))
+
+			if err := h.JoinGroup(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantRes != nil {
+				var got *handler.JoinGroupResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if diff := cmp.Diff(got, tt.wantRes); diff != "" {
+					t.Errorf(
+						"response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantRes, diff,
+					)
+				}
+			}
+			if tt.wantErrCode != "" {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if got == nil || got.Code != tt.wantErrCode {
+					t.Errorf("error response body: got = %v, want code = %s", got, tt.wantErrCode)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupJoinHandler_ApproveGroupJoinRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		newUsecase  func(ctrl *gomock.Controller) usecase.GroupJoinUsecase
+		wantStatus  int
+		wantRes     *handler.ApproveGroupJoinRequestResponse
+		wantErrCode response.ErrorCode
+	}{
+		{
+			name: "Approves the request",
+			newUsecase: func(ctrl *gomock.Controller) usecase.GroupJoinUsecase {
+				uc := mockusecase.NewMockGroupJoinUsecase(ctrl)
+				uc.EXPECT().
+					ApproveGroupJoinRequest(&dto.ApproveGroupJoinRequestInput{
+						Meta:               dto.Meta{Actor: "TEST_ACTOR"},
+						GroupID:            "TEST_GROUP_ID",
+						GroupJoinRequestID: "TEST_GROUP_JOIN_REQUEST_ID",
+					}).
+					Return(&dto.ApproveGroupJoinRequestOutput{Waitlisted: true}, nil)
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    &handler.ApproveGroupJoinRequestResponse{Waitlisted: true},
+		},
+		{
+			name: "Returns group join not allowed error response if the group is invite-only",
+			newUsecase: func(ctrl *gomock.Controller) usecase.GroupJoinUsecase {
+				uc := mockusecase.NewMockGroupJoinUsecase(ctrl)
+				uc.EXPECT().
+					ApproveGroupJoinRequest(gomock.Any()).
+					Return(nil, usecase.ErrGroupJoinNotAllowed)
+				return uc
+			},
+			wantStatus:  http.StatusForbidden,
+			wantErrCode: response.ErrorCodeGroupJoinNotAllowed,
+		},
+		{
+			name: "Returns group join request forbidden error response if the actor cannot respond to it",
+			newUsecase: func(ctrl *gomock.Controller) usecase.GroupJoinUsecase {
+				uc := mockusecase.NewMockGroupJoinUsecase(ctrl)
+				uc.EXPECT().
+					ApproveGroupJoinRequest(gomock.Any()).
+					Return(nil, usecase.ErrGroupJoinRequestForbidden)
+				return uc
+			},
+			wantStatus:  http.StatusForbidden,
+			wantErrCode: response.ErrorCodeGroupJoinRequestForbidden,
+		},
+		{
+			name: "Returns group join request not found error response",
+			newUsecase: func(ctrl *gomock.Controller) usecase.GroupJoinUsecase {
+				uc := mockusecase.NewMockGroupJoinUsecase(ctrl)
+				uc.EXPECT().
+					ApproveGroupJoinRequest(gomock.Any()).
+					Return(nil, usecase.ErrGroupJoinRequestNotFound)
+				return uc
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErrCode: response.ErrorCodeGroupJoinRequestNotFound,
+		},
+		{
+			name: "Returns group join request not pending error response",
+			newUsecase: func(ctrl *gomock.Controller) usecase.GroupJoinUsecase {
+				uc := mockusecase.NewMockGroupJoinUsecase(ctrl)
+				uc.EXPECT().
+					ApproveGroupJoinRequest(gomock.Any()).
+					Return(nil, usecase.ErrGroupJoinRequestNotPending)
+				return uc
+			},
+			wantStatus:  http.StatusConflict,
+			wantErrCode: response.ErrorCodeGroupJoinRequestNotPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"https://example.com:8080/groups/TEST_GROUP_ID/join-requests/TEST_GROUP_JOIN_REQUEST_ID/approve",
+				nil,
+			)
+			req.Header.Set(handler.HeaderXActorID, "TEST_ACTOR")
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id", "requestId")
+			c.SetParamValues("TEST_GROUP_ID", "TEST_GROUP_JOIN_REQUEST_ID")
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			h := handler.NewGroupJoinHandler(tt.newUsecase(ctrl))
+
+			if err := h.ApproveGroupJoinRequest(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantRes != nil {
+				var got *handler.ApproveGroupJoinRequestResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if diff := cmp.Diff(got, tt.wantRes); diff != "" {
+					t.Errorf(
+						"response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantRes, diff,
+					)
+				}
+			}
+			if tt.wantErrCode != "" {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if got == nil || got.Code != tt.wantErrCode {
+					t.Errorf("error response body: got = %v, want code = %s", got, tt.wantErrCode)
+				}
+			}
+		})
+	}
+}
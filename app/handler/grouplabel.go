@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+type (
+	PutGroupLabelsRequest struct {
+		// Labels replace all the labels of the group, which are removed if it is empty.
+		Labels map[string]string `json:"labels"`
+	}
+
+	PutGroupLabelsResponse struct {
+		Labels map[string]string `json:"labels"`
+	}
+)
+
+// PutGroupLabels replaces the labels of the group, each key of which is a name optionally prefixed with a DNS
+// subdomain and a slash, e.g. example.com/team.
+func (h *GroupHandler) PutGroupLabels(c echo.Context) error {
+	req := &PutGroupLabelsRequest{}
+	if err := c.Bind(req); err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	in := &dto.PutGroupLabelsInput{
+		Meta:    newMeta(c),
+		GroupID: c.Param("id"),
+		Labels:  req.Labels,
+	}
+
+	out, err := h.uc.PutGroupLabels(in)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidGroupInput) {
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		}
+		if errors.Is(err, usecase.ErrGroupNotFound) {
+			return response.Error(c, response.ErrorCodeGroupNotFound, http.StatusNotFound, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	labels := out.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	return response.OK(c, &PutGroupLabelsResponse{
+		Labels: labels,
+	})
+}
@@ -0,0 +1,168 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/labstack/echo"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	mockusecase "github.com/toshiykst/go-layerd-architecture/app/mock/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestGroupHandler_PutGroupLabels(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		newGroupUsecase func(ctrl *gomock.Controller) usecase.GroupUsecase
+		wantStatus      int
+		wantRes         *handler.PutGroupLabelsResponse
+		wantErrRes      *response.ErrorResponse
+	}{
+		{
+			name: "Replaces the labels and returns them",
+			body: `{"labels":{"team":"payments","example.com/env":"prod"}}`,
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					PutGroupLabels(&dto.PutGroupLabelsInput{
+						GroupID: "TEST_GROUP_ID",
+						Labels:  map[string]string{"team": "payments", "example.com/env": "prod"},
+					}).
+					Return(&dto.PutGroupLabelsOutput{
+						Labels: map[string]string{"team": "payments", "example.com/env": "prod"},
+					}, nil)
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes: &handler.PutGroupLabelsResponse{
+				Labels: map[string]string{"team": "payments", "example.com/env": "prod"},
+			},
+		},
+		{
+			name: "Returns empty labels when they are removed",
+			body: `{"labels":{}}`,
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					PutGroupLabels(gomock.Any()).
+					Return(&dto.PutGroupLabelsOutput{}, nil)
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    &handler.PutGroupLabelsResponse{Labels: map[string]string{}},
+		},
+		{
+			name: "Returns invalid arguments error response when a label is invalid",
+			body: `{"labels":{"team":"-payments"}}`,
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					PutGroupLabels(gomock.Any()).
+					Return(nil, usecase.ErrInvalidGroupInput)
+				return uc
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: usecase.ErrInvalidGroupInput.Error(),
+			},
+		},
+		{
+			name: "Returns group not found error response",
+			body: `{"labels":{}}`,
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					PutGroupLabels(gomock.Any()).
+					Return(nil, usecase.ErrGroupNotFound)
+				return uc
+			},
+			wantStatus: http.StatusNotFound,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeGroupNotFound,
+				Status:  http.StatusNotFound,
+				Message: usecase.ErrGroupNotFound.Error(),
+			},
+		},
+		{
+			name: "Returns internal server error response",
+			body: `{"labels":{}}`,
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					PutGroupLabels(gomock.Any()).
+					Return(nil, errors.New("an error occurred"))
+				return uc
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInternalServerError,
+				Status:  http.StatusInternalServerError,
+				Message: "an error occurred",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(
+				http.MethodPut, "https://example.com:8080/groups/TEST_GROUP_ID/labels", strings.NewReader(tt.body),
+			)
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("TEST_GROUP_ID")
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			h := handler.NewGroupHandler(tt.newGroupUsecase(ctrl))
+
+			if err := h.PutGroupLabels(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
+			}
+
+			resBody, err := io.ReadAll(rec.Body)
+			if err != nil {
+				t.Fatalf("Failed to read body: %s", err.Error())
+			}
+			if tt.wantRes != nil {
+				var got *handler.PutGroupLabelsResponse
+				_ = json.Unmarshal(resBody, &got)
+				if diff := cmp.Diff(got, tt.wantRes); diff != "" {
+					t.Errorf(
+						"response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantRes, diff,
+					)
+				}
+			}
+			if tt.wantErrRes != nil {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(resBody, &got)
+				if diff := cmp.Diff(got, tt.wantErrRes); diff != "" {
+					t.Errorf(
+						"error response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantErrRes, diff,
+					)
+				}
+			}
+		})
+	}
+}
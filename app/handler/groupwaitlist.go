@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+type GetGroupWaitlistResponse struct {
+	// UserIDs are the waitlisted users, the next one to be promoted first.
+	UserIDs []string `json:"userIds"`
+}
+
+// GetGroupWaitlist returns the users waiting in order for a place in the group at capacity.
+func (h *GroupHandler) GetGroupWaitlist(c echo.Context) error {
+	in := &dto.GetGroupWaitlistInput{
+		GroupID: c.Param("id"),
+	}
+
+	out, err := h.uc.GetGroupWaitlist(in)
+	if err != nil {
+		if errors.Is(err, usecase.ErrGroupNotFound) {
+			return response.Error(c, response.ErrorCodeGroupNotFound, http.StatusNotFound, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.OK(c, &GetGroupWaitlistResponse{
+		UserIDs: out.UserIDs,
+	})
+}
+
+type (
+	ReorderGroupWaitlistRequest struct {
+		UserIDs []string `json:"userIds"`
+	}
+)
+
+// ReorderGroupWaitlist orders the waitlist of the group as the request, which must have every waitlisted user once.
+func (h *GroupHandler) ReorderGroupWaitlist(c echo.Context) error {
+	req := &ReorderGroupWaitlistRequest{}
+	if err := c.Bind(req); err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	in := &dto.ReorderGroupWaitlistInput{
+		GroupID: c.Param("id"),
+		UserIDs: req.UserIDs,
+	}
+
+	if _, err := h.uc.ReorderGroupWaitlist(in); err != nil {
+		if errors.Is(err, usecase.ErrInvalidGroupWaitlistInput) {
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		}
+		if errors.Is(err, usecase.ErrGroupNotFound) {
+			return response.Error(c, response.ErrorCodeGroupNotFound, http.StatusNotFound, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.NoContent(c)
+}
+
+// LeaveGroupWaitlist takes the user off the waitlist of the group.
+func (h *GroupHandler) LeaveGroupWaitlist(c echo.Context) error {
+	in := &dto.LeaveGroupWaitlistInput{
+		GroupID: c.Param("id"),
+		UserID:  c.Param("userId"),
+	}
+
+	if _, err := h.uc.LeaveGroupWaitlist(in); err != nil {
+		if errors.Is(err, usecase.ErrGroupNotFound) {
+			return response.Error(c, response.ErrorCodeGroupNotFound, http.StatusNotFound, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.NoContent(c)
+}
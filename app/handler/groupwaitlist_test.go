@@ -0,0 +1,278 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/labstack/echo"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	mockusecase "github.com/toshiykst/go-layerd-architecture/app/mock/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestGroupHandler_GetGroupWaitlist(t *testing.T) {
+	tests := []struct {
+		name            string
+		newGroupUsecase func(ctrl *gomock.Controller) usecase.GroupUsecase
+		wantStatus      int
+		wantRes         *handler.GetGroupWaitlistResponse
+		wantErrRes      *response.ErrorResponse
+	}{
+		{
+			name: "Returns the waitlisted users",
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					GetGroupWaitlist(&dto.GetGroupWaitlistInput{GroupID: "TEST_GROUP_ID"}).
+					Return(&dto.GetGroupWaitlistOutput{UserIDs: []string{"TEST_USER_ID_2", "TEST_USER_ID_1"}}, nil)
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    &handler.GetGroupWaitlistResponse{UserIDs: []string{"TEST_USER_ID_2", "TEST_USER_ID_1"}},
+		},
+		{
+			name: "Returns group not found error response",
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					GetGroupWaitlist(gomock.Any()).
+					Return(nil, usecase.ErrGroupNotFound)
+				return uc
+			},
+			wantStatus: http.StatusNotFound,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeGroupNotFound,
+				Status:  http.StatusNotFound,
+				Message: usecase.ErrGroupNotFound.Error(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "https://example.com:8080/groups/TEST_GROUP_ID/waitlist", nil)
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("TEST_GROUP_ID")
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			h := handler.NewGroupHandler(tt.newGroupUsecase(ctrl))
+
+			if err := h.GetGroupWaitlist(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
+			}
+
+			resBody, err := io.ReadAll(rec.Body)
+			if err != nil {
+				t.Fatalf("Failed to read body: %s", err.Error())
+			}
+			if tt.wantRes != nil {
+				var got *handler.GetGroupWaitlistResponse
+				_ = json.Unmarshal(resBody, &got)
+				if diff := cmp.Diff(got, tt.wantRes); diff != "" {
+					t.Errorf(
+						"response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantRes, diff,
+					)
+				}
+			}
+			if tt.wantErrRes != nil {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(resBody, &got)
+				if diff := cmp.Diff(got, tt.wantErrRes); diff != "" {
+					t.Errorf(
+						"error response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantErrRes, diff,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupHandler_ReorderGroupWaitlist(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		newGroupUsecase func(ctrl *gomock.Controller) usecase.GroupUsecase
+		wantStatus      int
+		wantErrCode     response.ErrorCode
+	}{
+		{
+			name: "Reorders the waitlist",
+			body: `{"userIds":["TEST_USER_ID_2","TEST_USER_ID_1"]}`,
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					ReorderGroupWaitlist(&dto.ReorderGroupWaitlistInput{
+						GroupID: "TEST_GROUP_ID",
+						UserIDs: []string{"TEST_USER_ID_2", "TEST_USER_ID_1"},
+					}).
+					Return(&dto.ReorderGroupWaitlistOutput{}, nil)
+				return uc
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "Returns invalid arguments error response if the order is invalid",
+			body: `{"userIds":["TEST_USER_ID_2"]}`,
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					ReorderGroupWaitlist(gomock.Any()).
+					Return(nil, usecase.ErrInvalidGroupWaitlistInput)
+				return uc
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErrCode: response.ErrorCodeInvalidArguments,
+		},
+		{
+			name: "Returns group not found error response",
+			body: `{"userIds":[]}`,
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					ReorderGroupWaitlist(gomock.Any()).
+					Return(nil, usecase.ErrGroupNotFound)
+				return uc
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErrCode: response.ErrorCodeGroupNotFound,
+		},
+		{
+			name: "Returns internal server error response",
+			body: `{"userIds":[]}`,
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					ReorderGroupWaitlist(gomock.Any()).
+					Return(nil, errors.New("an error occurred"))
+				return uc
+			},
+			wantStatus:  http.StatusInternalServerError,
+			wantErrCode: response.ErrorCodeInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(
+				http.MethodPut,
+				"https://example.com:8080/groups/TEST_GROUP_ID/waitlist",
+				bytes.NewBufferString(tt.body),
+			)
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("TEST_GROUP_ID")
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			h := handler.NewGroupHandler(tt.newGroupUsecase(ctrl))
+
+			if err := h.ReorderGroupWaitlist(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantErrCode != "" {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if got == nil || got.Code != tt.wantErrCode {
+					t.Errorf("error response body: got = %v, want code = %s", got, tt.wantErrCode)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupHandler_LeaveGroupWaitlist(t *testing.T) {
+	tests := []struct {
+		name            string
+		newGroupUsecase func(ctrl *gomock.Controller) usecase.GroupUsecase
+		wantStatus      int
+		wantErrCode     response.ErrorCode
+	}{
+		{
+			name: "Takes the user off the waitlist",
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					LeaveGroupWaitlist(&dto.LeaveGroupWaitlistInput{GroupID: "TEST_GROUP_ID", UserID: "TEST_USER_ID"}).
+					Return(&dto.LeaveGroupWaitlistOutput{}, nil)
+				return uc
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "Returns group not found error response",
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					LeaveGroupWaitlist(gomock.Any()).
+					Return(nil, usecase.ErrGroupNotFound)
+				return uc
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErrCode: response.ErrorCodeGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(
+				http.MethodDelete,
+				"https://example.com:8080/groups/TEST_GROUP_ID/waitlist/TEST_USER_ID",
+				nil,
+			)
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id", "userId")
+			c.SetParamValues("TEST_GROUP_ID", "TEST_USER_ID")
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			h := handler.NewGroupHandler(tt.newGroupUsecase(ctrl))
+
+			if err := h.LeaveGroupWaitlist(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantErrCode != "" {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if got == nil || got.Code != tt.wantErrCode {
+					t.Errorf("error response body: got = %v, want code = %s", got, tt.wantErrCode)
+				}
+			}
+		})
+	}
+}
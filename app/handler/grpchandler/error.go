@@ -0,0 +1,34 @@
+package grpchandler
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+)
+
+var codesByErr = []struct {
+	err  error
+	code codes.Code
+}{
+	{err: usecase.ErrUserNotFound, code: codes.NotFound},
+	{err: usecase.ErrGroupNotFound, code: codes.NotFound},
+	{err: usecase.ErrInvalidUserInput, code: codes.InvalidArgument},
+	{err: usecase.ErrInvalidGroupInput, code: codes.InvalidArgument},
+	{err: usecase.ErrInvalidUserIDs, code: codes.InvalidArgument},
+	{err: usecase.ErrUserInactive, code: codes.FailedPrecondition},
+	{err: usecase.ErrInvalidUserStatusTransition, code: codes.FailedPrecondition},
+}
+
+// toStatusError converts the usecase error to the gRPC status error.
+// The errors which are not known to the usecase are internal errors.
+func toStatusError(err error) error {
+	for _, v := range codesByErr {
+		if errors.Is(err, v.err) {
+			return status.Error(v.code, err.Error())
+		}
+	}
+	return status.Error(codes.Internal, err.Error())
+}
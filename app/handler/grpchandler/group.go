@@ -0,0 +1,128 @@
+package grpchandler
+
+import (
+	"context"
+
+	layeredv1 "github.com/toshiykst/go-layerd-architecture/app/pb/layered/v1"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+type GroupServer struct {
+	layeredv1.UnimplementedGroupServiceServer
+	uc usecase.GroupUsecase
+}
+
+func NewGroupServer(uc usecase.GroupUsecase) *GroupServer {
+	return &GroupServer{uc: uc}
+}
+
+func (s *GroupServer) CreateGroup(
+	ctx context.Context,
+	req *layeredv1.CreateGroupRequest,
+) (*layeredv1.CreateGroupResponse, error) {
+	out, err := s.uc.CreateGroup(&dto.CreateGroupInput{
+		Meta:    newMeta(ctx),
+		Name:    req.GetName(),
+		UserIDs: req.GetUserIds(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &layeredv1.CreateGroupResponse{
+		Group: toGroupFromDTO(out.Group),
+	}, nil
+}
+
+func (s *GroupServer) GetGroup(
+	_ context.Context,
+	req *layeredv1.GetGroupRequest,
+) (*layeredv1.GetGroupResponse, error) {
+	out, err := s.uc.GetGroup(&dto.GetGroupInput{
+		GroupID: req.GetGroupId(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &layeredv1.GetGroupResponse{
+		Group: toGroupFromDTO(out.Group),
+	}, nil
+}
+
+func (s *GroupServer) GetGroups(
+	_ context.Context,
+	_ *layeredv1.GetGroupsRequest,
+) (*layeredv1.GetGroupsResponse, error) {
+	out, err := s.uc.GetGroups(&dto.GetGroupsInput{})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &layeredv1.GetGroupsResponse{
+		Groups: toGroupsFromDTO(out.Groups),
+	}, nil
+}
+
+func (s *GroupServer) UpdateGroup(
+	ctx context.Context,
+	req *layeredv1.UpdateGroupRequest,
+) (*layeredv1.UpdateGroupResponse, error) {
+	if _, err := s.uc.UpdateGroup(&dto.UpdateGroupInput{
+		Meta:    newMeta(ctx),
+		GroupID: req.GetGroupId(),
+		Name:    req.GetName(),
+	}); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &layeredv1.UpdateGroupResponse{}, nil
+}
+
+func (s *GroupServer) DeleteGroup(
+	ctx context.Context,
+	req *layeredv1.DeleteGroupRequest,
+) (*layeredv1.DeleteGroupResponse, error) {
+	if _, err := s.uc.DeleteGroup(&dto.DeleteGroupInput{
+		Meta:    newMeta(ctx),
+		GroupID: req.GetGroupId(),
+	}); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &layeredv1.DeleteGroupResponse{}, nil
+}
+
+func (s *GroupServer) AddGroupUsers(
+	ctx context.Context,
+	req *layeredv1.AddGroupUsersRequest,
+) (*layeredv1.AddGroupUsersResponse, error) {
+	out, err := s.uc.AddGroupUsers(&dto.AddGroupUsersInput{
+		Meta:    newMeta(ctx),
+		GroupID: req.GetGroupId(),
+		UserIDs: req.GetUserIds(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &layeredv1.AddGroupUsersResponse{
+		WaitlistedUserIds: out.WaitlistedUserIDs,
+	}, nil
+}
+
+func (s *GroupServer) RemoveGroupUsers(
+	ctx context.Context,
+	req *layeredv1.RemoveGroupUsersRequest,
+) (*layeredv1.RemoveGroupUsersResponse, error) {
+	if _, err := s.uc.RemoveGroupUsers(&dto.RemoveGroupUsersInput{
+		Meta:    newMeta(ctx),
+		GroupID: req.GetGroupId(),
+		UserIDs: req.GetUserIds(),
+	}); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &layeredv1.RemoveGroupUsersResponse{}, nil
+}
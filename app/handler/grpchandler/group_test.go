@@ -0,0 +1,169 @@
+package grpchandler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	mockusecase "github.com/toshiykst/go-layerd-architecture/app/mock/usecase"
+	layeredv1 "github.com/toshiykst/go-layerd-architecture/app/pb/layered/v1"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestGroupServer_GetGroups(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	updatedAt := time.Date(2023, 1, 3, 3, 4, 5, 0, time.UTC)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := mockusecase.NewMockGroupUsecase(ctrl)
+	uc.EXPECT().
+		GetGroups(&dto.GetGroupsInput{}).
+		Return(&dto.GetGroupsOutput{
+			Groups: []dto.Group{
+				{
+					GroupID: "TEST_GROUP_ID",
+					Name:    "TEST_GROUP_NAME",
+					Users: []dto.User{
+						{UserID: "TEST_USER_ID", Name: "TEST_USER_NAME", Email: "TEST_USER_EMAIL"},
+					},
+					Timestamps: dto.Timestamps{
+						CreatedAt: createdAt,
+						CreatedBy: "TEST_CREATOR",
+						UpdatedAt: updatedAt,
+						UpdatedBy: "TEST_UPDATER",
+					},
+				},
+			},
+		}, nil)
+
+	c := layeredv1.NewGroupServiceClient(newClientConn(t, nil, uc))
+
+	got, err := c.GetGroups(context.Background(), &layeredv1.GetGroupsRequest{})
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	want := &layeredv1.GetGroupsResponse{
+		Groups: []*layeredv1.Group{
+			{
+				GroupId: "TEST_GROUP_ID",
+				Name:    "TEST_GROUP_NAME",
+				Users: []*layeredv1.User{
+					{UserId: "TEST_USER_ID", Name: "TEST_USER_NAME", Email: "TEST_USER_EMAIL"},
+				},
+				CreatedAt: timestamppb.New(createdAt),
+				CreatedBy: "TEST_CREATOR",
+				UpdatedAt: timestamppb.New(updatedAt),
+				UpdatedBy: "TEST_UPDATER",
+			},
+		},
+	}
+	if diff := cmp.Diff(got, want, protocmp.Transform()); diff != "" {
+		t.Errorf("c.GetGroups()=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s", got, want, diff)
+	}
+}
+
+func TestGroupServer_AddGroupUsers(t *testing.T) {
+	tests := []struct {
+		name            string
+		req             *layeredv1.AddGroupUsersRequest
+		newGroupUsecase func(ctrl *gomock.Controller) usecase.GroupUsecase
+		want            *layeredv1.AddGroupUsersResponse
+		wantCode        codes.Code
+	}{
+		{
+			name: "Adds the users to the group",
+			req:  &layeredv1.AddGroupUsersRequest{GroupId: "TEST_GROUP_ID", UserIds: []string{"TEST_USER_ID"}},
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					AddGroupUsers(gomock.Any()).
+					DoAndReturn(func(in *dto.AddGroupUsersInput) (*dto.AddGroupUsersOutput, error) {
+						if diff := cmp.Diff(in.UserIDs, []string{"TEST_USER_ID"}); in.GroupID != "TEST_GROUP_ID" || diff != "" {
+							t.Errorf("uc.AddGroupUsers(%v); want group TEST_GROUP_ID and users [TEST_USER_ID]", in)
+						}
+						return &dto.AddGroupUsersOutput{}, nil
+					})
+				return uc
+			},
+			want:     &layeredv1.AddGroupUsersResponse{},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Returns the users waitlisted as the group is at capacity",
+			req:  &layeredv1.AddGroupUsersRequest{GroupId: "TEST_GROUP_ID", UserIds: []string{"TEST_USER_ID_1", "TEST_USER_ID_2"}},
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					AddGroupUsers(gomock.Any()).
+					Return(&dto.AddGroupUsersOutput{WaitlistedUserIDs: []string{"TEST_USER_ID_2"}}, nil)
+				return uc
+			},
+			want:     &layeredv1.AddGroupUsersResponse{WaitlistedUserIds: []string{"TEST_USER_ID_2"}},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Returns NotFound when the group does not exist",
+			req:  &layeredv1.AddGroupUsersRequest{GroupId: "TEST_GROUP_ID", UserIds: []string{"TEST_USER_ID"}},
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					AddGroupUsers(gomock.Any()).
+					Return(nil, usecase.ErrGroupNotFound)
+				return uc
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "Returns InvalidArgument when any of the users does not exist",
+			req:  &layeredv1.AddGroupUsersRequest{GroupId: "TEST_GROUP_ID", UserIds: []string{"TEST_USER_ID"}},
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					AddGroupUsers(gomock.Any()).
+					Return(nil, usecase.ErrInvalidUserIDs)
+				return uc
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Returns FailedPrecondition when any of the users is not active",
+			req:  &layeredv1.AddGroupUsersRequest{GroupId: "TEST_GROUP_ID", UserIds: []string{"TEST_USER_ID"}},
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					AddGroupUsers(gomock.Any()).
+					Return(nil, usecase.ErrUserInactive)
+				return uc
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			c := layeredv1.NewGroupServiceClient(newClientConn(t, nil, tt.newGroupUsecase(ctrl)))
+
+			got, err := c.AddGroupUsers(context.Background(), tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("c.AddGroupUsers(%v)=_, %v; want code %v", tt.req, err, tt.wantCode)
+			}
+			if tt.want != nil {
+				if diff := cmp.Diff(got, tt.want, protocmp.Transform()); diff != "" {
+					t.Errorf("c.AddGroupUsers(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s", tt.req, got, tt.want, diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package grpchandler
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+const (
+	// MetadataActorID is the metadata key carrying who performs the request.
+	MetadataActorID = "x-actor-id"
+	// MetadataRequestID is the metadata key carrying the request id. It is generated when missing.
+	MetadataRequestID = "x-request-id"
+)
+
+func newMeta(ctx context.Context) dto.Meta {
+	md, _ := metadata.FromIncomingContext(ctx)
+	rID := firstMetadata(md, MetadataRequestID)
+	if rID == "" {
+		rID = uuid.NewString()
+	}
+	return dto.Meta{
+		Actor:     firstMetadata(md, MetadataActorID),
+		RequestID: rID,
+	}
+}
+
+func firstMetadata(md metadata.MD, key string) string {
+	if vs := md.Get(key); len(vs) > 0 {
+		return vs[0]
+	}
+	return ""
+}
@@ -0,0 +1,59 @@
+package grpchandler
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	layeredv1 "github.com/toshiykst/go-layerd-architecture/app/pb/layered/v1"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func toUserFromDTO(dtou dto.User) *layeredv1.User {
+	return &layeredv1.User{
+		UserId:    dtou.UserID,
+		Name:      dtou.Name,
+		Email:     dtou.Email,
+		Status:    dtou.Status,
+		CreatedAt: toTimestamp(dtou.CreatedAt),
+		CreatedBy: dtou.CreatedBy,
+		UpdatedAt: toTimestamp(dtou.UpdatedAt),
+		UpdatedBy: dtou.UpdatedBy,
+	}
+}
+
+func toUsersFromDTO(dtous []dto.User) []*layeredv1.User {
+	result := make([]*layeredv1.User, len(dtous))
+	for i, dtou := range dtous {
+		result[i] = toUserFromDTO(dtou)
+	}
+	return result
+}
+
+func toGroupFromDTO(dtog dto.Group) *layeredv1.Group {
+	return &layeredv1.Group{
+		GroupId:   dtog.GroupID,
+		Name:      dtog.Name,
+		Users:     toUsersFromDTO(dtog.Users),
+		CreatedAt: toTimestamp(dtog.CreatedAt),
+		CreatedBy: dtog.CreatedBy,
+		UpdatedAt: toTimestamp(dtog.UpdatedAt),
+		UpdatedBy: dtog.UpdatedBy,
+	}
+}
+
+func toGroupsFromDTO(dtogs []dto.Group) []*layeredv1.Group {
+	result := make([]*layeredv1.Group, len(dtogs))
+	for i, dtog := range dtogs {
+		result[i] = toGroupFromDTO(dtog)
+	}
+	return result
+}
+
+// toTimestamp returns nil for the zero time, which a user or a group created before the timestamps has.
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,30 @@
+package grpchandler
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	layeredv1 "github.com/toshiykst/go-layerd-architecture/app/pb/layered/v1"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+)
+
+// NewServer returns a gRPC server serving the user and group services
+// along with the standard health and reflection services.
+func NewServer(uuc usecase.UserUsecase, guc usecase.GroupUsecase, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(opts...)
+
+	layeredv1.RegisterUserServiceServer(s, NewUserServer(uuc))
+	layeredv1.RegisterGroupServiceServer(s, NewGroupServer(guc))
+
+	hs := health.NewServer()
+	for name := range s.GetServiceInfo() {
+		hs.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(s, hs)
+
+	reflection.Register(s)
+
+	return s
+}
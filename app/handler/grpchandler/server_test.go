@@ -0,0 +1,57 @@
+package grpchandler_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/grpchandler"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+)
+
+func newClientConn(t *testing.T, uuc usecase.UserUsecase, guc usecase.GroupUsecase) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpchandler.NewServer(uuc, guc)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.DialContext(
+		context.Background(),
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+func TestNewServer_Health(t *testing.T) {
+	conn := newClientConn(t, nil, nil)
+	c := healthpb.NewHealthClient(conn)
+
+	for _, service := range []string{"", "layered.v1.UserService", "layered.v1.GroupService"} {
+		res, err := c.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("want no error, but has error %v", err)
+		}
+		if res.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("c.Check(%q).Status=%v; want %v", service, res.GetStatus(), healthpb.HealthCheckResponse_SERVING)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package grpchandler
+
+import (
+	"context"
+
+	layeredv1 "github.com/toshiykst/go-layerd-architecture/app/pb/layered/v1"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+type UserServer struct {
+	layeredv1.UnimplementedUserServiceServer
+	uc usecase.UserUsecase
+}
+
+func NewUserServer(uc usecase.UserUsecase) *UserServer {
+	return &UserServer{uc: uc}
+}
+
+func (s *UserServer) CreateUser(
+	ctx context.Context,
+	req *layeredv1.CreateUserRequest,
+) (*layeredv1.CreateUserResponse, error) {
+	out, err := s.uc.CreateUser(&dto.CreateUserInput{
+		Meta:  newMeta(ctx),
+		Name:  req.GetName(),
+		Email: req.GetEmail(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &layeredv1.CreateUserResponse{
+		User: toUserFromDTO(out.User),
+	}, nil
+}
+
+func (s *UserServer) GetUser(
+	_ context.Context,
+	req *layeredv1.GetUserRequest,
+) (*layeredv1.GetUserResponse, error) {
+	out, err := s.uc.GetUser(&dto.GetUserInput{
+		UserID: req.GetUserId(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &layeredv1.GetUserResponse{
+		User: toUserFromDTO(out.User),
+	}, nil
+}
+
+func (s *UserServer) GetUsers(
+	_ context.Context,
+	_ *layeredv1.GetUsersRequest,
+) (*layeredv1.GetUsersResponse, error) {
+	out, err := s.uc.GetUsers(&dto.GetUsersInput{})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &layeredv1.GetUsersResponse{
+		Users: toUsersFromDTO(out.Users),
+	}, nil
+}
+
+func (s *UserServer) UpdateUser(
+	ctx context.Context,
+	req *layeredv1.UpdateUserRequest,
+) (*layeredv1.UpdateUserResponse, error) {
+	if _, err := s.uc.UpdateUser(&dto.UpdateUserInput{
+		Meta:   newMeta(ctx),
+		UserID: req.GetUserId(),
+		Name:   req.GetName(),
+		Email:  req.GetEmail(),
+	}); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &layeredv1.UpdateUserResponse{}, nil
+}
+
+func (s *UserServer) DeleteUser(
+	ctx context.Context,
+	req *layeredv1.DeleteUserRequest,
+) (*layeredv1.DeleteUserResponse, error) {
+	if _, err := s.uc.DeleteUser(&dto.DeleteUserInput{
+		Meta:   newMeta(ctx),
+		UserID: req.GetUserId(),
+	}); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &layeredv1.DeleteUserResponse{}, nil
+}
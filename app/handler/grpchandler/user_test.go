@@ -0,0 +1,208 @@
+package grpchandler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/grpchandler"
+	mockusecase "github.com/toshiykst/go-layerd-architecture/app/mock/usecase"
+	layeredv1 "github.com/toshiykst/go-layerd-architecture/app/pb/layered/v1"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestUserServer_CreateUser(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            *layeredv1.CreateUserRequest
+		newUserUsecase func(ctrl *gomock.Controller) usecase.UserUsecase
+		want           *layeredv1.CreateUserResponse
+		wantCode       codes.Code
+	}{
+		{
+			name: "Returns the created user",
+			req:  &layeredv1.CreateUserRequest{Name: "TEST_USER_NAME", Email: "TEST_USER_EMAIL"},
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					CreateUser(&dto.CreateUserInput{
+						Meta:  dto.Meta{Actor: "TEST_ACTOR", RequestID: "TEST_REQUEST_ID"},
+						Name:  "TEST_USER_NAME",
+						Email: "TEST_USER_EMAIL",
+					}).
+					Return(&dto.CreateUserOutput{
+						User: dto.User{UserID: "TEST_USER_ID", Name: "TEST_USER_NAME", Email: "TEST_USER_EMAIL"},
+					}, nil)
+				return uc
+			},
+			want: &layeredv1.CreateUserResponse{
+				User: &layeredv1.User{UserId: "TEST_USER_ID", Name: "TEST_USER_NAME", Email: "TEST_USER_EMAIL"},
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Returns InvalidArgument when the input is invalid",
+			req:  &layeredv1.CreateUserRequest{},
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					CreateUser(gomock.Any()).
+					Return(nil, errors.Join(usecase.ErrInvalidUserInput, errors.New("user name must not empty")))
+				return uc
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Returns Internal when an unknown error occurred",
+			req:  &layeredv1.CreateUserRequest{Name: "TEST_USER_NAME", Email: "TEST_USER_EMAIL"},
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					CreateUser(gomock.Any()).
+					Return(nil, errors.New("an error occurred"))
+				return uc
+			},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			c := layeredv1.NewUserServiceClient(newClientConn(t, tt.newUserUsecase(ctrl), nil))
+			ctx := metadata.AppendToOutgoingContext(
+				context.Background(),
+				grpchandler.MetadataActorID, "TEST_ACTOR",
+				grpchandler.MetadataRequestID, "TEST_REQUEST_ID",
+			)
+
+			got, err := c.CreateUser(ctx, tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("c.CreateUser(%v)=_, %v; want code %v", tt.req, err, tt.wantCode)
+			}
+			if diff := cmp.Diff(got, tt.want, protocmp.Transform()); diff != "" {
+				t.Errorf(
+					"c.CreateUser(%v)=%v, _; want %v, _\ndiffers: (-got +want)\n%s",
+					tt.req, got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
+
+func TestUserServer_GetUser(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	updatedAt := time.Date(2023, 1, 3, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name           string
+		req            *layeredv1.GetUserRequest
+		newUserUsecase func(ctrl *gomock.Controller) usecase.UserUsecase
+		want           *layeredv1.GetUserResponse
+		wantCode       codes.Code
+	}{
+		{
+			name: "Returns the user",
+			req:  &layeredv1.GetUserRequest{UserId: "TEST_USER_ID"},
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					GetUser(&dto.GetUserInput{UserID: "TEST_USER_ID"}).
+					Return(&dto.GetUserOutput{
+						User: dto.User{
+							UserID: "TEST_USER_ID",
+							Name:   "TEST_USER_NAME",
+							Email:  "TEST_USER_EMAIL",
+							Status: "SUSPENDED",
+							Timestamps: dto.Timestamps{
+								CreatedAt: createdAt,
+								CreatedBy: "TEST_CREATOR",
+								UpdatedAt: updatedAt,
+								UpdatedBy: "TEST_UPDATER",
+							},
+						},
+					}, nil)
+				return uc
+			},
+			want: &layeredv1.GetUserResponse{
+				User: &layeredv1.User{
+					UserId:    "TEST_USER_ID",
+					Name:      "TEST_USER_NAME",
+					Email:     "TEST_USER_EMAIL",
+					Status:    "SUSPENDED",
+					CreatedAt: timestamppb.New(createdAt),
+					CreatedBy: "TEST_CREATOR",
+					UpdatedAt: timestamppb.New(updatedAt),
+					UpdatedBy: "TEST_UPDATER",
+				},
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Returns NotFound when the user does not exist",
+			req:  &layeredv1.GetUserRequest{UserId: "TEST_USER_ID"},
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					GetUser(&dto.GetUserInput{UserID: "TEST_USER_ID"}).
+					Return(nil, usecase.ErrUserNotFound)
+				return uc
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			c := layeredv1.NewUserServiceClient(newClientConn(t, tt.newUserUsecase(ctrl), nil))
+
+			got, err := c.GetUser(context.Background(), tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("c.GetUser(%v)=_, %v; want code %v", tt.req, err, tt.wantCode)
+			}
+			if diff := cmp.Diff(got, tt.want, protocmp.Transform()); diff != "" {
+				t.Errorf(
+					"c.GetUser(%v)=%v, _; want %v, _\ndiffers: (-got +want)\n%s",
+					tt.req, got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
+
+func TestUserServer_DeleteUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := mockusecase.NewMockUserUsecase(ctrl)
+	uc.EXPECT().
+		DeleteUser(gomock.Any()).
+		DoAndReturn(func(in *dto.DeleteUserInput) (*dto.DeleteUserOutput, error) {
+			if in.UserID != "TEST_USER_ID" {
+				t.Errorf("in.UserID=%s; want TEST_USER_ID", in.UserID)
+			}
+			if in.RequestID == "" {
+				t.Error("want the request id to be generated")
+			}
+			return &dto.DeleteUserOutput{}, nil
+		})
+
+	c := layeredv1.NewUserServiceClient(newClientConn(t, uc, nil))
+	if _, err := c.DeleteUser(context.Background(), &layeredv1.DeleteUserRequest{UserId: "TEST_USER_ID"}); err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+}
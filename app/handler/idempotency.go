@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+const (
+	// HeaderIdempotencyKey is the header carrying the key a client chooses for a request, so that its retries are
+	// responded with the response to the first one instead of being performed again.
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed is set on a response replayed for a retry.
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+type IdempotencyHandler struct {
+	uc usecase.IdempotencyUsecase
+}
+
+func NewIdempotencyHandler(uc usecase.IdempotencyUsecase) *IdempotencyHandler {
+	return &IdempotencyHandler{uc: uc}
+}
+
+// Middleware makes a POST request with an Idempotency-Key idempotent. The response to the first request with a key
+// is recorded and replayed on the retries with the same request, whereas a request with the key but another body
+// is rejected with IDEMPOTENCY_KEY_MISMATCH. A request failed with 5xx is not recorded, so that it can be retried.
+func (h *IdempotencyHandler) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		key := req.Header.Get(HeaderIdempotencyKey)
+		if req.Method != http.MethodPost || key == "" {
+			return next(c)
+		}
+
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return response.ErrorInternal(c, err)
+		}
+		// The handler binds the body again.
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		out, err := h.uc.BeginIdempotentRequest(&dto.BeginIdempotentRequestInput{
+			Key:         key,
+			Fingerprint: fingerprint(req, body),
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrInvalidIdempotencyKeyInput):
+				return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+			case errors.Is(err, usecase.ErrIdempotencyKeyMismatch):
+				return response.Error(c, response.ErrorCodeIdempotencyKeyMismatch, http.StatusUnprocessableEntity, err)
+			case errors.Is(err, usecase.ErrIdempotencyKeyInProgress):
+				return response.Error(c, response.ErrorCodeIdempotencyKeyInProgress, http.StatusConflict, err)
+			default:
+				return response.ErrorInternal(c, err)
+			}
+		}
+		if out.Replay != nil {
+			c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+			if len(out.Replay.Body) == 0 {
+				return c.NoContent(out.Replay.StatusCode)
+			}
+			return c.Blob(out.Replay.StatusCode, out.Replay.ContentType, out.Replay.Body)
+		}
+
+		res := c.Response()
+		w := &recordingResponseWriter{ResponseWriter: res.Writer}
+		res.Writer = w
+		err = next(c)
+		res.Writer = w.ResponseWriter
+
+		if err != nil || !res.Committed || res.Status >= http.StatusInternalServerError {
+			if _, rerr := h.uc.ReleaseIdempotentRequest(&dto.ReleaseIdempotentRequestInput{Key: key}); rerr != nil {
+				c.Logger().Errorf("failed to release the idempotency key %q: %v", key, rerr)
+			}
+			return err
+		}
+
+		if _, err := h.uc.CompleteIdempotentRequest(&dto.CompleteIdempotentRequestInput{
+			Key: key,
+			Response: dto.IdempotentResponse{
+				StatusCode:  res.Status,
+				ContentType: res.Header().Get(echo.HeaderContentType),
+				Body:        w.body.Bytes(),
+			},
+		}); err != nil {
+			// The request has been performed and responded, so the retries are rejected until the key expires.
+			c.Logger().Errorf("failed to complete the idempotency key %q: %v", key, err)
+		}
+		return nil
+	}
+}
+
+// fingerprint identifies a request by its method, uri, actor and body.
+func fingerprint(req *http.Request, body []byte) string {
+	h := sha256.New()
+	for _, v := range []string{req.Method, req.URL.RequestURI(), req.Header.Get(HeaderXActorID)} {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recordingResponseWriter records the body written to the response.
+type recordingResponseWriter struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingResponseWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
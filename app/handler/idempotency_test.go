@@ -0,0 +1,228 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/labstack/echo"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	mockusecase "github.com/toshiykst/go-layerd-architecture/app/mock/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestIdempotencyHandler_Middleware(t *testing.T) {
+	const reqBody = `{"name":"TEST_USER_NAME"}`
+	tests := []struct {
+		name                  string
+		method                string
+		key                   string
+		handlerStatus         int
+		newIdempotencyUsecase func(ctrl *gomock.Controller) usecase.IdempotencyUsecase
+		wantStatus            int
+		wantBody              string
+		wantErrCode           response.ErrorCode
+		wantReplayed          bool
+		wantHandled           bool
+	}{
+		{
+			name:          "Performs the request without a key",
+			method:        http.MethodPost,
+			handlerStatus: http.StatusCreated,
+			newIdempotencyUsecase: func(ctrl *gomock.Controller) usecase.IdempotencyUsecase {
+				return mockusecase.NewMockIdempotencyUsecase(ctrl)
+			},
+			wantStatus:  http.StatusCreated,
+			wantBody:    `{"userId":"TEST_USER_ID"}`,
+			wantHandled: true,
+		},
+		{
+			name:          "Performs the request other than POST",
+			method:        http.MethodPut,
+			key:           "TEST_IDEMPOTENCY_KEY",
+			handlerStatus: http.StatusOK,
+			newIdempotencyUsecase: func(ctrl *gomock.Controller) usecase.IdempotencyUsecase {
+				return mockusecase.NewMockIdempotencyUsecase(ctrl)
+			},
+			wantStatus:  http.StatusOK,
+			wantBody:    `{"userId":"TEST_USER_ID"}`,
+			wantHandled: true,
+		},
+		{
+			name:          "Performs the request with a new key and records the response",
+			method:        http.MethodPost,
+			key:           "TEST_IDEMPOTENCY_KEY",
+			handlerStatus: http.StatusCreated,
+			newIdempotencyUsecase: func(ctrl *gomock.Controller) usecase.IdempotencyUsecase {
+				uc := mockusecase.NewMockIdempotencyUsecase(ctrl)
+				uc.EXPECT().
+					BeginIdempotentRequest(gomock.Any()).
+					DoAndReturn(func(in *dto.BeginIdempotentRequestInput) (*dto.BeginIdempotentRequestOutput, error) {
+						if in.Key != "TEST_IDEMPOTENCY_KEY" || in.Fingerprint == "" {
+							t.Errorf("uc.BeginIdempotentRequest(%v); want the key and a fingerprint", in)
+						}
+						return &dto.BeginIdempotentRequestOutput{}, nil
+					})
+				uc.EXPECT().
+					CompleteIdempotentRequest(&dto.CompleteIdempotentRequestInput{
+						Key: "TEST_IDEMPOTENCY_KEY",
+						Response: dto.IdempotentResponse{
+							StatusCode:  http.StatusCreated,
+							ContentType: echo.MIMEApplicationJSONCharsetUTF8,
+							Body:        []byte(`{"userId":"TEST_USER_ID"}` + "\n"),
+						},
+					}).
+					Return(&dto.CompleteIdempotentRequestOutput{}, nil)
+				return uc
+			},
+			wantStatus:  http.StatusCreated,
+			wantBody:    `{"userId":"TEST_USER_ID"}`,
+			wantHandled: true,
+		},
+		{
+			name:          "Releases the key of a request failed with 5xx",
+			method:        http.MethodPost,
+			key:           "TEST_IDEMPOTENCY_KEY",
+			handlerStatus: http.StatusInternalServerError,
+			newIdempotencyUsecase: func(ctrl *gomock.Controller) usecase.IdempotencyUsecase {
+				uc := mockusecase.NewMockIdempotencyUsecase(ctrl)
+				uc.EXPECT().
+					BeginIdempotentRequest(gomock.Any()).
+					Return(&dto.BeginIdempotentRequestOutput{}, nil)
+				uc.EXPECT().
+					ReleaseIdempotentRequest(&dto.ReleaseIdempotentRequestInput{Key: "TEST_IDEMPOTENCY_KEY"}).
+					Return(&dto.ReleaseIdempotentRequestOutput{}, nil)
+				return uc
+			},
+			wantStatus:  http.StatusInternalServerError,
+			wantBody:    `{"userId":"TEST_USER_ID"}`,
+			wantHandled: true,
+		},
+		{
+			name:   "Replays the response to the completed request",
+			method: http.MethodPost,
+			key:    "TEST_IDEMPOTENCY_KEY",
+			newIdempotencyUsecase: func(ctrl *gomock.Controller) usecase.IdempotencyUsecase {
+				uc := mockusecase.NewMockIdempotencyUsecase(ctrl)
+				uc.EXPECT().
+					BeginIdempotentRequest(gomock.Any()).
+					Return(&dto.BeginIdempotentRequestOutput{
+						Replay: &dto.IdempotentResponse{
+							StatusCode:  http.StatusCreated,
+							ContentType: echo.MIMEApplicationJSONCharsetUTF8,
+							Body:        []byte(`{"userId":"TEST_FIRST_USER_ID"}`),
+						},
+					}, nil)
+				return uc
+			},
+			wantStatus:   http.StatusCreated,
+			wantBody:     `{"userId":"TEST_FIRST_USER_ID"}`,
+			wantReplayed: true,
+		},
+		{
+			name:   "Error the key is used for another request",
+			method: http.MethodPost,
+			key:    "TEST_IDEMPOTENCY_KEY",
+			newIdempotencyUsecase: func(ctrl *gomock.Controller) usecase.IdempotencyUsecase {
+				uc := mockusecase.NewMockIdempotencyUsecase(ctrl)
+				uc.EXPECT().
+					BeginIdempotentRequest(gomock.Any()).
+					Return(nil, usecase.ErrIdempotencyKeyMismatch)
+				return uc
+			},
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantErrCode: response.ErrorCodeIdempotencyKeyMismatch,
+		},
+		{
+			name:   "Error the request is in progress",
+			method: http.MethodPost,
+			key:    "TEST_IDEMPOTENCY_KEY",
+			newIdempotencyUsecase: func(ctrl *gomock.Controller) usecase.IdempotencyUsecase {
+				uc := mockusecase.NewMockIdempotencyUsecase(ctrl)
+				uc.EXPECT().
+					BeginIdempotentRequest(gomock.Any()).
+					Return(nil, usecase.ErrIdempotencyKeyInProgress)
+				return uc
+			},
+			wantStatus:  http.StatusConflict,
+			wantErrCode: response.ErrorCodeIdempotencyKeyInProgress,
+		},
+		{
+			name:   "Error invalid key",
+			method: http.MethodPost,
+			key:    "TEST_IDEMPOTENCY_KEY",
+			newIdempotencyUsecase: func(ctrl *gomock.Controller) usecase.IdempotencyUsecase {
+				uc := mockusecase.NewMockIdempotencyUsecase(ctrl)
+				uc.EXPECT().
+					BeginIdempotentRequest(gomock.Any()).
+					Return(nil, usecase.ErrInvalidIdempotencyKeyInput)
+				return uc
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErrCode: response.ErrorCodeInvalidArguments,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := handler.NewIdempotencyHandler(tt.newIdempotencyUsecase(ctrl))
+
+			handled := false
+			next := func(c echo.Context) error {
+				handled = true
+				body := map[string]any{}
+				if err := c.Bind(&body); err != nil || body["name"] != "TEST_USER_NAME" {
+					t.Errorf("c.Bind()=%v with %v; want the request body", err, body)
+				}
+				return c.JSON(tt.handlerStatus, map[string]string{"userId": "TEST_USER_ID"})
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(tt.method, "/users", strings.NewReader(reqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tt.key != "" {
+				req.Header.Set(handler.HeaderIdempotencyKey, tt.key)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			if err := h.Middleware(next)(c); err != nil {
+				t.Fatalf("want no error, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("rec.Code=%d; want %d", rec.Code, tt.wantStatus)
+			}
+			if handled != tt.wantHandled {
+				t.Errorf("handled=%t; want %t", handled, tt.wantHandled)
+			}
+			if got := rec.Header().Get(handler.HeaderIdempotentReplayed) == "true"; got != tt.wantReplayed {
+				t.Errorf("replayed=%t; want %t", got, tt.wantReplayed)
+			}
+
+			if tt.wantErrCode != "" {
+				res := &response.ErrorResponse{}
+				if err := json.Unmarshal(rec.Body.Bytes(), res); err != nil {
+					t.Fatalf("want no error, but has error: %v", err)
+				}
+				if res.Code != tt.wantErrCode {
+					t.Errorf("res.Code=%s; want %s", res.Code, tt.wantErrCode)
+				}
+				return
+			}
+			if diff := cmp.Diff(strings.TrimSpace(rec.Body.String()), tt.wantBody); diff != "" {
+				t.Errorf("rec.Body=%s; want %s\ndiffers: (-got +want)\n%s", rec.Body.String(), tt.wantBody, diff)
+			}
+		})
+	}
+}
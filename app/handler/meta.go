@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// HeaderXActorID is the header carrying who performs the request.
+const HeaderXActorID = "X-Actor-ID"
+
+func newMeta(c echo.Context) dto.Meta {
+	rID := c.Response().Header().Get(echo.HeaderXRequestID)
+	if rID == "" {
+		rID = c.Request().Header.Get(echo.HeaderXRequestID)
+	}
+	return dto.Meta{
+		Actor:     c.Request().Header.Get(HeaderXActorID),
+		RequestID: rID,
+	}
+}
@@ -0,0 +1,235 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+)
+
+// Version is the version of the API in the document.
+const Version = "1.0.0"
+
+type (
+	Document struct {
+		OpenAPI    string                                 `json:"openapi"`
+		Info       Info                                   `json:"info"`
+		Paths      map[string]map[string]*OperationObject `json:"paths"`
+		Components Components                             `json:"components"`
+	}
+
+	Info struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	}
+
+	OperationObject struct {
+		OperationID string                     `json:"operationId"`
+		Summary     string                     `json:"summary"`
+		Tags        []string                   `json:"tags"`
+		Parameters  []*ParameterObject         `json:"parameters,omitempty"`
+		RequestBody *RequestBody               `json:"requestBody,omitempty"`
+		Responses   map[string]*ResponseObject `json:"responses"`
+	}
+
+	ParameterObject struct {
+		Name        string  `json:"name"`
+		In          string  `json:"in"`
+		Description string  `json:"description,omitempty"`
+		Required    bool    `json:"required"`
+		Schema      *Schema `json:"schema"`
+	}
+
+	RequestBody struct {
+		Required bool                  `json:"required"`
+		Content  map[string]*MediaType `json:"content"`
+	}
+
+	ResponseObject struct {
+		Description string                `json:"description"`
+		Content     map[string]*MediaType `json:"content,omitempty"`
+	}
+
+	MediaType struct {
+		Schema *Schema `json:"schema"`
+	}
+
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	}
+)
+
+const contentTypeJSON = "application/json"
+
+// errorStatuses are the HTTP statuses responded with each error code.
+var errorStatuses = map[response.ErrorCode]int{
+	response.ErrorCodeInternalServerError:         http.StatusInternalServerError,
+	response.ErrorCodeInvalidArguments:            http.StatusBadRequest,
+	response.ErrorCodeUserNotFound:                http.StatusNotFound,
+	response.ErrorCodeGroupNotFound:               http.StatusNotFound,
+	response.ErrorCodeInvalidUserStatusTransition: http.StatusConflict,
+	response.ErrorCodeUserInactive:                http.StatusConflict,
+	response.ErrorCodeSubgroupNotFound:            http.StatusNotFound,
+	response.ErrorCodeGroupCycle:                  http.StatusConflict,
+	response.ErrorCodeGroupInvitationNotFound:     http.StatusNotFound,
+	response.ErrorCodeGroupInvitationNotPending:   http.StatusConflict,
+	response.ErrorCodeGroupJoinNotAllowed:         http.StatusForbidden,
+	response.ErrorCodeGroupJoinRequestNotFound:    http.StatusNotFound,
+	response.ErrorCodeGroupJoinRequestNotPending:  http.StatusConflict,
+	response.ErrorCodeGroupJoinRequestForbidden:   http.StatusForbidden,
+	response.ErrorCodeUnsupportedMediaType:        http.StatusUnsupportedMediaType,
+	response.ErrorCodeWebhookSubscriptionNotFound: http.StatusNotFound,
+	response.ErrorCodeWebhookDeliveryNotFound:     http.StatusNotFound,
+	response.ErrorCodeIdempotencyKeyMismatch:      http.StatusUnprocessableEntity,
+	response.ErrorCodeIdempotencyKeyInProgress:    http.StatusConflict,
+	response.ErrorCodeAttributeSchemaNotFound:     http.StatusNotFound,
+}
+
+var pathParamPattern = regexp.MustCompile(`:(\w+)`)
+
+// Generate generates the OpenAPI document of the operations.
+func Generate(ops []Operation) *Document {
+	g := newSchemaGenerator()
+
+	codes := make([]any, len(response.ErrorCodes))
+	for i, code := range response.ErrorCodes {
+		if _, ok := errorStatuses[code]; !ok {
+			panic(fmt.Sprintf("openapi: no HTTP status for the error code %s", code))
+		}
+		codes[i] = string(code)
+	}
+	errorRef := g.generate(reflect.TypeOf(response.ErrorResponse{}))
+	g.components["ErrorResponse"].Properties["code"].Enum = codes
+
+	doc := &Document{
+		OpenAPI: "3.1.0",
+		Info: Info{
+			Title:   "go-layerd-architecture",
+			Version: Version,
+		},
+		Paths:      map[string]map[string]*OperationObject{},
+		Components: Components{Schemas: g.components},
+	}
+	for _, op := range ops {
+		path := ToPath(op.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]*OperationObject{}
+		}
+		doc.Paths[path][strings.ToLower(op.Method)] = newOperationObject(g, op, errorRef)
+	}
+	return doc
+}
+
+// ToPath converts a path in echo's syntax to the OpenAPI syntax, e.g. /users/:id to /users/{id}.
+func ToPath(echoPath string) string {
+	return pathParamPattern.ReplaceAllString(echoPath, "{$1}")
+}
+
+func newOperationObject(g *schemaGenerator, op Operation, errorRef *Schema) *OperationObject {
+	item := &OperationObject{
+		OperationID: op.ID,
+		Summary:     op.Summary,
+		Tags:        []string{op.Tag},
+		Responses:   map[string]*ResponseObject{},
+	}
+
+	for _, m := range pathParamPattern.FindAllStringSubmatch(op.Path, -1) {
+		item.Parameters = append(item.Parameters, &ParameterObject{
+			Name:     m[1],
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+	for _, p := range op.Query {
+		item.Parameters = append(item.Parameters, newParameter(p, "query"))
+	}
+	headers := op.Headers
+	if op.Method == http.MethodPost {
+		headers = append(append([]Parameter{}, headers...), idempotencyKeyHeader)
+	}
+	if op.Conditional {
+		headers = append(append([]Parameter{}, headers...), ifModifiedSinceHeader)
+	}
+	for _, p := range headers {
+		item.Parameters = append(item.Parameters, newParameter(p, "header"))
+	}
+
+	requests := map[string]any{}
+	if op.Request != nil {
+		requests[contentTypeJSON] = op.Request
+	}
+	for ct, req := range op.Requests {
+		requests[ct] = req
+	}
+	if len(requests) > 0 {
+		item.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{},
+		}
+		for ct, req := range requests {
+			s := g.generate(reflect.TypeOf(req))
+			g.strict(s)
+			item.RequestBody.Content[ct] = &MediaType{Schema: s}
+		}
+	}
+
+	responses := map[string]any{}
+	if op.Response != nil {
+		ct := op.ContentType
+		if ct == "" {
+			ct = contentTypeJSON
+		}
+		responses[ct] = op.Response
+	}
+	for ct, res := range op.Responses {
+		responses[ct] = res
+	}
+	res := &ResponseObject{Description: http.StatusText(op.Status)}
+	if len(responses) > 0 {
+		res.Content = map[string]*MediaType{}
+		for ct, v := range responses {
+			res.Content[ct] = &MediaType{Schema: g.generate(reflect.TypeOf(v))}
+		}
+	}
+	item.Responses[fmt.Sprint(op.Status)] = res
+	if op.Conditional {
+		item.Responses[fmt.Sprint(http.StatusNotModified)] = &ResponseObject{
+			Description: http.StatusText(http.StatusNotModified),
+		}
+	}
+
+	codesByStatus := map[int][]string{}
+	codes := append([]response.ErrorCode{}, op.Errors...)
+	if op.Method == http.MethodPost {
+		codes = append(codes, response.ErrorCodeIdempotencyKeyMismatch, response.ErrorCodeIdempotencyKeyInProgress)
+	}
+	for _, code := range append(codes, response.ErrorCodeInternalServerError) {
+		status, ok := errorStatuses[code]
+		if !ok {
+			panic(fmt.Sprintf("openapi: no HTTP status for the error code %s", code))
+		}
+		codesByStatus[status] = append(codesByStatus[status], string(code))
+	}
+	for status, codes := range codesByStatus {
+		item.Responses[fmt.Sprint(status)] = &ResponseObject{
+			Description: strings.Join(codes, ", "),
+			Content: map[string]*MediaType{
+				contentTypeJSON: {Schema: errorRef},
+			},
+		}
+	}
+	return item
+}
+
+func newParameter(p Parameter, in string) *ParameterObject {
+	return &ParameterObject{
+		Name:        p.Name,
+		In:          in,
+		Description: p.Description,
+		Schema:      &Schema{Type: "string", Format: p.Format},
+	}
+}
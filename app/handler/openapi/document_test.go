@@ -0,0 +1,42 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/openapi"
+)
+
+var update = flag.Bool("update", false, "update openapi.json")
+
+// The committed openapi.json is the contract the clients rely on,
+// so a change of the routes or the request and response types fails until it is regenerated with:
+//
+//	make openapi
+const specFile = "openapi.json"
+
+func TestGenerate(t *testing.T) {
+	got, err := json.MarshalIndent(openapi.Generate(openapi.Operations), "", "  ")
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	got = append(got, '\n')
+
+	if *update {
+		if err := os.WriteFile(specFile, got, 0o644); err != nil {
+			t.Fatalf("want no err, but has error %v", err)
+		}
+	}
+
+	want, err := os.ReadFile(specFile)
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	if diff := cmp.Diff(string(got), string(want)); diff != "" {
+		t.Errorf("openapi.Generate(openapi.Operations) differs from %s\ndiffers: (-got +want)\n%s", specFile, diff)
+	}
+}
@@ -0,0 +1,78 @@
+package openapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+// SpecPath is the path the document is served at.
+const SpecPath = "/openapi.json"
+
+// swaggerInitializer replaces the initializer of Swagger UI, which loads the Petstore example.
+const swaggerInitializer = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: "` + SpecPath + `",
+    dom_id: '#swagger-ui',
+    deepLinking: true,
+    presets: [
+      SwaggerUIBundle.presets.apis,
+      SwaggerUIStandalonePreset
+    ],
+    plugins: [
+      SwaggerUIBundle.plugins.DownloadUrl
+    ],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+type Handler struct {
+	spec []byte
+}
+
+// NewHandler returns a handler serving the document of the operations and Swagger UI for it.
+func NewHandler(ops []Operation) (*Handler, error) {
+	spec, err := json.Marshal(Generate(ops))
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{spec: spec}, nil
+}
+
+func (h *Handler) GetSpec(c echo.Context) error {
+	return c.JSONBlob(http.StatusOK, h.spec)
+}
+
+// SwaggerUI serves the files of Swagger UI, routed at /swagger and /swagger/*.
+func (h *Handler) SwaggerUI(c echo.Context) error {
+	name := path.Clean("/" + c.Param("*"))
+	switch name {
+	case "/swagger-initializer.js":
+		return c.Blob(http.StatusOK, echo.MIMEApplicationJavaScript, []byte(swaggerInitializer))
+	case "/":
+		// The assets are referred to relatively to the index.
+		if !strings.HasSuffix(c.Request().URL.Path, "/") {
+			return c.Redirect(http.StatusMovedPermanently, c.Request().URL.Path+"/")
+		}
+		name = "/index.html"
+	}
+
+	f, err := swaggerFiles.FS.Open(name[1:])
+	if err != nil {
+		return echo.ErrNotFound
+	}
+	defer f.Close()
+	rs, ok := f.(io.ReadSeeker)
+	if !ok {
+		return echo.ErrNotFound
+	}
+	http.ServeContent(c.Response(), c.Request(), name, time.Time{}, rs)
+	return nil
+}
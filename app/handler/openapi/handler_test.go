@@ -0,0 +1,117 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/openapi"
+)
+
+func TestHandler_GetSpec(t *testing.T) {
+	h, err := openapi.NewHandler(openapi.Operations)
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, openapi.SpecPath, nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if err := h.GetSpec(c); err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("h.GetSpec(c) status=%d; want %d", rec.Code, http.StatusOK)
+	}
+	doc := &openapi.Document{}
+	if err := json.Unmarshal(rec.Body.Bytes(), doc); err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	if doc.OpenAPI != "3.1.0" {
+		t.Errorf("doc.OpenAPI=%s; want 3.1.0", doc.OpenAPI)
+	}
+}
+
+func TestHandler_SwaggerUI(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		param        string
+		wantStatus   int
+		wantLocation string
+		wantContains string
+	}{
+		{
+			name:         "Redirects to the index with a trailing slash",
+			path:         "/swagger",
+			wantStatus:   http.StatusMovedPermanently,
+			wantLocation: "/swagger/",
+		},
+		{
+			name:         "Serves the index",
+			path:         "/swagger/",
+			wantStatus:   http.StatusOK,
+			wantContains: "swagger-initializer.js",
+		},
+		{
+			name:         "Serves the initializer loading the document",
+			path:         "/swagger/swagger-initializer.js",
+			param:        "swagger-initializer.js",
+			wantStatus:   http.StatusOK,
+			wantContains: `url: "/openapi.json"`,
+		},
+		{
+			name:         "Serves an asset",
+			path:         "/swagger/swagger-ui.css",
+			param:        "swagger-ui.css",
+			wantStatus:   http.StatusOK,
+			wantContains: ".swagger-ui",
+		},
+	}
+
+	h, err := openapi.NewHandler(openapi.Operations)
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("*")
+			c.SetParamValues(tt.param)
+
+			if err := h.SwaggerUI(c); err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("h.SwaggerUI(c) status=%d; want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get(echo.HeaderLocation); got != tt.wantLocation {
+				t.Errorf("h.SwaggerUI(c) location=%s; want %s", got, tt.wantLocation)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantContains) {
+				t.Errorf("h.SwaggerUI(c) body does not contain %q", tt.wantContains)
+			}
+		})
+	}
+
+	t.Run("Not found", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/swagger/unknown.js", nil)
+		c := e.NewContext(req, httptest.NewRecorder())
+		c.SetParamNames("*")
+		c.SetParamValues("unknown.js")
+
+		if err := h.SwaggerUI(c); err != echo.ErrNotFound {
+			t.Errorf("h.SwaggerUI(c)=%v; want %v", err, echo.ErrNotFound)
+		}
+	})
+}
@@ -0,0 +1,803 @@
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/export"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/userimport"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// Operation describes an operation of the HTTP API, from which the document is generated.
+type Operation struct {
+	Method string
+	// Path is the path of the route in echo's syntax, e.g. /users/:id.
+	Path    string
+	ID      string
+	Summary string
+	Tag     string
+	Query   []Parameter
+	Headers []Parameter
+	// Request is a value of the type of the JSON request body, nil if there is no body.
+	Request any
+	// Requests are values of the types of the request bodies by content type, for a body other than JSON.
+	Requests map[string]any
+	Status   int
+	// Response is a value of the type of the response body, nil if there is no body.
+	Response any
+	// ContentType is the content type of the response, application/json if empty.
+	ContentType string
+	// Responses are values of the types of the response bodies by content type, for an operation responding in
+	// several content types.
+	Responses map[string]any
+	// Errors are the error codes the operation responds with besides INTERNAL_SERVER_ERROR.
+	Errors []response.ErrorCode
+	// Conditional is whether the operation responds with the Last-Modified header, and with 304 Not Modified to a
+	// request with the If-Modified-Since header if the resource has not been modified since then.
+	Conditional bool
+}
+
+// Parameter describes a query or header parameter.
+type Parameter struct {
+	Name        string
+	Description string
+	Format      string
+}
+
+var actorHeader = Parameter{
+	Name:        handler.HeaderXActorID,
+	Description: "Who performs the request, recorded in the audit log.",
+}
+
+// idempotencyKeyHeader is documented on every POST operation, since the idempotency middleware applies to all of them.
+var idempotencyKeyHeader = Parameter{
+	Name: handler.HeaderIdempotencyKey,
+	Description: "A unique key of the request, e.g. a UUID. A retry with the key is responded with the response " +
+		"to the first request instead of being performed again, until the key expires.",
+}
+
+var ifModifiedSinceHeader = Parameter{
+	Name:        echo.HeaderIfModifiedSince,
+	Description: "Responds with 304 Not Modified if the resource has not been updated since it.",
+}
+
+var sortQuery = Parameter{
+	Name: "sort",
+	Description: "createdAt or updatedAt, prefixed with - for descending order. Orders by it and then by id " +
+		"instead of by relevance.",
+}
+
+var exportFormatQuery = Parameter{
+	Name:        "format",
+	Description: "csv, ndjson or json, which takes precedence over the Accept header.",
+}
+
+var exportHeaders = []Parameter{
+	{Name: echo.HeaderAccept, Description: "text/csv, application/x-ndjson or application/json, the default."},
+	{Name: echo.HeaderAcceptEncoding, Description: "Compresses the export in gzip if it accepts gzip."},
+}
+
+// Operations are the operations of the HTTP API.
+var Operations = []Operation{
+	{
+		Method:   http.MethodPost,
+		Path:     "/users",
+		ID:       "createUser",
+		Summary:  "Create a user",
+		Tag:      "users",
+		Headers:  []Parameter{actorHeader},
+		Request:  handler.CreateUserRequest{},
+		Status:   http.StatusCreated,
+		Response: handler.CreateUserResponse{},
+		Errors:   []response.ErrorCode{response.ErrorCodeInvalidArguments},
+	},
+	{
+		Method:      http.MethodGet,
+		Path:        "/users/:id",
+		ID:          "getUser",
+		Summary:     "Get a user",
+		Tag:         "users",
+		Status:      http.StatusOK,
+		Response:    handler.GetUserResponse{},
+		Errors:      []response.ErrorCode{response.ErrorCodeUserNotFound},
+		Conditional: true,
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/users",
+		ID:      "getUsers",
+		Summary: "List users, or search them by name and email",
+		Tag:     "users",
+		Query: []Parameter{
+			{
+				Name: "q",
+				Description: "Searches the users whose name and email contains it, case-insensitively, ordered by " +
+					"relevance: an exact match, a prefix match and then a substring match.",
+			},
+			{Name: "noGroup", Description: "Lists only the users who belong to no group when true."},
+			{Name: "createdAfter", Description: "Lists only the users created after it.", Format: "date-time"},
+			{Name: "status", Description: "Lists only the users in it: INVITED, ACTIVE, SUSPENDED or DEACTIVATED."},
+			{Name: "attributes", Description: "Comma-separated key:value pairs. Lists only the users with all the attribute values."},
+			sortQuery,
+		},
+		Status:   http.StatusOK,
+		Response: handler.GetUsersResponse{},
+		Errors:   []response.ErrorCode{response.ErrorCodeInvalidArguments},
+	},
+	{
+		Method:  http.MethodPut,
+		Path:    "/users/:id",
+		ID:      "updateUser",
+		Summary: "Update a user",
+		Tag:     "users",
+		Headers: []Parameter{actorHeader},
+		Request: handler.UpdateUserRequest{},
+		Status:  http.StatusNoContent,
+		Errors:  []response.ErrorCode{response.ErrorCodeInvalidArguments, response.ErrorCodeUserNotFound},
+	},
+	{
+		Method:  http.MethodPatch,
+		Path:    "/users/:id",
+		ID:      "patchUser",
+		Summary: "Patch a user with a JSON Merge Patch or a JSON Patch",
+		Tag:     "users",
+		Headers: []Parameter{actorHeader},
+		Requests: map[string]any{
+			string(dto.PatchTypeMergePatch): handler.PatchUserRequest{},
+			string(dto.PatchTypeJSONPatch):  []handler.JSONPatchOperation{},
+		},
+		Status: http.StatusNoContent,
+		Errors: []response.ErrorCode{
+			response.ErrorCodeInvalidArguments,
+			response.ErrorCodeUserNotFound,
+			response.ErrorCodeUnsupportedMediaType,
+		},
+	},
+	{
+		Method:  http.MethodDelete,
+		Path:    "/users/:id",
+		ID:      "deleteUser",
+		Summary: "Delete a user",
+		Tag:     "users",
+		Headers: []Parameter{actorHeader},
+		Status:  http.StatusNoContent,
+		Errors:  []response.ErrorCode{response.ErrorCodeUserNotFound},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/users/:id/activate",
+		ID:       "activateUser",
+		Summary:  "Activate an invited user",
+		Tag:      "users",
+		Headers:  []Parameter{actorHeader},
+		Status:   http.StatusOK,
+		Response: handler.UserStatusResponse{},
+		Errors: []response.ErrorCode{
+			response.ErrorCodeUserNotFound,
+			response.ErrorCodeInvalidUserStatusTransition,
+		},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/users/:id/suspend",
+		ID:       "suspendUser",
+		Summary:  "Suspend an active user, who can join no group until reactivated",
+		Tag:      "users",
+		Headers:  []Parameter{actorHeader},
+		Status:   http.StatusOK,
+		Response: handler.UserStatusResponse{},
+		Errors: []response.ErrorCode{
+			response.ErrorCodeUserNotFound,
+			response.ErrorCodeInvalidUserStatusTransition,
+		},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/users/:id/reactivate",
+		ID:       "reactivateUser",
+		Summary:  "Reactivate a suspended or deactivated user",
+		Tag:      "users",
+		Headers:  []Parameter{actorHeader},
+		Status:   http.StatusOK,
+		Response: handler.UserStatusResponse{},
+		Errors: []response.ErrorCode{
+			response.ErrorCodeUserNotFound,
+			response.ErrorCodeInvalidUserStatusTransition,
+		},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/users/:id/deactivate",
+		ID:       "deactivateUser",
+		Summary:  "Deactivate a user, who leaves all the groups but is kept",
+		Tag:      "users",
+		Headers:  []Parameter{actorHeader},
+		Status:   http.StatusOK,
+		Response: handler.UserStatusResponse{},
+		Errors: []response.ErrorCode{
+			response.ErrorCodeUserNotFound,
+			response.ErrorCodeInvalidUserStatusTransition,
+		},
+	},
+	{
+		Method:  http.MethodPost,
+		Path:    "/users/import",
+		ID:      "importUsers",
+		Summary: "Import users from CSV with a header of name and email, or NDJSON of name and email",
+		Tag:     "users",
+		Query: []Parameter{
+			{Name: "mode", Description: "create, which fails the row of an existing email, or upsert, which updates it."},
+			{Name: "dryRun", Description: "Reports the results without importing the users."},
+			{Name: "batchSize", Description: "The number of the rows committed in a transaction."},
+		},
+		Headers: []Parameter{actorHeader},
+		Requests: map[string]any{
+			userimport.ContentTypes[userimport.FormatCSV]:    "",
+			userimport.ContentTypes[userimport.FormatNDJSON]: "",
+		},
+		Status:   http.StatusOK,
+		Response: handler.ImportUsersResponse{},
+		Errors: []response.ErrorCode{
+			response.ErrorCodeInvalidArguments,
+			response.ErrorCodeUnsupportedMediaType,
+		},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/users/export",
+		ID:      "exportUsers",
+		Summary: "Export all the users as a stream",
+		Tag:     "users",
+		Query:   []Parameter{exportFormatQuery},
+		Headers: exportHeaders,
+		Status:  http.StatusOK,
+		Responses: map[string]any{
+			export.ContentTypes[export.FormatCSV]:    "",
+			export.ContentTypes[export.FormatNDJSON]: response.User{},
+			export.ContentTypes[export.FormatJSON]:   []response.User{},
+		},
+		Errors: []response.ErrorCode{response.ErrorCodeInvalidArguments},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/groups",
+		ID:       "createGroup",
+		Summary:  "Create a group",
+		Tag:      "groups",
+		Headers:  []Parameter{actorHeader},
+		Request:  handler.CreateGroupRequest{},
+		Status:   http.StatusCreated,
+		Response: handler.CreateGroupResponse{},
+		Errors:   []response.ErrorCode{response.ErrorCodeInvalidArguments, response.ErrorCodeUserInactive},
+	},
+	{
+		Method:      http.MethodGet,
+		Path:        "/groups/:id",
+		ID:          "getGroup",
+		Summary:     "Get a group",
+		Tag:         "groups",
+		Status:      http.StatusOK,
+		Response:    handler.GetGroupResponse{},
+		Errors:      []response.ErrorCode{response.ErrorCodeGroupNotFound},
+		Conditional: true,
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/groups",
+		ID:      "getGroups",
+		Summary: "List groups, or search them by name",
+		Tag:     "groups",
+		Query: []Parameter{
+			{
+				Name: "q",
+				Description: "Searches the groups whose name contains it, case-insensitively, ordered by " +
+					"relevance: an exact match, a prefix match and then a substring match.",
+			},
+			{Name: "allUserIds", Description: "Comma-separated user ids. Lists only the groups all of the users belong to."},
+			{Name: "noUsers", Description: "Lists only the groups with no users when true."},
+			{Name: "full", Description: "Lists only the groups at capacity, which no more user can be added to, when true."},
+			{Name: "attributes", Description: "Comma-separated key:value pairs. Lists only the groups with all the attribute values."},
+			{
+				Name: "selector",
+				Description: "Comma-separated label requirements, all of which the labels of the groups must meet: " +
+					"key=value, key!=value, key in (v1,v2), key notin (v1,v2), key for existence and !key for " +
+					"non-existence.",
+			},
+			sortQuery,
+		},
+		Status:   http.StatusOK,
+		Response: handler.GetGroupsResponse{},
+		Errors:   []response.ErrorCode{response.ErrorCodeInvalidArguments},
+	},
+	{
+		Method:  http.MethodPut,
+		Path:    "/groups/:id",
+		ID:      "updateGroup",
+		Summary: "Update a group",
+		Tag:     "groups",
+		Headers: []Parameter{actorHeader},
+		Request: handler.UpdateGroupRequest{},
+		Status:  http.StatusNoContent,
+		Errors:  []response.ErrorCode{response.ErrorCodeInvalidArguments, response.ErrorCodeGroupNotFound},
+	},
+	{
+		Method:  http.MethodPatch,
+		Path:    "/groups/:id",
+		ID:      "patchGroup",
+		Summary: "Patch a group with a JSON Merge Patch or a JSON Patch, which may add and remove its users",
+		Tag:     "groups",
+		Headers: []Parameter{actorHeader},
+		Requests: map[string]any{
+			string(dto.PatchTypeMergePatch): handler.PatchGroupRequest{},
+			string(dto.PatchTypeJSONPatch):  []handler.JSONPatchOperation{},
+		},
+		Status: http.StatusNoContent,
+		Errors: []response.ErrorCode{
+			response.ErrorCodeInvalidArguments,
+			response.ErrorCodeGroupNotFound,
+			response.ErrorCodeUnsupportedMediaType,
+			response.ErrorCodeUserInactive,
+		},
+	},
+	{
+		Method:  http.MethodDelete,
+		Path:    "/groups/:id",
+		ID:      "deleteGroup",
+		Summary: "Delete a group",
+		Tag:     "groups",
+		Headers: []Parameter{actorHeader},
+		Status:  http.StatusNoContent,
+		Errors:  []response.ErrorCode{response.ErrorCodeGroupNotFound},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/groups/:id/labels",
+		ID:       "putGroupLabels",
+		Summary:  "Replace the labels of a group",
+		Tag:      "groups",
+		Headers:  []Parameter{actorHeader},
+		Request:  handler.PutGroupLabelsRequest{},
+		Status:   http.StatusOK,
+		Response: handler.PutGroupLabelsResponse{},
+		Errors:   []response.ErrorCode{response.ErrorCodeInvalidArguments, response.ErrorCodeGroupNotFound},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/groups/:id/waitlist",
+		ID:       "getGroupWaitlist",
+		Summary:  "Get the users waiting in order for a place in a group at capacity, promoted as members leave",
+		Tag:      "groups",
+		Status:   http.StatusOK,
+		Response: handler.GetGroupWaitlistResponse{},
+		Errors:   []response.ErrorCode{response.ErrorCodeGroupNotFound},
+	},
+	{
+		Method:  http.MethodPut,
+		Path:    "/groups/:id/waitlist",
+		ID:      "reorderGroupWaitlist",
+		Summary: "Reorder the waitlist of a group, which must have every waitlisted user once",
+		Tag:     "groups",
+		Request: handler.ReorderGroupWaitlistRequest{},
+		Status:  http.StatusNoContent,
+		Errors:  []response.ErrorCode{response.ErrorCodeInvalidArguments, response.ErrorCodeGroupNotFound},
+	},
+	{
+		Method:  http.MethodDelete,
+		Path:    "/groups/:id/waitlist/:userId",
+		ID:      "leaveGroupWaitlist",
+		Summary: "Take a user off the waitlist of a group",
+		Tag:     "groups",
+		Status:  http.StatusNoContent,
+		Errors:  []response.ErrorCode{response.ErrorCodeGroupNotFound},
+	},
+	{
+		Method:  http.MethodPost,
+		Path:    "/groups/:id/subgroups",
+		ID:      "addSubgroup",
+		Summary: "Nest a group in a group, whose members include the members of the subgroup, unless it makes a cycle",
+		Tag:     "groups",
+		Request: handler.AddSubgroupRequest{},
+		Status:  http.StatusNoContent,
+		Errors: []response.ErrorCode{
+			response.ErrorCodeInvalidArguments,
+			response.ErrorCodeGroupNotFound,
+			response.ErrorCodeGroupCycle,
+		},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/groups/:id/subgroups",
+		ID:       "getSubgroups",
+		Summary:  "Get the groups nested in a group",
+		Tag:      "groups",
+		Query:    []Parameter{{Name: "transitive", Description: "Gets the groups nested in them as well when true."}},
+		Status:   http.StatusOK,
+		Response: handler.GetSubgroupsResponse{},
+		Errors:   []response.ErrorCode{response.ErrorCodeInvalidArguments, response.ErrorCodeGroupNotFound},
+	},
+	{
+		Method:  http.MethodDelete,
+		Path:    "/groups/:id/subgroups/:subgroupId",
+		ID:      "removeSubgroup",
+		Summary: "Take a subgroup nested directly in a group out of it",
+		Tag:     "groups",
+		Status:  http.StatusNoContent,
+		Errors:  []response.ErrorCode{response.ErrorCodeSubgroupNotFound},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/groups/:id/members",
+		ID:      "getGroupMembers",
+		Summary: "Get the users who belong to a group",
+		Tag:     "groups",
+		Query: []Parameter{
+			{Name: "transitive", Description: "Gets the members of the groups nested in it as well when true."},
+		},
+		Status:   http.StatusOK,
+		Response: handler.GetGroupMembersResponse{},
+		Errors:   []response.ErrorCode{response.ErrorCodeInvalidArguments, response.ErrorCodeGroupNotFound},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/users/:id/groups",
+		ID:      "getUserGroups",
+		Summary: "Get the groups which a user belongs to",
+		Tag:     "users",
+		Query: []Parameter{
+			{Name: "transitive", Description: "Gets the groups nesting the groups of the user as well when true."},
+		},
+		Status:   http.StatusOK,
+		Response: handler.GetUserGroupsResponse{},
+		Errors:   []response.ErrorCode{response.ErrorCodeInvalidArguments, response.ErrorCodeUserNotFound},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/groups/export",
+		ID:      "exportGroups",
+		Summary: "Export all the groups as a stream, each with its users or flattened into the memberships",
+		Tag:     "groups",
+		Query: []Parameter{
+			exportFormatQuery,
+			{
+				Name: "membership",
+				Description: "nested, which exports the groups with their users, or flat, " +
+					"which exports an object of groupId and userId for each user of each group instead.",
+			},
+		},
+		Headers: exportHeaders,
+		Status:  http.StatusOK,
+		Responses: map[string]any{
+			export.ContentTypes[export.FormatCSV]:    "",
+			export.ContentTypes[export.FormatNDJSON]: response.Group{},
+			export.ContentTypes[export.FormatJSON]:   []response.Group{},
+		},
+		Errors: []response.ErrorCode{response.ErrorCodeInvalidArguments},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/groups/:id/invitations",
+		ID:       "inviteToGroup",
+		Summary:  "Invite a user or an email to a group, which the invitee accepts or declines until it expires",
+		Tag:      "invitations",
+		Headers:  []Parameter{actorHeader},
+		Request:  handler.InviteToGroupRequest{},
+		Status:   http.StatusCreated,
+		Response: handler.InviteToGroupResponse{},
+		Errors:   []response.ErrorCode{response.ErrorCodeInvalidArguments, response.ErrorCodeGroupNotFound},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/groups/:id/invitations",
+		ID:       "getGroupInvitations",
+		Summary:  "Get the pending invitations to a group",
+		Tag:      "invitations",
+		Status:   http.StatusOK,
+		Response: handler.GetGroupInvitationsResponse{},
+		Errors:   []response.ErrorCode{response.ErrorCodeGroupNotFound},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/users/:id/invitations",
+		ID:       "getUserInvitations",
+		Summary:  "Get the pending invitations for a user or the email of the user",
+		Tag:      "invitations",
+		Status:   http.StatusOK,
+		Response: handler.GetUserInvitationsResponse{},
+		Errors:   []response.ErrorCode{response.ErrorCodeUserNotFound},
+	},
+	{
+		Method: http.MethodPost,
+		Path:   "/invitations/:id/accept",
+		ID:     "acceptGroupInvitation",
+		Summary: "Accept an invitation by the invitee, who joins the group as added to it, " +
+			"or is waitlisted if the group is at capacity",
+		Tag:      "invitations",
+		Headers:  []Parameter{actorHeader},
+		Request:  handler.AcceptGroupInvitationRequest{},
+		Status:   http.StatusOK,
+		Response: handler.AcceptGroupInvitationResponse{},
+		Errors: []response.ErrorCode{
+			response.ErrorCodeInvalidArguments,
+			response.ErrorCodeGroupInvitationNotFound,
+			response.ErrorCodeUserNotFound,
+			response.ErrorCodeGroupNotFound,
+			response.ErrorCodeGroupInvitationNotPending,
+			response.ErrorCodeUserInactive,
+		},
+	},
+	{
+		Method:  http.MethodPost,
+		Path:    "/invitations/:id/decline",
+		ID:      "declineGroupInvitation",
+		Summary: "Decline an invitation",
+		Tag:     "invitations",
+		Status:  http.StatusNoContent,
+		Errors: []response.ErrorCode{
+			response.ErrorCodeGroupInvitationNotFound,
+			response.ErrorCodeGroupInvitationNotPending,
+		},
+	},
+	{
+		Method:  http.MethodPost,
+		Path:    "/invitations/:id/revoke",
+		ID:      "revokeGroupInvitation",
+		Summary: "Revoke an invitation before the invitee responds to it",
+		Tag:     "invitations",
+		Status:  http.StatusNoContent,
+		Errors: []response.ErrorCode{
+			response.ErrorCodeGroupInvitationNotFound,
+			response.ErrorCodeGroupInvitationNotPending,
+		},
+	},
+	{
+		Method: http.MethodPost,
+		Path:   "/groups/:id/join",
+		ID:     "joinGroup",
+		Summary: "Join a group, which adds the user to an open group or waitlists the user if it is at capacity, " +
+			"and requests the user to join an approval-only group",
+		Tag:      "join-requests",
+		Headers:  []Parameter{actorHeader},
+		Request:  handler.JoinGroupRequest{},
+		Status:   http.StatusOK,
+		Response: handler.JoinGroupResponse{},
+		Errors: []response.ErrorCode{
+			response.ErrorCodeInvalidArguments,
+			response.ErrorCodeGroupJoinNotAllowed,
+			response.ErrorCodeUserNotFound,
+			response.ErrorCodeGroupNotFound,
+			response.ErrorCodeUserInactive,
+		},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/groups/:id/join-requests",
+		ID:       "getGroupJoinRequests",
+		Summary:  "Get the pending requests to join a group",
+		Tag:      "join-requests",
+		Status:   http.StatusOK,
+		Response: handler.GetGroupJoinRequestsResponse{},
+		Errors:   []response.ErrorCode{response.ErrorCodeGroupNotFound},
+	},
+	{
+		Method: http.MethodPost,
+		Path:   "/groups/:id/join-requests/:requestId/approve",
+		ID:     "approveGroupJoinRequest",
+		Summary: "Approve a request to join a group, which adds the user to it or waitlists the user if it is at " +
+			"capacity. The actor must be a member of the group or an admin. " +
+			"A request to an invite-only group cannot be approved.",
+		Tag:      "join-requests",
+		Headers:  []Parameter{actorHeader},
+		Status:   http.StatusOK,
+		Response: handler.ApproveGroupJoinRequestResponse{},
+		Errors: []response.ErrorCode{
+			response.ErrorCodeInvalidArguments,
+			response.ErrorCodeGroupJoinNotAllowed,
+			response.ErrorCodeGroupJoinRequestForbidden,
+			response.ErrorCodeGroupJoinRequestNotFound,
+			response.ErrorCodeUserNotFound,
+			response.ErrorCodeGroupNotFound,
+			response.ErrorCodeGroupJoinRequestNotPending,
+			response.ErrorCodeUserInactive,
+		},
+	},
+	{
+		Method:  http.MethodPost,
+		Path:    "/groups/:id/join-requests/:requestId/reject",
+		ID:      "rejectGroupJoinRequest",
+		Summary: "Reject a request to join a group. The actor must be a member of the group or an admin.",
+		Tag:     "join-requests",
+		Headers: []Parameter{actorHeader},
+		Status:  http.StatusNoContent,
+		Errors: []response.ErrorCode{
+			response.ErrorCodeGroupJoinRequestForbidden,
+			response.ErrorCodeGroupJoinRequestNotFound,
+			response.ErrorCodeGroupNotFound,
+			response.ErrorCodeGroupJoinRequestNotPending,
+		},
+	},
+	{
+		Method: http.MethodPost,
+		Path:   "/batch",
+		ID:     "runBatch",
+		Summary: "Run operations of users and groups in order in a transaction, which is rolled back if any fails. " +
+			`A string "$ref:<id>.<field>" in the args refers to the result of an earlier operation`,
+		Tag:      "batch",
+		Headers:  []Parameter{actorHeader},
+		Request:  handler.BatchRequest{},
+		Status:   http.StatusOK,
+		Response: handler.BatchResponse{},
+		Errors: []response.ErrorCode{
+			response.ErrorCodeInvalidArguments,
+			response.ErrorCodeUserNotFound,
+			response.ErrorCodeGroupNotFound,
+			response.ErrorCodeUserInactive,
+		},
+	},
+	{
+		Method:  http.MethodPost,
+		Path:    "/graphql",
+		ID:      "queryGraphQL",
+		Summary: "Execute a GraphQL query or mutation",
+		Tag:     "graphql",
+		Headers: []Parameter{actorHeader},
+		Request: handler.GraphQLRequest{},
+		Status:  http.StatusOK,
+		// A GraphQL result, which is also the body of a 400 for a query exceeding the limits.
+		Response: map[string]any{},
+		Errors:   []response.ErrorCode{response.ErrorCodeInvalidArguments},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/audit-events",
+		ID:      "getAuditEvents",
+		Summary: "List audit events",
+		Tag:     "audit",
+		Query: []Parameter{
+			{Name: "actor", Description: "Who performed the change."},
+			{Name: "targetType", Description: "USER or GROUP."},
+			{Name: "targetId"},
+			{Name: "from", Description: "Inclusive lower bound of the time of the change.", Format: "date-time"},
+			{Name: "to", Description: "Exclusive upper bound of the time of the change.", Format: "date-time"},
+		},
+		Status:   http.StatusOK,
+		Response: handler.GetAuditEventsResponse{},
+		Errors:   []response.ErrorCode{response.ErrorCodeInvalidArguments},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/events/stream",
+		ID:      "streamEvents",
+		Summary: "Stream change events as server-sent events, the data of each being an Event",
+		Tag:     "events",
+		Query: []Parameter{
+			{Name: "resourceType", Description: "USER or GROUP."},
+			{Name: "resourceId"},
+		},
+		Headers: []Parameter{
+			{
+				Name: handler.HeaderLastEventID,
+				Description: "Replays the events after the event, unless it is older than the outbox retention. " +
+					"Events are delivered at least once, so a client should skip the ids it has seen.",
+			},
+		},
+		Status:      http.StatusOK,
+		Response:    response.Event{},
+		ContentType: "text/event-stream",
+		Errors:      []response.ErrorCode{response.ErrorCodeInvalidArguments},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/attribute-schemas",
+		ID:      "getAttributeSchemas",
+		Summary: "List the schemas of the custom attributes",
+		Tag:     "attribute-schemas",
+		Query: []Parameter{
+			{Name: "target", Description: "Lists only the schemas of it: USER or GROUP."},
+		},
+		Status:   http.StatusOK,
+		Response: handler.GetAttributeSchemasResponse{},
+		Errors:   []response.ErrorCode{response.ErrorCodeInvalidArguments},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/attribute-schemas/:target/:key",
+		ID:       "putAttributeSchema",
+		Summary:  "Create or replace the schema of a custom attribute of USER or GROUP",
+		Tag:      "attribute-schemas",
+		Request:  handler.PutAttributeSchemaRequest{},
+		Status:   http.StatusOK,
+		Response: handler.PutAttributeSchemaResponse{},
+		Errors:   []response.ErrorCode{response.ErrorCodeInvalidArguments},
+	},
+	{
+		Method:  http.MethodDelete,
+		Path:    "/attribute-schemas/:target/:key",
+		ID:      "deleteAttributeSchema",
+		Summary: "Delete the schema of a custom attribute",
+		Tag:     "attribute-schemas",
+		Status:  http.StatusNoContent,
+		Errors:  []response.ErrorCode{response.ErrorCodeAttributeSchemaNotFound},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/webhooks",
+		ID:       "createWebhookSubscription",
+		Summary:  "Create a webhook subscription",
+		Tag:      "webhooks",
+		Request:  handler.CreateWebhookSubscriptionRequest{},
+		Status:   http.StatusCreated,
+		Response: handler.CreateWebhookSubscriptionResponse{},
+		Errors:   []response.ErrorCode{response.ErrorCodeInvalidArguments},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/webhooks/:id",
+		ID:       "getWebhookSubscription",
+		Summary:  "Get a webhook subscription",
+		Tag:      "webhooks",
+		Status:   http.StatusOK,
+		Response: handler.GetWebhookSubscriptionResponse{},
+		Errors:   []response.ErrorCode{response.ErrorCodeWebhookSubscriptionNotFound},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/webhooks",
+		ID:       "getWebhookSubscriptions",
+		Summary:  "List webhook subscriptions",
+		Tag:      "webhooks",
+		Status:   http.StatusOK,
+		Response: handler.GetWebhookSubscriptionsResponse{},
+	},
+	{
+		Method:  http.MethodPut,
+		Path:    "/webhooks/:id",
+		ID:      "updateWebhookSubscription",
+		Summary: "Update a webhook subscription",
+		Tag:     "webhooks",
+		Request: handler.UpdateWebhookSubscriptionRequest{},
+		Status:  http.StatusNoContent,
+		Errors: []response.ErrorCode{
+			response.ErrorCodeInvalidArguments,
+			response.ErrorCodeWebhookSubscriptionNotFound,
+		},
+	},
+	{
+		Method:  http.MethodDelete,
+		Path:    "/webhooks/:id",
+		ID:      "deleteWebhookSubscription",
+		Summary: "Delete a webhook subscription",
+		Tag:     "webhooks",
+		Status:  http.StatusNoContent,
+		Errors:  []response.ErrorCode{response.ErrorCodeWebhookSubscriptionNotFound},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    "/webhooks/:id/deliveries",
+		ID:      "getWebhookDeliveries",
+		Summary: "List the deliveries of a webhook subscription",
+		Tag:     "webhooks",
+		Query: []Parameter{
+			{Name: "status", Description: "PENDING, SUCCEEDED or DEAD."},
+		},
+		Status:   http.StatusOK,
+		Response: handler.GetWebhookDeliveriesResponse{},
+		Errors: []response.ErrorCode{
+			response.ErrorCodeInvalidArguments,
+			response.ErrorCodeWebhookSubscriptionNotFound,
+		},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/webhooks/:id/deliveries/:deliveryId/redeliver",
+		ID:       "redeliverWebhook",
+		Summary:  "Redeliver a webhook delivery",
+		Tag:      "webhooks",
+		Status:   http.StatusOK,
+		Response: handler.RedeliverWebhookResponse{},
+		Errors:   []response.ErrorCode{response.ErrorCodeWebhookDeliveryNotFound},
+	},
+}
@@ -0,0 +1,214 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/handler"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// Schema is the JSON Schema of OpenAPI 3.1.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 any                `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	MaxLength            int                `json:"maxLength,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+}
+
+// maxLengths are the limits of the string fields of the request types, which the domain model enforces.
+var maxLengths = map[reflect.Type]map[string]int{
+	reflect.TypeOf(handler.CreateUserRequest{}): {
+		"name":  model.MaxUserNameLength,
+		"email": model.MaxUserEmailLength,
+	},
+	reflect.TypeOf(handler.UpdateUserRequest{}): {
+		"name":  model.MaxUserNameLength,
+		"email": model.MaxUserEmailLength,
+	},
+	reflect.TypeOf(handler.PatchUserRequest{}): {
+		"name":  model.MaxUserNameLength,
+		"email": model.MaxUserEmailLength,
+	},
+	reflect.TypeOf(handler.CreateGroupRequest{}): {
+		"name": model.MaxGroupNameLength,
+	},
+	reflect.TypeOf(handler.UpdateGroupRequest{}): {
+		"name": model.MaxGroupNameLength,
+	},
+	reflect.TypeOf(handler.PatchGroupRequest{}): {
+		"name": model.MaxGroupNameLength,
+	},
+}
+
+// enums are the values which the string fields of the request types can be.
+var enums = map[reflect.Type]map[string][]any{
+	reflect.TypeOf(handler.JSONPatchOperation{}): {
+		"op": {"add", "remove", "replace", "move", "copy", "test"},
+	},
+	reflect.TypeOf(handler.BatchOperationRequest{}): {
+		"op": batchOperationTypes(),
+	},
+	reflect.TypeOf(handler.PutAttributeSchemaRequest{}): {
+		"type": {
+			string(model.AttributeTypeString),
+			string(model.AttributeTypeNumber),
+			string(model.AttributeTypeBoolean),
+		},
+	},
+}
+
+func batchOperationTypes() []any {
+	types := make([]any, len(dto.BatchOperationTypes))
+	for i, t := range dto.BatchOperationTypes {
+		types[i] = string(t)
+	}
+	return types
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaGenerator generates the schemas of Go types from their JSON encoding.
+// Named struct types become components and are referred to.
+type schemaGenerator struct {
+	components map[string]*Schema
+	types      map[string]reflect.Type
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		components: map[string]*Schema{},
+		types:      map[string]reflect.Type{},
+	}
+}
+
+func (g *schemaGenerator) generate(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		// Any JSON value.
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := g.generate(t.Elem())
+		if s.Ref != "" || s.Type == nil {
+			return s
+		}
+		return &Schema{Type: []any{s.Type, "null"}, Format: s.Format, Items: s.Items}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.generate(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object"}
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.Struct:
+		return g.component(t)
+	}
+	panic(fmt.Sprintf("openapi: unsupported type %s", t))
+}
+
+func (g *schemaGenerator) component(t reflect.Type) *Schema {
+	name := t.Name()
+	ref := &Schema{Ref: "#/components/schemas/" + name}
+	if known, ok := g.types[name]; ok {
+		if known != t {
+			panic(fmt.Sprintf("openapi: both %s and %s are named %s", known, t, name))
+		}
+		return ref
+	}
+	g.types[name] = t
+
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	// Registered before the fields so that a recursive type refers to itself.
+	g.components[name] = s
+	g.properties(s, t)
+	return ref
+}
+
+// properties adds the fields of the struct to the properties of the schema, including the ones of the embedded
+// structs as encoding/json does.
+func (g *schemaGenerator) properties(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("json") == "" {
+			g.properties(s, f.Type)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name, omitempty := jsonName(f)
+		if name == "-" {
+			continue
+		}
+		s.Properties[name] = g.generate(f.Type)
+		if l, ok := maxLengths[t][name]; ok {
+			s.Properties[name].MaxLength = l
+		}
+		if e, ok := enums[t][name]; ok {
+			s.Properties[name].Enum = e
+		}
+		if !omitempty {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// jsonName returns the name of the field in JSON and whether it is omitted when empty.
+func jsonName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "" {
+		return f.Name, false
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = f.Name
+	}
+	return name, strings.Contains(","+opts+",", ",omitempty,")
+}
+
+// strict forbids the properties not declared in the object schemas the schema consists of.
+func (g *schemaGenerator) strict(s *Schema) {
+	if s.Ref != "" {
+		c := g.components[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+		if c.AdditionalProperties != nil {
+			return
+		}
+		s = c
+	}
+	if s.Properties != nil {
+		forbidden := false
+		s.AdditionalProperties = &forbidden
+		for _, p := range s.Properties {
+			g.strict(p)
+		}
+	}
+	if s.Items != nil {
+		g.strict(s.Items)
+	}
+}
@@ -0,0 +1,217 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+)
+
+var errEmptyBody = errors.New("request body must not empty")
+
+// Validator validates the JSON request bodies against the schemas in the document,
+// so that a malformed request does not reach the handlers.
+type Validator struct {
+	// schemas are the schemas of the request bodies by content type by operation.
+	schemas    map[string]map[string]*Schema
+	components map[string]*Schema
+}
+
+// NewValidator returns a validator of the request bodies of the operations.
+func NewValidator(ops []Operation) *Validator {
+	doc := Generate(ops)
+	v := &Validator{
+		schemas:    map[string]map[string]*Schema{},
+		components: doc.Components.Schemas,
+	}
+	for _, op := range ops {
+		body := doc.Paths[ToPath(op.Path)][strings.ToLower(op.Method)].RequestBody
+		if body == nil {
+			continue
+		}
+		schemas := map[string]*Schema{}
+		for ct, mt := range body.Content {
+			// Only JSON bodies are validated.
+			if ct == contentTypeJSON || strings.HasSuffix(ct, "+json") {
+				schemas[ct] = mt.Schema
+			}
+		}
+		v.schemas[op.Method+" "+op.Path] = schemas
+	}
+	return v
+}
+
+// Middleware validates the body of a request routed to an operation with a request body.
+// An invalid body is responded with INVALID_ARGUMENTS.
+func (v *Validator) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		schemas, ok := v.schemas[req.Method+" "+c.Path()]
+		if !ok {
+			return next(c)
+		}
+		// A body of an unknown content type is validated as JSON if the operation accepts JSON,
+		// otherwise left to the handler which rejects it.
+		mt, _, _ := mime.ParseMediaType(req.Header.Get(echo.HeaderContentType))
+		s, ok := schemas[mt]
+		if !ok {
+			s, ok = schemas[contentTypeJSON]
+		}
+		if !ok {
+			return next(c)
+		}
+
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return response.ErrorInternal(c, err)
+		}
+		// The handler binds the body again.
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		if err := v.validateBody(s, body); err != nil {
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		}
+		return next(c)
+	}
+}
+
+func (v *Validator) validateBody(s *Schema, body []byte) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return errEmptyBody
+	}
+
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	var value any
+	if err := d.Decode(&value); err != nil {
+		return fmt.Errorf("request body must be JSON: %w", err)
+	}
+	if d.More() {
+		return errors.New("request body must be a single JSON value")
+	}
+
+	var errs []string
+	v.validate(s, value, "", &errs)
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// validate validates the value at the JSON pointer against the schema.
+func (v *Validator) validate(s *Schema, value any, pointer string, errs *[]string) {
+	if s.Ref != "" {
+		s = v.components[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	}
+
+	at := pointer
+	if at == "" {
+		at = "/"
+	}
+	if types := schemaTypes(s); len(types) > 0 && !hasType(types, value) {
+		*errs = append(*errs, fmt.Sprintf("%s must be %s", at, strings.Join(types, " or ")))
+		return
+	}
+
+	switch value := value.(type) {
+	case string:
+		if s.MaxLength > 0 && utf8.RuneCountInString(value) > s.MaxLength {
+			*errs = append(*errs, fmt.Sprintf("%s must be at most %d characters", at, s.MaxLength))
+		}
+		if len(s.Enum) > 0 && !containsValue(s.Enum, value) {
+			*errs = append(*errs, fmt.Sprintf("%s must be one of %v", at, s.Enum))
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range value {
+				v.validate(s.Items, item, fmt.Sprintf("%s/%d", pointer, i), errs)
+			}
+		}
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := value[name]; !ok {
+				*errs = append(*errs, fmt.Sprintf("%s/%s is required", pointer, name))
+			}
+		}
+		names := make([]string, 0, len(value))
+		for name := range value {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			p, ok := s.Properties[name]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					*errs = append(*errs, fmt.Sprintf("%s/%s is unknown", pointer, name))
+				}
+				continue
+			}
+			v.validate(p, value[name], pointer+"/"+name, errs)
+		}
+	}
+}
+
+// schemaTypes returns the types the schema allows, none for any type.
+func schemaTypes(s *Schema) []string {
+	switch t := s.Type.(type) {
+	case string:
+		return []string{t}
+	case []any:
+		types := make([]string, len(t))
+		for i, v := range t {
+			types[i] = fmt.Sprint(v)
+		}
+		return types
+	}
+	return nil
+}
+
+func hasType(types []string, value any) bool {
+	for _, t := range types {
+		if isType(t, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func isType(t string, value any) bool {
+	switch value := value.(type) {
+	case nil:
+		return t == "null"
+	case bool:
+		return t == "boolean"
+	case string:
+		return t == "string"
+	case json.Number:
+		if t == "number" {
+			return true
+		}
+		_, err := value.Int64()
+		return t == "integer" && err == nil
+	case []any:
+		return t == "array"
+	case map[string]any:
+		return t == "object"
+	}
+	return false
+}
+
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
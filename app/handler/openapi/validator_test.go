@@ -0,0 +1,200 @@
+package openapi_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/openapi"
+)
+
+func TestValidator_Middleware(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		body        string
+		wantStatus  int
+		wantBody    string
+	}{
+		{
+			name:       "Passes a valid body",
+			method:     http.MethodPost,
+			path:       "/users",
+			body:       `{"name":"TEST_USER_NAME","email":"test@example.com"}`,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "Passes an operation without a request body",
+			method:     http.MethodGet,
+			path:       "/users/TEST_USER_ID",
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "Passes a body without the optional field",
+			method:     http.MethodPost,
+			path:       "/groups",
+			body:       `{"name":"TEST_GROUP_NAME"}`,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "Empty body",
+			method:     http.MethodPost,
+			path:       "/users",
+			wantStatus: http.StatusBadRequest,
+			wantBody: `{"code":"INVALID_ARGUMENTS","status":400,` +
+				`"message":"request body must not empty"}`,
+		},
+		{
+			name:       "Malformed JSON",
+			method:     http.MethodPost,
+			path:       "/users",
+			body:       `{"name":`,
+			wantStatus: http.StatusBadRequest,
+			wantBody: `{"code":"INVALID_ARGUMENTS","status":400,` +
+				`"message":"request body must be JSON: unexpected EOF"}`,
+		},
+		{
+			name:       "Not an object",
+			method:     http.MethodPost,
+			path:       "/users",
+			body:       `[]`,
+			wantStatus: http.StatusBadRequest,
+			wantBody: `{"code":"INVALID_ARGUMENTS","status":400,` +
+				`"message":"/ must be object"}`,
+		},
+		{
+			name:       "Missing required fields",
+			method:     http.MethodPost,
+			path:       "/users",
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody: `{"code":"INVALID_ARGUMENTS","status":400,` +
+				`"message":"/name is required; /email is required"}`,
+		},
+		{
+			name:       "Wrong types",
+			method:     http.MethodPut,
+			path:       "/users/TEST_USER_ID",
+			body:       `{"name":1,"email":null}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody: `{"code":"INVALID_ARGUMENTS","status":400,` +
+				`"message":"/email must be string; /name must be string"}`,
+		},
+		{
+			name:       "Wrong type of an item",
+			method:     http.MethodPost,
+			path:       "/groups",
+			body:       `{"name":"TEST_GROUP_NAME","userIds":["TEST_USER_ID",1]}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody: `{"code":"INVALID_ARGUMENTS","status":400,` +
+				`"message":"/userIds/1 must be string"}`,
+		},
+		{
+			name:       "Exceeds the max length of a user name",
+			method:     http.MethodPost,
+			path:       "/users",
+			body:       `{"name":"` + strings.Repeat("x", model.MaxUserNameLength+1) + `","email":"test@example.com"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody: `{"code":"INVALID_ARGUMENTS","status":400,` +
+				`"message":"/name must be at most 255 characters"}`,
+		},
+		{
+			name:       "Exceeds the max length of a group name",
+			method:     http.MethodPut,
+			path:       "/groups/TEST_GROUP_ID",
+			body:       `{"name":"` + strings.Repeat("x", model.MaxGroupNameLength+1) + `"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody: `{"code":"INVALID_ARGUMENTS","status":400,` +
+				`"message":"/name must be at most 255 characters"}`,
+		},
+		{
+			name:       "Unknown field",
+			method:     http.MethodPut,
+			path:       "/groups/TEST_GROUP_ID",
+			body:       `{"name":"TEST_GROUP_NAME","userIds":[]}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody: `{"code":"INVALID_ARGUMENTS","status":400,` +
+				`"message":"/userIds is unknown"}`,
+		},
+		{
+			name:        "Passes a valid JSON Patch",
+			method:      http.MethodPatch,
+			path:        "/groups/TEST_GROUP_ID",
+			contentType: "application/json-patch+json",
+			body:        `[{"op":"add","path":"/userIds/-","value":"TEST_USER_ID"}]`,
+			wantStatus:  http.StatusNoContent,
+		},
+		{
+			name:        "Passes an unsupported content type to the handler",
+			method:      http.MethodPatch,
+			path:        "/groups/TEST_GROUP_ID",
+			contentType: "text/plain",
+			body:        `name`,
+			wantStatus:  http.StatusNoContent,
+		},
+		{
+			name:        "Unknown operation of a JSON Patch",
+			method:      http.MethodPatch,
+			path:        "/groups/TEST_GROUP_ID",
+			contentType: "application/json-patch+json",
+			body:        `[{"op":"append","path":"/userIds/-","value":"TEST_USER_ID"}]`,
+			wantStatus:  http.StatusBadRequest,
+			wantBody: `{"code":"INVALID_ARGUMENTS","status":400,` +
+				`"message":"/0/op must be one of [add remove replace move copy test]"}`,
+		},
+		{
+			name:        "Exceeds the max length in a JSON Merge Patch",
+			method:      http.MethodPatch,
+			path:        "/users/TEST_USER_ID",
+			contentType: "application/merge-patch+json",
+			body:        `{"name":"` + strings.Repeat("x", model.MaxUserNameLength+1) + `","userId":"TEST_USER_ID"}`,
+			wantStatus:  http.StatusBadRequest,
+			wantBody: `{"code":"INVALID_ARGUMENTS","status":400,` +
+				`"message":"/name must be at most 255 characters; /userId is unknown"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentType := tt.contentType
+			if contentType == "" {
+				contentType = echo.MIMEApplicationJSON
+			}
+
+			e := echo.New()
+			e.Use(openapi.NewValidator(openapi.Operations).Middleware)
+			for _, op := range openapi.Operations {
+				e.Add(op.Method, op.Path, func(c echo.Context) error {
+					// The body is still readable by the handler.
+					body, err := io.ReadAll(c.Request().Body)
+					if err != nil {
+						return err
+					}
+					if string(body) != tt.body {
+						t.Errorf("the handler reads %s; want %s", body, tt.body)
+					}
+					return c.NoContent(http.StatusNoContent)
+				})
+			}
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, contentType)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status=%d; want %d", rec.Code, tt.wantStatus)
+			}
+			if got := strings.TrimSpace(rec.Body.String()); got != tt.wantBody {
+				t.Errorf("body=%s; want %s", got, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo"
+)
+
+// parseTimeParam parses an RFC 3339 query parameter. A missing parameter is the zero time.
+func parseTimeParam(c echo.Context, name string) (time.Time, error) {
+	v := c.QueryParam(name)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be RFC 3339 time: %w", name, err)
+	}
+	return t, nil
+}
+
+// parseBoolParam parses a boolean query parameter. A missing parameter is false.
+func parseBoolParam(c echo.Context, name string) (bool, error) {
+	v := c.QueryParam(name)
+	if v == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("%s must be a boolean: %w", name, err)
+	}
+	return b, nil
+}
+
+// listParam splits a comma-separated query parameter, dropping the empty elements. A missing parameter is nil.
+func listParam(c echo.Context, name string) []string {
+	var result []string
+	for _, v := range strings.Split(c.QueryParam(name), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// parseAttributesParam parses a comma-separated query parameter of key:value pairs of custom attributes.
+// A missing parameter is nil.
+func parseAttributesParam(c echo.Context, name string) (map[string]string, error) {
+	var result map[string]string
+	for _, v := range listParam(c, name) {
+		k, value, ok := strings.Cut(v, ":")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("%s must be key:value pairs: %q", name, v)
+		}
+		if result == nil {
+			result = make(map[string]string)
+		}
+		result[k] = value
+	}
+	return result, nil
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+var errUnsupportedPatchType = errors.New("unsupported patch type")
+
+// JSONPatchOperation is an operation of a JSON Patch (RFC 6902).
+type JSONPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// bindPatch reads the patch in the body of the request, of which the format is the content type.
+func bindPatch(c echo.Context) (dto.PatchType, []byte, error) {
+	ct := c.Request().Header.Get(echo.HeaderContentType)
+	mt, _, err := mime.ParseMediaType(ct)
+	pt := dto.PatchType(mt)
+	if err != nil || (pt != dto.PatchTypeMergePatch && pt != dto.PatchTypeJSONPatch) {
+		return "", nil, fmt.Errorf(
+			"content type %q must be %s or %s: %w",
+			ct, dto.PatchTypeMergePatch, dto.PatchTypeJSONPatch, errUnsupportedPatchType,
+		)
+	}
+
+	patch, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return "", nil, err
+	}
+	return pt, patch, nil
+}
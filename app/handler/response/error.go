@@ -13,8 +13,56 @@ const (
 	ErrorCodeInvalidArguments    ErrorCode = "INVALID_ARGUMENTS"
 	ErrorCodeUserNotFound        ErrorCode = "USER_NOT_FOUND"
 	ErrorCodeGroupNotFound       ErrorCode = "GROUP_NOT_FOUND"
+
+	ErrorCodeInvalidUserStatusTransition ErrorCode = "INVALID_USER_STATUS_TRANSITION"
+	ErrorCodeUserInactive                ErrorCode = "USER_INACTIVE"
+
+	ErrorCodeSubgroupNotFound ErrorCode = "SUBGROUP_NOT_FOUND"
+	ErrorCodeGroupCycle       ErrorCode = "GROUP_CYCLE"
+
+	ErrorCodeGroupInvitationNotFound   ErrorCode = "GROUP_INVITATION_NOT_FOUND"
+	ErrorCodeGroupInvitationNotPending ErrorCode = "GROUP_INVITATION_NOT_PENDING"
+
+	ErrorCodeGroupJoinNotAllowed        ErrorCode = "GROUP_JOIN_NOT_ALLOWED"
+	ErrorCodeGroupJoinRequestNotFound   ErrorCode = "GROUP_JOIN_REQUEST_NOT_FOUND"
+	ErrorCodeGroupJoinRequestNotPending ErrorCode = "GROUP_JOIN_REQUEST_NOT_PENDING"
+	ErrorCodeGroupJoinRequestForbidden  ErrorCode = "GROUP_JOIN_REQUEST_FORBIDDEN"
+
+	ErrorCodeUnsupportedMediaType ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+
+	ErrorCodeWebhookSubscriptionNotFound ErrorCode = "WEBHOOK_SUBSCRIPTION_NOT_FOUND"
+	ErrorCodeWebhookDeliveryNotFound     ErrorCode = "WEBHOOK_DELIVERY_NOT_FOUND"
+
+	ErrorCodeIdempotencyKeyMismatch   ErrorCode = "IDEMPOTENCY_KEY_MISMATCH"
+	ErrorCodeIdempotencyKeyInProgress ErrorCode = "IDEMPOTENCY_KEY_IN_PROGRESS"
+
+	ErrorCodeAttributeSchemaNotFound ErrorCode = "ATTRIBUTE_SCHEMA_NOT_FOUND"
 )
 
+// ErrorCodes are all the error codes, in the order of their declaration.
+var ErrorCodes = []ErrorCode{
+	ErrorCodeInternalServerError,
+	ErrorCodeInvalidArguments,
+	ErrorCodeUserNotFound,
+	ErrorCodeGroupNotFound,
+	ErrorCodeInvalidUserStatusTransition,
+	ErrorCodeUserInactive,
+	ErrorCodeSubgroupNotFound,
+	ErrorCodeGroupCycle,
+	ErrorCodeGroupInvitationNotFound,
+	ErrorCodeGroupInvitationNotPending,
+	ErrorCodeGroupJoinNotAllowed,
+	ErrorCodeGroupJoinRequestNotFound,
+	ErrorCodeGroupJoinRequestNotPending,
+	ErrorCodeGroupJoinRequestForbidden,
+	ErrorCodeUnsupportedMediaType,
+	ErrorCodeWebhookSubscriptionNotFound,
+	ErrorCodeWebhookDeliveryNotFound,
+	ErrorCodeIdempotencyKeyMismatch,
+	ErrorCodeIdempotencyKeyInProgress,
+	ErrorCodeAttributeSchemaNotFound,
+}
+
 type ErrorResponse struct {
 	Code    ErrorCode `json:"code"`
 	Status  int       `json:"status"`
@@ -1,27 +1,369 @@
 package response
 
-import "github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
 
 type User struct {
 	UserID string `json:"userId"`
 	Name   string `json:"name"`
 	Email  string `json:"email"`
+	Status string `json:"status"`
+	// Attributes are the values of the custom attributes of the user by their keys.
+	Attributes map[string]any `json:"attributes,omitempty"`
+	Timestamps
+}
+
+// Timestamps are when and by whom a user or a group was created and last updated.
+type Timestamps struct {
+	CreatedAt time.Time `json:"createdAt"`
+	CreatedBy string    `json:"createdBy,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	UpdatedBy string    `json:"updatedBy,omitempty"`
 }
 
 type Group struct {
+	GroupID    string `json:"groupId"`
+	Name       string `json:"name"`
+	JoinPolicy string `json:"joinPolicy"`
+	Users      []User `json:"users"`
+	// Attributes are the values of the custom attributes of the group by their keys.
+	Attributes map[string]any `json:"attributes,omitempty"`
+	// Labels are the key/value pairs which classify the group, e.g. team=payments.
+	Labels map[string]string `json:"labels,omitempty"`
+	Timestamps
+}
+
+// Highlight is a match of a search query in a field, from start inclusive to end exclusive in characters.
+type Highlight struct {
+	Field string `json:"field"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// GroupMembership is a user belonging to a group, a row of the flattened membership of the groups.
+type GroupMembership struct {
 	GroupID string `json:"groupId"`
-	Name    string `json:"name"`
-	Users   []User `json:"users"`
+	UserID  string `json:"userId"`
+}
+
+type AuditEvent struct {
+	AuditEventID string        `json:"auditEventId"`
+	Actor        string        `json:"actor"`
+	Action       string        `json:"action"`
+	TargetType   string        `json:"targetType"`
+	TargetID     string        `json:"targetId"`
+	Changes      []AuditChange `json:"changes"`
+	RequestID    string        `json:"requestId"`
+	OccurredAt   time.Time     `json:"occurredAt"`
+}
+
+type AuditChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// AttributeSchema is a custom attribute of the users or the groups. The enum is of the values in their string form.
+type AttributeSchema struct {
+	Target   string   `json:"target"`
+	Key      string   `json:"key"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Enum     []string `json:"enum,omitempty"`
+	Pattern  string   `json:"pattern,omitempty"`
+}
+
+type WebhookSubscription struct {
+	WebhookSubscriptionID string   `json:"webhookSubscriptionId"`
+	URL                   string   `json:"url"`
+	EventTypes            []string `json:"eventTypes"`
+}
+
+type WebhookDelivery struct {
+	WebhookDeliveryID     string     `json:"webhookDeliveryId"`
+	WebhookSubscriptionID string     `json:"webhookSubscriptionId"`
+	EventID               string     `json:"eventId"`
+	EventType             string     `json:"eventType"`
+	Status                string     `json:"status"`
+	Attempts              int        `json:"attempts"`
+	LastStatusCode        int        `json:"lastStatusCode"`
+	LastError             string     `json:"lastError"`
+	OccurredAt            time.Time  `json:"occurredAt"`
+	NextAttemptAt         time.Time  `json:"nextAttemptAt"`
+	DeliveredAt           *time.Time `json:"deliveredAt,omitempty"`
+}
+
+type GroupInvitation struct {
+	GroupInvitationID string     `json:"groupInvitationId"`
+	GroupID           string     `json:"groupId"`
+	Inviter           string     `json:"inviter"`
+	InviteeUserID     string     `json:"inviteeUserId,omitempty"`
+	InviteeEmail      string     `json:"inviteeEmail,omitempty"`
+	Status            string     `json:"status"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	ExpiresAt         time.Time  `json:"expiresAt"`
+	RespondedAt       *time.Time `json:"respondedAt,omitempty"`
+}
+
+type GroupJoinRequest struct {
+	GroupJoinRequestID string     `json:"groupJoinRequestId"`
+	GroupID            string     `json:"groupId"`
+	UserID             string     `json:"userId"`
+	Status             string     `json:"status"`
+	CreatedAt          time.Time  `json:"createdAt"`
+	RespondedAt        *time.Time `json:"respondedAt,omitempty"`
+	RespondedBy        string     `json:"respondedBy,omitempty"`
+}
+
+type Event struct {
+	EventID      string          `json:"eventId"`
+	EventType    string          `json:"eventType"`
+	ResourceType string          `json:"resourceType"`
+	ResourceID   string          `json:"resourceId"`
+	OccurredAt   time.Time       `json:"occurredAt"`
+	Data         json.RawMessage `json:"data"`
+}
+
+type ImportSummary struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+}
+
+type ImportUserResult struct {
+	Line   int    `json:"line"`
+	Status string `json:"status"`
+	UserID string `json:"userId,omitempty"`
+	Email  string `json:"email"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func ToUserFromDTO(dtou dto.User) User {
+	return User{
+		UserID:     dtou.UserID,
+		Name:       dtou.Name,
+		Email:      dtou.Email,
+		Status:     dtou.Status,
+		Attributes: dtou.Attributes,
+		Timestamps: ToTimestampsFromDTO(dtou.Timestamps),
+	}
+}
+
+func ToTimestampsFromDTO(dtots dto.Timestamps) Timestamps {
+	return Timestamps{
+		CreatedAt: dtots.CreatedAt,
+		CreatedBy: dtots.CreatedBy,
+		UpdatedAt: dtots.UpdatedAt,
+		UpdatedBy: dtots.UpdatedBy,
+	}
 }
 
 func ToUsersFromDTO(dtous []dto.User) []User {
 	us := make([]User, len(dtous))
 	for i, dtou := range dtous {
-		us[i] = User{
-			UserID: dtou.UserID,
-			Name:   dtou.Name,
-			Email:  dtou.Email,
-		}
+		us[i] = ToUserFromDTO(dtou)
 	}
 	return us
 }
+
+// ToHighlightsFromDTO converts the highlights by id, nil if not searched.
+func ToHighlightsFromDTO(dtohs map[string][]dto.Highlight) map[string][]Highlight {
+	if dtohs == nil {
+		return nil
+	}
+	result := make(map[string][]Highlight, len(dtohs))
+	for id, hs := range dtohs {
+		rhs := make([]Highlight, len(hs))
+		for i, h := range hs {
+			rhs[i] = Highlight{Field: h.Field, Start: h.Start, End: h.End}
+		}
+		result[id] = rhs
+	}
+	return result
+}
+
+func ToAuditEventsFromDTO(dtoes []dto.AuditEvent) []AuditEvent {
+	es := make([]AuditEvent, len(dtoes))
+	for i, dtoe := range dtoes {
+		changes := make([]AuditChange, len(dtoe.Changes))
+		for j, c := range dtoe.Changes {
+			changes[j] = AuditChange{
+				Field:  c.Field,
+				Before: c.Before,
+				After:  c.After,
+			}
+		}
+		es[i] = AuditEvent{
+			AuditEventID: dtoe.AuditEventID,
+			Actor:        dtoe.Actor,
+			Action:       dtoe.Action,
+			TargetType:   dtoe.TargetType,
+			TargetID:     dtoe.TargetID,
+			Changes:      changes,
+			RequestID:    dtoe.RequestID,
+			OccurredAt:   dtoe.OccurredAt,
+		}
+	}
+	return es
+}
+
+func ToAttributeSchemaFromDTO(dtos dto.AttributeSchema) AttributeSchema {
+	return AttributeSchema{
+		Target:   dtos.Target,
+		Key:      dtos.Key,
+		Type:     dtos.Type,
+		Required: dtos.Required,
+		Enum:     dtos.Enum,
+		Pattern:  dtos.Pattern,
+	}
+}
+
+func ToAttributeSchemasFromDTO(dtoss []dto.AttributeSchema) []AttributeSchema {
+	ss := make([]AttributeSchema, len(dtoss))
+	for i, dtos := range dtoss {
+		ss[i] = ToAttributeSchemaFromDTO(dtos)
+	}
+	return ss
+}
+
+func ToWebhookSubscriptionFromDTO(dtos dto.WebhookSubscription) WebhookSubscription {
+	return WebhookSubscription{
+		WebhookSubscriptionID: dtos.WebhookSubscriptionID,
+		URL:                   dtos.URL,
+		EventTypes:            dtos.EventTypes,
+	}
+}
+
+func ToWebhookSubscriptionsFromDTO(dtoss []dto.WebhookSubscription) []WebhookSubscription {
+	ss := make([]WebhookSubscription, len(dtoss))
+	for i, dtos := range dtoss {
+		ss[i] = ToWebhookSubscriptionFromDTO(dtos)
+	}
+	return ss
+}
+
+func ToWebhookDeliveryFromDTO(dtod dto.WebhookDelivery) WebhookDelivery {
+	var deliveredAt *time.Time
+	if !dtod.DeliveredAt.IsZero() {
+		deliveredAt = &dtod.DeliveredAt
+	}
+	return WebhookDelivery{
+		WebhookDeliveryID:     dtod.WebhookDeliveryID,
+		WebhookSubscriptionID: dtod.WebhookSubscriptionID,
+		EventID:               dtod.EventID,
+		EventType:             dtod.EventType,
+		Status:                dtod.Status,
+		Attempts:              dtod.Attempts,
+		LastStatusCode:        dtod.LastStatusCode,
+		LastError:             dtod.LastError,
+		OccurredAt:            dtod.OccurredAt,
+		NextAttemptAt:         dtod.NextAttemptAt,
+		DeliveredAt:           deliveredAt,
+	}
+}
+
+func ToWebhookDeliveriesFromDTO(dtods []dto.WebhookDelivery) []WebhookDelivery {
+	ds := make([]WebhookDelivery, len(dtods))
+	for i, dtod := range dtods {
+		ds[i] = ToWebhookDeliveryFromDTO(dtod)
+	}
+	return ds
+}
+
+func ToGroupInvitationFromDTO(dtoinv dto.GroupInvitation) GroupInvitation {
+	var respondedAt *time.Time
+	if !dtoinv.RespondedAt.IsZero() {
+		respondedAt = &dtoinv.RespondedAt
+	}
+	return GroupInvitation{
+		GroupInvitationID: dtoinv.GroupInvitationID,
+		GroupID:           dtoinv.GroupID,
+		Inviter:           dtoinv.Inviter,
+		InviteeUserID:     dtoinv.InviteeUserID,
+		InviteeEmail:      dtoinv.InviteeEmail,
+		Status:            dtoinv.Status,
+		CreatedAt:         dtoinv.CreatedAt,
+		ExpiresAt:         dtoinv.ExpiresAt,
+		RespondedAt:       respondedAt,
+	}
+}
+
+func ToGroupInvitationsFromDTO(dtoinvs []dto.GroupInvitation) []GroupInvitation {
+	invs := make([]GroupInvitation, len(dtoinvs))
+	for i, dtoinv := range dtoinvs {
+		invs[i] = ToGroupInvitationFromDTO(dtoinv)
+	}
+	return invs
+}
+
+func ToGroupJoinRequestFromDTO(dtojr dto.GroupJoinRequest) GroupJoinRequest {
+	var respondedAt *time.Time
+	if !dtojr.RespondedAt.IsZero() {
+		respondedAt = &dtojr.RespondedAt
+	}
+	return GroupJoinRequest{
+		GroupJoinRequestID: dtojr.GroupJoinRequestID,
+		GroupID:            dtojr.GroupID,
+		UserID:             dtojr.UserID,
+		Status:             dtojr.Status,
+		CreatedAt:          dtojr.CreatedAt,
+		RespondedAt:        respondedAt,
+		RespondedBy:        dtojr.RespondedBy,
+	}
+}
+
+func ToGroupJoinRequestsFromDTO(dtojrs []dto.GroupJoinRequest) []GroupJoinRequest {
+	jrs := make([]GroupJoinRequest, len(dtojrs))
+	for i, dtojr := range dtojrs {
+		jrs[i] = ToGroupJoinRequestFromDTO(dtojr)
+	}
+	return jrs
+}
+
+func ToEventFromDTO(dtoe dto.Event) Event {
+	return Event{
+		EventID:      dtoe.EventID,
+		EventType:    dtoe.EventType,
+		ResourceType: dtoe.ResourceType,
+		ResourceID:   dtoe.ResourceID,
+		OccurredAt:   dtoe.OccurredAt,
+		Data:         dtoe.Payload,
+	}
+}
+
+func ToImportUserResultsFromDTO(dtors []dto.ImportUserResult) []ImportUserResult {
+	rs := make([]ImportUserResult, len(dtors))
+	for i, dtor := range dtors {
+		rs[i] = ImportUserResult{
+			Line:   dtor.Line,
+			Status: string(dtor.Status),
+			UserID: dtor.UserID,
+			Email:  dtor.Email,
+			Reason: dtor.Reason,
+		}
+	}
+	return rs
+}
+
+// ToImportSummaryFromDTO counts the results by status.
+func ToImportSummaryFromDTO(dtors []dto.ImportUserResult) ImportSummary {
+	var s ImportSummary
+	for _, dtor := range dtors {
+		switch dtor.Status {
+		case dto.ImportStatusCreated:
+			s.Created++
+		case dto.ImportStatusUpdated:
+			s.Updated++
+		case dto.ImportStatusSkipped:
+			s.Skipped++
+		case dto.ImportStatusFailed:
+			s.Failed++
+		}
+	}
+	return s
+}
@@ -17,3 +17,7 @@ func Created(c echo.Context, body any) error {
 func NoContent(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
+
+func NotModified(c echo.Context) error {
+	return c.NoContent(http.StatusNotModified)
+}
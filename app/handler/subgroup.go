@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+type AddSubgroupRequest struct {
+	GroupID string `json:"groupId"`
+}
+
+// AddSubgroup nests the group of the request in the group, unless it would make a cycle.
+func (h *GroupHandler) AddSubgroup(c echo.Context) error {
+	req := &AddSubgroupRequest{}
+	if err := c.Bind(req); err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	in := &dto.AddSubgroupInput{
+		GroupID:    c.Param("id"),
+		SubgroupID: req.GroupID,
+	}
+
+	if _, err := h.uc.AddSubgroup(in); err != nil {
+		if errors.Is(err, usecase.ErrGroupNotFound) {
+			return response.Error(c, response.ErrorCodeGroupNotFound, http.StatusNotFound, err)
+		}
+		if errors.Is(err, usecase.ErrGroupCycle) {
+			return response.Error(c, response.ErrorCodeGroupCycle, http.StatusConflict, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.NoContent(c)
+}
+
+// RemoveSubgroup takes the subgroup, which must be nested in the group directly, out of the group.
+func (h *GroupHandler) RemoveSubgroup(c echo.Context) error {
+	in := &dto.RemoveSubgroupInput{
+		GroupID:    c.Param("id"),
+		SubgroupID: c.Param("subgroupId"),
+	}
+
+	if _, err := h.uc.RemoveSubgroup(in); err != nil {
+		if errors.Is(err, usecase.ErrSubgroupNotFound) {
+			return response.Error(c, response.ErrorCodeSubgroupNotFound, http.StatusNotFound, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.NoContent(c)
+}
+
+type GetSubgroupsResponse struct {
+	// GroupIDs are the subgroups sorted by id.
+	GroupIDs []string `json:"groupIds"`
+}
+
+// GetSubgroups returns the groups nested in the group, and the ones nested in them as well with transitive.
+func (h *GroupHandler) GetSubgroups(c echo.Context) error {
+	transitive, err := parseBoolParam(c, "transitive")
+	if err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	out, err := h.uc.GetSubgroups(&dto.GetSubgroupsInput{
+		GroupID:    c.Param("id"),
+		Transitive: transitive,
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrGroupNotFound) {
+			return response.Error(c, response.ErrorCodeGroupNotFound, http.StatusNotFound, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.OK(c, &GetSubgroupsResponse{
+		GroupIDs: out.GroupIDs,
+	})
+}
+
+type GetGroupMembersResponse struct {
+	Users []response.User `json:"users"`
+}
+
+// GetGroupMembers returns the users who belong to the group, and the effective members through its subgroups
+// as well with transitive.
+func (h *GroupHandler) GetGroupMembers(c echo.Context) error {
+	transitive, err := parseBoolParam(c, "transitive")
+	if err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	out, err := h.uc.GetGroupMembers(&dto.GetGroupMembersInput{
+		GroupID:    c.Param("id"),
+		Transitive: transitive,
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrGroupNotFound) {
+			return response.Error(c, response.ErrorCodeGroupNotFound, http.StatusNotFound, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.OK(c, &GetGroupMembersResponse{
+		Users: response.ToUsersFromDTO(out.Users),
+	})
+}
+
+type GetUserGroupsResponse struct {
+	Groups []response.Group `json:"groups"`
+}
+
+// GetUserGroups returns the groups which the user belongs to, and the effective memberships through the groups
+// nesting them as well with transitive.
+func (h *GroupHandler) GetUserGroups(c echo.Context) error {
+	transitive, err := parseBoolParam(c, "transitive")
+	if err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	out, err := h.uc.GetUserGroups(&dto.GetUserGroupsInput{
+		UserID:     c.Param("id"),
+		Transitive: transitive,
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			return response.Error(c, response.ErrorCodeUserNotFound, http.StatusNotFound, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	gs := make([]response.Group, len(out.Groups))
+	for i, g := range out.Groups {
+		gs[i] = response.Group{
+			GroupID:    g.GroupID,
+			Name:       g.Name,
+			JoinPolicy: g.JoinPolicy,
+			Attributes: g.Attributes,
+			Labels:     g.Labels,
+			Users:      response.ToUsersFromDTO(g.Users),
+			Timestamps: response.ToTimestampsFromDTO(g.Timestamps),
+		}
+	}
+
+	return response.OK(c, &GetUserGroupsResponse{
+		Groups: gs,
+	})
+}
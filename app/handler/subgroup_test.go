@@ -0,0 +1,289 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/labstack/echo"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	mockusecase "github.com/toshiykst/go-layerd-architecture/app/mock/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestGroupHandler_AddSubgroup(t *testing.T) {
+	tests := []struct {
+		name            string
+		newGroupUsecase func(ctrl *gomock.Controller) usecase.GroupUsecase
+		wantStatus      int
+		wantErrCode     response.ErrorCode
+	}{
+		{
+			name: "Nests the group in the group",
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					AddSubgroup(&dto.AddSubgroupInput{GroupID: "TEST_GROUP_ID_1", SubgroupID: "TEST_GROUP_ID_2"}).
+					Return(&dto.AddSubgroupOutput{}, nil)
+				return uc
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "Returns group cycle error response",
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					AddSubgroup(gomock.Any()).
+					Return(nil, usecase.ErrGroupCycle)
+				return uc
+			},
+			wantStatus:  http.StatusConflict,
+			wantErrCode: response.ErrorCodeGroupCycle,
+		},
+		{
+			name: "Returns group not found error response",
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					AddSubgroup(gomock.Any()).
+					Return(nil, usecase.ErrGroupNotFound)
+				return uc
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErrCode: response.ErrorCodeGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"https://example.com:8080/groups/TEST_GROUP_ID_1/subgroups",
+				bytes.NewBufferString(`{"groupId":"TEST_GROUP_ID_2"}`),
+			)
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("TEST_GROUP_ID_1")
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			h := handler.NewGroupHandler(tt.newGroupUsecase(ctrl))
+
+			if err := h.AddSubgroup(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantErrCode != "" {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if got == nil || got.Code != tt.wantErrCode {
+					t.Errorf("error response body: got = %v, want code = %s", got, tt.wantErrCode)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupHandler_GetGroupMembers(t *testing.T) {
+	tests := []struct {
+		name            string
+		query           string
+		newGroupUsecase func(ctrl *gomock.Controller) usecase.GroupUsecase
+		wantStatus      int
+		wantRes         *handler.GetGroupMembersResponse
+		wantErrCode     response.ErrorCode
+	}{
+		{
+			name:  "Returns the effective members of the group",
+			query: "?transitive=true",
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					GetGroupMembers(&dto.GetGroupMembersInput{GroupID: "TEST_GROUP_ID", Transitive: true}).
+					Return(&dto.GetGroupMembersOutput{
+						Users: []dto.User{{UserID: "TEST_USER_ID", Name: "TEST_USER_NAME", Email: "TEST_USER_EMAIL"}},
+					}, nil)
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes: &handler.GetGroupMembersResponse{
+				Users: []response.User{{UserID: "TEST_USER_ID", Name: "TEST_USER_NAME", Email: "TEST_USER_EMAIL"}},
+			},
+		},
+		{
+			name:  "Returns invalid arguments error response if transitive is not a bool",
+			query: "?transitive=yes",
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				return mockusecase.NewMockGroupUsecase(ctrl)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErrCode: response.ErrorCodeInvalidArguments,
+		},
+		{
+			name: "Returns group not found error response",
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					GetGroupMembers(&dto.GetGroupMembersInput{GroupID: "TEST_GROUP_ID"}).
+					Return(nil, usecase.ErrGroupNotFound)
+				return uc
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErrCode: response.ErrorCodeGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(
+				http.MethodGet,
+				"https://example.com:8080/groups/TEST_GROUP_ID/members"+tt.query,
+				nil,
+			)
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("TEST_GROUP_ID")
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			h := handler.NewGroupHandler(tt.newGroupUsecase(ctrl))
+
+			if err := h.GetGroupMembers(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantRes != nil {
+				var got *handler.GetGroupMembersResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if diff := cmp.Diff(got, tt.wantRes); diff != "" {
+					t.Errorf(
+						"response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantRes, diff,
+					)
+				}
+			}
+			if tt.wantErrCode != "" {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if got == nil || got.Code != tt.wantErrCode {
+					t.Errorf("error response body: got = %v, want code = %s", got, tt.wantErrCode)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupHandler_GetUserGroups(t *testing.T) {
+	tests := []struct {
+		name            string
+		newGroupUsecase func(ctrl *gomock.Controller) usecase.GroupUsecase
+		wantStatus      int
+		wantRes         *handler.GetUserGroupsResponse
+		wantErrCode     response.ErrorCode
+	}{
+		{
+			name: "Returns the effective memberships of the user",
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					GetUserGroups(&dto.GetUserGroupsInput{UserID: "TEST_USER_ID", Transitive: true}).
+					Return(&dto.GetUserGroupsOutput{
+						Groups: []dto.Group{{
+							GroupID:    "TEST_GROUP_ID",
+							Name:       "TEST_GROUP_NAME",
+							JoinPolicy: "OPEN",
+							Users:      []dto.User{},
+						}},
+					}, nil)
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes: &handler.GetUserGroupsResponse{
+				Groups: []response.Group{{
+					GroupID:    "TEST_GROUP_ID",
+					Name:       "TEST_GROUP_NAME",
+					JoinPolicy: "OPEN",
+					Users:      []response.User{},
+				}},
+			},
+		},
+		{
+			name: "Returns user not found error response",
+			newGroupUsecase: func(ctrl *gomock.Controller) usecase.GroupUsecase {
+				uc := mockusecase.NewMockGroupUsecase(ctrl)
+				uc.EXPECT().
+					GetUserGroups(gomock.Any()).
+					Return(nil, usecase.ErrUserNotFound)
+				return uc
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErrCode: response.ErrorCodeUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(
+				http.MethodGet,
+				"https://example.com:8080/users/TEST_USER_ID/groups?transitive=true",
+				nil,
+			)
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("TEST_USER_ID")
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			h := handler.NewGroupHandler(tt.newGroupUsecase(ctrl))
+
+			if err := h.GetUserGroups(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantRes != nil {
+				var got *handler.GetUserGroupsResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if diff := cmp.Diff(got, tt.wantRes); diff != "" {
+					t.Errorf(
+						"response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantRes, diff,
+					)
+				}
+			}
+			if tt.wantErrCode != "" {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if got == nil || got.Code != tt.wantErrCode {
+					t.Errorf("error response body: got = %v, want code = %s", got, tt.wantErrCode)
+				}
+			}
+		})
+	}
+}
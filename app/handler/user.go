@@ -2,11 +2,15 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo"
 
+	"github.com/toshiykst/go-layerd-architecture/app/handler/export"
 	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/userimport"
 	"github.com/toshiykst/go-layerd-architecture/app/usecase"
 	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
 )
@@ -23,6 +27,10 @@ type (
 	CreateUserRequest struct {
 		Name  string `json:"name"`
 		Email string `json:"email"`
+		// Status is the initial status of the user, INVITED or ACTIVE, which is ACTIVE if it is omitted.
+		Status string `json:"status,omitempty"`
+		// Attributes are the values of the custom attributes of the user by their keys.
+		Attributes map[string]any `json:"attributes,omitempty"`
 	}
 
 	CreateUserResponse struct {
@@ -37,8 +45,11 @@ func (h *UserHandler) CreateUser(c echo.Context) error {
 	}
 
 	in := &dto.CreateUserInput{
-		Name:  req.Name,
-		Email: req.Email,
+		Meta:       newMeta(c),
+		Name:       req.Name,
+		Email:      req.Email,
+		Status:     req.Status,
+		Attributes: req.Attributes,
 	}
 	out, err := h.uc.CreateUser(in)
 	if err != nil {
@@ -49,11 +60,7 @@ func (h *UserHandler) CreateUser(c echo.Context) error {
 	}
 
 	return response.Created(c, &CreateUserResponse{
-		User: response.User{
-			UserID: out.User.UserID,
-			Name:   out.User.Name,
-			Email:  out.User.Email,
-		},
+		User: response.ToUserFromDTO(out.User),
 	})
 }
 
@@ -77,30 +84,59 @@ func (h *UserHandler) GetUser(c echo.Context) error {
 			return response.ErrorInternal(c, err)
 		}
 	}
+	if notModified(c, out.User.UpdatedAt) {
+		return response.NotModified(c)
+	}
 
 	return response.OK(c, &GetUserResponse{
-		User: response.User{
-			UserID: out.User.UserID,
-			Name:   out.User.Name,
-			Email:  out.User.Email,
-		},
+		User: response.ToUserFromDTO(out.User),
 	})
 }
 
 type (
 	GetUsersResponse struct {
 		Users []response.User `json:"users"`
+		// Highlights are the matches of the query in the users by user id, when searched with q.
+		Highlights map[string][]response.Highlight `json:"highlights,omitempty"`
 	}
 )
 
+// GetUsers lists the users, or searches them by name and email with the query parameter q, ordered by relevance.
+// The users are narrowed down to the ones in no group with noGroup, to the ones created after createdAfter, and to
+// the ones in the status with status, and to the ones with all the comma-separated key:value pairs of attributes.
+// They are ordered by createdAt or updatedAt with sort, descending if prefixed with "-", instead.
 func (h *UserHandler) GetUsers(c echo.Context) error {
-	out, err := h.uc.GetUsers(nil)
+	noGroup, err := parseBoolParam(c, "noGroup")
+	if err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+	createdAfter, err := parseTimeParam(c, "createdAfter")
+	if err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+	attributes, err := parseAttributesParam(c, "attributes")
 	if err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	out, err := h.uc.GetUsers(&dto.GetUsersInput{
+		Query:        c.QueryParam("q"),
+		NoGroup:      noGroup,
+		CreatedAfter: createdAfter,
+		Status:       c.QueryParam("status"),
+		Attributes:   attributes,
+		Sort:         c.QueryParam("sort"),
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidUserInput) {
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		}
 		return response.ErrorInternal(c, err)
 	}
 
 	return response.OK(c, &GetUsersResponse{
-		Users: response.ToUsersFromDTO(out.Users),
+		Users:      response.ToUsersFromDTO(out.Users),
+		Highlights: response.ToHighlightsFromDTO(out.Highlights),
 	})
 }
 
@@ -108,6 +144,8 @@ type (
 	UpdateUserRequest struct {
 		Name  string `json:"name"`
 		Email string `json:"email"`
+		// Attributes replace the custom attributes of the user, which are kept as they are if it is omitted.
+		Attributes map[string]any `json:"attributes,omitempty"`
 	}
 )
 
@@ -120,6 +158,7 @@ func (h *UserHandler) UpdateUser(c echo.Context) error {
 	}
 
 	in := &dto.UpdateUserInput{
+		Meta:   newMeta(c),
 		UserID: uID,
 		Name:   req.Name,
 		Email:  req.Email,
@@ -139,10 +178,51 @@ func (h *UserHandler) UpdateUser(c echo.Context) error {
 	return response.NoContent(c)
 }
 
+type (
+	// PatchUserRequest is the JSON Merge Patch of a user.
+	PatchUserRequest struct {
+		Name       *string        `json:"name,omitempty"`
+		Email      *string        `json:"email,omitempty"`
+		Attributes map[string]any `json:"attributes,omitempty"`
+	}
+)
+
+// PatchUser patches the user with a JSON Merge Patch or a JSON Patch.
+func (h *UserHandler) PatchUser(c echo.Context) error {
+	pt, patch, err := bindPatch(c)
+	if err != nil {
+		if errors.Is(err, errUnsupportedPatchType) {
+			return response.Error(c, response.ErrorCodeUnsupportedMediaType, http.StatusUnsupportedMediaType, err)
+		}
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	in := &dto.PatchUserInput{
+		Meta:      newMeta(c),
+		UserID:    c.Param("id"),
+		PatchType: pt,
+		Patch:     patch,
+	}
+
+	_, err = h.uc.PatchUser(in)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidPatch) || errors.Is(err, usecase.ErrInvalidUserInput) {
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		}
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			return response.Error(c, response.ErrorCodeUserNotFound, http.StatusNotFound, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.NoContent(c)
+}
+
 func (h *UserHandler) DeleteUser(c echo.Context) error {
 	uID := c.Param("id")
 
 	in := &dto.DeleteUserInput{
+		Meta:   newMeta(c),
 		UserID: uID,
 	}
 
@@ -157,3 +237,165 @@ func (h *UserHandler) DeleteUser(c echo.Context) error {
 
 	return response.NoContent(c)
 }
+
+type (
+	UserStatusResponse struct {
+		User response.User `json:"user"`
+	}
+)
+
+// ActivateUser activates the invited user.
+func (h *UserHandler) ActivateUser(c echo.Context) error {
+	out, err := h.uc.ActivateUser(&dto.ActivateUserInput{Meta: newMeta(c), UserID: c.Param("id")})
+	if err != nil {
+		return h.respondStatusError(c, err)
+	}
+	return response.OK(c, &UserStatusResponse{User: response.ToUserFromDTO(out.User)})
+}
+
+// SuspendUser suspends the active user, who can join no group until reactivated.
+func (h *UserHandler) SuspendUser(c echo.Context) error {
+	out, err := h.uc.SuspendUser(&dto.SuspendUserInput{Meta: newMeta(c), UserID: c.Param("id")})
+	if err != nil {
+		return h.respondStatusError(c, err)
+	}
+	return response.OK(c, &UserStatusResponse{User: response.ToUserFromDTO(out.User)})
+}
+
+// ReactivateUser reactivates the suspended or deactivated user.
+func (h *UserHandler) ReactivateUser(c echo.Context) error {
+	out, err := h.uc.ReactivateUser(&dto.ReactivateUserInput{Meta: newMeta(c), UserID: c.Param("id")})
+	if err != nil {
+		return h.respondStatusError(c, err)
+	}
+	return response.OK(c, &UserStatusResponse{User: response.ToUserFromDTO(out.User)})
+}
+
+// DeactivateUser deactivates the user, who leaves all the groups but is kept.
+func (h *UserHandler) DeactivateUser(c echo.Context) error {
+	out, err := h.uc.DeactivateUser(&dto.DeactivateUserInput{Meta: newMeta(c), UserID: c.Param("id")})
+	if err != nil {
+		return h.respondStatusError(c, err)
+	}
+	return response.OK(c, &UserStatusResponse{User: response.ToUserFromDTO(out.User)})
+}
+
+func (h *UserHandler) respondStatusError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrUserNotFound):
+		return response.Error(c, response.ErrorCodeUserNotFound, http.StatusNotFound, err)
+	case errors.Is(err, usecase.ErrInvalidUserStatusTransition):
+		return response.Error(c, response.ErrorCodeInvalidUserStatusTransition, http.StatusConflict, err)
+	}
+	return response.ErrorInternal(c, err)
+}
+
+// DefaultImportBatchSize is the batch size of an import which does not specify it.
+const DefaultImportBatchSize = 100
+
+type (
+	ImportUsersResponse struct {
+		DryRun  bool                        `json:"dryRun"`
+		Summary response.ImportSummary      `json:"summary"`
+		Results []response.ImportUserResult `json:"results"`
+	}
+)
+
+// ImportUsers imports the users in the CSV or NDJSON body.
+// The query parameters mode, dryRun and batchSize control the import.
+func (h *UserHandler) ImportUsers(c echo.Context) error {
+	f, ok := userimport.FormatOf(c.Request().Header.Get(echo.HeaderContentType))
+	if !ok {
+		return response.Error(
+			c, response.ErrorCodeUnsupportedMediaType, http.StatusUnsupportedMediaType,
+			fmt.Errorf("content type must be %s or %s",
+				userimport.ContentTypes[userimport.FormatCSV], userimport.ContentTypes[userimport.FormatNDJSON]),
+		)
+	}
+
+	dryRun := false
+	if v := c.QueryParam("dryRun"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		}
+		dryRun = b
+	}
+	batchSize := DefaultImportBatchSize
+	if v := c.QueryParam("batchSize"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		}
+		batchSize = n
+	}
+
+	rows, err := userimport.Decode(c.Request().Body, f)
+	if err != nil {
+		if errors.Is(err, userimport.ErrInvalidFile) {
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	in := &dto.ImportUsersInput{
+		Meta:      newMeta(c),
+		Rows:      rows,
+		Mode:      dto.ImportMode(c.QueryParam("mode")),
+		DryRun:    dryRun,
+		BatchSize: batchSize,
+	}
+
+	out, err := h.uc.ImportUsers(in)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidImportInput) {
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.OK(c, &ImportUsersResponse{
+		DryRun:  dryRun,
+		Summary: response.ToImportSummaryFromDTO(out.Results),
+		Results: response.ToImportUserResultsFromDTO(out.Results),
+	})
+}
+
+// ExportUsers streams all the users in CSV, NDJSON or JSON, chosen by the format query parameter or the Accept header.
+func (h *UserHandler) ExportUsers(c echo.Context) error {
+	f, err := export.Negotiate(c.QueryParam("format"), c.Request().Header.Get(echo.HeaderAccept))
+	if err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	in := &dto.ExportUsersInput{Limit: ExportChunkSize}
+	out, err := h.uc.ExportUsers(in)
+	if err != nil {
+		return response.ErrorInternal(c, err)
+	}
+
+	// The response is committed from here, so an error only aborts the stream.
+	s, err := startExport(c, f, "users", []string{"userId", "name", "email", "status"})
+	if err != nil {
+		return err
+	}
+	for {
+		for _, u := range out.Users {
+			ru := response.ToUserFromDTO(u)
+			if err := s.Write(ru, []string{ru.UserID, ru.Name, ru.Email, ru.Status}); err != nil {
+				return err
+			}
+		}
+		if out.NextAfterUserID == "" {
+			return s.Close()
+		}
+		if err := s.Flush(); err != nil {
+			return err
+		}
+
+		in.AfterUserID = out.NextAfterUserID
+		if out, err = h.uc.ExportUsers(in); err != nil {
+			return err
+		}
+	}
+}
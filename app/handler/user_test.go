@@ -8,7 +8,9 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/labstack/echo"
@@ -319,9 +321,86 @@ func TestUserHandler_GetUser(t *testing.T) {
 	}
 }
 
+func TestUserHandler_GetUser_ifModifiedSince(t *testing.T) {
+	updatedAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		ifModifiedSince  string
+		wantStatus       int
+		wantLastModified string
+	}{
+		{
+			name:             "Returns the user with its last modified time",
+			wantStatus:       http.StatusOK,
+			wantLastModified: "Mon, 02 Jan 2023 03:04:05 GMT",
+		},
+		{
+			name:             "Returns the user modified since the time",
+			ifModifiedSince:  "Mon, 02 Jan 2023 03:04:04 GMT",
+			wantStatus:       http.StatusOK,
+			wantLastModified: "Mon, 02 Jan 2023 03:04:05 GMT",
+		},
+		{
+			name:             "Returns not modified if the user is not modified since the time",
+			ifModifiedSince:  "Mon, 02 Jan 2023 03:04:05 GMT",
+			wantStatus:       http.StatusNotModified,
+			wantLastModified: "Mon, 02 Jan 2023 03:04:05 GMT",
+		},
+		{
+			name:             "Ignores the time if it is invalid",
+			ifModifiedSince:  "TEST_INVALID_TIME",
+			wantStatus:       http.StatusOK,
+			wantLastModified: "Mon, 02 Jan 2023 03:04:05 GMT",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "https://example.com:8080/users/TEST_USER_ID", nil)
+			if tt.ifModifiedSince != "" {
+				req.Header.Set(echo.HeaderIfModifiedSince, tt.ifModifiedSince)
+			}
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetPath("/users/:id")
+			c.SetParamNames("id")
+			c.SetParamValues("TEST_USER_ID")
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			uc := mockusecase.NewMockUserUsecase(ctrl)
+			uc.EXPECT().
+				GetUser(&dto.GetUserInput{UserID: "TEST_USER_ID"}).
+				Return(&dto.GetUserOutput{
+					User: dto.User{
+						UserID:     "TEST_USER_ID",
+						Timestamps: dto.Timestamps{CreatedAt: updatedAt, UpdatedAt: updatedAt},
+					},
+				}, nil)
+
+			if err := handler.NewUserHandler(uc).GetUser(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get(echo.HeaderLastModified); got != tt.wantLastModified {
+				t.Errorf("Last-Modified got = %q, want = %q", got, tt.wantLastModified)
+			}
+			if tt.wantStatus == http.StatusNotModified && rec.Body.Len() > 0 {
+				t.Errorf("response body got = %q, want empty", rec.Body.String())
+			}
+		})
+	}
+}
+
 func TestUserHandler_GetUsers(t *testing.T) {
 	tests := []struct {
 		name           string
+		query          string
 		newUserUsecase func(ctrl *gomock.Controller) usecase.UserUsecase
 		wantStatus     int
 		wantRes        *handler.GetUsersResponse
@@ -378,6 +457,200 @@ func TestUserHandler_GetUsers(t *testing.T) {
 			},
 			wantErrRes: nil,
 		},
+		{
+			name:  "Returns users sorted by the sort order with their timestamps",
+			query: "?sort=-updatedAt",
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					GetUsers(&dto.GetUsersInput{Sort: "-updatedAt"}).
+					Return(
+						&dto.GetUsersOutput{
+							Users: []dto.User{
+								{
+									UserID: "TEST_USER_ID_1",
+									Name:   "TEST_USER_NAME_1",
+									Email:  "TEST_USER_EMAIL_1",
+									Timestamps: dto.Timestamps{
+										CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+										CreatedBy: "TEST_ACTOR",
+										UpdatedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+										UpdatedBy: "TEST_ACTOR_2",
+									},
+								},
+							},
+						}, nil)
+
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes: &handler.GetUsersResponse{
+				Users: []response.User{
+					{
+						UserID: "TEST_USER_ID_1",
+						Name:   "TEST_USER_NAME_1",
+						Email:  "TEST_USER_EMAIL_1",
+						Timestamps: response.Timestamps{
+							CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+							CreatedBy: "TEST_ACTOR",
+							UpdatedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+							UpdatedBy: "TEST_ACTOR_2",
+						},
+					},
+				},
+			},
+			wantErrRes: nil,
+		},
+		{
+			name:  "Returns users searched by the query with highlights",
+			query: "?q=ann",
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					GetUsers(&dto.GetUsersInput{Query: "ann"}).
+					Return(
+						&dto.GetUsersOutput{
+							Users: []dto.User{
+								{
+									UserID: "TEST_USER_ID_1",
+									Name:   "Ann",
+									Email:  "TEST_USER_EMAIL_1",
+								},
+							},
+							Highlights: map[string][]dto.Highlight{
+								"TEST_USER_ID_1": {{Field: "name", Start: 0, End: 3}},
+							},
+						}, nil)
+
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes: &handler.GetUsersResponse{
+				Users: []response.User{
+					{
+						UserID: "TEST_USER_ID_1",
+						Name:   "Ann",
+						Email:  "TEST_USER_EMAIL_1",
+					},
+				},
+				Highlights: map[string][]response.Highlight{
+					"TEST_USER_ID_1": {{Field: "name", Start: 0, End: 3}},
+				},
+			},
+			wantErrRes: nil,
+		},
+		{
+			name:  "Returns users filtered by the query parameters",
+			query: "?noGroup=true&createdAfter=2023-01-01T00:00:00Z&status=SUSPENDED",
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					GetUsers(&dto.GetUsersInput{
+						NoGroup:      true,
+						CreatedAfter: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+						Status:       "SUSPENDED",
+					}).
+					Return(&dto.GetUsersOutput{Users: []dto.User{}}, nil)
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes: &handler.GetUsersResponse{
+				Users: []response.User{},
+			},
+			wantErrRes: nil,
+		},
+		{
+			name:  "Returns users with the attribute values",
+			query: "?attributes=department:sales,level:2",
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					GetUsers(&dto.GetUsersInput{
+						Attributes: map[string]string{"department": "sales", "level": "2"},
+					}).
+					Return(&dto.GetUsersOutput{Users: []dto.User{
+						{
+							UserID:     "TEST_USER_ID_1",
+							Name:       "TEST_USER_NAME_1",
+							Email:      "TEST_USER_EMAIL_1",
+							Attributes: map[string]any{"department": "sales", "level": 2.0},
+						},
+					}}, nil)
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes: &handler.GetUsersResponse{
+				Users: []response.User{
+					{
+						UserID:     "TEST_USER_ID_1",
+						Name:       "TEST_USER_NAME_1",
+						Email:      "TEST_USER_EMAIL_1",
+						Attributes: map[string]any{"department": "sales", "level": 2.0},
+					},
+				},
+			},
+			wantErrRes: nil,
+		},
+		{
+			name:  "Returns invalid arguments error response if attributes are not key:value pairs",
+			query: "?attributes=department",
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				return mockusecase.NewMockUserUsecase(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    nil,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: `attributes must be key:value pairs: "department"`,
+			},
+		},
+		{
+			name:  "Returns invalid arguments error response if noGroup is not a boolean",
+			query: "?noGroup=maybe",
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				return mockusecase.NewMockUserUsecase(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    nil,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: `noGroup must be a boolean: strconv.ParseBool: parsing "maybe": invalid syntax`,
+			},
+		},
+		{
+			name:  "Returns invalid arguments error response if createdAfter is not a time",
+			query: "?createdAfter=yesterday",
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				return mockusecase.NewMockUserUsecase(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    nil,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: `createdAfter must be RFC 3339 time: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`,
+			},
+		},
+		{
+			name:  "Returns invalid arguments error response",
+			query: "?q=ann",
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					GetUsers(gomock.Any()).
+					Return(nil, usecase.ErrInvalidUserInput)
+				return uc
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    nil,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: usecase.ErrInvalidUserInput.Error(),
+			},
+		},
 		{
 			name: "Returns internal server error response",
 			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
@@ -400,7 +673,7 @@ func TestUserHandler_GetUsers(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			req := httptest.NewRequest(
 				http.MethodGet,
-				"https://example.com:8080/users",
+				"https://example.com:8080/users"+tt.query,
 				nil,
 			)
 			req.Header.Set("Content-Type", "application/json")
@@ -608,36 +881,103 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 	}
 }
 
-func TestUserHandler_DeleteUser(t *testing.T) {
+func TestUserHandler_PatchUser(t *testing.T) {
 	tests := []struct {
 		name           string
-		uID            string
+		id             string
+		contentType    string
+		patch          string
 		newUserUsecase func(ctrl *gomock.Controller) usecase.UserUsecase
 		wantStatus     int
 		wantErrRes     *response.ErrorResponse
 	}{
 		{
-			name: "Delete a user",
-			uID:  "TEST_USER_ID",
+			name:        "Patch a user",
+			id:          "TEST_USER_ID",
+			contentType: string(dto.PatchTypeMergePatch) + "; charset=utf-8",
+			patch:       `{"name":"TEST_USER_NAME"}`,
 			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
 				uc := mockusecase.NewMockUserUsecase(ctrl)
 				uc.EXPECT().
-					DeleteUser(gomock.Any()).
-					DoAndReturn(func(in *dto.DeleteUserInput) (*dto.DeleteUserOutput, error) {
-						return &dto.DeleteUserOutput{}, nil
+					PatchUser(gomock.Any()).
+					DoAndReturn(func(in *dto.PatchUserInput) (*dto.PatchUserOutput, error) {
+						want := &dto.PatchUserInput{
+							UserID:    "TEST_USER_ID",
+							PatchType: dto.PatchTypeMergePatch,
+							Patch:     []byte(`{"name":"TEST_USER_NAME"}`),
+						}
+						if diff := cmp.Diff(in, want); diff != "" {
+							t.Errorf("uc.PatchUser(%v); want %v\ndiffers: (-got +want)\n%s", in, want, diff)
+						}
+						return &dto.PatchUserOutput{}, nil
 					})
 				return uc
 			},
 			wantStatus: http.StatusNoContent,
-			wantErrRes: nil,
 		},
 		{
-			name: "Returns user not found error response",
-			uID:  "TEST_USER_ID",
+			name:        "Returns unsupported media type error response",
+			id:          "TEST_USER_ID",
+			contentType: "application/json",
+			patch:       `{"name":"TEST_USER_NAME"}`,
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				return mockusecase.NewMockUserUsecase(ctrl)
+			},
+			wantStatus: http.StatusUnsupportedMediaType,
+			wantErrRes: &response.ErrorResponse{
+				Code:   response.ErrorCodeUnsupportedMediaType,
+				Status: http.StatusUnsupportedMediaType,
+				Message: `content type "application/json" must be application/merge-patch+json ` +
+					`or application/json-patch+json: unsupported patch type`,
+			},
+		},
+		{
+			name:        "Returns invalid arguments error response when the patch is invalid",
+			id:          "TEST_USER_ID",
+			contentType: string(dto.PatchTypeMergePatch),
+			patch:       `{"name":"TEST_USER_NAME"}`,
 			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
 				uc := mockusecase.NewMockUserUsecase(ctrl)
 				uc.EXPECT().
-					DeleteUser(gomock.Any()).
+					PatchUser(gomock.Any()).
+					Return(nil, usecase.ErrInvalidPatch)
+				return uc
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: usecase.ErrInvalidPatch.Error(),
+			},
+		},
+		{
+			name:        "Returns invalid arguments error response when the patched user is invalid",
+			id:          "TEST_USER_ID",
+			contentType: string(dto.PatchTypeMergePatch),
+			patch:       `{"name":"TEST_USER_NAME"}`,
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					PatchUser(gomock.Any()).
+					Return(nil, usecase.ErrInvalidUserInput)
+				return uc
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: usecase.ErrInvalidUserInput.Error(),
+			},
+		},
+		{
+			name:        "Returns user not found error response",
+			id:          "TEST_USER_ID",
+			contentType: string(dto.PatchTypeMergePatch),
+			patch:       `{"name":"TEST_USER_NAME"}`,
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					PatchUser(gomock.Any()).
 					Return(nil, usecase.ErrUserNotFound)
 				return uc
 			},
@@ -649,12 +989,14 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 			},
 		},
 		{
-			name: "Returns internal server error response",
-			uID:  "TEST_USER_ID",
+			name:        "Returns internal server error response",
+			id:          "TEST_USER_ID",
+			contentType: string(dto.PatchTypeMergePatch),
+			patch:       `{"name":"TEST_USER_NAME"}`,
 			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
 				uc := mockusecase.NewMockUserUsecase(ctrl)
 				uc.EXPECT().
-					DeleteUser(gomock.Any()).
+					PatchUser(gomock.Any()).
 					Return(nil, errors.New("an error occurred"))
 				return uc
 			},
@@ -669,16 +1011,18 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			req := httptest.NewRequest(
-				http.MethodDelete,
-				fmt.Sprintf("https://example.com:8080/users/%s", tt.uID),
-				nil,
+				http.MethodPatch,
+				fmt.Sprintf("https://example.com:8080/users/%s", tt.id),
+				strings.NewReader(tt.patch),
 			)
-			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Content-Type", tt.contentType)
 
 			rec := httptest.NewRecorder()
 
 			e := echo.New()
 			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
 
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
@@ -686,32 +1030,17 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 
 			h := handler.NewUserHandler(uc)
 
-			err := h.DeleteUser(c)
-			if err != nil {
+			if err := h.PatchUser(c); err != nil {
 				t.Fatalf("want no err, but has error: %v", err)
 			}
 
-			res := rec.Result()
-
-			wantStatusCode := tt.wantStatus
-			gotStatusCode := res.StatusCode
-			if gotStatusCode != wantStatusCode {
-				t.Errorf("statusCode got = %d, want = %d", gotStatusCode, wantStatusCode)
-			}
-
-			resBody, err := io.ReadAll(rec.Body)
-			if err != nil {
-				t.Fatalf("Failed to read body: %s", err.Error())
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
 			}
-			defer func(Body io.ReadCloser) {
-				if err := Body.Close(); err != nil {
-					t.Fatalf("Failed to close body: %s", err.Error())
-				}
-			}(res.Body)
 
 			if tt.wantErrRes != nil {
 				var got *response.ErrorResponse
-				_ = json.Unmarshal(resBody, &got)
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
 				if diff := cmp.Diff(got, tt.wantErrRes); diff != "" {
 					t.Errorf(
 						"error response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
@@ -722,3 +1051,597 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserHandler_DeleteUser(t *testing.T) {
+	tests := []struct {
+		name           string
+		uID            string
+		newUserUsecase func(ctrl *gomock.Controller) usecase.UserUsecase
+		wantStatus     int
+		wantErrRes     *response.ErrorResponse
+	}{
+		{
+			name: "Delete a user",
+			uID:  "TEST_USER_ID",
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					DeleteUser(gomock.Any()).
+					DoAndReturn(func(in *dto.DeleteUserInput) (*dto.DeleteUserOutput, error) {
+						return &dto.DeleteUserOutput{}, nil
+					})
+				return uc
+			},
+			wantStatus: http.StatusNoContent,
+			wantErrRes: nil,
+		},
+		{
+			name: "Returns user not found error response",
+			uID:  "TEST_USER_ID",
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					DeleteUser(gomock.Any()).
+					Return(nil, usecase.ErrUserNotFound)
+				return uc
+			},
+			wantStatus: http.StatusNotFound,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeUserNotFound,
+				Status:  http.StatusNotFound,
+				Message: usecase.ErrUserNotFound.Error(),
+			},
+		},
+		{
+			name: "Returns internal server error response",
+			uID:  "TEST_USER_ID",
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					DeleteUser(gomock.Any()).
+					Return(nil, errors.New("an error occurred"))
+				return uc
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInternalServerError,
+				Status:  http.StatusInternalServerError,
+				Message: "an error occurred",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(
+				http.MethodDelete,
+				fmt.Sprintf("https://example.com:8080/users/%s", tt.uID),
+				nil,
+			)
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			uc := tt.newUserUsecase(ctrl)
+
+			h := handler.NewUserHandler(uc)
+
+			err := h.DeleteUser(c)
+			if err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			res := rec.Result()
+
+			wantStatusCode := tt.wantStatus
+			gotStatusCode := res.StatusCode
+			if gotStatusCode != wantStatusCode {
+				t.Errorf("statusCode got = %d, want = %d", gotStatusCode, wantStatusCode)
+			}
+
+			resBody, err := io.ReadAll(rec.Body)
+			if err != nil {
+				t.Fatalf("Failed to read body: %s", err.Error())
+			}
+			defer func(Body io.ReadCloser) {
+				if err := Body.Close(); err != nil {
+					t.Fatalf("Failed to close body: %s", err.Error())
+				}
+			}(res.Body)
+
+			if tt.wantErrRes != nil {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(resBody, &got)
+				if diff := cmp.Diff(got, tt.wantErrRes); diff != "" {
+					t.Errorf(
+						"error response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantErrRes, diff,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestUserHandler_ImportUsers(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		contentType    string
+		body           string
+		newUserUsecase func(ctrl *gomock.Controller) usecase.UserUsecase
+		wantStatus     int
+		wantRes        *handler.ImportUsersResponse
+		wantErrRes     *response.ErrorResponse
+	}{
+		{
+			name:        "Import users",
+			query:       "?mode=upsert&dryRun=true&batchSize=10",
+			contentType: "text/csv; charset=utf-8",
+			body:        "name,email\nTEST_USER_NAME_1,TEST_USER_EMAIL_1\nTEST_USER_NAME_2,TEST_USER_EMAIL_2\n",
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					ImportUsers(gomock.Any()).
+					DoAndReturn(func(in *dto.ImportUsersInput) (*dto.ImportUsersOutput, error) {
+						want := &dto.ImportUsersInput{
+							Rows: []dto.ImportUserRow{
+								{Line: 2, Name: "TEST_USER_NAME_1", Email: "TEST_USER_EMAIL_1"},
+								{Line: 3, Name: "TEST_USER_NAME_2", Email: "TEST_USER_EMAIL_2"},
+							},
+							Mode:      dto.ImportModeUpsert,
+							DryRun:    true,
+							BatchSize: 10,
+						}
+						if diff := cmp.Diff(in, want); diff != "" {
+							t.Errorf("uc.ImportUsers(%v); want %v\ndiffers: (-got +want)\n%s", in, want, diff)
+						}
+						return &dto.ImportUsersOutput{
+							Results: []dto.ImportUserResult{
+								{
+									Line:   2,
+									Status: dto.ImportStatusCreated,
+									UserID: "TEST_USER_ID_1",
+									Email:  "TEST_USER_EMAIL_1",
+								},
+								{
+									Line:   3,
+									Status: dto.ImportStatusFailed,
+									UserID: "TEST_USER_ID_2",
+									Email:  "TEST_USER_EMAIL_2",
+									Reason: "a user of the email already exists",
+								},
+							},
+						}, nil
+					})
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes: &handler.ImportUsersResponse{
+				DryRun:  true,
+				Summary: response.ImportSummary{Created: 1, Failed: 1},
+				Results: []response.ImportUserResult{
+					{
+						Line:   2,
+						Status: "CREATED",
+						UserID: "TEST_USER_ID_1",
+						Email:  "TEST_USER_EMAIL_1",
+					},
+					{
+						Line:   3,
+						Status: "FAILED",
+						UserID: "TEST_USER_ID_2",
+						Email:  "TEST_USER_EMAIL_2",
+						Reason: "a user of the email already exists",
+					},
+				},
+			},
+		},
+		{
+			name:        "Returns unsupported media type error response",
+			contentType: "application/json",
+			body:        `{"name":"TEST_USER_NAME","email":"TEST_USER_EMAIL"}`,
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				return mockusecase.NewMockUserUsecase(ctrl)
+			},
+			wantStatus: http.StatusUnsupportedMediaType,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeUnsupportedMediaType,
+				Status:  http.StatusUnsupportedMediaType,
+				Message: "content type must be text/csv or application/x-ndjson",
+			},
+		},
+		{
+			name:        "Returns invalid arguments error response when the batch size is not a number",
+			query:       "?batchSize=TEST",
+			contentType: "application/x-ndjson",
+			body:        `{"name":"TEST_USER_NAME","email":"TEST_USER_EMAIL"}`,
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				return mockusecase.NewMockUserUsecase(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: `strconv.Atoi: parsing "TEST": invalid syntax`,
+			},
+		},
+		{
+			name:        "Returns invalid arguments error response when the file is invalid",
+			contentType: "text/csv",
+			body:        "name,email,age\n",
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				return mockusecase.NewMockUserUsecase(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: `unknown column "age": invalid import file`,
+			},
+		},
+		{
+			name:        "Returns invalid arguments error response when the input is invalid",
+			contentType: "application/x-ndjson",
+			body:        `{"name":"TEST_USER_NAME","email":"TEST_USER_EMAIL"}`,
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					ImportUsers(gomock.Any()).
+					Return(nil, usecase.ErrInvalidImportInput)
+				return uc
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: usecase.ErrInvalidImportInput.Error(),
+			},
+		},
+		{
+			name:        "Returns internal server error response",
+			contentType: "application/x-ndjson",
+			body:        `{"name":"TEST_USER_NAME","email":"TEST_USER_EMAIL"}`,
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					ImportUsers(gomock.Any()).
+					Return(nil, errors.New("an error occurred"))
+				return uc
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInternalServerError,
+				Status:  http.StatusInternalServerError,
+				Message: "an error occurred",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"https://example.com:8080/users/import"+tt.query,
+				strings.NewReader(tt.body),
+			)
+			req.Header.Set("Content-Type", tt.contentType)
+
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			uc := tt.newUserUsecase(ctrl)
+
+			h := handler.NewUserHandler(uc)
+
+			if err := h.ImportUsers(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
+			}
+
+			if tt.wantRes != nil {
+				var got *handler.ImportUsersResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if diff := cmp.Diff(got, tt.wantRes); diff != "" {
+					t.Errorf(
+						"response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantRes, diff,
+					)
+				}
+			}
+
+			if tt.wantErrRes != nil {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if diff := cmp.Diff(got, tt.wantErrRes); diff != "" {
+					t.Errorf(
+						"error response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantErrRes, diff,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestUserHandler_ExportUsers(t *testing.T) {
+	ts := dto.Timestamps{
+		CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	tsJSON := `"createdAt":"2023-01-01T00:00:00Z","updatedAt":"2023-01-02T00:00:00Z"`
+	chunks := func(ctrl *gomock.Controller) usecase.UserUsecase {
+		uc := mockusecase.NewMockUserUsecase(ctrl)
+		gomock.InOrder(
+			uc.EXPECT().
+				ExportUsers(&dto.ExportUsersInput{Limit: handler.ExportChunkSize}).
+				Return(&dto.ExportUsersOutput{
+					Users: []dto.User{
+						{UserID: "TEST_USER_ID_1", Name: "TEST_USER_NAME_1", Email: "TEST_USER_EMAIL_1", Status: "ACTIVE", Timestamps: ts},
+					},
+					NextAfterUserID: "TEST_USER_ID_1",
+				}, nil),
+			uc.EXPECT().
+				ExportUsers(&dto.ExportUsersInput{AfterUserID: "TEST_USER_ID_1", Limit: handler.ExportChunkSize}).
+				Return(&dto.ExportUsersOutput{
+					Users: []dto.User{
+						{UserID: "TEST_USER_ID_2", Name: "TEST_USER_NAME_2", Email: "TEST_USER_EMAIL_2", Status: "ACTIVE", Timestamps: ts},
+					},
+				}, nil),
+		)
+		return uc
+	}
+
+	tests := []struct {
+		name            string
+		query           string
+		accept          string
+		acceptEncoding  string
+		newUserUsecase  func(ctrl *gomock.Controller) usecase.UserUsecase
+		wantStatus      int
+		wantContentType string
+		wantEncoding    string
+		wantBody        string
+		wantErrRes      *response.ErrorResponse
+	}{
+		{
+			name:            "Export users in JSON by default",
+			newUserUsecase:  chunks,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantBody: `[{"userId":"TEST_USER_ID_1","name":"TEST_USER_NAME_1","email":"TEST_USER_EMAIL_1","status":"ACTIVE",` + tsJSON + `},` +
+				`{"userId":"TEST_USER_ID_2","name":"TEST_USER_NAME_2","email":"TEST_USER_EMAIL_2","status":"ACTIVE",` + tsJSON + `}]` + "\n",
+		},
+		{
+			name:            "Export users in CSV by the Accept header",
+			accept:          "text/csv",
+			newUserUsecase:  chunks,
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv",
+			wantBody: "userId,name,email,status\n" +
+				"TEST_USER_ID_1,TEST_USER_NAME_1,TEST_USER_EMAIL_1,ACTIVE\n" +
+				"TEST_USER_ID_2,TEST_USER_NAME_2,TEST_USER_EMAIL_2,ACTIVE\n",
+		},
+		{
+			name:            "Export users in NDJSON compressed in gzip",
+			query:           "?format=ndjson",
+			accept:          "text/csv",
+			acceptEncoding:  "deflate, gzip",
+			newUserUsecase:  chunks,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/x-ndjson",
+			wantEncoding:    "gzip",
+			wantBody: `{"userId":"TEST_USER_ID_1","name":"TEST_USER_NAME_1","email":"TEST_USER_EMAIL_1","status":"ACTIVE",` + tsJSON + `}` + "\n" +
+				`{"userId":"TEST_USER_ID_2","name":"TEST_USER_NAME_2","email":"TEST_USER_EMAIL_2","status":"ACTIVE",` + tsJSON + `}` + "\n",
+		},
+		{
+			name:  "Returns invalid arguments error response when the format is unknown",
+			query: "?format=xml",
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				return mockusecase.NewMockUserUsecase(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: `format must be csv, ndjson or json: "xml": unknown export format`,
+			},
+		},
+		{
+			name: "Returns internal server error response",
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					ExportUsers(gomock.Any()).
+					Return(nil, errors.New("an error occurred"))
+				return uc
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInternalServerError,
+				Status:  http.StatusInternalServerError,
+				Message: "an error occurred",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "https://example.com:8080/users/export"+tt.query, nil)
+			req.Header.Set("Accept", tt.accept)
+			req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			uc := tt.newUserUsecase(ctrl)
+
+			h := handler.NewUserHandler(uc)
+
+			if err := h.ExportUsers(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
+			}
+
+			if tt.wantErrRes != nil {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if diff := cmp.Diff(got, tt.wantErrRes); diff != "" {
+					t.Errorf(
+						"error response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantErrRes, diff,
+					)
+				}
+				return
+			}
+
+			if got := rec.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("Content-Type got = %s, want = %s", got, tt.wantContentType)
+			}
+			if got := rec.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Errorf("Content-Encoding got = %s, want = %s", got, tt.wantEncoding)
+			}
+			if got := readExport(t, rec); got != tt.wantBody {
+				t.Errorf("response body: got = %q, want = %q", got, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestUserHandler_SuspendUser(t *testing.T) {
+	tests := []struct {
+		name           string
+		newUserUsecase func(ctrl *gomock.Controller) usecase.UserUsecase
+		wantStatus     int
+		wantRes        *handler.UserStatusResponse
+		wantErrCode    response.ErrorCode
+	}{
+		{
+			name: "Suspends the user",
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					SuspendUser(&dto.SuspendUserInput{UserID: "TEST_USER_ID"}).
+					Return(&dto.SuspendUserOutput{
+						User: dto.User{UserID: "TEST_USER_ID", Name: "TEST_USER_NAME", Email: "TEST_USER_EMAIL", Status: "SUSPENDED"},
+					}, nil)
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes: &handler.UserStatusResponse{
+				User: response.User{UserID: "TEST_USER_ID", Name: "TEST_USER_NAME", Email: "TEST_USER_EMAIL", Status: "SUSPENDED"},
+			},
+		},
+		{
+			name: "Returns invalid user status transition error response",
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					SuspendUser(gomock.Any()).
+					Return(nil, usecase.ErrInvalidUserStatusTransition)
+				return uc
+			},
+			wantStatus:  http.StatusConflict,
+			wantErrCode: response.ErrorCodeInvalidUserStatusTransition,
+		},
+		{
+			name: "Returns user not found error response",
+			newUserUsecase: func(ctrl *gomock.Controller) usecase.UserUsecase {
+				uc := mockusecase.NewMockUserUsecase(ctrl)
+				uc.EXPECT().
+					SuspendUser(gomock.Any()).
+					Return(nil, usecase.ErrUserNotFound)
+				return uc
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErrCode: response.ErrorCodeUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "https://example.com:8080/users/TEST_USER_ID/suspend", nil)
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("TEST_USER_ID")
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			h := handler.NewUserHandler(tt.newUserUsecase(ctrl))
+
+			if err := h.SuspendUser(c); err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("statusCode got = %d, want = %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantRes != nil {
+				var got *handler.UserStatusResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if diff := cmp.Diff(got, tt.wantRes); diff != "" {
+					t.Errorf(
+						"response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantRes, diff,
+					)
+				}
+			}
+			if tt.wantErrCode != "" {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(rec.Body.Bytes(), &got)
+				if got == nil || got.Code != tt.wantErrCode {
+					t.Errorf("error response body: got = %v, want code = %s", got, tt.wantErrCode)
+				}
+			}
+		})
+	}
+}
+
+func TestUserHandler_DeactivateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := mockusecase.NewMockUserUsecase(ctrl)
+	uc.EXPECT().
+		DeactivateUser(&dto.DeactivateUserInput{UserID: "TEST_USER_ID"}).
+		Return(&dto.DeactivateUserOutput{
+			User: dto.User{UserID: "TEST_USER_ID", Name: "TEST_USER_NAME", Email: "TEST_USER_EMAIL", Status: "DEACTIVATED"},
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "https://example.com:8080/users/TEST_USER_ID/deactivate", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("TEST_USER_ID")
+
+	if err := handler.NewUserHandler(uc).DeactivateUser(c); err != nil {
+		t.Fatalf("want no err, but has error: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("statusCode got = %d, want = %d", rec.Code, http.StatusOK)
+	}
+	var got *handler.UserStatusResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &got)
+	if got == nil || got.User.Status != "DEACTIVATED" {
+		t.Errorf("response body: got = %v, want status DEACTIVATED", got)
+	}
+}
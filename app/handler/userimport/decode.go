@@ -0,0 +1,157 @@
+// Package userimport reads the files of users to import, which the HTTP API and the CLI accept.
+package userimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// MaxRows is the limit of the number of the rows of a file.
+const MaxRows = 100000
+
+// maxLineSize is the limit of the size of a line of NDJSON.
+const maxLineSize = 1 << 20
+
+var ErrInvalidFile = errors.New("invalid import file")
+
+// Format is the format of a file.
+type Format string
+
+const (
+	// FormatCSV is CSV with a header of the columns name and email.
+	FormatCSV Format = "csv"
+	// FormatNDJSON is newline delimited JSON objects of name and email.
+	FormatNDJSON Format = "ndjson"
+)
+
+// ContentTypes are the content types of the formats.
+var ContentTypes = map[Format]string{
+	FormatCSV:    "text/csv",
+	FormatNDJSON: "application/x-ndjson",
+}
+
+// FormatOf returns the format of the content type.
+func FormatOf(contentType string) (Format, bool) {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+	for f, ct := range ContentTypes {
+		if ct == mt {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+// Decode reads the rows of the file. A row which cannot be read is returned with the error,
+// while a file which cannot be read as a whole is an error.
+func Decode(r io.Reader, f Format) ([]dto.ImportUserRow, error) {
+	switch f {
+	case FormatCSV:
+		return decodeCSV(r)
+	case FormatNDJSON:
+		return decodeNDJSON(r)
+	}
+	return nil, fmt.Errorf("unknown format %q: %w", f, ErrInvalidFile)
+}
+
+func decodeCSV(r io.Reader) ([]dto.ImportUserRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("the header is missing: %w", ErrInvalidFile)
+		}
+		return nil, errors.Join(ErrInvalidFile, err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "name" && name != "email" {
+			return nil, fmt.Errorf("unknown column %q: %w", name, ErrInvalidFile)
+		}
+		columns[name] = i
+	}
+	if len(columns) != 2 {
+		return nil, fmt.Errorf("the header must be name and email: %w", ErrInvalidFile)
+	}
+
+	var rows []dto.ImportUserRow
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		var pe *csv.ParseError
+		if err != nil && !errors.As(err, &pe) {
+			return nil, err
+		}
+		if len(rows) == MaxRows {
+			return nil, fmt.Errorf("exceeds the max rows %d: %w", MaxRows, ErrInvalidFile)
+		}
+
+		if pe != nil {
+			rows = append(rows, dto.ImportUserRow{Line: pe.StartLine, Err: pe.Err})
+			continue
+		}
+
+		line, _ := cr.FieldPos(0)
+		row := dto.ImportUserRow{Line: line}
+		switch {
+		case len(record) != len(header):
+			row.Err = fmt.Errorf("the row has %d columns, but the header has %d", len(record), len(header))
+		default:
+			row.Name = record[columns["name"]]
+			row.Email = record[columns["email"]]
+		}
+		rows = append(rows, row)
+	}
+}
+
+func decodeNDJSON(r io.Reader) ([]dto.ImportUserRow, error) {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	var rows []dto.ImportUserRow
+	for line := 1; s.Scan(); line++ {
+		b := bytes.TrimSpace(s.Bytes())
+		if len(b) == 0 {
+			continue
+		}
+		if len(rows) == MaxRows {
+			return nil, fmt.Errorf("exceeds the max rows %d: %w", MaxRows, ErrInvalidFile)
+		}
+
+		var v struct {
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		}
+		d := json.NewDecoder(bytes.NewReader(b))
+		d.DisallowUnknownFields()
+		row := dto.ImportUserRow{Line: line}
+		if err := d.Decode(&v); err != nil {
+			row.Err = err
+		} else {
+			row.Name = v.Name
+			row.Email = v.Email
+		}
+		rows = append(rows, row)
+	}
+	if err := s.Err(); err != nil {
+		return nil, errors.Join(ErrInvalidFile, err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,137 @@
+package userimport_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/userimport"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestFormatOf(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		want        userimport.Format
+		wantOK      bool
+	}{
+		{
+			name:        "Returns CSV",
+			contentType: "text/csv; charset=utf-8",
+			want:        userimport.FormatCSV,
+			wantOK:      true,
+		},
+		{
+			name:        "Returns NDJSON",
+			contentType: "application/x-ndjson",
+			want:        userimport.FormatNDJSON,
+			wantOK:      true,
+		},
+		{
+			name:        "Returns false for an unknown content type",
+			contentType: "application/json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := userimport.FormatOf(tt.contentType)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("userimport.FormatOf(%q)=%q, %t; want %q, %t", tt.contentType, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		format  userimport.Format
+		want    []dto.ImportUserRow
+		wantErr error
+	}{
+		{
+			name:   "Decodes CSV",
+			body:   "email, name\nTEST_USER_EMAIL_1,TEST_USER_NAME_1\n\nTEST_USER_EMAIL_2,TEST_USER_NAME_2\n",
+			format: userimport.FormatCSV,
+			want: []dto.ImportUserRow{
+				{Line: 2, Name: "TEST_USER_NAME_1", Email: "TEST_USER_EMAIL_1"},
+				{Line: 4, Name: "TEST_USER_NAME_2", Email: "TEST_USER_EMAIL_2"},
+			},
+		},
+		{
+			name:   "Decodes CSV with the errors of the rows",
+			body:   "name,email\nTEST_USER_NAME_1\n\"TEST_USER_NAME_2,TEST_USER_EMAIL_2\n",
+			format: userimport.FormatCSV,
+			want: []dto.ImportUserRow{
+				{Line: 2, Err: errors.New("the row has 1 columns, but the header has 2")},
+				{Line: 3, Err: errors.New("extraneous or missing \" in quoted-field")},
+			},
+		},
+		{
+			name:    "Returns error if the CSV header is missing",
+			body:    "",
+			format:  userimport.FormatCSV,
+			wantErr: userimport.ErrInvalidFile,
+		},
+		{
+			name:    "Returns error if the CSV header has an unknown column",
+			body:    "name,email,age\n",
+			format:  userimport.FormatCSV,
+			wantErr: userimport.ErrInvalidFile,
+		},
+		{
+			name:    "Returns error if the CSV header lacks a column",
+			body:    "name\n",
+			format:  userimport.FormatCSV,
+			wantErr: userimport.ErrInvalidFile,
+		},
+		{
+			name: "Decodes NDJSON",
+			body: `{"name":"TEST_USER_NAME_1","email":"TEST_USER_EMAIL_1"}` + "\n\n" +
+				`{"name":"TEST_USER_NAME_2","email":"TEST_USER_EMAIL_2","age":1}` + "\n" +
+				`{"name":`,
+			format: userimport.FormatNDJSON,
+			want: []dto.ImportUserRow{
+				{Line: 1, Name: "TEST_USER_NAME_1", Email: "TEST_USER_EMAIL_1"},
+				{Line: 3, Err: errors.New(`json: unknown field "age"`)},
+				{Line: 4, Err: errors.New("unexpected EOF")},
+			},
+		},
+		{
+			name:    "Returns error if the format is unknown",
+			format:  "TEST_FORMAT",
+			wantErr: userimport.ErrInvalidFile,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := userimport.Decode(strings.NewReader(tt.body), tt.format)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("userimport.Decode(%q, %s)=_, %v; want _, %v", tt.body, tt.format, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want, cmp.Comparer(equalErrors)); diff != "" {
+				t.Errorf(
+					"userimport.Decode(%q, %s)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+					tt.body, tt.format, got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
+
+func equalErrors(x, y error) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	return x.Error() == y.Error()
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+type WebhookHandler struct {
+	uc usecase.WebhookUsecase
+}
+
+func NewWebhookHandler(uc usecase.WebhookUsecase) *WebhookHandler {
+	return &WebhookHandler{uc: uc}
+}
+
+type (
+	CreateWebhookSubscriptionRequest struct {
+		URL        string   `json:"url"`
+		EventTypes []string `json:"eventTypes"`
+		Secret     string   `json:"secret"`
+	}
+
+	CreateWebhookSubscriptionResponse struct {
+		WebhookSubscription response.WebhookSubscription `json:"webhookSubscription"`
+	}
+)
+
+func (h *WebhookHandler) CreateWebhookSubscription(c echo.Context) error {
+	req := &CreateWebhookSubscriptionRequest{}
+	if err := c.Bind(req); err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	in := &dto.CreateWebhookSubscriptionInput{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+	}
+	out, err := h.uc.CreateWebhookSubscription(in)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidWebhookSubscriptionInput) {
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.Created(c, &CreateWebhookSubscriptionResponse{
+		WebhookSubscription: response.ToWebhookSubscriptionFromDTO(out.WebhookSubscription),
+	})
+}
+
+type (
+	GetWebhookSubscriptionResponse struct {
+		WebhookSubscription response.WebhookSubscription `json:"webhookSubscription"`
+	}
+)
+
+func (h *WebhookHandler) GetWebhookSubscription(c echo.Context) error {
+	in := &dto.GetWebhookSubscriptionInput{
+		WebhookSubscriptionID: c.Param("id"),
+	}
+
+	out, err := h.uc.GetWebhookSubscription(in)
+	if err != nil {
+		if errors.Is(err, usecase.ErrWebhookSubscriptionNotFound) {
+			return response.Error(c, response.ErrorCodeWebhookSubscriptionNotFound, http.StatusNotFound, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.OK(c, &GetWebhookSubscriptionResponse{
+		WebhookSubscription: response.ToWebhookSubscriptionFromDTO(out.WebhookSubscription),
+	})
+}
+
+type (
+	GetWebhookSubscriptionsResponse struct {
+		WebhookSubscriptions []response.WebhookSubscription `json:"webhookSubscriptions"`
+	}
+)
+
+func (h *WebhookHandler) GetWebhookSubscriptions(c echo.Context) error {
+	out, err := h.uc.GetWebhookSubscriptions(nil)
+	if err != nil {
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.OK(c, &GetWebhookSubscriptionsResponse{
+		WebhookSubscriptions: response.ToWebhookSubscriptionsFromDTO(out.WebhookSubscriptions),
+	})
+}
+
+type (
+	UpdateWebhookSubscriptionRequest struct {
+		URL        string   `json:"url"`
+		EventTypes []string `json:"eventTypes"`
+		Secret     string   `json:"secret,omitempty"`
+	}
+)
+
+func (h *WebhookHandler) UpdateWebhookSubscription(c echo.Context) error {
+	req := &UpdateWebhookSubscriptionRequest{}
+	if err := c.Bind(req); err != nil {
+		return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+	}
+
+	in := &dto.UpdateWebhookSubscriptionInput{
+		WebhookSubscriptionID: c.Param("id"),
+		URL:                   req.URL,
+		EventTypes:            req.EventTypes,
+		Secret:                req.Secret,
+	}
+
+	_, err := h.uc.UpdateWebhookSubscription(in)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidWebhookSubscriptionInput) {
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		}
+		if errors.Is(err, usecase.ErrWebhookSubscriptionNotFound) {
+			return response.Error(c, response.ErrorCodeWebhookSubscriptionNotFound, http.StatusNotFound, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.NoContent(c)
+}
+
+func (h *WebhookHandler) DeleteWebhookSubscription(c echo.Context) error {
+	in := &dto.DeleteWebhookSubscriptionInput{
+		WebhookSubscriptionID: c.Param("id"),
+	}
+
+	_, err := h.uc.DeleteWebhookSubscription(in)
+	if err != nil {
+		if errors.Is(err, usecase.ErrWebhookSubscriptionNotFound) {
+			return response.Error(c, response.ErrorCodeWebhookSubscriptionNotFound, http.StatusNotFound, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.NoContent(c)
+}
+
+type (
+	GetWebhookDeliveriesResponse struct {
+		WebhookDeliveries []response.WebhookDelivery `json:"webhookDeliveries"`
+	}
+)
+
+func (h *WebhookHandler) GetWebhookDeliveries(c echo.Context) error {
+	in := &dto.GetWebhookDeliveriesInput{
+		WebhookSubscriptionID: c.Param("id"),
+		Status:                c.QueryParam("status"),
+	}
+
+	out, err := h.uc.GetWebhookDeliveries(in)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidWebhookDeliveryInput) {
+			return response.Error(c, response.ErrorCodeInvalidArguments, http.StatusBadRequest, err)
+		}
+		if errors.Is(err, usecase.ErrWebhookSubscriptionNotFound) {
+			return response.Error(c, response.ErrorCodeWebhookSubscriptionNotFound, http.StatusNotFound, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.OK(c, &GetWebhookDeliveriesResponse{
+		WebhookDeliveries: response.ToWebhookDeliveriesFromDTO(out.WebhookDeliveries),
+	})
+}
+
+type (
+	RedeliverWebhookResponse struct {
+		WebhookDelivery response.WebhookDelivery `json:"webhookDelivery"`
+	}
+)
+
+func (h *WebhookHandler) RedeliverWebhook(c echo.Context) error {
+	in := &dto.RedeliverWebhookInput{
+		WebhookSubscriptionID: c.Param("id"),
+		WebhookDeliveryID:     c.Param("deliveryId"),
+	}
+
+	out, err := h.uc.RedeliverWebhook(in)
+	if err != nil {
+		if errors.Is(err, usecase.ErrWebhookDeliveryNotFound) {
+			return response.Error(c, response.ErrorCodeWebhookDeliveryNotFound, http.StatusNotFound, err)
+		}
+		return response.ErrorInternal(c, err)
+	}
+
+	return response.OK(c, &RedeliverWebhookResponse{
+		WebhookDelivery: response.ToWebhookDeliveryFromDTO(out.WebhookDelivery),
+	})
+}
@@ -0,0 +1,310 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/labstack/echo"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/handler"
+	"github.com/toshiykst/go-layerd-architecture/app/handler/response"
+	mockusecase "github.com/toshiykst/go-layerd-architecture/app/mock/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestWebhookHandler_CreateWebhookSubscription(t *testing.T) {
+	tests := []struct {
+		name              string
+		req               *handler.CreateWebhookSubscriptionRequest
+		newWebhookUsecase func(ctrl *gomock.Controller) usecase.WebhookUsecase
+		wantStatus        int
+		wantRes           *handler.CreateWebhookSubscriptionResponse
+		wantErrRes        *response.ErrorResponse
+	}{
+		{
+			name: "Create a webhook subscription and returns the webhook subscription response",
+			req: &handler.CreateWebhookSubscriptionRequest{
+				URL:        "https://example.com/hooks",
+				EventTypes: []string{"UserCreated"},
+				Secret:     "TEST_SECRET",
+			},
+			newWebhookUsecase: func(ctrl *gomock.Controller) usecase.WebhookUsecase {
+				uc := mockusecase.NewMockWebhookUsecase(ctrl)
+				uc.EXPECT().
+					CreateWebhookSubscription(&dto.CreateWebhookSubscriptionInput{
+						URL:        "https://example.com/hooks",
+						EventTypes: []string{"UserCreated"},
+						Secret:     "TEST_SECRET",
+					}).
+					Return(&dto.CreateWebhookSubscriptionOutput{
+						WebhookSubscription: dto.WebhookSubscription{
+							WebhookSubscriptionID: "TEST_WEBHOOK_SUBSCRIPTION_ID",
+							URL:                   "https://example.com/hooks",
+							EventTypes:            []string{"UserCreated"},
+						},
+					}, nil)
+				return uc
+			},
+			wantStatus: http.StatusCreated,
+			wantRes: &handler.CreateWebhookSubscriptionResponse{
+				WebhookSubscription: response.WebhookSubscription{
+					WebhookSubscriptionID: "TEST_WEBHOOK_SUBSCRIPTION_ID",
+					URL:                   "https://example.com/hooks",
+					EventTypes:            []string{"UserCreated"},
+				},
+			},
+		},
+		{
+			name: "Returns invalid arguments error response when the input is invalid",
+			req: &handler.CreateWebhookSubscriptionRequest{
+				URL: "not a url",
+			},
+			newWebhookUsecase: func(ctrl *gomock.Controller) usecase.WebhookUsecase {
+				uc := mockusecase.NewMockWebhookUsecase(ctrl)
+				uc.EXPECT().
+					CreateWebhookSubscription(gomock.Any()).
+					Return(nil, usecase.ErrInvalidWebhookSubscriptionInput)
+				return uc
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInvalidArguments,
+				Status:  http.StatusBadRequest,
+				Message: usecase.ErrInvalidWebhookSubscriptionInput.Error(),
+			},
+		},
+		{
+			name: "Returns internal server error response",
+			req:  &handler.CreateWebhookSubscriptionRequest{},
+			newWebhookUsecase: func(ctrl *gomock.Controller) usecase.WebhookUsecase {
+				uc := mockusecase.NewMockWebhookUsecase(ctrl)
+				uc.EXPECT().
+					CreateWebhookSubscription(gomock.Any()).
+					Return(nil, errors.New("an error occurred"))
+				return uc
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeInternalServerError,
+				Status:  http.StatusInternalServerError,
+				Message: "an error occurred",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqJson, _ := json.Marshal(tt.req)
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"https://example.com:8080/webhooks",
+				bytes.NewBuffer(reqJson),
+			)
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			uc := tt.newWebhookUsecase(ctrl)
+
+			h := handler.NewWebhookHandler(uc)
+
+			err := h.CreateWebhookSubscription(c)
+			if err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			res := rec.Result()
+
+			wantStatusCode := tt.wantStatus
+			gotStatusCode := res.StatusCode
+			if gotStatusCode != wantStatusCode {
+				t.Errorf("statusCode got = %d, want = %d", gotStatusCode, wantStatusCode)
+			}
+
+			resBody, err := io.ReadAll(rec.Body)
+			if err != nil {
+				t.Fatalf("Failed to read body: %s", err.Error())
+			}
+			defer func(Body io.ReadCloser) {
+				if err := Body.Close(); err != nil {
+					t.Fatalf("Failed to close body: %s", err.Error())
+				}
+			}(res.Body)
+
+			if tt.wantRes != nil {
+				var got *handler.CreateWebhookSubscriptionResponse
+				_ = json.Unmarshal(resBody, &got)
+				if diff := cmp.Diff(got, tt.wantRes); diff != "" {
+					t.Errorf(
+						"response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantRes, diff,
+					)
+				}
+			}
+
+			if tt.wantErrRes != nil {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(resBody, &got)
+				if diff := cmp.Diff(got, tt.wantErrRes); diff != "" {
+					t.Errorf(
+						"error response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantErrRes, diff,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_RedeliverWebhook(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name              string
+		id                string
+		deliveryID        string
+		newWebhookUsecase func(ctrl *gomock.Controller) usecase.WebhookUsecase
+		wantStatus        int
+		wantRes           *handler.RedeliverWebhookResponse
+		wantErrRes        *response.ErrorResponse
+	}{
+		{
+			name:       "Redeliver a webhook and returns the webhook delivery response",
+			id:         "TEST_WEBHOOK_SUBSCRIPTION_ID",
+			deliveryID: "TEST_WEBHOOK_DELIVERY_ID",
+			newWebhookUsecase: func(ctrl *gomock.Controller) usecase.WebhookUsecase {
+				uc := mockusecase.NewMockWebhookUsecase(ctrl)
+				uc.EXPECT().
+					RedeliverWebhook(&dto.RedeliverWebhookInput{
+						WebhookSubscriptionID: "TEST_WEBHOOK_SUBSCRIPTION_ID",
+						WebhookDeliveryID:     "TEST_WEBHOOK_DELIVERY_ID",
+					}).
+					Return(&dto.RedeliverWebhookOutput{
+						WebhookDelivery: dto.WebhookDelivery{
+							WebhookDeliveryID:     "TEST_WEBHOOK_DELIVERY_ID",
+							WebhookSubscriptionID: "TEST_WEBHOOK_SUBSCRIPTION_ID",
+							EventID:               "TEST_EVENT_ID",
+							EventType:             "UserCreated",
+							Status:                "PENDING",
+							Attempts:              8,
+							LastStatusCode:        http.StatusInternalServerError,
+							LastError:             "webhook responded with status 500",
+							OccurredAt:            occurredAt,
+							NextAttemptAt:         occurredAt,
+						},
+					}, nil)
+				return uc
+			},
+			wantStatus: http.StatusOK,
+			wantRes: &handler.RedeliverWebhookResponse{
+				WebhookDelivery: response.WebhookDelivery{
+					WebhookDeliveryID:     "TEST_WEBHOOK_DELIVERY_ID",
+					WebhookSubscriptionID: "TEST_WEBHOOK_SUBSCRIPTION_ID",
+					EventID:               "TEST_EVENT_ID",
+					EventType:             "UserCreated",
+					Status:                "PENDING",
+					Attempts:              8,
+					LastStatusCode:        http.StatusInternalServerError,
+					LastError:             "webhook responded with status 500",
+					OccurredAt:            occurredAt,
+					NextAttemptAt:         occurredAt,
+				},
+			},
+		},
+		{
+			name:       "Returns webhook delivery not found error response",
+			id:         "TEST_WEBHOOK_SUBSCRIPTION_ID",
+			deliveryID: "TEST_WEBHOOK_DELIVERY_ID",
+			newWebhookUsecase: func(ctrl *gomock.Controller) usecase.WebhookUsecase {
+				uc := mockusecase.NewMockWebhookUsecase(ctrl)
+				uc.EXPECT().
+					RedeliverWebhook(gomock.Any()).
+					Return(nil, usecase.ErrWebhookDeliveryNotFound)
+				return uc
+			},
+			wantStatus: http.StatusNotFound,
+			wantErrRes: &response.ErrorResponse{
+				Code:    response.ErrorCodeWebhookDeliveryNotFound,
+				Status:  http.StatusNotFound,
+				Message: usecase.ErrWebhookDeliveryNotFound.Error(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "https://example.com:8080", nil)
+
+			rec := httptest.NewRecorder()
+
+			e := echo.New()
+			c := e.NewContext(req, rec)
+			c.SetPath("/webhooks/:id/deliveries/:deliveryId/redeliver")
+			c.SetParamNames("id", "deliveryId")
+			c.SetParamValues(tt.id, tt.deliveryID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			uc := tt.newWebhookUsecase(ctrl)
+
+			h := handler.NewWebhookHandler(uc)
+
+			err := h.RedeliverWebhook(c)
+			if err != nil {
+				t.Fatalf("want no err, but has error: %v", err)
+			}
+
+			res := rec.Result()
+
+			wantStatusCode := tt.wantStatus
+			gotStatusCode := res.StatusCode
+			if gotStatusCode != wantStatusCode {
+				t.Errorf("statusCode got = %d, want = %d", gotStatusCode, wantStatusCode)
+			}
+
+			resBody, err := io.ReadAll(rec.Body)
+			if err != nil {
+				t.Fatalf("Failed to read body: %s", err.Error())
+			}
+			defer func(Body io.ReadCloser) {
+				if err := Body.Close(); err != nil {
+					t.Fatalf("Failed to close body: %s", err.Error())
+				}
+			}(res.Body)
+
+			if tt.wantRes != nil {
+				var got *handler.RedeliverWebhookResponse
+				_ = json.Unmarshal(resBody, &got)
+				if diff := cmp.Diff(got, tt.wantRes); diff != "" {
+					t.Errorf(
+						"response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantRes, diff,
+					)
+				}
+			}
+
+			if tt.wantErrRes != nil {
+				var got *response.ErrorResponse
+				_ = json.Unmarshal(resBody, &got)
+				if diff := cmp.Diff(got, tt.wantErrRes); diff != "" {
+					t.Errorf(
+						"error response body: got = %v, want = %v\ndiffers: (-got +want)\n%s",
+						got, tt.wantErrRes, diff,
+					)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"gorm.io/gorm"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+type dbAttributeSchemaRepository struct {
+	db *gorm.DB
+}
+
+func (r *dbAttributeSchemaRepository) Find(target model.AttributeTarget, key string) (*model.AttributeSchema, error) {
+	var dms datamodel.AttributeSchema
+	if err := r.db.Where("target = ? AND `key` = ?", target, key).First(&dms).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dms.ToModel(), nil
+}
+
+func (r *dbAttributeSchemaRepository) List(f repository.AttributeSchemaListFilter) (model.AttributeSchemas, error) {
+	db := r.db
+	if f.Target != "" {
+		db = db.Where("target = ?", f.Target)
+	}
+
+	var dmss datamodel.AttributeSchemas
+	if err := db.Order("target").Order("`key`").Find(&dmss).Error; err != nil {
+		return nil, err
+	}
+
+	return dmss.ToModel(), nil
+}
+
+func (r *dbAttributeSchemaRepository) Save(s *model.AttributeSchema) error {
+	return r.db.Save(datamodel.NewAttributeSchema(s)).Error
+}
+
+func (r *dbAttributeSchemaRepository) Delete(target model.AttributeTarget, key string) error {
+	return r.db.Where("target = ? AND `key` = ?", target, key).Delete(&datamodel.AttributeSchema{}).Error
+}
+
+// whereAttributes adds the conditions of the rows whose attributes column has all the values in their string form,
+// as model's Attributes.Matches compares them.
+func whereAttributes(db *gorm.DB, values map[string]string) (*gorm.DB, error) {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		// The key is put in the JSON path as it is, which a valid key is safe in.
+		if !model.IsValidAttributeKey(k) {
+			return nil, fmt.Errorf("invalid attribute key %q", k)
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		db = db.Where("JSON_UNQUOTE(JSON_EXTRACT(attributes, ?)) = ?", "$."+k, values[k])
+	}
+	return db, nil
+}
@@ -0,0 +1,119 @@
+package database_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database"
+)
+
+func TestDatabase_dbAttributeSchemaRepository_List(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   repository.AttributeSchemaListFilter
+		wantSQL  string
+		wantKeys []string
+		wantErr  error
+		dbErr    error
+	}{
+		{
+			name:     "Returns the schemas of the target",
+			filter:   repository.AttributeSchemaListFilter{Target: model.AttributeTargetUser},
+			wantSQL:  "SELECT * FROM `attribute_schemas` WHERE target = ? ORDER BY target,`key`",
+			wantKeys: []string{"department", "level"},
+		},
+		{
+			name:    "Error",
+			filter:  repository.AttributeSchemaListFilter{},
+			wantSQL: "SELECT * FROM `attribute_schemas` ORDER BY target,`key`",
+			wantErr: errors.New("an error occurred"),
+			dbErr:   errors.New("an error occurred"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db := dbMock(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			defer sqlDB.Close()
+
+			expectQuery := mock.ExpectQuery(regexp.QuoteMeta(tt.wantSQL))
+			if tt.filter.Target != "" {
+				expectQuery.WithArgs(tt.filter.Target)
+			}
+			if tt.dbErr != nil {
+				expectQuery.WillReturnError(tt.dbErr)
+			} else {
+				expectQuery.WillReturnRows(
+					sqlmock.NewRows([]string{"target", "key", "type", "required", "enum", "pattern"}).
+						AddRow("USER", "department", "STRING", true, `["sales","engineering"]`, "").
+						AddRow("USER", "level", "NUMBER", false, nil, ""),
+				)
+			}
+
+			r := &database.DBAttributeSchemaRepository{}
+			r.SetDB(db)
+
+			got, err := r.List(tt.filter)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("want an error, but has no error")
+				}
+				if err.Error() != tt.wantErr.Error() {
+					t.Errorf("r.List(%v)=_, %v; want _, %v", tt.filter, err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("want no err, but has error %v", err)
+				}
+				if len(got) != len(tt.wantKeys) {
+					t.Fatalf("len(got)=%d; want %d", len(got), len(tt.wantKeys))
+				}
+				for i, s := range got {
+					if s.Key() != tt.wantKeys[i] {
+						t.Errorf("got[%d].Key()=%s; want %s", i, s.Key(), tt.wantKeys[i])
+					}
+				}
+				if enum := got[0].Enum(); len(enum) != 2 || enum[1] != "engineering" {
+					t.Errorf("got[0].Enum()=%v; want [sales engineering]", enum)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDatabase_dbAttributeSchemaRepository_Delete(t *testing.T) {
+	mock, db := dbMock(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	defer sqlDB.Close()
+
+	mock.
+		ExpectExec(regexp.QuoteMeta("DELETE FROM `attribute_schemas` WHERE target = ? AND `key` = ?")).
+		WithArgs(model.AttributeTargetGroup, "cost_center").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r := &database.DBAttributeSchemaRepository{}
+	r.SetDB(db)
+
+	if err := r.Delete(model.AttributeTargetGroup, "cost_center"); err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+type dbAuditEventRepository struct {
+	db *gorm.DB
+}
+
+func (r *dbAuditEventRepository) List(f repository.AuditEventListFilter) (model.AuditEvents, error) {
+	db := r.db
+	if f.Actor != "" {
+		db = db.Where("actor = ?", f.Actor)
+	}
+	if f.TargetType != "" {
+		db = db.Where("target_type = ?", f.TargetType)
+	}
+	if f.TargetID != "" {
+		db = db.Where("target_id = ?", f.TargetID)
+	}
+	if !f.From.IsZero() {
+		db = db.Where("occurred_at >= ?", f.From)
+	}
+	if !f.To.IsZero() {
+		db = db.Where("occurred_at < ?", f.To)
+	}
+
+	var dmes datamodel.AuditEvents
+	if err := db.Order("occurred_at").Find(&dmes).Error; err != nil {
+		return nil, err
+	}
+
+	return dmes.ToModel(), nil
+}
+
+func (r *dbAuditEventRepository) Create(e *model.AuditEvent) (*model.AuditEvent, error) {
+	dme := datamodel.NewAuditEvent(e)
+	if err := r.db.Create(dme).Error; err != nil {
+		return nil, err
+	}
+	return dme.ToModel(), nil
+}
@@ -0,0 +1,232 @@
+package database_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database"
+)
+
+func TestDatabase_dbAuditEventRepository_List(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC)
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name     string
+		filter   repository.AuditEventListFilter
+		want     model.AuditEvents
+		wantSQL  string
+		wantArgs []any
+		wantErr  error
+		dbErr    error
+	}{
+		{
+			name:   "Returns audit events",
+			filter: repository.AuditEventListFilter{},
+			want: model.AuditEvents{
+				model.MustNewAuditEvent(
+					"TEST_AUDIT_EVENT_ID",
+					"TEST_ACTOR",
+					model.AuditActionCreateUser,
+					model.NewUserAuditTarget("TEST_USER_ID"),
+					[]model.AuditChange{{Field: "name", After: "TEST_USER_NAME"}},
+					"TEST_REQUEST_ID",
+					occurredAt,
+				),
+			},
+			wantSQL: "SELECT * FROM `audit_events` ORDER BY occurred_at",
+		},
+		{
+			name: "Returns audit events filtered by all conditions",
+			filter: repository.AuditEventListFilter{
+				Actor:      "TEST_ACTOR",
+				TargetType: model.AuditTargetTypeUser,
+				TargetID:   "TEST_USER_ID",
+				From:       from,
+				To:         to,
+			},
+			want: model.AuditEvents{
+				model.MustNewAuditEvent(
+					"TEST_AUDIT_EVENT_ID",
+					"TEST_ACTOR",
+					model.AuditActionCreateUser,
+					model.NewUserAuditTarget("TEST_USER_ID"),
+					[]model.AuditChange{{Field: "name", After: "TEST_USER_NAME"}},
+					"TEST_REQUEST_ID",
+					occurredAt,
+				),
+			},
+			wantSQL: "SELECT * FROM `audit_events` WHERE actor = ? AND target_type = ? AND target_id = ? " +
+				"AND occurred_at >= ? AND occurred_at < ? ORDER BY occurred_at",
+			wantArgs: []any{"TEST_ACTOR", model.AuditTargetTypeUser, "TEST_USER_ID", from, to},
+		},
+		{
+			name:    "Error",
+			filter:  repository.AuditEventListFilter{},
+			wantSQL: "SELECT * FROM `audit_events` ORDER BY occurred_at",
+			wantErr: errors.New("an error occurred"),
+			dbErr:   errors.New("an error occurred"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db := dbMock(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			defer sqlDB.Close()
+
+			expectQuery := mock.ExpectQuery(regexp.QuoteMeta(tt.wantSQL))
+			if len(tt.wantArgs) > 0 {
+				expectQuery = expectQuery.WithArgs(toDriverValues(t, tt.wantArgs...)...)
+			}
+
+			if tt.dbErr != nil {
+				expectQuery.WillReturnError(tt.dbErr)
+			} else {
+				rows := sqlmock.NewRows([]string{
+					"id", "actor", "action", "target_type", "target_id", "changes", "request_id", "occurred_at",
+				})
+				for _, e := range tt.want {
+					rows.AddRow(
+						e.ID(),
+						e.Actor(),
+						e.Action(),
+						e.Target().Type,
+						e.Target().ID,
+						`[{"field":"name","before":"","after":"TEST_USER_NAME"}]`,
+						e.RequestID(),
+						e.OccurredAt(),
+					)
+				}
+				expectQuery.WillReturnRows(rows)
+			}
+
+			r := &database.DBAuditEventRepository{}
+			r.SetDB(db)
+
+			got, err := r.List(tt.filter)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("want an error, but has no error")
+				}
+				if err.Error() != tt.wantErr.Error() {
+					t.Errorf("r.List(%v)=_, %v; want _, %v", tt.filter, err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("want no err, but has error %v", err)
+				}
+				if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(model.AuditEvent{})); diff != "" {
+					t.Errorf(
+						"r.List(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+						tt.filter, got, tt.want, diff,
+					)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDatabase_dbAuditEventRepository_Create(t *testing.T) {
+	e := model.MustNewAuditEvent(
+		"TEST_AUDIT_EVENT_ID",
+		"TEST_ACTOR",
+		model.AuditActionCreateUser,
+		model.NewUserAuditTarget("TEST_USER_ID"),
+		[]model.AuditChange{{Field: "name", After: "TEST_USER_NAME"}},
+		"TEST_REQUEST_ID",
+		time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+	)
+	tests := []struct {
+		name    string
+		e       *model.AuditEvent
+		want    *model.AuditEvent
+		wantErr error
+		dbErr   error
+	}{
+		{
+			name: "Creates a new audit event",
+			e:    e,
+			want: e,
+		},
+		{
+			name:    "Error",
+			e:       e,
+			wantErr: errors.New("an error occurred"),
+			dbErr:   errors.New("an error occurred"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db := dbMock(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			defer sqlDB.Close()
+
+			sql := "INSERT INTO `audit_events` " +
+				"(`id`,`actor`,`action`,`target_type`,`target_id`,`changes`,`request_id`,`occurred_at`) " +
+				"VALUES (?,?,?,?,?,?,?,?)"
+			expectExec := mock.
+				ExpectExec(regexp.QuoteMeta(sql)).
+				WithArgs(
+					tt.e.ID(),
+					tt.e.Actor(),
+					tt.e.Action(),
+					tt.e.Target().Type,
+					tt.e.Target().ID,
+					`[{"field":"name","before":"","after":"TEST_USER_NAME"}]`,
+					tt.e.RequestID(),
+					tt.e.OccurredAt(),
+				)
+			if tt.dbErr != nil {
+				expectExec.WillReturnError(tt.dbErr)
+			} else {
+				expectExec.WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			r := &database.DBAuditEventRepository{}
+			r.SetDB(db)
+
+			got, err := r.Create(tt.e)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("want an error, but has no error")
+				}
+				if err.Error() != tt.wantErr.Error() {
+					t.Errorf("r.Create(%v)=_, %v; want _, %v", tt.e, err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("want no err, but has error %v", err)
+				}
+				if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(model.AuditEvent{})); diff != "" {
+					t.Errorf(
+						"r.Create(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+						tt.e, got, tt.want, diff,
+					)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package datamodel
+
+import (
+	"encoding/json"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+type AttributeSchema struct {
+	Target   string `gorm:"primaryKey"`
+	Key      string `gorm:"primaryKey"`
+	Type     string
+	Required bool
+	Enum     []string `gorm:"serializer:json"`
+	Pattern  string
+}
+
+func NewAttributeSchema(s *model.AttributeSchema) *AttributeSchema {
+	return &AttributeSchema{
+		Target:   string(s.Target()),
+		Key:      s.Key(),
+		Type:     string(s.Type()),
+		Required: s.Required(),
+		Enum:     s.Enum(),
+		Pattern:  s.Pattern(),
+	}
+}
+
+func (s *AttributeSchema) ToModel() *model.AttributeSchema {
+	if s == nil {
+		return nil
+	}
+	return model.MustNewAttributeSchema(
+		model.AttributeTarget(s.Target),
+		s.Key,
+		model.AttributeType(s.Type),
+		s.Required,
+		s.Enum,
+		s.Pattern,
+	)
+}
+
+type AttributeSchemas []*AttributeSchema
+
+func (ss AttributeSchemas) ToModel() model.AttributeSchemas {
+	if ss == nil {
+		return nil
+	}
+	mss := make(model.AttributeSchemas, len(ss))
+	for i, s := range ss {
+		mss[i] = s.ToModel()
+	}
+	return mss
+}
+
+// AttributesValue returns the value of the attributes column as the serializer of it stores, for the updates by a map
+// which the serializer is not applied to.
+func AttributesValue(attrs model.Attributes) (any, error) {
+	if len(attrs) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal(map[string]any(attrs))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,61 @@
+package datamodel_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+func TestNewAttributeSchema(t *testing.T) {
+	s := model.MustNewAttributeSchema(
+		model.AttributeTargetUser,
+		"department",
+		model.AttributeTypeString,
+		true,
+		[]string{"sales", "engineering"},
+		"^[a-z]+$",
+	)
+	want := &datamodel.AttributeSchema{
+		Target:   "USER",
+		Key:      "department",
+		Type:     "STRING",
+		Required: true,
+		Enum:     []string{"sales", "engineering"},
+		Pattern:  "^[a-z]+$",
+	}
+
+	got := datamodel.NewAttributeSchema(s)
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("NewAttributeSchema(%v)=%v; want %v\ndiffers: (-got +want)\n%s", s, got, want, diff)
+	}
+
+	if diff := cmp.Diff(datamodel.NewAttributeSchema(got.ToModel()), want); diff != "" {
+		t.Errorf("s.ToModel()=%v; want %v\ndiffers: (-got +want)\n%s", got.ToModel(), s, diff)
+	}
+}
+
+func TestAttributesValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		attrs model.Attributes
+		want  any
+	}{
+		{name: "Returns nil for no attributes", attrs: nil, want: nil},
+		{name: "Returns the attributes as JSON", attrs: model.Attributes{"level": 2.0, "department": "sales"}, want: `{"department":"sales","level":2}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := datamodel.AttributesValue(tt.attrs)
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("AttributesValue(%v)=%v, nil; want %v, nil", tt.attrs, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package datamodel
+
+import (
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+type AuditEvent struct {
+	ID         string `gorm:"primaryKey"`
+	Actor      string
+	Action     string
+	TargetType string
+	TargetID   string
+	Changes    AuditChanges `gorm:"serializer:json"`
+	RequestID  string
+	OccurredAt time.Time
+}
+
+type AuditChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+type AuditChanges []AuditChange
+
+func NewAuditEvent(e *model.AuditEvent) *AuditEvent {
+	changes := make(AuditChanges, len(e.Changes()))
+	for i, c := range e.Changes() {
+		changes[i] = AuditChange{
+			Field:  c.Field,
+			Before: c.Before,
+			After:  c.After,
+		}
+	}
+	return &AuditEvent{
+		ID:         string(e.ID()),
+		Actor:      e.Actor(),
+		Action:     string(e.Action()),
+		TargetType: string(e.Target().Type),
+		TargetID:   e.Target().ID,
+		Changes:    changes,
+		RequestID:  e.RequestID(),
+		OccurredAt: e.OccurredAt(),
+	}
+}
+
+func (e *AuditEvent) ToModel() *model.AuditEvent {
+	if e == nil {
+		return nil
+	}
+	var changes []model.AuditChange
+	if len(e.Changes) > 0 {
+		changes = make([]model.AuditChange, len(e.Changes))
+		for i, c := range e.Changes {
+			changes[i] = model.AuditChange{
+				Field:  c.Field,
+				Before: c.Before,
+				After:  c.After,
+			}
+		}
+	}
+	return model.MustNewAuditEvent(
+		model.AuditEventID(e.ID),
+		e.Actor,
+		model.AuditAction(e.Action),
+		model.AuditTarget{
+			Type: model.AuditTargetType(e.TargetType),
+			ID:   e.TargetID,
+		},
+		changes,
+		e.RequestID,
+		e.OccurredAt,
+	)
+}
+
+type AuditEvents []*AuditEvent
+
+func (es AuditEvents) ToModel() model.AuditEvents {
+	if es == nil {
+		return nil
+	}
+	mes := make(model.AuditEvents, len(es))
+	for i, e := range es {
+		mes[i] = e.ToModel()
+	}
+	return mes
+}
@@ -0,0 +1,106 @@
+package datamodel_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+func TestNewAuditEvent(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name string
+		e    *model.AuditEvent
+		want *datamodel.AuditEvent
+	}{
+		{
+			name: "Creates a datamodel audit event",
+			e: model.MustNewAuditEvent(
+				"TEST_AUDIT_EVENT_ID",
+				"TEST_ACTOR",
+				model.AuditActionUpdateUser,
+				model.NewUserAuditTarget("TEST_USER_ID"),
+				[]model.AuditChange{{Field: "name", Before: "TEST_USER_NAME", After: "TEST_USER_NAME_UPDATED"}},
+				"TEST_REQUEST_ID",
+				occurredAt,
+			),
+			want: &datamodel.AuditEvent{
+				ID:         "TEST_AUDIT_EVENT_ID",
+				Actor:      "TEST_ACTOR",
+				Action:     "UPDATE_USER",
+				TargetType: "USER",
+				TargetID:   "TEST_USER_ID",
+				Changes: datamodel.AuditChanges{
+					{Field: "name", Before: "TEST_USER_NAME", After: "TEST_USER_NAME_UPDATED"},
+				},
+				RequestID:  "TEST_REQUEST_ID",
+				OccurredAt: occurredAt,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := datamodel.NewAuditEvent(tt.e)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(
+					"NewAuditEvent(%v)=%v; want %v\ndiffers: (-got +want)\n%s",
+					tt.e, got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
+
+func TestAuditEvent_ToModel(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name string
+		e    *datamodel.AuditEvent
+		want *model.AuditEvent
+	}{
+		{
+			name: "Convert to model.AuditEvent",
+			e: &datamodel.AuditEvent{
+				ID:         "TEST_AUDIT_EVENT_ID",
+				Actor:      "TEST_ACTOR",
+				Action:     "DELETE_GROUP",
+				TargetType: "GROUP",
+				TargetID:   "TEST_GROUP_ID",
+				Changes: datamodel.AuditChanges{
+					{Field: "name", Before: "TEST_GROUP_NAME"},
+				},
+				RequestID:  "TEST_REQUEST_ID",
+				OccurredAt: occurredAt,
+			},
+			want: model.MustNewAuditEvent(
+				"TEST_AUDIT_EVENT_ID",
+				"TEST_ACTOR",
+				model.AuditActionDeleteGroup,
+				model.NewGroupAuditTarget("TEST_GROUP_ID"),
+				[]model.AuditChange{{Field: "name", Before: "TEST_GROUP_NAME"}},
+				"TEST_REQUEST_ID",
+				occurredAt,
+			),
+		},
+		{
+			name: "Returns nil when the receiver is nil",
+			e:    nil,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.e.ToModel()
+			if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(model.AuditEvent{})); diff != "" {
+				t.Errorf(
+					"e.ToModel()=%v; want=%v,receiver=%v\ndiffers: (-got +want)\n%s",
+					got, tt.want, tt.e, diff,
+				)
+			}
+		})
+	}
+}
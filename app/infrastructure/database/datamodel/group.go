@@ -1,28 +1,72 @@
 package datamodel
 
-import "github.com/toshiykst/go-layerd-architecture/app/domain/model"
+import (
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
 
 type Group struct {
-	ID   string `gorm:"primaryKey"`
-	Name string
+	ID         string `gorm:"primaryKey"`
+	Name       string
+	JoinPolicy string
+	Attributes map[string]any `gorm:"serializer:json"`
+	// The timestamps are the ones of the model, which gorm does not set by itself.
+	CreatedAt time.Time `gorm:"autoCreateTime:false"`
+	CreatedBy string
+	UpdatedAt time.Time `gorm:"autoUpdateTime:false"`
+	UpdatedBy string
 }
 
-func NewGroup(gID model.GroupID, name string) *Group {
+func NewGroup(
+	gID model.GroupID,
+	name string,
+	joinPolicy model.GroupJoinPolicy,
+	attrs model.Attributes,
+	ts model.Timestamps,
+) *Group {
 	return &Group{
-		ID:   string(gID),
-		Name: name,
+		ID:         string(gID),
+		Name:       name,
+		JoinPolicy: string(joinPolicy),
+		Attributes: attrs,
+		CreatedAt:  ts.CreatedAt,
+		CreatedBy:  ts.CreatedBy,
+		UpdatedAt:  ts.UpdatedAt,
+		UpdatedBy:  ts.UpdatedBy,
 	}
 }
 
-func (g *Group) ToModel(gus GroupUsers) *model.Group {
+func (g *Group) ToModel(gus GroupUsers, gls GroupLabels) *model.Group {
 	if g == nil {
 		return nil
 	}
-	return model.MustNewGroup(
+	return g.toModel(gus.ModelUserIDs(), gls.ModelLabels())
+}
+
+// toModel returns the group of the users and the labels, whose join policy is the default one if the row has none.
+func (g *Group) toModel(uIDs []model.UserID, ls model.Labels) *model.Group {
+	mg := model.MustNewGroup(
 		model.GroupID(g.ID),
 		g.Name,
-		gus.ModelUserIDs(),
+		uIDs,
 	)
+	if g.JoinPolicy != "" {
+		if err := mg.ChangeJoinPolicy(model.GroupJoinPolicy(g.JoinPolicy)); err != nil {
+			panic(err)
+		}
+	}
+	mg.ChangeAttributes(g.Attributes)
+	if err := mg.ChangeLabels(ls); err != nil {
+		panic(err)
+	}
+	mg.ChangeTimestamps(model.Timestamps{
+		CreatedAt: g.CreatedAt,
+		CreatedBy: g.CreatedBy,
+		UpdatedAt: g.UpdatedAt,
+		UpdatedBy: g.UpdatedBy,
+	})
+	return mg
 }
 
 type Groups []*Group
@@ -38,18 +82,15 @@ func (gs Groups) IDs() []string {
 	return gIDs
 }
 
-func (gs Groups) ToModel(gus GroupUsers) model.Groups {
+func (gs Groups) ToModel(gus GroupUsers, gls GroupLabels) model.Groups {
 	if gs == nil {
 		return nil
 	}
 	uIDsByGID := gus.ModelUserIDsByGroupID()
+	lsByGID := gls.ModelLabelsByGroupID()
 	mgs := make(model.Groups, len(gs))
 	for i, g := range gs {
-		mgs[i] = model.MustNewGroup(
-			model.GroupID(g.ID),
-			g.Name,
-			uIDsByGID[g.ID],
-		)
+		mgs[i] = g.toModel(uIDsByGID[g.ID], lsByGID[g.ID])
 	}
 	return mgs
 }
@@ -2,6 +2,7 @@ package datamodel_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -11,8 +12,11 @@ import (
 
 func TestNewGroup(t *testing.T) {
 	type args struct {
-		id   model.GroupID
-		name string
+		id         model.GroupID
+		name       string
+		joinPolicy model.GroupJoinPolicy
+		attrs      model.Attributes
+		ts         model.Timestamps
 	}
 	tests := []struct {
 		name string
@@ -22,23 +26,32 @@ func TestNewGroup(t *testing.T) {
 		{
 			name: "Creates a datamodel user",
 			args: args{
-				id:   model.GroupID("TEST_GROUP_ID"),
-				name: "TEST_GROUP_NAME",
+				id:         model.GroupID("TEST_GROUP_ID"),
+				name:       "TEST_GROUP_NAME",
+				joinPolicy: model.GroupJoinPolicyApproval,
+				attrs:      model.Attributes{"cost_center": 100.0},
+				ts:         model.NewTimestamps("TEST_ACTOR", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
 			},
 			want: &datamodel.Group{
-				ID:   "TEST_GROUP_ID",
-				Name: "TEST_GROUP_NAME",
+				ID:         "TEST_GROUP_ID",
+				Name:       "TEST_GROUP_NAME",
+				JoinPolicy: "APPROVAL",
+				Attributes: map[string]any{"cost_center": 100.0},
+				CreatedAt:  time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				CreatedBy:  "TEST_ACTOR",
+				UpdatedAt:  time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedBy:  "TEST_ACTOR",
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := datamodel.NewGroup(tt.args.id, tt.args.name)
+			got := datamodel.NewGroup(tt.args.id, tt.args.name, tt.args.joinPolicy, tt.args.attrs, tt.args.ts)
 			if diff := cmp.Diff(got, tt.want); diff != "" {
 				t.Errorf(
-					"NewGroup(%s,%s)=%v; want %v\ndiffers: (-got +want)\n%s",
-					tt.args.id, tt.args.name, got, tt.want, diff,
+					"NewGroup(%s,%s,%s)=%v; want %v\ndiffers: (-got +want)\n%s",
+					tt.args.id, tt.args.name, tt.args.joinPolicy, got, tt.want, diff,
 				)
 			}
 		})
@@ -48,6 +61,7 @@ func TestNewGroup(t *testing.T) {
 func TestGroup_ToModel(t *testing.T) {
 	type args struct {
 		gus datamodel.GroupUsers
+		gls datamodel.GroupLabels
 	}
 	tests := []struct {
 		name  string
@@ -87,6 +101,41 @@ func TestGroup_ToModel(t *testing.T) {
 				},
 			),
 		},
+		{
+			name: "Convert to model.Group with the join policy",
+			group: &datamodel.Group{
+				ID:         "TEST_GROUP_ID",
+				Name:       "TEST_GROUP_NAME",
+				JoinPolicy: "INVITE_ONLY",
+			},
+			want: func() *model.Group {
+				g := model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", []model.UserID{})
+				if err := g.ChangeJoinPolicy(model.GroupJoinPolicyInviteOnly); err != nil {
+					panic(err)
+				}
+				return g
+			}(),
+		},
+		{
+			name: "Convert to model.Group with the labels",
+			group: &datamodel.Group{
+				ID:   "TEST_GROUP_ID",
+				Name: "TEST_GROUP_NAME",
+			},
+			args: args{
+				gls: datamodel.GroupLabels{
+					{GroupID: "TEST_GROUP_ID", Key: "team", Value: "payments"},
+					{GroupID: "TEST_GROUP_ID", Key: "env", Value: "prod"},
+				},
+			},
+			want: func() *model.Group {
+				g := model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", []model.UserID{})
+				if err := g.ChangeLabels(model.Labels{"team": "payments", "env": "prod"}); err != nil {
+					panic(err)
+				}
+				return g
+			}(),
+		},
 		{
 			name:  "Returns nil when the receiver is nil",
 			group: nil,
@@ -96,7 +145,7 @@ func TestGroup_ToModel(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := tt.group
-			got := g.ToModel(tt.args.gus)
+			got := g.ToModel(tt.args.gus, tt.args.gls)
 			if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(model.Group{})); diff != "" {
 				t.Errorf(
 					"g.ToModel(%v)=%v; want=%v,receiver=%v\ndiffers: (-got +want)\n%s",
@@ -158,6 +207,7 @@ func TestGroups_IDs(t *testing.T) {
 func TestGroups_ToModel(t *testing.T) {
 	type args struct {
 		gus datamodel.GroupUsers
+		gls datamodel.GroupLabels
 	}
 	tests := []struct {
 		name   string
@@ -196,6 +246,9 @@ func TestGroups_ToModel(t *testing.T) {
 						UserID:  "TEST_USER_ID_3",
 					},
 				},
+				gls: datamodel.GroupLabels{
+					{GroupID: "TEST_GROUP_ID_2", Key: "team", Value: "payments"},
+				},
 			},
 			want: model.Groups{
 				model.MustNewGroup(
@@ -203,11 +256,13 @@ func TestGroups_ToModel(t *testing.T) {
 					"TEST_GROUP_NAME_1",
 					[]model.UserID{"TEST_USER_ID_1"},
 				),
-				model.MustNewGroup(
-					"TEST_GROUP_ID_2",
-					"TEST_GROUP_NAME_2",
-					[]model.UserID{"TEST_USER_ID_2"},
-				),
+				func() *model.Group {
+					g := model.MustNewGroup("TEST_GROUP_ID_2", "TEST_GROUP_NAME_2", []model.UserID{"TEST_USER_ID_2"})
+					if err := g.ChangeLabels(model.Labels{"team": "payments"}); err != nil {
+						panic(err)
+					}
+					return g
+				}(),
 				model.MustNewGroup(
 					"TEST_GROUP_ID_3",
 					"TEST_GROUP_NAME_3",
@@ -227,7 +282,7 @@ func TestGroups_ToModel(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			gs := tt.groups
-			got := gs.ToModel(tt.args.gus)
+			got := gs.ToModel(tt.args.gus, tt.args.gls)
 			if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(model.Group{})); diff != "" {
 				t.Errorf(
 					"gs.ToModel(%v)=%v; want=%v,receiver=%v\ndiffers: (-got +want)\n%s",
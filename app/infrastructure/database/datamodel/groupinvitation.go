@@ -0,0 +1,76 @@
+package datamodel
+
+import (
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+type GroupInvitation struct {
+	ID            string `gorm:"primaryKey"`
+	GroupID       string
+	Inviter       string
+	InviteeUserID string
+	InviteeEmail  string
+	CreatedAt     time.Time
+	ExpiresAt     time.Time
+	Status        string
+	RespondedAt   *time.Time
+}
+
+func NewGroupInvitation(inv *model.GroupInvitation) *GroupInvitation {
+	st := inv.State()
+	var respondedAt *time.Time
+	if !st.RespondedAt.IsZero() {
+		respondedAt = &st.RespondedAt
+	}
+	return &GroupInvitation{
+		ID:            string(inv.ID()),
+		GroupID:       string(inv.GroupID()),
+		Inviter:       inv.Inviter(),
+		InviteeUserID: string(inv.Invitee().UserID),
+		InviteeEmail:  inv.Invitee().Email,
+		CreatedAt:     inv.CreatedAt(),
+		ExpiresAt:     inv.ExpiresAt(),
+		Status:        string(st.Status),
+		RespondedAt:   respondedAt,
+	}
+}
+
+func (inv *GroupInvitation) ToModel() *model.GroupInvitation {
+	if inv == nil {
+		return nil
+	}
+	var respondedAt time.Time
+	if inv.RespondedAt != nil {
+		respondedAt = *inv.RespondedAt
+	}
+	return model.MustNewGroupInvitation(
+		model.GroupInvitationID(inv.ID),
+		model.GroupID(inv.GroupID),
+		inv.Inviter,
+		model.GroupInvitee{
+			UserID: model.UserID(inv.InviteeUserID),
+			Email:  inv.InviteeEmail,
+		},
+		inv.CreatedAt,
+		inv.ExpiresAt,
+		model.GroupInvitationState{
+			Status:      model.GroupInvitationStatus(inv.Status),
+			RespondedAt: respondedAt,
+		},
+	)
+}
+
+type GroupInvitations []*GroupInvitation
+
+func (invs GroupInvitations) ToModel() model.GroupInvitations {
+	if invs == nil {
+		return nil
+	}
+	minvs := make(model.GroupInvitations, len(invs))
+	for i, inv := range invs {
+		minvs[i] = inv.ToModel()
+	}
+	return minvs
+}
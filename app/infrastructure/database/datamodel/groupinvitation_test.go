@@ -0,0 +1,127 @@
+package datamodel_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+func TestNewGroupInvitation(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expiresAt := createdAt.Add(time.Hour)
+	respondedAt := createdAt.Add(time.Minute)
+	tests := []struct {
+		name string
+		inv  *model.GroupInvitation
+		want *datamodel.GroupInvitation
+	}{
+		{
+			name: "Creates a datamodel pending group invitation",
+			inv: model.MustNewGroupInvitation(
+				"TEST_GROUP_INVITATION_ID",
+				"TEST_GROUP_ID",
+				"TEST_ACTOR",
+				model.GroupInvitee{UserID: "TEST_USER_ID"},
+				createdAt,
+				expiresAt,
+				model.GroupInvitationState{},
+			),
+			want: &datamodel.GroupInvitation{
+				ID:            "TEST_GROUP_INVITATION_ID",
+				GroupID:       "TEST_GROUP_ID",
+				Inviter:       "TEST_ACTOR",
+				InviteeUserID: "TEST_USER_ID",
+				CreatedAt:     createdAt,
+				ExpiresAt:     expiresAt,
+				Status:        "PENDING",
+			},
+		},
+		{
+			name: "Creates a datamodel accepted group invitation",
+			inv: model.MustNewGroupInvitation(
+				"TEST_GROUP_INVITATION_ID",
+				"TEST_GROUP_ID",
+				"TEST_ACTOR",
+				model.GroupInvitee{Email: "test@example.com"},
+				createdAt,
+				expiresAt,
+				model.GroupInvitationState{Status: model.GroupInvitationStatusAccepted, RespondedAt: respondedAt},
+			),
+			want: &datamodel.GroupInvitation{
+				ID:           "TEST_GROUP_INVITATION_ID",
+				GroupID:      "TEST_GROUP_ID",
+				Inviter:      "TEST_ACTOR",
+				InviteeEmail: "test@example.com",
+				CreatedAt:    createdAt,
+				ExpiresAt:    expiresAt,
+				Status:       "ACCEPTED",
+				RespondedAt:  &respondedAt,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := datamodel.NewGroupInvitation(tt.inv)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(
+					"NewGroupInvitation(%v)=%v; want %v\ndiffers: (-got +want)\n%s",
+					tt.inv, got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
+
+func TestGroupInvitation_ToModel(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expiresAt := createdAt.Add(time.Hour)
+	respondedAt := createdAt.Add(time.Minute)
+	tests := []struct {
+		name string
+		inv  *datamodel.GroupInvitation
+		want *model.GroupInvitation
+	}{
+		{
+			name: "Convert to model.GroupInvitation",
+			inv: &datamodel.GroupInvitation{
+				ID:            "TEST_GROUP_INVITATION_ID",
+				GroupID:       "TEST_GROUP_ID",
+				Inviter:       "TEST_ACTOR",
+				InviteeUserID: "TEST_USER_ID",
+				CreatedAt:     createdAt,
+				ExpiresAt:     expiresAt,
+				Status:        "DECLINED",
+				RespondedAt:   &respondedAt,
+			},
+			want: model.MustNewGroupInvitation(
+				"TEST_GROUP_INVITATION_ID",
+				"TEST_GROUP_ID",
+				"TEST_ACTOR",
+				model.GroupInvitee{UserID: "TEST_USER_ID"},
+				createdAt,
+				expiresAt,
+				model.GroupInvitationState{Status: model.GroupInvitationStatusDeclined, RespondedAt: respondedAt},
+			),
+		},
+		{
+			name: "Returns nil",
+			inv:  nil,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.inv.ToModel()
+			if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(model.GroupInvitation{})); diff != "" {
+				t.Errorf(
+					"inv.ToModel()=%v; want %v\ndiffers: (-got +want)\n%s",
+					got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package datamodel
+
+import (
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+type GroupJoinRequest struct {
+	ID          string `gorm:"primaryKey"`
+	GroupID     string
+	UserID      string
+	CreatedAt   time.Time
+	Status      string
+	RespondedAt *time.Time
+	RespondedBy string
+}
+
+func NewGroupJoinRequest(jr *model.GroupJoinRequest) *GroupJoinRequest {
+	st := jr.State()
+	var respondedAt *time.Time
+	if !st.RespondedAt.IsZero() {
+		respondedAt = &st.RespondedAt
+	}
+	return &GroupJoinRequest{
+		ID:          string(jr.ID()),
+		GroupID:     string(jr.GroupID()),
+		UserID:      string(jr.UserID()),
+		CreatedAt:   jr.CreatedAt(),
+		Status:      string(st.Status),
+		RespondedAt: respondedAt,
+		RespondedBy: st.RespondedBy,
+	}
+}
+
+func (jr *GroupJoinRequest) ToModel() *model.GroupJoinRequest {
+	if jr == nil {
+		return nil
+	}
+	var respondedAt time.Time
+	if jr.RespondedAt != nil {
+		respondedAt = *jr.RespondedAt
+	}
+	return model.MustNewGroupJoinRequest(
+		model.GroupJoinRequestID(jr.ID),
+		model.GroupID(jr.GroupID),
+		model.UserID(jr.UserID),
+		jr.CreatedAt,
+		model.GroupJoinRequestState{
+			Status:      model.GroupJoinRequestStatus(jr.Status),
+			RespondedAt: respondedAt,
+			RespondedBy: jr.RespondedBy,
+		},
+	)
+}
+
+type GroupJoinRequests []*GroupJoinRequest
+
+func (jrs GroupJoinRequests) ToModel() model.GroupJoinRequests {
+	if jrs == nil {
+		return nil
+	}
+	mjrs := make(model.GroupJoinRequests, len(jrs))
+	for i, jr := range jrs {
+		mjrs[i] = jr.ToModel()
+	}
+	return mjrs
+}
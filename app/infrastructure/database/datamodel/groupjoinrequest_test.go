@@ -0,0 +1,123 @@
+package datamodel_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+func TestNewGroupJoinRequest(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	respondedAt := createdAt.Add(time.Minute)
+	tests := []struct {
+		name string
+		jr   *model.GroupJoinRequest
+		want *datamodel.GroupJoinRequest
+	}{
+		{
+			name: "Creates a datamodel pending group join request",
+			jr: model.MustNewGroupJoinRequest(
+				"TEST_GROUP_JOIN_REQUEST_ID",
+				"TEST_GROUP_ID",
+				"TEST_USER_ID",
+				createdAt,
+				model.GroupJoinRequestState{},
+			),
+			want: &datamodel.GroupJoinRequest{
+				ID:        "TEST_GROUP_JOIN_REQUEST_ID",
+				GroupID:   "TEST_GROUP_ID",
+				UserID:    "TEST_USER_ID",
+				CreatedAt: createdAt,
+				Status:    "PENDING",
+			},
+		},
+		{
+			name: "Creates a datamodel approved group join request",
+			jr: model.MustNewGroupJoinRequest(
+				"TEST_GROUP_JOIN_REQUEST_ID",
+				"TEST_GROUP_ID",
+				"TEST_USER_ID",
+				createdAt,
+				model.GroupJoinRequestState{
+					Status:      model.GroupJoinRequestStatusApproved,
+					RespondedAt: respondedAt,
+					RespondedBy: "TEST_ACTOR",
+				},
+			),
+			want: &datamodel.GroupJoinRequest{
+				ID:          "TEST_GROUP_JOIN_REQUEST_ID",
+				GroupID:     "TEST_GROUP_ID",
+				UserID:      "TEST_USER_ID",
+				CreatedAt:   createdAt,
+				Status:      "APPROVED",
+				RespondedAt: &respondedAt,
+				RespondedBy: "TEST_ACTOR",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := datamodel.NewGroupJoinRequest(tt.jr)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(
+					"NewGroupJoinRequest(%v)=%v; want %v\ndiffers: (-got +want)\n%s",
+					tt.jr, got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
+
+func TestGroupJoinRequest_ToModel(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	respondedAt := createdAt.Add(time.Minute)
+	tests := []struct {
+		name string
+		jr   *datamodel.GroupJoinRequest
+		want *model.GroupJoinRequest
+	}{
+		{
+			name: "Convert to model.GroupJoinRequest",
+			jr: &datamodel.GroupJoinRequest{
+				ID:          "TEST_GROUP_JOIN_REQUEST_ID",
+				GroupID:     "TEST_GROUP_ID",
+				UserID:      "TEST_USER_ID",
+				CreatedAt:   createdAt,
+				Status:      "REJECTED",
+				RespondedAt: &respondedAt,
+				RespondedBy: "TEST_ACTOR",
+			},
+			want: model.MustNewGroupJoinRequest(
+				"TEST_GROUP_JOIN_REQUEST_ID",
+				"TEST_GROUP_ID",
+				"TEST_USER_ID",
+				createdAt,
+				model.GroupJoinRequestState{
+					Status:      model.GroupJoinRequestStatusRejected,
+					RespondedAt: respondedAt,
+					RespondedBy: "TEST_ACTOR",
+				},
+			),
+		},
+		{
+			name: "Returns nil",
+			jr:   nil,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.jr.ToModel()
+			if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(model.GroupJoinRequest{})); diff != "" {
+				t.Errorf(
+					"jr.ToModel()=%v; want %v\ndiffers: (-got +want)\n%s",
+					got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
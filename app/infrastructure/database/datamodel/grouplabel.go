@@ -0,0 +1,48 @@
+package datamodel
+
+import "github.com/toshiykst/go-layerd-architecture/app/domain/model"
+
+type GroupLabel struct {
+	GroupID string `gorm:"primaryKey"`
+	Key     string `gorm:"primaryKey"`
+	Value   string
+}
+
+type GroupLabels []*GroupLabel
+
+// NewGroupLabels returns the rows of the labels of the group in the order of the keys.
+func NewGroupLabels(gID model.GroupID, ls model.Labels) GroupLabels {
+	gls := make(GroupLabels, 0, len(ls))
+	for _, k := range ls.Keys() {
+		gls = append(gls, &GroupLabel{
+			GroupID: string(gID),
+			Key:     k,
+			Value:   ls[k],
+		})
+	}
+	return gls
+}
+
+func (gls GroupLabels) ModelLabels() model.Labels {
+	if len(gls) == 0 {
+		return nil
+	}
+	ls := make(model.Labels, len(gls))
+	for _, gl := range gls {
+		ls[gl.Key] = gl.Value
+	}
+	return ls
+}
+
+func (gls GroupLabels) ModelLabelsByGroupID() map[string]model.Labels {
+	result := make(map[string]model.Labels)
+	for _, gl := range gls {
+		ls, ok := result[gl.GroupID]
+		if !ok {
+			ls = make(model.Labels)
+			result[gl.GroupID] = ls
+		}
+		ls[gl.Key] = gl.Value
+	}
+	return result
+}
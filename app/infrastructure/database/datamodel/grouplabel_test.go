@@ -0,0 +1,67 @@
+package datamodel_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+func TestNewGroupLabels(t *testing.T) {
+	type args struct {
+		gID model.GroupID
+		ls  model.Labels
+	}
+	tests := []struct {
+		name string
+		args args
+		want datamodel.GroupLabels
+	}{
+		{
+			name: "Creates datamodel grouplabels in the order of the keys",
+			args: args{
+				gID: model.GroupID("TEST_GROUP_ID"),
+				ls:  model.Labels{"team": "payments", "env": "prod"},
+			},
+			want: datamodel.GroupLabels{
+				{GroupID: "TEST_GROUP_ID", Key: "env", Value: "prod"},
+				{GroupID: "TEST_GROUP_ID", Key: "team", Value: "payments"},
+			},
+		},
+		{
+			name: "Creates no datamodel grouplabels without labels",
+			args: args{gID: model.GroupID("TEST_GROUP_ID")},
+			want: datamodel.GroupLabels{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := datamodel.NewGroupLabels(tt.args.gID, tt.args.ls)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(
+					"NewGroupLabels(%s,%v)=%v; want %v\ndiffers: (-got +want)\n%s",
+					tt.args.gID, tt.args.ls, got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
+
+func TestGroupLabels_ModelLabelsByGroupID(t *testing.T) {
+	gls := datamodel.GroupLabels{
+		{GroupID: "TEST_GROUP_ID_1", Key: "team", Value: "payments"},
+		{GroupID: "TEST_GROUP_ID_1", Key: "env", Value: "prod"},
+		{GroupID: "TEST_GROUP_ID_2", Key: "team", Value: "search"},
+	}
+	want := map[string]model.Labels{
+		"TEST_GROUP_ID_1": {"team": "payments", "env": "prod"},
+		"TEST_GROUP_ID_2": {"team": "search"},
+	}
+
+	got := gls.ModelLabelsByGroupID()
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("gls.ModelLabelsByGroupID()=%v; want %v\ndiffers: (-got +want)\n%s", got, want, diff)
+	}
+}
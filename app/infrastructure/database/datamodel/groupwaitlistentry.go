@@ -0,0 +1,34 @@
+package datamodel
+
+import "github.com/toshiykst/go-layerd-architecture/app/domain/model"
+
+// GroupWaitlistEntry is a user waitlisted for a group, the next one to be promoted at the lowest position.
+type GroupWaitlistEntry struct {
+	GroupID  string `gorm:"primaryKey"`
+	UserID   string `gorm:"primaryKey"`
+	Position int
+}
+
+type GroupWaitlistEntries []*GroupWaitlistEntry
+
+func NewGroupWaitlistEntries(w *model.GroupWaitlist) GroupWaitlistEntries {
+	uIDs := w.UserIDs()
+	es := make(GroupWaitlistEntries, len(uIDs))
+	for i, uID := range uIDs {
+		es[i] = &GroupWaitlistEntry{
+			GroupID:  string(w.GroupID()),
+			UserID:   string(uID),
+			Position: i,
+		}
+	}
+	return es
+}
+
+// ToModel converts the entries of the group, which are ordered by their position, to its waitlist.
+func (es GroupWaitlistEntries) ToModel(gID model.GroupID) (*model.GroupWaitlist, error) {
+	var uIDs []model.UserID
+	for _, e := range es {
+		uIDs = append(uIDs, model.UserID(e.UserID))
+	}
+	return model.NewGroupWaitlist(gID, uIDs)
+}
@@ -0,0 +1,60 @@
+package datamodel_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+func TestNewGroupWaitlistEntries(t *testing.T) {
+	w := model.MustNewGroupWaitlist("TEST_GROUP_ID", []model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_1"})
+	want := datamodel.GroupWaitlistEntries{
+		{GroupID: "TEST_GROUP_ID", UserID: "TEST_USER_ID_2", Position: 0},
+		{GroupID: "TEST_GROUP_ID", UserID: "TEST_USER_ID_1", Position: 1},
+	}
+
+	got := datamodel.NewGroupWaitlistEntries(w)
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("NewGroupWaitlistEntries(%v)=%v; want %v\ndiffers: (-got +want)\n%s", w, got, want, diff)
+	}
+}
+
+func TestGroupWaitlistEntries_ToModel(t *testing.T) {
+	tests := []struct {
+		name string
+		es   datamodel.GroupWaitlistEntries
+		want *model.GroupWaitlist
+	}{
+		{
+			name: "Returns the waitlist in the order of the entries",
+			es: datamodel.GroupWaitlistEntries{
+				{GroupID: "TEST_GROUP_ID", UserID: "TEST_USER_ID_2", Position: 0},
+				{GroupID: "TEST_GROUP_ID", UserID: "TEST_USER_ID_1", Position: 1},
+			},
+			want: model.MustNewGroupWaitlist("TEST_GROUP_ID", []model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_1"}),
+		},
+		{
+			name: "Returns an empty waitlist if there are no entries",
+			es:   nil,
+			want: model.MustNewGroupWaitlist("TEST_GROUP_ID", nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.es.ToModel("TEST_GROUP_ID")
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(model.GroupWaitlist{})); diff != "" {
+				t.Errorf(
+					"es.ToModel(_)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+					got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
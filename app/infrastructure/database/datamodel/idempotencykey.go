@@ -0,0 +1,47 @@
+package datamodel
+
+import (
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+type IdempotencyKey struct {
+	Key          string `gorm:"primaryKey"`
+	Fingerprint  string
+	StatusCode   int
+	ContentType  string
+	ResponseBody []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+func NewIdempotencyKey(k *model.IdempotencyKey) *IdempotencyKey {
+	res := k.Response()
+	return &IdempotencyKey{
+		Key:          k.Key(),
+		Fingerprint:  k.Fingerprint(),
+		StatusCode:   res.StatusCode,
+		ContentType:  res.ContentType,
+		ResponseBody: res.Body,
+		CreatedAt:    k.CreatedAt(),
+		ExpiresAt:    k.ExpiresAt(),
+	}
+}
+
+func (k *IdempotencyKey) ToModel() *model.IdempotencyKey {
+	if k == nil {
+		return nil
+	}
+	return model.MustNewIdempotencyKey(
+		k.Key,
+		k.Fingerprint,
+		model.IdempotentResponse{
+			StatusCode:  k.StatusCode,
+			ContentType: k.ContentType,
+			Body:        k.ResponseBody,
+		},
+		k.CreatedAt,
+		k.ExpiresAt,
+	)
+}
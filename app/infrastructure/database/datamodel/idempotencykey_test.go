@@ -0,0 +1,40 @@
+package datamodel_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+func TestNewIdempotencyKey(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	k := model.MustNewIdempotencyKey(
+		"TEST_IDEMPOTENCY_KEY",
+		"TEST_FINGERPRINT",
+		model.IdempotentResponse{StatusCode: 201, ContentType: "application/json", Body: []byte(`{}`)},
+		createdAt,
+		createdAt.Add(time.Hour),
+	)
+	want := &datamodel.IdempotencyKey{
+		Key:          "TEST_IDEMPOTENCY_KEY",
+		Fingerprint:  "TEST_FINGERPRINT",
+		StatusCode:   201,
+		ContentType:  "application/json",
+		ResponseBody: []byte(`{}`),
+		CreatedAt:    createdAt,
+		ExpiresAt:    createdAt.Add(time.Hour),
+	}
+
+	got := datamodel.NewIdempotencyKey(k)
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("NewIdempotencyKey(%v)=%v; want %v\ndiffers: (-got +want)\n%s", k, got, want, diff)
+	}
+
+	if diff := cmp.Diff(got.ToModel(), k, cmp.AllowUnexported(model.IdempotencyKey{})); diff != "" {
+		t.Errorf("k.ToModel()=%v; want %v\ndiffers: (-got +want)\n%s", got.ToModel(), k, diff)
+	}
+}
@@ -0,0 +1,90 @@
+package datamodel
+
+import (
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+type OutboxMessage struct {
+	ID            string `gorm:"primaryKey"`
+	EventType     string
+	AggregateType string
+	AggregateID   string
+	Payload       []byte
+	OccurredAt    time.Time
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	PublishedAt   *time.Time
+
+	// Seq is the auto-increment sequence the messages are ordered by, assigned by the database on insert.
+	Seq int64 `gorm:"->"`
+	// PublishedSeq is the sequence the messages are resumed by, given by the relay before publishing them.
+	PublishedSeq *int64 `gorm:"->"`
+}
+
+func NewOutboxMessage(m *model.OutboxMessage) *OutboxMessage {
+	d := m.Delivery()
+	var publishedAt *time.Time
+	if !d.PublishedAt.IsZero() {
+		publishedAt = &d.PublishedAt
+	}
+	return &OutboxMessage{
+		ID:            string(m.ID()),
+		EventType:     string(m.EventType()),
+		AggregateType: string(m.AggregateType()),
+		AggregateID:   m.AggregateID(),
+		Payload:       m.Payload(),
+		OccurredAt:    m.OccurredAt(),
+		Attempts:      d.Attempts,
+		LastError:     d.LastError,
+		NextAttemptAt: d.NextAttemptAt,
+		PublishedAt:   publishedAt,
+	}
+}
+
+func (m *OutboxMessage) ToModel() *model.OutboxMessage {
+	if m == nil {
+		return nil
+	}
+	var publishedAt time.Time
+	if m.PublishedAt != nil {
+		publishedAt = *m.PublishedAt
+	}
+	return model.MustNewOutboxMessage(
+		model.OutboxMessageID(m.ID),
+		model.DomainEventType(m.EventType),
+		model.AggregateType(m.AggregateType),
+		m.AggregateID,
+		m.Payload,
+		m.OccurredAt,
+		model.OutboxDelivery{
+			Attempts:      m.Attempts,
+			LastError:     m.LastError,
+			NextAttemptAt: m.NextAttemptAt,
+			PublishedAt:   publishedAt,
+		},
+	)
+}
+
+type OutboxMessages []*OutboxMessage
+
+func NewOutboxMessages(ms model.OutboxMessages) OutboxMessages {
+	dmms := make(OutboxMessages, len(ms))
+	for i, m := range ms {
+		dmms[i] = NewOutboxMessage(m)
+	}
+	return dmms
+}
+
+func (ms OutboxMessages) ToModel() model.OutboxMessages {
+	if ms == nil {
+		return nil
+	}
+	mms := make(model.OutboxMessages, len(ms))
+	for i, m := range ms {
+		mms[i] = m.ToModel()
+	}
+	return mms
+}
@@ -0,0 +1,127 @@
+package datamodel_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+func TestNewOutboxMessage(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	publishedAt := time.Date(2023, 1, 2, 3, 4, 6, 0, time.UTC)
+	tests := []struct {
+		name string
+		m    *model.OutboxMessage
+		want *datamodel.OutboxMessage
+	}{
+		{
+			name: "Creates a datamodel outbox message",
+			m: model.MustNewOutboxMessage(
+				"TEST_OUTBOX_MESSAGE_ID",
+				model.DomainEventTypeUserCreated,
+				model.AggregateTypeUser,
+				"TEST_USER_ID",
+				[]byte(`{}`),
+				occurredAt,
+				model.OutboxDelivery{},
+			),
+			want: &datamodel.OutboxMessage{
+				ID:            "TEST_OUTBOX_MESSAGE_ID",
+				EventType:     "UserCreated",
+				AggregateType: "USER",
+				AggregateID:   "TEST_USER_ID",
+				Payload:       []byte(`{}`),
+				OccurredAt:    occurredAt,
+				NextAttemptAt: occurredAt,
+			},
+		},
+		{
+			name: "Creates a datamodel published outbox message",
+			m: model.MustNewOutboxMessage(
+				"TEST_OUTBOX_MESSAGE_ID",
+				model.DomainEventTypeUserCreated,
+				model.AggregateTypeUser,
+				"TEST_USER_ID",
+				[]byte(`{}`),
+				occurredAt,
+				model.OutboxDelivery{Attempts: 1, PublishedAt: publishedAt},
+			),
+			want: &datamodel.OutboxMessage{
+				ID:            "TEST_OUTBOX_MESSAGE_ID",
+				EventType:     "UserCreated",
+				AggregateType: "USER",
+				AggregateID:   "TEST_USER_ID",
+				Payload:       []byte(`{}`),
+				OccurredAt:    occurredAt,
+				Attempts:      1,
+				NextAttemptAt: occurredAt,
+				PublishedAt:   &publishedAt,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := datamodel.NewOutboxMessage(tt.m)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(
+					"NewOutboxMessage(%v)=%v; want %v\ndiffers: (-got +want)\n%s",
+					tt.m, got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
+
+func TestOutboxMessage_ToModel(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	publishedAt := time.Date(2023, 1, 2, 3, 4, 6, 0, time.UTC)
+	tests := []struct {
+		name string
+		m    *datamodel.OutboxMessage
+		want *model.OutboxMessage
+	}{
+		{
+			name: "Convert to model.OutboxMessage",
+			m: &datamodel.OutboxMessage{
+				ID:            "TEST_OUTBOX_MESSAGE_ID",
+				EventType:     "GroupDeleted",
+				AggregateType: "GROUP",
+				AggregateID:   "TEST_GROUP_ID",
+				Payload:       []byte(`{}`),
+				OccurredAt:    occurredAt,
+				Attempts:      1,
+				NextAttemptAt: occurredAt,
+				PublishedAt:   &publishedAt,
+			},
+			want: model.MustNewOutboxMessage(
+				"TEST_OUTBOX_MESSAGE_ID",
+				model.DomainEventTypeGroupDeleted,
+				model.AggregateTypeGroup,
+				"TEST_GROUP_ID",
+				[]byte(`{}`),
+				occurredAt,
+				model.OutboxDelivery{Attempts: 1, NextAttemptAt: occurredAt, PublishedAt: publishedAt},
+			),
+		},
+		{
+			name: "Returns nil",
+			m:    nil,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.m.ToModel()
+			if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(model.OutboxMessage{})); diff != "" {
+				t.Errorf(
+					"m.ToModel()=%v; want %v\ndiffers: (-got +want)\n%s",
+					got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package datamodel
+
+import (
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+type Subgroup struct {
+	ParentGroupID string `gorm:"primaryKey"`
+	ChildGroupID  string `gorm:"primaryKey"`
+}
+
+func NewSubgroup(s *model.Subgroup) *Subgroup {
+	return &Subgroup{
+		ParentGroupID: string(s.ParentID()),
+		ChildGroupID:  string(s.ChildID()),
+	}
+}
+
+func (s *Subgroup) ToModel() *model.Subgroup {
+	if s == nil {
+		return nil
+	}
+	return model.MustNewSubgroup(model.GroupID(s.ParentGroupID), model.GroupID(s.ChildGroupID))
+}
+
+type Subgroups []*Subgroup
+
+func (ss Subgroups) ToModel() model.Subgroups {
+	if ss == nil {
+		return nil
+	}
+	mss := make(model.Subgroups, len(ss))
+	for i, s := range ss {
+		mss[i] = s.ToModel()
+	}
+	return mss
+}
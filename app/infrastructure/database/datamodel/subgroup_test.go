@@ -0,0 +1,57 @@
+package datamodel_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+func TestNewSubgroup(t *testing.T) {
+	s := model.MustNewSubgroup("TEST_GROUP_ID_1", "TEST_GROUP_ID_2")
+	want := &datamodel.Subgroup{
+		ParentGroupID: "TEST_GROUP_ID_1",
+		ChildGroupID:  "TEST_GROUP_ID_2",
+	}
+
+	got := datamodel.NewSubgroup(s)
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("datamodel.NewSubgroup(%v)=%v; want %v\ndiffers: (-got +want)\n%s", s, got, want, diff)
+	}
+}
+
+func TestSubgroups_ToModel(t *testing.T) {
+	tests := []struct {
+		name string
+		ss   datamodel.Subgroups
+		want model.Subgroups
+	}{
+		{
+			name: "Converts to model subgroups",
+			ss: datamodel.Subgroups{
+				{ParentGroupID: "TEST_GROUP_ID_1", ChildGroupID: "TEST_GROUP_ID_2"},
+				{ParentGroupID: "TEST_GROUP_ID_1", ChildGroupID: "TEST_GROUP_ID_3"},
+			},
+			want: model.Subgroups{
+				model.MustNewSubgroup("TEST_GROUP_ID_1", "TEST_GROUP_ID_2"),
+				model.MustNewSubgroup("TEST_GROUP_ID_1", "TEST_GROUP_ID_3"),
+			},
+		},
+		{
+			name: "Converts nil to nil",
+			ss:   nil,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.ss.ToModel()
+			if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(model.Subgroup{})); diff != "" {
+				t.Errorf("ss.ToModel()=%v; want %v\ndiffers: (-got +want)\n%s", got, tt.want, diff)
+			}
+		})
+	}
+}
@@ -1,18 +1,41 @@
 package datamodel
 
-import "github.com/toshiykst/go-layerd-architecture/app/domain/model"
+import (
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
 
 type User struct {
-	ID    string `gorm:"primaryKey"`
-	Name  string
-	Email string
+	ID         string `gorm:"primaryKey"`
+	Name       string
+	Email      string
+	Status     string
+	Attributes map[string]any `gorm:"serializer:json"`
+	// The timestamps are the ones of the model, which gorm does not set by itself.
+	CreatedAt time.Time `gorm:"autoCreateTime:false"`
+	CreatedBy string
+	UpdatedAt time.Time `gorm:"autoUpdateTime:false"`
+	UpdatedBy string
 }
 
-func NewUser(uID model.UserID, name, email string) *User {
+func NewUser(
+	uID model.UserID,
+	name, email string,
+	status model.UserStatus,
+	attrs model.Attributes,
+	ts model.Timestamps,
+) *User {
 	return &User{
-		ID:    string(uID),
-		Name:  name,
-		Email: email,
+		ID:         string(uID),
+		Name:       name,
+		Email:      email,
+		Status:     string(status),
+		Attributes: attrs,
+		CreatedAt:  ts.CreatedAt,
+		CreatedBy:  ts.CreatedBy,
+		UpdatedAt:  ts.UpdatedAt,
+		UpdatedBy:  ts.UpdatedBy,
 	}
 }
 
@@ -20,11 +43,29 @@ func (u *User) ToModel() *model.User {
 	if u == nil {
 		return nil
 	}
-	return model.MustNewUser(
+	return u.toModel()
+}
+
+// toModel returns the user, whose status is the default one if the row has none.
+func (u *User) toModel() *model.User {
+	status := model.DefaultUserStatus
+	if u.Status != "" {
+		status = model.UserStatus(u.Status)
+	}
+	mu := model.MustNewUserWithStatus(
 		model.UserID(u.ID),
 		u.Name,
 		u.Email,
+		status,
 	)
+	mu.ChangeAttributes(u.Attributes)
+	mu.ChangeTimestamps(model.Timestamps{
+		CreatedAt: u.CreatedAt,
+		CreatedBy: u.CreatedBy,
+		UpdatedAt: u.UpdatedAt,
+		UpdatedBy: u.UpdatedBy,
+	})
+	return mu
 }
 
 type Users []*User
@@ -35,11 +76,7 @@ func (us Users) ToModel() model.Users {
 	}
 	mus := make(model.Users, len(us))
 	for i, u := range us {
-		mus[i] = model.MustNewUser(
-			model.UserID(u.ID),
-			u.Name,
-			u.Email,
-		)
+		mus[i] = u.toModel()
 	}
 	return mus
 }
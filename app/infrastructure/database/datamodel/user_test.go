@@ -2,6 +2,7 @@ package datamodel_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -11,9 +12,12 @@ import (
 
 func TestNewUser(t *testing.T) {
 	type args struct {
-		id    model.UserID
-		name  string
-		email string
+		id     model.UserID
+		name   string
+		email  string
+		status model.UserStatus
+		attrs  model.Attributes
+		ts     model.Timestamps
 	}
 	tests := []struct {
 		name string
@@ -23,25 +27,35 @@ func TestNewUser(t *testing.T) {
 		{
 			name: "Creates a datamodel user",
 			args: args{
-				id:    model.UserID("TEST_USER_ID"),
-				name:  "TEST_USER_NAME",
-				email: "TEST_USER_EMAIL",
+				id:     model.UserID("TEST_USER_ID"),
+				name:   "TEST_USER_NAME",
+				email:  "TEST_USER_EMAIL",
+				status: model.UserStatusSuspended,
+				attrs:  model.Attributes{"department": "sales"},
+				ts: model.NewTimestamps("TEST_ACTOR", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)).
+					Updated("TEST_ACTOR_2", time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)),
 			},
 			want: &datamodel.User{
-				ID:    "TEST_USER_ID",
-				Name:  "TEST_USER_NAME",
-				Email: "TEST_USER_EMAIL",
+				ID:         "TEST_USER_ID",
+				Name:       "TEST_USER_NAME",
+				Email:      "TEST_USER_EMAIL",
+				Status:     "SUSPENDED",
+				Attributes: map[string]any{"department": "sales"},
+				CreatedAt:  time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				CreatedBy:  "TEST_ACTOR",
+				UpdatedAt:  time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+				UpdatedBy:  "TEST_ACTOR_2",
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := datamodel.NewUser(tt.args.id, tt.args.name, tt.args.email)
+			got := datamodel.NewUser(tt.args.id, tt.args.name, tt.args.email, tt.args.status, tt.args.attrs, tt.args.ts)
 			if diff := cmp.Diff(got, tt.want); diff != "" {
 				t.Errorf(
-					"TestNewUser(%s, %s, %s)=%v; want %v\ndiffers: (-got +want)\n%s",
-					tt.args.id, tt.args.name, tt.args.email, got, tt.want, diff,
+					"TestNewUser(%s, %s, %s, %s)=%v; want %v\ndiffers: (-got +want)\n%s",
+					tt.args.id, tt.args.name, tt.args.email, tt.args.status, got, tt.want, diff,
 				)
 			}
 		})
@@ -63,6 +77,52 @@ func TestUser_ToModel(t *testing.T) {
 			},
 			want: model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"),
 		},
+		{
+			name: "Convert to model.User in the status",
+			user: &datamodel.User{
+				ID:     "TEST_USER_ID",
+				Name:   "TEST_USER_NAME",
+				Email:  "TEST_USER_EMAIL",
+				Status: "SUSPENDED",
+			},
+			want: model.MustNewUserWithStatus("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL", model.UserStatusSuspended),
+		},
+		{
+			name: "Convert to model.User with the attributes",
+			user: &datamodel.User{
+				ID:         "TEST_USER_ID",
+				Name:       "TEST_USER_NAME",
+				Email:      "TEST_USER_EMAIL",
+				Attributes: map[string]any{"department": "sales"},
+			},
+			want: func() *model.User {
+				u := model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL")
+				u.ChangeAttributes(model.Attributes{"department": "sales"})
+				return u
+			}(),
+		},
+		{
+			name: "Convert to model.User with the timestamps",
+			user: &datamodel.User{
+				ID:        "TEST_USER_ID",
+				Name:      "TEST_USER_NAME",
+				Email:     "TEST_USER_EMAIL",
+				CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				CreatedBy: "TEST_ACTOR",
+				UpdatedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+				UpdatedBy: "TEST_ACTOR_2",
+			},
+			want: func() *model.User {
+				u := model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL")
+				u.ChangeTimestamps(model.Timestamps{
+					CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+					CreatedBy: "TEST_ACTOR",
+					UpdatedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+					UpdatedBy: "TEST_ACTOR_2",
+				})
+				return u
+			}(),
+		},
 		{
 			name: "Returns nil when the receiver is nil",
 			user: nil,
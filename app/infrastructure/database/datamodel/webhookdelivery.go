@@ -0,0 +1,91 @@
+package datamodel
+
+import (
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+type WebhookDelivery struct {
+	ID             string `gorm:"primaryKey"`
+	SubscriptionID string
+	EventID        string
+	EventType      string
+	Payload        []byte
+	OccurredAt     time.Time
+	Status         string
+	Attempts       int
+	LastStatusCode int
+	LastError      string
+	NextAttemptAt  time.Time
+	DeliveredAt    *time.Time
+}
+
+func NewWebhookDelivery(d *model.WebhookDelivery) *WebhookDelivery {
+	st := d.State()
+	var deliveredAt *time.Time
+	if !st.DeliveredAt.IsZero() {
+		deliveredAt = &st.DeliveredAt
+	}
+	return &WebhookDelivery{
+		ID:             string(d.ID()),
+		SubscriptionID: string(d.SubscriptionID()),
+		EventID:        string(d.EventID()),
+		EventType:      string(d.EventType()),
+		Payload:        d.Payload(),
+		OccurredAt:     d.OccurredAt(),
+		Status:         string(st.Status),
+		Attempts:       st.Attempts,
+		LastStatusCode: st.LastStatusCode,
+		LastError:      st.LastError,
+		NextAttemptAt:  st.NextAttemptAt,
+		DeliveredAt:    deliveredAt,
+	}
+}
+
+func (d *WebhookDelivery) ToModel() *model.WebhookDelivery {
+	if d == nil {
+		return nil
+	}
+	var deliveredAt time.Time
+	if d.DeliveredAt != nil {
+		deliveredAt = *d.DeliveredAt
+	}
+	return model.MustNewWebhookDelivery(
+		model.WebhookDeliveryID(d.ID),
+		model.WebhookSubscriptionID(d.SubscriptionID),
+		model.OutboxMessageID(d.EventID),
+		model.DomainEventType(d.EventType),
+		d.Payload,
+		d.OccurredAt,
+		model.WebhookDeliveryState{
+			Status:         model.WebhookDeliveryStatus(d.Status),
+			Attempts:       d.Attempts,
+			LastStatusCode: d.LastStatusCode,
+			LastError:      d.LastError,
+			NextAttemptAt:  d.NextAttemptAt,
+			DeliveredAt:    deliveredAt,
+		},
+	)
+}
+
+type WebhookDeliveries []*WebhookDelivery
+
+func NewWebhookDeliveries(ds model.WebhookDeliveries) WebhookDeliveries {
+	dmds := make(WebhookDeliveries, len(ds))
+	for i, d := range ds {
+		dmds[i] = NewWebhookDelivery(d)
+	}
+	return dmds
+}
+
+func (ds WebhookDeliveries) ToModel() model.WebhookDeliveries {
+	if ds == nil {
+		return nil
+	}
+	mds := make(model.WebhookDeliveries, len(ds))
+	for i, d := range ds {
+		mds[i] = d.ToModel()
+	}
+	return mds
+}
@@ -0,0 +1,145 @@
+package datamodel_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+func TestNewWebhookDelivery(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	retryAt := time.Date(2023, 1, 2, 3, 4, 6, 0, time.UTC)
+	deliveredAt := time.Date(2023, 1, 2, 3, 4, 7, 0, time.UTC)
+	tests := []struct {
+		name string
+		d    *model.WebhookDelivery
+		want *datamodel.WebhookDelivery
+	}{
+		{
+			name: "Creates a datamodel pending webhook delivery",
+			d: model.MustNewWebhookDelivery(
+				"TEST_WEBHOOK_DELIVERY_ID",
+				"TEST_WEBHOOK_SUBSCRIPTION_ID",
+				"TEST_EVENT_ID",
+				model.DomainEventTypeUserCreated,
+				[]byte(`{}`),
+				occurredAt,
+				model.WebhookDeliveryState{},
+			),
+			want: &datamodel.WebhookDelivery{
+				ID:             "TEST_WEBHOOK_DELIVERY_ID",
+				SubscriptionID: "TEST_WEBHOOK_SUBSCRIPTION_ID",
+				EventID:        "TEST_EVENT_ID",
+				EventType:      "UserCreated",
+				Payload:        []byte(`{}`),
+				OccurredAt:     occurredAt,
+				Status:         "PENDING",
+				NextAttemptAt:  occurredAt,
+			},
+		},
+		{
+			name: "Creates a datamodel succeeded webhook delivery",
+			d: model.MustNewWebhookDelivery(
+				"TEST_WEBHOOK_DELIVERY_ID",
+				"TEST_WEBHOOK_SUBSCRIPTION_ID",
+				"TEST_EVENT_ID",
+				model.DomainEventTypeUserCreated,
+				[]byte(`{}`),
+				occurredAt,
+				model.WebhookDeliveryState{
+					Status:         model.WebhookDeliveryStatusSucceeded,
+					Attempts:       2,
+					LastStatusCode: 200,
+					NextAttemptAt:  retryAt,
+					DeliveredAt:    deliveredAt,
+				},
+			),
+			want: &datamodel.WebhookDelivery{
+				ID:             "TEST_WEBHOOK_DELIVERY_ID",
+				SubscriptionID: "TEST_WEBHOOK_SUBSCRIPTION_ID",
+				EventID:        "TEST_EVENT_ID",
+				EventType:      "UserCreated",
+				Payload:        []byte(`{}`),
+				OccurredAt:     occurredAt,
+				Status:         "SUCCEEDED",
+				Attempts:       2,
+				LastStatusCode: 200,
+				NextAttemptAt:  retryAt,
+				DeliveredAt:    &deliveredAt,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := datamodel.NewWebhookDelivery(tt.d)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(
+					"NewWebhookDelivery(%v)=%v; want %v\ndiffers: (-got +want)\n%s",
+					tt.d, got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
+
+func TestWebhookDelivery_ToModel(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	retryAt := time.Date(2023, 1, 2, 3, 4, 6, 0, time.UTC)
+	tests := []struct {
+		name string
+		d    *datamodel.WebhookDelivery
+		want *model.WebhookDelivery
+	}{
+		{
+			name: "Convert to model.WebhookDelivery",
+			d: &datamodel.WebhookDelivery{
+				ID:             "TEST_WEBHOOK_DELIVERY_ID",
+				SubscriptionID: "TEST_WEBHOOK_SUBSCRIPTION_ID",
+				EventID:        "TEST_EVENT_ID",
+				EventType:      "GroupDeleted",
+				Payload:        []byte(`{}`),
+				OccurredAt:     occurredAt,
+				Status:         "DEAD",
+				Attempts:       8,
+				LastStatusCode: 500,
+				LastError:      "an error occurred",
+				NextAttemptAt:  retryAt,
+			},
+			want: model.MustNewWebhookDelivery(
+				"TEST_WEBHOOK_DELIVERY_ID",
+				"TEST_WEBHOOK_SUBSCRIPTION_ID",
+				"TEST_EVENT_ID",
+				model.DomainEventTypeGroupDeleted,
+				[]byte(`{}`),
+				occurredAt,
+				model.WebhookDeliveryState{
+					Status:         model.WebhookDeliveryStatusDead,
+					Attempts:       8,
+					LastStatusCode: 500,
+					LastError:      "an error occurred",
+					NextAttemptAt:  retryAt,
+				},
+			),
+		},
+		{
+			name: "Returns nil",
+			d:    nil,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.d.ToModel()
+			if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(model.WebhookDelivery{})); diff != "" {
+				t.Errorf(
+					"d.ToModel()=%v; want %v\ndiffers: (-got +want)\n%s",
+					got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package datamodel
+
+import (
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+type WebhookSubscription struct {
+	ID         string `gorm:"primaryKey"`
+	URL        string
+	EventTypes []string `gorm:"serializer:json"`
+	Secret     string
+}
+
+func NewWebhookSubscription(s *model.WebhookSubscription) *WebhookSubscription {
+	eventTypes := make([]string, len(s.EventTypes()))
+	for i, t := range s.EventTypes() {
+		eventTypes[i] = string(t)
+	}
+	return &WebhookSubscription{
+		ID:         string(s.ID()),
+		URL:        s.URL(),
+		EventTypes: eventTypes,
+		Secret:     s.Secret(),
+	}
+}
+
+func (s *WebhookSubscription) ToModel() *model.WebhookSubscription {
+	if s == nil {
+		return nil
+	}
+	eventTypes := make([]model.DomainEventType, len(s.EventTypes))
+	for i, t := range s.EventTypes {
+		eventTypes[i] = model.DomainEventType(t)
+	}
+	return model.MustNewWebhookSubscription(
+		model.WebhookSubscriptionID(s.ID),
+		s.URL,
+		eventTypes,
+		s.Secret,
+	)
+}
+
+type WebhookSubscriptions []*WebhookSubscription
+
+func (ss WebhookSubscriptions) ToModel() model.WebhookSubscriptions {
+	if ss == nil {
+		return nil
+	}
+	mss := make(model.WebhookSubscriptions, len(ss))
+	for i, s := range ss {
+		mss[i] = s.ToModel()
+	}
+	return mss
+}
@@ -0,0 +1,34 @@
+package datamodel_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+func TestNewWebhookSubscription(t *testing.T) {
+	s := model.MustNewWebhookSubscription(
+		"TEST_WEBHOOK_SUBSCRIPTION_ID",
+		"https://example.com/hooks",
+		[]model.DomainEventType{model.DomainEventTypeUserCreated, model.DomainEventTypeGroupDeleted},
+		"TEST_SECRET",
+	)
+	want := &datamodel.WebhookSubscription{
+		ID:         "TEST_WEBHOOK_SUBSCRIPTION_ID",
+		URL:        "https://example.com/hooks",
+		EventTypes: []string{"UserCreated", "GroupDeleted"},
+		Secret:     "TEST_SECRET",
+	}
+
+	got := datamodel.NewWebhookSubscription(s)
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("NewWebhookSubscription(%v)=%v; want %v\ndiffers: (-got +want)\n%s", s, got, want, diff)
+	}
+
+	if diff := cmp.Diff(got.ToModel(), s, cmp.AllowUnexported(model.WebhookSubscription{})); diff != "" {
+		t.Errorf("s.ToModel()=%v; want %v\ndiffers: (-got +want)\n%s", got.ToModel(), s, diff)
+	}
+}
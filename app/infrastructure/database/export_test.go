@@ -13,3 +13,63 @@ type DBGroupRepository = dbGroupRepository
 func (r *DBGroupRepository) SetDB(db *gorm.DB) {
 	r.db = db
 }
+
+type DBAuditEventRepository = dbAuditEventRepository
+
+func (r *DBAuditEventRepository) SetDB(db *gorm.DB) {
+	r.db = db
+}
+
+type DBOutboxMessageRepository = dbOutboxMessageRepository
+
+func (r *DBOutboxMessageRepository) SetDB(db *gorm.DB) {
+	r.db = db
+}
+
+type DBWebhookSubscriptionRepository = dbWebhookSubscriptionRepository
+
+func (r *DBWebhookSubscriptionRepository) SetDB(db *gorm.DB) {
+	r.db = db
+}
+
+type DBWebhookDeliveryRepository = dbWebhookDeliveryRepository
+
+func (r *DBWebhookDeliveryRepository) SetDB(db *gorm.DB) {
+	r.db = db
+}
+
+type DBIdempotencyKeyRepository = dbIdempotencyKeyRepository
+
+func (r *DBIdempotencyKeyRepository) SetDB(db *gorm.DB) {
+	r.db = db
+}
+
+type DBGroupWaitlistRepository = dbGroupWaitlistRepository
+
+func (r *DBGroupWaitlistRepository) SetDB(db *gorm.DB) {
+	r.db = db
+}
+
+type DBGroupInvitationRepository = dbGroupInvitationRepository
+
+func (r *DBGroupInvitationRepository) SetDB(db *gorm.DB) {
+	r.db = db
+}
+
+type DBGroupJoinRequestRepository = dbGroupJoinRequestRepository
+
+func (r *DBGroupJoinRequestRepository) SetDB(db *gorm.DB) {
+	r.db = db
+}
+
+type DBSubgroupRepository = dbSubgroupRepository
+
+func (r *DBSubgroupRepository) SetDB(db *gorm.DB) {
+	r.db = db
+}
+
+type DBAttributeSchemaRepository = dbAttributeSchemaRepository
+
+func (r *DBAttributeSchemaRepository) SetDB(db *gorm.DB) {
+	r.db = db
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
 	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
@@ -15,9 +16,19 @@ type dbGroupRepository struct {
 }
 
 func (r *dbGroupRepository) Find(gID model.GroupID) (*model.Group, error) {
+	return findGroup(r.db, gID)
+}
+
+// FindForUpdate reads the group, its members and labels with SELECT ... FOR UPDATE, which reads the latest
+// committed rows and locks them until the transaction ends.
+func (r *dbGroupRepository) FindForUpdate(gID model.GroupID) (*model.Group, error) {
+	return findGroup(r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Session(&gorm.Session{}), gID)
+}
+
+func findGroup(db *gorm.DB, gID model.GroupID) (*model.Group, error) {
 	dmg := &datamodel.Group{ID: string(gID)}
 
-	if err := r.db.First(dmg).Error; err != nil {
+	if err := db.First(dmg).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -25,47 +36,129 @@ func (r *dbGroupRepository) Find(gID model.GroupID) (*model.Group, error) {
 	}
 
 	var dmgus datamodel.GroupUsers
-	if err := r.db.Where("group_id = ?", gID).Find(&dmgus).Error; err != nil {
+	if err := db.Where("group_id = ?", gID).Find(&dmgus).Error; err != nil {
 		return nil, err
 	}
 
-	return dmg.ToModel(dmgus), nil
+	var dmgls datamodel.GroupLabels
+	if err := db.Where("group_id = ?", gID).Find(&dmgls).Error; err != nil {
+		return nil, err
+	}
+
+	return dmg.ToModel(dmgus, dmgls), nil
 }
 
 func (r *dbGroupRepository) List(f repository.GroupListFilter) (model.Groups, error) {
 	var (
 		dmgs  datamodel.Groups
 		dmgus datamodel.GroupUsers
+		dmgls datamodel.GroupLabels
 	)
 
 	gdb := r.db
 	gudb := r.db
 
+	if len(f.GroupIDs) > 0 {
+		gdb = gdb.Where("id IN (?)", f.GroupIDs)
+	}
 	if len(f.UserIDs) > 0 {
-		if err := gudb.Where("user_id IN (?)", f.UserIDs).Find(&dmgus).Error; err != nil {
+		var matched datamodel.GroupUsers
+		if err := r.db.Where("user_id IN (?)", f.UserIDs).Find(&matched).Error; err != nil {
 			return nil, err
 		}
-		if len(dmgus) == 0 {
+		if len(matched) == 0 {
 			return nil, nil
 		}
 
-		if err := gdb.Where("group_id IN (?)", dmgus.GroupIDs()).Find(&dmgs).Error; err != nil {
-			return nil, err
+		gdb = gdb.Where("id IN (?)", matched.GroupIDs())
+	}
+	if len(f.AllUserIDs) > 0 {
+		uIDs := uniqueUserIDs(f.AllUserIDs)
+		gdb = gdb.Where(
+			"id IN (SELECT group_id FROM `group_users` WHERE user_id IN (?) GROUP BY group_id HAVING COUNT(*) = ?)",
+			uIDs, len(uIDs),
+		)
+	}
+	if f.NoUsers {
+		gdb = gdb.Where("NOT EXISTS (SELECT 1 FROM `group_users` WHERE `group_users`.group_id = `groups`.id)")
+	}
+	if f.MinUsers > 0 {
+		gdb = gdb.Where(
+			"id IN (SELECT group_id FROM `group_users` GROUP BY group_id HAVING COUNT(*) >= ?)",
+			f.MinUsers,
+		)
+	}
+	gdb, err := whereAttributes(gdb, f.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	gdb = whereLabelSelector(gdb, f.LabelSelector)
+	if f.AfterID != "" {
+		gdb = gdb.Where("id > ?", f.AfterID)
+	}
+	if f.Query != "" {
+		cond, order := searchClauses(f.Query, "name")
+		gdb = gdb.Where(cond)
+		if f.Sort.IsZero() {
+			gdb = gdb.Clauses(order)
 		}
-	} else {
-		if err := gdb.Find(&dmgs).Error; err != nil {
-			return nil, err
+	}
+	if !f.Sort.IsZero() {
+		gdb = orderBySort(gdb, f.Sort)
+	} else if f.Query == "" && f.Limit > 0 {
+		gdb = gdb.Order("id")
+	}
+	if f.Limit > 0 {
+		gdb = gdb.Limit(f.Limit)
+	}
+
+	if err := gdb.Find(&dmgs).Error; err != nil {
+		return nil, err
+	}
+	if len(dmgs) == 0 {
+		return nil, nil
+	}
+
+	// Load all the members of the groups, not only the users filtered by.
+	if err := gudb.Where("group_id IN (?)", dmgs.IDs()).Find(&dmgus).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("group_id IN (?)", dmgs.IDs()).Find(&dmgls).Error; err != nil {
+		return nil, err
+	}
+
+	return dmgs.ToModel(dmgus, dmgls), nil
+}
+
+// whereLabelSelector keeps the groups whose labels meet all the requirements of the selector. A negated requirement
+// keeps the groups which have no label of the key and the values, including the ones without the label.
+func whereLabelSelector(db *gorm.DB, sel model.LabelSelector) *gorm.DB {
+	for _, req := range sel {
+		cond := "id IN (SELECT group_id FROM `group_labels` WHERE `key` = ?"
+		if req.Negated() {
+			cond = "id NOT IN (SELECT group_id FROM `group_labels` WHERE `key` = ?"
 		}
-		if len(dmgs) == 0 {
-			return nil, nil
+		if len(req.Values) > 0 {
+			db = db.Where(cond+" AND value IN (?))", req.Key, req.Values)
+		} else {
+			db = db.Where(cond+")", req.Key)
 		}
+	}
+	return db
+}
 
-		if err := gudb.Where("group_id IN (?)", dmgs.IDs()).Find(&dmgus).Error; err != nil {
-			return nil, err
+// uniqueUserIDs returns the user ids without duplicates, so that the number of them is of the distinct members
+// a group must have.
+func uniqueUserIDs(uIDs []model.UserID) []model.UserID {
+	seen := make(map[model.UserID]bool, len(uIDs))
+	var result []model.UserID
+	for _, uID := range uIDs {
+		if !seen[uID] {
+			seen[uID] = true
+			result = append(result, uID)
 		}
 	}
-
-	return dmgs.ToModel(dmgus), nil
+	return result
 }
 
 func (r *dbGroupRepository) ListByUserIDs(uIDs []model.UserID) (model.Groups, error) {
@@ -88,11 +181,16 @@ func (r *dbGroupRepository) ListByUserIDs(uIDs []model.UserID) (model.Groups, er
 		return nil, err
 	}
 
-	return dmgs.ToModel(dmgus), nil
+	var dmgls datamodel.GroupLabels
+	if err := r.db.Where("group_id IN (?)", dmgus.GroupIDs()).Find(&dmgls).Error; err != nil {
+		return nil, err
+	}
+
+	return dmgs.ToModel(dmgus, dmgls), nil
 }
 
 func (r *dbGroupRepository) Create(g *model.Group) (*model.Group, error) {
-	dmg := datamodel.NewGroup(g.ID(), g.Name())
+	dmg := datamodel.NewGroup(g.ID(), g.Name(), g.JoinPolicy(), g.Attributes(), g.Timestamps())
 
 	if err := r.db.Create(dmg).Error; err != nil {
 		return nil, err
@@ -105,15 +203,30 @@ func (r *dbGroupRepository) Create(g *model.Group) (*model.Group, error) {
 		}
 	}
 
-	return dmg.ToModel(dmgus), nil
+	dmgls := datamodel.NewGroupLabels(g.ID(), g.Labels())
+	if len(dmgls) > 0 {
+		if err := r.db.Create(dmgls).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return dmg.ToModel(dmgus, dmgls), nil
 }
 
 func (r *dbGroupRepository) Update(g *model.Group) error {
 	if g.ID() == "" {
 		return errors.New("group id must not be empty")
 	}
+	attrs, err := datamodel.AttributesValue(g.Attributes())
+	if err != nil {
+		return err
+	}
 	if err := r.db.Model(&datamodel.Group{ID: string(g.ID())}).Updates(map[string]any{
-		"name": g.Name(),
+		"name":        g.Name(),
+		"join_policy": g.JoinPolicy(),
+		"attributes":  attrs,
+		"updated_at":  g.Timestamps().UpdatedAt,
+		"updated_by":  g.Timestamps().UpdatedBy,
 	}).Error; err != nil {
 		return err
 	}
@@ -128,6 +241,12 @@ func (r *dbGroupRepository) Delete(g *model.Group) error {
 			return err
 		}
 	}
+	if len(g.Labels()) > 0 {
+		if err := r.db.Where("group_id = ?", g.ID()).
+			Delete(&datamodel.GroupLabel{}).Error; err != nil {
+			return err
+		}
+	}
 
 	if err := r.db.Delete(&datamodel.Group{ID: string(g.ID())}).Error; err != nil {
 		return err
@@ -172,3 +291,18 @@ func (r *dbGroupRepository) RemoveUsersFromAll(uIDs []model.UserID) error {
 		Delete(&datamodel.GroupUser{}).
 		Error
 }
+
+func (r *dbGroupRepository) ReplaceLabels(gID model.GroupID, ls model.Labels) error {
+	if gID == "" {
+		return errors.New("group id must not be empty")
+	}
+	if err := r.db.Where("group_id = ?", gID).Delete(&datamodel.GroupLabel{}).Error; err != nil {
+		return err
+	}
+
+	dmgls := datamodel.NewGroupLabels(gID, ls)
+	if len(dmgls) == 0 {
+		return nil
+	}
+	return r.db.Create(dmgls).Error
+}
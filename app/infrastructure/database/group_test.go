@@ -1,6 +1,7 @@
 package database_test
 
 import (
+	"database/sql/driver"
 	"errors"
 	"regexp"
 	"strings"
@@ -18,25 +19,33 @@ import (
 
 func TestDatabase_dbGroupRepository_Find(t *testing.T) {
 	tests := []struct {
-		name           string
-		gID            model.GroupID
-		want           *model.Group
-		wantErr        error
-		dbGroupErr     error
-		dbGroupUserErr error
+		name            string
+		gID             model.GroupID
+		want            *model.Group
+		wantErr         error
+		dbGroupErr      error
+		dbGroupUserErr  error
+		dbGroupLabelErr error
 	}{
 		{
 			name: "Returns a group",
 			gID:  model.GroupID("TEST_GROUP_ID"),
-			want: model.MustNewGroup(
-				"TEST_GROUP_ID",
-				"TEST_GROUP_NAME",
-				[]model.UserID{
-					"TEST_USER_ID_1",
-					"TEST_USER_ID_2",
-					"TEST_USER_ID_3",
-				},
-			),
+			want: func() *model.Group {
+				g := model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{
+						"TEST_USER_ID_1",
+						"TEST_USER_ID_2",
+						"TEST_USER_ID_3",
+					},
+				)
+				if err := g.ChangeLabels(model.Labels{"team": "payments"}); err != nil {
+					panic(err)
+				}
+				g.ChangeTimestamps(model.NewTimestamps("", time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)))
+				return g
+			}(),
 			wantErr:        nil,
 			dbGroupErr:     nil,
 			dbGroupUserErr: nil,
@@ -65,6 +74,13 @@ func TestDatabase_dbGroupRepository_Find(t *testing.T) {
 			dbGroupErr:     nil,
 			dbGroupUserErr: errors.New("an error occurred"),
 		},
+		{
+			name:            "DB grouplabel error",
+			gID:             model.GroupID("TEST_GROUP_ID"),
+			want:            nil,
+			wantErr:         errors.New("an error occurred"),
+			dbGroupLabelErr: errors.New("an error occurred"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -88,7 +104,7 @@ func TestDatabase_dbGroupRepository_Find(t *testing.T) {
 			} else {
 				groupRows := sqlmock.
 					NewRows([]string{"id", "name", "created_at", "updated_at"}).
-					AddRow(tt.want.ID(), tt.want.Name(), now, now)
+					AddRow(tt.want.ID(), tt.want.Name(), tt.want.Timestamps().CreatedAt, tt.want.Timestamps().UpdatedAt)
 				groupsExpectQuery.WillReturnRows(groupRows)
 
 				groupUsersSQL := "SELECT * FROM `group_users` WHERE group_id = ?"
@@ -104,6 +120,21 @@ func TestDatabase_dbGroupRepository_Find(t *testing.T) {
 						groupUserRows.AddRow(tt.gID, uID, now)
 					}
 					groupUsersExpectQuery.WillReturnRows(groupUserRows)
+
+					groupLabelsSQL := "SELECT * FROM `group_labels` WHERE group_id = ?"
+					groupLabelsExpectQuery := mock.
+						ExpectQuery(regexp.QuoteMeta(groupLabelsSQL)).
+						WithArgs(tt.gID)
+					if tt.dbGroupLabelErr != nil {
+						groupLabelsExpectQuery.WillReturnError(tt.dbGroupLabelErr)
+					} else {
+						groupLabelRows := sqlmock.NewRows([]string{"group_id", "key", "value"})
+						ls := tt.want.Labels()
+						for _, k := range ls.Keys() {
+							groupLabelRows.AddRow(tt.gID, k, ls[k])
+						}
+						groupLabelsExpectQuery.WillReturnRows(groupLabelRows)
+					}
 				}
 			}
 
@@ -137,6 +168,48 @@ func TestDatabase_dbGroupRepository_Find(t *testing.T) {
 	}
 }
 
+func TestDatabase_dbGroupRepository_FindForUpdate(t *testing.T) {
+	mock, db := dbMock(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	defer sqlDB.Close()
+
+	gID := model.GroupID("TEST_GROUP_ID")
+	want := model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", []model.UserID{"TEST_USER_ID_1"})
+
+	mock.
+		ExpectQuery(regexp.QuoteMeta(
+			"SELECT * FROM `groups` WHERE `groups`.`id` = ? ORDER BY `groups`.`id` LIMIT 1 FOR UPDATE",
+		)).
+		WithArgs(gID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(gID, "TEST_GROUP_NAME"))
+	mock.
+		ExpectQuery(regexp.QuoteMeta("SELECT * FROM `group_users` WHERE group_id = ? FOR UPDATE")).
+		WithArgs(gID).
+		WillReturnRows(sqlmock.NewRows([]string{"group_id", "user_id"}).AddRow(gID, "TEST_USER_ID_1"))
+	mock.
+		ExpectQuery(regexp.QuoteMeta("SELECT * FROM `group_labels` WHERE group_id = ? FOR UPDATE")).
+		WithArgs(gID).
+		WillReturnRows(sqlmock.NewRows([]string{"group_id", "key", "value"}))
+
+	r := &database.DBGroupRepository{}
+	r.SetDB(db)
+
+	got, err := r.FindForUpdate(gID)
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	if diff := cmp.Diff(got, want, cmp.AllowUnexported(model.Group{})); diff != "" {
+		t.Errorf("r.FindForUpdate(%s)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s", gID, got, want, diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func TestDatabase_dbGroupRepository_List(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -255,7 +328,7 @@ func TestDatabase_dbGroupRepository_List(t *testing.T) {
 				now := time.Now()
 				groupRows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"})
 				for _, g := range tt.groups {
-					groupRows.AddRow(g.ID(), g.Name(), now, now)
+					groupRows.AddRow(g.ID(), g.Name(), g.Timestamps().CreatedAt, g.Timestamps().UpdatedAt)
 				}
 				groupsExpectQuery.WillReturnRows(groupRows)
 
@@ -274,6 +347,7 @@ func TestDatabase_dbGroupRepository_List(t *testing.T) {
 							}
 						}
 						groupUsersExpectQuery.WillReturnRows(groupUserRows)
+						expectGroupLabelsQuery(t, mock, tt.groups)
 					}
 				}
 			}
@@ -317,6 +391,7 @@ func TestDatabase_dbGroupRepository_List_FilterByUserIDs(t *testing.T) {
 		wantErr           error
 		wantGroupUsersSQL string
 		wantGroupsSQL     string
+		wantMembersSQL    string
 		dbGroupUsersErr   error
 		dbGroupsErr       error
 	}{
@@ -329,7 +404,7 @@ func TestDatabase_dbGroupRepository_List_FilterByUserIDs(t *testing.T) {
 				model.MustNewGroup(
 					"TEST_GROUP_ID_1",
 					"TEST_GROUP_NAME_1",
-					[]model.UserID{"TEST_USER_ID_1"},
+					[]model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_3"},
 				),
 				model.MustNewGroup(
 					"TEST_GROUP_ID_2",
@@ -341,7 +416,7 @@ func TestDatabase_dbGroupRepository_List_FilterByUserIDs(t *testing.T) {
 				model.MustNewGroup(
 					"TEST_GROUP_ID_1",
 					"TEST_GROUP_NAME_1",
-					[]model.UserID{"TEST_USER_ID_1"},
+					[]model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_3"},
 				),
 				model.MustNewGroup(
 					"TEST_GROUP_ID_2",
@@ -350,7 +425,8 @@ func TestDatabase_dbGroupRepository_List_FilterByUserIDs(t *testing.T) {
 				),
 			},
 			wantGroupUsersSQL: "SELECT * FROM `group_users` WHERE user_id IN (?,?)",
-			wantGroupsSQL:     "SELECT * FROM `groups` WHERE group_id IN (?,?)",
+			wantGroupsSQL:     "SELECT * FROM `groups` WHERE id IN (?,?)",
+			wantMembersSQL:    "SELECT * FROM `group_users` WHERE group_id IN (?,?)",
 			wantErr:           nil,
 			dbGroupUsersErr:   nil,
 			dbGroupsErr:       nil,
@@ -400,7 +476,7 @@ func TestDatabase_dbGroupRepository_List_FilterByUserIDs(t *testing.T) {
 			},
 			want:              nil,
 			wantGroupUsersSQL: "SELECT * FROM `group_users` WHERE user_id IN (?,?)",
-			wantGroupsSQL:     "SELECT * FROM `groups` WHERE group_id IN (?,?)",
+			wantGroupsSQL:     "SELECT * FROM `groups` WHERE id IN (?,?)",
 			wantErr:           errors.New("an error occurred"),
 			dbGroupUsersErr:   nil,
 			dbGroupsErr:       errors.New("an error occurred"),
@@ -428,7 +504,9 @@ func TestDatabase_dbGroupRepository_List_FilterByUserIDs(t *testing.T) {
 						NewRows([]string{"group_id", "user_id", "created_at"})
 					for _, g := range tt.groups {
 						for _, uID := range g.UserIDs() {
-							groupUserRows.AddRow(g.ID(), uID, now)
+							if containsUserID(tt.filter.UserIDs, uID) {
+								groupUserRows.AddRow(g.ID(), uID, now)
+							}
 						}
 					}
 					groupUsersExpectQuery.WillReturnRows(groupUserRows)
@@ -441,9 +519,20 @@ func TestDatabase_dbGroupRepository_List_FilterByUserIDs(t *testing.T) {
 							now := time.Now()
 							groupRows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"})
 							for _, g := range tt.groups {
-								groupRows.AddRow(g.ID(), g.Name(), now, now)
+								groupRows.AddRow(g.ID(), g.Name(), g.Timestamps().CreatedAt, g.Timestamps().UpdatedAt)
 							}
 							groupsExpectQuery.WillReturnRows(groupRows)
+
+							if tt.wantMembersSQL != "" {
+								memberRows := sqlmock.NewRows([]string{"group_id", "user_id", "created_at"})
+								for _, g := range tt.groups {
+									for _, uID := range g.UserIDs() {
+										memberRows.AddRow(g.ID(), uID, now)
+									}
+								}
+								mock.ExpectQuery(regexp.QuoteMeta(tt.wantMembersSQL)).WillReturnRows(memberRows)
+								expectGroupLabelsQuery(t, mock, tt.groups)
+							}
 						}
 					}
 				}
@@ -479,6 +568,207 @@ func TestDatabase_dbGroupRepository_List_FilterByUserIDs(t *testing.T) {
 	}
 }
 
+// expectGroupLabelsQuery expects the query of the labels of the groups, which returns their labels.
+func expectGroupLabelsQuery(t *testing.T, mock sqlmock.Sqlmock, gs model.Groups) {
+	t.Helper()
+
+	rows := sqlmock.NewRows([]string{"group_id", "key", "value"})
+	for _, g := range gs {
+		ls := g.Labels()
+		for _, k := range ls.Keys() {
+			rows.AddRow(g.ID(), k, ls[k])
+		}
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `group_labels` WHERE group_id IN (")).
+		WithArgs(toDriverValues[model.GroupID](t, gs.IDs()...)...).
+		WillReturnRows(rows)
+}
+
+func containsUserID(uIDs []model.UserID, uID model.UserID) bool {
+	for _, v := range uIDs {
+		if v == uID {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDatabase_dbGroupRepository_List_Page(t *testing.T) {
+	mock, db := dbMock(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	defer sqlDB.Close()
+
+	f := repository.GroupListFilter{AfterID: "TEST_GROUP_ID_1", Limit: 2}
+	want := model.Groups{
+		model.MustNewGroup("TEST_GROUP_ID_2", "TEST_GROUP_NAME_2", []model.UserID{"TEST_USER_ID_1"}),
+		model.MustNewGroup("TEST_GROUP_ID_3", "TEST_GROUP_NAME_3", []model.UserID{"TEST_USER_ID_2"}),
+	}
+
+	now := time.Now()
+	groupRows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"})
+	groupUserRows := sqlmock.NewRows([]string{"group_id", "user_id", "created_at"})
+	for _, g := range want {
+		groupRows.AddRow(g.ID(), g.Name(), g.Timestamps().CreatedAt, g.Timestamps().UpdatedAt)
+		for _, uID := range g.UserIDs() {
+			groupUserRows.AddRow(g.ID(), uID, now)
+		}
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `groups` WHERE id > ? ORDER BY id LIMIT 2")).
+		WithArgs(f.AfterID).
+		WillReturnRows(groupRows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `group_users` WHERE group_id IN (?,?)")).
+		WithArgs(toDriverValues[model.GroupID](t, want.IDs()...)...).
+		WillReturnRows(groupUserRows)
+	expectGroupLabelsQuery(t, mock, want)
+
+	r := &database.DBGroupRepository{}
+	r.SetDB(db)
+
+	got, err := r.List(f)
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	if diff := cmp.Diff(got, want, cmp.AllowUnexported(model.Group{})); diff != "" {
+		t.Errorf(
+			"r.List(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+			f, got, want, diff,
+		)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestDatabase_dbGroupRepository_List_FilterByMembers(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   repository.GroupListFilter
+		want     model.Groups
+		wantSQL  string
+		wantArgs []driver.Value
+	}{
+		{
+			name: "Returns groups by ids",
+			filter: repository.GroupListFilter{
+				GroupIDs: []model.GroupID{"TEST_GROUP_ID_1", "TEST_GROUP_ID_2"},
+			},
+			want: model.Groups{
+				model.MustNewGroup("TEST_GROUP_ID_2", "TEST_GROUP_NAME_2", nil),
+			},
+			wantSQL:  "SELECT * FROM `groups` WHERE id IN (?,?)",
+			wantArgs: []driver.Value{"TEST_GROUP_ID_1", "TEST_GROUP_ID_2"},
+		},
+		{
+			name: "Returns groups which all the users belong to",
+			filter: repository.GroupListFilter{
+				AllUserIDs: []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2", "TEST_USER_ID_1"},
+			},
+			want: model.Groups{
+				model.MustNewGroup("TEST_GROUP_ID_1", "TEST_GROUP_NAME_1", []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"}),
+			},
+			wantSQL:  "SELECT * FROM `groups` WHERE id IN (SELECT group_id FROM `group_users` WHERE user_id IN (?,?) GROUP BY group_id HAVING COUNT(*) = ?)",
+			wantArgs: []driver.Value{"TEST_USER_ID_1", "TEST_USER_ID_2", 2},
+		},
+		{
+			name: "Returns groups with no users",
+			filter: repository.GroupListFilter{
+				NoUsers: true,
+			},
+			want: model.Groups{
+				model.MustNewGroup("TEST_GROUP_ID_2", "TEST_GROUP_NAME_2", nil),
+			},
+			wantSQL: "SELECT * FROM `groups` WHERE NOT EXISTS (SELECT 1 FROM `group_users` WHERE `group_users`.group_id = `groups`.id)",
+		},
+		{
+			name: "Returns groups with at least the number of users",
+			filter: repository.GroupListFilter{
+				MinUsers: 5,
+			},
+			want: model.Groups{
+				model.MustNewGroup("TEST_GROUP_ID_3", "TEST_GROUP_NAME_3", []model.UserID{
+					"TEST_USER_ID_1", "TEST_USER_ID_2", "TEST_USER_ID_3", "TEST_USER_ID_4", "TEST_USER_ID_5",
+				}),
+			},
+			wantSQL:  "SELECT * FROM `groups` WHERE id IN (SELECT group_id FROM `group_users` GROUP BY group_id HAVING COUNT(*) >= ?)",
+			wantArgs: []driver.Value{5},
+		},
+		{
+			name: "Returns groups by the label selector",
+			filter: repository.GroupListFilter{
+				LabelSelector: model.LabelSelector{
+					{Key: "team", Operator: model.LabelOperatorIn, Values: []string{"payments", "search"}},
+					{Key: "env", Operator: model.LabelOperatorNotEquals, Values: []string{"prod"}},
+					{Key: "tier", Operator: model.LabelOperatorExists},
+				},
+			},
+			want: model.Groups{
+				func() *model.Group {
+					g := model.MustNewGroup("TEST_GROUP_ID_1", "TEST_GROUP_NAME_1", nil)
+					if err := g.ChangeLabels(model.Labels{"team": "payments", "tier": "1"}); err != nil {
+						panic(err)
+					}
+					return g
+				}(),
+			},
+			wantSQL: "SELECT * FROM `groups` WHERE " +
+				"(id IN (SELECT group_id FROM `group_labels` WHERE `key` = ? AND value IN (?,?))) AND " +
+				"(id NOT IN (SELECT group_id FROM `group_labels` WHERE `key` = ? AND value IN (?))) AND " +
+				"id IN (SELECT group_id FROM `group_labels` WHERE `key` = ?)",
+			wantArgs: []driver.Value{"team", "payments", "search", "env", "prod", "tier"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db := dbMock(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			defer sqlDB.Close()
+
+			now := time.Now()
+			groupRows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"})
+			groupUserRows := sqlmock.NewRows([]string{"group_id", "user_id", "created_at"})
+			for _, g := range tt.want {
+				groupRows.AddRow(g.ID(), g.Name(), g.Timestamps().CreatedAt, g.Timestamps().UpdatedAt)
+				for _, uID := range g.UserIDs() {
+					groupUserRows.AddRow(g.ID(), uID, now)
+				}
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(tt.wantSQL)).
+				WithArgs(tt.wantArgs...).
+				WillReturnRows(groupRows)
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `group_users` WHERE group_id IN (?)")).
+				WithArgs(toDriverValues[model.GroupID](t, tt.want.IDs()...)...).
+				WillReturnRows(groupUserRows)
+			expectGroupLabelsQuery(t, mock, tt.want)
+
+			r := &database.DBGroupRepository{}
+			r.SetDB(db)
+
+			got, err := r.List(tt.filter)
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(model.Group{})); diff != "" {
+				t.Errorf(
+					"r.List(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+					tt.filter, got, tt.want, diff,
+				)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
 func TestDatabase_dbGroupRepository_Create(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -504,7 +794,7 @@ func TestDatabase_dbGroupRepository_Create(t *testing.T) {
 				"TEST_GROUP_NAME",
 				[]model.UserID{},
 			),
-			wantGroupsSQL:     "INSERT INTO `groups` (`id`,`name`) VALUES (?,?)",
+			wantGroupsSQL:     "INSERT INTO `groups` (`id`,`name`,`join_policy`,`attributes`,`created_at`,`created_by`,`updated_at`,`updated_by`) VALUES (?,?,?,?,?,?,?,?)",
 			wantGroupUsersSQL: "",
 			wantErr:           nil,
 		},
@@ -518,7 +808,7 @@ func TestDatabase_dbGroupRepository_Create(t *testing.T) {
 			dbGroupsErr:       errors.New("an error occurred"),
 			dbGroupUsersErr:   nil,
 			want:              nil,
-			wantGroupsSQL:     "INSERT INTO `groups` (`id`,`name`) VALUES (?,?)",
+			wantGroupsSQL:     "INSERT INTO `groups` (`id`,`name`,`join_policy`,`attributes`,`created_at`,`created_by`,`updated_at`,`updated_by`) VALUES (?,?,?,?,?,?,?,?)",
 			wantGroupUsersSQL: "",
 			wantErr:           errors.New("an error occurred"),
 		},
@@ -544,7 +834,7 @@ func TestDatabase_dbGroupRepository_Create(t *testing.T) {
 					"TEST_USER_ID_3",
 				},
 			),
-			wantGroupsSQL:     "INSERT INTO `groups` (`id`,`name`) VALUES (?,?)",
+			wantGroupsSQL:     "INSERT INTO `groups` (`id`,`name`,`join_policy`,`attributes`,`created_at`,`created_by`,`updated_at`,`updated_by`) VALUES (?,?,?,?,?,?,?,?)",
 			wantGroupUsersSQL: "INSERT INTO `group_users` (`group_id`,`user_id`) VALUES (?,?),(?,?),(?,?)",
 			wantErr:           nil,
 		},
@@ -570,7 +860,7 @@ func TestDatabase_dbGroupRepository_Create(t *testing.T) {
 					"TEST_USER_ID_3",
 				},
 			),
-			wantGroupsSQL:     "INSERT INTO `groups` (`id`,`name`) VALUES (?,?)",
+			wantGroupsSQL:     "INSERT INTO `groups` (`id`,`name`,`join_policy`,`attributes`,`created_at`,`created_by`,`updated_at`,`updated_by`) VALUES (?,?,?,?,?,?,?,?)",
 			wantGroupUsersSQL: "INSERT INTO `group_users` (`group_id`,`user_id`) VALUES (?,?),(?,?),(?,?)",
 			wantErr:           errors.New("an error occurred"),
 		},
@@ -587,7 +877,11 @@ func TestDatabase_dbGroupRepository_Create(t *testing.T) {
 
 			groupsExpectExec := mock.
 				ExpectExec(regexp.QuoteMeta(tt.wantGroupsSQL)).
-				WithArgs(tt.group.ID(), tt.group.Name())
+				WithArgs(
+					tt.group.ID(), tt.group.Name(), tt.group.JoinPolicy(), nil,
+					tt.group.Timestamps().CreatedAt, tt.group.Timestamps().CreatedBy,
+					tt.group.Timestamps().UpdatedAt, tt.group.Timestamps().UpdatedBy,
+				)
 
 			if tt.dbGroupsErr != nil {
 				groupsExpectExec.WillReturnError(tt.dbGroupsErr)
@@ -668,8 +962,13 @@ func TestDatabase_dbGroupRepository_Update(t *testing.T) {
 			defer sqlDB.Close()
 
 			expectExec := mock.
-				ExpectExec(regexp.QuoteMeta("UPDATE `groups` SET `name`=? WHERE `id` = ?")).
-				WithArgs(tt.group.Name(), tt.group.ID())
+				ExpectExec(regexp.QuoteMeta(
+					"UPDATE `groups` SET `attributes`=?,`join_policy`=?,`name`=?,`updated_at`=?,`updated_by`=? WHERE `id` = ?",
+				)).
+				WithArgs(
+					nil, tt.group.JoinPolicy(), tt.group.Name(),
+					tt.group.Timestamps().UpdatedAt, tt.group.Timestamps().UpdatedBy, tt.group.ID(),
+				)
 
 			if tt.wantErr != nil {
 				expectExec.WillReturnError(tt.wantErr)
@@ -1138,3 +1437,90 @@ func TestDatabase_dbGroupRepository_RemoveUsersFromAll(t *testing.T) {
 		})
 	}
 }
+
+func TestDatabase_dbGroupRepository_ReplaceLabels(t *testing.T) {
+	type args struct {
+		gID model.GroupID
+		ls  model.Labels
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		wantInsertSQL string
+		wantInsertArg []driver.Value
+		dbErr         error
+		wantErr       error
+	}{
+		{
+			name: "Replaces the labels of the group",
+			args: args{
+				gID: "TEST_GROUP_ID",
+				ls:  model.Labels{"team": "payments", "env": "prod"},
+			},
+			wantInsertSQL: "INSERT INTO `group_labels` (`group_id`,`key`,`value`) VALUES (?,?,?),(?,?,?)",
+			wantInsertArg: []driver.Value{"TEST_GROUP_ID", "env", "prod", "TEST_GROUP_ID", "team", "payments"},
+		},
+		{
+			name: "Removes all the labels of the group",
+			args: args{gID: "TEST_GROUP_ID"},
+		},
+		{
+			name:    "Returns error if the group id is empty",
+			args:    args{ls: model.Labels{"team": "payments"}},
+			wantErr: errors.New("group id must not be empty"),
+		},
+		{
+			name:    "DB error",
+			args:    args{gID: "TEST_GROUP_ID", ls: model.Labels{"team": "payments"}},
+			dbErr:   errors.New("an error occurred"),
+			wantErr: errors.New("an error occurred"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db := dbMock(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			defer sqlDB.Close()
+
+			if tt.args.gID != "" {
+				expectExec := mock.
+					ExpectExec(regexp.QuoteMeta("DELETE FROM `group_labels` WHERE group_id = ?")).
+					WithArgs(tt.args.gID)
+				if tt.dbErr != nil {
+					expectExec.WillReturnError(tt.dbErr)
+				} else {
+					expectExec.WillReturnResult(sqlmock.NewResult(0, 2))
+				}
+			}
+			if tt.wantInsertSQL != "" {
+				mock.ExpectExec(regexp.QuoteMeta(tt.wantInsertSQL)).
+					WithArgs(tt.wantInsertArg...).
+					WillReturnResult(sqlmock.NewResult(0, int64(len(tt.args.ls))))
+			}
+
+			r := &database.DBGroupRepository{}
+			r.SetDB(db)
+
+			err = r.ReplaceLabels(tt.args.gID, tt.args.ls)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("want an error, but has no error")
+				}
+				if err.Error() != tt.wantErr.Error() {
+					t.Errorf("r.ReplaceLabels(%s, %v)=%v; want %v", tt.args.gID, tt.args.ls, err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+type dbGroupInvitationRepository struct {
+	db *gorm.DB
+}
+
+func (r *dbGroupInvitationRepository) Find(id model.GroupInvitationID) (*model.GroupInvitation, error) {
+	dminv := &datamodel.GroupInvitation{ID: string(id)}
+
+	if err := r.db.First(dminv).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dminv.ToModel(), nil
+}
+
+func (r *dbGroupInvitationRepository) List(f repository.GroupInvitationListFilter) (model.GroupInvitations, error) {
+	db := r.db
+	if f.GroupID != "" {
+		db = db.Where("group_id = ?", f.GroupID)
+	}
+	switch {
+	case f.InviteeUserID != "" && f.InviteeEmail != "":
+		db = db.Where(
+			"(invitee_user_id = ? OR LOWER(invitee_email) = ?)",
+			f.InviteeUserID, strings.ToLower(f.InviteeEmail),
+		)
+	case f.InviteeUserID != "":
+		db = db.Where("invitee_user_id = ?", f.InviteeUserID)
+	case f.InviteeEmail != "":
+		db = db.Where("LOWER(invitee_email) = ?", strings.ToLower(f.InviteeEmail))
+	}
+	if f.Status != "" {
+		db = db.Where("status = ?", f.Status)
+	}
+	if !f.ExpiresAfter.IsZero() {
+		db = db.Where("expires_at > ?", f.ExpiresAfter)
+	}
+
+	var dminvs datamodel.GroupInvitations
+	if err := db.Order("created_at").Find(&dminvs).Error; err != nil {
+		return nil, err
+	}
+
+	return dminvs.ToModel(), nil
+}
+
+func (r *dbGroupInvitationRepository) Create(inv *model.GroupInvitation) error {
+	return r.db.Create(datamodel.NewGroupInvitation(inv)).Error
+}
+
+func (r *dbGroupInvitationRepository) Update(inv *model.GroupInvitation) error {
+	if inv.ID() == "" {
+		return errors.New("group invitation id must not be empty")
+	}
+
+	dminv := datamodel.NewGroupInvitation(inv)
+	return r.db.Model(&datamodel.GroupInvitation{ID: dminv.ID}).
+		Updates(map[string]any{
+			"status":       dminv.Status,
+			"responded_at": dminv.RespondedAt,
+		}).Error
+}
+
+func (r *dbGroupInvitationRepository) ExpirePending(at time.Time) (int, error) {
+	result := r.db.Model(&datamodel.GroupInvitation{}).
+		Where("status = ? AND expires_at <= ?", model.GroupInvitationStatusPending, at).
+		Update("status", model.GroupInvitationStatusExpired)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return int(result.RowsAffected), nil
+}
+
+func (r *dbGroupInvitationRepository) DeleteByGroupID(gID model.GroupID) error {
+	if gID == "" {
+		return errors.New("group id must not be empty")
+	}
+	return r.db.Where("group_id = ?", gID).Delete(&datamodel.GroupInvitation{}).Error
+}
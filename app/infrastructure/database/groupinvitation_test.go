@@ -0,0 +1,109 @@
+package database_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database"
+)
+
+func TestDatabase_dbGroupInvitationRepository_List(t *testing.T) {
+	at := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name     string
+		f        repository.GroupInvitationListFilter
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name: "Lists the pending invitations of the group",
+			f: repository.GroupInvitationListFilter{
+				GroupID:      "TEST_GROUP_ID",
+				Status:       model.GroupInvitationStatusPending,
+				ExpiresAfter: at,
+			},
+			wantSQL: "SELECT * FROM `group_invitations` " +
+				"WHERE group_id = ? AND status = ? AND expires_at > ? ORDER BY created_at",
+			wantArgs: []any{"TEST_GROUP_ID", model.GroupInvitationStatusPending, at},
+		},
+		{
+			name: "Lists the invitations for the user or the email",
+			f: repository.GroupInvitationListFilter{
+				InviteeUserID: "TEST_USER_ID",
+				InviteeEmail:  "Test@Example.com",
+			},
+			wantSQL: "SELECT * FROM `group_invitations` " +
+				"WHERE (invitee_user_id = ? OR LOWER(invitee_email) = ?) ORDER BY created_at",
+			wantArgs: []any{"TEST_USER_ID", "test@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db := dbMock(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			defer sqlDB.Close()
+
+			mock.
+				ExpectQuery(regexp.QuoteMeta(tt.wantSQL)).
+				WithArgs(toDriverValues(t, tt.wantArgs...)...).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			r := &database.DBGroupInvitationRepository{}
+			r.SetDB(db)
+
+			got, err := r.List(tt.f)
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if len(got) != 0 {
+				t.Errorf("r.List(%v)=%v, nil; want empty, nil", tt.f, got)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDatabase_dbGroupInvitationRepository_ExpirePending(t *testing.T) {
+	at := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	mock, db := dbMock(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	defer sqlDB.Close()
+
+	mock.
+		ExpectExec(regexp.QuoteMeta(
+			"UPDATE `group_invitations` SET `status`=? WHERE status = ? AND expires_at <= ?",
+		)).
+		WithArgs(model.GroupInvitationStatusExpired, model.GroupInvitationStatusPending, at).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	r := &database.DBGroupInvitationRepository{}
+	r.SetDB(db)
+
+	got, err := r.ExpirePending(at)
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	if got != 2 {
+		t.Errorf("r.ExpirePending(%v)=%d, nil; want 2, nil", at, got)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+type dbGroupJoinRequestRepository struct {
+	db *gorm.DB
+}
+
+func (r *dbGroupJoinRequestRepository) Find(id model.GroupJoinRequestID) (*model.GroupJoinRequest, error) {
+	dmjr := &datamodel.GroupJoinRequest{ID: string(id)}
+
+	if err := r.db.First(dmjr).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dmjr.ToModel(), nil
+}
+
+func (r *dbGroupJoinRequestRepository) List(f repository.GroupJoinRequestListFilter) (model.GroupJoinRequests, error) {
+	db := r.db
+	if f.GroupID != "" {
+		db = db.Where("group_id = ?", f.GroupID)
+	}
+	if f.UserID != "" {
+		db = db.Where("user_id = ?", f.UserID)
+	}
+	if f.Status != "" {
+		db = db.Where("status = ?", f.Status)
+	}
+
+	var dmjrs datamodel.GroupJoinRequests
+	if err := db.Order("created_at").Find(&dmjrs).Error; err != nil {
+		return nil, err
+	}
+
+	return dmjrs.ToModel(), nil
+}
+
+func (r *dbGroupJoinRequestRepository) Create(jr *model.GroupJoinRequest) error {
+	return r.db.Create(datamodel.NewGroupJoinRequest(jr)).Error
+}
+
+func (r *dbGroupJoinRequestRepository) Update(jr *model.GroupJoinRequest) error {
+	if jr.ID() == "" {
+		return errors.New("group join request id must not be empty")
+	}
+
+	dmjr := datamodel.NewGroupJoinRequest(jr)
+	return r.db.Model(&datamodel.GroupJoinRequest{ID: dmjr.ID}).
+		Updates(map[string]any{
+			"status":       dmjr.Status,
+			"responded_at": dmjr.RespondedAt,
+			"responded_by": dmjr.RespondedBy,
+		}).Error
+}
+
+func (r *dbGroupJoinRequestRepository) DeleteByGroupID(gID model.GroupID) error {
+	if gID == "" {
+		return errors.New("group id must not be empty")
+	}
+	return r.db.Where("group_id = ?", gID).Delete(&datamodel.GroupJoinRequest{}).Error
+}
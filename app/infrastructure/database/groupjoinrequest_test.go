@@ -0,0 +1,112 @@
+package database_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database"
+)
+
+func TestDatabase_dbGroupJoinRequestRepository_List(t *testing.T) {
+	tests := []struct {
+		name     string
+		f        repository.GroupJoinRequestListFilter
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name: "Lists the pending join requests to the group",
+			f: repository.GroupJoinRequestListFilter{
+				GroupID: "TEST_GROUP_ID",
+				Status:  model.GroupJoinRequestStatusPending,
+			},
+			wantSQL:  "SELECT * FROM `group_join_requests` WHERE group_id = ? AND status = ? ORDER BY created_at",
+			wantArgs: []any{"TEST_GROUP_ID", model.GroupJoinRequestStatusPending},
+		},
+		{
+			name: "Lists the join requests of the user",
+			f: repository.GroupJoinRequestListFilter{
+				UserID: "TEST_USER_ID",
+			},
+			wantSQL:  "SELECT * FROM `group_join_requests` WHERE user_id = ? ORDER BY created_at",
+			wantArgs: []any{"TEST_USER_ID"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db := dbMock(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			defer sqlDB.Close()
+
+			mock.
+				ExpectQuery(regexp.QuoteMeta(tt.wantSQL)).
+				WithArgs(toDriverValues(t, tt.wantArgs...)...).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			r := &database.DBGroupJoinRequestRepository{}
+			r.SetDB(db)
+
+			got, err := r.List(tt.f)
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if len(got) != 0 {
+				t.Errorf("r.List(%v)=%v, nil; want empty, nil", tt.f, got)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDatabase_dbGroupJoinRequestRepository_Update(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	respondedAt := createdAt.Add(time.Hour)
+	jr := model.MustNewGroupJoinRequest(
+		"TEST_GROUP_JOIN_REQUEST_ID",
+		"TEST_GROUP_ID",
+		"TEST_USER_ID",
+		createdAt,
+		model.GroupJoinRequestState{
+			Status:      model.GroupJoinRequestStatusApproved,
+			RespondedAt: respondedAt,
+			RespondedBy: "TEST_ACTOR",
+		},
+	)
+
+	mock, db := dbMock(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	defer sqlDB.Close()
+
+	mock.
+		ExpectExec(regexp.QuoteMeta(
+			"UPDATE `group_join_requests` SET `responded_at`=?,`responded_by`=?,`status`=? WHERE `id` = ?",
+		)).
+		WithArgs(respondedAt, "TEST_ACTOR", "APPROVED", "TEST_GROUP_JOIN_REQUEST_ID").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r := &database.DBGroupJoinRequestRepository{}
+	r.SetDB(db)
+
+	if err := r.Update(jr); err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
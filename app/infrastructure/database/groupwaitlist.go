@@ -0,0 +1,46 @@
+package database
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+type dbGroupWaitlistRepository struct {
+	db *gorm.DB
+}
+
+func (r *dbGroupWaitlistRepository) Find(gID model.GroupID) (*model.GroupWaitlist, error) {
+	var dmes datamodel.GroupWaitlistEntries
+	if err := r.db.Where("group_id = ?", gID).Order("position").Find(&dmes).Error; err != nil {
+		return nil, err
+	}
+	return dmes.ToModel(gID)
+}
+
+func (r *dbGroupWaitlistRepository) Save(w *model.GroupWaitlist) error {
+	if err := r.Delete(w.GroupID()); err != nil {
+		return err
+	}
+	if len(w.UserIDs()) == 0 {
+		return nil
+	}
+	return r.db.Create(datamodel.NewGroupWaitlistEntries(w)).Error
+}
+
+func (r *dbGroupWaitlistRepository) Delete(gID model.GroupID) error {
+	if gID == "" {
+		return errors.New("group id must not be empty")
+	}
+	return r.db.Where("group_id = ?", gID).Delete(&datamodel.GroupWaitlistEntry{}).Error
+}
+
+func (r *dbGroupWaitlistRepository) RemoveUsersFromAll(uIDs []model.UserID) error {
+	if len(uIDs) == 0 {
+		return errors.New("user ids must not be empty")
+	}
+	return r.db.Where("user_id IN (?)", uIDs).Delete(&datamodel.GroupWaitlistEntry{}).Error
+}
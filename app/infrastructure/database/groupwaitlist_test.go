@@ -0,0 +1,145 @@
+package database_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database"
+)
+
+func TestDatabase_dbGroupWaitlistRepository_Find(t *testing.T) {
+	tests := []struct {
+		name string
+		rows *sqlmock.Rows
+		want *model.GroupWaitlist
+	}{
+		{
+			name: "Returns the waitlist in the order of position",
+			rows: sqlmock.NewRows([]string{"group_id", "user_id", "position"}).
+				AddRow("TEST_GROUP_ID", "TEST_USER_ID_2", 0).
+				AddRow("TEST_GROUP_ID", "TEST_USER_ID_1", 1),
+			want: model.MustNewGroupWaitlist("TEST_GROUP_ID", []model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_1"}),
+		},
+		{
+			name: "Returns an empty waitlist if no user is waitlisted",
+			rows: sqlmock.NewRows([]string{"group_id", "user_id", "position"}),
+			want: model.MustNewGroupWaitlist("TEST_GROUP_ID", nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db := dbMock(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			defer sqlDB.Close()
+
+			mock.
+				ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `group_waitlist_entries` WHERE group_id = ? ORDER BY position",
+				)).
+				WithArgs("TEST_GROUP_ID").
+				WillReturnRows(tt.rows)
+
+			r := &database.DBGroupWaitlistRepository{}
+			r.SetDB(db)
+
+			got, err := r.Find("TEST_GROUP_ID")
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(model.GroupWaitlist{})); diff != "" {
+				t.Errorf("r.Find(_)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s", got, tt.want, diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDatabase_dbGroupWaitlistRepository_Save(t *testing.T) {
+	tests := []struct {
+		name       string
+		w          *model.GroupWaitlist
+		wantInsert bool
+	}{
+		{
+			name:       "Replaces the entries of the group",
+			w:          model.MustNewGroupWaitlist("TEST_GROUP_ID", []model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_1"}),
+			wantInsert: true,
+		},
+		{
+			name:       "Only deletes the entries if the waitlist is empty",
+			w:          model.MustNewGroupWaitlist("TEST_GROUP_ID", nil),
+			wantInsert: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db := dbMock(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			defer sqlDB.Close()
+
+			mock.
+				ExpectExec(regexp.QuoteMeta("DELETE FROM `group_waitlist_entries` WHERE group_id = ?")).
+				WithArgs("TEST_GROUP_ID").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			if tt.wantInsert {
+				mock.
+					ExpectExec(regexp.QuoteMeta(
+						"INSERT INTO `group_waitlist_entries` (`group_id`,`user_id`,`position`) VALUES (?,?,?),(?,?,?)",
+					)).
+					WithArgs("TEST_GROUP_ID", "TEST_USER_ID_2", 0, "TEST_GROUP_ID", "TEST_USER_ID_1", 1).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			}
+
+			r := &database.DBGroupWaitlistRepository{}
+			r.SetDB(db)
+
+			if err := r.Save(tt.w); err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDatabase_dbGroupWaitlistRepository_RemoveUsersFromAll(t *testing.T) {
+	mock, db := dbMock(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	defer sqlDB.Close()
+
+	mock.
+		ExpectExec(regexp.QuoteMeta("DELETE FROM `group_waitlist_entries` WHERE user_id IN (?)")).
+		WithArgs("TEST_USER_ID").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	r := &database.DBGroupWaitlistRepository{}
+	r.SetDB(db)
+
+	if err := r.RemoveUsersFromAll([]model.UserID{"TEST_USER_ID"}); err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+// errNumberDuplicateEntry is the number of the MySQL error of a duplicate entry for a unique key.
+const errNumberDuplicateEntry = 1062
+
+type dbIdempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+func (r *dbIdempotencyKeyRepository) Find(key string) (*model.IdempotencyKey, error) {
+	dmk := &datamodel.IdempotencyKey{}
+
+	if err := r.db.Where("`key` = ?", key).First(dmk).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dmk.ToModel(), nil
+}
+
+func (r *dbIdempotencyKeyRepository) Create(k *model.IdempotencyKey) error {
+	err := r.db.Create(datamodel.NewIdempotencyKey(k)).Error
+	var me *mysql.MySQLError
+	if errors.As(err, &me) && me.Number == errNumberDuplicateEntry {
+		return repository.ErrIdempotencyKeyExists
+	}
+	return err
+}
+
+func (r *dbIdempotencyKeyRepository) Update(k *model.IdempotencyKey) error {
+	if k.Key() == "" {
+		return errors.New("idempotency key must not be empty")
+	}
+
+	return r.db.Save(datamodel.NewIdempotencyKey(k)).Error
+}
+
+func (r *dbIdempotencyKeyRepository) Delete(key string) error {
+	return r.db.Where("`key` = ?", key).Delete(&datamodel.IdempotencyKey{}).Error
+}
+
+func (r *dbIdempotencyKeyRepository) DeleteExpired(at time.Time) (int, error) {
+	res := r.db.Where("expires_at <= ?", at).Delete(&datamodel.IdempotencyKey{})
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	return int(res.RowsAffected), nil
+}
@@ -0,0 +1,169 @@
+package database_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database"
+)
+
+func TestDatabase_dbIdempotencyKeyRepository_Find(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		wantNil bool
+	}{
+		{
+			name: "Returns the idempotency key",
+			rows: sqlmock.NewRows([]string{
+				"key", "fingerprint", "status_code", "content_type", "response_body", "created_at", "expires_at",
+			}).AddRow(
+				"TEST_IDEMPOTENCY_KEY", "TEST_FINGERPRINT", 201, "application/json", []byte(`{}`),
+				createdAt, createdAt.Add(time.Hour),
+			),
+		},
+		{
+			name:    "Returns nil if not found",
+			rows:    sqlmock.NewRows([]string{"key"}),
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db := dbMock(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			defer sqlDB.Close()
+
+			mock.
+				ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `idempotency_keys` WHERE `key` = ? ORDER BY `idempotency_keys`.`key` LIMIT 1",
+				)).
+				WithArgs("TEST_IDEMPOTENCY_KEY").
+				WillReturnRows(tt.rows)
+
+			r := &database.DBIdempotencyKeyRepository{}
+			r.SetDB(db)
+
+			got, err := r.Find("TEST_IDEMPOTENCY_KEY")
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("r.Find()=%v, nil; want nil, nil", got)
+				}
+			} else if got.Fingerprint() != "TEST_FINGERPRINT" || !got.IsCompleted() {
+				t.Errorf("r.Find()=%v, nil; want the completed key", got)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDatabase_dbIdempotencyKeyRepository_Create(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	k := model.MustNewIdempotencyKey(
+		"TEST_IDEMPOTENCY_KEY", "TEST_FINGERPRINT", model.IdempotentResponse{}, createdAt, createdAt.Add(time.Hour),
+	)
+	tests := []struct {
+		name    string
+		dbErr   error
+		wantErr error
+	}{
+		{
+			name: "Creates the idempotency key",
+		},
+		{
+			name:    "Error the key exists",
+			dbErr:   &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'TEST_IDEMPOTENCY_KEY' for key 'PRIMARY'"},
+			wantErr: repository.ErrIdempotencyKeyExists,
+		},
+		{
+			name:    "Error",
+			dbErr:   errors.New("an error occurred"),
+			wantErr: errors.New("an error occurred"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db := dbMock(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			defer sqlDB.Close()
+
+			expectExec := mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `idempotency_keys`"))
+			if tt.dbErr != nil {
+				expectExec.WillReturnError(tt.dbErr)
+			} else {
+				expectExec.WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			r := &database.DBIdempotencyKeyRepository{}
+			r.SetDB(db)
+
+			err = r.Create(k)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("want an error, but has no error")
+				}
+				if !errors.Is(err, tt.wantErr) && err.Error() != tt.wantErr.Error() {
+					t.Errorf("r.Create(%v)=%v; want %v", k, err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDatabase_dbIdempotencyKeyRepository_DeleteExpired(t *testing.T) {
+	mock, db := dbMock(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	defer sqlDB.Close()
+
+	at := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.
+		ExpectExec(regexp.QuoteMeta("DELETE FROM `idempotency_keys` WHERE expires_at <= ?")).
+		WithArgs(at).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	r := &database.DBIdempotencyKeyRepository{}
+	r.SetDB(db)
+
+	got, err := r.DeleteExpired(at)
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	if got != 3 {
+		t.Errorf("r.DeleteExpired(%v)=%d, nil; want 3, nil", at, got)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
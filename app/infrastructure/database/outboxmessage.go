@@ -0,0 +1,110 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+type dbOutboxMessageRepository struct {
+	db *gorm.DB
+}
+
+func (r *dbOutboxMessageRepository) List(f repository.OutboxMessageListFilter) (model.OutboxMessages, error) {
+	db := r.db
+	if f.Unpublished {
+		db = db.Where("published_at IS NULL")
+	}
+	if !f.AvailableAt.IsZero() {
+		db = db.Where("next_attempt_at <= ?", f.AvailableAt)
+	}
+	order := "seq"
+	if f.Sequenced || f.AfterID != "" {
+		db = db.Where("published_seq IS NOT NULL")
+		order = "published_seq"
+	}
+	if f.AfterID != "" {
+		db = db.Where("published_seq > (SELECT published_seq FROM outbox_messages WHERE id = ?)", f.AfterID)
+	}
+	if f.AggregateType != "" {
+		db = db.Where("aggregate_type = ?", f.AggregateType)
+	}
+	if f.AggregateID != "" {
+		db = db.Where("aggregate_id = ?", f.AggregateID)
+	}
+	if f.Limit > 0 {
+		db = db.Limit(f.Limit)
+	}
+
+	var dmms datamodel.OutboxMessages
+	if err := db.Order(order).Find(&dmms).Error; err != nil {
+		return nil, err
+	}
+
+	return dmms.ToModel(), nil
+}
+
+func (r *dbOutboxMessageRepository) Create(ms model.OutboxMessages) error {
+	if len(ms) == 0 {
+		return nil
+	}
+	return r.db.Create(datamodel.NewOutboxMessages(ms)).Error
+}
+
+func (r *dbOutboxMessageRepository) Update(m *model.OutboxMessage) error {
+	if m.ID() == "" {
+		return errors.New("outbox message id must not be empty")
+	}
+
+	dmm := datamodel.NewOutboxMessage(m)
+	return r.db.Model(&datamodel.OutboxMessage{ID: dmm.ID}).
+		Updates(map[string]any{
+			"attempts":        dmm.Attempts,
+			"last_error":      dmm.LastError,
+			"next_attempt_at": dmm.NextAttemptAt,
+			"published_at":    dmm.PublishedAt,
+		}).Error
+}
+
+func (r *dbOutboxMessageRepository) Sequence(ms model.OutboxMessages) error {
+	if len(ms) == 0 {
+		return nil
+	}
+
+	// Locking the last sequence makes the transactions giving the next ones wait for this to commit.
+	var last int64
+	if err := r.db.Model(&datamodel.OutboxMessage{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("COALESCE(MAX(published_seq), 0)").
+		Scan(&last).Error; err != nil {
+		return err
+	}
+
+	for _, m := range ms {
+		res := r.db.Exec(
+			"UPDATE outbox_messages SET published_seq = ? WHERE id = ? AND published_seq IS NULL",
+			last+1, m.ID(),
+		)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected > 0 {
+			last++
+		}
+	}
+	return nil
+}
+
+func (r *dbOutboxMessageRepository) DeletePublished(at time.Time) (int, error) {
+	res := r.db.Where("published_at <= ?", at).Delete(&datamodel.OutboxMessage{})
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	return int(res.RowsAffected), nil
+}
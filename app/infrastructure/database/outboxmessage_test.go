@@ -0,0 +1,373 @@
+package database_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database"
+)
+
+func TestDatabase_dbOutboxMessageRepository_List(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	availableAt := time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		filter   repository.OutboxMessageListFilter
+		want     model.OutboxMessages
+		wantSQL  string
+		wantArgs []any
+		wantErr  error
+		dbErr    error
+	}{
+		{
+			name:   "Returns outbox messages",
+			filter: repository.OutboxMessageListFilter{},
+			want: model.OutboxMessages{
+				model.MustNewOutboxMessage(
+					"TEST_OUTBOX_MESSAGE_ID",
+					model.DomainEventTypeUserCreated,
+					model.AggregateTypeUser,
+					"TEST_USER_ID",
+					[]byte(`{"userId":"TEST_USER_ID"}`),
+					occurredAt,
+					model.OutboxDelivery{},
+				),
+			},
+			wantSQL: "SELECT * FROM `outbox_messages` ORDER BY seq",
+		},
+		{
+			name: "Returns unpublished and available outbox messages",
+			filter: repository.OutboxMessageListFilter{
+				Unpublished: true,
+				AvailableAt: availableAt,
+				Limit:       10,
+			},
+			want: model.OutboxMessages{
+				model.MustNewOutboxMessage(
+					"TEST_OUTBOX_MESSAGE_ID",
+					model.DomainEventTypeUserCreated,
+					model.AggregateTypeUser,
+					"TEST_USER_ID",
+					[]byte(`{"userId":"TEST_USER_ID"}`),
+					occurredAt,
+					model.OutboxDelivery{},
+				),
+			},
+			wantSQL:  "SELECT * FROM `outbox_messages` WHERE published_at IS NULL AND next_attempt_at <= ? ORDER BY seq LIMIT 10",
+			wantArgs: []any{availableAt},
+		},
+		{
+			name: "Returns outbox messages of the aggregate after the message",
+			filter: repository.OutboxMessageListFilter{
+				AfterID:       "TEST_OUTBOX_MESSAGE_ID_0",
+				AggregateType: model.AggregateTypeUser,
+				AggregateID:   "TEST_USER_ID",
+			},
+			want: model.OutboxMessages{
+				model.MustNewOutboxMessage(
+					"TEST_OUTBOX_MESSAGE_ID",
+					model.DomainEventTypeUserCreated,
+					model.AggregateTypeUser,
+					"TEST_USER_ID",
+					[]byte(`{"userId":"TEST_USER_ID"}`),
+					occurredAt,
+					model.OutboxDelivery{},
+				),
+			},
+			wantSQL: "SELECT * FROM `outbox_messages` WHERE published_seq IS NOT NULL " +
+				"AND published_seq > (SELECT published_seq FROM outbox_messages WHERE id = ?) " +
+				"AND aggregate_type = ? AND aggregate_id = ? ORDER BY published_seq",
+			wantArgs: []any{"TEST_OUTBOX_MESSAGE_ID_0", model.AggregateTypeUser, "TEST_USER_ID"},
+		},
+		{
+			name: "Returns outbox messages in the order they were sequenced",
+			filter: repository.OutboxMessageListFilter{
+				AfterID: "TEST_OUTBOX_MESSAGE_ID_0",
+			},
+			want: model.OutboxMessages{
+				model.MustNewOutboxMessage(
+					"TEST_OUTBOX_MESSAGE_ID_B",
+					model.DomainEventTypeUserCreated,
+					model.AggregateTypeUser,
+					"TEST_USER_ID",
+					[]byte(`{"userId":"TEST_USER_ID"}`),
+					occurredAt,
+					model.OutboxDelivery{},
+				),
+				model.MustNewOutboxMessage(
+					"TEST_OUTBOX_MESSAGE_ID_A",
+					model.DomainEventTypeUserUpdated,
+					model.AggregateTypeUser,
+					"TEST_USER_ID",
+					[]byte(`{"userId":"TEST_USER_ID"}`),
+					occurredAt,
+					model.OutboxDelivery{},
+				),
+			},
+			wantSQL: "SELECT * FROM `outbox_messages` WHERE published_seq IS NOT NULL " +
+				"AND published_seq > (SELECT published_seq FROM outbox_messages WHERE id = ?) ORDER BY published_seq",
+			wantArgs: []any{"TEST_OUTBOX_MESSAGE_ID_0"},
+		},
+		{
+			name: "Returns sequenced outbox messages",
+			filter: repository.OutboxMessageListFilter{
+				Sequenced: true,
+			},
+			want: model.OutboxMessages{
+				model.MustNewOutboxMessage(
+					"TEST_OUTBOX_MESSAGE_ID",
+					model.DomainEventTypeUserCreated,
+					model.AggregateTypeUser,
+					"TEST_USER_ID",
+					[]byte(`{"userId":"TEST_USER_ID"}`),
+					occurredAt,
+					model.OutboxDelivery{},
+				),
+			},
+			wantSQL: "SELECT * FROM `outbox_messages` WHERE published_seq IS NOT NULL ORDER BY published_seq",
+		},
+		{
+			name:    "Error",
+			filter:  repository.OutboxMessageListFilter{},
+			wantSQL: "SELECT * FROM `outbox_messages` ORDER BY seq",
+			wantErr: errors.New("an error occurred"),
+			dbErr:   errors.New("an error occurred"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db := dbMock(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			defer sqlDB.Close()
+
+			expectQuery := mock.ExpectQuery(regexp.QuoteMeta(tt.wantSQL))
+			if len(tt.wantArgs) > 0 {
+				expectQuery = expectQuery.WithArgs(toDriverValues(t, tt.wantArgs...)...)
+			}
+
+			if tt.dbErr != nil {
+				expectQuery.WillReturnError(tt.dbErr)
+			} else {
+				rows := sqlmock.NewRows([]string{
+					"id", "event_type", "aggregate_type", "aggregate_id", "payload",
+					"occurred_at", "attempts", "last_error", "next_attempt_at", "published_at", "seq",
+				})
+				for i, m := range tt.want {
+					d := m.Delivery()
+					rows.AddRow(
+						m.ID(),
+						m.EventType(),
+						m.AggregateType(),
+						m.AggregateID(),
+						m.Payload(),
+						m.OccurredAt(),
+						d.Attempts,
+						d.LastError,
+						d.NextAttemptAt,
+						nil,
+						i+1,
+					)
+				}
+				expectQuery.WillReturnRows(rows)
+			}
+
+			r := &database.DBOutboxMessageRepository{}
+			r.SetDB(db)
+
+			got, err := r.List(tt.filter)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("want an error, but has no error")
+				}
+				if err.Error() != tt.wantErr.Error() {
+					t.Errorf("r.List(%v)=_, %v; want _, %v", tt.filter, err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("want no err, but has error %v", err)
+				}
+				if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(model.OutboxMessage{})); diff != "" {
+					t.Errorf(
+						"r.List(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+						tt.filter, got, tt.want, diff,
+					)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDatabase_dbOutboxMessageRepository_Update(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	publishedAt := time.Date(2023, 1, 2, 3, 4, 6, 0, time.UTC)
+	m := model.MustNewOutboxMessage(
+		"TEST_OUTBOX_MESSAGE_ID",
+		model.DomainEventTypeUserCreated,
+		model.AggregateTypeUser,
+		"TEST_USER_ID",
+		[]byte(`{"userId":"TEST_USER_ID"}`),
+		occurredAt,
+		model.OutboxDelivery{},
+	)
+	m.MarkPublished(publishedAt)
+
+	tests := []struct {
+		name    string
+		m       *model.OutboxMessage
+		wantErr error
+		dbErr   error
+	}{
+		{
+			name: "Updates the delivery of an outbox message",
+			m:    m,
+		},
+		{
+			name:    "Error",
+			m:       m,
+			wantErr: errors.New("an error occurred"),
+			dbErr:   errors.New("an error occurred"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db := dbMock(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			defer sqlDB.Close()
+
+			sql := "UPDATE `outbox_messages` " +
+				"SET `attempts`=?,`last_error`=?,`next_attempt_at`=?,`published_at`=? WHERE `id` = ?"
+			expectExec := mock.
+				ExpectExec(regexp.QuoteMeta(sql)).
+				WithArgs(1, "", occurredAt, publishedAt, tt.m.ID())
+			if tt.dbErr != nil {
+				expectExec.WillReturnError(tt.dbErr)
+			} else {
+				expectExec.WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			r := &database.DBOutboxMessageRepository{}
+			r.SetDB(db)
+
+			err = r.Update(tt.m)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("want an error, but has no error")
+				}
+				if err.Error() != tt.wantErr.Error() {
+					t.Errorf("r.Update(%v)=%v; want %v", tt.m, err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDatabase_dbOutboxMessageRepository_Sequence(t *testing.T) {
+	mock, db := dbMock(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	defer sqlDB.Close()
+
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	newMessage := func(id model.OutboxMessageID) *model.OutboxMessage {
+		return model.MustNewOutboxMessage(
+			id,
+			model.DomainEventTypeUserCreated,
+			model.AggregateTypeUser,
+			"TEST_USER_ID",
+			[]byte(`{"userId":"TEST_USER_ID"}`),
+			occurredAt,
+			model.OutboxDelivery{},
+		)
+	}
+	ms := model.OutboxMessages{
+		newMessage("TEST_OUTBOX_MESSAGE_ID_1"),
+		newMessage("TEST_OUTBOX_MESSAGE_ID_2"),
+		newMessage("TEST_OUTBOX_MESSAGE_ID_3"),
+	}
+
+	sql := "UPDATE outbox_messages SET published_seq = ? WHERE id = ? AND published_seq IS NULL"
+	mock.
+		ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(published_seq), 0) FROM `outbox_messages` FOR UPDATE")).
+		WillReturnRows(sqlmock.NewRows([]string{"COALESCE(MAX(published_seq), 0)"}).AddRow(5))
+	mock.
+		ExpectExec(regexp.QuoteMeta(sql)).
+		WithArgs(6, "TEST_OUTBOX_MESSAGE_ID_1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// The message sequenced already keeps its sequence, and the next one takes the one skipped.
+	mock.
+		ExpectExec(regexp.QuoteMeta(sql)).
+		WithArgs(7, "TEST_OUTBOX_MESSAGE_ID_2").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.
+		ExpectExec(regexp.QuoteMeta(sql)).
+		WithArgs(7, "TEST_OUTBOX_MESSAGE_ID_3").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r := &database.DBOutboxMessageRepository{}
+	r.SetDB(db)
+
+	if err := r.Sequence(ms); err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestDatabase_dbOutboxMessageRepository_DeletePublished(t *testing.T) {
+	mock, db := dbMock(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	defer sqlDB.Close()
+
+	at := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.
+		ExpectExec(regexp.QuoteMeta("DELETE FROM `outbox_messages` WHERE published_at <= ?")).
+		WithArgs(at).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	r := &database.DBOutboxMessageRepository{}
+	r.SetDB(db)
+
+	got, err := r.DeletePublished(at)
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	if got != 3 {
+		t.Errorf("r.DeletePublished(%v)=%d, nil; want 3, nil", at, got)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
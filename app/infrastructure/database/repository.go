@@ -74,3 +74,63 @@ func (r *dbRepository) Group() repository.GroupRepositoryQuery {
 func (tx *dbTransaction) Group() repository.GroupRepositoryCommand {
 	return &dbGroupRepository{db: tx.db}
 }
+func (r *dbRepository) GroupWaitlist() repository.GroupWaitlistRepositoryQuery {
+	return &dbGroupWaitlistRepository{db: r.db}
+}
+func (tx *dbTransaction) GroupWaitlist() repository.GroupWaitlistRepositoryCommand {
+	return &dbGroupWaitlistRepository{db: tx.db}
+}
+func (r *dbRepository) GroupInvitation() repository.GroupInvitationRepositoryQuery {
+	return &dbGroupInvitationRepository{db: r.db}
+}
+func (tx *dbTransaction) GroupInvitation() repository.GroupInvitationRepositoryCommand {
+	return &dbGroupInvitationRepository{db: tx.db}
+}
+func (r *dbRepository) GroupJoinRequest() repository.GroupJoinRequestRepositoryQuery {
+	return &dbGroupJoinRequestRepository{db: r.db}
+}
+func (tx *dbTransaction) GroupJoinRequest() repository.GroupJoinRequestRepositoryCommand {
+	return &dbGroupJoinRequestRepository{db: tx.db}
+}
+func (r *dbRepository) Subgroup() repository.SubgroupRepositoryQuery {
+	return &dbSubgroupRepository{db: r.db}
+}
+func (tx *dbTransaction) Subgroup() repository.SubgroupRepositoryCommand {
+	return &dbSubgroupRepository{db: tx.db}
+}
+func (r *dbRepository) AuditEvent() repository.AuditEventRepositoryQuery {
+	return &dbAuditEventRepository{db: r.db}
+}
+func (tx *dbTransaction) AuditEvent() repository.AuditEventRepositoryCommand {
+	return &dbAuditEventRepository{db: tx.db}
+}
+func (r *dbRepository) OutboxMessage() repository.OutboxMessageRepositoryQuery {
+	return &dbOutboxMessageRepository{db: r.db}
+}
+func (tx *dbTransaction) OutboxMessage() repository.OutboxMessageRepositoryCommand {
+	return &dbOutboxMessageRepository{db: tx.db}
+}
+func (r *dbRepository) WebhookSubscription() repository.WebhookSubscriptionRepositoryQuery {
+	return &dbWebhookSubscriptionRepository{db: r.db}
+}
+func (tx *dbTransaction) WebhookSubscription() repository.WebhookSubscriptionRepositoryCommand {
+	return &dbWebhookSubscriptionRepository{db: tx.db}
+}
+func (r *dbRepository) WebhookDelivery() repository.WebhookDeliveryRepositoryQuery {
+	return &dbWebhookDeliveryRepository{db: r.db}
+}
+func (tx *dbTransaction) WebhookDelivery() repository.WebhookDeliveryRepositoryCommand {
+	return &dbWebhookDeliveryRepository{db: tx.db}
+}
+func (r *dbRepository) IdempotencyKey() repository.IdempotencyKeyRepositoryQuery {
+	return &dbIdempotencyKeyRepository{db: r.db}
+}
+func (tx *dbTransaction) IdempotencyKey() repository.IdempotencyKeyRepositoryCommand {
+	return &dbIdempotencyKeyRepository{db: tx.db}
+}
+func (r *dbRepository) AttributeSchema() repository.AttributeSchemaRepositoryQuery {
+	return &dbAttributeSchemaRepository{db: r.db}
+}
+func (tx *dbTransaction) AttributeSchema() repository.AttributeSchemaRepositoryCommand {
+	return &dbAttributeSchemaRepository{db: tx.db}
+}
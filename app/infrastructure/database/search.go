@@ -0,0 +1,75 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm/clause"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// searchClauses returns the condition of the rows whose columns contain the normalized query, and the order of them
+// by the rank model's SearchRank gives and then by id. The id is in the order since gorm drops an order by
+// an expression merged with another order. The columns are compared in their case-insensitive and accent-sensitive
+// collation utf8mb4_0900_as_ci, which matches as model.NormalizeSearchQuery lowers the query.
+// The ngram full-text index of exactly the columns finds the rows having the characters of the query in a row,
+// and the LIKE keeps those containing the query itself, as the index skips the punctuation and a phrase matches
+// across the columns.
+func searchClauses(q string, columns ...string) (clause.Expr, clause.OrderBy) {
+	escaped := likeEscaper.Replace(q)
+	contains := "%" + escaped + "%"
+
+	conds := make([]string, len(columns))
+	condVars := make([]any, len(columns))
+	for i, c := range columns {
+		conds[i] = c + " LIKE ?"
+		condVars[i] = contains
+	}
+	condSQL := "(" + strings.Join(conds, " OR ") + ")"
+	if phrase, ok := fulltextPhrase(q); ok {
+		condSQL = "MATCH(" + strings.Join(columns, ", ") + ") AGAINST(? IN BOOLEAN MODE) AND " + condSQL
+		condVars = append([]any{phrase}, condVars...)
+	}
+
+	var (
+		cases    []string
+		caseVars []any
+	)
+	for level, m := range []struct {
+		op      string
+		pattern string
+	}{
+		{op: "=", pattern: q},
+		{op: "LIKE", pattern: escaped + "%"},
+		{op: "LIKE", pattern: contains},
+	} {
+		for i, c := range columns {
+			cases = append(cases, fmt.Sprintf("WHEN %s %s ? THEN %d", c, m.op, level*len(columns)+i))
+			caseVars = append(caseVars, m.pattern)
+		}
+	}
+
+	cond := clause.Expr{
+		SQL:  condSQL,
+		Vars: condVars,
+	}
+	order := clause.OrderBy{
+		Expression: clause.Expr{
+			SQL:                "CASE " + strings.Join(cases, " ") + " END, id",
+			Vars:               caseVars,
+			WithoutParentheses: true,
+		},
+	}
+	return cond, order
+}
+
+// fulltextPhrase returns the boolean mode phrase of the query, whose double quotes are dropped as the ngram parser
+// skips the punctuation anyway. A query with no letter nor digit has no token to look up by, so it has no phrase.
+func fulltextPhrase(q string) (string, bool) {
+	if strings.IndexFunc(q, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+		return "", false
+	}
+	return `"` + strings.ReplaceAll(q, `"`, " ") + `"`, true
+}
@@ -0,0 +1,123 @@
+package database_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository/repositorytest"
+	"github.com/toshiykst/go-layerd-architecture/app/env"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database"
+)
+
+// mysqlRepository returns the repository of the MySQL the environment configures, skipping the test without it.
+func mysqlRepository(t *testing.T) repository.Repository {
+	t.Helper()
+
+	c, err := env.NewConfig()
+	if err != nil {
+		t.Fatalf("env.NewConfig()=_, %v; want _, nil", err)
+	}
+	if c.DBHost == "" {
+		t.Skip("MYSQL_HOST is not set")
+	}
+	return database.NewDBRepository(context.Background(), database.Config{
+		User:     c.DBUser,
+		Password: c.DBPassword,
+		Host:     c.DBHost,
+		DBName:   c.DBName,
+	})
+}
+
+func TestDatabase_UserSearch(t *testing.T) {
+	repositorytest.RunUserSearchTests(t, mysqlRepository(t))
+}
+
+func TestDatabase_GroupSearch(t *testing.T) {
+	repositorytest.RunGroupSearchTests(t, mysqlRepository(t))
+}
+
+func TestDatabase_UserFilter(t *testing.T) {
+	repositorytest.RunUserFilterTests(t, mysqlRepository(t))
+}
+
+func TestDatabase_GroupFilter(t *testing.T) {
+	repositorytest.RunGroupFilterTests(t, mysqlRepository(t))
+}
+
+func TestDatabase_OutboxMessageOrder(t *testing.T) {
+	repositorytest.RunOutboxMessageOrderTests(t, mysqlRepository(t))
+}
+
+func TestDatabase_GroupMembership(t *testing.T) {
+	repositorytest.RunGroupMembershipTests(t, mysqlRepository(t))
+}
+
+func TestDatabase_dbUserRepository_List_Search(t *testing.T) {
+	order := "ORDER BY " +
+		"CASE WHEN name = ? THEN 0 WHEN email = ? THEN 1 WHEN name LIKE ? THEN 2 WHEN email LIKE ? THEN 3 " +
+		"WHEN name LIKE ? THEN 4 WHEN email LIKE ? THEN 5 END, id LIMIT 10"
+
+	tests := []struct {
+		name  string
+		q     string
+		query string
+		args  []driver.Value
+	}{
+		{
+			name: "Finds the rows by the full-text index and the LIKE",
+			q:    `a_b%"`,
+			query: "SELECT * FROM `users` WHERE MATCH(name, email) AGAINST(? IN BOOLEAN MODE) AND " +
+				"(name LIKE ? OR email LIKE ?) " + order,
+			args: []driver.Value{
+				`"a_b% "`,
+				`%a\_b\%"%`, `%a\_b\%"%`,
+				`a_b%"`, `a_b%"`,
+				`a\_b\%"%`, `a\_b\%"%`,
+				`%a\_b\%"%`, `%a\_b\%"%`,
+			},
+		},
+		{
+			name:  "Finds the rows by the LIKE for a query with no letter nor digit",
+			q:     "_%",
+			query: "SELECT * FROM `users` WHERE (name LIKE ? OR email LIKE ?) " + order,
+			args: []driver.Value{
+				`%\_\%%`, `%\_\%%`,
+				"_%", "_%",
+				`\_\%%`, `\_\%%`,
+				`%\_\%%`, `%\_\%%`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db := dbMock(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			defer sqlDB.Close()
+
+			mock.
+				ExpectQuery(regexp.QuoteMeta(tt.query)).
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}))
+
+			r := &database.DBUserRepository{}
+			r.SetDB(db)
+
+			if _, err := r.List(repository.UserListFilter{Query: tt.q, Limit: 10}); err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
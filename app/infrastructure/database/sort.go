@@ -0,0 +1,19 @@
+package database
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+// sortColumns are the columns of the fields the rows are sorted by.
+var sortColumns = map[model.SortField]string{
+	model.SortFieldCreatedAt: "created_at",
+	model.SortFieldUpdatedAt: "updated_at",
+}
+
+// orderBySort orders the rows by the column of the field of the sort order and then by id.
+func orderBySort(db *gorm.DB, o model.SortOrder) *gorm.DB {
+	return db.Order(clause.OrderByColumn{Column: clause.Column{Name: sortColumns[o.Field]}, Desc: o.Desc}).Order("id")
+}
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"errors"
+	"sort"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+// descendantIDsSQL walks down the subgroups from the group. UNION, unlike UNION ALL, drops the groups reached
+// before, so that the recursion ends even if the subgroups have a cycle.
+const descendantIDsSQL = "WITH RECURSIVE `descendants` (`group_id`) AS (" +
+	"SELECT `child_group_id` FROM `subgroups` WHERE `parent_group_id` = ? " +
+	"UNION " +
+	"SELECT `subgroups`.`child_group_id` FROM `subgroups` " +
+	"JOIN `descendants` ON `subgroups`.`parent_group_id` = `descendants`.`group_id`" +
+	") SELECT `group_id` FROM `descendants` ORDER BY `group_id`"
+
+// ancestorIDsSQL walks up the subgroups from the groups as descendantIDsSQL walks down.
+const ancestorIDsSQL = "WITH RECURSIVE `ancestors` (`group_id`) AS (" +
+	"SELECT `parent_group_id` FROM `subgroups` WHERE `child_group_id` IN (?) " +
+	"UNION " +
+	"SELECT `subgroups`.`parent_group_id` FROM `subgroups` " +
+	"JOIN `ancestors` ON `subgroups`.`child_group_id` = `ancestors`.`group_id`" +
+	") SELECT `group_id` FROM `ancestors` ORDER BY `group_id`"
+
+type dbSubgroupRepository struct {
+	db *gorm.DB
+}
+
+func (r *dbSubgroupRepository) List(f repository.SubgroupListFilter) (model.Subgroups, error) {
+	db := r.db
+	if f.ParentID != "" {
+		db = db.Where("parent_group_id = ?", f.ParentID)
+	}
+	if f.ChildID != "" {
+		db = db.Where("child_group_id = ?", f.ChildID)
+	}
+
+	var dmss datamodel.Subgroups
+	if err := db.Order("child_group_id").Find(&dmss).Error; err != nil {
+		return nil, err
+	}
+
+	return dmss.ToModel(), nil
+}
+
+func (r *dbSubgroupRepository) ListDescendantIDs(gID model.GroupID) ([]model.GroupID, error) {
+	var gIDs []model.GroupID
+	if err := r.db.Raw(descendantIDsSQL, gID).Scan(&gIDs).Error; err != nil {
+		return nil, err
+	}
+	return gIDs, nil
+}
+
+func (r *dbSubgroupRepository) ListAncestorIDs(gIDs []model.GroupID) ([]model.GroupID, error) {
+	if len(gIDs) == 0 {
+		return nil, nil
+	}
+
+	var result []model.GroupID
+	if err := r.db.Raw(ancestorIDsSQL, gIDs).Scan(&result).Error; err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListAncestorIDsForUpdate walks up the subgroups level by level with SELECT ... FOR SHARE, unlike ancestorIDsSQL,
+// whose recursive reads are not locked. Each read locks the subgroups of the groups and the gaps of the index of
+// child_group_id where they are, so that a concurrent transaction nesting any of the groups waits for the transaction
+// or deadlocks with it, instead of nesting one of them into a cycle missed by both.
+func (r *dbSubgroupRepository) ListAncestorIDsForUpdate(gIDs []model.GroupID) ([]model.GroupID, error) {
+	var result []model.GroupID
+	reached := map[model.GroupID]bool{}
+	for len(gIDs) > 0 {
+		var dmss datamodel.Subgroups
+		if err := r.db.
+			Clauses(clause.Locking{Strength: "SHARE"}).
+			Where("child_group_id IN (?)", gIDs).
+			Find(&dmss).
+			Error; err != nil {
+			return nil, err
+		}
+
+		var next []model.GroupID
+		for _, s := range dmss.ToModel() {
+			if reached[s.ParentID()] {
+				continue
+			}
+			reached[s.ParentID()] = true
+			next = append(next, s.ParentID())
+		}
+		result = append(result, next...)
+		gIDs = next
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result, nil
+}
+
+func (r *dbSubgroupRepository) Create(s *model.Subgroup) error {
+	return r.db.Create(datamodel.NewSubgroup(s)).Error
+}
+
+func (r *dbSubgroupRepository) Delete(s *model.Subgroup) error {
+	return r.db.
+		Where("parent_group_id = ?", s.ParentID()).
+		Where("child_group_id = ?", s.ChildID()).
+		Delete(&datamodel.Subgroup{}).
+		Error
+}
+
+func (r *dbSubgroupRepository) DeleteByGroupID(gID model.GroupID) error {
+	if gID == "" {
+		return errors.New("group id must not be empty")
+	}
+	return r.db.
+		Where("parent_group_id = ? OR child_group_id = ?", gID, gID).
+		Delete(&datamodel.Subgroup{}).
+		Error
+}
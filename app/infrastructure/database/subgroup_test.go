@@ -0,0 +1,195 @@
+package database_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database"
+)
+
+func TestDatabase_dbSubgroupRepository_List(t *testing.T) {
+	mock, db := dbMock(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	defer sqlDB.Close()
+
+	mock.
+		ExpectQuery(regexp.QuoteMeta("SELECT * FROM `subgroups` WHERE parent_group_id = ? ORDER BY child_group_id")).
+		WithArgs("TEST_GROUP_ID_1").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"parent_group_id", "child_group_id"}).
+				AddRow("TEST_GROUP_ID_1", "TEST_GROUP_ID_2"),
+		)
+
+	r := &database.DBSubgroupRepository{}
+	r.SetDB(db)
+
+	f := repository.SubgroupListFilter{ParentID: "TEST_GROUP_ID_1"}
+	got, err := r.List(f)
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	want := model.Subgroups{model.MustNewSubgroup("TEST_GROUP_ID_1", "TEST_GROUP_ID_2")}
+	if diff := cmp.Diff(got, want, cmp.AllowUnexported(model.Subgroup{})); diff != "" {
+		t.Errorf("r.List(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s", f, got, want, diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestDatabase_dbSubgroupRepository_ListDescendantIDs(t *testing.T) {
+	mock, db := dbMock(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	defer sqlDB.Close()
+
+	mock.
+		ExpectQuery(regexp.QuoteMeta(
+			"WITH RECURSIVE `descendants` (`group_id`) AS (" +
+				"SELECT `child_group_id` FROM `subgroups` WHERE `parent_group_id` = ? " +
+				"UNION " +
+				"SELECT `subgroups`.`child_group_id` FROM `subgroups` " +
+				"JOIN `descendants` ON `subgroups`.`parent_group_id` = `descendants`.`group_id`" +
+				") SELECT `group_id` FROM `descendants` ORDER BY `group_id`",
+		)).
+		WithArgs("TEST_GROUP_ID_1").
+		WillReturnRows(sqlmock.NewRows([]string{"group_id"}).AddRow("TEST_GROUP_ID_2").AddRow("TEST_GROUP_ID_3"))
+
+	r := &database.DBSubgroupRepository{}
+	r.SetDB(db)
+
+	got, err := r.ListDescendantIDs("TEST_GROUP_ID_1")
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	want := []model.GroupID{"TEST_GROUP_ID_2", "TEST_GROUP_ID_3"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("r.ListDescendantIDs(TEST_GROUP_ID_1)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s", got, want, diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestDatabase_dbSubgroupRepository_ListAncestorIDs(t *testing.T) {
+	mock, db := dbMock(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	defer sqlDB.Close()
+
+	mock.
+		ExpectQuery(regexp.QuoteMeta(
+			"WITH RECURSIVE `ancestors` (`group_id`) AS ("+
+				"SELECT `parent_group_id` FROM `subgroups` WHERE `child_group_id` IN (?,?) "+
+				"UNION "+
+				"SELECT `subgroups`.`parent_group_id` FROM `subgroups` "+
+				"JOIN `ancestors` ON `subgroups`.`child_group_id` = `ancestors`.`group_id`"+
+				") SELECT `group_id` FROM `ancestors` ORDER BY `group_id`",
+		)).
+		WithArgs("TEST_GROUP_ID_2", "TEST_GROUP_ID_3").
+		WillReturnRows(sqlmock.NewRows([]string{"group_id"}).AddRow("TEST_GROUP_ID_1"))
+
+	r := &database.DBSubgroupRepository{}
+	r.SetDB(db)
+
+	gIDs := []model.GroupID{"TEST_GROUP_ID_2", "TEST_GROUP_ID_3"}
+	got, err := r.ListAncestorIDs(gIDs)
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	want := []model.GroupID{"TEST_GROUP_ID_1"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("r.ListAncestorIDs(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s", gIDs, got, want, diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestDatabase_dbSubgroupRepository_ListAncestorIDsForUpdate(t *testing.T) {
+	mock, db := dbMock(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	defer sqlDB.Close()
+
+	query := "SELECT * FROM `subgroups` WHERE child_group_id IN (?) FOR SHARE"
+	mock.
+		ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs("TEST_GROUP_ID_4").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"parent_group_id", "child_group_id"}).
+				AddRow("TEST_GROUP_ID_3", "TEST_GROUP_ID_4").
+				AddRow("TEST_GROUP_ID_2", "TEST_GROUP_ID_4"),
+		)
+	mock.
+		ExpectQuery(regexp.QuoteMeta("SELECT * FROM `subgroups` WHERE child_group_id IN (?,?) FOR SHARE")).
+		WithArgs("TEST_GROUP_ID_3", "TEST_GROUP_ID_2").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"parent_group_id", "child_group_id"}).
+				AddRow("TEST_GROUP_ID_1", "TEST_GROUP_ID_3").
+				AddRow("TEST_GROUP_ID_2", "TEST_GROUP_ID_3"),
+		)
+	mock.
+		ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs("TEST_GROUP_ID_1").
+		WillReturnRows(sqlmock.NewRows([]string{"parent_group_id", "child_group_id"}))
+
+	r := &database.DBSubgroupRepository{}
+	r.SetDB(db)
+
+	gIDs := []model.GroupID{"TEST_GROUP_ID_4"}
+	got, err := r.ListAncestorIDsForUpdate(gIDs)
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	want := []model.GroupID{"TEST_GROUP_ID_1", "TEST_GROUP_ID_2", "TEST_GROUP_ID_3"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("r.ListAncestorIDsForUpdate(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s", gIDs, got, want, diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestDatabase_dbSubgroupRepository_DeleteByGroupID(t *testing.T) {
+	mock, db := dbMock(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	defer sqlDB.Close()
+
+	mock.
+		ExpectExec(regexp.QuoteMeta("DELETE FROM `subgroups` WHERE parent_group_id = ? OR child_group_id = ?")).
+		WithArgs("TEST_GROUP_ID", "TEST_GROUP_ID").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	r := &database.DBSubgroupRepository{}
+	r.SetDB(db)
+
+	if err := r.DeleteByGroupID("TEST_GROUP_ID"); err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -32,6 +32,43 @@ func (r *dbUserRepository) List(f repository.UserListFilter) (model.Users, error
 	if len(f.UserIDs) > 0 {
 		db = db.Where("id IN (?)", f.UserIDs)
 	}
+	if len(f.Emails) > 0 {
+		db = db.Where("email IN (?)", f.Emails)
+	}
+	if len(f.GroupIDs) > 0 {
+		db = db.Where("id IN (SELECT user_id FROM `group_users` WHERE group_id IN (?))", f.GroupIDs)
+	}
+	if f.Status != "" {
+		db = db.Where("status = ?", f.Status)
+	}
+	db, err := whereAttributes(db, f.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	if f.NoGroup {
+		db = db.Where("NOT EXISTS (SELECT 1 FROM `group_users` WHERE `group_users`.user_id = `users`.id)")
+	}
+	if !f.CreatedAfter.IsZero() {
+		db = db.Where("created_at > ?", f.CreatedAfter)
+	}
+	if f.AfterID != "" {
+		db = db.Where("id > ?", f.AfterID)
+	}
+	if f.Query != "" {
+		cond, order := searchClauses(f.Query, "name", "email")
+		db = db.Where(cond)
+		if f.Sort.IsZero() {
+			db = db.Clauses(order)
+		}
+	}
+	if !f.Sort.IsZero() {
+		db = orderBySort(db, f.Sort)
+	} else if f.Query == "" && f.Limit > 0 {
+		db = db.Order("id")
+	}
+	if f.Limit > 0 {
+		db = db.Limit(f.Limit)
+	}
 
 	var dmus datamodel.Users
 	if err := db.Find(&dmus).Error; err != nil {
@@ -42,7 +79,7 @@ func (r *dbUserRepository) List(f repository.UserListFilter) (model.Users, error
 }
 
 func (r *dbUserRepository) Create(u *model.User) (*model.User, error) {
-	dmu := datamodel.NewUser(u.ID(), u.Name(), u.Email())
+	dmu := datamodel.NewUser(u.ID(), u.Name(), u.Email(), u.Status(), u.Attributes(), u.Timestamps())
 
 	if err := r.db.Create(dmu).Error; err != nil {
 		return nil, err
@@ -55,11 +92,19 @@ func (r *dbUserRepository) Update(u *model.User) error {
 	if u.ID() == "" {
 		return errors.New("user id must not be empty")
 	}
+	attrs, err := datamodel.AttributesValue(u.Attributes())
+	if err != nil {
+		return err
+	}
 
 	if err := r.db.Model(&datamodel.User{ID: string(u.ID())}).
 		Updates(map[string]any{
-			"name":  u.Name(),
-			"email": u.Email(),
+			"name":       u.Name(),
+			"email":      u.Email(),
+			"status":     u.Status(),
+			"attributes": attrs,
+			"updated_at": u.Timestamps().UpdatedAt,
+			"updated_by": u.Timestamps().UpdatedBy,
 		}).Error; err != nil {
 		return err
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
 	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
 	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
 )
 
 func TestDatabase_dbUserRepository_Find(t *testing.T) {
@@ -24,9 +25,13 @@ func TestDatabase_dbUserRepository_Find(t *testing.T) {
 		dbErr   error
 	}{
 		{
-			name:    "Returns a user",
-			uID:     model.UserID("TEST_USER_ID"),
-			want:    model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"),
+			name: "Returns a user",
+			uID:  model.UserID("TEST_USER_ID"),
+			want: func() *model.User {
+				u := model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL")
+				u.ChangeTimestamps(model.NewTimestamps("TEST_ACTOR", time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)))
+				return u
+			}(),
 			wantErr: nil,
 			dbErr:   nil,
 		},
@@ -63,10 +68,10 @@ func TestDatabase_dbUserRepository_Find(t *testing.T) {
 			if tt.dbErr != nil {
 				expectQuery.WillReturnError(tt.dbErr)
 			} else {
-				now := time.Now()
+				ts := tt.want.Timestamps()
 				rows := sqlmock.
-					NewRows([]string{"id", "name", "email", "created_at", "updated_at"}).
-					AddRow(tt.want.ID(), tt.want.Name(), tt.want.Email(), now, now)
+					NewRows([]string{"id", "name", "email", "created_at", "created_by", "updated_at", "updated_by"}).
+					AddRow(tt.want.ID(), tt.want.Name(), tt.want.Email(), ts.CreatedAt, ts.CreatedBy, ts.UpdatedAt, ts.UpdatedBy)
 				expectQuery.WillReturnRows(rows)
 			}
 
@@ -157,6 +162,152 @@ func TestDatabase_dbUserRepository_List(t *testing.T) {
 			wantErr: nil,
 			dbErr:   nil,
 		},
+		{
+			name: "Returns users filtered by emails",
+			filter: repository.UserListFilter{
+				Emails: []string{"TEST_USER_EMAIL_2"},
+			},
+			want: model.Users{
+				model.MustNewUser(
+					"TEST_USER_ID_2",
+					"TEST_USER_NAME_2",
+					"TEST_USER_EMAIL_2",
+				),
+			},
+			wantSQL: "SELECT * FROM `users` WHERE email IN (?)",
+			wantErr: nil,
+			dbErr:   nil,
+		},
+		{
+			name: "Returns users in any of the groups",
+			filter: repository.UserListFilter{
+				GroupIDs: []model.GroupID{"TEST_GROUP_ID_1", "TEST_GROUP_ID_2"},
+			},
+			want: model.Users{
+				model.MustNewUser(
+					"TEST_USER_ID_1",
+					"TEST_USER_NAME_1",
+					"TEST_USER_EMAIL_1",
+				),
+			},
+			wantSQL: "SELECT * FROM `users` WHERE id IN (SELECT user_id FROM `group_users` WHERE group_id IN (?,?))",
+			wantErr: nil,
+			dbErr:   nil,
+		},
+		{
+			name: "Returns users in no group",
+			filter: repository.UserListFilter{
+				NoGroup: true,
+			},
+			want: model.Users{
+				model.MustNewUser(
+					"TEST_USER_ID_2",
+					"TEST_USER_NAME_2",
+					"TEST_USER_EMAIL_2",
+				),
+			},
+			wantSQL: "SELECT * FROM `users` WHERE NOT EXISTS (SELECT 1 FROM `group_users` WHERE `group_users`.user_id = `users`.id)",
+			wantErr: nil,
+			dbErr:   nil,
+		},
+		{
+			name: "Returns users in the status",
+			filter: repository.UserListFilter{
+				Status: model.UserStatusSuspended,
+			},
+			want: model.Users{
+				model.MustNewUserWithStatus(
+					"TEST_USER_ID_2",
+					"TEST_USER_NAME_2",
+					"TEST_USER_EMAIL_2",
+					model.UserStatusSuspended,
+				),
+			},
+			wantSQL: "SELECT * FROM `users` WHERE status = ?",
+			wantErr: nil,
+			dbErr:   nil,
+		},
+		{
+			name: "Returns users with the attributes",
+			filter: repository.UserListFilter{
+				Attributes: map[string]string{"department": "sales"},
+			},
+			want: model.Users{
+				func() *model.User {
+					u := model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2")
+					u.ChangeAttributes(model.Attributes{"department": "sales"})
+					return u
+				}(),
+			},
+			wantSQL: "SELECT * FROM `users` WHERE JSON_UNQUOTE(JSON_EXTRACT(attributes, ?)) = ?",
+			wantErr: nil,
+			dbErr:   nil,
+		},
+		{
+			name: "Returns users created after the time",
+			filter: repository.UserListFilter{
+				CreatedAfter: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			want: model.Users{
+				model.MustNewUser(
+					"TEST_USER_ID_3",
+					"TEST_USER_NAME_3",
+					"TEST_USER_EMAIL_3",
+				),
+			},
+			wantSQL: "SELECT * FROM `users` WHERE created_at > ?",
+			wantErr: nil,
+			dbErr:   nil,
+		},
+		{
+			name: "Returns a page of users after the id",
+			filter: repository.UserListFilter{
+				AfterID: "TEST_USER_ID_1",
+				Limit:   2,
+			},
+			want: model.Users{
+				model.MustNewUser(
+					"TEST_USER_ID_2",
+					"TEST_USER_NAME_2",
+					"TEST_USER_EMAIL_2",
+				),
+				model.MustNewUser(
+					"TEST_USER_ID_3",
+					"TEST_USER_NAME_3",
+					"TEST_USER_EMAIL_3",
+				),
+			},
+			wantSQL: "SELECT * FROM `users` WHERE id > ? ORDER BY id LIMIT 2",
+			wantErr: nil,
+			dbErr:   nil,
+		},
+		{
+			name: "Returns users sorted by the timestamps",
+			filter: repository.UserListFilter{
+				Sort: model.SortOrder{Field: model.SortFieldUpdatedAt, Desc: true},
+			},
+			want: model.Users{
+				newTimestampedUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+				newTimestampedUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+			},
+			wantSQL: "SELECT * FROM `users` ORDER BY `updated_at` DESC,id",
+			wantErr: nil,
+			dbErr:   nil,
+		},
+		{
+			name: "Returns users searched by the query sorted by the timestamps",
+			filter: repository.UserListFilter{
+				Query: "name",
+				Sort:  model.SortOrder{Field: model.SortFieldCreatedAt},
+			},
+			want: model.Users{
+				newTimestampedUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+			},
+			wantSQL: "SELECT * FROM `users` WHERE MATCH(name, email) AGAINST(? IN BOOLEAN MODE) AND " +
+				"(name LIKE ? OR email LIKE ?) ORDER BY `created_at`,id",
+			wantErr: nil,
+			dbErr:   nil,
+		},
 		{
 			name:    "Error",
 			want:    nil,
@@ -181,14 +332,35 @@ func TestDatabase_dbUserRepository_List(t *testing.T) {
 			if len(tt.filter.UserIDs) > 0 {
 				expectQuery.WithArgs(toDriverValues[model.UserID](t, tt.filter.UserIDs...)...)
 			}
+			if len(tt.filter.Emails) > 0 {
+				expectQuery.WithArgs(toDriverValues[string](t, tt.filter.Emails...)...)
+			}
+			if tt.filter.Status != "" {
+				expectQuery.WithArgs(tt.filter.Status)
+			}
+			for k, v := range tt.filter.Attributes {
+				expectQuery.WithArgs("$."+k, v)
+			}
+			if !tt.filter.CreatedAfter.IsZero() {
+				expectQuery.WithArgs(tt.filter.CreatedAfter)
+			}
+			if tt.filter.AfterID != "" {
+				expectQuery.WithArgs(tt.filter.AfterID)
+			}
 
 			if tt.dbErr != nil {
 				expectQuery.WillReturnError(tt.dbErr)
 			} else {
-				now := time.Now()
-				rows := sqlmock.NewRows([]string{"id", "name", "email", "created_at", "updated_at"})
+				rows := sqlmock.NewRows([]string{
+					"id", "name", "email", "status", "attributes", "created_at", "created_by", "updated_at", "updated_by",
+				})
 				for _, u := range tt.want {
-					rows.AddRow(u.ID(), u.Name(), u.Email(), now, now)
+					attrs, err := datamodel.AttributesValue(u.Attributes())
+					if err != nil {
+						t.Fatalf("want no err, but has error %v", err)
+					}
+					ts := u.Timestamps()
+					rows.AddRow(u.ID(), u.Name(), u.Email(), u.Status(), attrs, ts.CreatedAt, ts.CreatedBy, ts.UpdatedAt, ts.UpdatedBy)
 				}
 				expectQuery.WillReturnRows(rows)
 			}
@@ -232,8 +404,8 @@ func TestDatabase_dbUserRepository_Create(t *testing.T) {
 	}{
 		{
 			name:    "Creates a new user",
-			user:    model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"),
-			want:    model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"),
+			user:    newTimestampedUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"),
+			want:    newTimestampedUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"),
 			wantErr: nil,
 		},
 		{
@@ -254,8 +426,15 @@ func TestDatabase_dbUserRepository_Create(t *testing.T) {
 			defer sqlDB.Close()
 
 			expectExec := mock.
-				ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`id`,`name`,`email`) VALUES (?,?,?)")).
-				WithArgs(tt.user.ID(), tt.user.Name(), tt.user.Email())
+				ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `users` (`id`,`name`,`email`,`status`,`attributes`,`created_at`,`created_by`,`updated_at`,`updated_by`) "+
+						"VALUES (?,?,?,?,?,?,?,?,?)",
+				)).
+				WithArgs(
+					tt.user.ID(), tt.user.Name(), tt.user.Email(), tt.user.Status(), nil,
+					tt.user.Timestamps().CreatedAt, tt.user.Timestamps().CreatedBy,
+					tt.user.Timestamps().UpdatedAt, tt.user.Timestamps().UpdatedBy,
+				)
 
 			if tt.wantErr != nil {
 				expectExec.WillReturnError(tt.wantErr)
@@ -295,15 +474,26 @@ func TestDatabase_dbUserRepository_Create(t *testing.T) {
 
 func TestDatabase_dbUserRepository_Update(t *testing.T) {
 	tests := []struct {
-		name    string
-		user    *model.User
-		wantErr error
+		name           string
+		user           *model.User
+		wantAttributes any
+		wantErr        error
 	}{
 		{
 			name:    "Updates a user",
-			user:    model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"),
+			user:    newTimestampedUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"),
 			wantErr: nil,
 		},
+		{
+			name: "Updates a user with the attributes",
+			user: func() *model.User {
+				u := model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL")
+				u.ChangeAttributes(model.Attributes{"department": "sales"})
+				return u
+			}(),
+			wantAttributes: `{"department":"sales"}`,
+			wantErr:        nil,
+		},
 		{
 			name:    "Error",
 			user:    model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"),
@@ -321,8 +511,13 @@ func TestDatabase_dbUserRepository_Update(t *testing.T) {
 			defer sqlDB.Close()
 
 			expectExec := mock.
-				ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `email`=?,`name`=? WHERE `id` = ?")).
-				WithArgs(tt.user.Email(), tt.user.Name(), tt.user.ID())
+				ExpectExec(regexp.QuoteMeta(
+					"UPDATE `users` SET `attributes`=?,`email`=?,`name`=?,`status`=?,`updated_at`=?,`updated_by`=? WHERE `id` = ?",
+				)).
+				WithArgs(
+					tt.wantAttributes, tt.user.Email(), tt.user.Name(), tt.user.Status(),
+					tt.user.Timestamps().UpdatedAt, tt.user.Timestamps().UpdatedBy, tt.user.ID(),
+				)
 
 			if tt.wantErr != nil {
 				expectExec.WillReturnError(tt.wantErr)
@@ -411,3 +606,13 @@ func TestDatabase_dbUserRepository_Delete(t *testing.T) {
 		})
 	}
 }
+
+// newTimestampedUser returns the user created by TEST_ACTOR and updated by TEST_ACTOR_2.
+func newTimestampedUser(id model.UserID, name, email string) *model.User {
+	u := model.MustNewUser(id, name, email)
+	u.ChangeTimestamps(
+		model.NewTimestamps("TEST_ACTOR", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)).
+			Updated("TEST_ACTOR_2", time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)),
+	)
+	return u
+}
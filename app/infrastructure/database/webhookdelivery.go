@@ -0,0 +1,75 @@
+package database
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+type dbWebhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func (r *dbWebhookDeliveryRepository) Find(id model.WebhookDeliveryID) (*model.WebhookDelivery, error) {
+	dmd := &datamodel.WebhookDelivery{ID: string(id)}
+
+	if err := r.db.First(dmd).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dmd.ToModel(), nil
+}
+
+func (r *dbWebhookDeliveryRepository) List(f repository.WebhookDeliveryListFilter) (model.WebhookDeliveries, error) {
+	db := r.db
+	if f.SubscriptionID != "" {
+		db = db.Where("subscription_id = ?", f.SubscriptionID)
+	}
+	if f.Status != "" {
+		db = db.Where("status = ?", f.Status)
+	}
+	if !f.AvailableAt.IsZero() {
+		db = db.Where("next_attempt_at <= ?", f.AvailableAt)
+	}
+	if f.Limit > 0 {
+		db = db.Limit(f.Limit)
+	}
+
+	var dmds datamodel.WebhookDeliveries
+	if err := db.Order("occurred_at").Find(&dmds).Error; err != nil {
+		return nil, err
+	}
+
+	return dmds.ToModel(), nil
+}
+
+func (r *dbWebhookDeliveryRepository) Create(ds model.WebhookDeliveries) error {
+	if len(ds) == 0 {
+		return nil
+	}
+	return r.db.Create(datamodel.NewWebhookDeliveries(ds)).Error
+}
+
+func (r *dbWebhookDeliveryRepository) Update(d *model.WebhookDelivery) error {
+	if d.ID() == "" {
+		return errors.New("webhook delivery id must not be empty")
+	}
+
+	dmd := datamodel.NewWebhookDelivery(d)
+	return r.db.Model(&datamodel.WebhookDelivery{ID: dmd.ID}).
+		Updates(map[string]any{
+			"status":           dmd.Status,
+			"attempts":         dmd.Attempts,
+			"last_status_code": dmd.LastStatusCode,
+			"last_error":       dmd.LastError,
+			"next_attempt_at":  dmd.NextAttemptAt,
+			"delivered_at":     dmd.DeliveredAt,
+		}).Error
+}
@@ -0,0 +1,380 @@
+package database_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database"
+)
+
+var webhookDeliveryColumns = []string{
+	"id", "subscription_id", "event_id", "event_type", "payload", "occurred_at",
+	"status", "attempts", "last_status_code", "last_error", "next_attempt_at", "delivered_at",
+}
+
+func TestDatabase_dbWebhookDeliveryRepository_Find(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	retryAt := time.Date(2023, 1, 2, 3, 4, 6, 0, time.UTC)
+	tests := []struct {
+		name    string
+		id      model.WebhookDeliveryID
+		rows    *sqlmock.Rows
+		want    *model.WebhookDelivery
+		wantErr error
+		dbErr   error
+	}{
+		{
+			name: "Returns the webhook delivery",
+			id:   "TEST_WEBHOOK_DELIVERY_ID",
+			rows: sqlmock.NewRows(webhookDeliveryColumns).AddRow(
+				"TEST_WEBHOOK_DELIVERY_ID", "TEST_WEBHOOK_SUBSCRIPTION_ID", "TEST_EVENT_ID", "UserCreated",
+				[]byte(`{}`), occurredAt, "PENDING", 1, 500, "an error occurred", retryAt, nil,
+			),
+			want: model.MustNewWebhookDelivery(
+				"TEST_WEBHOOK_DELIVERY_ID",
+				"TEST_WEBHOOK_SUBSCRIPTION_ID",
+				"TEST_EVENT_ID",
+				model.DomainEventTypeUserCreated,
+				[]byte(`{}`),
+				occurredAt,
+				model.WebhookDeliveryState{
+					Status:         model.WebhookDeliveryStatusPending,
+					Attempts:       1,
+					LastStatusCode: 500,
+					LastError:      "an error occurred",
+					NextAttemptAt:  retryAt,
+				},
+			),
+		},
+		{
+			name: "Returns nil if not found",
+			id:   "TEST_WEBHOOK_DELIVERY_ID",
+			rows: sqlmock.NewRows(webhookDeliveryColumns),
+			want: nil,
+		},
+		{
+			name:    "Error",
+			id:      "TEST_WEBHOOK_DELIVERY_ID",
+			wantErr: errors.New("an error occurred"),
+			dbErr:   errors.New("an error occurred"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db := dbMock(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			defer sqlDB.Close()
+
+			expectQuery := mock.
+				ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webhook_deliveries` WHERE `webhook_deliveries`.`id` = ? " +
+						"ORDER BY `webhook_deliveries`.`id` LIMIT 1",
+				)).
+				WithArgs(tt.id)
+			if tt.dbErr != nil {
+				expectQuery.WillReturnError(tt.dbErr)
+			} else {
+				expectQuery.WillReturnRows(tt.rows)
+			}
+
+			r := &database.DBWebhookDeliveryRepository{}
+			r.SetDB(db)
+
+			got, err := r.Find(tt.id)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("want an error, but has no error")
+				}
+				if err.Error() != tt.wantErr.Error() {
+					t.Errorf("r.Find(%s)=_, %v; want _, %v", tt.id, err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("want no err, but has error %v", err)
+				}
+				if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(model.WebhookDelivery{})); diff != "" {
+					t.Errorf(
+						"r.Find(%s)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+						tt.id, got, tt.want, diff,
+					)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDatabase_dbWebhookDeliveryRepository_List(t *testing.T) {
+	availableAt := time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		f        repository.WebhookDeliveryListFilter
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:    "Lists the webhook deliveries",
+			f:       repository.WebhookDeliveryListFilter{},
+			wantSQL: "SELECT * FROM `webhook_deliveries` ORDER BY occurred_at",
+		},
+		{
+			name: "Lists the dead webhook deliveries of the subscription",
+			f: repository.WebhookDeliveryListFilter{
+				SubscriptionID: "TEST_WEBHOOK_SUBSCRIPTION_ID",
+				Status:         model.WebhookDeliveryStatusDead,
+			},
+			wantSQL:  "SELECT * FROM `webhook_deliveries` WHERE subscription_id = ? AND status = ? ORDER BY occurred_at",
+			wantArgs: []any{"TEST_WEBHOOK_SUBSCRIPTION_ID", model.WebhookDeliveryStatusDead},
+		},
+		{
+			name: "Lists the pending webhook deliveries due for an attempt",
+			f: repository.WebhookDeliveryListFilter{
+				Status:      model.WebhookDeliveryStatusPending,
+				AvailableAt: availableAt,
+				Limit:       10,
+			},
+			wantSQL: "SELECT * FROM `webhook_deliveries` " +
+				"WHERE status = ? AND next_attempt_at <= ? ORDER BY occurred_at LIMIT 10",
+			wantArgs: []any{model.WebhookDeliveryStatusPending, availableAt},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db := dbMock(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			defer sqlDB.Close()
+
+			expectQuery := mock.ExpectQuery(regexp.QuoteMeta(tt.wantSQL))
+			if len(tt.wantArgs) > 0 {
+				expectQuery = expectQuery.WithArgs(toDriverValues(t, tt.wantArgs...)...)
+			}
+			expectQuery.WillReturnRows(sqlmock.NewRows(webhookDeliveryColumns))
+
+			r := &database.DBWebhookDeliveryRepository{}
+			r.SetDB(db)
+
+			got, err := r.List(tt.f)
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if len(got) != 0 {
+				t.Errorf("r.List(%v)=%v, nil; want empty, nil", tt.f, got)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDatabase_dbWebhookDeliveryRepository_Create(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	ds := model.WebhookDeliveries{
+		model.MustNewWebhookDelivery(
+			"TEST_WEBHOOK_DELIVERY_ID_1",
+			"TEST_WEBHOOK_SUBSCRIPTION_ID_1",
+			"TEST_EVENT_ID",
+			model.DomainEventTypeUserCreated,
+			[]byte(`{}`),
+			occurredAt,
+			model.WebhookDeliveryState{},
+		),
+		model.MustNewWebhookDelivery(
+			"TEST_WEBHOOK_DELIVERY_ID_2",
+			"TEST_WEBHOOK_SUBSCRIPTION_ID_2",
+			"TEST_EVENT_ID",
+			model.DomainEventTypeUserCreated,
+			[]byte(`{}`),
+			occurredAt,
+			model.WebhookDeliveryState{},
+		),
+	}
+	tests := []struct {
+		name    string
+		ds      model.WebhookDeliveries
+		noSQL   bool
+		wantErr error
+		dbErr   error
+	}{
+		{
+			name: "Creates the webhook deliveries",
+			ds:   ds,
+		},
+		{
+			name:  "Creates nothing without webhook deliveries",
+			ds:    nil,
+			noSQL: true,
+		},
+		{
+			name:    "Error",
+			ds:      ds,
+			wantErr: errors.New("an error occurred"),
+			dbErr:   errors.New("an error occurred"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db := dbMock(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			defer sqlDB.Close()
+
+			if !tt.noSQL {
+				sql := "INSERT INTO `webhook_deliveries` " +
+					"(`id`,`subscription_id`,`event_id`,`event_type`,`payload`,`occurred_at`," +
+					"`status`,`attempts`,`last_status_code`,`last_error`,`next_attempt_at`,`delivered_at`) " +
+					"VALUES (?,?,?,?,?,?,?,?,?,?,?,?),(?,?,?,?,?,?,?,?,?,?,?,?)"
+				var args []any
+				for _, d := range tt.ds {
+					args = append(args,
+						d.ID(), d.SubscriptionID(), d.EventID(), d.EventType(), d.Payload(), occurredAt,
+						model.WebhookDeliveryStatusPending, 0, 0, "", occurredAt, nil,
+					)
+				}
+				expectExec := mock.ExpectExec(regexp.QuoteMeta(sql)).WithArgs(toDriverValues(t, args...)...)
+				if tt.dbErr != nil {
+					expectExec.WillReturnError(tt.dbErr)
+				} else {
+					expectExec.WillReturnResult(sqlmock.NewResult(0, int64(len(tt.ds))))
+				}
+			}
+
+			r := &database.DBWebhookDeliveryRepository{}
+			r.SetDB(db)
+
+			err = r.Create(tt.ds)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("want an error, but has no error")
+				}
+				if err.Error() != tt.wantErr.Error() {
+					t.Errorf("r.Create(%v)=%v; want %v", tt.ds, err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDatabase_dbWebhookDeliveryRepository_Update(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	retryAt := time.Date(2023, 1, 2, 3, 4, 6, 0, time.UTC)
+	deliveredAt := time.Date(2023, 1, 2, 3, 4, 7, 0, time.UTC)
+	newDelivery := func() *model.WebhookDelivery {
+		return model.MustNewWebhookDelivery(
+			"TEST_WEBHOOK_DELIVERY_ID",
+			"TEST_WEBHOOK_SUBSCRIPTION_ID",
+			"TEST_EVENT_ID",
+			model.DomainEventTypeUserCreated,
+			[]byte(`{}`),
+			occurredAt,
+			model.WebhookDeliveryState{},
+		)
+	}
+	failed := newDelivery()
+	failed.MarkFailed(500, errors.New("an error occurred"), retryAt)
+	succeeded := newDelivery()
+	succeeded.MarkSucceeded(200, deliveredAt)
+	dead := newDelivery()
+	dead.MarkDead(0, errors.New("connection refused"))
+
+	tests := []struct {
+		name     string
+		d        *model.WebhookDelivery
+		wantArgs []any
+		wantErr  error
+		dbErr    error
+	}{
+		{
+			name:     "Updates the state of a failed webhook delivery",
+			d:        failed,
+			wantArgs: []any{1, nil, "an error occurred", 500, retryAt, model.WebhookDeliveryStatusPending},
+		},
+		{
+			name:     "Updates the state of a succeeded webhook delivery",
+			d:        succeeded,
+			wantArgs: []any{1, deliveredAt, "", 200, occurredAt, model.WebhookDeliveryStatusSucceeded},
+		},
+		{
+			name:     "Updates the state of a dead webhook delivery",
+			d:        dead,
+			wantArgs: []any{1, nil, "connection refused", 0, occurredAt, model.WebhookDeliveryStatusDead},
+		},
+		{
+			name:     "Error",
+			d:        failed,
+			wantArgs: []any{1, nil, "an error occurred", 500, retryAt, model.WebhookDeliveryStatusPending},
+			wantErr:  errors.New("an error occurred"),
+			dbErr:    errors.New("an error occurred"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db := dbMock(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			defer sqlDB.Close()
+
+			sql := "UPDATE `webhook_deliveries` " +
+				"SET `attempts`=?,`delivered_at`=?,`last_error`=?,`last_status_code`=?,`next_attempt_at`=?,`status`=? " +
+				"WHERE `id` = ?"
+			expectExec := mock.
+				ExpectExec(regexp.QuoteMeta(sql)).
+				WithArgs(toDriverValues(t, append(tt.wantArgs, tt.d.ID())...)...)
+			if tt.dbErr != nil {
+				expectExec.WillReturnError(tt.dbErr)
+			} else {
+				expectExec.WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			r := &database.DBWebhookDeliveryRepository{}
+			r.SetDB(db)
+
+			err = r.Update(tt.d)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("want an error, but has no error")
+				}
+				if err.Error() != tt.wantErr.Error() {
+					t.Errorf("r.Update(%v)=%v; want %v", tt.d, err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database/datamodel"
+)
+
+type dbWebhookSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func (r *dbWebhookSubscriptionRepository) Find(id model.WebhookSubscriptionID) (*model.WebhookSubscription, error) {
+	dms := &datamodel.WebhookSubscription{ID: string(id)}
+
+	if err := r.db.First(dms).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dms.ToModel(), nil
+}
+
+func (r *dbWebhookSubscriptionRepository) List() (model.WebhookSubscriptions, error) {
+	var dmss datamodel.WebhookSubscriptions
+	if err := r.db.Find(&dmss).Error; err != nil {
+		return nil, err
+	}
+
+	return dmss.ToModel(), nil
+}
+
+func (r *dbWebhookSubscriptionRepository) Create(s *model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	dms := datamodel.NewWebhookSubscription(s)
+	if err := r.db.Create(dms).Error; err != nil {
+		return nil, err
+	}
+
+	return dms.ToModel(), nil
+}
+
+func (r *dbWebhookSubscriptionRepository) Update(s *model.WebhookSubscription) error {
+	if s.ID() == "" {
+		return errors.New("webhook subscription id must not be empty")
+	}
+
+	return r.db.Save(datamodel.NewWebhookSubscription(s)).Error
+}
+
+func (r *dbWebhookSubscriptionRepository) Delete(id model.WebhookSubscriptionID) error {
+	if err := r.db.Where("subscription_id = ?", id).Delete(&datamodel.WebhookDelivery{}).Error; err != nil {
+		return err
+	}
+	return r.db.Delete(&datamodel.WebhookSubscription{ID: string(id)}).Error
+}
@@ -0,0 +1,75 @@
+package database_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/database"
+)
+
+func TestDatabase_dbWebhookSubscriptionRepository_Delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      model.WebhookSubscriptionID
+		wantErr error
+		dbErr   error
+	}{
+		{
+			name: "Deletes the webhook subscription and its deliveries",
+			id:   "TEST_WEBHOOK_SUBSCRIPTION_ID",
+		},
+		{
+			name:    "Error",
+			id:      "TEST_WEBHOOK_SUBSCRIPTION_ID",
+			wantErr: errors.New("an error occurred"),
+			dbErr:   errors.New("an error occurred"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db := dbMock(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			defer sqlDB.Close()
+
+			expectExec := mock.
+				ExpectExec(regexp.QuoteMeta("DELETE FROM `webhook_deliveries` WHERE subscription_id = ?")).
+				WithArgs(tt.id)
+			if tt.dbErr != nil {
+				expectExec.WillReturnError(tt.dbErr)
+			} else {
+				expectExec.WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.
+					ExpectExec(regexp.QuoteMeta("DELETE FROM `webhook_subscriptions` WHERE `webhook_subscriptions`.`id` = ?")).
+					WithArgs(tt.id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			r := &database.DBWebhookSubscriptionRepository{}
+			r.SetDB(db)
+
+			err = r.Delete(tt.id)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("want an error, but has no error")
+				}
+				if err.Error() != tt.wantErr.Error() {
+					t.Errorf("r.Delete(%s)=%v; want %v", tt.id, err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
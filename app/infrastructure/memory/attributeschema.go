@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+)
+
+type memoryAttributeSchemaRepository struct {
+	s *store
+}
+
+func (r *memoryAttributeSchemaRepository) Find(target model.AttributeTarget, key string) (*model.AttributeSchema, error) {
+	for _, s := range r.s.attributeSchemas {
+		if s.Target() == target && s.Key() == key {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryAttributeSchemaRepository) List(f repository.AttributeSchemaListFilter) (model.AttributeSchemas, error) {
+	var result model.AttributeSchemas
+	for _, s := range r.s.attributeSchemas {
+		if f.Target != "" && s.Target() != f.Target {
+			continue
+		}
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Target() != result[j].Target() {
+			return result[i].Target() < result[j].Target()
+		}
+		return result[i].Key() < result[j].Key()
+	})
+	return result, nil
+}
+
+func (r *memoryAttributeSchemaRepository) Save(s *model.AttributeSchema) error {
+	for i, ss := range r.s.attributeSchemas {
+		if ss.Target() == s.Target() && ss.Key() == s.Key() {
+			r.s.attributeSchemas[i] = s
+			return nil
+		}
+	}
+	r.s.AddAttributeSchemas(s)
+	return nil
+}
+
+func (r *memoryAttributeSchemaRepository) Delete(target model.AttributeTarget, key string) error {
+	var ss model.AttributeSchemas
+	for _, s := range r.s.attributeSchemas {
+		if s.Target() != target || s.Key() != key {
+			ss = append(ss, s)
+		}
+	}
+	r.s.attributeSchemas = ss
+	return nil
+}
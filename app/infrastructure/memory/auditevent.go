@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+)
+
+type memoryAuditEventRepository struct {
+	s *store
+}
+
+func (r *memoryAuditEventRepository) List(f repository.AuditEventListFilter) (model.AuditEvents, error) {
+	var result model.AuditEvents
+	for _, e := range r.s.auditEvents {
+		if f.Actor != "" && e.Actor() != f.Actor {
+			continue
+		}
+		if f.TargetType != "" && e.Target().Type != f.TargetType {
+			continue
+		}
+		if f.TargetID != "" && e.Target().ID != f.TargetID {
+			continue
+		}
+		if !f.From.IsZero() && e.OccurredAt().Before(f.From) {
+			continue
+		}
+		if !f.To.IsZero() && !e.OccurredAt().Before(f.To) {
+			continue
+		}
+
+		result = append(result, e)
+	}
+
+	return result, nil
+}
+
+func (r *memoryAuditEventRepository) Create(e *model.AuditEvent) (*model.AuditEvent, error) {
+	r.s.AddAuditEvents(e)
+	return e, nil
+}
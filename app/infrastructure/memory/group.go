@@ -2,6 +2,7 @@ package memory
 
 import (
 	"errors"
+	"sort"
 
 	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
 	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
@@ -20,9 +21,17 @@ func (r *memoryGroupRepository) Find(gID model.GroupID) (*model.Group, error) {
 	return nil, nil
 }
 
+// FindForUpdate is Find, as the store is not safe for concurrent use and has nothing to lock.
+func (r *memoryGroupRepository) FindForUpdate(gID model.GroupID) (*model.Group, error) {
+	return r.Find(gID)
+}
+
 func (r *memoryGroupRepository) List(f repository.GroupListFilter) (model.Groups, error) {
 	var result model.Groups
 	for _, g := range r.s.groups {
+		if len(f.GroupIDs) > 0 && !containsGroupID(f.GroupIDs, g.ID()) {
+			continue
+		}
 		if len(f.UserIDs) > 0 {
 			found := false
 			for _, uID := range f.UserIDs {
@@ -40,13 +49,75 @@ func (r *memoryGroupRepository) List(f repository.GroupListFilter) (model.Groups
 				continue
 			}
 		}
+		if len(f.AllUserIDs) > 0 && !hasAllUsers(g, f.AllUserIDs) {
+			continue
+		}
+		if f.NoUsers && len(g.UserIDs()) > 0 {
+			continue
+		}
+		if f.MinUsers > 0 && len(g.UserIDs()) < f.MinUsers {
+			continue
+		}
+		if !g.Attributes().Matches(f.Attributes) {
+			continue
+		}
+		if !f.LabelSelector.Matches(g.Labels()) {
+			continue
+		}
+		if f.AfterID != "" && g.ID() <= f.AfterID {
+			continue
+		}
+		if f.Query != "" {
+			if _, ok := g.SearchRank(f.Query); !ok {
+				continue
+			}
+		}
 
 		result = append(result, g)
 	}
 
+	if !f.Sort.IsZero() {
+		sort.Slice(result, func(i, j int) bool {
+			if c := f.Sort.Compare(result[i].Timestamps(), result[j].Timestamps()); c != 0 {
+				return c < 0
+			}
+			return result[i].ID() < result[j].ID()
+		})
+	} else if f.Query != "" {
+		sort.Slice(result, func(i, j int) bool {
+			ri, _ := result[i].SearchRank(f.Query)
+			rj, _ := result[j].SearchRank(f.Query)
+			if ri != rj {
+				return ri < rj
+			}
+			return result[i].ID() < result[j].ID()
+		})
+	} else if f.Limit > 0 {
+		sort.Slice(result, func(i, j int) bool { return result[i].ID() < result[j].ID() })
+	}
+	if f.Limit > 0 && len(result) > f.Limit {
+		result = result[:f.Limit]
+	}
+
 	return result, nil
 }
 
+func hasAllUsers(g *model.Group, uIDs []model.UserID) bool {
+	for _, uID := range uIDs {
+		found := false
+		for _, guID := range g.UserIDs() {
+			if guID == uID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 func (r *memoryGroupRepository) Create(g *model.Group) (*model.Group, error) {
 	r.s.AddGroups(g)
 	return g, nil
@@ -72,7 +143,7 @@ func (r *memoryGroupRepository) Delete(g *model.Group) error {
 	var result model.Groups
 	for _, sg := range r.s.groups {
 		if sg.ID() != gID {
-			result = append(result, g)
+			result = append(result, sg)
 		}
 	}
 	r.s.groups = result
@@ -86,6 +157,14 @@ func (r *memoryGroupRepository) AddUsers(gID model.GroupID, uIDs []model.UserID)
 			if err != nil {
 				return err
 			}
+			if err := mg.ChangeJoinPolicy(g.JoinPolicy()); err != nil {
+				return err
+			}
+			mg.ChangeAttributes(g.Attributes())
+			if err := mg.ChangeLabels(g.Labels()); err != nil {
+				return err
+			}
+			mg.ChangeTimestamps(g.Timestamps())
 			r.s.groups[i] = mg
 			return nil
 		}
@@ -118,6 +197,14 @@ func (r *memoryGroupRepository) RemoveUsers(gID model.GroupID, uIDs []model.User
 		if err != nil {
 			return err
 		}
+		if err := mg.ChangeJoinPolicy(g.JoinPolicy()); err != nil {
+			return err
+		}
+		mg.ChangeAttributes(g.Attributes())
+		if err := mg.ChangeLabels(g.Labels()); err != nil {
+			return err
+		}
+		mg.ChangeTimestamps(g.Timestamps())
 
 		r.s.groups[i] = mg
 		return nil
@@ -145,9 +232,50 @@ func (r *memoryGroupRepository) RemoveUsersFromAll(uIDs []model.UserID) error {
 		if err != nil {
 			return err
 		}
+		if err := mg.ChangeJoinPolicy(g.JoinPolicy()); err != nil {
+			return err
+		}
+		mg.ChangeAttributes(g.Attributes())
+		if err := mg.ChangeLabels(g.Labels()); err != nil {
+			return err
+		}
+		mg.ChangeTimestamps(g.Timestamps())
+
+		r.s.groups[i] = mg
+	}
+	return nil
+}
+
+func (r *memoryGroupRepository) ReplaceLabels(gID model.GroupID, ls model.Labels) error {
+	for i, g := range r.s.groups {
+		if g.ID() != gID {
+			continue
+		}
+
+		mg, err := model.NewGroup(g.ID(), g.Name(), g.UserIDs())
+		if err != nil {
+			return err
+		}
+		if err := mg.ChangeJoinPolicy(g.JoinPolicy()); err != nil {
+			return err
+		}
+		mg.ChangeAttributes(g.Attributes())
+		if err := mg.ChangeLabels(ls); err != nil {
+			return err
+		}
+		mg.ChangeTimestamps(g.Timestamps())
 
 		r.s.groups[i] = mg
 		return nil
 	}
 	return nil
 }
+
+func containsGroupID(gIDs []model.GroupID, gID model.GroupID) bool {
+	for _, id := range gIDs {
+		if id == gID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+)
+
+type memoryGroupInvitationRepository struct {
+	s *store
+}
+
+func (r *memoryGroupInvitationRepository) Find(id model.GroupInvitationID) (*model.GroupInvitation, error) {
+	for _, inv := range r.s.groupInvitations {
+		if inv.ID() == id {
+			return inv, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryGroupInvitationRepository) List(f repository.GroupInvitationListFilter) (model.GroupInvitations, error) {
+	var result model.GroupInvitations
+	for _, inv := range r.s.groupInvitations {
+		if f.GroupID != "" && inv.GroupID() != f.GroupID {
+			continue
+		}
+		if (f.InviteeUserID != "" || f.InviteeEmail != "") && !isInvitee(inv, f.InviteeUserID, f.InviteeEmail) {
+			continue
+		}
+		if f.Status != "" && inv.State().Status != f.Status {
+			continue
+		}
+		if !f.ExpiresAfter.IsZero() && !inv.ExpiresAt().After(f.ExpiresAfter) {
+			continue
+		}
+
+		result = append(result, inv)
+	}
+
+	return result, nil
+}
+
+func isInvitee(inv *model.GroupInvitation, uID model.UserID, email string) bool {
+	invitee := inv.Invitee()
+	return (uID != "" && invitee.UserID == uID) ||
+		(email != "" && strings.EqualFold(invitee.Email, email))
+}
+
+func (r *memoryGroupInvitationRepository) Create(inv *model.GroupInvitation) error {
+	r.s.AddGroupInvitations(inv)
+	return nil
+}
+
+func (r *memoryGroupInvitationRepository) Update(inv *model.GroupInvitation) error {
+	if inv.ID() == "" {
+		return errors.New("group invitation id must not be empty")
+	}
+
+	for i, sinv := range r.s.groupInvitations {
+		if sinv.ID() == inv.ID() {
+			r.s.groupInvitations[i] = inv
+			break
+		}
+	}
+
+	return nil
+}
+
+func (r *memoryGroupInvitationRepository) ExpirePending(at time.Time) (int, error) {
+	var expired int
+	for i, inv := range r.s.groupInvitations {
+		st := inv.State()
+		if st.Status != model.GroupInvitationStatusPending || !inv.IsExpired(at) {
+			continue
+		}
+
+		e, err := model.NewGroupInvitation(
+			inv.ID(),
+			inv.GroupID(),
+			inv.Inviter(),
+			inv.Invitee(),
+			inv.CreatedAt(),
+			inv.ExpiresAt(),
+			model.GroupInvitationState{Status: model.GroupInvitationStatusExpired, RespondedAt: st.RespondedAt},
+		)
+		if err != nil {
+			return 0, err
+		}
+		r.s.groupInvitations[i] = e
+		expired++
+	}
+	return expired, nil
+}
+
+func (r *memoryGroupInvitationRepository) DeleteByGroupID(gID model.GroupID) error {
+	var invs model.GroupInvitations
+	for _, inv := range r.s.groupInvitations {
+		if inv.GroupID() != gID {
+			invs = append(invs, inv)
+		}
+	}
+	r.s.groupInvitations = invs
+	return nil
+}
@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"errors"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+)
+
+type memoryGroupJoinRequestRepository struct {
+	s *store
+}
+
+func (r *memoryGroupJoinRequestRepository) Find(id model.GroupJoinRequestID) (*model.GroupJoinRequest, error) {
+	for _, jr := range r.s.groupJoinRequests {
+		if jr.ID() == id {
+			return jr, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryGroupJoinRequestRepository) List(f repository.GroupJoinRequestListFilter) (model.GroupJoinRequests, error) {
+	var result model.GroupJoinRequests
+	for _, jr := range r.s.groupJoinRequests {
+		if f.GroupID != "" && jr.GroupID() != f.GroupID {
+			continue
+		}
+		if f.UserID != "" && jr.UserID() != f.UserID {
+			continue
+		}
+		if f.Status != "" && jr.State().Status != f.Status {
+			continue
+		}
+
+		result = append(result, jr)
+	}
+
+	return result, nil
+}
+
+func (r *memoryGroupJoinRequestRepository) Create(jr *model.GroupJoinRequest) error {
+	r.s.AddGroupJoinRequests(jr)
+	return nil
+}
+
+func (r *memoryGroupJoinRequestRepository) Update(jr *model.GroupJoinRequest) error {
+	if jr.ID() == "" {
+		return errors.New("group join request id must not be empty")
+	}
+
+	for i, sjr := range r.s.groupJoinRequests {
+		if sjr.ID() == jr.ID() {
+			r.s.groupJoinRequests[i] = jr
+			break
+		}
+	}
+
+	return nil
+}
+
+func (r *memoryGroupJoinRequestRepository) DeleteByGroupID(gID model.GroupID) error {
+	var jrs model.GroupJoinRequests
+	for _, jr := range r.s.groupJoinRequests {
+		if jr.GroupID() != gID {
+			jrs = append(jrs, jr)
+		}
+	}
+	r.s.groupJoinRequests = jrs
+	return nil
+}
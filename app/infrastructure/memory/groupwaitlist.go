@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+type memoryGroupWaitlistRepository struct {
+	s *store
+}
+
+// Find returns a copy of the stored waitlist, so that changing it never affects the store until it is saved.
+func (r *memoryGroupWaitlistRepository) Find(gID model.GroupID) (*model.GroupWaitlist, error) {
+	for _, w := range r.s.groupWaitlists {
+		if w.GroupID() == gID {
+			return model.NewGroupWaitlist(gID, append([]model.UserID{}, w.UserIDs()...))
+		}
+	}
+	return model.NewGroupWaitlist(gID, nil)
+}
+
+func (r *memoryGroupWaitlistRepository) Save(w *model.GroupWaitlist) error {
+	if err := r.Delete(w.GroupID()); err != nil {
+		return err
+	}
+	if len(w.UserIDs()) == 0 {
+		return nil
+	}
+
+	saved, err := model.NewGroupWaitlist(w.GroupID(), append([]model.UserID{}, w.UserIDs()...))
+	if err != nil {
+		return err
+	}
+	r.s.AddGroupWaitlists(saved)
+	return nil
+}
+
+func (r *memoryGroupWaitlistRepository) Delete(gID model.GroupID) error {
+	var ws []*model.GroupWaitlist
+	for _, w := range r.s.groupWaitlists {
+		if w.GroupID() != gID {
+			ws = append(ws, w)
+		}
+	}
+	r.s.groupWaitlists = ws
+	return nil
+}
+
+func (r *memoryGroupWaitlistRepository) RemoveUsersFromAll(uIDs []model.UserID) error {
+	var ws []*model.GroupWaitlist
+	for _, w := range r.s.groupWaitlists {
+		remained, err := model.NewGroupWaitlist(w.GroupID(), append([]model.UserID{}, w.UserIDs()...))
+		if err != nil {
+			return err
+		}
+		remained.Remove(uIDs)
+		if len(remained.UserIDs()) > 0 {
+			ws = append(ws, remained)
+		}
+	}
+	r.s.groupWaitlists = ws
+	return nil
+}
@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+)
+
+type memoryIdempotencyKeyRepository struct {
+	s *store
+}
+
+func (r *memoryIdempotencyKeyRepository) Find(key string) (*model.IdempotencyKey, error) {
+	for _, k := range r.s.idempotencyKeys {
+		if k.Key() == key {
+			return k, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryIdempotencyKeyRepository) Create(k *model.IdempotencyKey) error {
+	if sk, _ := r.Find(k.Key()); sk != nil {
+		return repository.ErrIdempotencyKeyExists
+	}
+	r.s.AddIdempotencyKeys(k)
+	return nil
+}
+
+func (r *memoryIdempotencyKeyRepository) Update(k *model.IdempotencyKey) error {
+	for i, sk := range r.s.idempotencyKeys {
+		if sk.Key() == k.Key() {
+			r.s.idempotencyKeys[i] = k
+			break
+		}
+	}
+	return nil
+}
+
+func (r *memoryIdempotencyKeyRepository) Delete(key string) error {
+	var ks model.IdempotencyKeys
+	for _, k := range r.s.idempotencyKeys {
+		if k.Key() != key {
+			ks = append(ks, k)
+		}
+	}
+	r.s.idempotencyKeys = ks
+	return nil
+}
+
+func (r *memoryIdempotencyKeyRepository) DeleteExpired(at time.Time) (int, error) {
+	var ks model.IdempotencyKeys
+	for _, k := range r.s.idempotencyKeys {
+		if !k.IsExpired(at) {
+			ks = append(ks, k)
+		}
+	}
+	n := len(r.s.idempotencyKeys) - len(ks)
+	r.s.idempotencyKeys = ks
+	return n, nil
+}
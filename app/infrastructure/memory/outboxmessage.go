@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"errors"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+)
+
+type memoryOutboxMessageRepository struct {
+	s *store
+}
+
+func (r *memoryOutboxMessageRepository) List(f repository.OutboxMessageListFilter) (model.OutboxMessages, error) {
+	ms := r.s.outboxMessages
+	if f.Sequenced || f.AfterID != "" {
+		ms = r.sequenced(f.AfterID)
+	}
+
+	var result model.OutboxMessages
+	for _, m := range ms {
+		if f.AggregateType != "" && m.AggregateType() != f.AggregateType {
+			continue
+		}
+		if f.AggregateID != "" && m.AggregateID() != f.AggregateID {
+			continue
+		}
+		if f.Unpublished && m.IsPublished() {
+			continue
+		}
+		if !f.AvailableAt.IsZero() && m.Delivery().NextAttemptAt.After(f.AvailableAt) {
+			continue
+		}
+		if f.Limit > 0 && len(result) >= f.Limit {
+			break
+		}
+
+		result = append(result, m)
+	}
+
+	return result, nil
+}
+
+// sequenced returns the messages sequenced after the message of the id, or all of them if the id is empty.
+func (r *memoryOutboxMessageRepository) sequenced(afterID model.OutboxMessageID) model.OutboxMessages {
+	seq := r.s.outboxSequence
+	if afterID != "" {
+		i := r.s.outboxSequenceOf(afterID)
+		if i < 0 || r.find(afterID) == nil {
+			return nil
+		}
+		seq = seq[i+1:]
+	}
+
+	var ms model.OutboxMessages
+	for _, id := range seq {
+		if m := r.find(id); m != nil {
+			ms = append(ms, m)
+		}
+	}
+	return ms
+}
+
+func (r *memoryOutboxMessageRepository) find(id model.OutboxMessageID) *model.OutboxMessage {
+	for _, m := range r.s.outboxMessages {
+		if m.ID() == id {
+			return m
+		}
+	}
+	return nil
+}
+
+func (r *memoryOutboxMessageRepository) Create(ms model.OutboxMessages) error {
+	r.s.AddOutboxMessages(ms...)
+	return nil
+}
+
+func (r *memoryOutboxMessageRepository) Update(m *model.OutboxMessage) error {
+	if m.ID() == "" {
+		return errors.New("outbox message id must not be empty")
+	}
+
+	for i, sm := range r.s.outboxMessages {
+		if sm.ID() == m.ID() {
+			r.s.outboxMessages[i] = m
+			break
+		}
+	}
+
+	return nil
+}
+
+func (r *memoryOutboxMessageRepository) Sequence(ms model.OutboxMessages) error {
+	r.s.SequenceOutboxMessages(ms...)
+	return nil
+}
+
+func (r *memoryOutboxMessageRepository) DeletePublished(at time.Time) (int, error) {
+	var ms model.OutboxMessages
+	for _, m := range r.s.outboxMessages {
+		if !m.IsPublished() || m.Delivery().PublishedAt.After(at) {
+			ms = append(ms, m)
+		}
+	}
+	n := len(r.s.outboxMessages) - len(ms)
+	r.s.outboxMessages = ms
+	return n, nil
+}
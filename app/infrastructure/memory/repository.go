@@ -16,8 +16,11 @@ func NewMemoryRepository(s *store) repository.Repository {
 	return &memoryRepository{s: s}
 }
 
+// RunTransaction restores the store if f fails, as a database rolls the transaction back.
 func (r *memoryRepository) RunTransaction(f func(repository.Transaction) error) error {
+	snapshot := r.s.clone()
 	if err := f(&memoryTransaction{s: r.s}); err != nil {
+		*r.s = snapshot
 		return err
 	}
 	return nil
@@ -35,3 +38,63 @@ func (r *memoryRepository) Group() repository.GroupRepositoryQuery {
 func (tx *memoryTransaction) Group() repository.GroupRepositoryCommand {
 	return &memoryGroupRepository{s: tx.s}
 }
+func (r *memoryRepository) GroupWaitlist() repository.GroupWaitlistRepositoryQuery {
+	return &memoryGroupWaitlistRepository{s: r.s}
+}
+func (tx *memoryTransaction) GroupWaitlist() repository.GroupWaitlistRepositoryCommand {
+	return &memoryGroupWaitlistRepository{s: tx.s}
+}
+func (r *memoryRepository) GroupInvitation() repository.GroupInvitationRepositoryQuery {
+	return &memoryGroupInvitationRepository{s: r.s}
+}
+func (tx *memoryTransaction) GroupInvitation() repository.GroupInvitationRepositoryCommand {
+	return &memoryGroupInvitationRepository{s: tx.s}
+}
+func (r *memoryRepository) GroupJoinRequest() repository.GroupJoinRequestRepositoryQuery {
+	return &memoryGroupJoinRequestRepository{s: r.s}
+}
+func (tx *memoryTransaction) GroupJoinRequest() repository.GroupJoinRequestRepositoryCommand {
+	return &memoryGroupJoinRequestRepository{s: tx.s}
+}
+func (r *memoryRepository) Subgroup() repository.SubgroupRepositoryQuery {
+	return &memorySubgroupRepository{s: r.s}
+}
+func (tx *memoryTransaction) Subgroup() repository.SubgroupRepositoryCommand {
+	return &memorySubgroupRepository{s: tx.s}
+}
+func (r *memoryRepository) AuditEvent() repository.AuditEventRepositoryQuery {
+	return &memoryAuditEventRepository{s: r.s}
+}
+func (tx *memoryTransaction) AuditEvent() repository.AuditEventRepositoryCommand {
+	return &memoryAuditEventRepository{s: tx.s}
+}
+func (r *memoryRepository) OutboxMessage() repository.OutboxMessageRepositoryQuery {
+	return &memoryOutboxMessageRepository{s: r.s}
+}
+func (tx *memoryTransaction) OutboxMessage() repository.OutboxMessageRepositoryCommand {
+	return &memoryOutboxMessageRepository{s: tx.s}
+}
+func (r *memoryRepository) WebhookSubscription() repository.WebhookSubscriptionRepositoryQuery {
+	return &memoryWebhookSubscriptionRepository{s: r.s}
+}
+func (tx *memoryTransaction) WebhookSubscription() repository.WebhookSubscriptionRepositoryCommand {
+	return &memoryWebhookSubscriptionRepository{s: tx.s}
+}
+func (r *memoryRepository) WebhookDelivery() repository.WebhookDeliveryRepositoryQuery {
+	return &memoryWebhookDeliveryRepository{s: r.s}
+}
+func (tx *memoryTransaction) WebhookDelivery() repository.WebhookDeliveryRepositoryCommand {
+	return &memoryWebhookDeliveryRepository{s: tx.s}
+}
+func (r *memoryRepository) IdempotencyKey() repository.IdempotencyKeyRepositoryQuery {
+	return &memoryIdempotencyKeyRepository{s: r.s}
+}
+func (tx *memoryTransaction) IdempotencyKey() repository.IdempotencyKeyRepositoryCommand {
+	return &memoryIdempotencyKeyRepository{s: tx.s}
+}
+func (r *memoryRepository) AttributeSchema() repository.AttributeSchemaRepositoryQuery {
+	return &memoryAttributeSchemaRepository{s: r.s}
+}
+func (tx *memoryTransaction) AttributeSchema() repository.AttributeSchemaRepositoryCommand {
+	return &memoryAttributeSchemaRepository{s: tx.s}
+}
@@ -0,0 +1,32 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository/repositorytest"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/memory"
+)
+
+func TestMemory_UserSearch(t *testing.T) {
+	repositorytest.RunUserSearchTests(t, memory.NewMemoryRepository(memory.NewStore()))
+}
+
+func TestMemory_GroupSearch(t *testing.T) {
+	repositorytest.RunGroupSearchTests(t, memory.NewMemoryRepository(memory.NewStore()))
+}
+
+func TestMemory_UserFilter(t *testing.T) {
+	repositorytest.RunUserFilterTests(t, memory.NewMemoryRepository(memory.NewStore()))
+}
+
+func TestMemory_GroupFilter(t *testing.T) {
+	repositorytest.RunGroupFilterTests(t, memory.NewMemoryRepository(memory.NewStore()))
+}
+
+func TestMemory_OutboxMessageOrder(t *testing.T) {
+	repositorytest.RunOutboxMessageOrderTests(t, memory.NewMemoryRepository(memory.NewStore()))
+}
+
+func TestMemory_GroupMembership(t *testing.T) {
+	repositorytest.RunGroupMembershipTests(t, memory.NewMemoryRepository(memory.NewStore()))
+}
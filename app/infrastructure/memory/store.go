@@ -1,20 +1,121 @@
 package memory
 
-import "github.com/toshiykst/go-layerd-architecture/app/domain/model"
+import (
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
 
 type store struct {
-	users  model.Users
-	groups model.Groups
+	users                model.Users
+	groups               model.Groups
+	auditEvents          model.AuditEvents
+	outboxMessages       model.OutboxMessages
+	outboxSequence       []model.OutboxMessageID
+	webhookSubscriptions model.WebhookSubscriptions
+	webhookDeliveries    model.WebhookDeliveries
+	idempotencyKeys      model.IdempotencyKeys
+	groupWaitlists       []*model.GroupWaitlist
+	groupInvitations     model.GroupInvitations
+	groupJoinRequests    model.GroupJoinRequests
+	subgroups            model.Subgroups
+	attributeSchemas     model.AttributeSchemas
 }
 
 func NewStore() *store {
 	return &store{}
 }
 
+// clone returns a copy of the store, which shares the models but not the slices of them.
+func (s *store) clone() store {
+	return store{
+		users:                append(model.Users(nil), s.users...),
+		groups:               append(model.Groups(nil), s.groups...),
+		auditEvents:          append(model.AuditEvents(nil), s.auditEvents...),
+		outboxMessages:       append(model.OutboxMessages(nil), s.outboxMessages...),
+		outboxSequence:       append([]model.OutboxMessageID(nil), s.outboxSequence...),
+		webhookSubscriptions: append(model.WebhookSubscriptions(nil), s.webhookSubscriptions...),
+		webhookDeliveries:    append(model.WebhookDeliveries(nil), s.webhookDeliveries...),
+		idempotencyKeys:      append(model.IdempotencyKeys(nil), s.idempotencyKeys...),
+		groupWaitlists:       append([]*model.GroupWaitlist(nil), s.groupWaitlists...),
+		groupInvitations:     append(model.GroupInvitations(nil), s.groupInvitations...),
+		groupJoinRequests:    append(model.GroupJoinRequests(nil), s.groupJoinRequests...),
+		subgroups:            append(model.Subgroups(nil), s.subgroups...),
+		attributeSchemas:     append(model.AttributeSchemas(nil), s.attributeSchemas...),
+	}
+}
+
 func (s *store) AddUsers(us ...*model.User) {
 	s.users = append(s.users, us...)
 }
 
+// AddUsersCreatedAt adds the users, changing their timestamps to the ones of being created at the time.
+func (s *store) AddUsersCreatedAt(at time.Time, us ...*model.User) {
+	for _, u := range us {
+		u.ChangeTimestamps(model.NewTimestamps("", at))
+	}
+	s.AddUsers(us...)
+}
+
 func (s *store) AddGroups(gs ...*model.Group) {
 	s.groups = append(s.groups, gs...)
 }
+
+func (s *store) AddAuditEvents(es ...*model.AuditEvent) {
+	s.auditEvents = append(s.auditEvents, es...)
+}
+
+func (s *store) AddOutboxMessages(ms ...*model.OutboxMessage) {
+	s.outboxMessages = append(s.outboxMessages, ms...)
+}
+
+// SequenceOutboxMessages gives the messages the next publish sequences in order, keeping the ones already given.
+func (s *store) SequenceOutboxMessages(ms ...*model.OutboxMessage) {
+	for _, m := range ms {
+		if s.outboxSequenceOf(m.ID()) < 0 {
+			s.outboxSequence = append(s.outboxSequence, m.ID())
+		}
+	}
+}
+
+// outboxSequenceOf returns the publish sequence of the message, or -1 if it has not been sequenced.
+func (s *store) outboxSequenceOf(id model.OutboxMessageID) int {
+	for i, sid := range s.outboxSequence {
+		if sid == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *store) AddWebhookSubscriptions(ss ...*model.WebhookSubscription) {
+	s.webhookSubscriptions = append(s.webhookSubscriptions, ss...)
+}
+
+func (s *store) AddWebhookDeliveries(ds ...*model.WebhookDelivery) {
+	s.webhookDeliveries = append(s.webhookDeliveries, ds...)
+}
+
+func (s *store) AddIdempotencyKeys(ks ...*model.IdempotencyKey) {
+	s.idempotencyKeys = append(s.idempotencyKeys, ks...)
+}
+
+func (s *store) AddGroupWaitlists(ws ...*model.GroupWaitlist) {
+	s.groupWaitlists = append(s.groupWaitlists, ws...)
+}
+
+func (s *store) AddGroupInvitations(invs ...*model.GroupInvitation) {
+	s.groupInvitations = append(s.groupInvitations, invs...)
+}
+
+func (s *store) AddGroupJoinRequests(jrs ...*model.GroupJoinRequest) {
+	s.groupJoinRequests = append(s.groupJoinRequests, jrs...)
+}
+
+func (s *store) AddSubgroups(ss ...*model.Subgroup) {
+	s.subgroups = append(s.subgroups, ss...)
+}
+
+func (s *store) AddAttributeSchemas(ss ...*model.AttributeSchema) {
+	s.attributeSchemas = append(s.attributeSchemas, ss...)
+}
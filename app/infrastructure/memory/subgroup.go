@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+)
+
+type memorySubgroupRepository struct {
+	s *store
+}
+
+func (r *memorySubgroupRepository) List(f repository.SubgroupListFilter) (model.Subgroups, error) {
+	var result model.Subgroups
+	for _, s := range r.s.subgroups {
+		if f.ParentID != "" && s.ParentID() != f.ParentID {
+			continue
+		}
+		if f.ChildID != "" && s.ChildID() != f.ChildID {
+			continue
+		}
+
+		result = append(result, s)
+	}
+
+	return result, nil
+}
+
+func (r *memorySubgroupRepository) ListDescendantIDs(gID model.GroupID) ([]model.GroupID, error) {
+	return r.s.subgroups.DescendantIDs(gID), nil
+}
+
+func (r *memorySubgroupRepository) ListAncestorIDs(gIDs []model.GroupID) ([]model.GroupID, error) {
+	return r.s.subgroups.AncestorIDs(gIDs), nil
+}
+
+// ListAncestorIDsForUpdate is ListAncestorIDs, as the store is not safe for concurrent use and has nothing to lock.
+func (r *memorySubgroupRepository) ListAncestorIDsForUpdate(gIDs []model.GroupID) ([]model.GroupID, error) {
+	return r.ListAncestorIDs(gIDs)
+}
+
+func (r *memorySubgroupRepository) Create(s *model.Subgroup) error {
+	r.s.AddSubgroups(s)
+	return nil
+}
+
+func (r *memorySubgroupRepository) Delete(s *model.Subgroup) error {
+	var ss model.Subgroups
+	for _, ms := range r.s.subgroups {
+		if ms.ParentID() != s.ParentID() || ms.ChildID() != s.ChildID() {
+			ss = append(ss, ms)
+		}
+	}
+	r.s.subgroups = ss
+	return nil
+}
+
+func (r *memorySubgroupRepository) DeleteByGroupID(gID model.GroupID) error {
+	var ss model.Subgroups
+	for _, s := range r.s.subgroups {
+		if s.ParentID() != gID && s.ChildID() != gID {
+			ss = append(ss, s)
+		}
+	}
+	r.s.subgroups = ss
+	return nil
+}
@@ -2,6 +2,7 @@ package memory
 
 import (
 	"errors"
+	"sort"
 
 	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
 	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
@@ -35,13 +36,93 @@ func (r *memoryUserRepository) List(f repository.UserListFilter) (model.Users, e
 				continue
 			}
 		}
+		if len(f.Emails) > 0 {
+			found := false
+			for _, email := range f.Emails {
+				if u.Email() == email {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		if len(f.GroupIDs) > 0 && !r.belongsToAnyGroup(u.ID(), f.GroupIDs) {
+			continue
+		}
+		if f.Status != "" && u.Status() != f.Status {
+			continue
+		}
+		if !u.Attributes().Matches(f.Attributes) {
+			continue
+		}
+		if f.NoGroup && r.belongsToGroup(u.ID()) {
+			continue
+		}
+		if !f.CreatedAfter.IsZero() && !u.Timestamps().CreatedAt.After(f.CreatedAfter) {
+			continue
+		}
+		if f.AfterID != "" && u.ID() <= f.AfterID {
+			continue
+		}
+		if f.Query != "" {
+			if _, ok := u.SearchRank(f.Query); !ok {
+				continue
+			}
+		}
 
 		result = append(result, u)
 	}
 
+	if !f.Sort.IsZero() {
+		sort.Slice(result, func(i, j int) bool {
+			if c := f.Sort.Compare(result[i].Timestamps(), result[j].Timestamps()); c != 0 {
+				return c < 0
+			}
+			return result[i].ID() < result[j].ID()
+		})
+	} else if f.Query != "" {
+		sort.Slice(result, func(i, j int) bool {
+			ri, _ := result[i].SearchRank(f.Query)
+			rj, _ := result[j].SearchRank(f.Query)
+			if ri != rj {
+				return ri < rj
+			}
+			return result[i].ID() < result[j].ID()
+		})
+	} else if f.Limit > 0 {
+		sort.Slice(result, func(i, j int) bool { return result[i].ID() < result[j].ID() })
+	}
+	if f.Limit > 0 && len(result) > f.Limit {
+		result = result[:f.Limit]
+	}
+
 	return result, nil
 }
 
+func (r *memoryUserRepository) belongsToAnyGroup(uID model.UserID, gIDs []model.GroupID) bool {
+	for _, gID := range gIDs {
+		for _, g := range r.s.groups {
+			if g.ID() == gID && g.HasUser(uID) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *memoryUserRepository) belongsToGroup(uID model.UserID) bool {
+	for _, g := range r.s.groups {
+		for _, guID := range g.UserIDs() {
+			if guID == uID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (r *memoryUserRepository) Create(u *model.User) (*model.User, error) {
 	r.s.AddUsers(u)
 	return u, nil
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"errors"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+)
+
+type memoryWebhookDeliveryRepository struct {
+	s *store
+}
+
+func (r *memoryWebhookDeliveryRepository) Find(id model.WebhookDeliveryID) (*model.WebhookDelivery, error) {
+	for _, d := range r.s.webhookDeliveries {
+		if d.ID() == id {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryWebhookDeliveryRepository) List(f repository.WebhookDeliveryListFilter) (model.WebhookDeliveries, error) {
+	var result model.WebhookDeliveries
+	for _, d := range r.s.webhookDeliveries {
+		if f.SubscriptionID != "" && d.SubscriptionID() != f.SubscriptionID {
+			continue
+		}
+		if f.Status != "" && d.State().Status != f.Status {
+			continue
+		}
+		if !f.AvailableAt.IsZero() && d.State().NextAttemptAt.After(f.AvailableAt) {
+			continue
+		}
+		if f.Limit > 0 && len(result) >= f.Limit {
+			break
+		}
+
+		result = append(result, d)
+	}
+
+	return result, nil
+}
+
+func (r *memoryWebhookDeliveryRepository) Create(ds model.WebhookDeliveries) error {
+	r.s.AddWebhookDeliveries(ds...)
+	return nil
+}
+
+func (r *memoryWebhookDeliveryRepository) Update(d *model.WebhookDelivery) error {
+	if d.ID() == "" {
+		return errors.New("webhook delivery id must not be empty")
+	}
+
+	for i, sd := range r.s.webhookDeliveries {
+		if sd.ID() == d.ID() {
+			r.s.webhookDeliveries[i] = d
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"errors"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+type memoryWebhookSubscriptionRepository struct {
+	s *store
+}
+
+func (r *memoryWebhookSubscriptionRepository) Find(id model.WebhookSubscriptionID) (*model.WebhookSubscription, error) {
+	for _, s := range r.s.webhookSubscriptions {
+		if s.ID() == id {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryWebhookSubscriptionRepository) List() (model.WebhookSubscriptions, error) {
+	return r.s.webhookSubscriptions, nil
+}
+
+func (r *memoryWebhookSubscriptionRepository) Create(s *model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	r.s.AddWebhookSubscriptions(s)
+	return s, nil
+}
+
+func (r *memoryWebhookSubscriptionRepository) Update(s *model.WebhookSubscription) error {
+	if s.ID() == "" {
+		return errors.New("webhook subscription id must not be empty")
+	}
+
+	for i, ss := range r.s.webhookSubscriptions {
+		if ss.ID() == s.ID() {
+			r.s.webhookSubscriptions[i] = s
+			break
+		}
+	}
+
+	return nil
+}
+
+func (r *memoryWebhookSubscriptionRepository) Delete(id model.WebhookSubscriptionID) error {
+	var ss model.WebhookSubscriptions
+	for _, s := range r.s.webhookSubscriptions {
+		if s.ID() != id {
+			ss = append(ss, s)
+		}
+	}
+	r.s.webhookSubscriptions = ss
+
+	var ds model.WebhookDeliveries
+	for _, d := range r.s.webhookDeliveries {
+		if d.SubscriptionID() != id {
+			ds = append(ds, d)
+		}
+	}
+	r.s.webhookDeliveries = ds
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package publisher
+
+import (
+	"sync"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+// Bus publishes messages to in-process subscribers synchronously.
+type Bus struct {
+	mu       sync.RWMutex
+	nextID   int
+	handlers map[int]func(m *model.OutboxMessage) error
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: map[int]func(m *model.OutboxMessage) error{}}
+}
+
+// Subscribe registers h and returns a function that unregisters it.
+// Handlers are called synchronously, so they must not block.
+func (b *Bus) Subscribe(h func(m *model.OutboxMessage) error) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = h
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers, id)
+	}
+}
+
+func (b *Bus) Publish(m *model.OutboxMessage) error {
+	b.mu.RLock()
+	hs := make([]func(m *model.OutboxMessage) error, 0, len(b.handlers))
+	for _, h := range b.handlers {
+		hs = append(hs, h)
+	}
+	b.mu.RUnlock()
+
+	for _, h := range hs {
+		if err := h(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
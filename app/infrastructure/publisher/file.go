@@ -0,0 +1,39 @@
+package publisher
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+// File appends messages to a local file as newline delimited JSON.
+type File struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+func (p *File) Publish(m *model.OutboxMessage) error {
+	b, err := json.Marshal(NewMessage(m))
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f, err := os.OpenFile(p.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,30 @@
+package publisher
+
+import (
+	"encoding/json"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+// Logger is the subset of echo.Logger used by Log.
+type Logger interface {
+	Infof(format string, args ...interface{})
+}
+
+// Log writes messages to a logger, which is handy for local development.
+type Log struct {
+	l Logger
+}
+
+func NewLog(l Logger) *Log {
+	return &Log{l: l}
+}
+
+func (p *Log) Publish(m *model.OutboxMessage) error {
+	b, err := json.Marshal(NewMessage(m))
+	if err != nil {
+		return err
+	}
+	p.l.Infof("outbox message published: %s", b)
+	return nil
+}
@@ -0,0 +1,29 @@
+package publisher
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+// Message is the envelope every publisher sends for an outbox message.
+type Message struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   string          `json:"aggregateId"`
+	OccurredAt    time.Time       `json:"occurredAt"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+func NewMessage(m *model.OutboxMessage) Message {
+	return Message{
+		ID:            string(m.ID()),
+		Type:          string(m.EventType()),
+		AggregateType: string(m.AggregateType()),
+		AggregateID:   m.AggregateID(),
+		OccurredAt:    m.OccurredAt(),
+		Payload:       m.Payload(),
+	}
+}
@@ -0,0 +1,19 @@
+package publisher
+
+import (
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+)
+
+// Multi publishes messages to every publisher in order and stops at the first error.
+// The message is retried as a whole, so publishers before the failing one see it again.
+type Multi []usecase.Publisher
+
+func (p Multi) Publish(m *model.OutboxMessage) error {
+	for _, pub := range p {
+		if err := pub.Publish(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package publisher_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/publisher"
+)
+
+var occurredAt = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func newOutboxMessage() *model.OutboxMessage {
+	return model.MustNewOutboxMessage(
+		"TEST_OUTBOX_MESSAGE_ID",
+		model.DomainEventTypeUserCreated,
+		model.AggregateTypeUser,
+		"TEST_USER_ID",
+		[]byte(`{"userId":"TEST_USER_ID"}`),
+		occurredAt,
+		model.OutboxDelivery{},
+	)
+}
+
+var wantMessage = publisher.Message{
+	ID:            "TEST_OUTBOX_MESSAGE_ID",
+	Type:          "UserCreated",
+	AggregateType: "USER",
+	AggregateID:   "TEST_USER_ID",
+	OccurredAt:    occurredAt,
+	Payload:       json.RawMessage(`{"userId":"TEST_USER_ID"}`),
+}
+
+func TestBus_Publish(t *testing.T) {
+	b := publisher.NewBus()
+
+	var got []publisher.Message
+	unsubscribe := b.Subscribe(func(m *model.OutboxMessage) error {
+		got = append(got, publisher.NewMessage(m))
+		return nil
+	})
+
+	if err := b.Publish(newOutboxMessage()); err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	unsubscribe()
+	if err := b.Publish(newOutboxMessage()); err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+
+	if diff := cmp.Diff(got, []publisher.Message{wantMessage}); diff != "" {
+		t.Errorf("received messages differ: (-got +want)\n%s", diff)
+	}
+}
+
+func TestBus_Publish_Error(t *testing.T) {
+	b := publisher.NewBus()
+	wantErr := errors.New("an error occurred")
+	b.Subscribe(func(m *model.OutboxMessage) error { return wantErr })
+
+	if err := b.Publish(newOutboxMessage()); !errors.Is(err, wantErr) {
+		t.Errorf("b.Publish()=%v; want %v", err, wantErr)
+	}
+}
+
+func TestFile_Publish(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.ndjson")
+	p := publisher.NewFile(path)
+
+	for i := 0; i < 2; i++ {
+		if err := p.Publish(newOutboxMessage()); err != nil {
+			t.Fatalf("want no error, but has error %v", err)
+		}
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("len(lines)=%d; want 2", len(lines))
+	}
+
+	var got publisher.Message
+	if err := json.Unmarshal([]byte(lines[1]), &got); err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	if diff := cmp.Diff(got, wantMessage); diff != "" {
+		t.Errorf("written message differs: (-got +want)\n%s", diff)
+	}
+}
+
+func TestWebhook_Publish(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{
+			name:   "Posts the message",
+			status: http.StatusNoContent,
+		},
+		{
+			name:    "Error non 2xx response",
+			status:  http.StatusInternalServerError,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got publisher.Message
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("r.Method=%s; want %s", r.Method, http.MethodPost)
+				}
+				if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+					t.Errorf("Content-Type=%s; want application/json", ct)
+				}
+				b, _ := io.ReadAll(r.Body)
+				if err := json.Unmarshal(b, &got); err != nil {
+					t.Errorf("want no error, but has error %v", err)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			err := publisher.NewWebhook(srv.URL, srv.Client()).Publish(newOutboxMessage())
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("want an error, but has no error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, wantMessage); diff != "" {
+				t.Errorf("posted message differs: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMulti_Publish(t *testing.T) {
+	wantErr := errors.New("an error occurred")
+	failing := publisher.NewBus()
+	failing.Subscribe(func(m *model.OutboxMessage) error { return wantErr })
+
+	var called bool
+	last := publisher.NewBus()
+	last.Subscribe(func(m *model.OutboxMessage) error {
+		called = true
+		return nil
+	})
+
+	err := publisher.Multi{failing, last}.Publish(newOutboxMessage())
+	if !errors.Is(err, wantErr) {
+		t.Errorf("p.Publish()=%v; want %v", err, wantErr)
+	}
+	if called {
+		t.Error("the publisher after the failing one was called")
+	}
+}
@@ -0,0 +1,52 @@
+package publisher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+// Webhook posts messages as JSON to a single URL.
+// The receiver sees a message at least once: a message is posted again when the relay retries it,
+// e.g. after this or another publisher failed, so the receiver should skip the ids it has seen.
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhook returns a publisher posting to url with client,
+// or with a client timing out after 10 seconds if client is nil so that a hung receiver does not block the relay.
+func NewWebhook(url string, client *http.Client) *Webhook {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Webhook{url: url, client: client}
+}
+
+func (p *Webhook) Publish(m *model.OutboxMessage) error {
+	b, err := json.Marshal(NewMessage(m))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+	return nil
+}
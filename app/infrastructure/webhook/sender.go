@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
+
+const (
+	HeaderXWebhookID        = "X-Webhook-Id"
+	HeaderXWebhookEvent     = "X-Webhook-Event"
+	HeaderXWebhookTimestamp = "X-Webhook-Timestamp"
+	HeaderXWebhookSignature = "X-Webhook-Signature"
+)
+
+// Payload is the body of a delivery.
+// ID is the id of the event, which is the same among the retries of a delivery.
+// A delivery is retried until the receiver responds with 2xx, so the receiver may see an event
+// more than once and should skip the ids it has seen.
+type Payload struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Sender posts deliveries as JSON signed with the secret of the subscription.
+type Sender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewSender(client *http.Client) *Sender {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Sender{client: client, now: time.Now}
+}
+
+func (s *Sender) Send(sub *model.WebhookSubscription, d *model.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(Payload{
+		ID:         string(d.EventID()),
+		Type:       string(d.EventType()),
+		OccurredAt: d.OccurredAt(),
+		Data:       d.Payload(),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, sub.URL(), bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderXWebhookID, string(d.ID()))
+	req.Header.Set(HeaderXWebhookEvent, string(d.EventType()))
+	req.Header.Set(HeaderXWebhookTimestamp, timestamp)
+	req.Header.Set(HeaderXWebhookSignature, Sign(sub.Secret(), timestamp, body))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
+
+// Sign returns the signature of a delivery, which is the hex encoded HMAC-SHA256 of "<timestamp>.<body>".
+// Receivers compute the same value to verify the delivery and reject old timestamps to prevent replays.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,114 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/webhook"
+)
+
+func TestSender_Send(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	d := model.MustNewWebhookDelivery(
+		"TEST_WEBHOOK_DELIVERY_ID",
+		"TEST_WEBHOOK_SUBSCRIPTION_ID",
+		"TEST_EVENT_ID",
+		model.DomainEventTypeUserCreated,
+		[]byte(`{"userId":"TEST_USER_ID"}`),
+		occurredAt,
+		model.WebhookDeliveryState{},
+	)
+
+	tests := []struct {
+		name           string
+		status         int
+		wantStatusCode int
+		wantErr        bool
+	}{
+		{
+			name:           "Sends the signed delivery",
+			status:         http.StatusOK,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "Error non 2xx response",
+			status:         http.StatusServiceUnavailable,
+			wantStatusCode: http.StatusServiceUnavailable,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				gotHeader http.Header
+				gotBody   []byte
+			)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotHeader = r.Header.Clone()
+				gotBody, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			s := model.MustNewWebhookSubscription(
+				"TEST_WEBHOOK_SUBSCRIPTION_ID",
+				srv.URL,
+				[]model.DomainEventType{model.DomainEventTypeUserCreated},
+				"TEST_SECRET",
+			)
+
+			statusCode, err := webhook.NewSender(srv.Client()).Send(s, d)
+			if statusCode != tt.wantStatusCode {
+				t.Errorf("statusCode=%d; want %d", statusCode, tt.wantStatusCode)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("want an error, but has no error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+
+			timestamp := gotHeader.Get(webhook.HeaderXWebhookTimestamp)
+			wantSignature := webhook.Sign("TEST_SECRET", timestamp, gotBody)
+			if got := gotHeader.Get(webhook.HeaderXWebhookSignature); got != wantSignature {
+				t.Errorf("signature=%s; want %s", got, wantSignature)
+			}
+			if got := gotHeader.Get(webhook.HeaderXWebhookID); got != "TEST_WEBHOOK_DELIVERY_ID" {
+				t.Errorf("delivery id=%s; want TEST_WEBHOOK_DELIVERY_ID", got)
+			}
+
+			var got webhook.Payload
+			if err := json.Unmarshal(gotBody, &got); err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			want := webhook.Payload{
+				ID:         "TEST_EVENT_ID",
+				Type:       "UserCreated",
+				OccurredAt: occurredAt,
+				Data:       json.RawMessage(`{"userId":"TEST_USER_ID"}`),
+			}
+			if diff := cmp.Diff(got, want); diff != "" {
+				t.Errorf("payload differs: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	got := webhook.Sign("TEST_SECRET", "1672628645", []byte(`{}`))
+	want := "sha256=f2c34efb7a4dc6e0d79c862a7b769256c6b444fbe8b6e854b8395b3d3af6b3a8"
+	if got != want {
+		t.Errorf("webhook.Sign()=%s; want %s", got, want)
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: auditevent.go
+
+// Package mockfactory is a generated GoMock package.
+package mockfactory
+
+import (
+	reflect "reflect"
+	time "time"
+
+	model "github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuditEventFactory is a mock of AuditEventFactory interface.
+type MockAuditEventFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditEventFactoryMockRecorder
+}
+
+// MockAuditEventFactoryMockRecorder is the mock recorder for MockAuditEventFactory.
+type MockAuditEventFactoryMockRecorder struct {
+	mock *MockAuditEventFactory
+}
+
+// NewMockAuditEventFactory creates a new mock instance.
+func NewMockAuditEventFactory(ctrl *gomock.Controller) *MockAuditEventFactory {
+	mock := &MockAuditEventFactory{ctrl: ctrl}
+	mock.recorder = &MockAuditEventFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditEventFactory) EXPECT() *MockAuditEventFactoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAuditEventFactory) Create(actor, requestID string, action model.AuditAction, target model.AuditTarget, changes []model.AuditChange, occurredAt time.Time) (*model.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", actor, requestID, action, target, changes, occurredAt)
+	ret0, _ := ret[0].(*model.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAuditEventFactoryMockRecorder) Create(actor, requestID, action, target, changes, occurredAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuditEventFactory)(nil).Create), actor, requestID, action, target, changes, occurredAt)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: groupinvitation.go
+
+// Package mockfactory is a generated GoMock package.
+package mockfactory
+
+import (
+	reflect "reflect"
+	time "time"
+
+	model "github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGroupInvitationFactory is a mock of GroupInvitationFactory interface.
+type MockGroupInvitationFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupInvitationFactoryMockRecorder
+}
+
+// MockGroupInvitationFactoryMockRecorder is the mock recorder for MockGroupInvitationFactory.
+type MockGroupInvitationFactoryMockRecorder struct {
+	mock *MockGroupInvitationFactory
+}
+
+// NewMockGroupInvitationFactory creates a new mock instance.
+func NewMockGroupInvitationFactory(ctrl *gomock.Controller) *MockGroupInvitationFactory {
+	mock := &MockGroupInvitationFactory{ctrl: ctrl}
+	mock.recorder = &MockGroupInvitationFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupInvitationFactory) EXPECT() *MockGroupInvitationFactoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockGroupInvitationFactory) Create(gID model.GroupID, inviter string, invitee model.GroupInvitee, createdAt time.Time) (*model.GroupInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", gID, inviter, invitee, createdAt)
+	ret0, _ := ret[0].(*model.GroupInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGroupInvitationFactoryMockRecorder) Create(gID, inviter, invitee, createdAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGroupInvitationFactory)(nil).Create), gID, inviter, invitee, createdAt)
+}
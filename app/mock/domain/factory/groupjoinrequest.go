@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: groupjoinrequest.go
+
+// Package mockfactory is a generated GoMock package.
+package mockfactory
+
+import (
+	reflect "reflect"
+	time "time"
+
+	model "github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGroupJoinRequestFactory is a mock of GroupJoinRequestFactory interface.
+type MockGroupJoinRequestFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupJoinRequestFactoryMockRecorder
+}
+
+// MockGroupJoinRequestFactoryMockRecorder is the mock recorder for MockGroupJoinRequestFactory.
+type MockGroupJoinRequestFactoryMockRecorder struct {
+	mock *MockGroupJoinRequestFactory
+}
+
+// NewMockGroupJoinRequestFactory creates a new mock instance.
+func NewMockGroupJoinRequestFactory(ctrl *gomock.Controller) *MockGroupJoinRequestFactory {
+	mock := &MockGroupJoinRequestFactory{ctrl: ctrl}
+	mock.recorder = &MockGroupJoinRequestFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupJoinRequestFactory) EXPECT() *MockGroupJoinRequestFactoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockGroupJoinRequestFactory) Create(gID model.GroupID, uID model.UserID, createdAt time.Time) (*model.GroupJoinRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", gID, uID, createdAt)
+	ret0, _ := ret[0].(*model.GroupJoinRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGroupJoinRequestFactoryMockRecorder) Create(gID, uID, createdAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGroupJoinRequestFactory)(nil).Create), gID, uID, createdAt)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: outboxmessage.go
+
+// Package mockfactory is a generated GoMock package.
+package mockfactory
+
+import (
+	reflect "reflect"
+	time "time"
+
+	model "github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutboxMessageFactory is a mock of OutboxMessageFactory interface.
+type MockOutboxMessageFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMessageFactoryMockRecorder
+}
+
+// MockOutboxMessageFactoryMockRecorder is the mock recorder for MockOutboxMessageFactory.
+type MockOutboxMessageFactoryMockRecorder struct {
+	mock *MockOutboxMessageFactory
+}
+
+// NewMockOutboxMessageFactory creates a new mock instance.
+func NewMockOutboxMessageFactory(ctrl *gomock.Controller) *MockOutboxMessageFactory {
+	mock := &MockOutboxMessageFactory{ctrl: ctrl}
+	mock.recorder = &MockOutboxMessageFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxMessageFactory) EXPECT() *MockOutboxMessageFactoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOutboxMessageFactory) Create(es []model.DomainEvent, occurredAt time.Time) (model.OutboxMessages, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", es, occurredAt)
+	ret0, _ := ret[0].(model.OutboxMessages)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOutboxMessageFactoryMockRecorder) Create(es, occurredAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOutboxMessageFactory)(nil).Create), es, occurredAt)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhookdelivery.go
+
+// Package mockfactory is a generated GoMock package.
+package mockfactory
+
+import (
+	reflect "reflect"
+
+	model "github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookDeliveryFactory is a mock of WebhookDeliveryFactory interface.
+type MockWebhookDeliveryFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookDeliveryFactoryMockRecorder
+}
+
+// MockWebhookDeliveryFactoryMockRecorder is the mock recorder for MockWebhookDeliveryFactory.
+type MockWebhookDeliveryFactoryMockRecorder struct {
+	mock *MockWebhookDeliveryFactory
+}
+
+// NewMockWebhookDeliveryFactory creates a new mock instance.
+func NewMockWebhookDeliveryFactory(ctrl *gomock.Controller) *MockWebhookDeliveryFactory {
+	mock := &MockWebhookDeliveryFactory{ctrl: ctrl}
+	mock.recorder = &MockWebhookDeliveryFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookDeliveryFactory) EXPECT() *MockWebhookDeliveryFactoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhookDeliveryFactory) Create(ss model.WebhookSubscriptions, ms model.OutboxMessages) (model.WebhookDeliveries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ss, ms)
+	ret0, _ := ret[0].(model.WebhookDeliveries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookDeliveryFactoryMockRecorder) Create(ss, ms interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookDeliveryFactory)(nil).Create), ss, ms)
+}
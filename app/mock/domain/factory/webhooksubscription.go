@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhooksubscription.go
+
+// Package mockfactory is a generated GoMock package.
+package mockfactory
+
+import (
+	reflect "reflect"
+
+	model "github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookSubscriptionFactory is a mock of WebhookSubscriptionFactory interface.
+type MockWebhookSubscriptionFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookSubscriptionFactoryMockRecorder
+}
+
+// MockWebhookSubscriptionFactoryMockRecorder is the mock recorder for MockWebhookSubscriptionFactory.
+type MockWebhookSubscriptionFactoryMockRecorder struct {
+	mock *MockWebhookSubscriptionFactory
+}
+
+// NewMockWebhookSubscriptionFactory creates a new mock instance.
+func NewMockWebhookSubscriptionFactory(ctrl *gomock.Controller) *MockWebhookSubscriptionFactory {
+	mock := &MockWebhookSubscriptionFactory{ctrl: ctrl}
+	mock.recorder = &MockWebhookSubscriptionFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookSubscriptionFactory) EXPECT() *MockWebhookSubscriptionFactoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhookSubscriptionFactory) Create(url string, eventTypes []model.DomainEventType, secret string) (*model.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", url, eventTypes, secret)
+	ret0, _ := ret[0].(*model.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookSubscriptionFactoryMockRecorder) Create(url, eventTypes, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookSubscriptionFactory)(nil).Create), url, eventTypes, secret)
+}
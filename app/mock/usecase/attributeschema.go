@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: attributeschema.go
+
+// Package mockusecase is a generated GoMock package.
+package mockusecase
+
+import (
+	reflect "reflect"
+
+	dto "github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAttributeSchemaUsecase is a mock of AttributeSchemaUsecase interface.
+type MockAttributeSchemaUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttributeSchemaUsecaseMockRecorder
+}
+
+// MockAttributeSchemaUsecaseMockRecorder is the mock recorder for MockAttributeSchemaUsecase.
+type MockAttributeSchemaUsecaseMockRecorder struct {
+	mock *MockAttributeSchemaUsecase
+}
+
+// NewMockAttributeSchemaUsecase creates a new mock instance.
+func NewMockAttributeSchemaUsecase(ctrl *gomock.Controller) *MockAttributeSchemaUsecase {
+	mock := &MockAttributeSchemaUsecase{ctrl: ctrl}
+	mock.recorder = &MockAttributeSchemaUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttributeSchemaUsecase) EXPECT() *MockAttributeSchemaUsecaseMockRecorder {
+	return m.recorder
+}
+
+// DeleteAttributeSchema mocks base method.
+func (m *MockAttributeSchemaUsecase) DeleteAttributeSchema(in *dto.DeleteAttributeSchemaInput) (*dto.DeleteAttributeSchemaOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttributeSchema", in)
+	ret0, _ := ret[0].(*dto.DeleteAttributeSchemaOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAttributeSchema indicates an expected call of DeleteAttributeSchema.
+func (mr *MockAttributeSchemaUsecaseMockRecorder) DeleteAttributeSchema(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttributeSchema", reflect.TypeOf((*MockAttributeSchemaUsecase)(nil).DeleteAttributeSchema), in)
+}
+
+// GetAttributeSchemas mocks base method.
+func (m *MockAttributeSchemaUsecase) GetAttributeSchemas(in *dto.GetAttributeSchemasInput) (*dto.GetAttributeSchemasOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttributeSchemas", in)
+	ret0, _ := ret[0].(*dto.GetAttributeSchemasOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttributeSchemas indicates an expected call of GetAttributeSchemas.
+func (mr *MockAttributeSchemaUsecaseMockRecorder) GetAttributeSchemas(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttributeSchemas", reflect.TypeOf((*MockAttributeSchemaUsecase)(nil).GetAttributeSchemas), in)
+}
+
+// PutAttributeSchema mocks base method.
+func (m *MockAttributeSchemaUsecase) PutAttributeSchema(in *dto.PutAttributeSchemaInput) (*dto.PutAttributeSchemaOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutAttributeSchema", in)
+	ret0, _ := ret[0].(*dto.PutAttributeSchemaOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutAttributeSchema indicates an expected call of PutAttributeSchema.
+func (mr *MockAttributeSchemaUsecaseMockRecorder) PutAttributeSchema(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutAttributeSchema", reflect.TypeOf((*MockAttributeSchemaUsecase)(nil).PutAttributeSchema), in)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: auditevent.go
+
+// Package mockusecase is a generated GoMock package.
+package mockusecase
+
+import (
+	reflect "reflect"
+
+	dto "github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuditEventUsecase is a mock of AuditEventUsecase interface.
+type MockAuditEventUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditEventUsecaseMockRecorder
+}
+
+// MockAuditEventUsecaseMockRecorder is the mock recorder for MockAuditEventUsecase.
+type MockAuditEventUsecaseMockRecorder struct {
+	mock *MockAuditEventUsecase
+}
+
+// NewMockAuditEventUsecase creates a new mock instance.
+func NewMockAuditEventUsecase(ctrl *gomock.Controller) *MockAuditEventUsecase {
+	mock := &MockAuditEventUsecase{ctrl: ctrl}
+	mock.recorder = &MockAuditEventUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditEventUsecase) EXPECT() *MockAuditEventUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetAuditEvents mocks base method.
+func (m *MockAuditEventUsecase) GetAuditEvents(in *dto.GetAuditEventsInput) (*dto.GetAuditEventsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditEvents", in)
+	ret0, _ := ret[0].(*dto.GetAuditEventsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditEvents indicates an expected call of GetAuditEvents.
+func (mr *MockAuditEventUsecaseMockRecorder) GetAuditEvents(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEvents", reflect.TypeOf((*MockAuditEventUsecase)(nil).GetAuditEvents), in)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: batch.go
+
+// Package mockusecase is a generated GoMock package.
+package mockusecase
+
+import (
+	reflect "reflect"
+
+	dto "github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBatchUsecase is a mock of BatchUsecase interface.
+type MockBatchUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchUsecaseMockRecorder
+}
+
+// MockBatchUsecaseMockRecorder is the mock recorder for MockBatchUsecase.
+type MockBatchUsecaseMockRecorder struct {
+	mock *MockBatchUsecase
+}
+
+// NewMockBatchUsecase creates a new mock instance.
+func NewMockBatchUsecase(ctrl *gomock.Controller) *MockBatchUsecase {
+	mock := &MockBatchUsecase{ctrl: ctrl}
+	mock.recorder = &MockBatchUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchUsecase) EXPECT() *MockBatchUsecaseMockRecorder {
+	return m.recorder
+}
+
+// RunBatch mocks base method.
+func (m *MockBatchUsecase) RunBatch(in *dto.RunBatchInput) (*dto.RunBatchOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunBatch", in)
+	ret0, _ := ret[0].(*dto.RunBatchOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunBatch indicates an expected call of RunBatch.
+func (mr *MockBatchUsecaseMockRecorder) RunBatch(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunBatch", reflect.TypeOf((*MockBatchUsecase)(nil).RunBatch), in)
+}
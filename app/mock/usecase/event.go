@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: event.go
+
+// Package mockusecase is a generated GoMock package.
+package mockusecase
+
+import (
+	reflect "reflect"
+
+	model "github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	dto "github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventSource is a mock of EventSource interface.
+type MockEventSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventSourceMockRecorder
+}
+
+// MockEventSourceMockRecorder is the mock recorder for MockEventSource.
+type MockEventSourceMockRecorder struct {
+	mock *MockEventSource
+}
+
+// NewMockEventSource creates a new mock instance.
+func NewMockEventSource(ctrl *gomock.Controller) *MockEventSource {
+	mock := &MockEventSource{ctrl: ctrl}
+	mock.recorder = &MockEventSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventSource) EXPECT() *MockEventSourceMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockEventSource) Subscribe(h func(*model.OutboxMessage) error) func() {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", h)
+	ret0, _ := ret[0].(func())
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockEventSourceMockRecorder) Subscribe(h interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockEventSource)(nil).Subscribe), h)
+}
+
+// MockEventUsecase is a mock of EventUsecase interface.
+type MockEventUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventUsecaseMockRecorder
+}
+
+// MockEventUsecaseMockRecorder is the mock recorder for MockEventUsecase.
+type MockEventUsecaseMockRecorder struct {
+	mock *MockEventUsecase
+}
+
+// NewMockEventUsecase creates a new mock instance.
+func NewMockEventUsecase(ctrl *gomock.Controller) *MockEventUsecase {
+	mock := &MockEventUsecase{ctrl: ctrl}
+	mock.recorder = &MockEventUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventUsecase) EXPECT() *MockEventUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetEvents mocks base method.
+func (m *MockEventUsecase) GetEvents(in *dto.GetEventsInput) (*dto.GetEventsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvents", in)
+	ret0, _ := ret[0].(*dto.GetEventsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvents indicates an expected call of GetEvents.
+func (mr *MockEventUsecaseMockRecorder) GetEvents(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockEventUsecase)(nil).GetEvents), in)
+}
+
+// SubscribeEvents mocks base method.
+func (m *MockEventUsecase) SubscribeEvents(in *dto.SubscribeEventsInput) (*dto.SubscribeEventsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeEvents", in)
+	ret0, _ := ret[0].(*dto.SubscribeEventsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeEvents indicates an expected call of SubscribeEvents.
+func (mr *MockEventUsecaseMockRecorder) SubscribeEvents(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeEvents", reflect.TypeOf((*MockEventUsecase)(nil).SubscribeEvents), in)
+}
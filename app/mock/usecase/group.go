@@ -34,6 +34,36 @@ func (m *MockGroupUsecase) EXPECT() *MockGroupUsecaseMockRecorder {
 	return m.recorder
 }
 
+// AddGroupUsers mocks base method.
+func (m *MockGroupUsecase) AddGroupUsers(in *dto.AddGroupUsersInput) (*dto.AddGroupUsersOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGroupUsers", in)
+	ret0, _ := ret[0].(*dto.AddGroupUsersOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddGroupUsers indicates an expected call of AddGroupUsers.
+func (mr *MockGroupUsecaseMockRecorder) AddGroupUsers(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGroupUsers", reflect.TypeOf((*MockGroupUsecase)(nil).AddGroupUsers), in)
+}
+
+// AddSubgroup mocks base method.
+func (m *MockGroupUsecase) AddSubgroup(in *dto.AddSubgroupInput) (*dto.AddSubgroupOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSubgroup", in)
+	ret0, _ := ret[0].(*dto.AddSubgroupOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSubgroup indicates an expected call of AddSubgroup.
+func (mr *MockGroupUsecaseMockRecorder) AddSubgroup(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubgroup", reflect.TypeOf((*MockGroupUsecase)(nil).AddSubgroup), in)
+}
+
 // CreateGroup mocks base method.
 func (m *MockGroupUsecase) CreateGroup(in *dto.CreateGroupInput) (*dto.CreateGroupOutput, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +94,21 @@ func (mr *MockGroupUsecaseMockRecorder) DeleteGroup(in interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockGroupUsecase)(nil).DeleteGroup), in)
 }
 
+// ExportGroups mocks base method.
+func (m *MockGroupUsecase) ExportGroups(in *dto.ExportGroupsInput) (*dto.ExportGroupsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportGroups", in)
+	ret0, _ := ret[0].(*dto.ExportGroupsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportGroups indicates an expected call of ExportGroups.
+func (mr *MockGroupUsecaseMockRecorder) ExportGroups(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportGroups", reflect.TypeOf((*MockGroupUsecase)(nil).ExportGroups), in)
+}
+
 // GetGroup mocks base method.
 func (m *MockGroupUsecase) GetGroup(in *dto.GetGroupInput) (*dto.GetGroupOutput, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +124,36 @@ func (mr *MockGroupUsecaseMockRecorder) GetGroup(in interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroup", reflect.TypeOf((*MockGroupUsecase)(nil).GetGroup), in)
 }
 
+// GetGroupMembers mocks base method.
+func (m *MockGroupUsecase) GetGroupMembers(in *dto.GetGroupMembersInput) (*dto.GetGroupMembersOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupMembers", in)
+	ret0, _ := ret[0].(*dto.GetGroupMembersOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupMembers indicates an expected call of GetGroupMembers.
+func (mr *MockGroupUsecaseMockRecorder) GetGroupMembers(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupMembers", reflect.TypeOf((*MockGroupUsecase)(nil).GetGroupMembers), in)
+}
+
+// GetGroupWaitlist mocks base method.
+func (m *MockGroupUsecase) GetGroupWaitlist(in *dto.GetGroupWaitlistInput) (*dto.GetGroupWaitlistOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupWaitlist", in)
+	ret0, _ := ret[0].(*dto.GetGroupWaitlistOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupWaitlist indicates an expected call of GetGroupWaitlist.
+func (mr *MockGroupUsecaseMockRecorder) GetGroupWaitlist(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupWaitlist", reflect.TypeOf((*MockGroupUsecase)(nil).GetGroupWaitlist), in)
+}
+
 // GetGroups mocks base method.
 func (m *MockGroupUsecase) GetGroups(in *dto.GetGroupsInput) (*dto.GetGroupsOutput, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +169,126 @@ func (mr *MockGroupUsecaseMockRecorder) GetGroups(in interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroups", reflect.TypeOf((*MockGroupUsecase)(nil).GetGroups), in)
 }
 
+// GetSubgroups mocks base method.
+func (m *MockGroupUsecase) GetSubgroups(in *dto.GetSubgroupsInput) (*dto.GetSubgroupsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubgroups", in)
+	ret0, _ := ret[0].(*dto.GetSubgroupsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubgroups indicates an expected call of GetSubgroups.
+func (mr *MockGroupUsecaseMockRecorder) GetSubgroups(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubgroups", reflect.TypeOf((*MockGroupUsecase)(nil).GetSubgroups), in)
+}
+
+// GetUserGroups mocks base method.
+func (m *MockGroupUsecase) GetUserGroups(in *dto.GetUserGroupsInput) (*dto.GetUserGroupsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserGroups", in)
+	ret0, _ := ret[0].(*dto.GetUserGroupsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserGroups indicates an expected call of GetUserGroups.
+func (mr *MockGroupUsecaseMockRecorder) GetUserGroups(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserGroups", reflect.TypeOf((*MockGroupUsecase)(nil).GetUserGroups), in)
+}
+
+// LeaveGroupWaitlist mocks base method.
+func (m *MockGroupUsecase) LeaveGroupWaitlist(in *dto.LeaveGroupWaitlistInput) (*dto.LeaveGroupWaitlistOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaveGroupWaitlist", in)
+	ret0, _ := ret[0].(*dto.LeaveGroupWaitlistOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LeaveGroupWaitlist indicates an expected call of LeaveGroupWaitlist.
+func (mr *MockGroupUsecaseMockRecorder) LeaveGroupWaitlist(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaveGroupWaitlist", reflect.TypeOf((*MockGroupUsecase)(nil).LeaveGroupWaitlist), in)
+}
+
+// PatchGroup mocks base method.
+func (m *MockGroupUsecase) PatchGroup(in *dto.PatchGroupInput) (*dto.PatchGroupOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchGroup", in)
+	ret0, _ := ret[0].(*dto.PatchGroupOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchGroup indicates an expected call of PatchGroup.
+func (mr *MockGroupUsecaseMockRecorder) PatchGroup(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchGroup", reflect.TypeOf((*MockGroupUsecase)(nil).PatchGroup), in)
+}
+
+// PutGroupLabels mocks base method.
+func (m *MockGroupUsecase) PutGroupLabels(in *dto.PutGroupLabelsInput) (*dto.PutGroupLabelsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutGroupLabels", in)
+	ret0, _ := ret[0].(*dto.PutGroupLabelsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutGroupLabels indicates an expected call of PutGroupLabels.
+func (mr *MockGroupUsecaseMockRecorder) PutGroupLabels(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutGroupLabels", reflect.TypeOf((*MockGroupUsecase)(nil).PutGroupLabels), in)
+}
+
+// RemoveGroupUsers mocks base method.
+func (m *MockGroupUsecase) RemoveGroupUsers(in *dto.RemoveGroupUsersInput) (*dto.RemoveGroupUsersOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveGroupUsers", in)
+	ret0, _ := ret[0].(*dto.RemoveGroupUsersOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveGroupUsers indicates an expected call of RemoveGroupUsers.
+func (mr *MockGroupUsecaseMockRecorder) RemoveGroupUsers(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveGroupUsers", reflect.TypeOf((*MockGroupUsecase)(nil).RemoveGroupUsers), in)
+}
+
+// RemoveSubgroup mocks base method.
+func (m *MockGroupUsecase) RemoveSubgroup(in *dto.RemoveSubgroupInput) (*dto.RemoveSubgroupOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSubgroup", in)
+	ret0, _ := ret[0].(*dto.RemoveSubgroupOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveSubgroup indicates an expected call of RemoveSubgroup.
+func (mr *MockGroupUsecaseMockRecorder) RemoveSubgroup(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubgroup", reflect.TypeOf((*MockGroupUsecase)(nil).RemoveSubgroup), in)
+}
+
+// ReorderGroupWaitlist mocks base method.
+func (m *MockGroupUsecase) ReorderGroupWaitlist(in *dto.ReorderGroupWaitlistInput) (*dto.ReorderGroupWaitlistOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderGroupWaitlist", in)
+	ret0, _ := ret[0].(*dto.ReorderGroupWaitlistOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderGroupWaitlist indicates an expected call of ReorderGroupWaitlist.
+func (mr *MockGroupUsecaseMockRecorder) ReorderGroupWaitlist(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderGroupWaitlist", reflect.TypeOf((*MockGroupUsecase)(nil).ReorderGroupWaitlist), in)
+}
+
 // UpdateGroup mocks base method.
 func (m *MockGroupUsecase) UpdateGroup(in *dto.UpdateGroupInput) (*dto.UpdateGroupOutput, error) {
 	m.ctrl.T.Helper()
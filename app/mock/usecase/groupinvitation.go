@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: groupinvitation.go
+
+// Package mockusecase is a generated GoMock package.
+package mockusecase
+
+import (
+	reflect "reflect"
+
+	dto "github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGroupInvitationUsecase is a mock of GroupInvitationUsecase interface.
+type MockGroupInvitationUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupInvitationUsecaseMockRecorder
+}
+
+// MockGroupInvitationUsecaseMockRecorder is the mock recorder for MockGroupInvitationUsecase.
+type MockGroupInvitationUsecaseMockRecorder struct {
+	mock *MockGroupInvitationUsecase
+}
+
+// NewMockGroupInvitationUsecase creates a new mock instance.
+func NewMockGroupInvitationUsecase(ctrl *gomock.Controller) *MockGroupInvitationUsecase {
+	mock := &MockGroupInvitationUsecase{ctrl: ctrl}
+	mock.recorder = &MockGroupInvitationUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupInvitationUsecase) EXPECT() *MockGroupInvitationUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AcceptGroupInvitation mocks base method.
+func (m *MockGroupInvitationUsecase) AcceptGroupInvitation(in *dto.AcceptGroupInvitationInput) (*dto.AcceptGroupInvitationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptGroupInvitation", in)
+	ret0, _ := ret[0].(*dto.AcceptGroupInvitationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptGroupInvitation indicates an expected call of AcceptGroupInvitation.
+func (mr *MockGroupInvitationUsecaseMockRecorder) AcceptGroupInvitation(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptGroupInvitation", reflect.TypeOf((*MockGroupInvitationUsecase)(nil).AcceptGroupInvitation), in)
+}
+
+// DeclineGroupInvitation mocks base method.
+func (m *MockGroupInvitationUsecase) DeclineGroupInvitation(in *dto.DeclineGroupInvitationInput) (*dto.DeclineGroupInvitationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineGroupInvitation", in)
+	ret0, _ := ret[0].(*dto.DeclineGroupInvitationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeclineGroupInvitation indicates an expected call of DeclineGroupInvitation.
+func (mr *MockGroupInvitationUsecaseMockRecorder) DeclineGroupInvitation(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineGroupInvitation", reflect.TypeOf((*MockGroupInvitationUsecase)(nil).DeclineGroupInvitation), in)
+}
+
+// ExpireGroupInvitations mocks base method.
+func (m *MockGroupInvitationUsecase) ExpireGroupInvitations(in *dto.ExpireGroupInvitationsInput) (*dto.ExpireGroupInvitationsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireGroupInvitations", in)
+	ret0, _ := ret[0].(*dto.ExpireGroupInvitationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireGroupInvitations indicates an expected call of ExpireGroupInvitations.
+func (mr *MockGroupInvitationUsecaseMockRecorder) ExpireGroupInvitations(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireGroupInvitations", reflect.TypeOf((*MockGroupInvitationUsecase)(nil).ExpireGroupInvitations), in)
+}
+
+// GetGroupInvitations mocks base method.
+func (m *MockGroupInvitationUsecase) GetGroupInvitations(in *dto.GetGroupInvitationsInput) (*dto.GetGroupInvitationsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupInvitations", in)
+	ret0, _ := ret[0].(*dto.GetGroupInvitationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupInvitations indicates an expected call of GetGroupInvitations.
+func (mr *MockGroupInvitationUsecaseMockRecorder) GetGroupInvitations(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupInvitations", reflect.TypeOf((*MockGroupInvitationUsecase)(nil).GetGroupInvitations), in)
+}
+
+// GetUserInvitations mocks base method.
+func (m *MockGroupInvitationUsecase) GetUserInvitations(in *dto.GetUserInvitationsInput) (*dto.GetUserInvitationsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserInvitations", in)
+	ret0, _ := ret[0].(*dto.GetUserInvitationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserInvitations indicates an expected call of GetUserInvitations.
+func (mr *MockGroupInvitationUsecaseMockRecorder) GetUserInvitations(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInvitations", reflect.TypeOf((*MockGroupInvitationUsecase)(nil).GetUserInvitations), in)
+}
+
+// InviteToGroup mocks base method.
+func (m *MockGroupInvitationUsecase) InviteToGroup(in *dto.InviteToGroupInput) (*dto.InviteToGroupOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteToGroup", in)
+	ret0, _ := ret[0].(*dto.InviteToGroupOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InviteToGroup indicates an expected call of InviteToGroup.
+func (mr *MockGroupInvitationUsecaseMockRecorder) InviteToGroup(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteToGroup", reflect.TypeOf((*MockGroupInvitationUsecase)(nil).InviteToGroup), in)
+}
+
+// RevokeGroupInvitation mocks base method.
+func (m *MockGroupInvitationUsecase) RevokeGroupInvitation(in *dto.RevokeGroupInvitationInput) (*dto.RevokeGroupInvitationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeGroupInvitation", in)
+	ret0, _ := ret[0].(*dto.RevokeGroupInvitationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeGroupInvitation indicates an expected call of RevokeGroupInvitation.
+func (mr *MockGroupInvitationUsecaseMockRecorder) RevokeGroupInvitation(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeGroupInvitation", reflect.TypeOf((*MockGroupInvitationUsecase)(nil).RevokeGroupInvitation), in)
+}
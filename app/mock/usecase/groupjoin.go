@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: groupjoin.go
+
+// Package mockusecase is a generated GoMock package.
+package mockusecase
+
+import (
+	reflect "reflect"
+
+	dto "github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGroupJoinUsecase is a mock of GroupJoinUsecase interface.
+type MockGroupJoinUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupJoinUsecaseMockRecorder
+}
+
+// MockGroupJoinUsecaseMockRecorder is the mock recorder for MockGroupJoinUsecase.
+type MockGroupJoinUsecaseMockRecorder struct {
+	mock *MockGroupJoinUsecase
+}
+
+// NewMockGroupJoinUsecase creates a new mock instance.
+func NewMockGroupJoinUsecase(ctrl *gomock.Controller) *MockGroupJoinUsecase {
+	mock := &MockGroupJoinUsecase{ctrl: ctrl}
+	mock.recorder = &MockGroupJoinUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupJoinUsecase) EXPECT() *MockGroupJoinUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ApproveGroupJoinRequest mocks base method.
+func (m *MockGroupJoinUsecase) ApproveGroupJoinRequest(in *dto.ApproveGroupJoinRequestInput) (*dto.ApproveGroupJoinRequestOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveGroupJoinRequest", in)
+	ret0, _ := ret[0].(*dto.ApproveGroupJoinRequestOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveGroupJoinRequest indicates an expected call of ApproveGroupJoinRequest.
+func (mr *MockGroupJoinUsecaseMockRecorder) ApproveGroupJoinRequest(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveGroupJoinRequest", reflect.TypeOf((*MockGroupJoinUsecase)(nil).ApproveGroupJoinRequest), in)
+}
+
+// GetGroupJoinRequests mocks base method.
+func (m *MockGroupJoinUsecase) GetGroupJoinRequests(in *dto.GetGroupJoinRequestsInput) (*dto.GetGroupJoinRequestsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupJoinRequests", in)
+	ret0, _ := ret[0].(*dto.GetGroupJoinRequestsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupJoinRequests indicates an expected call of GetGroupJoinRequests.
+func (mr *MockGroupJoinUsecaseMockRecorder) GetGroupJoinRequests(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupJoinRequests", reflect.TypeOf((*MockGroupJoinUsecase)(nil).GetGroupJoinRequests), in)
+}
+
+// JoinGroup mocks base method.
+func (m *MockGroupJoinUsecase) JoinGroup(in *dto.JoinGroupInput) (*dto.JoinGroupOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JoinGroup", in)
+	ret0, _ := ret[0].(*dto.JoinGroupOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JoinGroup indicates an expected call of JoinGroup.
+func (mr *MockGroupJoinUsecaseMockRecorder) JoinGroup(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinGroup", reflect.TypeOf((*MockGroupJoinUsecase)(nil).JoinGroup), in)
+}
+
+// RejectGroupJoinRequest mocks base method.
+func (m *MockGroupJoinUsecase) RejectGroupJoinRequest(in *dto.RejectGroupJoinRequestInput) (*dto.RejectGroupJoinRequestOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectGroupJoinRequest", in)
+	ret0, _ := ret[0].(*dto.RejectGroupJoinRequestOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectGroupJoinRequest indicates an expected call of RejectGroupJoinRequest.
+func (mr *MockGroupJoinUsecaseMockRecorder) RejectGroupJoinRequest(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectGroupJoinRequest", reflect.TypeOf((*MockGroupJoinUsecase)(nil).RejectGroupJoinRequest), in)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: idempotency.go
+
+// Package mockusecase is a generated GoMock package.
+package mockusecase
+
+import (
+	reflect "reflect"
+
+	dto "github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIdempotencyUsecase is a mock of IdempotencyUsecase interface.
+type MockIdempotencyUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyUsecaseMockRecorder
+}
+
+// MockIdempotencyUsecaseMockRecorder is the mock recorder for MockIdempotencyUsecase.
+type MockIdempotencyUsecaseMockRecorder struct {
+	mock *MockIdempotencyUsecase
+}
+
+// NewMockIdempotencyUsecase creates a new mock instance.
+func NewMockIdempotencyUsecase(ctrl *gomock.Controller) *MockIdempotencyUsecase {
+	mock := &MockIdempotencyUsecase{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyUsecase) EXPECT() *MockIdempotencyUsecaseMockRecorder {
+	return m.recorder
+}
+
+// BeginIdempotentRequest mocks base method.
+func (m *MockIdempotencyUsecase) BeginIdempotentRequest(in *dto.BeginIdempotentRequestInput) (*dto.BeginIdempotentRequestOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginIdempotentRequest", in)
+	ret0, _ := ret[0].(*dto.BeginIdempotentRequestOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginIdempotentRequest indicates an expected call of BeginIdempotentRequest.
+func (mr *MockIdempotencyUsecaseMockRecorder) BeginIdempotentRequest(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginIdempotentRequest", reflect.TypeOf((*MockIdempotencyUsecase)(nil).BeginIdempotentRequest), in)
+}
+
+// CompleteIdempotentRequest mocks base method.
+func (m *MockIdempotencyUsecase) CompleteIdempotentRequest(in *dto.CompleteIdempotentRequestInput) (*dto.CompleteIdempotentRequestOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteIdempotentRequest", in)
+	ret0, _ := ret[0].(*dto.CompleteIdempotentRequestOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteIdempotentRequest indicates an expected call of CompleteIdempotentRequest.
+func (mr *MockIdempotencyUsecaseMockRecorder) CompleteIdempotentRequest(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteIdempotentRequest", reflect.TypeOf((*MockIdempotencyUsecase)(nil).CompleteIdempotentRequest), in)
+}
+
+// PurgeIdempotencyKeys mocks base method.
+func (m *MockIdempotencyUsecase) PurgeIdempotencyKeys(in *dto.PurgeIdempotencyKeysInput) (*dto.PurgeIdempotencyKeysOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeIdempotencyKeys", in)
+	ret0, _ := ret[0].(*dto.PurgeIdempotencyKeysOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeIdempotencyKeys indicates an expected call of PurgeIdempotencyKeys.
+func (mr *MockIdempotencyUsecaseMockRecorder) PurgeIdempotencyKeys(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeIdempotencyKeys", reflect.TypeOf((*MockIdempotencyUsecase)(nil).PurgeIdempotencyKeys), in)
+}
+
+// ReleaseIdempotentRequest mocks base method.
+func (m *MockIdempotencyUsecase) ReleaseIdempotentRequest(in *dto.ReleaseIdempotentRequestInput) (*dto.ReleaseIdempotentRequestOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseIdempotentRequest", in)
+	ret0, _ := ret[0].(*dto.ReleaseIdempotentRequestOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseIdempotentRequest indicates an expected call of ReleaseIdempotentRequest.
+func (mr *MockIdempotencyUsecaseMockRecorder) ReleaseIdempotentRequest(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseIdempotentRequest", reflect.TypeOf((*MockIdempotencyUsecase)(nil).ReleaseIdempotentRequest), in)
+}
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: outbox.go
+
+// Package mockusecase is a generated GoMock package.
+package mockusecase
+
+import (
+	reflect "reflect"
+
+	model "github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	dto "github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPublisher is a mock of Publisher interface.
+type MockPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherMockRecorder
+}
+
+// MockPublisherMockRecorder is the mock recorder for MockPublisher.
+type MockPublisherMockRecorder struct {
+	mock *MockPublisher
+}
+
+// NewMockPublisher creates a new mock instance.
+func NewMockPublisher(ctrl *gomock.Controller) *MockPublisher {
+	mock := &MockPublisher{ctrl: ctrl}
+	mock.recorder = &MockPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisher) EXPECT() *MockPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m_2 *MockPublisher) Publish(m *model.OutboxMessage) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Publish", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockPublisherMockRecorder) Publish(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockPublisher)(nil).Publish), m)
+}
+
+// MockOutboxUsecase is a mock of OutboxUsecase interface.
+type MockOutboxUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxUsecaseMockRecorder
+}
+
+// MockOutboxUsecaseMockRecorder is the mock recorder for MockOutboxUsecase.
+type MockOutboxUsecaseMockRecorder struct {
+	mock *MockOutboxUsecase
+}
+
+// NewMockOutboxUsecase creates a new mock instance.
+func NewMockOutboxUsecase(ctrl *gomock.Controller) *MockOutboxUsecase {
+	mock := &MockOutboxUsecase{ctrl: ctrl}
+	mock.recorder = &MockOutboxUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxUsecase) EXPECT() *MockOutboxUsecaseMockRecorder {
+	return m.recorder
+}
+
+// PurgeOutbox mocks base method.
+func (m *MockOutboxUsecase) PurgeOutbox(in *dto.PurgeOutboxInput) (*dto.PurgeOutboxOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeOutbox", in)
+	ret0, _ := ret[0].(*dto.PurgeOutboxOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeOutbox indicates an expected call of PurgeOutbox.
+func (mr *MockOutboxUsecaseMockRecorder) PurgeOutbox(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeOutbox", reflect.TypeOf((*MockOutboxUsecase)(nil).PurgeOutbox), in)
+}
+
+// RelayOutbox mocks base method.
+func (m *MockOutboxUsecase) RelayOutbox(in *dto.RelayOutboxInput) (*dto.RelayOutboxOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayOutbox", in)
+	ret0, _ := ret[0].(*dto.RelayOutboxOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelayOutbox indicates an expected call of RelayOutbox.
+func (mr *MockOutboxUsecaseMockRecorder) RelayOutbox(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayOutbox", reflect.TypeOf((*MockOutboxUsecase)(nil).RelayOutbox), in)
+}
+
+// MockeventRecorder is a mock of eventRecorder interface.
+type MockeventRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockeventRecorderMockRecorder
+}
+
+// MockeventRecorderMockRecorder is the mock recorder for MockeventRecorder.
+type MockeventRecorderMockRecorder struct {
+	mock *MockeventRecorder
+}
+
+// NewMockeventRecorder creates a new mock instance.
+func NewMockeventRecorder(ctrl *gomock.Controller) *MockeventRecorder {
+	mock := &MockeventRecorder{ctrl: ctrl}
+	mock.recorder = &MockeventRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockeventRecorder) EXPECT() *MockeventRecorderMockRecorder {
+	return m.recorder
+}
+
+// PullEvents mocks base method.
+func (m *MockeventRecorder) PullEvents() []model.DomainEvent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PullEvents")
+	ret0, _ := ret[0].([]model.DomainEvent)
+	return ret0
+}
+
+// PullEvents indicates an expected call of PullEvents.
+func (mr *MockeventRecorderMockRecorder) PullEvents() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullEvents", reflect.TypeOf((*MockeventRecorder)(nil).PullEvents))
+}
@@ -34,6 +34,21 @@ func (m *MockUserUsecase) EXPECT() *MockUserUsecaseMockRecorder {
 	return m.recorder
 }
 
+// ActivateUser mocks base method.
+func (m *MockUserUsecase) ActivateUser(in *dto.ActivateUserInput) (*dto.ActivateUserOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateUser", in)
+	ret0, _ := ret[0].(*dto.ActivateUserOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateUser indicates an expected call of ActivateUser.
+func (mr *MockUserUsecaseMockRecorder) ActivateUser(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateUser", reflect.TypeOf((*MockUserUsecase)(nil).ActivateUser), in)
+}
+
 // CreateUser mocks base method.
 func (m *MockUserUsecase) CreateUser(in *dto.CreateUserInput) (*dto.CreateUserOutput, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +64,21 @@ func (mr *MockUserUsecaseMockRecorder) CreateUser(in interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserUsecase)(nil).CreateUser), in)
 }
 
+// DeactivateUser mocks base method.
+func (m *MockUserUsecase) DeactivateUser(in *dto.DeactivateUserInput) (*dto.DeactivateUserOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateUser", in)
+	ret0, _ := ret[0].(*dto.DeactivateUserOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateUser indicates an expected call of DeactivateUser.
+func (mr *MockUserUsecaseMockRecorder) DeactivateUser(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateUser", reflect.TypeOf((*MockUserUsecase)(nil).DeactivateUser), in)
+}
+
 // DeleteUser mocks base method.
 func (m *MockUserUsecase) DeleteUser(in *dto.DeleteUserInput) (*dto.DeleteUserOutput, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +94,21 @@ func (mr *MockUserUsecaseMockRecorder) DeleteUser(in interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserUsecase)(nil).DeleteUser), in)
 }
 
+// ExportUsers mocks base method.
+func (m *MockUserUsecase) ExportUsers(in *dto.ExportUsersInput) (*dto.ExportUsersOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUsers", in)
+	ret0, _ := ret[0].(*dto.ExportUsersOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUsers indicates an expected call of ExportUsers.
+func (mr *MockUserUsecaseMockRecorder) ExportUsers(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUsers", reflect.TypeOf((*MockUserUsecase)(nil).ExportUsers), in)
+}
+
 // GetUser mocks base method.
 func (m *MockUserUsecase) GetUser(in *dto.GetUserInput) (*dto.GetUserOutput, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +139,66 @@ func (mr *MockUserUsecaseMockRecorder) GetUsers(in interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockUserUsecase)(nil).GetUsers), in)
 }
 
+// ImportUsers mocks base method.
+func (m *MockUserUsecase) ImportUsers(in *dto.ImportUsersInput) (*dto.ImportUsersOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportUsers", in)
+	ret0, _ := ret[0].(*dto.ImportUsersOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportUsers indicates an expected call of ImportUsers.
+func (mr *MockUserUsecaseMockRecorder) ImportUsers(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportUsers", reflect.TypeOf((*MockUserUsecase)(nil).ImportUsers), in)
+}
+
+// PatchUser mocks base method.
+func (m *MockUserUsecase) PatchUser(in *dto.PatchUserInput) (*dto.PatchUserOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchUser", in)
+	ret0, _ := ret[0].(*dto.PatchUserOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchUser indicates an expected call of PatchUser.
+func (mr *MockUserUsecaseMockRecorder) PatchUser(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchUser", reflect.TypeOf((*MockUserUsecase)(nil).PatchUser), in)
+}
+
+// ReactivateUser mocks base method.
+func (m *MockUserUsecase) ReactivateUser(in *dto.ReactivateUserInput) (*dto.ReactivateUserOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactivateUser", in)
+	ret0, _ := ret[0].(*dto.ReactivateUserOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReactivateUser indicates an expected call of ReactivateUser.
+func (mr *MockUserUsecaseMockRecorder) ReactivateUser(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateUser", reflect.TypeOf((*MockUserUsecase)(nil).ReactivateUser), in)
+}
+
+// SuspendUser mocks base method.
+func (m *MockUserUsecase) SuspendUser(in *dto.SuspendUserInput) (*dto.SuspendUserOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuspendUser", in)
+	ret0, _ := ret[0].(*dto.SuspendUserOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuspendUser indicates an expected call of SuspendUser.
+func (mr *MockUserUsecaseMockRecorder) SuspendUser(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendUser", reflect.TypeOf((*MockUserUsecase)(nil).SuspendUser), in)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserUsecase) UpdateUser(in *dto.UpdateUserInput) (*dto.UpdateUserOutput, error) {
 	m.ctrl.T.Helper()
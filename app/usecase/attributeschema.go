@@ -0,0 +1,136 @@
+//go:generate mockgen -source=$GOFILE -package=mock$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+type AttributeSchemaUsecase interface {
+	GetAttributeSchemas(in *dto.GetAttributeSchemasInput) (*dto.GetAttributeSchemasOutput, error)
+	PutAttributeSchema(in *dto.PutAttributeSchemaInput) (*dto.PutAttributeSchemaOutput, error)
+	DeleteAttributeSchema(in *dto.DeleteAttributeSchemaInput) (*dto.DeleteAttributeSchemaOutput, error)
+}
+
+type attributeSchemaUsecase struct {
+	r repository.Repository
+}
+
+func NewAttributeSchemaUsecase(r repository.Repository) AttributeSchemaUsecase {
+	return &attributeSchemaUsecase{r: r}
+}
+
+func (uc *attributeSchemaUsecase) GetAttributeSchemas(
+	in *dto.GetAttributeSchemasInput,
+) (*dto.GetAttributeSchemasOutput, error) {
+	target := model.AttributeTarget(in.Target)
+	if target != "" && !target.IsValid() {
+		return nil, fmt.Errorf("unknown attribute target %s: %w", target, ErrInvalidAttributeSchemaInput)
+	}
+
+	ss, err := uc.r.AttributeSchema().List(repository.AttributeSchemaListFilter{Target: target})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.GetAttributeSchemasOutput{
+		AttributeSchemas: dto.ToAttributeSchemasFromModel(ss),
+	}, nil
+}
+
+// PutAttributeSchema creates the schema of the attribute, or replaces the one of the same target and key.
+// The attributes the users or the groups already have are validated against it when they are updated next.
+func (uc *attributeSchemaUsecase) PutAttributeSchema(
+	in *dto.PutAttributeSchemaInput,
+) (*dto.PutAttributeSchemaOutput, error) {
+	s, err := model.NewAttributeSchema(
+		model.AttributeTarget(in.Target),
+		in.Key,
+		model.AttributeType(in.Type),
+		in.Required,
+		in.Enum,
+		in.Pattern,
+	)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidAttributeSchemaInput, err)
+	}
+
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		return tx.AttributeSchema().Save(s)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.PutAttributeSchemaOutput{
+		AttributeSchema: dto.ToAttributeSchemaFromModel(s),
+	}, nil
+}
+
+// DeleteAttributeSchema deletes the schema of the attribute. The values of it the users or the groups have are kept,
+// which are rejected as unknown when they are updated next.
+func (uc *attributeSchemaUsecase) DeleteAttributeSchema(
+	in *dto.DeleteAttributeSchemaInput,
+) (*dto.DeleteAttributeSchemaOutput, error) {
+	target := model.AttributeTarget(in.Target)
+
+	s, err := uc.r.AttributeSchema().Find(target, in.Key)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, ErrAttributeSchemaNotFound
+	}
+
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		return tx.AttributeSchema().Delete(target, in.Key)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.DeleteAttributeSchemaOutput{}, nil
+}
+
+// validateAttributes validates the attributes against the schemas of the target, returning the invalid input error
+// joined if they are invalid.
+func validateAttributes(
+	r repository.Repository,
+	target model.AttributeTarget,
+	attrs model.Attributes,
+	invalidInput error,
+) error {
+	ss, err := r.AttributeSchema().List(repository.AttributeSchemaListFilter{Target: target})
+	if err != nil {
+		return err
+	}
+	if err := ss.Validate(attrs); err != nil {
+		return errors.Join(invalidInput, err)
+	}
+	return nil
+}
+
+// validateAttributeFilter returns the invalid input error if the values filter by an attribute no schema of
+// the target defines.
+func validateAttributeFilter(
+	r repository.Repository,
+	target model.AttributeTarget,
+	values map[string]string,
+	invalidInput error,
+) error {
+	if len(values) == 0 {
+		return nil
+	}
+	ss, err := r.AttributeSchema().List(repository.AttributeSchemaListFilter{Target: target})
+	if err != nil {
+		return err
+	}
+	for k := range values {
+		if ss.Find(k) == nil {
+			return fmt.Errorf("unknown attribute %s: %w", k, invalidInput)
+		}
+	}
+	return nil
+}
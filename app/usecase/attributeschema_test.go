@@ -0,0 +1,545 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/domainservice"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/memory"
+	mockfactory "github.com/toshiykst/go-layerd-architecture/app/mock/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// newAttributeMemoryRepository returns the repository with the schemas of a required department and an optional
+// level of the users and an optional cost center of the groups, and a user and a group with the attributes.
+func newAttributeMemoryRepository() repository.Repository {
+	s := memory.NewStore()
+	s.AddAttributeSchemas(
+		model.MustNewAttributeSchema(model.AttributeTargetUser, "department", model.AttributeTypeString, true, []string{"sales", "engineering"}, ""),
+		model.MustNewAttributeSchema(model.AttributeTargetUser, "level", model.AttributeTypeNumber, false, nil, ""),
+		model.MustNewAttributeSchema(model.AttributeTargetGroup, "costCenter", model.AttributeTypeString, false, nil, "^CC-[0-9]+$"),
+	)
+	u := model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL")
+	u.ChangeAttributes(model.Attributes{"department": "sales"})
+	s.AddUsers(u, model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"))
+	g := model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", []model.UserID{"TEST_USER_ID"})
+	g.ChangeAttributes(model.Attributes{"costCenter": "CC-1"})
+	s.AddGroups(g)
+	return memory.NewMemoryRepository(s)
+}
+
+func newAttributeUserUsecase(f factory.UserFactory, r repository.Repository) usecase.UserUsecase {
+	return usecase.NewUserUsecase(
+		r,
+		f,
+		domainservice.NewUserService(r),
+		domainservice.NewGroupService(r),
+		factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+		factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+		factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+		model.DefaultPolicy(),
+		testClock,
+	)
+}
+
+func newAttributeGroupUsecase(f factory.GroupFactory, r repository.Repository) usecase.GroupUsecase {
+	return usecase.NewGroupUsecase(
+		r,
+		f,
+		domainservice.NewGroupService(r),
+		domainservice.NewUserService(r),
+		factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+		factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+		factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+		model.DefaultPolicy(),
+		testClock,
+	)
+}
+
+func TestAttributeSchemaUsecase_PutAttributeSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       *dto.PutAttributeSchemaInput
+		wantKeys []string
+		wantErr  error
+	}{
+		{
+			name: "Creates the schema",
+			in: &dto.PutAttributeSchemaInput{
+				Target:  "USER",
+				Key:     "slack",
+				Type:    "STRING",
+				Pattern: "^@[a-z]+$",
+			},
+			wantKeys: []string{"department", "level", "slack"},
+		},
+		{
+			name: "Replaces the schema of the same target and key",
+			in: &dto.PutAttributeSchemaInput{
+				Target: "USER",
+				Key:    "department",
+				Type:   "STRING",
+			},
+			wantKeys: []string{"department", "level"},
+		},
+		{
+			name: "Returns error if the schema is invalid",
+			in: &dto.PutAttributeSchemaInput{
+				Target: "USER",
+				Key:    "remote",
+				Type:   "BOOLEAN",
+				Enum:   []string{"true"},
+			},
+			wantErr: usecase.ErrInvalidAttributeSchemaInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newAttributeMemoryRepository()
+			uc := usecase.NewAttributeSchemaUsecase(r)
+
+			got, err := uc.PutAttributeSchema(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("uc.PutAttributeSchema(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			want := dto.AttributeSchema{
+				Target:   tt.in.Target,
+				Key:      tt.in.Key,
+				Type:     tt.in.Type,
+				Required: tt.in.Required,
+				Enum:     tt.in.Enum,
+				Pattern:  tt.in.Pattern,
+			}
+			if diff := cmp.Diff(got.AttributeSchema, want); diff != "" {
+				t.Errorf("uc.PutAttributeSchema(%v) differs: (-got +want)\n%s", tt.in, diff)
+			}
+
+			out, err := uc.GetAttributeSchemas(&dto.GetAttributeSchemasInput{Target: "USER"})
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			var keys []string
+			for _, s := range out.AttributeSchemas {
+				keys = append(keys, s.Key)
+			}
+			if diff := cmp.Diff(keys, tt.wantKeys); diff != "" {
+				t.Errorf("the keys of the schemas differ: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAttributeSchemaUsecase_DeleteAttributeSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      *dto.DeleteAttributeSchemaInput
+		wantErr error
+	}{
+		{
+			name: "Deletes the schema",
+			in:   &dto.DeleteAttributeSchemaInput{Target: "GROUP", Key: "costCenter"},
+		},
+		{
+			name:    "Returns error if the schema does not exist",
+			in:      &dto.DeleteAttributeSchemaInput{Target: "USER", Key: "costCenter"},
+			wantErr: usecase.ErrAttributeSchemaNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newAttributeMemoryRepository()
+			uc := usecase.NewAttributeSchemaUsecase(r)
+
+			if _, err := uc.DeleteAttributeSchema(tt.in); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("uc.DeleteAttributeSchema(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if s, _ := r.AttributeSchema().Find(model.AttributeTarget(tt.in.Target), tt.in.Key); s != nil {
+				t.Errorf("r.AttributeSchema().Find(%s, %s)=%v, _; want nil, nil", tt.in.Target, tt.in.Key, s)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_CreateUser_attributes(t *testing.T) {
+	tests := []struct {
+		name    string
+		attrs   map[string]any
+		wantErr error
+	}{
+		{
+			name:  "Creates the user with the attributes",
+			attrs: map[string]any{"department": "engineering", "level": 3.0},
+		},
+		{
+			name:    "Returns error if the required attribute is missing",
+			attrs:   map[string]any{"level": 3.0},
+			wantErr: usecase.ErrInvalidUserInput,
+		},
+		{
+			name:    "Returns error if the attribute is unknown",
+			attrs:   map[string]any{"department": "sales", "title": "manager"},
+			wantErr: usecase.ErrInvalidUserInput,
+		},
+		{
+			name:    "Returns error if the attribute is not in the enum",
+			attrs:   map[string]any{"department": "legal"},
+			wantErr: usecase.ErrInvalidUserInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := mockfactory.NewMockUserFactory(ctrl)
+			f.EXPECT().Create("TEST_USER_NAME_3", "TEST_USER_EMAIL_3").
+				Return(model.MustNewUser("TEST_USER_ID_3", "TEST_USER_NAME_3", "TEST_USER_EMAIL_3"), nil)
+			r := newAttributeMemoryRepository()
+			uc := newAttributeUserUsecase(f, r)
+
+			in := &dto.CreateUserInput{Name: "TEST_USER_NAME_3", Email: "TEST_USER_EMAIL_3", Attributes: tt.attrs}
+			got, err := uc.CreateUser(in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("uc.CreateUser(%v)=_, %v; want _, %v", in, err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if u, _ := r.User().Find("TEST_USER_ID_3"); u != nil {
+					t.Errorf("r.User().Find(TEST_USER_ID_3)=%v, _; want nil, nil", u)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(got.User.Attributes, tt.attrs); diff != "" {
+				t.Errorf("got.User.Attributes differs: (-got +want)\n%s", diff)
+			}
+			u, _ := r.User().Find("TEST_USER_ID_3")
+			if diff := cmp.Diff(map[string]any(u.Attributes()), tt.attrs); diff != "" {
+				t.Errorf("u.Attributes() differs: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_UpdateUser_attributes(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        *dto.UpdateUserInput
+		wantAttrs model.Attributes
+		wantErr   error
+	}{
+		{
+			name: "Keeps the attributes if they are not given",
+			in: &dto.UpdateUserInput{
+				UserID: "TEST_USER_ID",
+				Name:   "TEST_USER_NAME_UPDATED",
+				Email:  "TEST_USER_EMAIL",
+			},
+			wantAttrs: model.Attributes{"department": "sales"},
+		},
+		{
+			name: "Replaces the attributes",
+			in: &dto.UpdateUserInput{
+				UserID:     "TEST_USER_ID",
+				Name:       "TEST_USER_NAME",
+				Email:      "TEST_USER_EMAIL",
+				Attributes: map[string]any{"department": "engineering", "level": 1.0},
+			},
+			wantAttrs: model.Attributes{"department": "engineering", "level": 1.0},
+		},
+		{
+			name: "Returns error if the attribute is of another type",
+			in: &dto.UpdateUserInput{
+				UserID:     "TEST_USER_ID",
+				Name:       "TEST_USER_NAME",
+				Email:      "TEST_USER_EMAIL",
+				Attributes: map[string]any{"department": "sales", "level": "high"},
+			},
+			wantAttrs: model.Attributes{"department": "sales"},
+			wantErr:   usecase.ErrInvalidUserInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			r := newAttributeMemoryRepository()
+			uc := newAttributeUserUsecase(mockfactory.NewMockUserFactory(ctrl), r)
+
+			if _, err := uc.UpdateUser(tt.in); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("uc.UpdateUser(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+			}
+			u, _ := r.User().Find("TEST_USER_ID")
+			if diff := cmp.Diff(u.Attributes(), tt.wantAttrs); diff != "" {
+				t.Errorf("u.Attributes() differs: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_PatchUser_attributes(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        *dto.PatchUserInput
+		wantAttrs model.Attributes
+		wantErr   error
+	}{
+		{
+			name: "Adds the attribute with a JSON Patch",
+			in: &dto.PatchUserInput{
+				UserID:    "TEST_USER_ID",
+				PatchType: dto.PatchTypeJSONPatch,
+				Patch:     []byte(`[{"op":"add","path":"/attributes/level","value":2}]`),
+			},
+			wantAttrs: model.Attributes{"department": "sales", "level": 2.0},
+		},
+		{
+			name: "Adds the attribute to the user with no attributes with a JSON Patch",
+			in: &dto.PatchUserInput{
+				UserID:    "TEST_USER_ID_2",
+				PatchType: dto.PatchTypeJSONPatch,
+				Patch:     []byte(`[{"op":"add","path":"/attributes/department","value":"engineering"}]`),
+			},
+			wantAttrs: model.Attributes{"department": "engineering"},
+		},
+		{
+			name: "Returns error if the required attribute is removed with a JSON Merge Patch",
+			in: &dto.PatchUserInput{
+				UserID:    "TEST_USER_ID",
+				PatchType: dto.PatchTypeMergePatch,
+				Patch:     []byte(`{"attributes":{"department":null}}`),
+			},
+			wantAttrs: model.Attributes{"department": "sales"},
+			wantErr:   usecase.ErrInvalidUserInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			r := newAttributeMemoryRepository()
+			uc := newAttributeUserUsecase(mockfactory.NewMockUserFactory(ctrl), r)
+
+			if _, err := uc.PatchUser(tt.in); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("uc.PatchUser(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+			}
+			u, _ := r.User().Find(model.UserID(tt.in.UserID))
+			if diff := cmp.Diff(u.Attributes(), tt.wantAttrs); diff != "" {
+				t.Errorf("u.Attributes() differs: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_GetUsers_attributes(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      *dto.GetUsersInput
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "Returns the users with the attribute value",
+			in:   &dto.GetUsersInput{Attributes: map[string]string{"department": "sales"}},
+			want: []string{"TEST_USER_ID"},
+		},
+		{
+			name:    "Returns error if the attribute is unknown",
+			in:      &dto.GetUsersInput{Attributes: map[string]string{"costCenter": "CC-1"}},
+			wantErr: usecase.ErrInvalidUserInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := newAttributeUserUsecase(mockfactory.NewMockUserFactory(ctrl), newAttributeMemoryRepository())
+
+			got, err := uc.GetUsers(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("uc.GetUsers(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			var uIDs []string
+			for _, u := range got.Users {
+				uIDs = append(uIDs, u.UserID)
+			}
+			if diff := cmp.Diff(uIDs, tt.want); diff != "" {
+				t.Errorf("the users differ: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGroupUsecase_UpdateGroup_attributes(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        *dto.UpdateGroupInput
+		wantAttrs model.Attributes
+		wantErr   error
+	}{
+		{
+			name:      "Keeps the attributes if they are not given",
+			in:        &dto.UpdateGroupInput{GroupID: "TEST_GROUP_ID", Name: "TEST_GROUP_NAME_UPDATED"},
+			wantAttrs: model.Attributes{"costCenter": "CC-1"},
+		},
+		{
+			name:      "Clears the attributes if they are empty",
+			in:        &dto.UpdateGroupInput{GroupID: "TEST_GROUP_ID", Name: "TEST_GROUP_NAME", Attributes: map[string]any{}},
+			wantAttrs: nil,
+		},
+		{
+			name: "Returns error if the attribute does not match the pattern",
+			in: &dto.UpdateGroupInput{
+				GroupID:    "TEST_GROUP_ID",
+				Name:       "TEST_GROUP_NAME",
+				Attributes: map[string]any{"costCenter": "1"},
+			},
+			wantAttrs: model.Attributes{"costCenter": "CC-1"},
+			wantErr:   usecase.ErrInvalidGroupInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			r := newAttributeMemoryRepository()
+			uc := newAttributeGroupUsecase(mockfactory.NewMockGroupFactory(ctrl), r)
+
+			if _, err := uc.UpdateGroup(tt.in); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("uc.UpdateGroup(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+			}
+			g, _ := r.Group().Find("TEST_GROUP_ID")
+			if diff := cmp.Diff(g.Attributes(), tt.wantAttrs); diff != "" {
+				t.Errorf("g.Attributes() differs: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGroupUsecase_PatchGroup_attributes(t *testing.T) {
+	tests := []struct {
+		name           string
+		in             *dto.PatchGroupInput
+		wantAttrs      model.Attributes
+		wantEventTypes []model.DomainEventType
+		wantErr        error
+	}{
+		{
+			name: "Replaces only the attributes of the group with a JSON Patch",
+			in: &dto.PatchGroupInput{
+				Meta:      dto.Meta{Actor: "TEST_ACTOR", RequestID: "TEST_REQUEST_ID"},
+				GroupID:   "TEST_GROUP_ID",
+				PatchType: dto.PatchTypeJSONPatch,
+				Patch:     []byte(`[{"op":"replace","path":"/attributes/costCenter","value":"CC-2"}]`),
+			},
+			wantAttrs:      model.Attributes{"costCenter": "CC-2"},
+			wantEventTypes: []model.DomainEventType{model.DomainEventTypeGroupUpdated},
+		},
+		{
+			name: "Removes only the attributes of the group with a JSON Merge Patch",
+			in: &dto.PatchGroupInput{
+				Meta:      dto.Meta{Actor: "TEST_ACTOR", RequestID: "TEST_REQUEST_ID"},
+				GroupID:   "TEST_GROUP_ID",
+				PatchType: dto.PatchTypeMergePatch,
+				Patch:     []byte(`{"attributes":{"costCenter":null}}`),
+			},
+			wantAttrs:      nil,
+			wantEventTypes: []model.DomainEventType{model.DomainEventTypeGroupUpdated},
+		},
+		{
+			name: "Does nothing if the attributes are the same",
+			in: &dto.PatchGroupInput{
+				GroupID:   "TEST_GROUP_ID",
+				PatchType: dto.PatchTypeJSONPatch,
+				Patch:     []byte(`[{"op":"replace","path":"/attributes/costCenter","value":"CC-1"}]`),
+			},
+			wantAttrs: model.Attributes{"costCenter": "CC-1"},
+		},
+		{
+			name: "Returns error if the attribute does not match the pattern",
+			in: &dto.PatchGroupInput{
+				GroupID:   "TEST_GROUP_ID",
+				PatchType: dto.PatchTypeJSONPatch,
+				Patch:     []byte(`[{"op":"replace","path":"/attributes/costCenter","value":"1"}]`),
+			},
+			wantAttrs: model.Attributes{"costCenter": "CC-1"},
+			wantErr:   usecase.ErrInvalidGroupInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			r := newAttributeMemoryRepository()
+			uc := newAttributeGroupUsecase(mockfactory.NewMockGroupFactory(ctrl), r)
+
+			if _, err := uc.PatchGroup(tt.in); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("uc.PatchGroup(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+			}
+			g, _ := r.Group().Find("TEST_GROUP_ID")
+			if diff := cmp.Diff(g.Attributes(), tt.wantAttrs); diff != "" {
+				t.Errorf("g.Attributes() differs: (-got +want)\n%s", diff)
+			}
+			if diff := cmp.Diff(g.UserIDs(), []model.UserID{"TEST_USER_ID"}); diff != "" {
+				t.Errorf("g.UserIDs() differs: (-got +want)\n%s", diff)
+			}
+			assertOutboxMessages(t, r, tt.wantEventTypes...)
+			if tt.wantEventTypes != nil {
+				assertAuditEvent(t, r, tt.in.Meta, model.AuditActionUpdateGroup, model.NewGroupAuditTarget("TEST_GROUP_ID"))
+			}
+		})
+	}
+}
+
+func TestGroupUsecase_GetGroups_attributes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := newAttributeGroupUsecase(mockfactory.NewMockGroupFactory(ctrl), newAttributeMemoryRepository())
+
+	in := &dto.GetGroupsInput{Attributes: map[string]string{"costCenter": "CC-1"}}
+	got, err := uc.GetGroups(in)
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	if len(got.Groups) != 1 || got.Groups[0].GroupID != "TEST_GROUP_ID" {
+		t.Fatalf("uc.GetGroups(%v)=%v, nil; want the group TEST_GROUP_ID", in, got)
+	}
+	if diff := cmp.Diff(got.Groups[0].Attributes, map[string]any{"costCenter": "CC-1"}); diff != "" {
+		t.Errorf("got.Groups[0].Attributes differs: (-got +want)\n%s", diff)
+	}
+
+	in = &dto.GetGroupsInput{Attributes: map[string]string{"department": "sales"}}
+	if _, err := uc.GetGroups(in); !errors.Is(err, usecase.ErrInvalidGroupInput) {
+		t.Errorf("uc.GetGroups(%v)=_, %v; want _, %v", in, err, usecase.ErrInvalidGroupInput)
+	}
+}
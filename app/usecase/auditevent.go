@@ -0,0 +1,47 @@
+//go:generate mockgen -source=$GOFILE -package=mock$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+type AuditEventUsecase interface {
+	GetAuditEvents(in *dto.GetAuditEventsInput) (*dto.GetAuditEventsOutput, error)
+}
+
+type auditEventUsecase struct {
+	r repository.Repository
+}
+
+func NewAuditEventUsecase(r repository.Repository) AuditEventUsecase {
+	return &auditEventUsecase{r: r}
+}
+
+func (uc *auditEventUsecase) GetAuditEvents(in *dto.GetAuditEventsInput) (*dto.GetAuditEventsOutput, error) {
+	tt := model.AuditTargetType(in.TargetType)
+	if tt != "" && !tt.IsValid() {
+		return nil, fmt.Errorf("unknown target type %q: %w", in.TargetType, ErrInvalidAuditEventInput)
+	}
+	if !in.From.IsZero() && !in.To.IsZero() && !in.From.Before(in.To) {
+		return nil, fmt.Errorf("from must be before to: %w", ErrInvalidAuditEventInput)
+	}
+
+	es, err := uc.r.AuditEvent().List(repository.AuditEventListFilter{
+		Actor:      in.Actor,
+		TargetType: tt,
+		TargetID:   in.TargetID,
+		From:       in.From,
+		To:         in.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.GetAuditEventsOutput{
+		AuditEvents: dto.ToAuditEventsFromModel(es),
+	}, nil
+}
@@ -0,0 +1,123 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/memory"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestAuditEventUsecase_GetAuditEvents(t *testing.T) {
+	t1 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	t3 := time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	newMemoryRepository := func() repository.Repository {
+		s := memory.NewStore()
+		s.AddAuditEvents(
+			model.MustNewAuditEvent(
+				"TEST_AUDIT_EVENT_ID_1",
+				"TEST_ACTOR_1",
+				model.AuditActionCreateUser,
+				model.NewUserAuditTarget("TEST_USER_ID"),
+				[]model.AuditChange{{Field: "name", After: "TEST_USER_NAME"}},
+				"TEST_REQUEST_ID_1",
+				t1,
+			),
+			model.MustNewAuditEvent(
+				"TEST_AUDIT_EVENT_ID_2",
+				"TEST_ACTOR_2",
+				model.AuditActionUpdateUser,
+				model.NewUserAuditTarget("TEST_USER_ID"),
+				[]model.AuditChange{{Field: "name", Before: "TEST_USER_NAME", After: "TEST_USER_NAME_UPDATED"}},
+				"TEST_REQUEST_ID_2",
+				t2,
+			),
+			model.MustNewAuditEvent(
+				"TEST_AUDIT_EVENT_ID_3",
+				"TEST_ACTOR_1",
+				model.AuditActionDeleteGroup,
+				model.NewGroupAuditTarget("TEST_GROUP_ID"),
+				nil,
+				"TEST_REQUEST_ID_3",
+				t3,
+			),
+		)
+		return memory.NewMemoryRepository(s)
+	}
+
+	tests := []struct {
+		name    string
+		in      *dto.GetAuditEventsInput
+		wantIDs []string
+		wantErr error
+	}{
+		{
+			name:    "Returns all audit events",
+			in:      &dto.GetAuditEventsInput{},
+			wantIDs: []string{"TEST_AUDIT_EVENT_ID_1", "TEST_AUDIT_EVENT_ID_2", "TEST_AUDIT_EVENT_ID_3"},
+		},
+		{
+			name:    "Returns audit events filtered by actor",
+			in:      &dto.GetAuditEventsInput{Actor: "TEST_ACTOR_1"},
+			wantIDs: []string{"TEST_AUDIT_EVENT_ID_1", "TEST_AUDIT_EVENT_ID_3"},
+		},
+		{
+			name:    "Returns audit events filtered by target",
+			in:      &dto.GetAuditEventsInput{TargetType: "USER", TargetID: "TEST_USER_ID"},
+			wantIDs: []string{"TEST_AUDIT_EVENT_ID_1", "TEST_AUDIT_EVENT_ID_2"},
+		},
+		{
+			name:    "Returns audit events filtered by time range",
+			in:      &dto.GetAuditEventsInput{From: t2, To: t3},
+			wantIDs: []string{"TEST_AUDIT_EVENT_ID_2"},
+		},
+		{
+			name:    "Returns error if the target type is unknown",
+			in:      &dto.GetAuditEventsInput{TargetType: "UNKNOWN"},
+			wantErr: usecase.ErrInvalidAuditEventInput,
+		},
+		{
+			name:    "Returns error if from is not before to",
+			in:      &dto.GetAuditEventsInput{From: t3, To: t2},
+			wantErr: usecase.ErrInvalidAuditEventInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := usecase.NewAuditEventUsecase(newMemoryRepository())
+
+			got, err := uc.GetAuditEvents(tt.in)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("want an error, but has no error")
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("uc.GetAuditEvents(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("want no error, but has error %v", err)
+				}
+				gotIDs := make([]string, len(got.AuditEvents))
+				for i, e := range got.AuditEvents {
+					gotIDs[i] = e.AuditEventID
+				}
+				if diff := cmp.Diff(gotIDs, tt.wantIDs); diff != "" {
+					t.Errorf(
+						"uc.GetAuditEvents(%v) ids=%v; want %v\ndiffers: (-got +want)\n%s",
+						tt.in, gotIDs, tt.wantIDs, diff,
+					)
+				}
+			}
+		})
+	}
+}
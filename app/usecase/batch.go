@@ -0,0 +1,295 @@
+//go:generate mockgen -source=$GOFILE -package=mock$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// MaxBatchOperations is the limit of the number of the operations of a batch.
+const MaxBatchOperations = 100
+
+// batchRefPrefix prefixes a reference to a field of the result of an earlier operation, e.g. $ref:op1.userId.
+const batchRefPrefix = "$ref:"
+
+// BatchOperationError is the error of the operation which failed the batch.
+type BatchOperationError struct {
+	Index int
+	ID    string
+	Err   error
+}
+
+func (e *BatchOperationError) Error() string {
+	return fmt.Sprintf("operation %d (%s): %s", e.Index, e.ID, e.Err)
+}
+
+func (e *BatchOperationError) Unwrap() error {
+	return e.Err
+}
+
+// BatchUsecases are the usecases which run the operations of a batch.
+type BatchUsecases struct {
+	User  UserUsecase
+	Group GroupUsecase
+}
+
+type BatchUsecase interface {
+	RunBatch(in *dto.RunBatchInput) (*dto.RunBatchOutput, error)
+}
+
+type batchUsecase struct {
+	r repository.Repository
+	// newUsecases builds the usecases on the repository of the transaction of a batch.
+	newUsecases func(r repository.Repository) BatchUsecases
+}
+
+func NewBatchUsecase(r repository.Repository, newUsecases func(r repository.Repository) BatchUsecases) BatchUsecase {
+	return &batchUsecase{r: r, newUsecases: newUsecases}
+}
+
+// RunBatch runs the operations in order in a transaction, which is rolled back entirely if any of them fails.
+func (uc *batchUsecase) RunBatch(in *dto.RunBatchInput) (*dto.RunBatchOutput, error) {
+	if len(in.Operations) == 0 {
+		return nil, fmt.Errorf("operations must not empty: %w", ErrInvalidBatchInput)
+	}
+	if len(in.Operations) > MaxBatchOperations {
+		return nil, fmt.Errorf("exceeds the max operations %d: %w", MaxBatchOperations, ErrInvalidBatchInput)
+	}
+	ids := map[string]bool{}
+	for i, op := range in.Operations {
+		if op.ID == "" {
+			return nil, &BatchOperationError{Index: i, Err: fmt.Errorf("id must not empty: %w", ErrInvalidBatchInput)}
+		}
+		if ids[op.ID] {
+			return nil, &BatchOperationError{Index: i, ID: op.ID, Err: fmt.Errorf("duplicate id: %w", ErrInvalidBatchInput)}
+		}
+		ids[op.ID] = true
+	}
+
+	results := make([]dto.BatchResult, len(in.Operations))
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		ucs := uc.newUsecases(repository.InTransaction(tx))
+		byID := map[string]map[string]any{}
+		for i, op := range in.Operations {
+			result, err := runBatchOperation(ucs, in.Meta, op, byID)
+			if err != nil {
+				return &BatchOperationError{Index: i, ID: op.ID, Err: err}
+			}
+			byID[op.ID] = result
+			results[i] = dto.BatchResult{ID: op.ID, Type: op.Type, Result: result}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.RunBatchOutput{Results: results}, nil
+}
+
+type (
+	batchUserArgs struct {
+		UserID string `json:"userId"`
+		Name   string `json:"name"`
+		Email  string `json:"email"`
+	}
+
+	batchGroupArgs struct {
+		GroupID string   `json:"groupId"`
+		Name    string   `json:"name"`
+		UserIDs []string `json:"userIds"`
+	}
+)
+
+// runBatchOperation runs the operation with the references in its arguments resolved by the results so far.
+func runBatchOperation(
+	ucs BatchUsecases,
+	meta dto.Meta,
+	op dto.BatchOperation,
+	results map[string]map[string]any,
+) (map[string]any, error) {
+	switch op.Type {
+	case dto.BatchOperationCreateUser:
+		var args batchUserArgs
+		if err := decodeBatchArgs(op.Args, results, &args); err != nil {
+			return nil, err
+		}
+		out, err := ucs.User.CreateUser(&dto.CreateUserInput{Meta: meta, Name: args.Name, Email: args.Email})
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"userId": out.User.UserID, "name": out.User.Name, "email": out.User.Email}, nil
+
+	case dto.BatchOperationUpdateUser:
+		var args batchUserArgs
+		if err := decodeBatchArgs(op.Args, results, &args); err != nil {
+			return nil, err
+		}
+		if _, err := ucs.User.UpdateUser(&dto.UpdateUserInput{
+			Meta:   meta,
+			UserID: args.UserID,
+			Name:   args.Name,
+			Email:  args.Email,
+		}); err != nil {
+			return nil, err
+		}
+		return map[string]any{"userId": args.UserID}, nil
+
+	case dto.BatchOperationDeleteUser:
+		var args batchUserArgs
+		if err := decodeBatchArgs(op.Args, results, &args); err != nil {
+			return nil, err
+		}
+		if _, err := ucs.User.DeleteUser(&dto.DeleteUserInput{Meta: meta, UserID: args.UserID}); err != nil {
+			return nil, err
+		}
+		return map[string]any{"userId": args.UserID}, nil
+
+	case dto.BatchOperationCreateGroup:
+		var args batchGroupArgs
+		if err := decodeBatchArgs(op.Args, results, &args); err != nil {
+			return nil, err
+		}
+		out, err := ucs.Group.CreateGroup(&dto.CreateGroupInput{Meta: meta, Name: args.Name, UserIDs: args.UserIDs})
+		if err != nil {
+			return nil, err
+		}
+		uIDs := make([]any, len(out.Group.Users))
+		for i, u := range out.Group.Users {
+			uIDs[i] = u.UserID
+		}
+		return map[string]any{"groupId": out.Group.GroupID, "name": out.Group.Name, "userIds": uIDs}, nil
+
+	case dto.BatchOperationUpdateGroup:
+		var args batchGroupArgs
+		if err := decodeBatchArgs(op.Args, results, &args); err != nil {
+			return nil, err
+		}
+		if _, err := ucs.Group.UpdateGroup(&dto.UpdateGroupInput{
+			Meta:    meta,
+			GroupID: args.GroupID,
+			Name:    args.Name,
+		}); err != nil {
+			return nil, err
+		}
+		return map[string]any{"groupId": args.GroupID}, nil
+
+	case dto.BatchOperationDeleteGroup:
+		var args batchGroupArgs
+		if err := decodeBatchArgs(op.Args, results, &args); err != nil {
+			return nil, err
+		}
+		if _, err := ucs.Group.DeleteGroup(&dto.DeleteGroupInput{Meta: meta, GroupID: args.GroupID}); err != nil {
+			return nil, err
+		}
+		return map[string]any{"groupId": args.GroupID}, nil
+
+	case dto.BatchOperationAddGroupUsers:
+		var args batchGroupArgs
+		if err := decodeBatchArgs(op.Args, results, &args); err != nil {
+			return nil, err
+		}
+		out, err := ucs.Group.AddGroupUsers(&dto.AddGroupUsersInput{
+			Meta:    meta,
+			GroupID: args.GroupID,
+			UserIDs: args.UserIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"groupId": args.GroupID, "waitlistedUserIds": out.WaitlistedUserIDs}, nil
+
+	case dto.BatchOperationRemoveGroupUsers:
+		var args batchGroupArgs
+		if err := decodeBatchArgs(op.Args, results, &args); err != nil {
+			return nil, err
+		}
+		if _, err := ucs.Group.RemoveGroupUsers(&dto.RemoveGroupUsersInput{
+			Meta:    meta,
+			GroupID: args.GroupID,
+			UserIDs: args.UserIDs,
+		}); err != nil {
+			return nil, err
+		}
+		return map[string]any{"groupId": args.GroupID}, nil
+	}
+	return nil, fmt.Errorf("unknown operation %q: %w", op.Type, ErrInvalidBatchInput)
+}
+
+// decodeBatchArgs resolves the references in the arguments and decodes them strictly into v.
+func decodeBatchArgs(args []byte, results map[string]map[string]any, v any) error {
+	var doc any
+	if len(bytes.TrimSpace(args)) > 0 {
+		if err := json.Unmarshal(args, &doc); err != nil {
+			return errors.Join(ErrInvalidBatchInput, err)
+		}
+	}
+	resolved, err := resolveBatchRefs(doc, results)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(resolved)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.DisallowUnknownFields()
+	if err := d.Decode(v); err != nil {
+		return errors.Join(ErrInvalidBatchInput, err)
+	}
+	return nil
+}
+
+// resolveBatchRefs replaces the references in the value with the fields of the results they refer to.
+func resolveBatchRefs(v any, results map[string]map[string]any) (any, error) {
+	switch v := v.(type) {
+	case string:
+		ref, ok := strings.CutPrefix(v, batchRefPrefix)
+		if !ok {
+			return v, nil
+		}
+		id, field, ok := strings.Cut(ref, ".")
+		if !ok {
+			return nil, fmt.Errorf("reference %q must be $ref:<id>.<field>: %w", v, ErrInvalidBatchInput)
+		}
+		result, ok := results[id]
+		if !ok {
+			return nil, fmt.Errorf("reference %q to no earlier operation: %w", v, ErrInvalidBatchInput)
+		}
+		value, ok := result[field]
+		if !ok {
+			return nil, fmt.Errorf("reference %q to no field of the result: %w", v, ErrInvalidBatchInput)
+		}
+		return value, nil
+	case []any:
+		resolved := make([]any, len(v))
+		for i, e := range v {
+			r, err := resolveBatchRefs(e, results)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = r
+		}
+		return resolved, nil
+	case map[string]any:
+		resolved := make(map[string]any, len(v))
+		for k, e := range v {
+			r, err := resolveBatchRefs(e, results)
+			if err != nil {
+				return nil, err
+			}
+			resolved[k] = r
+		}
+		return resolved, nil
+	}
+	return v, nil
+}
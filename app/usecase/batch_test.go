@@ -0,0 +1,303 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/domainservice"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/memory"
+	mockfactory "github.com/toshiykst/go-layerd-architecture/app/mock/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestBatchUsecase_RunBatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		in             *dto.RunBatchInput
+		want           *dto.RunBatchOutput
+		wantUsers      model.Users
+		wantGroups     model.Groups
+		wantEventTypes []model.DomainEventType
+		wantErr        error
+		wantErrIndex   int
+	}{
+		{
+			name: "Runs the operations referring to the results of the earlier ones",
+			in: &dto.RunBatchInput{
+				Meta: dto.Meta{Actor: "TEST_ACTOR"},
+				Operations: []dto.BatchOperation{
+					{
+						ID:   "u1",
+						Type: dto.BatchOperationCreateUser,
+						Args: []byte(`{"name":"TEST_USER_NAME_1","email":"TEST_USER_EMAIL_1"}`),
+					},
+					{
+						ID:   "u2",
+						Type: dto.BatchOperationCreateUser,
+						Args: []byte(`{"name":"TEST_USER_NAME_2","email":"TEST_USER_EMAIL_2"}`),
+					},
+					{
+						ID:   "g1",
+						Type: dto.BatchOperationCreateGroup,
+						Args: []byte(`{"name":"TEST_GROUP_NAME","userIds":["$ref:u1.userId"]}`),
+					},
+					{
+						ID:   "add",
+						Type: dto.BatchOperationAddGroupUsers,
+						Args: []byte(`{"groupId":"$ref:g1.groupId","userIds":["$ref:u2.userId"]}`),
+					},
+				},
+			},
+			want: &dto.RunBatchOutput{
+				Results: []dto.BatchResult{
+					{
+						ID:   "u1",
+						Type: dto.BatchOperationCreateUser,
+						Result: map[string]any{
+							"userId": "TEST_USER_ID_TEST_USER_NAME_1",
+							"name":   "TEST_USER_NAME_1",
+							"email":  "TEST_USER_EMAIL_1",
+						},
+					},
+					{
+						ID:   "u2",
+						Type: dto.BatchOperationCreateUser,
+						Result: map[string]any{
+							"userId": "TEST_USER_ID_TEST_USER_NAME_2",
+							"name":   "TEST_USER_NAME_2",
+							"email":  "TEST_USER_EMAIL_2",
+						},
+					},
+					{
+						ID:   "g1",
+						Type: dto.BatchOperationCreateGroup,
+						Result: map[string]any{
+							"groupId": "TEST_GROUP_ID_TEST_GROUP_NAME",
+							"name":    "TEST_GROUP_NAME",
+							"userIds": []any{"TEST_USER_ID_TEST_USER_NAME_1"},
+						},
+					},
+					{
+						ID:   "add",
+						Type: dto.BatchOperationAddGroupUsers,
+						Result: map[string]any{
+							"groupId":           "TEST_GROUP_ID_TEST_GROUP_NAME",
+							"waitlistedUserIds": []string{},
+						},
+					},
+				},
+			},
+			wantUsers: model.Users{
+				stamped(
+					model.MustNewUser("TEST_USER_ID_TEST_USER_NAME_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+					model.NewTimestamps("TEST_ACTOR", testNow),
+				),
+				stamped(
+					model.MustNewUser("TEST_USER_ID_TEST_USER_NAME_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+					model.NewTimestamps("TEST_ACTOR", testNow),
+				),
+			},
+			wantGroups: model.Groups{
+				stamped(
+					model.MustNewGroup(
+						"TEST_GROUP_ID_TEST_GROUP_NAME",
+						"TEST_GROUP_NAME",
+						[]model.UserID{"TEST_USER_ID_TEST_USER_NAME_1", "TEST_USER_ID_TEST_USER_NAME_2"},
+					),
+					model.NewTimestamps("TEST_ACTOR", testNow),
+				),
+			},
+			wantEventTypes: []model.DomainEventType{
+				model.DomainEventTypeUserCreated,
+				model.DomainEventTypeUserCreated,
+				model.DomainEventTypeGroupCreated,
+				model.DomainEventTypeGroupMembershipChanged,
+			},
+		},
+		{
+			name: "Rolls back all the operations if any fails",
+			in: &dto.RunBatchInput{
+				Operations: []dto.BatchOperation{
+					{
+						ID:   "u1",
+						Type: dto.BatchOperationCreateUser,
+						Args: []byte(`{"name":"TEST_USER_NAME_1","email":"TEST_USER_EMAIL_1"}`),
+					},
+					{
+						ID:   "u2",
+						Type: dto.BatchOperationCreateUser,
+						Args: []byte(`{"name":"TEST_USER_NAME_XXXXXXXXXXXXXXXXXXXXXXXXXX","email":"TEST_USER_EMAIL_2"}`),
+					},
+				},
+			},
+			wantEventTypes: []model.DomainEventType{},
+			wantErr:        usecase.ErrInvalidUserInput,
+			wantErrIndex:   1,
+		},
+		{
+			name: "Returns error if a reference is to a later operation",
+			in: &dto.RunBatchInput{
+				Operations: []dto.BatchOperation{
+					{
+						ID:   "g1",
+						Type: dto.BatchOperationCreateGroup,
+						Args: []byte(`{"name":"TEST_GROUP_NAME","userIds":["$ref:u1.userId"]}`),
+					},
+					{
+						ID:   "u1",
+						Type: dto.BatchOperationCreateUser,
+						Args: []byte(`{"name":"TEST_USER_NAME_1","email":"TEST_USER_EMAIL_1"}`),
+					},
+				},
+			},
+			wantEventTypes: []model.DomainEventType{},
+			wantErr:        usecase.ErrInvalidBatchInput,
+			wantErrIndex:   0,
+		},
+		{
+			name: "Returns error if the arguments have an unknown field",
+			in: &dto.RunBatchInput{
+				Operations: []dto.BatchOperation{
+					{
+						ID:   "u1",
+						Type: dto.BatchOperationCreateUser,
+						Args: []byte(`{"name":"TEST_USER_NAME_1","email":"TEST_USER_EMAIL_1","actor":"TEST_ACTOR"}`),
+					},
+				},
+			},
+			wantEventTypes: []model.DomainEventType{},
+			wantErr:        usecase.ErrInvalidBatchInput,
+			wantErrIndex:   0,
+		},
+		{
+			name: "Returns error if the type of an operation is unknown",
+			in: &dto.RunBatchInput{
+				Operations: []dto.BatchOperation{
+					{ID: "op1", Type: "TEST_TYPE"},
+				},
+			},
+			wantEventTypes: []model.DomainEventType{},
+			wantErr:        usecase.ErrInvalidBatchInput,
+			wantErrIndex:   0,
+		},
+		{
+			name: "Returns error if the ids of operations are duplicate",
+			in: &dto.RunBatchInput{
+				Operations: []dto.BatchOperation{
+					{ID: "op1", Type: dto.BatchOperationDeleteUser},
+					{ID: "op1", Type: dto.BatchOperationDeleteUser},
+				},
+			},
+			wantEventTypes: []model.DomainEventType{},
+			wantErr:        usecase.ErrInvalidBatchInput,
+			wantErrIndex:   1,
+		},
+		{
+			name:           "Returns error if there are no operations",
+			in:             &dto.RunBatchInput{},
+			wantEventTypes: []model.DomainEventType{},
+			wantErr:        usecase.ErrInvalidBatchInput,
+			wantErrIndex:   -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uf := mockfactory.NewMockUserFactory(ctrl)
+			uf.EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(name, email string) (*model.User, error) {
+					if err := model.DefaultPolicy().User.ValidateName(name); err != nil {
+						return nil, err
+					}
+					return model.NewUser(model.UserID("TEST_USER_ID_"+name), name, email)
+				}).
+				AnyTimes()
+			gf := mockfactory.NewMockGroupFactory(ctrl)
+			gf.EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(name string, uIDs []model.UserID) (*model.Group, error) {
+					if err := model.DefaultPolicy().Group.ValidateName(name); err != nil {
+						return nil, err
+					}
+					return model.NewGroup(model.GroupID("TEST_GROUP_ID_"+name), name, uIDs)
+				}).
+				AnyTimes()
+
+			r := memory.NewMemoryRepository(memory.NewStore())
+			uc := usecase.NewBatchUsecase(r, func(r repository.Repository) usecase.BatchUsecases {
+				us := domainservice.NewUserService(r)
+				gs := domainservice.NewGroupService(r)
+				return usecase.BatchUsecases{
+					User: usecase.NewUserUsecase(
+						r, uf, us, gs,
+						factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+						factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+						factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+						model.DefaultPolicy(),
+						testClock,
+					),
+					Group: usecase.NewGroupUsecase(
+						r, gf, gs, us,
+						factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+						factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+						factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+						model.DefaultPolicy(),
+						testClock,
+					),
+				}
+			})
+
+			got, err := uc.RunBatch(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("uc.RunBatch(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+				var oe *usecase.BatchOperationError
+				if errors.As(err, &oe) {
+					if oe.Index != tt.wantErrIndex {
+						t.Errorf("the index of the failed operation=%d; want %d", oe.Index, tt.wantErrIndex)
+					}
+				} else if tt.wantErrIndex >= 0 {
+					t.Errorf("uc.RunBatch(%v)=_, %v; want a *usecase.BatchOperationError", tt.in, err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("want no err, but has error %v", err)
+				}
+				if diff := cmp.Diff(got, tt.want); diff != "" {
+					t.Errorf(
+						"uc.RunBatch(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+						tt.in, got, tt.want, diff,
+					)
+				}
+			}
+
+			gotUsers, _ := r.User().List(repository.UserListFilter{})
+			if diff := cmp.Diff(gotUsers, tt.wantUsers, cmp.AllowUnexported(model.User{})); diff != "" {
+				t.Errorf(
+					"r.User().List()=%v, _; want %v, nil\ndiffers: (-got +want)\n%s",
+					gotUsers, tt.wantUsers, diff,
+				)
+			}
+			gotGroups, _ := r.Group().List(repository.GroupListFilter{})
+			if diff := cmp.Diff(gotGroups, tt.wantGroups, cmp.AllowUnexported(model.Group{})); diff != "" {
+				t.Errorf(
+					"r.Group().List()=%v, _; want %v, nil\ndiffers: (-got +want)\n%s",
+					gotGroups, tt.wantGroups, diff,
+				)
+			}
+			assertOutboxMessages(t, r, tt.wantEventTypes...)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package usecase
+
+import "time"
+
+// Clock tells the time at which the users and the groups are created or updated.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc is an adapter to use an ordinary function such as time.Now as a Clock.
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// now returns the time of the clock in UTC truncated to seconds,
+// which is the precision of both the database and the HTTP dates.
+func now(c Clock) time.Time {
+	return c.Now().UTC().Truncate(time.Second)
+}
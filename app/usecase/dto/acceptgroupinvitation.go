@@ -0,0 +1,14 @@
+package dto
+
+type (
+	AcceptGroupInvitationInput struct {
+		Meta
+		GroupInvitationID string
+		UserID            string
+	}
+
+	AcceptGroupInvitationOutput struct {
+		// Waitlisted is true if the group is at capacity and the user is waitlisted instead of joining it.
+		Waitlisted bool
+	}
+)
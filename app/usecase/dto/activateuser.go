@@ -0,0 +1,12 @@
+package dto
+
+type (
+	ActivateUserInput struct {
+		Meta
+		UserID string
+	}
+
+	ActivateUserOutput struct {
+		User User
+	}
+)
@@ -0,0 +1,13 @@
+package dto
+
+type (
+	AddGroupUsersInput struct {
+		Meta
+		GroupID string
+		UserIDs []string
+	}
+	AddGroupUsersOutput struct {
+		// WaitlistedUserIDs are the users put on the waitlist as the group is at capacity.
+		WaitlistedUserIDs []string
+	}
+)
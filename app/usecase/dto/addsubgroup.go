@@ -0,0 +1,9 @@
+package dto
+
+type (
+	AddSubgroupInput struct {
+		GroupID    string
+		SubgroupID string
+	}
+	AddSubgroupOutput struct{}
+)
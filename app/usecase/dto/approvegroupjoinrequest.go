@@ -0,0 +1,14 @@
+package dto
+
+type (
+	ApproveGroupJoinRequestInput struct {
+		Meta
+		GroupID            string
+		GroupJoinRequestID string
+	}
+
+	ApproveGroupJoinRequestOutput struct {
+		// Waitlisted is true if the group is at capacity and the user is waitlisted instead of joining it.
+		Waitlisted bool
+	}
+)
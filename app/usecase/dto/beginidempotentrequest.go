@@ -0,0 +1,14 @@
+package dto
+
+type (
+	BeginIdempotentRequestInput struct {
+		Key         string
+		Fingerprint string
+	}
+
+	// BeginIdempotentRequestOutput has the response to replay if the request has been performed,
+	// otherwise the request is to be performed and completed.
+	BeginIdempotentRequestOutput struct {
+		Replay *IdempotentResponse
+	}
+)
@@ -0,0 +1,10 @@
+package dto
+
+type (
+	CompleteIdempotentRequestInput struct {
+		Key      string
+		Response IdempotentResponse
+	}
+
+	CompleteIdempotentRequestOutput struct{}
+)
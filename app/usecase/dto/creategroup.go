@@ -2,8 +2,13 @@ package dto
 
 type (
 	CreateGroupInput struct {
+		Meta
 		Name    string
 		UserIDs []string
+		// JoinPolicy is the default one if it is empty.
+		JoinPolicy string
+		// Attributes are the custom attributes of the group, which the schemas of the groups validate.
+		Attributes map[string]any
 	}
 
 	CreateGroupOutput struct {
@@ -2,8 +2,13 @@ package dto
 
 type (
 	CreateUserInput struct {
+		Meta
 		Name  string
 		Email string
+		// Status is the initial status of the user, which is either INVITED or ACTIVE. It is ACTIVE when it is empty.
+		Status string
+		// Attributes are the custom attributes of the user, which the schemas of the users validate.
+		Attributes map[string]any
 	}
 
 	CreateUserOutput struct {
@@ -0,0 +1,13 @@
+package dto
+
+type (
+	CreateWebhookSubscriptionInput struct {
+		URL        string
+		EventTypes []string
+		Secret     string
+	}
+
+	CreateWebhookSubscriptionOutput struct {
+		WebhookSubscription WebhookSubscription
+	}
+)
@@ -0,0 +1,12 @@
+package dto
+
+type (
+	DeactivateUserInput struct {
+		Meta
+		UserID string
+	}
+
+	DeactivateUserOutput struct {
+		User User
+	}
+)
@@ -0,0 +1,9 @@
+package dto
+
+type (
+	DeclineGroupInvitationInput struct {
+		GroupInvitationID string
+	}
+
+	DeclineGroupInvitationOutput struct{}
+)
@@ -0,0 +1,10 @@
+package dto
+
+type (
+	DeleteAttributeSchemaInput struct {
+		Target string
+		Key    string
+	}
+
+	DeleteAttributeSchemaOutput struct{}
+)
@@ -2,6 +2,7 @@ package dto
 
 type (
 	DeleteGroupInput struct {
+		Meta
 		GroupID string
 	}
 
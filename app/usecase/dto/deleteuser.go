@@ -2,6 +2,7 @@ package dto
 
 type (
 	DeleteUserInput struct {
+		Meta
 		UserID string
 	}
 
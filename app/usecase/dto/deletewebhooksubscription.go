@@ -0,0 +1,9 @@
+package dto
+
+type (
+	DeleteWebhookSubscriptionInput struct {
+		WebhookSubscriptionID string
+	}
+
+	DeleteWebhookSubscriptionOutput struct{}
+)
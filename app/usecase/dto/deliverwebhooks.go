@@ -0,0 +1,13 @@
+package dto
+
+type (
+	DeliverWebhooksInput struct {
+		BatchSize int
+	}
+
+	DeliverWebhooksOutput struct {
+		Succeeded int
+		Failed    int
+		Dead      int
+	}
+)
@@ -0,0 +1,9 @@
+package dto
+
+type (
+	ExpireGroupInvitationsInput struct{}
+
+	ExpireGroupInvitationsOutput struct {
+		Expired int
+	}
+)
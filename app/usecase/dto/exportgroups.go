@@ -0,0 +1,15 @@
+package dto
+
+type (
+	// ExportGroupsInput requests a chunk of the groups in the order of id.
+	ExportGroupsInput struct {
+		AfterGroupID string
+		Limit        int
+	}
+
+	ExportGroupsOutput struct {
+		Groups []Group
+		// NextAfterGroupID is the cursor of the next chunk, empty if the chunk is the last one.
+		NextAfterGroupID string
+	}
+)
@@ -0,0 +1,15 @@
+package dto
+
+type (
+	// ExportUsersInput requests a chunk of the users in the order of id.
+	ExportUsersInput struct {
+		AfterUserID string
+		Limit       int
+	}
+
+	ExportUsersOutput struct {
+		Users []User
+		// NextAfterUserID is the cursor of the next chunk, empty if the chunk is the last one.
+		NextAfterUserID string
+	}
+)
@@ -0,0 +1,12 @@
+package dto
+
+type (
+	GetAttributeSchemasInput struct {
+		// Target narrows the schemas down to the ones of the target when it is not empty.
+		Target string
+	}
+
+	GetAttributeSchemasOutput struct {
+		AttributeSchemas []AttributeSchema
+	}
+)
@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+type (
+	GetAuditEventsInput struct {
+		Actor      string
+		TargetType string
+		TargetID   string
+		From       time.Time
+		To         time.Time
+	}
+
+	GetAuditEventsOutput struct {
+		AuditEvents []AuditEvent
+	}
+)
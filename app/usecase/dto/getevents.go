@@ -0,0 +1,14 @@
+package dto
+
+type (
+	GetEventsInput struct {
+		AfterEventID string
+		ResourceType string
+		ResourceID   string
+		Limit        int
+	}
+
+	GetEventsOutput struct {
+		Events []Event
+	}
+)
@@ -0,0 +1,11 @@
+package dto
+
+type (
+	GetGroupInvitationsInput struct {
+		GroupID string
+	}
+
+	GetGroupInvitationsOutput struct {
+		GroupInvitations []GroupInvitation
+	}
+)
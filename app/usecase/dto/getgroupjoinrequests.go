@@ -0,0 +1,11 @@
+package dto
+
+type (
+	GetGroupJoinRequestsInput struct {
+		GroupID string
+	}
+
+	GetGroupJoinRequestsOutput struct {
+		GroupJoinRequests []GroupJoinRequest
+	}
+)
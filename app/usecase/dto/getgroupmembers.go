@@ -0,0 +1,13 @@
+package dto
+
+type (
+	GetGroupMembersInput struct {
+		GroupID string
+		// Transitive gets the members of the subgroups, directly or transitively nested, as well when it is true.
+		Transitive bool
+	}
+
+	GetGroupMembersOutput struct {
+		Users []User
+	}
+)
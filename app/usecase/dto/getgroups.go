@@ -1,9 +1,30 @@
 package dto
 
 type (
-	GetGroupsInput struct{}
+	GetGroupsInput struct {
+		// UserIDs narrows the groups down to the ones any of the users belong to when it is not empty.
+		UserIDs []string
+		// Query searches the groups by name, ordering them by relevance, when it is not empty.
+		Query string
+		// AllUserIDs narrows the groups down to the ones all the users belong to when it is not empty.
+		AllUserIDs []string
+		// NoUsers narrows the groups down to the ones with no users when it is true.
+		NoUsers bool
+		// Full narrows the groups down to the ones at capacity when it is true.
+		Full bool
+		// Attributes narrows the groups down to the ones with all the attribute values, in their string form,
+		// when it is not empty.
+		Attributes map[string]string
+		// LabelSelector narrows the groups down to the ones whose labels meet the selector, e.g. team=payments,env!=dev,
+		// when it is not empty.
+		LabelSelector string
+		// Sort orders the groups by createdAt or updatedAt, descending if prefixed with "-", when it is not empty.
+		Sort string
+	}
 
 	GetGroupsOutput struct {
 		Groups []Group
+		// Highlights are the matches of the query in the fields of the groups by group id, when searched.
+		Highlights map[string][]Highlight
 	}
 )
@@ -0,0 +1,12 @@
+package dto
+
+type (
+	GetGroupWaitlistInput struct {
+		GroupID string
+	}
+
+	GetGroupWaitlistOutput struct {
+		// UserIDs are the waitlisted users, the next one to be promoted first.
+		UserIDs []string
+	}
+)
@@ -0,0 +1,14 @@
+package dto
+
+type (
+	GetSubgroupsInput struct {
+		GroupID string
+		// Transitive gets the groups nested in the subgroups as well when it is true.
+		Transitive bool
+	}
+
+	GetSubgroupsOutput struct {
+		// GroupIDs are the subgroups sorted by id.
+		GroupIDs []string
+	}
+)
@@ -0,0 +1,14 @@
+package dto
+
+type (
+	GetUserGroupsInput struct {
+		UserID string
+		// Transitive gets the groups which the groups of the user are nested in, directly or transitively,
+		// as well when it is true.
+		Transitive bool
+	}
+
+	GetUserGroupsOutput struct {
+		Groups []Group
+	}
+)
@@ -0,0 +1,11 @@
+package dto
+
+type (
+	GetUserInvitationsInput struct {
+		UserID string
+	}
+
+	GetUserInvitationsOutput struct {
+		GroupInvitations []GroupInvitation
+	}
+)
@@ -1,9 +1,29 @@
 package dto
 
+import "time"
+
 type (
-	GetUsersInput struct{}
+	GetUsersInput struct {
+		// UserIDs narrows the users down when it is not empty.
+		UserIDs []string
+		// Query searches the users by name and email, ordering them by relevance, when it is not empty.
+		Query string
+		// NoGroup narrows the users down to the ones in no group when it is true.
+		NoGroup bool
+		// Status narrows the users down to the ones in the status when it is not empty.
+		Status string
+		// Attributes narrows the users down to the ones with all the attribute values, in their string form,
+		// when it is not empty.
+		Attributes map[string]string
+		// CreatedAfter narrows the users down to the ones created after it when it is not zero.
+		CreatedAfter time.Time
+		// Sort orders the users by createdAt or updatedAt, descending if prefixed with "-", when it is not empty.
+		Sort string
+	}
 
 	GetUsersOutput struct {
 		Users []User
+		// Highlights are the matches of the query in the fields of the users by user id, when searched.
+		Highlights map[string][]Highlight
 	}
 )
@@ -0,0 +1,12 @@
+package dto
+
+type (
+	GetWebhookDeliveriesInput struct {
+		WebhookSubscriptionID string
+		Status                string
+	}
+
+	GetWebhookDeliveriesOutput struct {
+		WebhookDeliveries []WebhookDelivery
+	}
+)
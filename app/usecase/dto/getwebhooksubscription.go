@@ -0,0 +1,11 @@
+package dto
+
+type (
+	GetWebhookSubscriptionInput struct {
+		WebhookSubscriptionID string
+	}
+
+	GetWebhookSubscriptionOutput struct {
+		WebhookSubscription WebhookSubscription
+	}
+)
@@ -0,0 +1,9 @@
+package dto
+
+type (
+	GetWebhookSubscriptionsInput struct{}
+
+	GetWebhookSubscriptionsOutput struct {
+		WebhookSubscriptions []WebhookSubscription
+	}
+)
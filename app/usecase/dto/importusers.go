@@ -0,0 +1,52 @@
+package dto
+
+// ImportMode is how an imported user whose email already exists is handled.
+type ImportMode string
+
+const (
+	// ImportModeCreate fails the row of an existing email.
+	ImportModeCreate ImportMode = "create"
+	// ImportModeUpsert updates the name of the user of an existing email.
+	ImportModeUpsert ImportMode = "upsert"
+)
+
+// ImportStatus is the result of importing a row.
+type ImportStatus string
+
+const (
+	ImportStatusCreated ImportStatus = "CREATED"
+	ImportStatusUpdated ImportStatus = "UPDATED"
+	ImportStatusSkipped ImportStatus = "SKIPPED"
+	ImportStatusFailed  ImportStatus = "FAILED"
+)
+
+type (
+	ImportUsersInput struct {
+		Meta
+		Rows      []ImportUserRow
+		Mode      ImportMode
+		DryRun    bool
+		BatchSize int
+	}
+
+	// ImportUserRow is a row of an import file. Err is the error reading the row, if any.
+	ImportUserRow struct {
+		Line  int
+		Name  string
+		Email string
+		Err   error
+	}
+
+	ImportUsersOutput struct {
+		Results []ImportUserResult
+	}
+
+	// ImportUserResult is the result of a row, which would be if the import is a dry run.
+	ImportUserResult struct {
+		Line   int
+		Status ImportStatus
+		UserID string
+		Email  string
+		Reason string
+	}
+)
@@ -0,0 +1,15 @@
+package dto
+
+type (
+	InviteToGroupInput struct {
+		Meta
+		GroupID string
+		// Either UserID or Email is the invitee.
+		UserID string
+		Email  string
+	}
+
+	InviteToGroupOutput struct {
+		GroupInvitation GroupInvitation
+	}
+)
@@ -0,0 +1,17 @@
+package dto
+
+type (
+	JoinGroupInput struct {
+		Meta
+		GroupID string
+		UserID  string
+	}
+
+	JoinGroupOutput struct {
+		// GroupJoinRequest is the pending request to join the group if its join policy requires an approval,
+		// and nil if the user joins it immediately.
+		GroupJoinRequest *GroupJoinRequest
+		// Waitlisted is true if the group is at capacity and the user is waitlisted instead of joining it.
+		Waitlisted bool
+	}
+)
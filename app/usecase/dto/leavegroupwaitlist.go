@@ -0,0 +1,9 @@
+package dto
+
+type (
+	LeaveGroupWaitlistInput struct {
+		GroupID string
+		UserID  string
+	}
+	LeaveGroupWaitlistOutput struct{}
+)
@@ -0,0 +1,7 @@
+package dto
+
+// Meta describes who invoked a usecase and from which request.
+type Meta struct {
+	Actor     string
+	RequestID string
+}
@@ -1,31 +1,160 @@
 package dto
 
-import "github.com/toshiykst/go-layerd-architecture/app/domain/model"
+import (
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+)
 
 type User struct {
 	UserID string
 	Name   string
 	Email  string
+	Status string
+	// Attributes are the custom attributes of the user, nil if it has none.
+	Attributes map[string]any
+	Timestamps
+}
+
+// Timestamps are when and by whom a user or a group was created and last updated.
+type Timestamps struct {
+	CreatedAt time.Time
+	CreatedBy string
+	UpdatedAt time.Time
+	UpdatedBy string
 }
 
 type Group struct {
-	GroupID string
-	Name    string
-	Users   []User
+	GroupID    string
+	Name       string
+	JoinPolicy string
+	// Attributes are the custom attributes of the group, nil if it has none.
+	Attributes map[string]any
+	// Labels are the labels of the group, nil if it has none.
+	Labels map[string]string
+	Users  []User
+	Timestamps
+}
+
+type AuditEvent struct {
+	AuditEventID string
+	Actor        string
+	Action       string
+	TargetType   string
+	TargetID     string
+	Changes      []AuditChange
+	RequestID    string
+	OccurredAt   time.Time
+}
+
+type AuditChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+type AttributeSchema struct {
+	Target   string
+	Key      string
+	Type     string
+	Required bool
+	Enum     []string
+	Pattern  string
+}
+
+type WebhookSubscription struct {
+	WebhookSubscriptionID string
+	URL                   string
+	EventTypes            []string
+}
+
+type WebhookDelivery struct {
+	WebhookDeliveryID     string
+	WebhookSubscriptionID string
+	EventID               string
+	EventType             string
+	Status                string
+	Attempts              int
+	LastStatusCode        int
+	LastError             string
+	OccurredAt            time.Time
+	NextAttemptAt         time.Time
+	DeliveredAt           time.Time
+}
+
+type GroupInvitation struct {
+	GroupInvitationID string
+	GroupID           string
+	Inviter           string
+	InviteeUserID     string
+	InviteeEmail      string
+	Status            string
+	CreatedAt         time.Time
+	ExpiresAt         time.Time
+	RespondedAt       time.Time
+}
+
+type GroupJoinRequest struct {
+	GroupJoinRequestID string
+	GroupID            string
+	UserID             string
+	Status             string
+	CreatedAt          time.Time
+	RespondedAt        time.Time
+	RespondedBy        string
+}
+
+type Event struct {
+	EventID      string
+	EventType    string
+	ResourceType string
+	ResourceID   string
+	OccurredAt   time.Time
+	// Payload is the JSON encoded domain event.
+	Payload []byte
+}
+
+// Highlight is a match of a search query in a field, from Start inclusive to End exclusive in runes.
+type Highlight struct {
+	Field string
+	Start int
+	End   int
+}
+
+type IdempotentResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+func ToUserFromModel(mu *model.User) User {
+	return User{
+		UserID:     string(mu.ID()),
+		Name:       mu.Name(),
+		Email:      mu.Email(),
+		Status:     string(mu.Status()),
+		Attributes: mu.Attributes(),
+		Timestamps: ToTimestampsFromModel(mu.Timestamps()),
+	}
 }
 
 func ToUsersFromModel(mus model.Users) []User {
 	result := make([]User, len(mus))
 	for i, mu := range mus {
-		result[i] = User{
-			UserID: string(mu.ID()),
-			Name:   mu.Name(),
-			Email:  mu.Email(),
-		}
+		result[i] = ToUserFromModel(mu)
 	}
 	return result
 }
 
+func ToTimestampsFromModel(ts model.Timestamps) Timestamps {
+	return Timestamps{
+		CreatedAt: ts.CreatedAt,
+		CreatedBy: ts.CreatedBy,
+		UpdatedAt: ts.UpdatedAt,
+		UpdatedBy: ts.UpdatedBy,
+	}
+}
+
 func ToModelUserIDs(ids []string) []model.UserID {
 	uIDs := make([]model.UserID, len(ids))
 	for i, id := range ids {
@@ -33,3 +162,219 @@ func ToModelUserIDs(ids []string) []model.UserID {
 	}
 	return uIDs
 }
+
+func ToUserIDsFromModel(uIDs []model.UserID) []string {
+	ids := make([]string, len(uIDs))
+	for i, uID := range uIDs {
+		ids[i] = string(uID)
+	}
+	return ids
+}
+
+func ToGroupIDsFromModel(gIDs []model.GroupID) []string {
+	ids := make([]string, len(gIDs))
+	for i, gID := range gIDs {
+		ids[i] = string(gID)
+	}
+	return ids
+}
+
+func ToAuditEventsFromModel(mes model.AuditEvents) []AuditEvent {
+	result := make([]AuditEvent, len(mes))
+	for i, me := range mes {
+		changes := make([]AuditChange, len(me.Changes()))
+		for j, c := range me.Changes() {
+			changes[j] = AuditChange{
+				Field:  c.Field,
+				Before: c.Before,
+				After:  c.After,
+			}
+		}
+		result[i] = AuditEvent{
+			AuditEventID: string(me.ID()),
+			Actor:        me.Actor(),
+			Action:       string(me.Action()),
+			TargetType:   string(me.Target().Type),
+			TargetID:     me.Target().ID,
+			Changes:      changes,
+			RequestID:    me.RequestID(),
+			OccurredAt:   me.OccurredAt(),
+		}
+	}
+	return result
+}
+
+func ToAttributeSchemaFromModel(ms *model.AttributeSchema) AttributeSchema {
+	return AttributeSchema{
+		Target:   string(ms.Target()),
+		Key:      ms.Key(),
+		Type:     string(ms.Type()),
+		Required: ms.Required(),
+		Enum:     ms.Enum(),
+		Pattern:  ms.Pattern(),
+	}
+}
+
+func ToAttributeSchemasFromModel(mss model.AttributeSchemas) []AttributeSchema {
+	result := make([]AttributeSchema, len(mss))
+	for i, ms := range mss {
+		result[i] = ToAttributeSchemaFromModel(ms)
+	}
+	return result
+}
+
+func ToWebhookSubscriptionFromModel(ms *model.WebhookSubscription) WebhookSubscription {
+	eventTypes := make([]string, len(ms.EventTypes()))
+	for i, t := range ms.EventTypes() {
+		eventTypes[i] = string(t)
+	}
+	return WebhookSubscription{
+		WebhookSubscriptionID: string(ms.ID()),
+		URL:                   ms.URL(),
+		EventTypes:            eventTypes,
+	}
+}
+
+func ToWebhookSubscriptionsFromModel(mss model.WebhookSubscriptions) []WebhookSubscription {
+	result := make([]WebhookSubscription, len(mss))
+	for i, ms := range mss {
+		result[i] = ToWebhookSubscriptionFromModel(ms)
+	}
+	return result
+}
+
+func ToModelDomainEventTypes(ts []string) []model.DomainEventType {
+	result := make([]model.DomainEventType, len(ts))
+	for i, t := range ts {
+		result[i] = model.DomainEventType(t)
+	}
+	return result
+}
+
+func ToWebhookDeliveryFromModel(md *model.WebhookDelivery) WebhookDelivery {
+	st := md.State()
+	return WebhookDelivery{
+		WebhookDeliveryID:     string(md.ID()),
+		WebhookSubscriptionID: string(md.SubscriptionID()),
+		EventID:               string(md.EventID()),
+		EventType:             string(md.EventType()),
+		Status:                string(st.Status),
+		Attempts:              st.Attempts,
+		LastStatusCode:        st.LastStatusCode,
+		LastError:             st.LastError,
+		OccurredAt:            md.OccurredAt(),
+		NextAttemptAt:         st.NextAttemptAt,
+		DeliveredAt:           st.DeliveredAt,
+	}
+}
+
+func ToWebhookDeliveriesFromModel(mds model.WebhookDeliveries) []WebhookDelivery {
+	result := make([]WebhookDelivery, len(mds))
+	for i, md := range mds {
+		result[i] = ToWebhookDeliveryFromModel(md)
+	}
+	return result
+}
+
+func ToGroupInvitationFromModel(minv *model.GroupInvitation) GroupInvitation {
+	st := minv.State()
+	return GroupInvitation{
+		GroupInvitationID: string(minv.ID()),
+		GroupID:           string(minv.GroupID()),
+		Inviter:           minv.Inviter(),
+		InviteeUserID:     string(minv.Invitee().UserID),
+		InviteeEmail:      minv.Invitee().Email,
+		Status:            string(st.Status),
+		CreatedAt:         minv.CreatedAt(),
+		ExpiresAt:         minv.ExpiresAt(),
+		RespondedAt:       st.RespondedAt,
+	}
+}
+
+func ToGroupInvitationsFromModel(minvs model.GroupInvitations) []GroupInvitation {
+	result := make([]GroupInvitation, len(minvs))
+	for i, minv := range minvs {
+		result[i] = ToGroupInvitationFromModel(minv)
+	}
+	return result
+}
+
+func ToGroupJoinRequestFromModel(mjr *model.GroupJoinRequest) GroupJoinRequest {
+	st := mjr.State()
+	return GroupJoinRequest{
+		GroupJoinRequestID: string(mjr.ID()),
+		GroupID:            string(mjr.GroupID()),
+		UserID:             string(mjr.UserID()),
+		Status:             string(st.Status),
+		CreatedAt:          mjr.CreatedAt(),
+		RespondedAt:        st.RespondedAt,
+		RespondedBy:        st.RespondedBy,
+	}
+}
+
+func ToGroupJoinRequestsFromModel(mjrs model.GroupJoinRequests) []GroupJoinRequest {
+	result := make([]GroupJoinRequest, len(mjrs))
+	for i, mjr := range mjrs {
+		result[i] = ToGroupJoinRequestFromModel(mjr)
+	}
+	return result
+}
+
+func ToEventFromModel(mm *model.OutboxMessage) Event {
+	return Event{
+		EventID:      string(mm.ID()),
+		EventType:    string(mm.EventType()),
+		ResourceType: string(mm.AggregateType()),
+		ResourceID:   mm.AggregateID(),
+		OccurredAt:   mm.OccurredAt(),
+		Payload:      mm.Payload(),
+	}
+}
+
+func ToEventsFromModel(mms model.OutboxMessages) []Event {
+	result := make([]Event, len(mms))
+	for i, mm := range mms {
+		result[i] = ToEventFromModel(mm)
+	}
+	return result
+}
+
+func ToIdempotentResponseFromModel(mr model.IdempotentResponse) IdempotentResponse {
+	return IdempotentResponse{
+		StatusCode:  mr.StatusCode,
+		ContentType: mr.ContentType,
+		Body:        mr.Body,
+	}
+}
+
+func ToModelIdempotentResponse(r IdempotentResponse) model.IdempotentResponse {
+	return model.IdempotentResponse{
+		StatusCode:  r.StatusCode,
+		ContentType: r.ContentType,
+		Body:        r.Body,
+	}
+}
+
+func ToUserHighlightsFromModel(q string, mus model.Users) map[string][]Highlight {
+	result := make(map[string][]Highlight, len(mus))
+	for _, mu := range mus {
+		result[string(mu.ID())] = append(toHighlights(q, "name", mu.Name()), toHighlights(q, "email", mu.Email())...)
+	}
+	return result
+}
+
+func ToGroupHighlightsFromModel(q string, mgs model.Groups) map[string][]Highlight {
+	result := make(map[string][]Highlight, len(mgs))
+	for _, mg := range mgs {
+		result[string(mg.ID())] = toHighlights(q, "name", mg.Name())
+	}
+	return result
+}
+
+func toHighlights(q, field, text string) []Highlight {
+	var result []Highlight
+	for _, r := range model.MatchRanges(q, text) {
+		result = append(result, Highlight{Field: field, Start: r.Start, End: r.End})
+	}
+	return result
+}
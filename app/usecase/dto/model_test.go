@@ -39,16 +39,19 @@ func TestToUsersFromModel(t *testing.T) {
 					UserID: "TEST_USER_ID_1",
 					Name:   "TEST_USER_NAME_1",
 					Email:  "TEST_USER_EMAIL_1",
+					Status: "ACTIVE",
 				},
 				{
 					UserID: "TEST_USER_ID_2",
 					Name:   "TEST_USER_NAME_2",
 					Email:  "TEST_USER_EMAIL_2",
+					Status: "ACTIVE",
 				},
 				{
 					UserID: "TEST_USER_ID_3",
 					Name:   "TEST_USER_NAME_3",
 					Email:  "TEST_USER_EMAIL_3",
+					Status: "ACTIVE",
 				},
 			},
 		},
@@ -100,3 +103,35 @@ func TestToModelUserIDs(t *testing.T) {
 		})
 	}
 }
+
+func TestToUserIDsFromModel(t *testing.T) {
+	tests := []struct {
+		name string
+		uIDs []model.UserID
+		want []string
+	}{
+		{
+			name: "Convert model.UserID slice to string slice",
+			uIDs: []model.UserID{
+				"TEST_USER_ID_1",
+				"TEST_USER_ID_2",
+			},
+			want: []string{
+				"TEST_USER_ID_1",
+				"TEST_USER_ID_2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := dto.ToUserIDsFromModel(tt.uIDs)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(
+					"dto.ToUserIDsFromModel(%v)=%v; want %v\ndiffers: (-got +want)\n%s",
+					tt.uIDs, got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
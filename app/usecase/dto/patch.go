@@ -0,0 +1,11 @@
+package dto
+
+// PatchType is the format of a patch, named after its media type.
+type PatchType string
+
+const (
+	// PatchTypeMergePatch is JSON Merge Patch (RFC 7396).
+	PatchTypeMergePatch PatchType = "application/merge-patch+json"
+	// PatchTypeJSONPatch is JSON Patch (RFC 6902).
+	PatchTypeJSONPatch PatchType = "application/json-patch+json"
+)
@@ -0,0 +1,12 @@
+package dto
+
+type (
+	PatchGroupInput struct {
+		Meta
+		GroupID   string
+		PatchType PatchType
+		Patch     []byte
+	}
+
+	PatchGroupOutput struct{}
+)
@@ -0,0 +1,12 @@
+package dto
+
+type (
+	PatchUserInput struct {
+		Meta
+		UserID    string
+		PatchType PatchType
+		Patch     []byte
+	}
+
+	PatchUserOutput struct{}
+)
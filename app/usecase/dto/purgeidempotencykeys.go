@@ -0,0 +1,9 @@
+package dto
+
+type (
+	PurgeIdempotencyKeysInput struct{}
+
+	PurgeIdempotencyKeysOutput struct {
+		Purged int
+	}
+)
@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+type (
+	PurgeOutboxInput struct {
+		Retention time.Duration
+	}
+
+	PurgeOutboxOutput struct {
+		Purged int
+	}
+)
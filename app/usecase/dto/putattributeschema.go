@@ -0,0 +1,18 @@
+package dto
+
+type (
+	PutAttributeSchemaInput struct {
+		Target   string
+		Key      string
+		Type     string
+		Required bool
+		// Enum is of the values the attribute may have in their string form, any value if it is empty.
+		Enum []string
+		// Pattern is the regular expression a string attribute must match, if it is not empty.
+		Pattern string
+	}
+
+	PutAttributeSchemaOutput struct {
+		AttributeSchema AttributeSchema
+	}
+)
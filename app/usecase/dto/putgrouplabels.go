@@ -0,0 +1,14 @@
+package dto
+
+type (
+	PutGroupLabelsInput struct {
+		Meta
+		GroupID string
+		// Labels replace all the labels of the group, which are removed if it is empty.
+		Labels map[string]string
+	}
+
+	PutGroupLabelsOutput struct {
+		Labels map[string]string
+	}
+)
@@ -0,0 +1,12 @@
+package dto
+
+type (
+	ReactivateUserInput struct {
+		Meta
+		UserID string
+	}
+
+	ReactivateUserOutput struct {
+		User User
+	}
+)
@@ -0,0 +1,12 @@
+package dto
+
+type (
+	RedeliverWebhookInput struct {
+		WebhookSubscriptionID string
+		WebhookDeliveryID     string
+	}
+
+	RedeliverWebhookOutput struct {
+		WebhookDelivery WebhookDelivery
+	}
+)
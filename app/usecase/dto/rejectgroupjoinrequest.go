@@ -0,0 +1,11 @@
+package dto
+
+type (
+	RejectGroupJoinRequestInput struct {
+		Meta
+		GroupID            string
+		GroupJoinRequestID string
+	}
+
+	RejectGroupJoinRequestOutput struct{}
+)
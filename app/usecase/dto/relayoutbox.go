@@ -0,0 +1,12 @@
+package dto
+
+type (
+	RelayOutboxInput struct {
+		BatchSize int
+	}
+
+	RelayOutboxOutput struct {
+		Published int
+		Failed    int
+	}
+)
@@ -0,0 +1,9 @@
+package dto
+
+type (
+	ReleaseIdempotentRequestInput struct {
+		Key string
+	}
+
+	ReleaseIdempotentRequestOutput struct{}
+)
@@ -0,0 +1,10 @@
+package dto
+
+type (
+	RemoveGroupUsersInput struct {
+		Meta
+		GroupID string
+		UserIDs []string
+	}
+	RemoveGroupUsersOutput struct{}
+)
@@ -0,0 +1,9 @@
+package dto
+
+type (
+	RemoveSubgroupInput struct {
+		GroupID    string
+		SubgroupID string
+	}
+	RemoveSubgroupOutput struct{}
+)
@@ -0,0 +1,9 @@
+package dto
+
+type (
+	ReorderGroupWaitlistInput struct {
+		GroupID string
+		UserIDs []string
+	}
+	ReorderGroupWaitlistOutput struct{}
+)
@@ -0,0 +1,9 @@
+package dto
+
+type (
+	RevokeGroupInvitationInput struct {
+		GroupInvitationID string
+	}
+
+	RevokeGroupInvitationOutput struct{}
+)
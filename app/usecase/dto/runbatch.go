@@ -0,0 +1,52 @@
+package dto
+
+// BatchOperationType is the usecase an operation of a batch runs.
+type BatchOperationType string
+
+const (
+	BatchOperationCreateUser       BatchOperationType = "createUser"
+	BatchOperationUpdateUser       BatchOperationType = "updateUser"
+	BatchOperationDeleteUser       BatchOperationType = "deleteUser"
+	BatchOperationCreateGroup      BatchOperationType = "createGroup"
+	BatchOperationUpdateGroup      BatchOperationType = "updateGroup"
+	BatchOperationDeleteGroup      BatchOperationType = "deleteGroup"
+	BatchOperationAddGroupUsers    BatchOperationType = "addGroupUsers"
+	BatchOperationRemoveGroupUsers BatchOperationType = "removeGroupUsers"
+)
+
+// BatchOperationTypes are all the types of the operations.
+var BatchOperationTypes = []BatchOperationType{
+	BatchOperationCreateUser,
+	BatchOperationUpdateUser,
+	BatchOperationDeleteUser,
+	BatchOperationCreateGroup,
+	BatchOperationUpdateGroup,
+	BatchOperationDeleteGroup,
+	BatchOperationAddGroupUsers,
+	BatchOperationRemoveGroupUsers,
+}
+
+type (
+	RunBatchInput struct {
+		Meta
+		Operations []BatchOperation
+	}
+
+	// BatchOperation is an operation of a batch. Args is a JSON object of the arguments, of which a string
+	// "$ref:<id>.<field>" is replaced with the field of the result of the earlier operation of the id.
+	BatchOperation struct {
+		ID   string
+		Type BatchOperationType
+		Args []byte
+	}
+
+	RunBatchOutput struct {
+		Results []BatchResult
+	}
+
+	BatchResult struct {
+		ID     string
+		Type   BatchOperationType
+		Result map[string]any
+	}
+)
@@ -0,0 +1,18 @@
+package dto
+
+type (
+	SubscribeEventsInput struct {
+		ResourceType string
+		ResourceID   string
+	}
+
+	SubscribeEventsOutput struct {
+		// Events receives the events as they are published.
+		Events <-chan Event
+		// Dropped is closed when the subscriber could not keep up and missed events.
+		// The subscriber should resume from the last event it received.
+		Dropped <-chan struct{}
+		// Unsubscribe stops the subscription.
+		Unsubscribe func()
+	}
+)
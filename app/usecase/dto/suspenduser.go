@@ -0,0 +1,12 @@
+package dto
+
+type (
+	SuspendUserInput struct {
+		Meta
+		UserID string
+	}
+
+	SuspendUserOutput struct {
+		User User
+	}
+)
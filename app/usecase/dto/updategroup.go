@@ -2,8 +2,13 @@ package dto
 
 type (
 	UpdateGroupInput struct {
+		Meta
 		GroupID string
 		Name    string
+		// JoinPolicy is kept as it is if it is empty.
+		JoinPolicy string
+		// Attributes replace the custom attributes of the group. They are kept as they are if it is nil.
+		Attributes map[string]any
 	}
 
 	UpdateGroupOutput struct{}
@@ -2,9 +2,12 @@ package dto
 
 type (
 	UpdateUserInput struct {
+		Meta
 		UserID string
 		Name   string
 		Email  string
+		// Attributes replace the custom attributes of the user. They are kept as they are if it is nil.
+		Attributes map[string]any
 	}
 
 	UpdateUserOutput struct{}
@@ -0,0 +1,13 @@
+package dto
+
+type (
+	UpdateWebhookSubscriptionInput struct {
+		WebhookSubscriptionID string
+		URL                   string
+		EventTypes            []string
+		// Secret is kept as it is if empty.
+		Secret string
+	}
+
+	UpdateWebhookSubscriptionOutput struct{}
+)
@@ -3,9 +3,46 @@ package usecase
 import "errors"
 
 var (
-	ErrUserNotFound      = errors.New("user not found")
-	ErrGroupNotFound     = errors.New("group not found")
-	ErrInvalidUserInput  = errors.New("invalid user input")
-	ErrInvalidGroupInput = errors.New("invalid group input")
-	ErrInvalidUserIDs    = errors.New("invalid user ids")
+	ErrUserNotFound              = errors.New("user not found")
+	ErrGroupNotFound             = errors.New("group not found")
+	ErrInvalidUserInput          = errors.New("invalid user input")
+	ErrInvalidGroupInput         = errors.New("invalid group input")
+	ErrInvalidGroupWaitlistInput = errors.New("invalid group waitlist input")
+	ErrInvalidUserIDs            = errors.New("invalid user ids")
+	ErrInvalidPatch              = errors.New("invalid patch")
+
+	ErrInvalidUserStatusTransition = errors.New("invalid user status transition")
+	ErrUserInactive                = errors.New("user is not active")
+
+	ErrAttributeSchemaNotFound     = errors.New("attribute schema not found")
+	ErrInvalidAttributeSchemaInput = errors.New("invalid attribute schema input")
+
+	ErrSubgroupNotFound = errors.New("subgroup not found")
+	ErrGroupCycle       = errors.New("group cycle")
+
+	ErrGroupInvitationNotFound     = errors.New("group invitation not found")
+	ErrInvalidGroupInvitationInput = errors.New("invalid group invitation input")
+	ErrGroupInvitationNotPending   = errors.New("group invitation is not pending")
+
+	ErrGroupJoinNotAllowed          = errors.New("group join not allowed")
+	ErrGroupJoinRequestNotFound     = errors.New("group join request not found")
+	ErrInvalidGroupJoinRequestInput = errors.New("invalid group join request input")
+	ErrGroupJoinRequestNotPending   = errors.New("group join request is not pending")
+	ErrGroupJoinRequestForbidden    = errors.New("group join request forbidden")
+
+	ErrInvalidImportInput = errors.New("invalid import input")
+	ErrInvalidBatchInput  = errors.New("invalid batch input")
+
+	ErrInvalidAuditEventInput = errors.New("invalid audit event input")
+
+	ErrWebhookSubscriptionNotFound     = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound         = errors.New("webhook delivery not found")
+	ErrInvalidWebhookSubscriptionInput = errors.New("invalid webhook subscription input")
+	ErrInvalidWebhookDeliveryInput     = errors.New("invalid webhook delivery input")
+
+	ErrInvalidEventInput = errors.New("invalid event input")
+
+	ErrInvalidIdempotencyKeyInput = errors.New("invalid idempotency key input")
+	ErrIdempotencyKeyMismatch     = errors.New("idempotency key is used for another request")
+	ErrIdempotencyKeyInProgress   = errors.New("request with the idempotency key is in progress")
 )
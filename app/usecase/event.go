@@ -0,0 +1,107 @@
+//go:generate mockgen -source=$GOFILE -package=mock$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+const (
+	defaultEventLimit = 100
+	// eventBufferSize is the number of events a subscriber may fall behind before it is dropped.
+	eventBufferSize = 64
+)
+
+// EventSource notifies the outbox messages as they are published.
+type EventSource interface {
+	Subscribe(h func(m *model.OutboxMessage) error) (unsubscribe func())
+}
+
+type EventUsecase interface {
+	GetEvents(in *dto.GetEventsInput) (*dto.GetEventsOutput, error)
+	SubscribeEvents(in *dto.SubscribeEventsInput) (*dto.SubscribeEventsOutput, error)
+}
+
+type eventUsecase struct {
+	r  repository.Repository
+	es EventSource
+}
+
+func NewEventUsecase(r repository.Repository, es EventSource) EventUsecase {
+	return &eventUsecase{r: r, es: es}
+}
+
+func (uc *eventUsecase) GetEvents(in *dto.GetEventsInput) (*dto.GetEventsOutput, error) {
+	at, err := toAggregateType(in.ResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := in.Limit
+	if limit <= 0 {
+		limit = defaultEventLimit
+	}
+
+	ms, err := uc.r.OutboxMessage().List(repository.OutboxMessageListFilter{
+		Sequenced:     true,
+		AfterID:       model.OutboxMessageID(in.AfterEventID),
+		AggregateType: at,
+		AggregateID:   in.ResourceID,
+		Limit:         limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.GetEventsOutput{
+		Events: dto.ToEventsFromModel(ms),
+	}, nil
+}
+
+func (uc *eventUsecase) SubscribeEvents(in *dto.SubscribeEventsInput) (*dto.SubscribeEventsOutput, error) {
+	at, err := toAggregateType(in.ResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events  = make(chan dto.Event, eventBufferSize)
+		dropped = make(chan struct{})
+		once    sync.Once
+	)
+	unsubscribe := uc.es.Subscribe(func(m *model.OutboxMessage) error {
+		if at != "" && m.AggregateType() != at {
+			return nil
+		}
+		if in.ResourceID != "" && m.AggregateID() != in.ResourceID {
+			return nil
+		}
+
+		// Never block the publisher on a slow subscriber.
+		select {
+		case events <- dto.ToEventFromModel(m):
+		default:
+			once.Do(func() { close(dropped) })
+		}
+		return nil
+	})
+
+	return &dto.SubscribeEventsOutput{
+		Events:      events,
+		Dropped:     dropped,
+		Unsubscribe: unsubscribe,
+	}, nil
+}
+
+func toAggregateType(resourceType string) (model.AggregateType, error) {
+	at := model.AggregateType(resourceType)
+	switch at {
+	case "", model.AggregateTypeUser, model.AggregateTypeGroup:
+		return at, nil
+	}
+	return "", fmt.Errorf("unknown resource type %q: %w", resourceType, ErrInvalidEventInput)
+}
@@ -0,0 +1,191 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/memory"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/publisher"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func newEventMessage(id model.OutboxMessageID, at model.AggregateType, aggregateID string) *model.OutboxMessage {
+	et := model.DomainEventTypeUserCreated
+	if at == model.AggregateTypeGroup {
+		et = model.DomainEventTypeGroupCreated
+	}
+	return model.MustNewOutboxMessage(
+		id,
+		et,
+		at,
+		aggregateID,
+		[]byte(`{}`),
+		time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		model.OutboxDelivery{},
+	)
+}
+
+func eventIDs(es []dto.Event) []string {
+	ids := make([]string, len(es))
+	for i, e := range es {
+		ids[i] = e.EventID
+	}
+	return ids
+}
+
+func TestEventUsecase_GetEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      *dto.GetEventsInput
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "Returns all events in the order they were sequenced, leaving out the unsequenced ones",
+			in:   &dto.GetEventsInput{},
+			want: []string{"TEST_EVENT_ID_1", "TEST_EVENT_ID_2", "TEST_EVENT_ID_3", "TEST_EVENT_ID_4"},
+		},
+		{
+			name: "Returns the events after the event id",
+			in:   &dto.GetEventsInput{AfterEventID: "TEST_EVENT_ID_2"},
+			want: []string{"TEST_EVENT_ID_3", "TEST_EVENT_ID_4"},
+		},
+		{
+			name: "Returns the events filtered by the resource",
+			in:   &dto.GetEventsInput{ResourceType: "USER", ResourceID: "TEST_USER_ID_1"},
+			want: []string{"TEST_EVENT_ID_1", "TEST_EVENT_ID_4"},
+		},
+		{
+			name: "Returns the events up to the limit",
+			in:   &dto.GetEventsInput{AfterEventID: "TEST_EVENT_ID_1", Limit: 2},
+			want: []string{"TEST_EVENT_ID_2", "TEST_EVENT_ID_3"},
+		},
+		{
+			name:    "Returns an error when the resource type is unknown",
+			in:      &dto.GetEventsInput{ResourceType: "UNKNOWN"},
+			wantErr: usecase.ErrInvalidEventInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := []*model.OutboxMessage{
+				newEventMessage("TEST_EVENT_ID_1", model.AggregateTypeUser, "TEST_USER_ID_1"),
+				newEventMessage("TEST_EVENT_ID_2", model.AggregateTypeUser, "TEST_USER_ID_2"),
+				newEventMessage("TEST_EVENT_ID_3", model.AggregateTypeGroup, "TEST_GROUP_ID"),
+				newEventMessage("TEST_EVENT_ID_4", model.AggregateTypeUser, "TEST_USER_ID_1"),
+				newEventMessage("TEST_EVENT_ID_5", model.AggregateTypeUser, "TEST_USER_ID_1"),
+			}
+			s := memory.NewStore()
+			// TEST_EVENT_ID_1 is recorded last but committed, and so sequenced, first.
+			s.AddOutboxMessages(ms[1:]...)
+			s.AddOutboxMessages(ms[0])
+			s.SequenceOutboxMessages(ms[:4]...)
+			uc := usecase.NewEventUsecase(memory.NewMemoryRepository(s), publisher.NewBus())
+
+			got, err := uc.GetEvents(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("uc.GetEvents(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if diff := cmp.Diff(eventIDs(got.Events), tt.want); diff != "" {
+				t.Errorf(
+					"uc.GetEvents(%v) event ids=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+					tt.in, eventIDs(got.Events), tt.want, diff,
+				)
+			}
+		})
+	}
+}
+
+func TestEventUsecase_SubscribeEvents(t *testing.T) {
+	t.Run("Receives the events of the resource", func(t *testing.T) {
+		bus := publisher.NewBus()
+		uc := usecase.NewEventUsecase(memory.NewMemoryRepository(memory.NewStore()), bus)
+
+		out, err := uc.SubscribeEvents(&dto.SubscribeEventsInput{ResourceType: "USER", ResourceID: "TEST_USER_ID_1"})
+		if err != nil {
+			t.Fatalf("want no error, but has error %v", err)
+		}
+		defer out.Unsubscribe()
+
+		for _, m := range []*model.OutboxMessage{
+			newEventMessage("TEST_EVENT_ID_1", model.AggregateTypeUser, "TEST_USER_ID_1"),
+			newEventMessage("TEST_EVENT_ID_2", model.AggregateTypeUser, "TEST_USER_ID_2"),
+			newEventMessage("TEST_EVENT_ID_3", model.AggregateTypeGroup, "TEST_GROUP_ID"),
+			newEventMessage("TEST_EVENT_ID_4", model.AggregateTypeUser, "TEST_USER_ID_1"),
+		} {
+			if err := bus.Publish(m); err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+		}
+
+		var got []string
+		for len(got) < 2 {
+			select {
+			case e := <-out.Events:
+				got = append(got, e.EventID)
+			case <-time.After(time.Second):
+				t.Fatalf("timed out waiting for events, received %v", got)
+			}
+		}
+		want := []string{"TEST_EVENT_ID_1", "TEST_EVENT_ID_4"}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("received event ids=%v; want %v\ndiffers: (-got +want)\n%s", got, want, diff)
+		}
+		select {
+		case e := <-out.Events:
+			t.Errorf("received unexpected event %v", e)
+		default:
+		}
+	})
+
+	t.Run("Drops a subscriber which falls behind without blocking the publisher", func(t *testing.T) {
+		bus := publisher.NewBus()
+		uc := usecase.NewEventUsecase(memory.NewMemoryRepository(memory.NewStore()), bus)
+
+		out, err := uc.SubscribeEvents(&dto.SubscribeEventsInput{})
+		if err != nil {
+			t.Fatalf("want no error, but has error %v", err)
+		}
+		defer out.Unsubscribe()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 1000; i++ {
+				_ = bus.Publish(newEventMessage("TEST_EVENT_ID", model.AggregateTypeUser, "TEST_USER_ID"))
+			}
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("the publisher was blocked by the subscriber")
+		}
+
+		select {
+		case <-out.Dropped:
+		default:
+			t.Error("want the subscriber to be dropped")
+		}
+	})
+
+	t.Run("Returns an error when the resource type is unknown", func(t *testing.T) {
+		uc := usecase.NewEventUsecase(memory.NewMemoryRepository(memory.NewStore()), publisher.NewBus())
+
+		_, err := uc.SubscribeEvents(&dto.SubscribeEventsInput{ResourceType: "UNKNOWN"})
+		if !errors.Is(err, usecase.ErrInvalidEventInput) {
+			t.Errorf("uc.SubscribeEvents()=_, %v; want _, %v", err, usecase.ErrInvalidEventInput)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// MaxExportLimit is the limit of the number of the users or groups in a chunk of an export,
+// which is also the default.
+const MaxExportLimit = 1000
+
+func exportLimit(limit int) int {
+	if limit <= 0 || limit > MaxExportLimit {
+		return MaxExportLimit
+	}
+	return limit
+}
+
+// ExportUsers returns a chunk of the users, so that all the users are exported without being loaded at once.
+func (uc *userUsecase) ExportUsers(in *dto.ExportUsersInput) (*dto.ExportUsersOutput, error) {
+	limit := exportLimit(in.Limit)
+	us, err := uc.r.User().List(repository.UserListFilter{
+		AfterID: model.UserID(in.AfterUserID),
+		Limit:   limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &dto.ExportUsersOutput{Users: dto.ToUsersFromModel(us)}
+	if len(us) == limit {
+		out.NextAfterUserID = string(us[len(us)-1].ID())
+	}
+	return out, nil
+}
+
+// ExportGroups returns a chunk of the groups with their users, so that all the groups are exported
+// without being loaded at once.
+func (uc *groupUsecase) ExportGroups(in *dto.ExportGroupsInput) (*dto.ExportGroupsOutput, error) {
+	limit := exportLimit(in.Limit)
+	gs, err := uc.r.Group().List(repository.GroupListFilter{
+		AfterID: model.GroupID(in.AfterGroupID),
+		Limit:   limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dtogs, err := uc.toDTOGroups(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &dto.ExportGroupsOutput{Groups: dtogs}
+	if len(gs) == limit {
+		out.NextAfterGroupID = string(gs[len(gs)-1].ID())
+	}
+	return out, nil
+}
@@ -0,0 +1,168 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/domainservice"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/memory"
+	mockfactory "github.com/toshiykst/go-layerd-architecture/app/mock/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestUserUsecase_ExportUsers(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *dto.ExportUsersInput
+		want *dto.ExportUsersOutput
+	}{
+		{
+			name: "Returns the first chunk with the cursor of the next chunk",
+			in:   &dto.ExportUsersInput{Limit: 2},
+			want: &dto.ExportUsersOutput{
+				Users: []dto.User{
+					{UserID: "TEST_USER_ID_1", Name: "TEST_USER_NAME_1", Email: "TEST_USER_EMAIL_1", Status: "ACTIVE"},
+					{UserID: "TEST_USER_ID_2", Name: "TEST_USER_NAME_2", Email: "TEST_USER_EMAIL_2", Status: "ACTIVE"},
+				},
+				NextAfterUserID: "TEST_USER_ID_2",
+			},
+		},
+		{
+			name: "Returns the last chunk without the cursor",
+			in:   &dto.ExportUsersInput{AfterUserID: "TEST_USER_ID_2", Limit: 2},
+			want: &dto.ExportUsersOutput{
+				Users: []dto.User{
+					{UserID: "TEST_USER_ID_3", Name: "TEST_USER_NAME_3", Email: "TEST_USER_EMAIL_3", Status: "ACTIVE"},
+				},
+			},
+		},
+		{
+			name: "Returns all the users in a chunk of the max limit",
+			in:   &dto.ExportUsersInput{Limit: usecase.MaxExportLimit + 1},
+			want: &dto.ExportUsersOutput{
+				Users: []dto.User{
+					{UserID: "TEST_USER_ID_1", Name: "TEST_USER_NAME_1", Email: "TEST_USER_EMAIL_1", Status: "ACTIVE"},
+					{UserID: "TEST_USER_ID_2", Name: "TEST_USER_NAME_2", Email: "TEST_USER_EMAIL_2", Status: "ACTIVE"},
+					{UserID: "TEST_USER_ID_3", Name: "TEST_USER_NAME_3", Email: "TEST_USER_EMAIL_3", Status: "ACTIVE"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := memory.NewStore()
+			s.AddUsers(
+				model.MustNewUser("TEST_USER_ID_3", "TEST_USER_NAME_3", "TEST_USER_EMAIL_3"),
+				model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+				model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+			)
+			r := memory.NewMemoryRepository(s)
+			us := domainservice.NewUserService(r)
+			gs := domainservice.NewGroupService(r)
+			uc := usecase.NewUserUsecase(
+				r, mockfactory.NewMockUserFactory(ctrl), us, gs,
+				factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+				factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+				factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+				model.DefaultPolicy(),
+				testClock,
+			)
+
+			got, err := uc.ExportUsers(tt.in)
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(
+					"uc.ExportUsers(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+					tt.in, got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
+
+func TestGroupUsecase_ExportGroups(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *dto.ExportGroupsInput
+		want *dto.ExportGroupsOutput
+	}{
+		{
+			name: "Returns the first chunk with the cursor of the next chunk",
+			in:   &dto.ExportGroupsInput{Limit: 1},
+			want: &dto.ExportGroupsOutput{
+				Groups: []dto.Group{
+					{
+						GroupID:    "TEST_GROUP_ID_1",
+						Name:       "TEST_GROUP_NAME_1",
+						JoinPolicy: "OPEN",
+						Users: []dto.User{
+							{UserID: "TEST_USER_ID_1", Name: "TEST_USER_NAME_1", Email: "TEST_USER_EMAIL_1", Status: "ACTIVE"},
+						},
+					},
+				},
+				NextAfterGroupID: "TEST_GROUP_ID_1",
+			},
+		},
+		{
+			name: "Returns the last chunk without the cursor",
+			in:   &dto.ExportGroupsInput{AfterGroupID: "TEST_GROUP_ID_1", Limit: 2},
+			want: &dto.ExportGroupsOutput{
+				Groups: []dto.Group{
+					{
+						GroupID:    "TEST_GROUP_ID_2",
+						Name:       "TEST_GROUP_NAME_2",
+						JoinPolicy: "OPEN",
+						Users:      []dto.User{},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := memory.NewStore()
+			s.AddUsers(model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"))
+			s.AddGroups(
+				model.MustNewGroup("TEST_GROUP_ID_2", "TEST_GROUP_NAME_2", []model.UserID{}),
+				model.MustNewGroup("TEST_GROUP_ID_1", "TEST_GROUP_NAME_1", []model.UserID{"TEST_USER_ID_1"}),
+			)
+			r := memory.NewMemoryRepository(s)
+			gs := domainservice.NewGroupService(r)
+			us := domainservice.NewUserService(r)
+			uc := usecase.NewGroupUsecase(
+				r, mockfactory.NewMockGroupFactory(ctrl), gs, us,
+				factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+				factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+				factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+				model.DefaultPolicy(),
+				testClock,
+			)
+
+			got, err := uc.ExportGroups(tt.in)
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(
+					"uc.ExportGroups(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+					tt.in, got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
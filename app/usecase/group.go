@@ -3,6 +3,9 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
 
 	"github.com/labstack/gommon/log"
 
@@ -19,6 +22,19 @@ type GroupUsecase interface {
 	GetGroups(in *dto.GetGroupsInput) (*dto.GetGroupsOutput, error)
 	UpdateGroup(in *dto.UpdateGroupInput) (*dto.UpdateGroupOutput, error)
 	DeleteGroup(in *dto.DeleteGroupInput) (*dto.DeleteGroupOutput, error)
+	AddGroupUsers(in *dto.AddGroupUsersInput) (*dto.AddGroupUsersOutput, error)
+	RemoveGroupUsers(in *dto.RemoveGroupUsersInput) (*dto.RemoveGroupUsersOutput, error)
+	PatchGroup(in *dto.PatchGroupInput) (*dto.PatchGroupOutput, error)
+	PutGroupLabels(in *dto.PutGroupLabelsInput) (*dto.PutGroupLabelsOutput, error)
+	ExportGroups(in *dto.ExportGroupsInput) (*dto.ExportGroupsOutput, error)
+	GetGroupWaitlist(in *dto.GetGroupWaitlistInput) (*dto.GetGroupWaitlistOutput, error)
+	ReorderGroupWaitlist(in *dto.ReorderGroupWaitlistInput) (*dto.ReorderGroupWaitlistOutput, error)
+	LeaveGroupWaitlist(in *dto.LeaveGroupWaitlistInput) (*dto.LeaveGroupWaitlistOutput, error)
+	AddSubgroup(in *dto.AddSubgroupInput) (*dto.AddSubgroupOutput, error)
+	RemoveSubgroup(in *dto.RemoveSubgroupInput) (*dto.RemoveSubgroupOutput, error)
+	GetSubgroups(in *dto.GetSubgroupsInput) (*dto.GetSubgroupsOutput, error)
+	GetGroupMembers(in *dto.GetGroupMembersInput) (*dto.GetGroupMembersOutput, error)
+	GetUserGroups(in *dto.GetUserGroupsInput) (*dto.GetUserGroupsOutput, error)
 }
 
 type groupUsecase struct {
@@ -26,6 +42,11 @@ type groupUsecase struct {
 	f  factory.GroupFactory
 	gs domainservice.GroupService
 	us domainservice.UserService
+	af factory.AuditEventFactory
+	of factory.OutboxMessageFactory
+	wf factory.WebhookDeliveryFactory
+	p  model.Policy
+	c  Clock
 }
 
 func NewGroupUsecase(
@@ -33,8 +54,13 @@ func NewGroupUsecase(
 	f factory.GroupFactory,
 	gs domainservice.GroupService,
 	us domainservice.UserService,
+	af factory.AuditEventFactory,
+	of factory.OutboxMessageFactory,
+	wf factory.WebhookDeliveryFactory,
+	p model.Policy,
+	c Clock,
 ) GroupUsecase {
-	return &groupUsecase{r: r, f: f, gs: gs, us: us}
+	return &groupUsecase{r: r, f: f, gs: gs, us: us, af: af, of: of, wf: wf, p: p, c: c}
 }
 
 func (uc *groupUsecase) CreateGroup(in *dto.CreateGroupInput) (*dto.CreateGroupOutput, error) {
@@ -45,6 +71,17 @@ func (uc *groupUsecase) CreateGroup(in *dto.CreateGroupInput) (*dto.CreateGroupO
 		}
 		return nil, err
 	}
+	if in.JoinPolicy != "" {
+		if err := g.ChangeJoinPolicy(model.GroupJoinPolicy(in.JoinPolicy)); err != nil {
+			return nil, errors.Join(ErrInvalidGroupInput, err)
+		}
+	}
+	g.ChangeAttributes(in.Attributes)
+	if err := validateAttributes(uc.r, model.AttributeTargetGroup, g.Attributes(), ErrInvalidGroupInput); err != nil {
+		return nil, err
+	}
+	at := now(uc.c)
+	g.ChangeTimestamps(model.NewTimestamps(in.Actor, at))
 
 	uIDs := g.UserIDs()
 	if len(uIDs) > 0 {
@@ -55,6 +92,27 @@ func (uc *groupUsecase) CreateGroup(in *dto.CreateGroupInput) (*dto.CreateGroupO
 		if !ok {
 			return nil, ErrInvalidUserIDs
 		}
+		if err := uc.checkUsersCanJoin(uIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	e, err := uc.af.Create(
+		in.Actor,
+		in.RequestID,
+		model.AuditActionCreateGroup,
+		model.NewGroupAuditTarget(g.ID()),
+		model.DiffGroup(nil, g),
+		at,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	g.RecordCreated()
+	ms, err := uc.of.Create(pullEvents(g), at)
+	if err != nil {
+		return nil, err
 	}
 
 	var created *model.Group
@@ -62,6 +120,15 @@ func (uc *groupUsecase) CreateGroup(in *dto.CreateGroupInput) (*dto.CreateGroupO
 		if created, err = tx.Group().Create(g); err != nil {
 			return err
 		}
+		if _, err := tx.AuditEvent().Create(e); err != nil {
+			return err
+		}
+		if err := tx.OutboxMessage().Create(ms); err != nil {
+			return err
+		}
+		if err := enqueueWebhookDeliveries(tx, uc.wf, ms); err != nil {
+			return err
+		}
 		return nil
 	}); err != nil {
 		return nil, err
@@ -79,9 +146,13 @@ func (uc *groupUsecase) CreateGroup(in *dto.CreateGroupInput) (*dto.CreateGroupO
 
 	return &dto.CreateGroupOutput{
 		Group: dto.Group{
-			GroupID: string(created.ID()),
-			Name:    created.Name(),
-			Users:   dto.ToUsersFromModel(us),
+			GroupID:    string(created.ID()),
+			Name:       created.Name(),
+			JoinPolicy: string(created.JoinPolicy()),
+			Attributes: created.Attributes(),
+			Labels:     created.Labels(),
+			Users:      dto.ToUsersFromModel(us),
+			Timestamps: dto.ToTimestampsFromModel(created.Timestamps()),
 		},
 	}, nil
 }
@@ -108,38 +179,98 @@ func (uc *groupUsecase) GetGroup(in *dto.GetGroupInput) (*dto.GetGroupOutput, er
 
 	return &dto.GetGroupOutput{
 		Group: dto.Group{
-			GroupID: string(g.ID()),
-			Name:    g.Name(),
-			Users:   dto.ToUsersFromModel(us),
+			GroupID:    string(g.ID()),
+			Name:       g.Name(),
+			JoinPolicy: string(g.JoinPolicy()),
+			Attributes: g.Attributes(),
+			Labels:     g.Labels(),
+			Users:      dto.ToUsersFromModel(us),
+			Timestamps: dto.ToTimestampsFromModel(g.Timestamps()),
 		},
 	}, nil
 }
 
-func (uc *groupUsecase) GetGroups(_ *dto.GetGroupsInput) (*dto.GetGroupsOutput, error) {
-	gs, err := uc.r.Group().List(repository.GroupListFilter{})
+func (uc *groupUsecase) GetGroups(in *dto.GetGroupsInput) (*dto.GetGroupsOutput, error) {
+	var f repository.GroupListFilter
+	if in != nil {
+		f.UserIDs = dto.ToModelUserIDs(in.UserIDs)
+		f.Query = model.NormalizeSearchQuery(in.Query)
+		f.AllUserIDs = dto.ToModelUserIDs(in.AllUserIDs)
+		f.NoUsers = in.NoUsers
+		if in.Full {
+			// The capacity of a group depends on its scope, so the storage keeps the groups at the smallest one and
+			// the groups below their own are dropped after listing.
+			f.MinUsers = uc.p.MinGroupUsers()
+		}
+		f.Attributes = in.Attributes
+		sel, err := model.ParseLabelSelector(in.LabelSelector)
+		if err != nil {
+			return nil, errors.Join(ErrInvalidGroupInput, err)
+		}
+		f.LabelSelector = sel
+		order, err := model.ParseSortOrder(in.Sort)
+		if err != nil {
+			return nil, errors.Join(ErrInvalidGroupInput, err)
+		}
+		f.Sort = order
+	}
+	if utf8.RuneCountInString(f.Query) > model.MaxSearchQueryLength {
+		return nil, fmt.Errorf("query must be at most %d characters: %w", model.MaxSearchQueryLength, ErrInvalidGroupInput)
+	}
+	if err := validateAttributeFilter(uc.r, model.AttributeTargetGroup, f.Attributes, ErrInvalidGroupInput); err != nil {
+		return nil, err
+	}
+
+	gs, err := uc.r.Group().List(f)
 	if err != nil {
 		return nil, err
 	}
+	if f.MinUsers > 0 {
+		full := make(model.Groups, 0, len(gs))
+		for _, g := range gs {
+			if uc.p.GroupPolicyFor(g).IsFull(g) {
+				full = append(full, g)
+			}
+		}
+		gs = full
+	}
 	if len(gs) == 0 {
 		return &dto.GetGroupsOutput{
 			Groups: []dto.Group{},
 		}, nil
 	}
 
+	dtogs, err := uc.toDTOGroups(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &dto.GetGroupsOutput{
+		Groups: dtogs,
+	}
+	if f.Query != "" {
+		out.Highlights = dto.ToGroupHighlightsFromModel(f.Query, gs)
+	}
+	return out, nil
+}
+
+// toDTOGroups converts the groups with their users, which are loaded at once.
+func (uc *groupUsecase) toDTOGroups(gs model.Groups) ([]dto.Group, error) {
 	uIDs := gs.UserIDs()
 	if len(uIDs) == 0 {
 		dtogs := make([]dto.Group, len(gs))
 		for i, g := range gs {
 			dtogs[i] = dto.Group{
-				GroupID: string(g.ID()),
-				Name:    g.Name(),
-				Users:   []dto.User{},
+				GroupID:    string(g.ID()),
+				Name:       g.Name(),
+				JoinPolicy: string(g.JoinPolicy()),
+				Attributes: g.Attributes(),
+				Labels:     g.Labels(),
+				Users:      []dto.User{},
+				Timestamps: dto.ToTimestampsFromModel(g.Timestamps()),
 			}
 		}
-
-		return &dto.GetGroupsOutput{
-			Groups: dtogs,
-		}, nil
+		return dtogs, nil
 	}
 
 	us, err := uc.r.User().List(repository.UserListFilter{
@@ -160,15 +291,16 @@ func (uc *groupUsecase) GetGroups(_ *dto.GetGroupsInput) (*dto.GetGroupsOutput,
 			}
 		}
 		dtogs[i] = dto.Group{
-			GroupID: string(g.ID()),
-			Name:    g.Name(),
-			Users:   dto.ToUsersFromModel(gus),
+			GroupID:    string(g.ID()),
+			Name:       g.Name(),
+			JoinPolicy: string(g.JoinPolicy()),
+			Attributes: g.Attributes(),
+			Labels:     g.Labels(),
+			Users:      dto.ToUsersFromModel(gus),
+			Timestamps: dto.ToTimestampsFromModel(g.Timestamps()),
 		}
 	}
-
-	return &dto.GetGroupsOutput{
-		Groups: dtogs,
-	}, nil
+	return dtogs, nil
 }
 
 func (uc *groupUsecase) UpdateGroup(in *dto.UpdateGroupInput) (*dto.UpdateGroupOutput, error) {
@@ -177,16 +309,73 @@ func (uc *groupUsecase) UpdateGroup(in *dto.UpdateGroupInput) (*dto.UpdateGroupO
 		return nil, errors.Join(ErrInvalidGroupInput, err)
 	}
 
-	isExisted, err := uc.gs.Exists(g.ID())
+	before, err := uc.r.Group().Find(g.ID())
 	if err != nil {
 		return nil, err
 	}
-	if !isExisted {
+	if before == nil {
 		return nil, ErrGroupNotFound
 	}
+	if err := uc.p.GroupPolicyFor(before).ValidateName(g.Name()); err != nil {
+		return nil, errors.Join(ErrInvalidGroupInput, err)
+	}
+
+	// The update only renames the group and changes its join policy and its attributes, so keep its current members
+	// and its labels.
+	after, err := model.NewGroup(g.ID(), g.Name(), before.UserIDs())
+	if err != nil {
+		return nil, errors.Join(ErrInvalidGroupInput, err)
+	}
+	joinPolicy := before.JoinPolicy()
+	if in.JoinPolicy != "" {
+		joinPolicy = model.GroupJoinPolicy(in.JoinPolicy)
+	}
+	if err := after.ChangeJoinPolicy(joinPolicy); err != nil {
+		return nil, errors.Join(ErrInvalidGroupInput, err)
+	}
+	attrs := before.Attributes()
+	if in.Attributes != nil {
+		attrs = in.Attributes
+	}
+	after.ChangeAttributes(attrs)
+	if err := validateAttributes(uc.r, model.AttributeTargetGroup, after.Attributes(), ErrInvalidGroupInput); err != nil {
+		return nil, err
+	}
+	if err := after.ChangeLabels(before.Labels()); err != nil {
+		return nil, err
+	}
+	at := now(uc.c)
+	after.ChangeTimestamps(before.Timestamps().Updated(in.Actor, at))
+
+	e, err := uc.af.Create(
+		in.Actor,
+		in.RequestID,
+		model.AuditActionUpdateGroup,
+		model.NewGroupAuditTarget(g.ID()),
+		model.DiffGroup(before, after),
+		at,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	after.RecordUpdated()
+	ms, err := uc.of.Create(pullEvents(after), at)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
-		if err := tx.Group().Update(g); err != nil {
+		if err := tx.Group().Update(after); err != nil {
+			return err
+		}
+		if _, err := tx.AuditEvent().Create(e); err != nil {
+			return err
+		}
+		if err := tx.OutboxMessage().Create(ms); err != nil {
+			return err
+		}
+		if err := enqueueWebhookDeliveries(tx, uc.wf, ms); err != nil {
 			return err
 		}
 		return nil
@@ -208,10 +397,50 @@ func (uc *groupUsecase) DeleteGroup(in *dto.DeleteGroupInput) (*dto.DeleteGroupO
 		return nil, ErrGroupNotFound
 	}
 
+	at := now(uc.c)
+	e, err := uc.af.Create(
+		in.Actor,
+		in.RequestID,
+		model.AuditActionDeleteGroup,
+		model.NewGroupAuditTarget(gID),
+		model.DiffGroup(g, nil),
+		at,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	g.RecordDeleted()
+	ms, err := uc.of.Create(pullEvents(g), at)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		if err := tx.GroupWaitlist().Delete(gID); err != nil {
+			return err
+		}
+		if err := tx.GroupInvitation().DeleteByGroupID(gID); err != nil {
+			return err
+		}
+		if err := tx.GroupJoinRequest().DeleteByGroupID(gID); err != nil {
+			return err
+		}
+		if err := tx.Subgroup().DeleteByGroupID(gID); err != nil {
+			return err
+		}
 		if err := tx.Group().Delete(g); err != nil {
 			return err
 		}
+		if _, err := tx.AuditEvent().Create(e); err != nil {
+			return err
+		}
+		if err := tx.OutboxMessage().Create(ms); err != nil {
+			return err
+		}
+		if err := enqueueWebhookDeliveries(tx, uc.wf, ms); err != nil {
+			return err
+		}
 		return nil
 	}); err != nil {
 		return nil, err
@@ -219,3 +448,330 @@ func (uc *groupUsecase) DeleteGroup(in *dto.DeleteGroupInput) (*dto.DeleteGroupO
 
 	return &dto.DeleteGroupOutput{}, nil
 }
+
+func (uc *groupUsecase) AddGroupUsers(in *dto.AddGroupUsersInput) (*dto.AddGroupUsersOutput, error) {
+	uIDs := dto.ToModelUserIDs(in.UserIDs)
+	if len(uIDs) == 0 {
+		return nil, ErrInvalidUserIDs
+	}
+
+	out := &dto.AddGroupUsersOutput{}
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		before, err := findGroupForUpdate(tx, model.GroupID(in.GroupID))
+		if err != nil {
+			return err
+		}
+
+		ok, err := uc.us.ExistsAll(uIDs)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrInvalidUserIDs
+		}
+		if err := uc.checkUsersCanJoin(subtractUserIDs(uIDs, before.UserIDs())); err != nil {
+			return err
+		}
+
+		w, err := tx.GroupWaitlist().Find(before.ID())
+		if err != nil {
+			return err
+		}
+
+		after, err := copyGroup(before)
+		if err != nil {
+			return err
+		}
+		waitlisted, err := after.AddUsersOrWaitlist(uc.p.GroupPolicyFor(after), w, uIDs)
+		if err != nil {
+			return errors.Join(ErrInvalidGroupInput, err)
+		}
+		out.WaitlistedUserIDs = dto.ToUserIDsFromModel(waitlisted)
+
+		added := subtractUserIDs(after.UserIDs(), before.UserIDs())
+		if len(added) == 0 {
+			if len(waitlisted) == 0 {
+				// All the users already belong to the group or are waitlisted.
+				return nil
+			}
+			return tx.GroupWaitlist().Save(w)
+		}
+
+		if err := tx.Group().AddUsers(after.ID(), added); err != nil {
+			return err
+		}
+		if err := tx.GroupWaitlist().Save(w); err != nil {
+			return err
+		}
+		return uc.changeGroupIn(tx, in.Meta, before, after)
+	}); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (uc *groupUsecase) RemoveGroupUsers(in *dto.RemoveGroupUsersInput) (*dto.RemoveGroupUsersOutput, error) {
+	uIDs := dto.ToModelUserIDs(in.UserIDs)
+	if len(uIDs) == 0 {
+		return nil, ErrInvalidUserIDs
+	}
+
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		before, err := findGroupForUpdate(tx, model.GroupID(in.GroupID))
+		if err != nil {
+			return err
+		}
+
+		after, err := copyGroup(before)
+		if err != nil {
+			return err
+		}
+		after.RemoveUsers(uIDs)
+
+		removed := subtractUserIDs(before.UserIDs(), after.UserIDs())
+		if len(removed) == 0 {
+			// None of the users belong to the group.
+			return nil
+		}
+
+		w, err := tx.GroupWaitlist().Find(after.ID())
+		if err != nil {
+			return err
+		}
+		promoted, err := after.PromoteWaitlisted(uc.p.GroupPolicyFor(after), w)
+		if err != nil {
+			return errors.Join(ErrInvalidGroupInput, err)
+		}
+
+		if err := tx.Group().RemoveUsers(after.ID(), removed); err != nil {
+			return err
+		}
+		if err := promoteWaitlisted(tx, after.ID(), promoted, w); err != nil {
+			return err
+		}
+		return uc.changeGroupIn(tx, in.Meta, before, after)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.RemoveGroupUsersOutput{}, nil
+}
+
+// PatchGroup applies the patch to the name, the join policy, the attributes and the members of the group.
+// The members are added and removed as AddGroupUsers and RemoveGroupUsers do.
+func (uc *groupUsecase) PatchGroup(in *dto.PatchGroupInput) (*dto.PatchGroupOutput, error) {
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		before, err := findGroupForUpdate(tx, model.GroupID(in.GroupID))
+		if err != nil {
+			return err
+		}
+
+		doc, err := applyPatch(in.PatchType, in.Patch, groupDocument{
+			Name:       before.Name(),
+			JoinPolicy: string(before.JoinPolicy()),
+			UserIDs:    dto.ToUserIDsFromModel(before.UserIDs()),
+			Attributes: attributesDocument(before.Attributes()),
+		})
+		if err != nil {
+			return err
+		}
+
+		after, err := model.NewGroup(before.ID(), doc.Name, append([]model.UserID{}, before.UserIDs()...))
+		if err != nil {
+			return errors.Join(ErrInvalidGroupInput, err)
+		}
+		if err := after.ChangeJoinPolicy(model.GroupJoinPolicy(doc.JoinPolicy)); err != nil {
+			return errors.Join(ErrInvalidGroupInput, err)
+		}
+		if err := uc.p.GroupPolicyFor(before).ValidateName(after.Name()); err != nil {
+			return errors.Join(ErrInvalidGroupInput, err)
+		}
+		after.ChangeAttributes(doc.Attributes)
+		if err := validateAttributes(uc.r, model.AttributeTargetGroup, after.Attributes(), ErrInvalidGroupInput); err != nil {
+			return err
+		}
+		if err := after.ChangeLabels(before.Labels()); err != nil {
+			return err
+		}
+
+		w, err := tx.GroupWaitlist().Find(after.ID())
+		if err != nil {
+			return err
+		}
+
+		// The waitlisted users are promoted to the room made by the removed members before the new members are
+		// added, who are waitlisted after them if the group is at capacity.
+		uIDs := dto.ToModelUserIDs(doc.UserIDs)
+		after.RemoveUsers(subtractUserIDs(before.UserIDs(), uIDs))
+		if _, err := after.PromoteWaitlisted(uc.p.GroupPolicyFor(after), w); err != nil {
+			return errors.Join(ErrInvalidGroupInput, err)
+		}
+		var waitlisted []model.UserID
+		if added := subtractUserIDs(uIDs, after.UserIDs()); len(added) > 0 {
+			ok, err := uc.us.ExistsAll(added)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return ErrInvalidUserIDs
+			}
+			if err := uc.checkUsersCanJoin(added); err != nil {
+				return err
+			}
+			if waitlisted, err = after.AddUsersOrWaitlist(uc.p.GroupPolicyFor(after), w, added); err != nil {
+				return errors.Join(ErrInvalidGroupInput, err)
+			}
+		}
+
+		updated := after.Name() != before.Name() || after.JoinPolicy() != before.JoinPolicy() ||
+			after.Attributes().String() != before.Attributes().String()
+		added := subtractUserIDs(after.UserIDs(), before.UserIDs())
+		removed := subtractUserIDs(before.UserIDs(), after.UserIDs())
+		if !updated && len(added) == 0 && len(removed) == 0 {
+			if len(waitlisted) == 0 {
+				return nil
+			}
+			return tx.GroupWaitlist().Save(w)
+		}
+		if updated {
+			after.RecordUpdated()
+		}
+
+		if len(removed) > 0 {
+			if err := tx.Group().RemoveUsers(after.ID(), removed); err != nil {
+				return err
+			}
+		}
+		if len(added) > 0 {
+			if err := tx.Group().AddUsers(after.ID(), added); err != nil {
+				return err
+			}
+		}
+		if err := tx.GroupWaitlist().Save(w); err != nil {
+			return err
+		}
+		return uc.changeGroupIn(tx, in.Meta, before, after)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.PatchGroupOutput{}, nil
+}
+
+// changeGroupIn stores the change of the group in the transaction with its audit event and domain events.
+func (uc *groupUsecase) changeGroupIn(tx repository.Transaction, meta dto.Meta, before, after *model.Group) error {
+	return storeGroupChangeIn(tx, uc.af, uc.of, uc.wf, now(uc.c), meta, before, after)
+}
+
+// storeGroupChangeIn updates the group in the transaction, stamped as updated by the actor at the time,
+// with its audit event and domain events.
+func storeGroupChangeIn(
+	tx repository.Transaction,
+	af factory.AuditEventFactory,
+	of factory.OutboxMessageFactory,
+	wf factory.WebhookDeliveryFactory,
+	at time.Time,
+	meta dto.Meta,
+	before, after *model.Group,
+) error {
+	after.ChangeTimestamps(before.Timestamps().Updated(meta.Actor, at))
+
+	e, err := af.Create(
+		meta.Actor,
+		meta.RequestID,
+		model.AuditActionUpdateGroup,
+		model.NewGroupAuditTarget(after.ID()),
+		model.DiffGroup(before, after),
+		at,
+	)
+	if err != nil {
+		return err
+	}
+
+	ms, err := of.Create(pullEvents(after), at)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Group().Update(after); err != nil {
+		return err
+	}
+	if _, err := tx.AuditEvent().Create(e); err != nil {
+		return err
+	}
+	if err := tx.OutboxMessage().Create(ms); err != nil {
+		return err
+	}
+	return enqueueWebhookDeliveries(tx, wf, ms)
+}
+
+// findGroupForUpdate finds the group locked until the transaction ends, which must exist.
+func findGroupForUpdate(tx repository.Transaction, gID model.GroupID) (*model.Group, error) {
+	g, err := tx.Group().FindForUpdate(gID)
+	if err != nil {
+		return nil, err
+	}
+	if g == nil {
+		return nil, ErrGroupNotFound
+	}
+	return g, nil
+}
+
+// promoteWaitlisted stores the users promoted from the waitlist to the members of the group, and the rest of it.
+func promoteWaitlisted(tx repository.Transaction, gID model.GroupID, promoted []model.UserID, w *model.GroupWaitlist) error {
+	if len(promoted) == 0 {
+		return nil
+	}
+	if err := tx.Group().AddUsers(gID, promoted); err != nil {
+		return err
+	}
+	return tx.GroupWaitlist().Save(w)
+}
+
+// checkUsersCanJoin returns ErrUserInactive unless all the users are in a status which can join groups.
+func (uc *groupUsecase) checkUsersCanJoin(uIDs []model.UserID) error {
+	ok, err := uc.us.CanAllJoinGroups(uIDs)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrUserInactive
+	}
+	return nil
+}
+
+// copyGroup copies the group so that changing the copy never affects the found one.
+func copyGroup(g *model.Group) (*model.Group, error) {
+	c, err := model.NewGroup(g.ID(), g.Name(), append([]model.UserID{}, g.UserIDs()...))
+	if err != nil {
+		return nil, err
+	}
+	if err := c.ChangeJoinPolicy(g.JoinPolicy()); err != nil {
+		return nil, err
+	}
+	c.ChangeAttributes(g.Attributes())
+	if err := c.ChangeLabels(g.Labels()); err != nil {
+		return nil, err
+	}
+	c.ChangeTimestamps(g.Timestamps())
+	return c, nil
+}
+
+// subtractUserIDs returns the user ids in a which are not in b.
+func subtractUserIDs(a, b []model.UserID) []model.UserID {
+	var result []model.UserID
+	for _, uID := range a {
+		found := false
+		for _, v := range b {
+			if v == uID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, uID)
+		}
+	}
+	return result
+}
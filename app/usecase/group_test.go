@@ -2,6 +2,8 @@ package usecase_test
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -34,9 +36,11 @@ func TestGroupUsecase_CreateGroup(t *testing.T) {
 			},
 			want: &dto.CreateGroupOutput{
 				Group: dto.Group{
-					GroupID: "TEST_GROUP_ID",
-					Name:    "TEST_GROUP_NAME",
-					Users:   []dto.User{},
+					GroupID:    "TEST_GROUP_ID",
+					Name:       "TEST_GROUP_NAME",
+					JoinPolicy: "OPEN",
+					Users:      []dto.User{},
+					Timestamps: dto.Timestamps{CreatedAt: testNow, UpdatedAt: testNow},
 				},
 			},
 			wantErr: nil,
@@ -65,25 +69,30 @@ func TestGroupUsecase_CreateGroup(t *testing.T) {
 			},
 			want: &dto.CreateGroupOutput{
 				Group: dto.Group{
-					GroupID: "TEST_GROUP_ID",
-					Name:    "TEST_GROUP_NAME",
+					GroupID:    "TEST_GROUP_ID",
+					Name:       "TEST_GROUP_NAME",
+					JoinPolicy: "OPEN",
 					Users: []dto.User{
 						{
 							UserID: "TEST_USER_ID_1",
 							Name:   "TEST_USER_NAME_1",
 							Email:  "TEST_USER_EMAIL_1",
+							Status: "ACTIVE",
 						},
 						{
 							UserID: "TEST_USER_ID_2",
 							Name:   "TEST_USER_NAME_2",
 							Email:  "TEST_USER_EMAIL_2",
+							Status: "ACTIVE",
 						},
 						{
 							UserID: "TEST_USER_ID_3",
 							Name:   "TEST_USER_NAME_3",
 							Email:  "TEST_USER_EMAIL_3",
+							Status: "ACTIVE",
 						},
 					},
+					Timestamps: dto.Timestamps{CreatedAt: testNow, UpdatedAt: testNow},
 				},
 			},
 			wantErr: nil,
@@ -181,7 +190,14 @@ func TestGroupUsecase_CreateGroup(t *testing.T) {
 			r := tt.newMemoryRepository()
 			gs := domainservice.NewGroupService(r)
 			us := domainservice.NewUserService(r)
-			uc := usecase.NewGroupUsecase(r, tt.newMockFactory(ctrl), gs, us)
+			uc := usecase.NewGroupUsecase(
+				r, tt.newMockFactory(ctrl), gs, us,
+				factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+				factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+				factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+				model.DefaultPolicy(),
+				testClock,
+			)
 
 			got, err := uc.CreateGroup(tt.in)
 			if tt.wantErr != nil {
@@ -204,6 +220,12 @@ func TestGroupUsecase_CreateGroup(t *testing.T) {
 						tt.in, got, tt.want, diff,
 					)
 				}
+				assertAuditEvent(
+					t, r, tt.in.Meta,
+					model.AuditActionCreateGroup,
+					model.NewGroupAuditTarget(model.GroupID(got.Group.GroupID)),
+				)
+				assertOutboxMessages(t, r, model.DomainEventTypeGroupCreated)
 			}
 		})
 	}
@@ -224,23 +246,27 @@ func TestGroupUsecase_GetGroup(t *testing.T) {
 			},
 			want: &dto.GetGroupOutput{
 				Group: dto.Group{
-					GroupID: "TEST_GROUP_ID",
-					Name:    "TEST_GROUP_NAME",
+					GroupID:    "TEST_GROUP_ID",
+					Name:       "TEST_GROUP_NAME",
+					JoinPolicy: "OPEN",
 					Users: []dto.User{
 						{
 							UserID: "TEST_USER_ID_1",
 							Name:   "TEST_USER_NAME_1",
 							Email:  "TEST_USER_EMAIL_1",
+							Status: "ACTIVE",
 						},
 						{
 							UserID: "TEST_USER_ID_2",
 							Name:   "TEST_USER_NAME_2",
 							Email:  "TEST_USER_EMAIL_2",
+							Status: "ACTIVE",
 						},
 						{
 							UserID: "TEST_USER_ID_3",
 							Name:   "TEST_USER_NAME_3",
 							Email:  "TEST_USER_EMAIL_3",
+							Status: "ACTIVE",
 						},
 					},
 				},
@@ -289,7 +315,14 @@ func TestGroupUsecase_GetGroup(t *testing.T) {
 			r := tt.newMemoryRepository()
 			gs := domainservice.NewGroupService(r)
 			us := domainservice.NewUserService(r)
-			uc := usecase.NewGroupUsecase(r, mockfactory.NewMockGroupFactory(ctrl), gs, us)
+			uc := usecase.NewGroupUsecase(
+				r, mockfactory.NewMockGroupFactory(ctrl), gs, us,
+				factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+				factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+				factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+				model.DefaultPolicy(),
+				testClock,
+			)
 
 			got, err := uc.GetGroup(tt.in)
 			if tt.wantErr != nil {
@@ -321,6 +354,7 @@ func TestGroupUsecase_GetGroup(t *testing.T) {
 func TestGroupUsecase_GetGroups(t *testing.T) {
 	tests := []struct {
 		name                string
+		in                  *dto.GetGroupsInput
 		want                *dto.GetGroupsOutput
 		wantErr             error
 		newMemoryRepository func() repository.Repository
@@ -330,50 +364,59 @@ func TestGroupUsecase_GetGroups(t *testing.T) {
 			want: &dto.GetGroupsOutput{
 				Groups: []dto.Group{
 					{
-						GroupID: "TEST_GROUP_ID_1",
-						Name:    "TEST_GROUP_NAME_1",
+						GroupID:    "TEST_GROUP_ID_1",
+						Name:       "TEST_GROUP_NAME_1",
+						JoinPolicy: "OPEN",
 						Users: []dto.User{
 							{
 								UserID: "TEST_USER_ID_1",
 								Name:   "TEST_USER_NAME_1",
 								Email:  "TEST_USER_EMAIL_1",
+								Status: "ACTIVE",
 							},
 						},
 					},
 					{
-						GroupID: "TEST_GROUP_ID_2",
-						Name:    "TEST_GROUP_NAME_2",
+						GroupID:    "TEST_GROUP_ID_2",
+						Name:       "TEST_GROUP_NAME_2",
+						JoinPolicy: "OPEN",
 						Users: []dto.User{
 							{
 								UserID: "TEST_USER_ID_1",
 								Name:   "TEST_USER_NAME_1",
 								Email:  "TEST_USER_EMAIL_1",
+								Status: "ACTIVE",
 							},
 							{
 								UserID: "TEST_USER_ID_2",
 								Name:   "TEST_USER_NAME_2",
 								Email:  "TEST_USER_EMAIL_2",
+								Status: "ACTIVE",
 							},
 						},
 					},
 					{
-						GroupID: "TEST_GROUP_ID_3",
-						Name:    "TEST_GROUP_NAME_3",
+						GroupID:    "TEST_GROUP_ID_3",
+						Name:       "TEST_GROUP_NAME_3",
+						JoinPolicy: "OPEN",
 						Users: []dto.User{
 							{
 								UserID: "TEST_USER_ID_1",
 								Name:   "TEST_USER_NAME_1",
 								Email:  "TEST_USER_EMAIL_1",
+								Status: "ACTIVE",
 							},
 							{
 								UserID: "TEST_USER_ID_2",
 								Name:   "TEST_USER_NAME_2",
 								Email:  "TEST_USER_EMAIL_2",
+								Status: "ACTIVE",
 							},
 							{
 								UserID: "TEST_USER_ID_3",
 								Name:   "TEST_USER_NAME_3",
 								Email:  "TEST_USER_EMAIL_3",
+								Status: "ACTIVE",
 							},
 						},
 					},
@@ -417,6 +460,62 @@ func TestGroupUsecase_GetGroups(t *testing.T) {
 				return r
 			},
 		},
+		{
+			name: "Returns groups the users belong to with all their members",
+			in:   &dto.GetGroupsInput{UserIDs: []string{"TEST_USER_ID_3"}},
+			want: &dto.GetGroupsOutput{
+				Groups: []dto.Group{
+					{
+						GroupID:    "TEST_GROUP_ID_1",
+						Name:       "TEST_GROUP_NAME_1",
+						JoinPolicy: "OPEN",
+						Users: []dto.User{
+							{
+								UserID: "TEST_USER_ID_1",
+								Name:   "TEST_USER_NAME_1",
+								Email:  "TEST_USER_EMAIL_1",
+								Status: "ACTIVE",
+							},
+							{
+								UserID: "TEST_USER_ID_3",
+								Name:   "TEST_USER_NAME_3",
+								Email:  "TEST_USER_EMAIL_3",
+								Status: "ACTIVE",
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddGroups(
+					model.MustNewGroup(
+						"TEST_GROUP_ID_1",
+						"TEST_GROUP_NAME_1",
+						[]model.UserID{
+							"TEST_USER_ID_1",
+							"TEST_USER_ID_3",
+						},
+					),
+					model.MustNewGroup(
+						"TEST_GROUP_ID_2",
+						"TEST_GROUP_NAME_2",
+						[]model.UserID{
+							"TEST_USER_ID_1",
+							"TEST_USER_ID_2",
+						},
+					),
+				)
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+					model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+					model.MustNewUser("TEST_USER_ID_3", "TEST_USER_NAME_3", "TEST_USER_EMAIL_3"),
+				)
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
 		{
 			name: "Returns empty groups if not exist",
 			want: &dto.GetGroupsOutput{
@@ -434,19 +533,22 @@ func TestGroupUsecase_GetGroups(t *testing.T) {
 			want: &dto.GetGroupsOutput{
 				Groups: []dto.Group{
 					{
-						GroupID: "TEST_GROUP_ID_1",
-						Name:    "TEST_GROUP_NAME_1",
-						Users:   []dto.User{},
+						GroupID:    "TEST_GROUP_ID_1",
+						Name:       "TEST_GROUP_NAME_1",
+						JoinPolicy: "OPEN",
+						Users:      []dto.User{},
 					},
 					{
-						GroupID: "TEST_GROUP_ID_2",
-						Name:    "TEST_GROUP_NAME_2",
-						Users:   []dto.User{},
+						GroupID:    "TEST_GROUP_ID_2",
+						Name:       "TEST_GROUP_NAME_2",
+						JoinPolicy: "OPEN",
+						Users:      []dto.User{},
 					},
 					{
-						GroupID: "TEST_GROUP_ID_3",
-						Name:    "TEST_GROUP_NAME_3",
-						Users:   []dto.User{},
+						GroupID:    "TEST_GROUP_ID_3",
+						Name:       "TEST_GROUP_NAME_3",
+						JoinPolicy: "OPEN",
+						Users:      []dto.User{},
 					},
 				},
 			},
@@ -474,6 +576,158 @@ func TestGroupUsecase_GetGroups(t *testing.T) {
 				return r
 			},
 		},
+		{
+			name: "Returns groups all the users belong to",
+			in:   &dto.GetGroupsInput{AllUserIDs: []string{"TEST_USER_ID_1", "TEST_USER_ID_2"}},
+			want: &dto.GetGroupsOutput{
+				Groups: []dto.Group{
+					{
+						GroupID:    "TEST_GROUP_ID_2",
+						Name:       "TEST_GROUP_NAME_2",
+						JoinPolicy: "OPEN",
+						Users: []dto.User{
+							{
+								UserID: "TEST_USER_ID_1",
+								Name:   "TEST_USER_NAME_1",
+								Email:  "TEST_USER_EMAIL_1",
+								Status: "ACTIVE",
+							},
+							{
+								UserID: "TEST_USER_ID_2",
+								Name:   "TEST_USER_NAME_2",
+								Email:  "TEST_USER_EMAIL_2",
+								Status: "ACTIVE",
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddGroups(
+					model.MustNewGroup("TEST_GROUP_ID_1", "TEST_GROUP_NAME_1", []model.UserID{"TEST_USER_ID_1"}),
+					model.MustNewGroup("TEST_GROUP_ID_2", "TEST_GROUP_NAME_2", []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"}),
+				)
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+					model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+				)
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Returns groups with no users",
+			in:   &dto.GetGroupsInput{NoUsers: true},
+			want: &dto.GetGroupsOutput{
+				Groups: []dto.Group{
+					{
+						GroupID:    "TEST_GROUP_ID_2",
+						Name:       "TEST_GROUP_NAME_2",
+						JoinPolicy: "OPEN",
+						Users:      []dto.User{},
+					},
+				},
+			},
+			wantErr: nil,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddGroups(
+					model.MustNewGroup("TEST_GROUP_ID_1", "TEST_GROUP_NAME_1", []model.UserID{"TEST_USER_ID_1"}),
+					model.MustNewGroup("TEST_GROUP_ID_2", "TEST_GROUP_NAME_2", []model.UserID{}),
+				)
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+				)
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Returns groups at capacity",
+			in:   &dto.GetGroupsInput{Full: true},
+			want: &dto.GetGroupsOutput{
+				Groups: []dto.Group{
+					{
+						GroupID:    "TEST_GROUP_ID_2",
+						Name:       "TEST_GROUP_NAME_2",
+						JoinPolicy: "OPEN",
+						Users: []dto.User{
+							{UserID: "TEST_USER_ID_1", Name: "TEST_USER_NAME_1", Email: "TEST_USER_EMAIL_1", Status: "ACTIVE"},
+							{UserID: "TEST_USER_ID_2", Name: "TEST_USER_NAME_2", Email: "TEST_USER_EMAIL_2", Status: "ACTIVE"},
+							{UserID: "TEST_USER_ID_3", Name: "TEST_USER_NAME_3", Email: "TEST_USER_EMAIL_3", Status: "ACTIVE"},
+							{UserID: "TEST_USER_ID_4", Name: "TEST_USER_NAME_4", Email: "TEST_USER_EMAIL_4", Status: "ACTIVE"},
+							{UserID: "TEST_USER_ID_5", Name: "TEST_USER_NAME_5", Email: "TEST_USER_EMAIL_5", Status: "ACTIVE"},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddGroups(
+					model.MustNewGroup("TEST_GROUP_ID_1", "TEST_GROUP_NAME_1", []model.UserID{"TEST_USER_ID_1"}),
+					model.MustNewGroup("TEST_GROUP_ID_2", "TEST_GROUP_NAME_2", []model.UserID{
+						"TEST_USER_ID_1", "TEST_USER_ID_2", "TEST_USER_ID_3", "TEST_USER_ID_4", "TEST_USER_ID_5",
+					}),
+				)
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+					model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+					model.MustNewUser("TEST_USER_ID_3", "TEST_USER_NAME_3", "TEST_USER_EMAIL_3"),
+					model.MustNewUser("TEST_USER_ID_4", "TEST_USER_NAME_4", "TEST_USER_EMAIL_4"),
+					model.MustNewUser("TEST_USER_ID_5", "TEST_USER_NAME_5", "TEST_USER_EMAIL_5"),
+				)
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Returns groups searched by the query with highlights",
+			in:   &dto.GetGroupsInput{Query: "team"},
+			want: &dto.GetGroupsOutput{
+				Groups: []dto.Group{
+					{
+						GroupID:    "TEST_GROUP_ID_2",
+						Name:       "Team A",
+						JoinPolicy: "OPEN",
+						Users:      []dto.User{},
+					},
+					{
+						GroupID:    "TEST_GROUP_ID_1",
+						Name:       "The Team",
+						JoinPolicy: "OPEN",
+						Users:      []dto.User{},
+					},
+				},
+				Highlights: map[string][]dto.Highlight{
+					"TEST_GROUP_ID_1": {{Field: "name", Start: 4, End: 8}},
+					"TEST_GROUP_ID_2": {{Field: "name", Start: 0, End: 4}},
+				},
+			},
+			wantErr: nil,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddGroups(
+					model.MustNewGroup("TEST_GROUP_ID_1", "The Team", []model.UserID{}),
+					model.MustNewGroup("TEST_GROUP_ID_2", "Team A", []model.UserID{}),
+					model.MustNewGroup("TEST_GROUP_ID_3", "Other", []model.UserID{}),
+				)
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Error too long query",
+			in:   &dto.GetGroupsInput{Query: strings.Repeat("a", model.MaxSearchQueryLength+1)},
+			wantErr: fmt.Errorf(
+				"query must be at most %d characters: %w", model.MaxSearchQueryLength, usecase.ErrInvalidGroupInput,
+			),
+			newMemoryRepository: func() repository.Repository {
+				return memory.NewMemoryRepository(memory.NewStore())
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -484,9 +738,16 @@ func TestGroupUsecase_GetGroups(t *testing.T) {
 			r := tt.newMemoryRepository()
 			gs := domainservice.NewGroupService(r)
 			us := domainservice.NewUserService(r)
-			uc := usecase.NewGroupUsecase(r, mockfactory.NewMockGroupFactory(ctrl), gs, us)
+			uc := usecase.NewGroupUsecase(
+				r, mockfactory.NewMockGroupFactory(ctrl), gs, us,
+				factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+				factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+				factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+				model.DefaultPolicy(),
+				testClock,
+			)
 
-			in := &dto.GetGroupsInput{}
+			in := tt.in
 			got, err := uc.GetGroups(in)
 			if tt.wantErr != nil {
 				if err == nil {
@@ -527,10 +788,9 @@ func TestGroupUsecase_UpdateGroup(t *testing.T) {
 				GroupID: "TEST_GROUP_ID",
 				Name:    "TEST_GROUP_NAME_UPDATED",
 			},
-			wantGroup: model.MustNewGroup(
-				"TEST_GROUP_ID",
-				"TEST_GROUP_NAME_UPDATED",
-				[]model.UserID{},
+			wantGroup: stamped(
+				model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME_UPDATED", []model.UserID{}),
+				model.Timestamps{UpdatedAt: testNow},
 			),
 			newMemoryRepository: func() repository.Repository {
 				s := memory.NewStore()
@@ -553,6 +813,7 @@ func TestGroupUsecase_UpdateGroup(t *testing.T) {
 			wantErr:   usecase.ErrInvalidGroupInput,
 			newMemoryRepository: func() repository.Repository {
 				s := memory.NewStore()
+				s.AddGroups(model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", []model.UserID{}))
 				r := memory.NewMemoryRepository(s)
 				return r
 			},
@@ -582,7 +843,14 @@ func TestGroupUsecase_UpdateGroup(t *testing.T) {
 			r := tt.newMemoryRepository()
 			gs := domainservice.NewGroupService(r)
 			us := domainservice.NewUserService(r)
-			uc := usecase.NewGroupUsecase(r, f, gs, us)
+			uc := usecase.NewGroupUsecase(
+				r, f, gs, us,
+				factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+				factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+				factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+				model.DefaultPolicy(),
+				testClock,
+			)
 
 			_, err := uc.UpdateGroup(tt.in)
 			if tt.wantErr != nil {
@@ -607,6 +875,8 @@ func TestGroupUsecase_UpdateGroup(t *testing.T) {
 						gID, got, tt.wantGroup, diff,
 					)
 				}
+				assertAuditEvent(t, r, tt.in.Meta, model.AuditActionUpdateGroup, model.NewGroupAuditTarget(gID))
+				assertOutboxMessages(t, r, model.DomainEventTypeGroupUpdated)
 			}
 		})
 	}
@@ -622,6 +892,10 @@ func TestGroupUsecase_DeleteGroup(t *testing.T) {
 		{
 			name: "Delete a group",
 			in: &dto.DeleteGroupInput{
+				Meta: dto.Meta{
+					Actor:     "TEST_ACTOR",
+					RequestID: "TEST_REQUEST_ID",
+				},
 				GroupID: "TEST_GROUP_ID",
 			},
 			newMemoryRepository: func() repository.Repository {
@@ -678,7 +952,14 @@ func TestGroupUsecase_DeleteGroup(t *testing.T) {
 			r := tt.newMemoryRepository()
 			gs := domainservice.NewGroupService(r)
 			us := domainservice.NewUserService(r)
-			uc := usecase.NewGroupUsecase(r, f, gs, us)
+			uc := usecase.NewGroupUsecase(
+				r, f, gs, us,
+				factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+				factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+				factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+				model.DefaultPolicy(),
+				testClock,
+			)
 
 			_, err := uc.DeleteGroup(tt.in)
 			if tt.wantErr != nil {
@@ -700,7 +981,759 @@ func TestGroupUsecase_DeleteGroup(t *testing.T) {
 				if got != nil {
 					t.Errorf("r.Group().Find(%s)=%v, _; want nil, nil", gID, got)
 				}
+				assertAuditEvent(t, r, tt.in.Meta, model.AuditActionDeleteGroup, model.NewGroupAuditTarget(gID))
+				assertOutboxMessages(t, r, model.DomainEventTypeGroupDeleted)
+			}
+		})
+	}
+}
+
+func TestGroupUsecase_AddGroupUsers(t *testing.T) {
+	tests := []struct {
+		name                string
+		in                  *dto.AddGroupUsersInput
+		wantGroup           *model.Group
+		wantWaitlist        []model.UserID
+		wantEventTypes      []model.DomainEventType
+		newMemoryRepository func() repository.Repository
+		maxGroupUsers       int
+		wantErr             error
+	}{
+		{
+			name: "Add users to a group",
+			in: &dto.AddGroupUsersInput{
+				GroupID: "TEST_GROUP_ID",
+				UserIDs: []string{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+			},
+			wantGroup: stamped(
+				model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+				),
+				model.Timestamps{UpdatedAt: testNow},
+			),
+			wantEventTypes: []model.DomainEventType{model.DomainEventTypeGroupMembershipChanged},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+					model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+				)
+				s.AddGroups(model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_1"},
+				))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Does nothing if all the users already belong to the group",
+			in: &dto.AddGroupUsersInput{
+				GroupID: "TEST_GROUP_ID",
+				UserIDs: []string{"TEST_USER_ID_1"},
+			},
+			wantGroup: model.MustNewGroup(
+				"TEST_GROUP_ID",
+				"TEST_GROUP_NAME",
+				[]model.UserID{"TEST_USER_ID_1"},
+			),
+			wantEventTypes: []model.DomainEventType{},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"))
+				s.AddGroups(model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_1"},
+				))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Returns error if any of the users does not exist",
+			in: &dto.AddGroupUsersInput{
+				GroupID: "TEST_GROUP_ID",
+				UserIDs: []string{"TEST_USER_ID_1"},
+			},
+			wantErr: usecase.ErrInvalidUserIDs,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddGroups(model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", []model.UserID{}))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Returns error if any of the users is suspended",
+			in: &dto.AddGroupUsersInput{
+				GroupID: "TEST_GROUP_ID",
+				UserIDs: []string{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+			},
+			wantErr: usecase.ErrUserInactive,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+					model.MustNewUserWithStatus("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2", model.UserStatusSuspended),
+				)
+				s.AddGroups(model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", []model.UserID{}))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Returns error if no user ids are given",
+			in: &dto.AddGroupUsersInput{
+				GroupID: "TEST_GROUP_ID",
+			},
+			wantErr: usecase.ErrInvalidUserIDs,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Waitlists the users exceeding the capacity of the policy in order",
+			in: &dto.AddGroupUsersInput{
+				GroupID: "TEST_GROUP_ID",
+				UserIDs: []string{"TEST_USER_ID_2", "TEST_USER_ID_3", "TEST_USER_ID_4"},
+			},
+			wantGroup: stamped(
+				model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+				),
+				model.Timestamps{UpdatedAt: testNow},
+			),
+			wantWaitlist:   []model.UserID{"TEST_USER_ID_3", "TEST_USER_ID_4"},
+			wantEventTypes: []model.DomainEventType{model.DomainEventTypeGroupMembershipChanged},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+					model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+					model.MustNewUser("TEST_USER_ID_3", "TEST_USER_NAME_3", "TEST_USER_EMAIL_3"),
+					model.MustNewUser("TEST_USER_ID_4", "TEST_USER_NAME_4", "TEST_USER_EMAIL_4"),
+				)
+				s.AddGroups(model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_1"},
+				))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+			maxGroupUsers: 2,
+		},
+		{
+			name: "Waitlists the users without changing the group at capacity",
+			in: &dto.AddGroupUsersInput{
+				GroupID: "TEST_GROUP_ID",
+				UserIDs: []string{"TEST_USER_ID_2"},
+			},
+			wantGroup: model.MustNewGroup(
+				"TEST_GROUP_ID",
+				"TEST_GROUP_NAME",
+				[]model.UserID{"TEST_USER_ID_1"},
+			),
+			wantWaitlist:   []model.UserID{"TEST_USER_ID_2"},
+			wantEventTypes: []model.DomainEventType{},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+					model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+				)
+				s.AddGroups(model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_1"},
+				))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+			maxGroupUsers: 1,
+		},
+		{
+			name: "Returns error if the group does not exist",
+			in: &dto.AddGroupUsersInput{
+				GroupID: "TEST_GROUP_ID",
+				UserIDs: []string{"TEST_USER_ID_1"},
+			},
+			wantErr: usecase.ErrGroupNotFound,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := mockfactory.NewMockGroupFactory(ctrl)
+			r := tt.newMemoryRepository()
+			gs := domainservice.NewGroupService(r)
+			us := domainservice.NewUserService(r)
+			p := model.DefaultPolicy()
+			if tt.maxGroupUsers > 0 {
+				p.Group.MaxUsers = tt.maxGroupUsers
+			}
+			uc := usecase.NewGroupUsecase(
+				r, f, gs, us,
+				factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+				factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+				factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+				p,
+				testClock,
+			)
+
+			out, err := uc.AddGroupUsers(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf(
+						"uc.AddGroupUsers(%v)=_, %v; want _, %v",
+						tt.in, err, tt.wantErr,
+					)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			gID := model.GroupID(tt.in.GroupID)
+			got, _ := r.Group().Find(gID)
+			if diff := cmp.Diff(got, tt.wantGroup, cmp.AllowUnexported(model.Group{})); diff != "" {
+				t.Errorf(
+					"r.Group().Find(%s)=%v, _; want %v, nil\ndiffers: (-got +want)\n%s",
+					gID, got, tt.wantGroup, diff,
+				)
+			}
+			if diff := cmp.Diff(out.WaitlistedUserIDs, dto.ToUserIDsFromModel(tt.wantWaitlist)); diff != "" {
+				t.Errorf(
+					"uc.AddGroupUsers(%v).WaitlistedUserIDs=%v; want %v\ndiffers: (-got +want)\n%s",
+					tt.in, out.WaitlistedUserIDs, tt.wantWaitlist, diff,
+				)
+			}
+			assertGroupWaitlist(t, r, gID, tt.wantWaitlist...)
+			assertOutboxMessages(t, r, tt.wantEventTypes...)
+		})
+	}
+}
+
+func TestGroupUsecase_RemoveGroupUsers(t *testing.T) {
+	tests := []struct {
+		name                string
+		in                  *dto.RemoveGroupUsersInput
+		wantGroup           *model.Group
+		wantWaitlist        []model.UserID
+		wantEventTypes      []model.DomainEventType
+		newMemoryRepository func() repository.Repository
+		wantErr             error
+	}{
+		{
+			name: "Remove users from a group",
+			in: &dto.RemoveGroupUsersInput{
+				GroupID: "TEST_GROUP_ID",
+				UserIDs: []string{"TEST_USER_ID_1", "TEST_USER_ID_3"},
+			},
+			wantGroup: stamped(
+				model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_2"},
+				),
+				model.Timestamps{UpdatedAt: testNow},
+			),
+			wantEventTypes: []model.DomainEventType{model.DomainEventTypeGroupMembershipChanged},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddGroups(model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+				))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Promotes the waitlisted users in order to the room made in the group",
+			in: &dto.RemoveGroupUsersInput{
+				GroupID: "TEST_GROUP_ID",
+				UserIDs: []string{"TEST_USER_ID_1"},
+			},
+			wantGroup: stamped(
+				model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{
+						"TEST_USER_ID_2",
+						"TEST_USER_ID_3",
+						"TEST_USER_ID_4",
+						"TEST_USER_ID_5",
+						"TEST_USER_ID_7",
+					},
+				),
+				model.Timestamps{UpdatedAt: testNow},
+			),
+			wantWaitlist: []model.UserID{"TEST_USER_ID_6"},
+			wantEventTypes: []model.DomainEventType{
+				model.DomainEventTypeGroupMembershipChanged,
+				model.DomainEventTypeGroupMembershipChanged,
+				model.DomainEventTypeGroupWaitlistPromoted,
+			},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddGroups(model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{
+						"TEST_USER_ID_1",
+						"TEST_USER_ID_2",
+						"TEST_USER_ID_3",
+						"TEST_USER_ID_4",
+						"TEST_USER_ID_5",
+					},
+				))
+				s.AddGroupWaitlists(model.MustNewGroupWaitlist(
+					"TEST_GROUP_ID",
+					[]model.UserID{"TEST_USER_ID_7", "TEST_USER_ID_6"},
+				))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Does nothing if none of the users belong to the group",
+			in: &dto.RemoveGroupUsersInput{
+				GroupID: "TEST_GROUP_ID",
+				UserIDs: []string{"TEST_USER_ID_2"},
+			},
+			wantGroup: model.MustNewGroup(
+				"TEST_GROUP_ID",
+				"TEST_GROUP_NAME",
+				[]model.UserID{"TEST_USER_ID_1"},
+			),
+			wantEventTypes: []model.DomainEventType{},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddGroups(model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_1"},
+				))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Returns error if the group does not exist",
+			in: &dto.RemoveGroupUsersInput{
+				GroupID: "TEST_GROUP_ID",
+				UserIDs: []string{"TEST_USER_ID_1"},
+			},
+			wantErr: usecase.ErrGroupNotFound,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := mockfactory.NewMockGroupFactory(ctrl)
+			r := tt.newMemoryRepository()
+			gs := domainservice.NewGroupService(r)
+			us := domainservice.NewUserService(r)
+			uc := usecase.NewGroupUsecase(
+				r, f, gs, us,
+				factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+				factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+				factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+				model.DefaultPolicy(),
+				testClock,
+			)
+
+			_, err := uc.RemoveGroupUsers(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf(
+						"uc.RemoveGroupUsers(%v)=_, %v; want _, %v",
+						tt.in, err, tt.wantErr,
+					)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			gID := model.GroupID(tt.in.GroupID)
+			got, _ := r.Group().Find(gID)
+			if diff := cmp.Diff(got, tt.wantGroup, cmp.AllowUnexported(model.Group{})); diff != "" {
+				t.Errorf(
+					"r.Group().Find(%s)=%v, _; want %v, nil\ndiffers: (-got +want)\n%s",
+					gID, got, tt.wantGroup, diff,
+				)
+			}
+			assertGroupWaitlist(t, r, gID, tt.wantWaitlist...)
+			assertOutboxMessages(t, r, tt.wantEventTypes...)
+		})
+	}
+}
+
+func TestGroupUsecase_PatchGroup(t *testing.T) {
+	tests := []struct {
+		name                string
+		in                  *dto.PatchGroupInput
+		wantGroup           *model.Group
+		wantEventTypes      []model.DomainEventType
+		newMemoryRepository func() repository.Repository
+		wantErr             error
+	}{
+		{
+			name: "Rename a group with a JSON Merge Patch",
+			in: &dto.PatchGroupInput{
+				GroupID:   "TEST_GROUP_ID",
+				PatchType: dto.PatchTypeMergePatch,
+				Patch:     []byte(`{"name":"TEST_GROUP_NAME_UPDATED"}`),
+			},
+			wantGroup: stamped(
+				model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME_UPDATED",
+					[]model.UserID{"TEST_USER_ID_1"},
+				),
+				model.Timestamps{UpdatedAt: testNow},
+			),
+			wantEventTypes: []model.DomainEventType{model.DomainEventTypeGroupUpdated},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+					model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+				)
+				s.AddGroups(model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_1"},
+				))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Change the join policy of a group with a JSON Merge Patch",
+			in: &dto.PatchGroupInput{
+				GroupID:   "TEST_GROUP_ID",
+				PatchType: dto.PatchTypeMergePatch,
+				Patch:     []byte(`{"joinPolicy":"APPROVAL"}`),
+			},
+			wantGroup: stamped(
+				newGroupWithJoinPolicy(
+					"TEST_GROUP_ID",
+					[]model.UserID{"TEST_USER_ID_1"},
+					model.GroupJoinPolicyApproval,
+				),
+				model.Timestamps{UpdatedAt: testNow},
+			),
+			wantEventTypes: []model.DomainEventType{model.DomainEventTypeGroupUpdated},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"))
+				s.AddGroups(model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_1"},
+				))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Error the join policy is unknown",
+			in: &dto.PatchGroupInput{
+				GroupID:   "TEST_GROUP_ID",
+				PatchType: dto.PatchTypeMergePatch,
+				Patch:     []byte(`{"joinPolicy":"UNKNOWN"}`),
+			},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddGroups(model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", []model.UserID{}))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+			wantErr: usecase.ErrInvalidGroupInput,
+		},
+		{
+			name: "Replace the users of a group with a JSON Merge Patch",
+			in: &dto.PatchGroupInput{
+				GroupID:   "TEST_GROUP_ID",
+				PatchType: dto.PatchTypeMergePatch,
+				Patch:     []byte(`{"userIds":["TEST_USER_ID_2"]}`),
+			},
+			wantGroup: stamped(
+				model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_2"},
+				),
+				model.Timestamps{UpdatedAt: testNow},
+			),
+			wantEventTypes: []model.DomainEventType{
+				model.DomainEventTypeGroupMembershipChanged,
+				model.DomainEventTypeGroupMembershipChanged,
+			},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+					model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+				)
+				s.AddGroups(model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_1"},
+				))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Add a user to a group with a JSON Patch",
+			in: &dto.PatchGroupInput{
+				GroupID:   "TEST_GROUP_ID",
+				PatchType: dto.PatchTypeJSONPatch,
+				Patch:     []byte(`[{"op":"add","path":"/userIds/-","value":"TEST_USER_ID_2"}]`),
+			},
+			wantGroup: stamped(
+				model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+				),
+				model.Timestamps{UpdatedAt: testNow},
+			),
+			wantEventTypes: []model.DomainEventType{model.DomainEventTypeGroupMembershipChanged},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+					model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+				)
+				s.AddGroups(model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_1"},
+				))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Remove a user from a group and rename it with a JSON Patch",
+			in: &dto.PatchGroupInput{
+				GroupID:   "TEST_GROUP_ID",
+				PatchType: dto.PatchTypeJSONPatch,
+				Patch: []byte(`[
+					{"op":"remove","path":"/userIds/0"},
+					{"op":"replace","path":"/name","value":"TEST_GROUP_NAME_UPDATED"}
+				]`),
+			},
+			wantGroup: stamped(
+				model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME_UPDATED",
+					nil,
+				),
+				model.Timestamps{UpdatedAt: testNow},
+			),
+			wantEventTypes: []model.DomainEventType{
+				model.DomainEventTypeGroupMembershipChanged,
+				model.DomainEventTypeGroupUpdated,
+			},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+					model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+				)
+				s.AddGroups(model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_1"},
+				))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Does nothing if the patch changes nothing",
+			in: &dto.PatchGroupInput{
+				GroupID:   "TEST_GROUP_ID",
+				PatchType: dto.PatchTypeMergePatch,
+				Patch:     []byte(`{"name":"TEST_GROUP_NAME"}`),
+			},
+			wantGroup: model.MustNewGroup(
+				"TEST_GROUP_ID",
+				"TEST_GROUP_NAME",
+				[]model.UserID{"TEST_USER_ID_1"},
+			),
+			wantEventTypes: []model.DomainEventType{},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+					model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+				)
+				s.AddGroups(model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_1"},
+				))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Returns error if an added user does not exist",
+			in: &dto.PatchGroupInput{
+				GroupID:   "TEST_GROUP_ID",
+				PatchType: dto.PatchTypeJSONPatch,
+				Patch:     []byte(`[{"op":"add","path":"/userIds/-","value":"TEST_USER_ID_3"}]`),
+			},
+			wantErr: usecase.ErrInvalidUserIDs,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+					model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+				)
+				s.AddGroups(model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_1"},
+				))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Returns error if the patched group is invalid",
+			in: &dto.PatchGroupInput{
+				GroupID:   "TEST_GROUP_ID",
+				PatchType: dto.PatchTypeMergePatch,
+				Patch:     []byte(`{"name":""}`),
+			},
+			wantErr: usecase.ErrInvalidGroupInput,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+					model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+				)
+				s.AddGroups(model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_1"},
+				))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Returns error if the patch is malformed",
+			in: &dto.PatchGroupInput{
+				GroupID:   "TEST_GROUP_ID",
+				PatchType: dto.PatchTypeJSONPatch,
+				Patch:     []byte(`{"op":"add"}`),
+			},
+			wantErr: usecase.ErrInvalidPatch,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+					model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+				)
+				s.AddGroups(model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{"TEST_USER_ID_1"},
+				))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Returns error if the group does not exist",
+			in: &dto.PatchGroupInput{
+				GroupID:   "TEST_GROUP_ID",
+				PatchType: dto.PatchTypeMergePatch,
+				Patch:     []byte(`{"name":"TEST_GROUP_NAME_UPDATED"}`),
+			},
+			wantErr: usecase.ErrGroupNotFound,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := mockfactory.NewMockGroupFactory(ctrl)
+			r := tt.newMemoryRepository()
+			gs := domainservice.NewGroupService(r)
+			us := domainservice.NewUserService(r)
+			uc := usecase.NewGroupUsecase(
+				r, f, gs, us,
+				factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+				factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+				factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+				model.DefaultPolicy(),
+				testClock,
+			)
+
+			_, err := uc.PatchGroup(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf(
+						"uc.PatchGroup(%v)=_, %v; want _, %v",
+						tt.in, err, tt.wantErr,
+					)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			gID := model.GroupID(tt.in.GroupID)
+			got, _ := r.Group().Find(gID)
+			if diff := cmp.Diff(got, tt.wantGroup, cmp.AllowUnexported(model.Group{})); diff != "" {
+				t.Errorf(
+					"r.Group().Find(%s)=%v, _; want %v, nil\ndiffers: (-got +want)\n%s",
+					gID, got, tt.wantGroup, diff,
+				)
 			}
+			assertOutboxMessages(t, r, tt.wantEventTypes...)
 		})
 	}
 }
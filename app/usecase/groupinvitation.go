@@ -0,0 +1,307 @@
+//go:generate mockgen -source=$GOFILE -package=mock$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+type GroupInvitationUsecase interface {
+	InviteToGroup(in *dto.InviteToGroupInput) (*dto.InviteToGroupOutput, error)
+	GetGroupInvitations(in *dto.GetGroupInvitationsInput) (*dto.GetGroupInvitationsOutput, error)
+	GetUserInvitations(in *dto.GetUserInvitationsInput) (*dto.GetUserInvitationsOutput, error)
+	AcceptGroupInvitation(in *dto.AcceptGroupInvitationInput) (*dto.AcceptGroupInvitationOutput, error)
+	DeclineGroupInvitation(in *dto.DeclineGroupInvitationInput) (*dto.DeclineGroupInvitationOutput, error)
+	RevokeGroupInvitation(in *dto.RevokeGroupInvitationInput) (*dto.RevokeGroupInvitationOutput, error)
+	ExpireGroupInvitations(in *dto.ExpireGroupInvitationsInput) (*dto.ExpireGroupInvitationsOutput, error)
+}
+
+type groupInvitationUsecase struct {
+	r  repository.Repository
+	f  factory.GroupInvitationFactory
+	af factory.AuditEventFactory
+	of factory.OutboxMessageFactory
+	wf factory.WebhookDeliveryFactory
+	p  model.Policy
+	c  Clock
+}
+
+func NewGroupInvitationUsecase(
+	r repository.Repository,
+	f factory.GroupInvitationFactory,
+	af factory.AuditEventFactory,
+	of factory.OutboxMessageFactory,
+	wf factory.WebhookDeliveryFactory,
+	p model.Policy,
+	c Clock,
+) GroupInvitationUsecase {
+	return &groupInvitationUsecase{r: r, f: f, af: af, of: of, wf: wf, p: p, c: c}
+}
+
+// InviteToGroup invites the user or the email to the group on behalf of the actor.
+// A user must exist and not belong to the group, and an invitee must not have another pending invitation to it.
+func (uc *groupInvitationUsecase) InviteToGroup(in *dto.InviteToGroupInput) (*dto.InviteToGroupOutput, error) {
+	g, err := uc.r.Group().Find(model.GroupID(in.GroupID))
+	if err != nil {
+		return nil, err
+	}
+	if g == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	invitee := model.GroupInvitee{UserID: model.UserID(in.UserID), Email: in.Email}
+	if invitee.UserID != "" {
+		u, err := uc.r.User().Find(invitee.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if u == nil {
+			return nil, fmt.Errorf("the user %s is not found: %w", invitee.UserID, ErrInvalidGroupInvitationInput)
+		}
+		if g.HasUser(u.ID()) {
+			return nil, fmt.Errorf("the user %s already belongs to the group: %w", u.ID(), ErrInvalidGroupInvitationInput)
+		}
+	}
+
+	at := now(uc.c)
+	invs, err := uc.r.GroupInvitation().List(repository.GroupInvitationListFilter{
+		GroupID:       g.ID(),
+		InviteeUserID: invitee.UserID,
+		InviteeEmail:  invitee.Email,
+		Status:        model.GroupInvitationStatusPending,
+		ExpiresAfter:  at,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(invs) > 0 {
+		return nil, fmt.Errorf("the invitee has a pending invitation to the group: %w", ErrInvalidGroupInvitationInput)
+	}
+
+	inv, err := uc.f.Create(g.ID(), in.Actor, invitee, at)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidGroupInvitation) {
+			return nil, errors.Join(ErrInvalidGroupInvitationInput, err)
+		}
+		return nil, err
+	}
+
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		return tx.GroupInvitation().Create(inv)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.InviteToGroupOutput{
+		GroupInvitation: dto.ToGroupInvitationFromModel(inv),
+	}, nil
+}
+
+// GetGroupInvitations returns the pending invitations to the group.
+func (uc *groupInvitationUsecase) GetGroupInvitations(
+	in *dto.GetGroupInvitationsInput,
+) (*dto.GetGroupInvitationsOutput, error) {
+	gID := model.GroupID(in.GroupID)
+
+	g, err := uc.r.Group().Find(gID)
+	if err != nil {
+		return nil, err
+	}
+	if g == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	invs, err := uc.r.GroupInvitation().List(repository.GroupInvitationListFilter{
+		GroupID:      gID,
+		Status:       model.GroupInvitationStatusPending,
+		ExpiresAfter: now(uc.c),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.GetGroupInvitationsOutput{
+		GroupInvitations: dto.ToGroupInvitationsFromModel(invs),
+	}, nil
+}
+
+// GetUserInvitations returns the pending invitations for the user, either to the user or to the email of the user.
+func (uc *groupInvitationUsecase) GetUserInvitations(
+	in *dto.GetUserInvitationsInput,
+) (*dto.GetUserInvitationsOutput, error) {
+	u, err := uc.r.User().Find(model.UserID(in.UserID))
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, ErrUserNotFound
+	}
+
+	invs, err := uc.r.GroupInvitation().List(repository.GroupInvitationListFilter{
+		InviteeUserID: u.ID(),
+		InviteeEmail:  u.Email(),
+		Status:        model.GroupInvitationStatusPending,
+		ExpiresAfter:  now(uc.c),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.GetUserInvitationsOutput{
+		GroupInvitations: dto.ToGroupInvitationsFromModel(invs),
+	}, nil
+}
+
+// AcceptGroupInvitation adds the invitee to the group as AddGroupUsers does,
+// which waitlists the invitee if the group is at capacity.
+func (uc *groupInvitationUsecase) AcceptGroupInvitation(
+	in *dto.AcceptGroupInvitationInput,
+) (*dto.AcceptGroupInvitationOutput, error) {
+	inv, err := uc.findGroupInvitation(model.GroupInvitationID(in.GroupInvitationID))
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := uc.r.User().Find(model.UserID(in.UserID))
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, ErrUserNotFound
+	}
+	if !u.CanJoinGroups() {
+		return nil, ErrUserInactive
+	}
+
+	out := &dto.AcceptGroupInvitationOutput{}
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		before, err := findGroupForUpdate(tx, inv.GroupID())
+		if err != nil {
+			return err
+		}
+
+		if err := respondGroupInvitation(inv.Accept(u.ID(), u.Email(), now(uc.c))); err != nil {
+			return err
+		}
+
+		w, err := tx.GroupWaitlist().Find(before.ID())
+		if err != nil {
+			return err
+		}
+
+		after, err := copyGroup(before)
+		if err != nil {
+			return err
+		}
+		if _, err := after.AddUsersOrWaitlist(uc.p.GroupPolicyFor(after), w, []model.UserID{u.ID()}); err != nil {
+			return errors.Join(ErrInvalidGroupInput, err)
+		}
+		out.Waitlisted = w.Has(u.ID())
+
+		if err := tx.GroupWaitlist().Save(w); err != nil {
+			return err
+		}
+		if err := tx.GroupInvitation().Update(inv); err != nil {
+			return err
+		}
+		if !after.HasUser(u.ID()) || before.HasUser(u.ID()) {
+			// The user already belongs to the group or is waitlisted.
+			return nil
+		}
+		if err := tx.Group().AddUsers(after.ID(), []model.UserID{u.ID()}); err != nil {
+			return err
+		}
+		return storeGroupChangeIn(tx, uc.af, uc.of, uc.wf, now(uc.c), in.Meta, before, after)
+	}); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (uc *groupInvitationUsecase) DeclineGroupInvitation(
+	in *dto.DeclineGroupInvitationInput,
+) (*dto.DeclineGroupInvitationOutput, error) {
+	inv, err := uc.findGroupInvitation(model.GroupInvitationID(in.GroupInvitationID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := respondGroupInvitation(inv.Decline(now(uc.c))); err != nil {
+		return nil, err
+	}
+
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		return tx.GroupInvitation().Update(inv)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.DeclineGroupInvitationOutput{}, nil
+}
+
+func (uc *groupInvitationUsecase) RevokeGroupInvitation(
+	in *dto.RevokeGroupInvitationInput,
+) (*dto.RevokeGroupInvitationOutput, error) {
+	inv, err := uc.findGroupInvitation(model.GroupInvitationID(in.GroupInvitationID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := respondGroupInvitation(inv.Revoke(now(uc.c))); err != nil {
+		return nil, err
+	}
+
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		return tx.GroupInvitation().Update(inv)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.RevokeGroupInvitationOutput{}, nil
+}
+
+// ExpireGroupInvitations marks the pending invitations which have expired as expired.
+func (uc *groupInvitationUsecase) ExpireGroupInvitations(
+	_ *dto.ExpireGroupInvitationsInput,
+) (*dto.ExpireGroupInvitationsOutput, error) {
+	var n int
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		var err error
+		n, err = tx.GroupInvitation().ExpirePending(now(uc.c))
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.ExpireGroupInvitationsOutput{Expired: n}, nil
+}
+
+func (uc *groupInvitationUsecase) findGroupInvitation(id model.GroupInvitationID) (*model.GroupInvitation, error) {
+	inv, err := uc.r.GroupInvitation().Find(id)
+	if err != nil {
+		return nil, err
+	}
+	if inv == nil {
+		return nil, ErrGroupInvitationNotFound
+	}
+	return inv, nil
+}
+
+// respondGroupInvitation translates the error of responding to an invitation into the one of the usecase.
+func respondGroupInvitation(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, model.ErrGroupInvitationNotPending):
+		return errors.Join(ErrGroupInvitationNotPending, err)
+	case errors.Is(err, model.ErrInvalidGroupInvitation):
+		return errors.Join(ErrInvalidGroupInvitationInput, err)
+	}
+	return err
+}
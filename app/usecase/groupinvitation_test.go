@@ -0,0 +1,466 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/memory"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func newGroupInvitationMemoryRepository() repository.Repository {
+	now := testNow
+	s := memory.NewStore()
+	s.AddUsers(
+		model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "test1@example.com"),
+		model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "test2@example.com"),
+		model.MustNewUser("TEST_USER_ID_3", "TEST_USER_NAME_3", "test3@example.com"),
+	)
+	s.AddGroups(model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", []model.UserID{"TEST_USER_ID_1"}))
+	s.AddGroupInvitations(
+		model.MustNewGroupInvitation(
+			"TEST_GROUP_INVITATION_ID_1",
+			"TEST_GROUP_ID",
+			"TEST_ACTOR",
+			model.GroupInvitee{UserID: "TEST_USER_ID_2"},
+			now.Add(-time.Minute),
+			now.Add(time.Hour),
+			model.GroupInvitationState{},
+		),
+		model.MustNewGroupInvitation(
+			"TEST_GROUP_INVITATION_ID_2",
+			"TEST_GROUP_ID",
+			"TEST_ACTOR",
+			model.GroupInvitee{Email: "TEST3@example.com"},
+			now.Add(-time.Minute),
+			now.Add(time.Hour),
+			model.GroupInvitationState{},
+		),
+		model.MustNewGroupInvitation(
+			"TEST_GROUP_INVITATION_ID_EXPIRED",
+			"TEST_GROUP_ID",
+			"TEST_ACTOR",
+			model.GroupInvitee{UserID: "TEST_USER_ID_3"},
+			now.Add(-2*time.Hour),
+			now.Add(-time.Hour),
+			model.GroupInvitationState{},
+		),
+		model.MustNewGroupInvitation(
+			"TEST_GROUP_INVITATION_ID_DECLINED",
+			"TEST_GROUP_ID",
+			"TEST_ACTOR",
+			model.GroupInvitee{UserID: "TEST_USER_ID_3"},
+			now.Add(-time.Minute),
+			now.Add(time.Hour),
+			model.GroupInvitationState{Status: model.GroupInvitationStatusDeclined, RespondedAt: now},
+		),
+	)
+	return memory.NewMemoryRepository(s)
+}
+
+func newGroupInvitationUsecase(r repository.Repository, p model.Policy) usecase.GroupInvitationUsecase {
+	return usecase.NewGroupInvitationUsecase(
+		r,
+		factory.NewGroupInvitationFactory(time.Hour, factory.NewSequenceIDGenerator("TEST_NEW_GROUP_INVITATION_ID_")),
+		factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+		factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+		factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+		p,
+		testClock,
+	)
+}
+
+func groupInvitationIDs(invs []dto.GroupInvitation) []string {
+	ids := make([]string, len(invs))
+	for i, inv := range invs {
+		ids[i] = inv.GroupInvitationID
+	}
+	return ids
+}
+
+func TestGroupInvitationUsecase_InviteToGroup(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      *dto.InviteToGroupInput
+		wantErr error
+	}{
+		{
+			name: "Invites a user",
+			in:   &dto.InviteToGroupInput{GroupID: "TEST_GROUP_ID", UserID: "TEST_USER_ID_3"},
+		},
+		{
+			name: "Invites an email",
+			in:   &dto.InviteToGroupInput{GroupID: "TEST_GROUP_ID", Email: "new@example.com"},
+		},
+		{
+			name:    "Returns error if the group does not exist",
+			in:      &dto.InviteToGroupInput{GroupID: "TEST_GROUP_ID_UNKNOWN", UserID: "TEST_USER_ID_3"},
+			wantErr: usecase.ErrGroupNotFound,
+		},
+		{
+			name:    "Returns error if the user does not exist",
+			in:      &dto.InviteToGroupInput{GroupID: "TEST_GROUP_ID", UserID: "TEST_USER_ID_UNKNOWN"},
+			wantErr: usecase.ErrInvalidGroupInvitationInput,
+		},
+		{
+			name:    "Returns error if the user already belongs to the group",
+			in:      &dto.InviteToGroupInput{GroupID: "TEST_GROUP_ID", UserID: "TEST_USER_ID_1"},
+			wantErr: usecase.ErrInvalidGroupInvitationInput,
+		},
+		{
+			name:    "Returns error if the user has a pending invitation to the group",
+			in:      &dto.InviteToGroupInput{GroupID: "TEST_GROUP_ID", UserID: "TEST_USER_ID_2"},
+			wantErr: usecase.ErrInvalidGroupInvitationInput,
+		},
+		{
+			name:    "Returns error if the email has a pending invitation to the group",
+			in:      &dto.InviteToGroupInput{GroupID: "TEST_GROUP_ID", Email: "test3@example.com"},
+			wantErr: usecase.ErrInvalidGroupInvitationInput,
+		},
+		{
+			name:    "Returns error if the invitee is empty",
+			in:      &dto.InviteToGroupInput{GroupID: "TEST_GROUP_ID"},
+			wantErr: usecase.ErrInvalidGroupInvitationInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.in.Meta = dto.Meta{Actor: "TEST_ACTOR", RequestID: "TEST_REQUEST_ID"}
+			r := newGroupInvitationMemoryRepository()
+			uc := newGroupInvitationUsecase(r, model.DefaultPolicy())
+
+			got, err := uc.InviteToGroup(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("uc.InviteToGroup(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+
+			inv := got.GroupInvitation
+			if inv.GroupID != tt.in.GroupID || inv.InviteeUserID != tt.in.UserID || inv.InviteeEmail != tt.in.Email {
+				t.Errorf("uc.InviteToGroup(%v)=%v, nil; want the invitation of the input", tt.in, got)
+			}
+			if inv.Inviter != "TEST_ACTOR" {
+				t.Errorf("inv.Inviter=%s; want TEST_ACTOR", inv.Inviter)
+			}
+			if inv.Status != string(model.GroupInvitationStatusPending) {
+				t.Errorf("inv.Status=%s; want %s", inv.Status, model.GroupInvitationStatusPending)
+			}
+
+			stored, err := r.GroupInvitation().Find(model.GroupInvitationID(inv.GroupInvitationID))
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if stored == nil {
+				t.Fatalf("r.GroupInvitation().Find(%s)=nil, nil; want the invitation", inv.GroupInvitationID)
+			}
+			if !stored.CreatedAt().Equal(testNow) || !stored.ExpiresAt().Equal(testNow.Add(time.Hour)) {
+				t.Errorf(
+					"stored.CreatedAt(), stored.ExpiresAt()=%v, %v; want %v, %v",
+					stored.CreatedAt(), stored.ExpiresAt(), testNow, testNow.Add(time.Hour),
+				)
+			}
+		})
+	}
+}
+
+func TestGroupInvitationUsecase_GetGroupInvitations(t *testing.T) {
+	uc := newGroupInvitationUsecase(newGroupInvitationMemoryRepository(), model.DefaultPolicy())
+
+	got, err := uc.GetGroupInvitations(&dto.GetGroupInvitationsInput{GroupID: "TEST_GROUP_ID"})
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	want := []string{"TEST_GROUP_INVITATION_ID_1", "TEST_GROUP_INVITATION_ID_2"}
+	if diff := cmp.Diff(groupInvitationIDs(got.GroupInvitations), want); diff != "" {
+		t.Errorf("uc.GetGroupInvitations() ids differs: (-got +want)\n%s", diff)
+	}
+
+	if _, err := uc.GetGroupInvitations(&dto.GetGroupInvitationsInput{GroupID: "TEST_GROUP_ID_UNKNOWN"}); !errors.Is(err, usecase.ErrGroupNotFound) {
+		t.Errorf("uc.GetGroupInvitations(unknown)=_, %v; want _, %v", err, usecase.ErrGroupNotFound)
+	}
+}
+
+func TestGroupInvitationUsecase_GetUserInvitations(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      *dto.GetUserInvitationsInput
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "Returns the pending invitations for the user",
+			in:   &dto.GetUserInvitationsInput{UserID: "TEST_USER_ID_2"},
+			want: []string{"TEST_GROUP_INVITATION_ID_1"},
+		},
+		{
+			name: "Returns the pending invitations for the email of the user",
+			in:   &dto.GetUserInvitationsInput{UserID: "TEST_USER_ID_3"},
+			want: []string{"TEST_GROUP_INVITATION_ID_2"},
+		},
+		{
+			name:    "Returns error if the user does not exist",
+			in:      &dto.GetUserInvitationsInput{UserID: "TEST_USER_ID_UNKNOWN"},
+			wantErr: usecase.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := newGroupInvitationUsecase(newGroupInvitationMemoryRepository(), model.DefaultPolicy())
+
+			got, err := uc.GetUserInvitations(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("uc.GetUserInvitations(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if diff := cmp.Diff(groupInvitationIDs(got.GroupInvitations), tt.want); diff != "" {
+				t.Errorf("uc.GetUserInvitations(%v) ids differs: (-got +want)\n%s", tt.in, diff)
+			}
+		})
+	}
+}
+
+func TestGroupInvitationUsecase_AcceptGroupInvitation(t *testing.T) {
+	tests := []struct {
+		name          string
+		in            *dto.AcceptGroupInvitationInput
+		maxGroupUsers int
+		concurrent    func(tx repository.Transaction) error
+		want          *dto.AcceptGroupInvitationOutput
+		wantUserIDs   []model.UserID
+		wantWaitlist  []model.UserID
+		wantOutbox    []model.DomainEventType
+		wantErr       error
+	}{
+		{
+			name:          "Adds the invitee to the group",
+			in:            &dto.AcceptGroupInvitationInput{GroupInvitationID: "TEST_GROUP_INVITATION_ID_1", UserID: "TEST_USER_ID_2"},
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			want:          &dto.AcceptGroupInvitationOutput{Waitlisted: false},
+			wantUserIDs:   []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+			wantOutbox:    []model.DomainEventType{model.DomainEventTypeGroupMembershipChanged},
+		},
+		{
+			name:          "Adds the user invited by the email to the group",
+			in:            &dto.AcceptGroupInvitationInput{GroupInvitationID: "TEST_GROUP_INVITATION_ID_2", UserID: "TEST_USER_ID_3"},
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			want:          &dto.AcceptGroupInvitationOutput{Waitlisted: false},
+			wantUserIDs:   []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_3"},
+			wantOutbox:    []model.DomainEventType{model.DomainEventTypeGroupMembershipChanged},
+		},
+		{
+			name:          "Waitlists the invitee if the group is at capacity",
+			in:            &dto.AcceptGroupInvitationInput{GroupInvitationID: "TEST_GROUP_INVITATION_ID_1", UserID: "TEST_USER_ID_2"},
+			maxGroupUsers: 1,
+			want:          &dto.AcceptGroupInvitationOutput{Waitlisted: true},
+			wantUserIDs:   []model.UserID{"TEST_USER_ID_1"},
+			wantWaitlist:  []model.UserID{"TEST_USER_ID_2"},
+		},
+		{
+			name:          "Waitlists the invitee after the user waitlisted concurrently",
+			in:            &dto.AcceptGroupInvitationInput{GroupInvitationID: "TEST_GROUP_INVITATION_ID_1", UserID: "TEST_USER_ID_2"},
+			maxGroupUsers: 1,
+			concurrent: func(tx repository.Transaction) error {
+				return tx.GroupWaitlist().Save(model.MustNewGroupWaitlist("TEST_GROUP_ID", []model.UserID{"TEST_USER_ID_3"}))
+			},
+			want:         &dto.AcceptGroupInvitationOutput{Waitlisted: true},
+			wantUserIDs:  []model.UserID{"TEST_USER_ID_1"},
+			wantWaitlist: []model.UserID{"TEST_USER_ID_3", "TEST_USER_ID_2"},
+		},
+		{
+			name:          "Returns error if the user is not the invitee",
+			in:            &dto.AcceptGroupInvitationInput{GroupInvitationID: "TEST_GROUP_INVITATION_ID_1", UserID: "TEST_USER_ID_3"},
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			wantUserIDs:   []model.UserID{"TEST_USER_ID_1"},
+			wantErr:       usecase.ErrInvalidGroupInvitationInput,
+		},
+		{
+			name:          "Returns error if the invitation has expired",
+			in:            &dto.AcceptGroupInvitationInput{GroupInvitationID: "TEST_GROUP_INVITATION_ID_EXPIRED", UserID: "TEST_USER_ID_3"},
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			wantUserIDs:   []model.UserID{"TEST_USER_ID_1"},
+			wantErr:       usecase.ErrGroupInvitationNotPending,
+		},
+		{
+			name:          "Returns error if the invitation has been declined",
+			in:            &dto.AcceptGroupInvitationInput{GroupInvitationID: "TEST_GROUP_INVITATION_ID_DECLINED", UserID: "TEST_USER_ID_3"},
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			wantUserIDs:   []model.UserID{"TEST_USER_ID_1"},
+			wantErr:       usecase.ErrGroupInvitationNotPending,
+		},
+		{
+			name:          "Returns error if the invitation does not exist",
+			in:            &dto.AcceptGroupInvitationInput{GroupInvitationID: "TEST_GROUP_INVITATION_ID_UNKNOWN", UserID: "TEST_USER_ID_2"},
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			wantUserIDs:   []model.UserID{"TEST_USER_ID_1"},
+			wantErr:       usecase.ErrGroupInvitationNotFound,
+		},
+		{
+			name:          "Returns error if the user does not exist",
+			in:            &dto.AcceptGroupInvitationInput{GroupInvitationID: "TEST_GROUP_INVITATION_ID_1", UserID: "TEST_USER_ID_UNKNOWN"},
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			wantUserIDs:   []model.UserID{"TEST_USER_ID_1"},
+			wantErr:       usecase.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.in.Meta = dto.Meta{Actor: "TEST_ACTOR", RequestID: "TEST_REQUEST_ID"}
+			p := model.DefaultPolicy()
+			p.Group.MaxUsers = tt.maxGroupUsers
+			r := &interleavedRepository{Repository: newGroupInvitationMemoryRepository(), concurrent: tt.concurrent}
+			uc := newGroupInvitationUsecase(r, p)
+
+			got, err := uc.AcceptGroupInvitation(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("uc.AcceptGroupInvitation(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("want no error, but has error %v", err)
+				}
+				if diff := cmp.Diff(got, tt.want); diff != "" {
+					t.Errorf(
+						"uc.AcceptGroupInvitation(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+						tt.in, got, tt.want, diff,
+					)
+				}
+
+				inv, err := r.GroupInvitation().Find(model.GroupInvitationID(tt.in.GroupInvitationID))
+				if err != nil {
+					t.Fatalf("want no error, but has error %v", err)
+				}
+				if inv.State().Status != model.GroupInvitationStatusAccepted {
+					t.Errorf("inv.State().Status=%s; want %s", inv.State().Status, model.GroupInvitationStatusAccepted)
+				}
+			}
+
+			g, err := r.Group().Find("TEST_GROUP_ID")
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if diff := cmp.Diff(g.UserIDs(), tt.wantUserIDs); diff != "" {
+				t.Errorf("g.UserIDs()=%v; want %v\ndiffers: (-got +want)\n%s", g.UserIDs(), tt.wantUserIDs, diff)
+			}
+			assertGroupWaitlist(t, r, "TEST_GROUP_ID", tt.wantWaitlist...)
+			assertOutboxMessages(t, r, tt.wantOutbox...)
+		})
+	}
+}
+
+func TestGroupInvitationUsecase_DeclineGroupInvitation(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      *dto.DeclineGroupInvitationInput
+		wantErr error
+	}{
+		{
+			name: "Declines the invitation",
+			in:   &dto.DeclineGroupInvitationInput{GroupInvitationID: "TEST_GROUP_INVITATION_ID_1"},
+		},
+		{
+			name:    "Returns error if the invitation has been declined",
+			in:      &dto.DeclineGroupInvitationInput{GroupInvitationID: "TEST_GROUP_INVITATION_ID_DECLINED"},
+			wantErr: usecase.ErrGroupInvitationNotPending,
+		},
+		{
+			name:    "Returns error if the invitation does not exist",
+			in:      &dto.DeclineGroupInvitationInput{GroupInvitationID: "TEST_GROUP_INVITATION_ID_UNKNOWN"},
+			wantErr: usecase.ErrGroupInvitationNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newGroupInvitationMemoryRepository()
+			uc := newGroupInvitationUsecase(r, model.DefaultPolicy())
+
+			_, err := uc.DeclineGroupInvitation(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("uc.DeclineGroupInvitation(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+
+			inv, err := r.GroupInvitation().Find(model.GroupInvitationID(tt.in.GroupInvitationID))
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if inv.State().Status != model.GroupInvitationStatusDeclined {
+				t.Errorf("inv.State().Status=%s; want %s", inv.State().Status, model.GroupInvitationStatusDeclined)
+			}
+			if !inv.State().RespondedAt.Equal(testNow) {
+				t.Errorf("inv.State().RespondedAt=%v; want %v", inv.State().RespondedAt, testNow)
+			}
+		})
+	}
+}
+
+func TestGroupInvitationUsecase_RevokeGroupInvitation(t *testing.T) {
+	r := newGroupInvitationMemoryRepository()
+	uc := newGroupInvitationUsecase(r, model.DefaultPolicy())
+
+	in := &dto.RevokeGroupInvitationInput{GroupInvitationID: "TEST_GROUP_INVITATION_ID_2"}
+	if _, err := uc.RevokeGroupInvitation(in); err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+
+	inv, err := r.GroupInvitation().Find("TEST_GROUP_INVITATION_ID_2")
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	if inv.State().Status != model.GroupInvitationStatusRevoked {
+		t.Errorf("inv.State().Status=%s; want %s", inv.State().Status, model.GroupInvitationStatusRevoked)
+	}
+
+	if _, err := uc.RevokeGroupInvitation(in); !errors.Is(err, usecase.ErrGroupInvitationNotPending) {
+		t.Errorf("uc.RevokeGroupInvitation(%v)=_, %v; want _, %v", in, err, usecase.ErrGroupInvitationNotPending)
+	}
+}
+
+func TestGroupInvitationUsecase_ExpireGroupInvitations(t *testing.T) {
+	r := newGroupInvitationMemoryRepository()
+	uc := newGroupInvitationUsecase(r, model.DefaultPolicy())
+
+	got, err := uc.ExpireGroupInvitations(&dto.ExpireGroupInvitationsInput{})
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	if want := (&dto.ExpireGroupInvitationsOutput{Expired: 1}); !cmp.Equal(got, want) {
+		t.Errorf("uc.ExpireGroupInvitations()=%v, nil; want %v, nil", got, want)
+	}
+
+	invs, err := r.GroupInvitation().List(repository.GroupInvitationListFilter{
+		Status: model.GroupInvitationStatusExpired,
+	})
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	if len(invs) != 1 || invs[0].ID() != "TEST_GROUP_INVITATION_ID_EXPIRED" {
+		t.Errorf("r.GroupInvitation().List(expired)=%v; want TEST_GROUP_INVITATION_ID_EXPIRED", invs)
+	}
+}
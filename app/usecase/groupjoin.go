@@ -0,0 +1,306 @@
+//go:generate mockgen -source=$GOFILE -package=mock$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+type GroupJoinUsecase interface {
+	JoinGroup(in *dto.JoinGroupInput) (*dto.JoinGroupOutput, error)
+	GetGroupJoinRequests(in *dto.GetGroupJoinRequestsInput) (*dto.GetGroupJoinRequestsOutput, error)
+	ApproveGroupJoinRequest(in *dto.ApproveGroupJoinRequestInput) (*dto.ApproveGroupJoinRequestOutput, error)
+	RejectGroupJoinRequest(in *dto.RejectGroupJoinRequestInput) (*dto.RejectGroupJoinRequestOutput, error)
+}
+
+type groupJoinUsecase struct {
+	r  repository.Repository
+	f  factory.GroupJoinRequestFactory
+	af factory.AuditEventFactory
+	of factory.OutboxMessageFactory
+	wf factory.WebhookDeliveryFactory
+	p  model.Policy
+	c  Clock
+}
+
+func NewGroupJoinUsecase(
+	r repository.Repository,
+	f factory.GroupJoinRequestFactory,
+	af factory.AuditEventFactory,
+	of factory.OutboxMessageFactory,
+	wf factory.WebhookDeliveryFactory,
+	p model.Policy,
+	c Clock,
+) GroupJoinUsecase {
+	return &groupJoinUsecase{r: r, f: f, af: af, of: of, wf: wf, p: p, c: c}
+}
+
+// JoinGroup adds the user to the group as AddGroupUsers does if the group is open,
+// or requests the user to join it if its join policy requires an approval.
+// The user must not belong to the group, and must not have another pending request to it.
+func (uc *groupJoinUsecase) JoinGroup(in *dto.JoinGroupInput) (*dto.JoinGroupOutput, error) {
+	g, err := uc.r.Group().Find(model.GroupID(in.GroupID))
+	if err != nil {
+		return nil, err
+	}
+	if g == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	u, err := uc.r.User().Find(model.UserID(in.UserID))
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, ErrUserNotFound
+	}
+	if !u.CanJoinGroups() {
+		return nil, ErrUserInactive
+	}
+	if g.HasUser(u.ID()) {
+		return nil, fmt.Errorf("the user %s already belongs to the group: %w", u.ID(), ErrInvalidGroupJoinRequestInput)
+	}
+
+	requiresApproval, err := g.RequiresJoinApproval()
+	if err != nil {
+		if errors.Is(err, model.ErrGroupJoinNotAllowed) {
+			return nil, errors.Join(ErrGroupJoinNotAllowed, err)
+		}
+		return nil, err
+	}
+	if !requiresApproval {
+		waitlisted, err := uc.addUser(in.Meta, g.ID(), u.ID(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return &dto.JoinGroupOutput{Waitlisted: waitlisted}, nil
+	}
+
+	jrs, err := uc.r.GroupJoinRequest().List(repository.GroupJoinRequestListFilter{
+		GroupID: g.ID(),
+		UserID:  u.ID(),
+		Status:  model.GroupJoinRequestStatusPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(jrs) > 0 {
+		return nil, fmt.Errorf("the user %s has a pending request to the group: %w", u.ID(), ErrInvalidGroupJoinRequestInput)
+	}
+
+	jr, err := uc.f.Create(g.ID(), u.ID(), now(uc.c))
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidGroupJoinRequest) {
+			return nil, errors.Join(ErrInvalidGroupJoinRequestInput, err)
+		}
+		return nil, err
+	}
+
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		return tx.GroupJoinRequest().Create(jr)
+	}); err != nil {
+		return nil, err
+	}
+
+	dtojr := dto.ToGroupJoinRequestFromModel(jr)
+	return &dto.JoinGroupOutput{GroupJoinRequest: &dtojr}, nil
+}
+
+// GetGroupJoinRequests returns the pending requests to join the group.
+func (uc *groupJoinUsecase) GetGroupJoinRequests(
+	in *dto.GetGroupJoinRequestsInput,
+) (*dto.GetGroupJoinRequestsOutput, error) {
+	gID := model.GroupID(in.GroupID)
+
+	g, err := uc.r.Group().Find(gID)
+	if err != nil {
+		return nil, err
+	}
+	if g == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	jrs, err := uc.r.GroupJoinRequest().List(repository.GroupJoinRequestListFilter{
+		GroupID: gID,
+		Status:  model.GroupJoinRequestStatusPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.GetGroupJoinRequestsOutput{
+		GroupJoinRequests: dto.ToGroupJoinRequestsFromModel(jrs),
+	}, nil
+}
+
+// ApproveGroupJoinRequest approves the request on behalf of the actor, who must be a member of the group or an admin,
+// and adds the user to the group as AddGroupUsers does, which waitlists the user if the group is at capacity.
+func (uc *groupJoinUsecase) ApproveGroupJoinRequest(
+	in *dto.ApproveGroupJoinRequestInput,
+) (*dto.ApproveGroupJoinRequestOutput, error) {
+	jr, err := uc.findGroupJoinRequest(model.GroupID(in.GroupID), model.GroupJoinRequestID(in.GroupJoinRequestID))
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := uc.r.Group().Find(jr.GroupID())
+	if err != nil {
+		return nil, err
+	}
+	if g == nil {
+		return nil, ErrGroupNotFound
+	}
+	if err := uc.checkResponder(g, in.Actor); err != nil {
+		return nil, err
+	}
+
+	u, err := uc.r.User().Find(jr.UserID())
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, ErrUserNotFound
+	}
+	if !u.CanJoinGroups() {
+		return nil, ErrUserInactive
+	}
+
+	if err := respondGroupJoinRequest(jr.Approve(g, in.Actor, now(uc.c))); err != nil {
+		return nil, err
+	}
+
+	waitlisted, err := uc.addUser(in.Meta, g.ID(), u.ID(), jr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ApproveGroupJoinRequestOutput{Waitlisted: waitlisted}, nil
+}
+
+// RejectGroupJoinRequest rejects the request on behalf of the actor, who must be a member of the group or an admin.
+func (uc *groupJoinUsecase) RejectGroupJoinRequest(
+	in *dto.RejectGroupJoinRequestInput,
+) (*dto.RejectGroupJoinRequestOutput, error) {
+	jr, err := uc.findGroupJoinRequest(model.GroupID(in.GroupID), model.GroupJoinRequestID(in.GroupJoinRequestID))
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := uc.r.Group().Find(jr.GroupID())
+	if err != nil {
+		return nil, err
+	}
+	if g == nil {
+		return nil, ErrGroupNotFound
+	}
+	if err := uc.checkResponder(g, in.Actor); err != nil {
+		return nil, err
+	}
+
+	if err := respondGroupJoinRequest(jr.Reject(in.Actor, now(uc.c))); err != nil {
+		return nil, err
+	}
+
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		return tx.GroupJoinRequest().Update(jr)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.RejectGroupJoinRequestOutput{}, nil
+}
+
+// addUser adds the user to the group, or waitlists the user if the group is at capacity,
+// and stores the join request responded to if any. It reports whether the user is waitlisted.
+// The group is read again locked in the transaction, so that the changes of it run one after another.
+func (uc *groupJoinUsecase) addUser(
+	meta dto.Meta,
+	gID model.GroupID,
+	uID model.UserID,
+	jr *model.GroupJoinRequest,
+) (bool, error) {
+	var waitlisted bool
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		before, err := findGroupForUpdate(tx, gID)
+		if err != nil {
+			return err
+		}
+
+		w, err := tx.GroupWaitlist().Find(before.ID())
+		if err != nil {
+			return err
+		}
+
+		after, err := copyGroup(before)
+		if err != nil {
+			return err
+		}
+		if _, err := after.AddUsersOrWaitlist(uc.p.GroupPolicyFor(after), w, []model.UserID{uID}); err != nil {
+			return errors.Join(ErrInvalidGroupInput, err)
+		}
+		waitlisted = w.Has(uID)
+
+		if err := tx.GroupWaitlist().Save(w); err != nil {
+			return err
+		}
+		if jr != nil {
+			if err := tx.GroupJoinRequest().Update(jr); err != nil {
+				return err
+			}
+		}
+		if !after.HasUser(uID) || before.HasUser(uID) {
+			// The user already belongs to the group or is waitlisted.
+			return nil
+		}
+		if err := tx.Group().AddUsers(after.ID(), []model.UserID{uID}); err != nil {
+			return err
+		}
+		return storeGroupChangeIn(tx, uc.af, uc.of, uc.wf, now(uc.c), meta, before, after)
+	}); err != nil {
+		return false, err
+	}
+	return waitlisted, nil
+}
+
+// findGroupJoinRequest finds the join request to the group.
+func (uc *groupJoinUsecase) findGroupJoinRequest(
+	gID model.GroupID,
+	id model.GroupJoinRequestID,
+) (*model.GroupJoinRequest, error) {
+	jr, err := uc.r.GroupJoinRequest().Find(id)
+	if err != nil {
+		return nil, err
+	}
+	if jr == nil || jr.GroupID() != gID {
+		return nil, ErrGroupJoinRequestNotFound
+	}
+	return jr, nil
+}
+
+// checkResponder reports an error unless the actor may respond to the requests to join the group.
+func (uc *groupJoinUsecase) checkResponder(g *model.Group, actor string) error {
+	if !uc.p.CanManageGroup(g, model.UserID(actor)) {
+		return fmt.Errorf("the actor %q is neither a member of the group nor an admin: %w", actor, ErrGroupJoinRequestForbidden)
+	}
+	return nil
+}
+
+// respondGroupJoinRequest translates the error of responding to a join request into the one of the usecase.
+func respondGroupJoinRequest(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, model.ErrGroupJoinRequestNotPending):
+		return errors.Join(ErrGroupJoinRequestNotPending, err)
+	case errors.Is(err, model.ErrGroupJoinNotAllowed):
+		return errors.Join(ErrGroupJoinNotAllowed, err)
+	case errors.Is(err, model.ErrInvalidGroupJoinRequest):
+		return errors.Join(ErrInvalidGroupJoinRequestInput, err)
+	}
+	return err
+}
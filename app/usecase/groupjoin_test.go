@@ -0,0 +1,477 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/memory"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func newGroupWithJoinPolicy(id model.GroupID, uIDs []model.UserID, p model.GroupJoinPolicy) *model.Group {
+	g := model.MustNewGroup(id, "TEST_GROUP_NAME", uIDs)
+	if err := g.ChangeJoinPolicy(p); err != nil {
+		panic(err)
+	}
+	return g
+}
+
+func newGroupJoinMemoryRepository() repository.Repository {
+	now := testNow
+	s := memory.NewStore()
+	s.AddUsers(
+		model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "test1@example.com"),
+		model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "test2@example.com"),
+		model.MustNewUser("TEST_USER_ID_3", "TEST_USER_NAME_3", "test3@example.com"),
+		model.MustNewUserWithStatus("TEST_USER_ID_4", "TEST_USER_NAME_4", "test4@example.com", model.UserStatusSuspended),
+	)
+	s.AddGroups(
+		newGroupWithJoinPolicy("TEST_GROUP_ID_OPEN", []model.UserID{"TEST_USER_ID_1"}, model.GroupJoinPolicyOpen),
+		newGroupWithJoinPolicy("TEST_GROUP_ID_APPROVAL", []model.UserID{"TEST_USER_ID_1"}, model.GroupJoinPolicyApproval),
+		newGroupWithJoinPolicy("TEST_GROUP_ID_INVITE_ONLY", []model.UserID{"TEST_USER_ID_1"}, model.GroupJoinPolicyInviteOnly),
+	)
+	s.AddGroupJoinRequests(
+		model.MustNewGroupJoinRequest(
+			"TEST_GROUP_JOIN_REQUEST_ID_1",
+			"TEST_GROUP_ID_APPROVAL",
+			"TEST_USER_ID_2",
+			now.Add(-time.Minute),
+			model.GroupJoinRequestState{},
+		),
+		model.MustNewGroupJoinRequest(
+			"TEST_GROUP_JOIN_REQUEST_ID_REJECTED",
+			"TEST_GROUP_ID_APPROVAL",
+			"TEST_USER_ID_3",
+			now.Add(-time.Minute),
+			model.GroupJoinRequestState{
+				Status:      model.GroupJoinRequestStatusRejected,
+				RespondedAt: now,
+				RespondedBy: "TEST_ACTOR",
+			},
+		),
+		model.MustNewGroupJoinRequest(
+			"TEST_GROUP_JOIN_REQUEST_ID_INVITE_ONLY",
+			"TEST_GROUP_ID_INVITE_ONLY",
+			"TEST_USER_ID_2",
+			now.Add(-time.Minute),
+			model.GroupJoinRequestState{},
+		),
+	)
+	return memory.NewMemoryRepository(s)
+}
+
+// testGroupJoinActor returns the actor of a test case, which is TEST_USER_ID_1, a member of all the groups,
+// if it is empty and none if it is "-".
+func testGroupJoinActor(actor string) string {
+	switch actor {
+	case "":
+		return "TEST_USER_ID_1"
+	case "-":
+		return ""
+	}
+	return actor
+}
+
+func newGroupJoinUsecase(r repository.Repository, p model.Policy) usecase.GroupJoinUsecase {
+	return usecase.NewGroupJoinUsecase(
+		r,
+		factory.NewGroupJoinRequestFactory(factory.NewSequenceIDGenerator("TEST_NEW_GROUP_JOIN_REQUEST_ID_")),
+		factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+		factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+		factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+		p,
+		testClock,
+	)
+}
+
+func TestGroupJoinUsecase_JoinGroup(t *testing.T) {
+	tests := []struct {
+		name          string
+		in            *dto.JoinGroupInput
+		maxGroupUsers int
+		concurrent    func(tx repository.Transaction) error
+		wantRequest   bool
+		wantWaitlist  bool
+		wantUserIDs   []model.UserID
+		wantOutbox    []model.DomainEventType
+		wantErr       error
+	}{
+		{
+			name:          "Adds the user to an open group",
+			in:            &dto.JoinGroupInput{GroupID: "TEST_GROUP_ID_OPEN", UserID: "TEST_USER_ID_2"},
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			wantUserIDs:   []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+			wantOutbox:    []model.DomainEventType{model.DomainEventTypeGroupMembershipChanged},
+		},
+		{
+			name:          "Waitlists the user if an open group is at capacity",
+			in:            &dto.JoinGroupInput{GroupID: "TEST_GROUP_ID_OPEN", UserID: "TEST_USER_ID_2"},
+			maxGroupUsers: 1,
+			wantWaitlist:  true,
+			wantUserIDs:   []model.UserID{"TEST_USER_ID_1"},
+		},
+		{
+			name:          "Waitlists the user if an open group gets at capacity concurrently",
+			in:            &dto.JoinGroupInput{GroupID: "TEST_GROUP_ID_OPEN", UserID: "TEST_USER_ID_2"},
+			maxGroupUsers: 2,
+			concurrent: func(tx repository.Transaction) error {
+				return tx.Group().AddUsers("TEST_GROUP_ID_OPEN", []model.UserID{"TEST_USER_ID_3"})
+			},
+			wantWaitlist: true,
+			wantUserIDs:  []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_3"},
+		},
+		{
+			name:          "Requests the user to join an approval-only group",
+			in:            &dto.JoinGroupInput{GroupID: "TEST_GROUP_ID_APPROVAL", UserID: "TEST_USER_ID_3"},
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			wantRequest:   true,
+			wantUserIDs:   []model.UserID{"TEST_USER_ID_1"},
+		},
+		{
+			name:          "Returns error if the group is invite-only",
+			in:            &dto.JoinGroupInput{GroupID: "TEST_GROUP_ID_INVITE_ONLY", UserID: "TEST_USER_ID_3"},
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			wantErr:       usecase.ErrGroupJoinNotAllowed,
+		},
+		{
+			name:          "Returns error if the user has a pending request to the group",
+			in:            &dto.JoinGroupInput{GroupID: "TEST_GROUP_ID_APPROVAL", UserID: "TEST_USER_ID_2"},
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			wantErr:       usecase.ErrInvalidGroupJoinRequestInput,
+		},
+		{
+			name:          "Returns error if the user already belongs to the group",
+			in:            &dto.JoinGroupInput{GroupID: "TEST_GROUP_ID_OPEN", UserID: "TEST_USER_ID_1"},
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			wantErr:       usecase.ErrInvalidGroupJoinRequestInput,
+		},
+		{
+			name:          "Returns error if the user is suspended",
+			in:            &dto.JoinGroupInput{GroupID: "TEST_GROUP_ID_OPEN", UserID: "TEST_USER_ID_4"},
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			wantErr:       usecase.ErrUserInactive,
+		},
+		{
+			name:          "Returns error if the group does not exist",
+			in:            &dto.JoinGroupInput{GroupID: "TEST_GROUP_ID_UNKNOWN", UserID: "TEST_USER_ID_2"},
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			wantErr:       usecase.ErrGroupNotFound,
+		},
+		{
+			name:          "Returns error if the user does not exist",
+			in:            &dto.JoinGroupInput{GroupID: "TEST_GROUP_ID_OPEN", UserID: "TEST_USER_ID_UNKNOWN"},
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			wantErr:       usecase.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.in.Meta = dto.Meta{Actor: "TEST_USER_ID_2", RequestID: "TEST_REQUEST_ID"}
+			p := model.DefaultPolicy()
+			p.Group.MaxUsers = tt.maxGroupUsers
+			r := &interleavedRepository{Repository: newGroupJoinMemoryRepository(), concurrent: tt.concurrent}
+			uc := newGroupJoinUsecase(r, p)
+
+			got, err := uc.JoinGroup(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("uc.JoinGroup(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+				assertOutboxMessages(t, r)
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+
+			if got.Waitlisted != tt.wantWaitlist {
+				t.Errorf("got.Waitlisted=%t; want %t", got.Waitlisted, tt.wantWaitlist)
+			}
+			if (got.GroupJoinRequest != nil) != tt.wantRequest {
+				t.Fatalf("got.GroupJoinRequest=%v; want a request %t", got.GroupJoinRequest, tt.wantRequest)
+			}
+			if tt.wantRequest {
+				jr, err := r.GroupJoinRequest().Find(model.GroupJoinRequestID(got.GroupJoinRequest.GroupJoinRequestID))
+				if err != nil {
+					t.Fatalf("want no error, but has error %v", err)
+				}
+				if !jr.IsPending() || jr.UserID() != model.UserID(tt.in.UserID) {
+					t.Errorf("r.GroupJoinRequest().Find()=%v; want the pending request of %s", jr, tt.in.UserID)
+				}
+				if !jr.CreatedAt().Equal(testNow) {
+					t.Errorf("jr.CreatedAt()=%v; want %v", jr.CreatedAt(), testNow)
+				}
+			}
+
+			g, err := r.Group().Find(model.GroupID(tt.in.GroupID))
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if diff := cmp.Diff(g.UserIDs(), tt.wantUserIDs); diff != "" {
+				t.Errorf("g.UserIDs()=%v; want %v\ndiffers: (-got +want)\n%s", g.UserIDs(), tt.wantUserIDs, diff)
+			}
+			if g.JoinPolicy() == "" {
+				t.Error("g.JoinPolicy() is empty; want the join policy kept")
+			}
+			assertOutboxMessages(t, r, tt.wantOutbox...)
+		})
+	}
+}
+
+func TestGroupJoinUsecase_GetGroupJoinRequests(t *testing.T) {
+	uc := newGroupJoinUsecase(newGroupJoinMemoryRepository(), model.DefaultPolicy())
+
+	got, err := uc.GetGroupJoinRequests(&dto.GetGroupJoinRequestsInput{GroupID: "TEST_GROUP_ID_APPROVAL"})
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	ids := make([]string, len(got.GroupJoinRequests))
+	for i, jr := range got.GroupJoinRequests {
+		ids[i] = jr.GroupJoinRequestID
+	}
+	if diff := cmp.Diff(ids, []string{"TEST_GROUP_JOIN_REQUEST_ID_1"}); diff != "" {
+		t.Errorf("uc.GetGroupJoinRequests() ids differs: (-got +want)\n%s", diff)
+	}
+
+	if _, err := uc.GetGroupJoinRequests(&dto.GetGroupJoinRequestsInput{GroupID: "TEST_GROUP_ID_UNKNOWN"}); !errors.Is(err, usecase.ErrGroupNotFound) {
+		t.Errorf("uc.GetGroupJoinRequests(unknown)=_, %v; want _, %v", err, usecase.ErrGroupNotFound)
+	}
+}
+
+func TestGroupJoinUsecase_ApproveGroupJoinRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		in            *dto.ApproveGroupJoinRequestInput
+		actor         string
+		maxGroupUsers int
+		want          *dto.ApproveGroupJoinRequestOutput
+		wantUserIDs   []model.UserID
+		wantWaitlist  []model.UserID
+		wantOutbox    []model.DomainEventType
+		wantErr       error
+	}{
+		{
+			name: "Adds the user of the request to the group",
+			in: &dto.ApproveGroupJoinRequestInput{
+				GroupID:            "TEST_GROUP_ID_APPROVAL",
+				GroupJoinRequestID: "TEST_GROUP_JOIN_REQUEST_ID_1",
+			},
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			want:          &dto.ApproveGroupJoinRequestOutput{Waitlisted: false},
+			wantUserIDs:   []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+			wantOutbox:    []model.DomainEventType{model.DomainEventTypeGroupMembershipChanged},
+		},
+		{
+			name: "Waitlists the user of the request if the group is at capacity",
+			in: &dto.ApproveGroupJoinRequestInput{
+				GroupID:            "TEST_GROUP_ID_APPROVAL",
+				GroupJoinRequestID: "TEST_GROUP_JOIN_REQUEST_ID_1",
+			},
+			maxGroupUsers: 1,
+			want:          &dto.ApproveGroupJoinRequestOutput{Waitlisted: true},
+			wantUserIDs:   []model.UserID{"TEST_USER_ID_1"},
+			wantWaitlist:  []model.UserID{"TEST_USER_ID_2"},
+		},
+		{
+			name: "Returns error if the group has become invite-only",
+			in: &dto.ApproveGroupJoinRequestInput{
+				GroupID:            "TEST_GROUP_ID_INVITE_ONLY",
+				GroupJoinRequestID: "TEST_GROUP_JOIN_REQUEST_ID_INVITE_ONLY",
+			},
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			wantErr:       usecase.ErrGroupJoinNotAllowed,
+		},
+		{
+			name: "Returns error if the request has been rejected",
+			in: &dto.ApproveGroupJoinRequestInput{
+				GroupID:            "TEST_GROUP_ID_APPROVAL",
+				GroupJoinRequestID: "TEST_GROUP_JOIN_REQUEST_ID_REJECTED",
+			},
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			wantErr:       usecase.ErrGroupJoinRequestNotPending,
+		},
+		{
+			name: "Returns error if the request is not to the group",
+			in: &dto.ApproveGroupJoinRequestInput{
+				GroupID:            "TEST_GROUP_ID_OPEN",
+				GroupJoinRequestID: "TEST_GROUP_JOIN_REQUEST_ID_1",
+			},
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			wantErr:       usecase.ErrGroupJoinRequestNotFound,
+		},
+		{
+			name: "Adds the user of the request approved by an admin who is not a member",
+			in: &dto.ApproveGroupJoinRequestInput{
+				GroupID:            "TEST_GROUP_ID_APPROVAL",
+				GroupJoinRequestID: "TEST_GROUP_JOIN_REQUEST_ID_1",
+			},
+			actor:         "TEST_ADMIN_ID",
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			want:          &dto.ApproveGroupJoinRequestOutput{Waitlisted: false},
+			wantUserIDs:   []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"},
+			wantOutbox:    []model.DomainEventType{model.DomainEventTypeGroupMembershipChanged},
+		},
+		{
+			name: "Returns error if the actor is neither a member nor an admin",
+			in: &dto.ApproveGroupJoinRequestInput{
+				GroupID:            "TEST_GROUP_ID_APPROVAL",
+				GroupJoinRequestID: "TEST_GROUP_JOIN_REQUEST_ID_1",
+			},
+			actor:         "TEST_USER_ID_3",
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			wantErr:       usecase.ErrGroupJoinRequestForbidden,
+		},
+		{
+			name: "Returns error if there is no actor",
+			in: &dto.ApproveGroupJoinRequestInput{
+				GroupID:            "TEST_GROUP_ID_APPROVAL",
+				GroupJoinRequestID: "TEST_GROUP_JOIN_REQUEST_ID_1",
+			},
+			actor:         "-",
+			maxGroupUsers: model.DefaultMaxGroupUsers,
+			wantErr:       usecase.ErrGroupJoinRequestForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.in.Meta = dto.Meta{Actor: testGroupJoinActor(tt.actor), RequestID: "TEST_REQUEST_ID"}
+			p := model.DefaultPolicy()
+			p.Group.MaxUsers = tt.maxGroupUsers
+			p.Admins = []model.UserID{"TEST_ADMIN_ID"}
+			r := newGroupJoinMemoryRepository()
+			uc := newGroupJoinUsecase(r, p)
+
+			got, err := uc.ApproveGroupJoinRequest(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("uc.ApproveGroupJoinRequest(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+				assertOutboxMessages(t, r)
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(
+					"uc.ApproveGroupJoinRequest(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+					tt.in, got, tt.want, diff,
+				)
+			}
+
+			jr, err := r.GroupJoinRequest().Find(model.GroupJoinRequestID(tt.in.GroupJoinRequestID))
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if st := jr.State(); st.Status != model.GroupJoinRequestStatusApproved || st.RespondedBy != tt.in.Actor ||
+				!st.RespondedAt.Equal(testNow) {
+				t.Errorf("jr.State()=%v; want approved by %s at %v", st, tt.in.Actor, testNow)
+			}
+
+			g, err := r.Group().Find(model.GroupID(tt.in.GroupID))
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if diff := cmp.Diff(g.UserIDs(), tt.wantUserIDs); diff != "" {
+				t.Errorf("g.UserIDs()=%v; want %v\ndiffers: (-got +want)\n%s", g.UserIDs(), tt.wantUserIDs, diff)
+			}
+			assertGroupWaitlist(t, r, g.ID(), tt.wantWaitlist...)
+			assertOutboxMessages(t, r, tt.wantOutbox...)
+		})
+	}
+}
+
+func TestGroupJoinUsecase_RejectGroupJoinRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      *dto.RejectGroupJoinRequestInput
+		actor   string
+		wantErr error
+	}{
+		{
+			name: "Rejects the request",
+			in: &dto.RejectGroupJoinRequestInput{
+				GroupID:            "TEST_GROUP_ID_APPROVAL",
+				GroupJoinRequestID: "TEST_GROUP_JOIN_REQUEST_ID_1",
+			},
+		},
+		{
+			name: "Rejects the request to an invite-only group",
+			in: &dto.RejectGroupJoinRequestInput{
+				GroupID:            "TEST_GROUP_ID_INVITE_ONLY",
+				GroupJoinRequestID: "TEST_GROUP_JOIN_REQUEST_ID_INVITE_ONLY",
+			},
+		},
+		{
+			name: "Returns error if the request has been rejected",
+			in: &dto.RejectGroupJoinRequestInput{
+				GroupID:            "TEST_GROUP_ID_APPROVAL",
+				GroupJoinRequestID: "TEST_GROUP_JOIN_REQUEST_ID_REJECTED",
+			},
+			wantErr: usecase.ErrGroupJoinRequestNotPending,
+		},
+		{
+			name: "Returns error if the request does not exist",
+			in: &dto.RejectGroupJoinRequestInput{
+				GroupID:            "TEST_GROUP_ID_APPROVAL",
+				GroupJoinRequestID: "TEST_GROUP_JOIN_REQUEST_ID_UNKNOWN",
+			},
+			wantErr: usecase.ErrGroupJoinRequestNotFound,
+		},
+		{
+			name: "Rejects the request by an admin who is not a member",
+			in: &dto.RejectGroupJoinRequestInput{
+				GroupID:            "TEST_GROUP_ID_APPROVAL",
+				GroupJoinRequestID: "TEST_GROUP_JOIN_REQUEST_ID_1",
+			},
+			actor: "TEST_ADMIN_ID",
+		},
+		{
+			name: "Returns error if the actor is neither a member nor an admin",
+			in: &dto.RejectGroupJoinRequestInput{
+				GroupID:            "TEST_GROUP_ID_APPROVAL",
+				GroupJoinRequestID: "TEST_GROUP_JOIN_REQUEST_ID_1",
+			},
+			actor:   "TEST_USER_ID_2",
+			wantErr: usecase.ErrGroupJoinRequestForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.in.Meta = dto.Meta{Actor: testGroupJoinActor(tt.actor), RequestID: "TEST_REQUEST_ID"}
+			p := model.DefaultPolicy()
+			p.Admins = []model.UserID{"TEST_ADMIN_ID"}
+			r := newGroupJoinMemoryRepository()
+			uc := newGroupJoinUsecase(r, p)
+
+			_, err := uc.RejectGroupJoinRequest(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("uc.RejectGroupJoinRequest(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+
+			jr, err := r.GroupJoinRequest().Find(model.GroupJoinRequestID(tt.in.GroupJoinRequestID))
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if st := jr.State(); st.Status != model.GroupJoinRequestStatusRejected || st.RespondedBy != tt.in.Actor {
+				t.Errorf("jr.State()=%v; want rejected by %s", st, tt.in.Actor)
+			}
+		})
+	}
+}
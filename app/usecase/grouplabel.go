@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// PutGroupLabels replaces all the labels of the group, which is recorded as an update of the group.
+func (uc *groupUsecase) PutGroupLabels(in *dto.PutGroupLabelsInput) (*dto.PutGroupLabelsOutput, error) {
+	before, err := uc.r.Group().Find(model.GroupID(in.GroupID))
+	if err != nil {
+		return nil, err
+	}
+	if before == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	after, err := copyGroup(before)
+	if err != nil {
+		return nil, err
+	}
+	if err := after.ChangeLabels(in.Labels); err != nil {
+		return nil, errors.Join(ErrInvalidGroupInput, err)
+	}
+	// The labels may move the group to the policy of another type or tenant, whose name rules it must meet.
+	// Its members stay even beyond the capacity of the policy, which only keeps more users from joining.
+	if err := uc.p.GroupPolicyFor(after).ValidateName(after.Name()); err != nil {
+		return nil, errors.Join(ErrInvalidGroupInput, err)
+	}
+	at := now(uc.c)
+	after.ChangeTimestamps(before.Timestamps().Updated(in.Actor, at))
+
+	e, err := uc.af.Create(
+		in.Actor,
+		in.RequestID,
+		model.AuditActionUpdateGroup,
+		model.NewGroupAuditTarget(after.ID()),
+		model.DiffGroup(before, after),
+		at,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	after.RecordUpdated()
+	ms, err := uc.of.Create(pullEvents(after), at)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		if err := tx.Group().ReplaceLabels(after.ID(), after.Labels()); err != nil {
+			return err
+		}
+		if err := tx.Group().Update(after); err != nil {
+			return err
+		}
+		if _, err := tx.AuditEvent().Create(e); err != nil {
+			return err
+		}
+		if err := tx.OutboxMessage().Create(ms); err != nil {
+			return err
+		}
+		return enqueueWebhookDeliveries(tx, uc.wf, ms)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.PutGroupLabelsOutput{
+		Labels: after.Labels(),
+	}, nil
+}
@@ -0,0 +1,165 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/memory"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// newLabelMemoryRepository returns a repository of TEST_GROUP_ID_1 labeled team=payments,env=prod and
+// TEST_GROUP_ID_2 labeled team=search.
+func newLabelMemoryRepository() repository.Repository {
+	g1 := model.MustNewGroup("TEST_GROUP_ID_1", "TEST_GROUP_NAME_1", []model.UserID{})
+	if err := g1.ChangeLabels(model.Labels{"team": "payments", "env": "prod"}); err != nil {
+		panic(err)
+	}
+	g2 := model.MustNewGroup("TEST_GROUP_ID_2", "TEST_GROUP_NAME_2", []model.UserID{})
+	if err := g2.ChangeLabels(model.Labels{"team": "search"}); err != nil {
+		panic(err)
+	}
+
+	s := memory.NewStore()
+	s.AddGroups(g1, g2)
+	return memory.NewMemoryRepository(s)
+}
+
+func TestGroupUsecase_PutGroupLabels(t *testing.T) {
+	tests := []struct {
+		name       string
+		in         *dto.PutGroupLabelsInput
+		wantLabels model.Labels
+		wantErr    error
+	}{
+		{
+			name: "Replaces the labels of the group",
+			in: &dto.PutGroupLabelsInput{
+				Meta:    dto.Meta{Actor: "TEST_ACTOR", RequestID: "TEST_REQUEST_ID"},
+				GroupID: "TEST_GROUP_ID_1",
+				Labels:  map[string]string{"team": "billing", "example.com/tier": "1"},
+			},
+			wantLabels: model.Labels{"team": "billing", "example.com/tier": "1"},
+		},
+		{
+			name: "Removes the labels of the group if they are empty",
+			in: &dto.PutGroupLabelsInput{
+				GroupID: "TEST_GROUP_ID_1",
+				Labels:  map[string]string{},
+			},
+			wantLabels: nil,
+		},
+		{
+			name: "Returns error if a label is invalid",
+			in: &dto.PutGroupLabelsInput{
+				GroupID: "TEST_GROUP_ID_1",
+				Labels:  map[string]string{"team": "-payments"},
+			},
+			wantLabels: model.Labels{"team": "payments", "env": "prod"},
+			wantErr:    usecase.ErrInvalidGroupInput,
+		},
+		{
+			name:    "Returns error if the group does not exist",
+			in:      &dto.PutGroupLabelsInput{GroupID: "TEST_GROUP_ID_UNKNOWN"},
+			wantErr: usecase.ErrGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newLabelMemoryRepository()
+			uc := newGroupWaitlistUsecase(t, r)
+
+			out, err := uc.PutGroupLabels(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("uc.PutGroupLabels(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+			}
+			if tt.wantErr == nil {
+				if diff := cmp.Diff(model.Labels(out.Labels), tt.wantLabels); diff != "" {
+					t.Errorf("out.Labels differs: (-got +want)\n%s", diff)
+				}
+				assertAuditEvent(t, r, tt.in.Meta, model.AuditActionUpdateGroup, model.NewGroupAuditTarget(model.GroupID(tt.in.GroupID)))
+			}
+
+			g, err := r.Group().Find(model.GroupID(tt.in.GroupID))
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if diff := cmp.Diff(g.Labels(), tt.wantLabels); diff != "" {
+				t.Errorf("g.Labels() differs: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGroupUsecase_UpdateGroup_labels(t *testing.T) {
+	r := newLabelMemoryRepository()
+	uc := newGroupWaitlistUsecase(t, r)
+
+	in := &dto.UpdateGroupInput{GroupID: "TEST_GROUP_ID_1", Name: "TEST_GROUP_NAME_UPDATED"}
+	if _, err := uc.UpdateGroup(in); err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+
+	g, err := r.Group().Find("TEST_GROUP_ID_1")
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	want := model.Labels{"team": "payments", "env": "prod"}
+	if diff := cmp.Diff(g.Labels(), want); diff != "" {
+		t.Errorf("g.Labels() differs: (-got +want)\n%s", diff)
+	}
+}
+
+func TestGroupUsecase_GetGroups_labelSelector(t *testing.T) {
+	tests := []struct {
+		name          string
+		labelSelector string
+		want          []string
+		wantErr       error
+	}{
+		{
+			name:          "Returns the groups whose labels meet the selector",
+			labelSelector: "team in (payments,billing),env=prod",
+			want:          []string{"TEST_GROUP_ID_1"},
+		},
+		{
+			name:          "Returns the groups without the label",
+			labelSelector: "!env",
+			want:          []string{"TEST_GROUP_ID_2"},
+		},
+		{
+			name:          "Returns error if the selector is invalid",
+			labelSelector: "team in payments",
+			wantErr:       usecase.ErrInvalidGroupInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := newGroupWaitlistUsecase(t, newLabelMemoryRepository())
+
+			in := &dto.GetGroupsInput{LabelSelector: tt.labelSelector}
+			out, err := uc.GetGroups(in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("uc.GetGroups(%v)=_, %v; want _, %v", in, err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			var got []string
+			for _, g := range out.Groups {
+				got = append(got, g.GroupID)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("uc.GetGroups(%v) group ids=%v; want %v\ndiffers: (-got +want)\n%s", in, got, tt.want, diff)
+			}
+		})
+	}
+}
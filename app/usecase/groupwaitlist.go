@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func (uc *groupUsecase) GetGroupWaitlist(in *dto.GetGroupWaitlistInput) (*dto.GetGroupWaitlistOutput, error) {
+	w, err := uc.findGroupWaitlist(model.GroupID(in.GroupID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.GetGroupWaitlistOutput{
+		UserIDs: dto.ToUserIDsFromModel(w.UserIDs()),
+	}, nil
+}
+
+// ReorderGroupWaitlist orders the waitlist as the input, which must have every waitlisted user once.
+func (uc *groupUsecase) ReorderGroupWaitlist(
+	in *dto.ReorderGroupWaitlistInput,
+) (*dto.ReorderGroupWaitlistOutput, error) {
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		w, err := findGroupWaitlistForUpdate(tx, model.GroupID(in.GroupID))
+		if err != nil {
+			return err
+		}
+
+		if err := w.Reorder(dto.ToModelUserIDs(in.UserIDs)); err != nil {
+			return errors.Join(ErrInvalidGroupWaitlistInput, err)
+		}
+		return tx.GroupWaitlist().Save(w)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.ReorderGroupWaitlistOutput{}, nil
+}
+
+// LeaveGroupWaitlist takes the user off the waitlist. It does nothing if the user is not waitlisted.
+func (uc *groupUsecase) LeaveGroupWaitlist(in *dto.LeaveGroupWaitlistInput) (*dto.LeaveGroupWaitlistOutput, error) {
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		w, err := findGroupWaitlistForUpdate(tx, model.GroupID(in.GroupID))
+		if err != nil {
+			return err
+		}
+
+		uID := model.UserID(in.UserID)
+		if !w.Has(uID) {
+			return nil
+		}
+		w.Remove([]model.UserID{uID})
+		return tx.GroupWaitlist().Save(w)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.LeaveGroupWaitlistOutput{}, nil
+}
+
+// findGroupWaitlist returns the waitlist of the group, which must exist.
+func (uc *groupUsecase) findGroupWaitlist(gID model.GroupID) (*model.GroupWaitlist, error) {
+	g, err := uc.r.Group().Find(gID)
+	if err != nil {
+		return nil, err
+	}
+	if g == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	return uc.r.GroupWaitlist().Find(gID)
+}
+
+// findGroupWaitlistForUpdate returns the waitlist of the group, which must exist, locking the group until
+// the transaction ends.
+func findGroupWaitlistForUpdate(tx repository.Transaction, gID model.GroupID) (*model.GroupWaitlist, error) {
+	if _, err := findGroupForUpdate(tx, gID); err != nil {
+		return nil, err
+	}
+
+	return tx.GroupWaitlist().Find(gID)
+}
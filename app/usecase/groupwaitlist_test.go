@@ -0,0 +1,301 @@
+package usecase_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/domainservice"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/memory"
+	mockfactory "github.com/toshiykst/go-layerd-architecture/app/mock/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func newGroupWaitlistMemoryRepository() repository.Repository {
+	s := memory.NewStore()
+	s.AddGroups(model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", []model.UserID{"TEST_USER_ID_1"}))
+	s.AddGroupWaitlists(model.MustNewGroupWaitlist(
+		"TEST_GROUP_ID",
+		[]model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_3"},
+	))
+	return memory.NewMemoryRepository(s)
+}
+
+func newGroupWaitlistUsecase(t *testing.T, r repository.Repository) usecase.GroupUsecase {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	return usecase.NewGroupUsecase(
+		r,
+		mockfactory.NewMockGroupFactory(ctrl),
+		domainservice.NewGroupService(r),
+		domainservice.NewUserService(r),
+		factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+		factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+		factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+		model.DefaultPolicy(),
+		testClock,
+	)
+}
+
+func TestGroupUsecase_GetGroupWaitlist(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      *dto.GetGroupWaitlistInput
+		want    *dto.GetGroupWaitlistOutput
+		wantErr error
+	}{
+		{
+			name: "Returns the waitlisted users in order",
+			in:   &dto.GetGroupWaitlistInput{GroupID: "TEST_GROUP_ID"},
+			want: &dto.GetGroupWaitlistOutput{UserIDs: []string{"TEST_USER_ID_2", "TEST_USER_ID_3"}},
+		},
+		{
+			name:    "Returns error if the group does not exist",
+			in:      &dto.GetGroupWaitlistInput{GroupID: "TEST_GROUP_ID_UNKNOWN"},
+			wantErr: usecase.ErrGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := newGroupWaitlistUsecase(t, newGroupWaitlistMemoryRepository())
+
+			got, err := uc.GetGroupWaitlist(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("uc.GetGroupWaitlist(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(
+					"uc.GetGroupWaitlist(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+					tt.in, got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
+
+func TestGroupUsecase_ReorderGroupWaitlist(t *testing.T) {
+	tests := []struct {
+		name         string
+		in           *dto.ReorderGroupWaitlistInput
+		wantWaitlist []model.UserID
+		wantErr      error
+	}{
+		{
+			name: "Reorders the waitlist",
+			in: &dto.ReorderGroupWaitlistInput{
+				GroupID: "TEST_GROUP_ID",
+				UserIDs: []string{"TEST_USER_ID_3", "TEST_USER_ID_2"},
+			},
+			wantWaitlist: []model.UserID{"TEST_USER_ID_3", "TEST_USER_ID_2"},
+		},
+		{
+			name: "Returns error if the order misses a waitlisted user",
+			in: &dto.ReorderGroupWaitlistInput{
+				GroupID: "TEST_GROUP_ID",
+				UserIDs: []string{"TEST_USER_ID_3"},
+			},
+			wantWaitlist: []model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_3"},
+			wantErr:      usecase.ErrInvalidGroupWaitlistInput,
+		},
+		{
+			name: "Returns error if the order has a user who is not waitlisted",
+			in: &dto.ReorderGroupWaitlistInput{
+				GroupID: "TEST_GROUP_ID",
+				UserIDs: []string{"TEST_USER_ID_3", "TEST_USER_ID_1"},
+			},
+			wantWaitlist: []model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_3"},
+			wantErr:      usecase.ErrInvalidGroupWaitlistInput,
+		},
+		{
+			name:    "Returns error if the group does not exist",
+			in:      &dto.ReorderGroupWaitlistInput{GroupID: "TEST_GROUP_ID_UNKNOWN"},
+			wantErr: usecase.ErrGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newGroupWaitlistMemoryRepository()
+			uc := newGroupWaitlistUsecase(t, r)
+
+			_, err := uc.ReorderGroupWaitlist(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("uc.ReorderGroupWaitlist(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			assertGroupWaitlist(t, r, model.GroupID(tt.in.GroupID), tt.wantWaitlist...)
+		})
+	}
+}
+
+func TestGroupUsecase_LeaveGroupWaitlist(t *testing.T) {
+	tests := []struct {
+		name         string
+		in           *dto.LeaveGroupWaitlistInput
+		wantWaitlist []model.UserID
+		wantErr      error
+	}{
+		{
+			name:         "Takes the user off the waitlist",
+			in:           &dto.LeaveGroupWaitlistInput{GroupID: "TEST_GROUP_ID", UserID: "TEST_USER_ID_2"},
+			wantWaitlist: []model.UserID{"TEST_USER_ID_3"},
+		},
+		{
+			name:         "Does nothing if the user is not waitlisted",
+			in:           &dto.LeaveGroupWaitlistInput{GroupID: "TEST_GROUP_ID", UserID: "TEST_USER_ID_1"},
+			wantWaitlist: []model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_3"},
+		},
+		{
+			name:    "Returns error if the group does not exist",
+			in:      &dto.LeaveGroupWaitlistInput{GroupID: "TEST_GROUP_ID_UNKNOWN", UserID: "TEST_USER_ID_2"},
+			wantErr: usecase.ErrGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newGroupWaitlistMemoryRepository()
+			uc := newGroupWaitlistUsecase(t, r)
+
+			_, err := uc.LeaveGroupWaitlist(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("uc.LeaveGroupWaitlist(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			assertGroupWaitlist(t, r, model.GroupID(tt.in.GroupID), tt.wantWaitlist...)
+		})
+	}
+}
+
+func TestGroupUsecase_keepsConcurrentWaitlistChange(t *testing.T) {
+	tests := []struct {
+		name         string
+		run          func(uc usecase.GroupUsecase) error
+		wantUserIDs  []model.UserID
+		wantWaitlist []model.UserID
+	}{
+		{
+			name: "AddGroupUsers",
+			run: func(uc usecase.GroupUsecase) error {
+				_, err := uc.AddGroupUsers(&dto.AddGroupUsersInput{
+					GroupID: "TEST_GROUP_ID",
+					UserIDs: []string{"TEST_USER_ID_4"},
+				})
+				return err
+			},
+			wantUserIDs:  []model.UserID{"TEST_USER_ID_1"},
+			wantWaitlist: []model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_3", "TEST_USER_ID_4"},
+		},
+		{
+			name: "RemoveGroupUsers",
+			run: func(uc usecase.GroupUsecase) error {
+				_, err := uc.RemoveGroupUsers(&dto.RemoveGroupUsersInput{
+					GroupID: "TEST_GROUP_ID",
+					UserIDs: []string{"TEST_USER_ID_1"},
+				})
+				return err
+			},
+			wantUserIDs:  []model.UserID{"TEST_USER_ID_2"},
+			wantWaitlist: []model.UserID{"TEST_USER_ID_3"},
+		},
+		{
+			name: "PatchGroup",
+			run: func(uc usecase.GroupUsecase) error {
+				_, err := uc.PatchGroup(&dto.PatchGroupInput{
+					GroupID:   "TEST_GROUP_ID",
+					PatchType: dto.PatchTypeMergePatch,
+					Patch:     []byte(`{"userIds":["TEST_USER_ID_4"]}`),
+				})
+				return err
+			},
+			wantUserIDs:  []model.UserID{"TEST_USER_ID_2"},
+			wantWaitlist: []model.UserID{"TEST_USER_ID_3", "TEST_USER_ID_4"},
+		},
+		{
+			name: "LeaveGroupWaitlist",
+			run: func(uc usecase.GroupUsecase) error {
+				_, err := uc.LeaveGroupWaitlist(&dto.LeaveGroupWaitlistInput{
+					GroupID: "TEST_GROUP_ID",
+					UserID:  "TEST_USER_ID_2",
+				})
+				return err
+			},
+			wantUserIDs:  []model.UserID{"TEST_USER_ID_1"},
+			wantWaitlist: []model.UserID{"TEST_USER_ID_3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := memory.NewStore()
+			for i := 1; i <= 4; i++ {
+				s.AddUsers(model.MustNewUser(
+					model.UserID(fmt.Sprintf("TEST_USER_ID_%d", i)),
+					fmt.Sprintf("TEST_USER_NAME_%d", i),
+					fmt.Sprintf("TEST_USER_EMAIL_%d", i),
+				))
+			}
+			s.AddGroups(model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", []model.UserID{"TEST_USER_ID_1"}))
+			s.AddGroupWaitlists(model.MustNewGroupWaitlist("TEST_GROUP_ID", []model.UserID{"TEST_USER_ID_2"}))
+			r := &interleavedRepository{
+				Repository: memory.NewMemoryRepository(s),
+				concurrent: func(tx repository.Transaction) error {
+					return tx.GroupWaitlist().Save(
+						model.MustNewGroupWaitlist("TEST_GROUP_ID", []model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_3"}),
+					)
+				},
+			}
+
+			ctrl := gomock.NewController(t)
+			p := model.DefaultPolicy()
+			p.Group.MaxUsers = 1
+			uc := usecase.NewGroupUsecase(
+				r,
+				mockfactory.NewMockGroupFactory(ctrl),
+				domainservice.NewGroupService(r),
+				domainservice.NewUserService(r),
+				factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+				factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+				factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+				p,
+				testClock,
+			)
+
+			if err := tt.run(uc); err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+
+			g, err := r.Group().Find("TEST_GROUP_ID")
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if diff := cmp.Diff(g.UserIDs(), tt.wantUserIDs); diff != "" {
+				t.Errorf("g.UserIDs()=%v; want %v\ndiffers: (-got +want)\n%s", g.UserIDs(), tt.wantUserIDs, diff)
+			}
+			assertGroupWaitlist(t, r, "TEST_GROUP_ID", tt.wantWaitlist...)
+		})
+	}
+}
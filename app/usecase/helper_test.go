@@ -0,0 +1,117 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// testNow is the time of testClock, at which the users and the groups are created or updated in the tests.
+var testNow = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+var testClock = usecase.ClockFunc(func() time.Time { return testNow })
+
+func assertAuditEvent(
+	t *testing.T,
+	r repository.Repository,
+	meta dto.Meta,
+	action model.AuditAction,
+	target model.AuditTarget,
+) {
+	t.Helper()
+
+	es, err := r.AuditEvent().List(repository.AuditEventListFilter{
+		TargetType: target.Type,
+		TargetID:   target.ID,
+	})
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	if len(es) != 1 {
+		t.Fatalf("len(r.AuditEvent().List(%v))=%d; want 1", target, len(es))
+	}
+
+	e := es[0]
+	if e.Action() != action {
+		t.Errorf("e.Action()=%s; want %s", e.Action(), action)
+	}
+	if e.Actor() != meta.Actor {
+		t.Errorf("e.Actor()=%s; want %s", e.Actor(), meta.Actor)
+	}
+	if e.RequestID() != meta.RequestID {
+		t.Errorf("e.RequestID()=%s; want %s", e.RequestID(), meta.RequestID)
+	}
+	if !e.OccurredAt().Equal(testNow) {
+		t.Errorf("e.OccurredAt()=%v; want %v", e.OccurredAt(), testNow)
+	}
+}
+
+func assertOutboxMessages(t *testing.T, r repository.Repository, want ...model.DomainEventType) {
+	t.Helper()
+
+	ms, err := r.OutboxMessage().List(repository.OutboxMessageListFilter{})
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+
+	got := make([]model.DomainEventType, len(ms))
+	for i, m := range ms {
+		got[i] = m.EventType()
+		if !m.OccurredAt().Equal(testNow) {
+			t.Errorf("m.OccurredAt()=%v; want %v", m.OccurredAt(), testNow)
+		}
+	}
+	if len(got) == 0 && len(want) == 0 {
+		return
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("r.OutboxMessage().List() event types=%v; want %v\ndiffers: (-got +want)\n%s", got, want, diff)
+	}
+}
+
+func assertGroupWaitlist(t *testing.T, r repository.Repository, gID model.GroupID, want ...model.UserID) {
+	t.Helper()
+
+	w, err := r.GroupWaitlist().Find(gID)
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+
+	got := w.UserIDs()
+	if len(got) == 0 && len(want) == 0 {
+		return
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("r.GroupWaitlist().Find(%s) user ids=%v; want %v\ndiffers: (-got +want)\n%s", gID, got, want, diff)
+	}
+}
+
+// stamped changes the timestamps of the user or the group, for the ones expected to be stored.
+func stamped[T interface{ ChangeTimestamps(ts model.Timestamps) }](x T, ts model.Timestamps) T {
+	x.ChangeTimestamps(ts)
+	return x
+}
+
+// interleavedRepository commits a concurrent change just before the first transaction run on it, as if another
+// request committed it between the reads of a usecase and the transaction of the usecase.
+type interleavedRepository struct {
+	repository.Repository
+	concurrent func(tx repository.Transaction) error
+}
+
+func (r *interleavedRepository) RunTransaction(f func(repository.Transaction) error) error {
+	if r.concurrent != nil {
+		concurrent := r.concurrent
+		r.concurrent = nil
+		if err := r.Repository.RunTransaction(concurrent); err != nil {
+			return err
+		}
+	}
+	return r.Repository.RunTransaction(f)
+}
@@ -0,0 +1,137 @@
+//go:generate mockgen -source=$GOFILE -package=mock$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// IdempotencyUsecase keeps the idempotency keys of the requests, so that a retried request is not performed twice
+// but responded with the response to the first one.
+type IdempotencyUsecase interface {
+	BeginIdempotentRequest(in *dto.BeginIdempotentRequestInput) (*dto.BeginIdempotentRequestOutput, error)
+	CompleteIdempotentRequest(in *dto.CompleteIdempotentRequestInput) (*dto.CompleteIdempotentRequestOutput, error)
+	ReleaseIdempotentRequest(in *dto.ReleaseIdempotentRequestInput) (*dto.ReleaseIdempotentRequestOutput, error)
+	PurgeIdempotencyKeys(in *dto.PurgeIdempotencyKeysInput) (*dto.PurgeIdempotencyKeysOutput, error)
+}
+
+type idempotencyUsecase struct {
+	r   repository.Repository
+	ttl time.Duration
+	c   Clock
+}
+
+// NewIdempotencyUsecase returns the usecase which keeps the idempotency keys for ttl after their first requests.
+func NewIdempotencyUsecase(r repository.Repository, ttl time.Duration, c Clock) IdempotencyUsecase {
+	return &idempotencyUsecase{r: r, ttl: ttl, c: c}
+}
+
+func (uc *idempotencyUsecase) BeginIdempotentRequest(
+	in *dto.BeginIdempotentRequestInput,
+) (*dto.BeginIdempotentRequestOutput, error) {
+	at := now(uc.c)
+	nk, err := model.NewIdempotencyKey(in.Key, in.Fingerprint, model.IdempotentResponse{}, at, at.Add(uc.ttl))
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidIdempotencyKey) {
+			return nil, errors.Join(ErrInvalidIdempotencyKeyInput, err)
+		}
+		return nil, err
+	}
+
+	var replay *dto.IdempotentResponse
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		k, err := tx.IdempotencyKey().Find(in.Key)
+		if err != nil {
+			return err
+		}
+		if k.IsExpired(at) {
+			if err := tx.IdempotencyKey().Delete(in.Key); err != nil {
+				return err
+			}
+			k = nil
+		}
+
+		if k != nil {
+			if k.Fingerprint() != in.Fingerprint {
+				return ErrIdempotencyKeyMismatch
+			}
+			if !k.IsCompleted() {
+				return ErrIdempotencyKeyInProgress
+			}
+			res := dto.ToIdempotentResponseFromModel(k.Response())
+			replay = &res
+			return nil
+		}
+
+		// A concurrent request with the key may have created it since the lookup.
+		if err := tx.IdempotencyKey().Create(nk); err != nil {
+			if errors.Is(err, repository.ErrIdempotencyKeyExists) {
+				return ErrIdempotencyKeyInProgress
+			}
+			return err
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.BeginIdempotentRequestOutput{Replay: replay}, nil
+}
+
+func (uc *idempotencyUsecase) CompleteIdempotentRequest(
+	in *dto.CompleteIdempotentRequestInput,
+) (*dto.CompleteIdempotentRequestOutput, error) {
+	if in.Response.StatusCode == 0 {
+		return nil, errors.Join(ErrInvalidIdempotencyKeyInput, errors.New("status code must not empty"))
+	}
+
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		k, err := tx.IdempotencyKey().Find(in.Key)
+		if err != nil {
+			return err
+		}
+		// The key has expired and been purged while the request was performed.
+		if k == nil {
+			return nil
+		}
+
+		k.Complete(dto.ToModelIdempotentResponse(in.Response))
+		return tx.IdempotencyKey().Update(k)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.CompleteIdempotentRequestOutput{}, nil
+}
+
+// ReleaseIdempotentRequest forgets the key of a request which has not been completed, so that it can be retried.
+func (uc *idempotencyUsecase) ReleaseIdempotentRequest(
+	in *dto.ReleaseIdempotentRequestInput,
+) (*dto.ReleaseIdempotentRequestOutput, error) {
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		return tx.IdempotencyKey().Delete(in.Key)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.ReleaseIdempotentRequestOutput{}, nil
+}
+
+func (uc *idempotencyUsecase) PurgeIdempotencyKeys(
+	_ *dto.PurgeIdempotencyKeysInput,
+) (*dto.PurgeIdempotencyKeysOutput, error) {
+	var n int
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		var err error
+		n, err = tx.IdempotencyKey().DeleteExpired(now(uc.c))
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.PurgeIdempotencyKeysOutput{Purged: n}, nil
+}
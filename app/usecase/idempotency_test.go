@@ -0,0 +1,251 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/memory"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestIdempotencyUsecase_BeginIdempotentRequest(t *testing.T) {
+	now := testNow
+	completed := model.IdempotentResponse{StatusCode: 201, ContentType: "application/json", Body: []byte(`{}`)}
+	tests := []struct {
+		name        string
+		keys        model.IdempotencyKeys
+		in          *dto.BeginIdempotentRequestInput
+		want        *dto.BeginIdempotentRequestOutput
+		wantErr     error
+		wantStarted bool
+	}{
+		{
+			name:        "Begins a request with a new key",
+			in:          &dto.BeginIdempotentRequestInput{Key: "TEST_IDEMPOTENCY_KEY", Fingerprint: "TEST_FINGERPRINT"},
+			want:        &dto.BeginIdempotentRequestOutput{},
+			wantStarted: true,
+		},
+		{
+			name: "Replays the response to the completed request",
+			keys: model.IdempotencyKeys{
+				model.MustNewIdempotencyKey("TEST_IDEMPOTENCY_KEY", "TEST_FINGERPRINT", completed, now, now.Add(time.Hour)),
+			},
+			in: &dto.BeginIdempotentRequestInput{Key: "TEST_IDEMPOTENCY_KEY", Fingerprint: "TEST_FINGERPRINT"},
+			want: &dto.BeginIdempotentRequestOutput{
+				Replay: &dto.IdempotentResponse{StatusCode: 201, ContentType: "application/json", Body: []byte(`{}`)},
+			},
+		},
+		{
+			name: "Begins a request with an expired key",
+			keys: model.IdempotencyKeys{
+				model.MustNewIdempotencyKey(
+					"TEST_IDEMPOTENCY_KEY", "TEST_OTHER_FINGERPRINT", completed, now.Add(-2*time.Hour), now.Add(-time.Hour),
+				),
+			},
+			in:          &dto.BeginIdempotentRequestInput{Key: "TEST_IDEMPOTENCY_KEY", Fingerprint: "TEST_FINGERPRINT"},
+			want:        &dto.BeginIdempotentRequestOutput{},
+			wantStarted: true,
+		},
+		{
+			name: "Error the key is used for another request",
+			keys: model.IdempotencyKeys{
+				model.MustNewIdempotencyKey(
+					"TEST_IDEMPOTENCY_KEY", "TEST_OTHER_FINGERPRINT", completed, now, now.Add(time.Hour),
+				),
+			},
+			in:      &dto.BeginIdempotentRequestInput{Key: "TEST_IDEMPOTENCY_KEY", Fingerprint: "TEST_FINGERPRINT"},
+			wantErr: usecase.ErrIdempotencyKeyMismatch,
+		},
+		{
+			name: "Error the request is in progress",
+			keys: model.IdempotencyKeys{
+				model.MustNewIdempotencyKey(
+					"TEST_IDEMPOTENCY_KEY", "TEST_FINGERPRINT", model.IdempotentResponse{}, now, now.Add(time.Hour),
+				),
+			},
+			in:      &dto.BeginIdempotentRequestInput{Key: "TEST_IDEMPOTENCY_KEY", Fingerprint: "TEST_FINGERPRINT"},
+			wantErr: usecase.ErrIdempotencyKeyInProgress,
+		},
+		{
+			name:    "Error empty key",
+			in:      &dto.BeginIdempotentRequestInput{Fingerprint: "TEST_FINGERPRINT"},
+			wantErr: usecase.ErrInvalidIdempotencyKeyInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := memory.NewStore()
+			s.AddIdempotencyKeys(tt.keys...)
+			r := memory.NewMemoryRepository(s)
+			uc := usecase.NewIdempotencyUsecase(r, time.Hour, testClock)
+
+			got, err := uc.BeginIdempotentRequest(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("uc.BeginIdempotentRequest(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(
+					"uc.BeginIdempotentRequest(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+					tt.in, got, tt.want, diff,
+				)
+			}
+
+			if tt.wantStarted {
+				k := findIdempotencyKey(t, r, tt.in.Key)
+				if k.Fingerprint() != tt.in.Fingerprint || k.IsCompleted() {
+					t.Errorf("r.IdempotencyKey().Find(%s)=%v; want the key in progress", tt.in.Key, k)
+				}
+				if want := now.Add(time.Hour); !k.ExpiresAt().Equal(want) {
+					t.Errorf("k.ExpiresAt()=%v; want %v", k.ExpiresAt(), want)
+				}
+			}
+		})
+	}
+}
+
+// concurrentKeyRepository misses the idempotency keys on lookup within the transactions,
+// as if a concurrent request created the key after the lookup and before the creation.
+type concurrentKeyRepository struct {
+	repository.Repository
+}
+
+func (r concurrentKeyRepository) RunTransaction(f func(repository.Transaction) error) error {
+	return r.Repository.RunTransaction(func(tx repository.Transaction) error {
+		return f(concurrentKeyTransaction{tx})
+	})
+}
+
+type concurrentKeyTransaction struct {
+	repository.Transaction
+}
+
+func (tx concurrentKeyTransaction) IdempotencyKey() repository.IdempotencyKeyRepositoryCommand {
+	return missingIdempotencyKeyRepository{tx.Transaction.IdempotencyKey()}
+}
+
+type missingIdempotencyKeyRepository struct {
+	repository.IdempotencyKeyRepositoryCommand
+}
+
+func (missingIdempotencyKeyRepository) Find(string) (*model.IdempotencyKey, error) {
+	return nil, nil
+}
+
+func TestIdempotencyUsecase_BeginIdempotentRequest_Concurrent(t *testing.T) {
+	now := testNow
+	s := memory.NewStore()
+	s.AddIdempotencyKeys(model.MustNewIdempotencyKey(
+		"TEST_IDEMPOTENCY_KEY", "TEST_FINGERPRINT", model.IdempotentResponse{}, now, now.Add(time.Hour),
+	))
+	r := memory.NewMemoryRepository(s)
+	uc := usecase.NewIdempotencyUsecase(concurrentKeyRepository{r}, time.Hour, testClock)
+
+	in := &dto.BeginIdempotentRequestInput{Key: "TEST_IDEMPOTENCY_KEY", Fingerprint: "TEST_FINGERPRINT"}
+	if _, err := uc.BeginIdempotentRequest(in); !errors.Is(err, usecase.ErrIdempotencyKeyInProgress) {
+		t.Fatalf("uc.BeginIdempotentRequest(%v)=_, %v; want _, %v", in, err, usecase.ErrIdempotencyKeyInProgress)
+	}
+	if k := findIdempotencyKey(t, r, in.Key); k.IsCompleted() {
+		t.Errorf("r.IdempotencyKey().Find(%s)=%v; want the key of the concurrent request in progress", in.Key, k)
+	}
+}
+
+func TestIdempotencyUsecase_CompleteIdempotentRequest(t *testing.T) {
+	s := memory.NewStore()
+	r := memory.NewMemoryRepository(s)
+	uc := usecase.NewIdempotencyUsecase(r, time.Hour, testClock)
+
+	if _, err := uc.BeginIdempotentRequest(&dto.BeginIdempotentRequestInput{
+		Key:         "TEST_IDEMPOTENCY_KEY",
+		Fingerprint: "TEST_FINGERPRINT",
+	}); err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+
+	res := dto.IdempotentResponse{StatusCode: 201, ContentType: "application/json", Body: []byte(`{}`)}
+	in := &dto.CompleteIdempotentRequestInput{Key: "TEST_IDEMPOTENCY_KEY", Response: res}
+	if _, err := uc.CompleteIdempotentRequest(in); err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+
+	got, err := uc.BeginIdempotentRequest(&dto.BeginIdempotentRequestInput{
+		Key:         "TEST_IDEMPOTENCY_KEY",
+		Fingerprint: "TEST_FINGERPRINT",
+	})
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	want := &dto.BeginIdempotentRequestOutput{Replay: &res}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("uc.BeginIdempotentRequest()=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s", got, want, diff)
+	}
+}
+
+func TestIdempotencyUsecase_ReleaseIdempotentRequest(t *testing.T) {
+	now := testNow
+	s := memory.NewStore()
+	s.AddIdempotencyKeys(model.MustNewIdempotencyKey(
+		"TEST_IDEMPOTENCY_KEY", "TEST_FINGERPRINT", model.IdempotentResponse{}, now, now.Add(time.Hour),
+	))
+	r := memory.NewMemoryRepository(s)
+	uc := usecase.NewIdempotencyUsecase(r, time.Hour, testClock)
+
+	if _, err := uc.ReleaseIdempotentRequest(&dto.ReleaseIdempotentRequestInput{Key: "TEST_IDEMPOTENCY_KEY"}); err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+
+	if k, _ := r.IdempotencyKey().Find("TEST_IDEMPOTENCY_KEY"); k != nil {
+		t.Errorf("r.IdempotencyKey().Find()=%v; want nil", k)
+	}
+}
+
+func TestIdempotencyUsecase_PurgeIdempotencyKeys(t *testing.T) {
+	now := testNow
+	s := memory.NewStore()
+	s.AddIdempotencyKeys(
+		model.MustNewIdempotencyKey(
+			"TEST_EXPIRED_KEY", "TEST_FINGERPRINT", model.IdempotentResponse{}, now.Add(-2*time.Hour), now.Add(-time.Hour),
+		),
+		model.MustNewIdempotencyKey(
+			"TEST_IDEMPOTENCY_KEY", "TEST_FINGERPRINT", model.IdempotentResponse{}, now, now.Add(time.Hour),
+		),
+	)
+	r := memory.NewMemoryRepository(s)
+	uc := usecase.NewIdempotencyUsecase(r, time.Hour, testClock)
+
+	got, err := uc.PurgeIdempotencyKeys(&dto.PurgeIdempotencyKeysInput{})
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	want := &dto.PurgeIdempotencyKeysOutput{Purged: 1}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("uc.PurgeIdempotencyKeys()=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s", got, want, diff)
+	}
+
+	findIdempotencyKey(t, r, "TEST_IDEMPOTENCY_KEY")
+}
+
+func findIdempotencyKey(t *testing.T, r repository.Repository, key string) *model.IdempotencyKey {
+	t.Helper()
+
+	k, err := r.IdempotencyKey().Find(key)
+	if err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+	if k == nil {
+		t.Fatalf("r.IdempotencyKey().Find(%s)=nil; want the key", key)
+	}
+	return k
+}
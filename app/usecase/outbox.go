@@ -0,0 +1,120 @@
+//go:generate mockgen -source=$GOFILE -package=mock$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+const (
+	defaultRelayBatchSize = 100
+	minRetryDelay         = time.Second
+	maxRetryDelay         = time.Hour
+)
+
+// Publisher delivers an outbox message to the outside of the application.
+// A message may be published more than once, so subscribers must be idempotent.
+type Publisher interface {
+	Publish(m *model.OutboxMessage) error
+}
+
+type OutboxUsecase interface {
+	RelayOutbox(in *dto.RelayOutboxInput) (*dto.RelayOutboxOutput, error)
+	PurgeOutbox(in *dto.PurgeOutboxInput) (*dto.PurgeOutboxOutput, error)
+}
+
+type outboxUsecase struct {
+	r repository.Repository
+	p Publisher
+	c Clock
+}
+
+func NewOutboxUsecase(r repository.Repository, p Publisher, c Clock) OutboxUsecase {
+	return &outboxUsecase{r: r, p: p, c: c}
+}
+
+func (uc *outboxUsecase) RelayOutbox(in *dto.RelayOutboxInput) (*dto.RelayOutboxOutput, error) {
+	limit := in.BatchSize
+	if limit <= 0 {
+		limit = defaultRelayBatchSize
+	}
+
+	ms, err := uc.r.OutboxMessage().List(repository.OutboxMessageListFilter{
+		Unpublished: true,
+		AvailableAt: now(uc.c),
+		Limit:       limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Sequence the messages in a transaction committed before publishing them, so that a reader
+	// resuming after a published message finds the ones committed late after it too.
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		return tx.OutboxMessage().Sequence(ms)
+	}); err != nil {
+		return nil, err
+	}
+
+	out := &dto.RelayOutboxOutput{}
+	for _, m := range ms {
+		if err := uc.p.Publish(m); err != nil {
+			m.MarkFailed(err, now(uc.c).Add(retryDelay(m.Delivery().Attempts)))
+			out.Failed++
+		} else {
+			m.MarkPublished(now(uc.c))
+			out.Published++
+		}
+
+		if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+			return tx.OutboxMessage().Update(m)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// PurgeOutbox deletes the messages published longer ago than the retention,
+// after which a reader can no longer resume after them.
+func (uc *outboxUsecase) PurgeOutbox(in *dto.PurgeOutboxInput) (*dto.PurgeOutboxOutput, error) {
+	var n int
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		var err error
+		n, err = tx.OutboxMessage().DeletePublished(now(uc.c).Add(-in.Retention))
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.PurgeOutboxOutput{Purged: n}, nil
+}
+
+// retryDelay doubles the delay for every failed attempt, up to maxRetryDelay.
+func retryDelay(attempts int) time.Duration {
+	d := minRetryDelay
+	for i := 0; i < attempts; i++ {
+		d *= 2
+		if d >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+	return d
+}
+
+type eventRecorder interface {
+	PullEvents() []model.DomainEvent
+}
+
+// pullEvents collects the domain events recorded by the given aggregates in order.
+func pullEvents[T eventRecorder](as ...T) []model.DomainEvent {
+	var es []model.DomainEvent
+	for _, a := range as {
+		es = append(es, a.PullEvents()...)
+	}
+	return es
+}
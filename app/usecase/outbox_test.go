@@ -0,0 +1,183 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/memory"
+	mockusecase "github.com/toshiykst/go-layerd-architecture/app/mock/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestOutboxUsecase_RelayOutbox(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	newMessage := func(id model.OutboxMessageID, d model.OutboxDelivery) *model.OutboxMessage {
+		return model.MustNewOutboxMessage(
+			id,
+			model.DomainEventTypeUserCreated,
+			model.AggregateTypeUser,
+			"TEST_USER_ID",
+			[]byte(`{}`),
+			occurredAt,
+			d,
+		)
+	}
+
+	tests := []struct {
+		name                string
+		in                  *dto.RelayOutboxInput
+		newMemoryRepository func() repository.Repository
+		setupPublisher      func(p *mockusecase.MockPublisher)
+		want                *dto.RelayOutboxOutput
+		wantAttempts        map[model.OutboxMessageID]int
+		wantPublished       map[model.OutboxMessageID]bool
+		wantSequenced       []model.OutboxMessageID
+	}{
+		{
+			name: "Publishes the pending messages and records the failures",
+			in:   &dto.RelayOutboxInput{},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddOutboxMessages(
+					newMessage("TEST_OUTBOX_MESSAGE_ID_1", model.OutboxDelivery{}),
+					newMessage("TEST_OUTBOX_MESSAGE_ID_2", model.OutboxDelivery{Attempts: 2}),
+				)
+				return memory.NewMemoryRepository(s)
+			},
+			setupPublisher: func(p *mockusecase.MockPublisher) {
+				gomock.InOrder(
+					p.EXPECT().Publish(gomock.Any()).Return(nil),
+					p.EXPECT().Publish(gomock.Any()).Return(errors.New("an error occurred")),
+				)
+			},
+			want: &dto.RelayOutboxOutput{Published: 1, Failed: 1},
+			wantAttempts: map[model.OutboxMessageID]int{
+				"TEST_OUTBOX_MESSAGE_ID_1": 1,
+				"TEST_OUTBOX_MESSAGE_ID_2": 3,
+			},
+			wantPublished: map[model.OutboxMessageID]bool{
+				"TEST_OUTBOX_MESSAGE_ID_1": true,
+				"TEST_OUTBOX_MESSAGE_ID_2": false,
+			},
+			wantSequenced: []model.OutboxMessageID{"TEST_OUTBOX_MESSAGE_ID_1", "TEST_OUTBOX_MESSAGE_ID_2"},
+		},
+		{
+			name: "Skips published messages and messages waiting for a retry",
+			in:   &dto.RelayOutboxInput{BatchSize: 10},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddOutboxMessages(
+					newMessage("TEST_OUTBOX_MESSAGE_ID_1", model.OutboxDelivery{Attempts: 1, PublishedAt: occurredAt}),
+					newMessage("TEST_OUTBOX_MESSAGE_ID_2", model.OutboxDelivery{
+						Attempts:      1,
+						NextAttemptAt: testNow.Add(time.Hour),
+					}),
+				)
+				return memory.NewMemoryRepository(s)
+			},
+			setupPublisher: func(p *mockusecase.MockPublisher) {},
+			want:           &dto.RelayOutboxOutput{},
+			wantAttempts: map[model.OutboxMessageID]int{
+				"TEST_OUTBOX_MESSAGE_ID_1": 1,
+				"TEST_OUTBOX_MESSAGE_ID_2": 1,
+			},
+			wantPublished: map[model.OutboxMessageID]bool{
+				"TEST_OUTBOX_MESSAGE_ID_1": true,
+				"TEST_OUTBOX_MESSAGE_ID_2": false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			p := mockusecase.NewMockPublisher(ctrl)
+			tt.setupPublisher(p)
+			r := tt.newMemoryRepository()
+			uc := usecase.NewOutboxUsecase(r, p, testClock)
+
+			got, err := uc.RelayOutbox(tt.in)
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(
+					"uc.RelayOutbox(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+					tt.in, got, tt.want, diff,
+				)
+			}
+
+			ms, _ := r.OutboxMessage().List(repository.OutboxMessageListFilter{})
+			for _, m := range ms {
+				if m.Delivery().Attempts != tt.wantAttempts[m.ID()] {
+					t.Errorf("%s: m.Delivery().Attempts=%d; want %d", m.ID(), m.Delivery().Attempts, tt.wantAttempts[m.ID()])
+				}
+				if m.IsPublished() != tt.wantPublished[m.ID()] {
+					t.Errorf("%s: m.IsPublished()=%t; want %t", m.ID(), m.IsPublished(), tt.wantPublished[m.ID()])
+				}
+				if !m.IsPublished() && m.Delivery().Attempts > 0 && !m.Delivery().NextAttemptAt.After(occurredAt) {
+					t.Errorf("%s: m.Delivery().NextAttemptAt=%v; want a retry later", m.ID(), m.Delivery().NextAttemptAt)
+				}
+			}
+
+			sms, _ := r.OutboxMessage().List(repository.OutboxMessageListFilter{Sequenced: true})
+			var gotSequenced []model.OutboxMessageID
+			for _, m := range sms {
+				gotSequenced = append(gotSequenced, m.ID())
+			}
+			if diff := cmp.Diff(gotSequenced, tt.wantSequenced); diff != "" {
+				t.Errorf("sequenced messages=%v; want %v\ndiffers: (-got +want)\n%s", gotSequenced, tt.wantSequenced, diff)
+			}
+		})
+	}
+}
+
+func TestOutboxUsecase_PurgeOutbox(t *testing.T) {
+	newMessage := func(id model.OutboxMessageID, publishedAt time.Time) *model.OutboxMessage {
+		return model.MustNewOutboxMessage(
+			id,
+			model.DomainEventTypeUserCreated,
+			model.AggregateTypeUser,
+			"TEST_USER_ID",
+			[]byte(`{}`),
+			testNow.Add(-3*time.Hour),
+			model.OutboxDelivery{Attempts: 1, PublishedAt: publishedAt},
+		)
+	}
+	s := memory.NewStore()
+	s.AddOutboxMessages(
+		newMessage("TEST_EXPIRED_MESSAGE_ID", testNow.Add(-2*time.Hour)),
+		newMessage("TEST_PUBLISHED_MESSAGE_ID", testNow.Add(-time.Minute)),
+		newMessage("TEST_UNPUBLISHED_MESSAGE_ID", time.Time{}),
+	)
+	r := memory.NewMemoryRepository(s)
+	uc := usecase.NewOutboxUsecase(r, nil, testClock)
+
+	got, err := uc.PurgeOutbox(&dto.PurgeOutboxInput{Retention: time.Hour})
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	want := &dto.PurgeOutboxOutput{Purged: 1}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("uc.PurgeOutbox()=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s", got, want, diff)
+	}
+
+	ms, _ := r.OutboxMessage().List(repository.OutboxMessageListFilter{})
+	var gotIDs []model.OutboxMessageID
+	for _, m := range ms {
+		gotIDs = append(gotIDs, m.ID())
+	}
+	wantIDs := []model.OutboxMessageID{"TEST_PUBLISHED_MESSAGE_ID", "TEST_UNPUBLISHED_MESSAGE_ID"}
+	if diff := cmp.Diff(gotIDs, wantIDs); diff != "" {
+		t.Errorf("outbox message ids=%v; want %v\ndiffers: (-got +want)\n%s", gotIDs, wantIDs, diff)
+	}
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+type (
+	// userDocument is the JSON document of a user which a patch applies to.
+	userDocument struct {
+		Name       string         `json:"name"`
+		Email      string         `json:"email"`
+		Attributes map[string]any `json:"attributes"`
+	}
+
+	// groupDocument is the JSON document of a group which a patch applies to.
+	groupDocument struct {
+		Name       string         `json:"name"`
+		JoinPolicy string         `json:"joinPolicy"`
+		UserIDs    []string       `json:"userIds"`
+		Attributes map[string]any `json:"attributes"`
+	}
+)
+
+// attributesDocument returns the attributes in a document, which are an object even if there are none
+// so that a JSON patch can add a member to it.
+func attributesDocument(attrs model.Attributes) map[string]any {
+	if attrs == nil {
+		return map[string]any{}
+	}
+	return attrs
+}
+
+// applyPatch applies the patch to the document and returns the patched document.
+// A member not in the document cannot be added by the patch.
+func applyPatch[T any](pt dto.PatchType, patch []byte, doc T) (T, error) {
+	var patched T
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return patched, err
+	}
+
+	switch pt {
+	case dto.PatchTypeMergePatch:
+		b, err = jsonpatch.MergePatch(b, patch)
+	case dto.PatchTypeJSONPatch:
+		var p jsonpatch.Patch
+		p, err = jsonpatch.DecodePatch(patch)
+		if err == nil {
+			b, err = p.Apply(b)
+		}
+	default:
+		err = fmt.Errorf("unknown patch type %q", pt)
+	}
+	if err != nil {
+		return patched, errors.Join(ErrInvalidPatch, err)
+	}
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.DisallowUnknownFields()
+	if err := d.Decode(&patched); err != nil {
+		return patched, errors.Join(ErrInvalidPatch, err)
+	}
+	return patched, nil
+}
@@ -0,0 +1,176 @@
+package usecase_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/domainservice"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/memory"
+	mockfactory "github.com/toshiykst/go-layerd-architecture/app/mock/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// labeled changes the labels of the group, panicking if they are invalid.
+func labeled(g *model.Group, ls model.Labels) *model.Group {
+	if err := g.ChangeLabels(ls); err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// newPolicyOverrideUsecase returns a usecase of the default policy overridden for the groups of type team,
+// which take at most 2 users named in lowercase letters.
+func newPolicyOverrideUsecase(t *testing.T, r repository.Repository) usecase.GroupUsecase {
+	ctrl := gomock.NewController(t)
+	p := model.DefaultPolicy()
+	p.GroupOverrides = map[model.PolicyScope]model.GroupPolicy{
+		{GroupType: "team"}: {
+			Name:     model.NamePolicy{MaxLength: 30, Pattern: regexp.MustCompile(`^[a-z]+$`)},
+			MaxUsers: 2,
+		},
+	}
+	return usecase.NewGroupUsecase(
+		r, mockfactory.NewMockGroupFactory(ctrl), domainservice.NewGroupService(r), domainservice.NewUserService(r),
+		factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+		factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+		factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+		p,
+		testClock,
+	)
+}
+
+func TestGroupUsecase_AddGroupUsers_policyOverride(t *testing.T) {
+	s := memory.NewStore()
+	s.AddUsers(
+		model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+		model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+		model.MustNewUser("TEST_USER_ID_3", "TEST_USER_NAME_3", "TEST_USER_EMAIL_3"),
+	)
+	s.AddGroups(
+		labeled(model.MustNewGroup("TEST_GROUP_ID_1", "team", []model.UserID{}), model.Labels{"type": "team"}),
+		model.MustNewGroup("TEST_GROUP_ID_2", "TEST_GROUP_NAME_2", []model.UserID{}),
+	)
+	r := memory.NewMemoryRepository(s)
+	uc := newPolicyOverrideUsecase(t, r)
+
+	tests := []struct {
+		name         string
+		gID          model.GroupID
+		wantWaitlist []model.UserID
+	}{
+		{
+			name:         "Waitlists the users exceeding the capacity of the override of the group type",
+			gID:          "TEST_GROUP_ID_1",
+			wantWaitlist: []model.UserID{"TEST_USER_ID_3"},
+		},
+		{
+			name: "Adds the users up to the default capacity to the group of no type",
+			gID:  "TEST_GROUP_ID_2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := &dto.AddGroupUsersInput{
+				GroupID: string(tt.gID),
+				UserIDs: []string{"TEST_USER_ID_1", "TEST_USER_ID_2", "TEST_USER_ID_3"},
+			}
+			out, err := uc.AddGroupUsers(in)
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if diff := cmp.Diff(out.WaitlistedUserIDs, dto.ToUserIDsFromModel(tt.wantWaitlist)); diff != "" {
+				t.Errorf(
+					"uc.AddGroupUsers(%v).WaitlistedUserIDs=%v; want %v\ndiffers: (-got +want)\n%s",
+					in, out.WaitlistedUserIDs, tt.wantWaitlist, diff,
+				)
+			}
+			assertGroupWaitlist(t, r, tt.gID, tt.wantWaitlist...)
+		})
+	}
+}
+
+func TestGroupUsecase_GetGroups_policyOverride(t *testing.T) {
+	s := memory.NewStore()
+	s.AddGroups(
+		labeled(
+			model.MustNewGroup("TEST_GROUP_ID_1", "team", []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"}),
+			model.Labels{"type": "team"},
+		),
+		model.MustNewGroup("TEST_GROUP_ID_2", "TEST_GROUP_NAME_2", []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"}),
+	)
+	s.AddUsers(
+		model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+		model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+	)
+	uc := newPolicyOverrideUsecase(t, memory.NewMemoryRepository(s))
+
+	in := &dto.GetGroupsInput{Full: true}
+	got, err := uc.GetGroups(in)
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	var gIDs []string
+	for _, g := range got.Groups {
+		gIDs = append(gIDs, g.GroupID)
+	}
+	if want := []string{"TEST_GROUP_ID_1"}; !cmp.Equal(gIDs, want) {
+		t.Errorf("uc.GetGroups(%v) lists groups %v; want %v", in, gIDs, want)
+	}
+}
+
+func TestGroupUsecase_UpdateGroup_policyOverride(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      *dto.UpdateGroupInput
+		wantErr error
+	}{
+		{
+			name: "Renames the group of the type to a name allowed by the override",
+			in:   &dto.UpdateGroupInput{GroupID: "TEST_GROUP_ID_1", Name: "renamed"},
+		},
+		{
+			name:    "Returns error if the name is disallowed by the override of the group type",
+			in:      &dto.UpdateGroupInput{GroupID: "TEST_GROUP_ID_1", Name: "TEST_GROUP_NAME_UPDATED"},
+			wantErr: usecase.ErrInvalidGroupInput,
+		},
+		{
+			name: "Renames the group of no type to a name allowed by the default",
+			in:   &dto.UpdateGroupInput{GroupID: "TEST_GROUP_ID_2", Name: "TEST_GROUP_NAME_UPDATED"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := memory.NewStore()
+			s.AddGroups(
+				labeled(model.MustNewGroup("TEST_GROUP_ID_1", "team", []model.UserID{}), model.Labels{"type": "team"}),
+				model.MustNewGroup("TEST_GROUP_ID_2", "TEST_GROUP_NAME_2", []model.UserID{}),
+			)
+			uc := newPolicyOverrideUsecase(t, memory.NewMemoryRepository(s))
+
+			if _, err := uc.UpdateGroup(tt.in); !errors.Is(err, tt.wantErr) {
+				t.Errorf("uc.UpdateGroup(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGroupUsecase_PutGroupLabels_policyOverride(t *testing.T) {
+	s := memory.NewStore()
+	s.AddGroups(model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", []model.UserID{}))
+	uc := newPolicyOverrideUsecase(t, memory.NewMemoryRepository(s))
+
+	in := &dto.PutGroupLabelsInput{GroupID: "TEST_GROUP_ID", Labels: map[string]string{"type": "team"}}
+	if _, err := uc.PutGroupLabels(in); !errors.Is(err, usecase.ErrInvalidGroupInput) {
+		t.Errorf("uc.PutGroupLabels(%v)=_, %v; want _, %v", in, err, usecase.ErrInvalidGroupInput)
+	}
+}
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// AddSubgroup nests the subgroup in the group, so that the members of the subgroup are the members of the group
+// transitively. It does nothing if the subgroup is nested in the group already.
+// The nesting runs one after another with the ones of the same groups, whose rows are locked in the order of id,
+// and waits for the ones of their ancestors, whose subgroups are locked as the ancestors are read.
+func (uc *groupUsecase) AddSubgroup(in *dto.AddSubgroupInput) (*dto.AddSubgroupOutput, error) {
+	parentID, childID := model.GroupID(in.GroupID), model.GroupID(in.SubgroupID)
+
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		gIDs := []model.GroupID{parentID, childID}
+		sort.Slice(gIDs, func(i, j int) bool { return gIDs[i] < gIDs[j] })
+		for _, gID := range gIDs {
+			if _, err := findGroupForUpdate(tx, gID); err != nil {
+				return err
+			}
+		}
+
+		ss, err := tx.Subgroup().List(repository.SubgroupListFilter{ParentID: parentID, ChildID: childID})
+		if err != nil {
+			return err
+		}
+		if len(ss) > 0 {
+			return nil
+		}
+
+		ancestorIDs, err := tx.Subgroup().ListAncestorIDsForUpdate([]model.GroupID{parentID})
+		if err != nil {
+			return err
+		}
+		s, err := model.NestGroup(parentID, childID, ancestorIDs)
+		if err != nil {
+			if errors.Is(err, model.ErrGroupCycle) {
+				return errors.Join(ErrGroupCycle, err)
+			}
+			return err
+		}
+		return tx.Subgroup().Create(s)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.AddSubgroupOutput{}, nil
+}
+
+func (uc *groupUsecase) RemoveSubgroup(in *dto.RemoveSubgroupInput) (*dto.RemoveSubgroupOutput, error) {
+	s, err := uc.findSubgroup(model.GroupID(in.GroupID), model.GroupID(in.SubgroupID))
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, ErrSubgroupNotFound
+	}
+
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		return tx.Subgroup().Delete(s)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.RemoveSubgroupOutput{}, nil
+}
+
+func (uc *groupUsecase) GetSubgroups(in *dto.GetSubgroupsInput) (*dto.GetSubgroupsOutput, error) {
+	gID := model.GroupID(in.GroupID)
+	if err := uc.checkGroupsExist(gID); err != nil {
+		return nil, err
+	}
+
+	var gIDs []model.GroupID
+	if in.Transitive {
+		var err error
+		if gIDs, err = uc.r.Subgroup().ListDescendantIDs(gID); err != nil {
+			return nil, err
+		}
+	} else {
+		ss, err := uc.r.Subgroup().List(repository.SubgroupListFilter{ParentID: gID})
+		if err != nil {
+			return nil, err
+		}
+		gIDs = ss.ChildIDs()
+	}
+
+	return &dto.GetSubgroupsOutput{
+		GroupIDs: dto.ToGroupIDsFromModel(gIDs),
+	}, nil
+}
+
+// GetGroupMembers gets the users who belong to the group, and to its subgroups if it is transitive.
+// A user who belongs to several of the groups is got once.
+func (uc *groupUsecase) GetGroupMembers(in *dto.GetGroupMembersInput) (*dto.GetGroupMembersOutput, error) {
+	gID := model.GroupID(in.GroupID)
+	if err := uc.checkGroupsExist(gID); err != nil {
+		return nil, err
+	}
+
+	gIDs := []model.GroupID{gID}
+	if in.Transitive {
+		descendantIDs, err := uc.r.Subgroup().ListDescendantIDs(gID)
+		if err != nil {
+			return nil, err
+		}
+		gIDs = append(gIDs, descendantIDs...)
+	}
+
+	us, err := uc.r.User().List(repository.UserListFilter{GroupIDs: gIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.GetGroupMembersOutput{
+		Users: dto.ToUsersFromModel(us),
+	}, nil
+}
+
+// GetUserGroups gets the groups which the user belongs to, and the ones they are nested in if it is transitive.
+func (uc *groupUsecase) GetUserGroups(in *dto.GetUserGroupsInput) (*dto.GetUserGroupsOutput, error) {
+	uID := model.UserID(in.UserID)
+	u, err := uc.r.User().Find(uID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, ErrUserNotFound
+	}
+
+	gs, err := uc.r.Group().List(repository.GroupListFilter{UserIDs: []model.UserID{uID}})
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Transitive && len(gs) > 0 {
+		ancestorIDs, err := uc.r.Subgroup().ListAncestorIDs(gs.IDs())
+		if err != nil {
+			return nil, err
+		}
+		if len(ancestorIDs) > 0 {
+			if gs, err = uc.r.Group().List(repository.GroupListFilter{
+				GroupIDs: append(gs.IDs(), ancestorIDs...),
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	dtogs, err := uc.toDTOGroups(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.GetUserGroupsOutput{
+		Groups: dtogs,
+	}, nil
+}
+
+// checkGroupsExist returns ErrGroupNotFound unless all the groups exist.
+func (uc *groupUsecase) checkGroupsExist(gIDs ...model.GroupID) error {
+	for _, gID := range gIDs {
+		ok, err := uc.gs.Exists(gID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrGroupNotFound
+		}
+	}
+	return nil
+}
+
+// findSubgroup returns the subgroup nested in the parent directly, or nil if it is not.
+func (uc *groupUsecase) findSubgroup(parentID, childID model.GroupID) (*model.Subgroup, error) {
+	ss, err := uc.r.Subgroup().List(repository.SubgroupListFilter{
+		ParentID: parentID,
+		ChildID:  childID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(ss) == 0 {
+		return nil, nil
+	}
+	return ss[0], nil
+}
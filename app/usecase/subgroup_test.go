@@ -0,0 +1,339 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/memory"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// newSubgroupMemoryRepository returns a repository of the groups nested as TEST_GROUP_ID_1 > TEST_GROUP_ID_2 >
+// TEST_GROUP_ID_3, and TEST_GROUP_ID_4 apart from them.
+func newSubgroupMemoryRepository() repository.Repository {
+	s := memory.NewStore()
+	s.AddUsers(
+		model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+		model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+		model.MustNewUser("TEST_USER_ID_3", "TEST_USER_NAME_3", "TEST_USER_EMAIL_3"),
+		model.MustNewUser("TEST_USER_ID_4", "TEST_USER_NAME_4", "TEST_USER_EMAIL_4"),
+		model.MustNewUser("TEST_USER_ID_5", "TEST_USER_NAME_5", "TEST_USER_EMAIL_5"),
+	)
+	s.AddGroups(
+		model.MustNewGroup("TEST_GROUP_ID_1", "TEST_GROUP_NAME_1", []model.UserID{"TEST_USER_ID_1"}),
+		model.MustNewGroup("TEST_GROUP_ID_2", "TEST_GROUP_NAME_2", []model.UserID{"TEST_USER_ID_1", "TEST_USER_ID_2"}),
+		model.MustNewGroup("TEST_GROUP_ID_3", "TEST_GROUP_NAME_3", []model.UserID{"TEST_USER_ID_3"}),
+		model.MustNewGroup("TEST_GROUP_ID_4", "TEST_GROUP_NAME_4", []model.UserID{"TEST_USER_ID_4"}),
+	)
+	s.AddSubgroups(
+		model.MustNewSubgroup("TEST_GROUP_ID_1", "TEST_GROUP_ID_2"),
+		model.MustNewSubgroup("TEST_GROUP_ID_2", "TEST_GROUP_ID_3"),
+	)
+	return memory.NewMemoryRepository(s)
+}
+
+func TestGroupUsecase_AddSubgroup(t *testing.T) {
+	tests := []struct {
+		name          string
+		in            *dto.AddSubgroupInput
+		concurrent    func(tx repository.Transaction) error
+		wantSubgroups []string
+		wantErr       error
+	}{
+		{
+			name:          "Nests the group in the group",
+			in:            &dto.AddSubgroupInput{GroupID: "TEST_GROUP_ID_3", SubgroupID: "TEST_GROUP_ID_4"},
+			wantSubgroups: []string{"TEST_GROUP_ID_4"},
+		},
+		{
+			name:          "Does nothing if the group is nested in the group already",
+			in:            &dto.AddSubgroupInput{GroupID: "TEST_GROUP_ID_2", SubgroupID: "TEST_GROUP_ID_3"},
+			wantSubgroups: []string{"TEST_GROUP_ID_3"},
+		},
+		{
+			name:    "Returns error if the group is an ancestor of the group",
+			in:      &dto.AddSubgroupInput{GroupID: "TEST_GROUP_ID_3", SubgroupID: "TEST_GROUP_ID_1"},
+			wantErr: usecase.ErrGroupCycle,
+		},
+		{
+			name: "Returns error if the group is nested in the group concurrently",
+			in:   &dto.AddSubgroupInput{GroupID: "TEST_GROUP_ID_3", SubgroupID: "TEST_GROUP_ID_4"},
+			concurrent: func(tx repository.Transaction) error {
+				return tx.Subgroup().Create(model.MustNewSubgroup("TEST_GROUP_ID_4", "TEST_GROUP_ID_3"))
+			},
+			wantErr: usecase.ErrGroupCycle,
+		},
+		{
+			name: "Returns error if the subgroup is deleted concurrently",
+			in:   &dto.AddSubgroupInput{GroupID: "TEST_GROUP_ID_3", SubgroupID: "TEST_GROUP_ID_4"},
+			concurrent: func(tx repository.Transaction) error {
+				g, err := tx.Group().Find("TEST_GROUP_ID_4")
+				if err != nil {
+					return err
+				}
+				return tx.Group().Delete(g)
+			},
+			wantErr: usecase.ErrGroupNotFound,
+		},
+		{
+			name:    "Returns error if the group is nested in itself",
+			in:      &dto.AddSubgroupInput{GroupID: "TEST_GROUP_ID_4", SubgroupID: "TEST_GROUP_ID_4"},
+			wantErr: usecase.ErrGroupCycle,
+		},
+		{
+			name:    "Returns error if the group does not exist",
+			in:      &dto.AddSubgroupInput{GroupID: "TEST_GROUP_ID_UNKNOWN", SubgroupID: "TEST_GROUP_ID_4"},
+			wantErr: usecase.ErrGroupNotFound,
+		},
+		{
+			name:    "Returns error if the subgroup does not exist",
+			in:      &dto.AddSubgroupInput{GroupID: "TEST_GROUP_ID_4", SubgroupID: "TEST_GROUP_ID_UNKNOWN"},
+			wantErr: usecase.ErrGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &interleavedRepository{Repository: newSubgroupMemoryRepository(), concurrent: tt.concurrent}
+			uc := newGroupWaitlistUsecase(t, r)
+
+			_, err := uc.AddSubgroup(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("uc.AddSubgroup(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+				ss, err := r.Subgroup().List(repository.SubgroupListFilter{
+					ParentID: model.GroupID(tt.in.GroupID),
+					ChildID:  model.GroupID(tt.in.SubgroupID),
+				})
+				if err != nil {
+					t.Fatalf("want no error, but has error %v", err)
+				}
+				if len(ss) > 0 {
+					t.Errorf("r.Subgroup().List()=%v; want no subgroup nested", ss)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+
+			out, err := uc.GetSubgroups(&dto.GetSubgroupsInput{GroupID: tt.in.GroupID})
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if diff := cmp.Diff(out.GroupIDs, tt.wantSubgroups); diff != "" {
+				t.Errorf("subgroups=%v; want %v\ndiffers: (-got +want)\n%s", out.GroupIDs, tt.wantSubgroups, diff)
+			}
+		})
+	}
+}
+
+func TestGroupUsecase_RemoveSubgroup(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      *dto.RemoveSubgroupInput
+		wantErr error
+	}{
+		{
+			name: "Removes the subgroup from the group",
+			in:   &dto.RemoveSubgroupInput{GroupID: "TEST_GROUP_ID_1", SubgroupID: "TEST_GROUP_ID_2"},
+		},
+		{
+			name:    "Returns error if the group is nested in the group only transitively",
+			in:      &dto.RemoveSubgroupInput{GroupID: "TEST_GROUP_ID_1", SubgroupID: "TEST_GROUP_ID_3"},
+			wantErr: usecase.ErrSubgroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newSubgroupMemoryRepository()
+			uc := newGroupWaitlistUsecase(t, r)
+
+			_, err := uc.RemoveSubgroup(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("uc.RemoveSubgroup(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+
+			ss, err := r.Subgroup().List(repository.SubgroupListFilter{ParentID: model.GroupID(tt.in.GroupID)})
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if len(ss) != 0 {
+				t.Errorf("len(r.Subgroup().List())=%d; want 0", len(ss))
+			}
+		})
+	}
+}
+
+func TestGroupUsecase_GetSubgroups(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      *dto.GetSubgroupsInput
+		want    *dto.GetSubgroupsOutput
+		wantErr error
+	}{
+		{
+			name: "Returns the groups nested directly",
+			in:   &dto.GetSubgroupsInput{GroupID: "TEST_GROUP_ID_1"},
+			want: &dto.GetSubgroupsOutput{GroupIDs: []string{"TEST_GROUP_ID_2"}},
+		},
+		{
+			name: "Returns the groups nested transitively",
+			in:   &dto.GetSubgroupsInput{GroupID: "TEST_GROUP_ID_1", Transitive: true},
+			want: &dto.GetSubgroupsOutput{GroupIDs: []string{"TEST_GROUP_ID_2", "TEST_GROUP_ID_3"}},
+		},
+		{
+			name:    "Returns error if the group does not exist",
+			in:      &dto.GetSubgroupsInput{GroupID: "TEST_GROUP_ID_UNKNOWN"},
+			wantErr: usecase.ErrGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := newGroupWaitlistUsecase(t, newSubgroupMemoryRepository())
+
+			got, err := uc.GetSubgroups(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("uc.GetSubgroups(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("uc.GetSubgroups(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s", tt.in, got, tt.want, diff)
+			}
+		})
+	}
+}
+
+func TestGroupUsecase_GetGroupMembers(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          *dto.GetGroupMembersInput
+		wantUserIDs []string
+		wantErr     error
+	}{
+		{
+			name:        "Returns the users who belong to the group",
+			in:          &dto.GetGroupMembersInput{GroupID: "TEST_GROUP_ID_1"},
+			wantUserIDs: []string{"TEST_USER_ID_1"},
+		},
+		{
+			name:        "Returns the users who belong to the group and its subgroups once",
+			in:          &dto.GetGroupMembersInput{GroupID: "TEST_GROUP_ID_1", Transitive: true},
+			wantUserIDs: []string{"TEST_USER_ID_1", "TEST_USER_ID_2", "TEST_USER_ID_3"},
+		},
+		{
+			name:        "Returns the users of the group without subgroups",
+			in:          &dto.GetGroupMembersInput{GroupID: "TEST_GROUP_ID_4", Transitive: true},
+			wantUserIDs: []string{"TEST_USER_ID_4"},
+		},
+		{
+			name:    "Returns error if the group does not exist",
+			in:      &dto.GetGroupMembersInput{GroupID: "TEST_GROUP_ID_UNKNOWN"},
+			wantErr: usecase.ErrGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := newGroupWaitlistUsecase(t, newSubgroupMemoryRepository())
+
+			got, err := uc.GetGroupMembers(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("uc.GetGroupMembers(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+
+			uIDs := make([]string, len(got.Users))
+			for i, u := range got.Users {
+				uIDs[i] = u.UserID
+			}
+			if diff := cmp.Diff(uIDs, tt.wantUserIDs); diff != "" {
+				t.Errorf("uc.GetGroupMembers(%v) user ids=%v; want %v\ndiffers: (-got +want)\n%s", tt.in, uIDs, tt.wantUserIDs, diff)
+			}
+		})
+	}
+}
+
+func TestGroupUsecase_GetUserGroups(t *testing.T) {
+	tests := []struct {
+		name         string
+		in           *dto.GetUserGroupsInput
+		wantGroupIDs []string
+		wantErr      error
+	}{
+		{
+			name:         "Returns the groups which the user belongs to",
+			in:           &dto.GetUserGroupsInput{UserID: "TEST_USER_ID_3"},
+			wantGroupIDs: []string{"TEST_GROUP_ID_3"},
+		},
+		{
+			name:         "Returns the groups which the groups of the user are nested in",
+			in:           &dto.GetUserGroupsInput{UserID: "TEST_USER_ID_3", Transitive: true},
+			wantGroupIDs: []string{"TEST_GROUP_ID_1", "TEST_GROUP_ID_2", "TEST_GROUP_ID_3"},
+		},
+		{
+			name:         "Returns a group which the user belongs to directly and transitively once",
+			in:           &dto.GetUserGroupsInput{UserID: "TEST_USER_ID_1", Transitive: true},
+			wantGroupIDs: []string{"TEST_GROUP_ID_1", "TEST_GROUP_ID_2"},
+		},
+		{
+			name:         "Returns no groups if the user belongs to no group",
+			in:           &dto.GetUserGroupsInput{UserID: "TEST_USER_ID_5", Transitive: true},
+			wantGroupIDs: []string{},
+		},
+		{
+			name:    "Returns error if the user does not exist",
+			in:      &dto.GetUserGroupsInput{UserID: "TEST_USER_ID_UNKNOWN"},
+			wantErr: usecase.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := newGroupWaitlistUsecase(t, newSubgroupMemoryRepository())
+
+			got, err := uc.GetUserGroups(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("uc.GetUserGroups(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+
+			gIDs := make([]string, len(got.Groups))
+			for i, g := range got.Groups {
+				gIDs[i] = g.GroupID
+			}
+			if diff := cmp.Diff(gIDs, tt.wantGroupIDs); diff != "" {
+				t.Errorf("uc.GetUserGroups(%v) group ids=%v; want %v\ndiffers: (-got +want)\n%s", tt.in, gIDs, tt.wantGroupIDs, diff)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
+	"unicode/utf8"
 
 	"github.com/labstack/gommon/log"
 
@@ -18,7 +20,14 @@ type UserUsecase interface {
 	GetUser(in *dto.GetUserInput) (*dto.GetUserOutput, error)
 	GetUsers(in *dto.GetUsersInput) (*dto.GetUsersOutput, error)
 	UpdateUser(in *dto.UpdateUserInput) (*dto.UpdateUserOutput, error)
+	PatchUser(in *dto.PatchUserInput) (*dto.PatchUserOutput, error)
 	DeleteUser(in *dto.DeleteUserInput) (*dto.DeleteUserOutput, error)
+	ActivateUser(in *dto.ActivateUserInput) (*dto.ActivateUserOutput, error)
+	SuspendUser(in *dto.SuspendUserInput) (*dto.SuspendUserOutput, error)
+	ReactivateUser(in *dto.ReactivateUserInput) (*dto.ReactivateUserOutput, error)
+	DeactivateUser(in *dto.DeactivateUserInput) (*dto.DeactivateUserOutput, error)
+	ImportUsers(in *dto.ImportUsersInput) (*dto.ImportUsersOutput, error)
+	ExportUsers(in *dto.ExportUsersInput) (*dto.ExportUsersOutput, error)
 }
 
 type userUsecase struct {
@@ -26,6 +35,11 @@ type userUsecase struct {
 	f  factory.UserFactory
 	us domainservice.UserService
 	gs domainservice.GroupService
+	af factory.AuditEventFactory
+	of factory.OutboxMessageFactory
+	wf factory.WebhookDeliveryFactory
+	p  model.Policy
+	c  Clock
 }
 
 func NewUserUsecase(
@@ -33,34 +47,80 @@ func NewUserUsecase(
 	f factory.UserFactory,
 	us domainservice.UserService,
 	gs domainservice.GroupService,
+	af factory.AuditEventFactory,
+	of factory.OutboxMessageFactory,
+	wf factory.WebhookDeliveryFactory,
+	p model.Policy,
+	c Clock,
 ) UserUsecase {
-	return &userUsecase{r: r, f: f, us: us, gs: gs}
+	return &userUsecase{r: r, f: f, us: us, gs: gs, af: af, of: of, wf: wf, p: p, c: c}
 }
 
 func (uc *userUsecase) CreateUser(in *dto.CreateUserInput) (*dto.CreateUserOutput, error) {
-	u, err := uc.f.Create(in.Name, in.Email)
+	status := model.DefaultUserStatus
+	if in.Status != "" {
+		status = model.UserStatus(in.Status)
+	}
+	if !status.IsInitial() {
+		return nil, fmt.Errorf("a user cannot be created in the status %s: %w", status, ErrInvalidUserInput)
+	}
+
+	created, err := uc.f.Create(in.Name, in.Email)
 	if err != nil {
 		if errors.Is(err, model.ErrInvalidUser) {
 			return nil, errors.Join(ErrInvalidUserInput, err)
 		}
 		return nil, err
 	}
+	u, err := model.NewUserWithStatus(created.ID(), created.Name(), created.Email(), status)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidUserInput, err)
+	}
+	u.ChangeAttributes(in.Attributes)
+	if err := validateAttributes(uc.r, model.AttributeTargetUser, u.Attributes(), ErrInvalidUserInput); err != nil {
+		return nil, err
+	}
+	at := now(uc.c)
+	u.ChangeTimestamps(model.NewTimestamps(in.Actor, at))
+
+	e, err := uc.af.Create(
+		in.Actor,
+		in.RequestID,
+		model.AuditActionCreateUser,
+		model.NewUserAuditTarget(u.ID()),
+		model.DiffUser(nil, u),
+		at,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	u.RecordCreated()
+	ms, err := uc.of.Create(pullEvents(u), at)
+	if err != nil {
+		return nil, err
+	}
 
 	if err = uc.r.RunTransaction(func(tx repository.Transaction) error {
 		if _, err := tx.User().Create(u); err != nil {
 			return err
 		}
+		if _, err := tx.AuditEvent().Create(e); err != nil {
+			return err
+		}
+		if err := tx.OutboxMessage().Create(ms); err != nil {
+			return err
+		}
+		if err := enqueueWebhookDeliveries(tx, uc.wf, ms); err != nil {
+			return err
+		}
 		return nil
 	}); err != nil {
 		return nil, err
 	}
 
 	return &dto.CreateUserOutput{
-		User: dto.User{
-			UserID: string(u.ID()),
-			Name:   u.Name(),
-			Email:  u.Email(),
-		},
+		User: dto.ToUserFromModel(u),
 	}, nil
 }
 
@@ -78,77 +138,220 @@ func (uc *userUsecase) GetUser(in *dto.GetUserInput) (*dto.GetUserOutput, error)
 	}
 
 	return &dto.GetUserOutput{
-		User: dto.User{
-			UserID: string(u.ID()),
-			Name:   u.Name(),
-			Email:  u.Email(),
-		},
+		User: dto.ToUserFromModel(u),
 	}, nil
 }
 
-func (uc *userUsecase) GetUsers(_ *dto.GetUsersInput) (*dto.GetUsersOutput, error) {
-	us, err := uc.r.User().List(repository.UserListFilter{})
+func (uc *userUsecase) GetUsers(in *dto.GetUsersInput) (*dto.GetUsersOutput, error) {
+	var f repository.UserListFilter
+	if in != nil {
+		f.UserIDs = dto.ToModelUserIDs(in.UserIDs)
+		f.Query = model.NormalizeSearchQuery(in.Query)
+		f.NoGroup = in.NoGroup
+		f.CreatedAfter = in.CreatedAfter
+		f.Status = model.UserStatus(in.Status)
+		f.Attributes = in.Attributes
+		order, err := model.ParseSortOrder(in.Sort)
+		if err != nil {
+			return nil, errors.Join(ErrInvalidUserInput, err)
+		}
+		f.Sort = order
+	}
+	if f.Status != "" && !f.Status.IsValid() {
+		return nil, fmt.Errorf("unknown status %s: %w", f.Status, ErrInvalidUserInput)
+	}
+	if err := validateAttributeFilter(uc.r, model.AttributeTargetUser, f.Attributes, ErrInvalidUserInput); err != nil {
+		return nil, err
+	}
+	if utf8.RuneCountInString(f.Query) > model.MaxSearchQueryLength {
+		return nil, fmt.Errorf("query must be at most %d characters: %w", model.MaxSearchQueryLength, ErrInvalidUserInput)
+	}
+
+	us, err := uc.r.User().List(f)
 	if err != nil {
 		return nil, err
 	}
 
-	return &dto.GetUsersOutput{
+	out := &dto.GetUsersOutput{
 		Users: dto.ToUsersFromModel(us),
-	}, nil
+	}
+	if f.Query != "" {
+		out.Highlights = dto.ToUserHighlightsFromModel(f.Query, us)
+	}
+	return out, nil
 }
 
 func (uc *userUsecase) UpdateUser(in *dto.UpdateUserInput) (*dto.UpdateUserOutput, error) {
-	u, err := model.NewUser(model.UserID(in.UserID), in.Name, in.Email)
+	u, err := uc.newUser(model.UserID(in.UserID), in.Name, in.Email, model.DefaultUserStatus)
 	if err != nil {
 		return nil, errors.Join(ErrInvalidUserInput, err)
 	}
 
-	isExisted, err := uc.us.Exists(u.ID())
+	before, err := uc.r.User().Find(u.ID())
 	if err != nil {
 		return nil, err
 	}
-	if !isExisted {
+	if before == nil {
 		return nil, ErrUserNotFound
 	}
+	// The update changes only the name, the email and the attributes, so keep the current status.
+	if u, err = uc.newUser(u.ID(), u.Name(), u.Email(), before.Status()); err != nil {
+		return nil, errors.Join(ErrInvalidUserInput, err)
+	}
+	attrs := before.Attributes()
+	if in.Attributes != nil {
+		attrs = in.Attributes
+	}
+	u.ChangeAttributes(attrs)
+	if err := validateAttributes(uc.r, model.AttributeTargetUser, u.Attributes(), ErrInvalidUserInput); err != nil {
+		return nil, err
+	}
 
-	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
-		if err := tx.User().Update(u); err != nil {
-			return err
-		}
-		return nil
-	}); err != nil {
+	if err := uc.update(in.Meta, before, u); err != nil {
 		return nil, err
 	}
 
 	return &dto.UpdateUserOutput{}, nil
 }
 
+// PatchUser applies the patch to the user, which is validated as a whole as UpdateUser does.
+func (uc *userUsecase) PatchUser(in *dto.PatchUserInput) (*dto.PatchUserOutput, error) {
+	before, err := uc.r.User().Find(model.UserID(in.UserID))
+	if err != nil {
+		return nil, err
+	}
+	if before == nil {
+		return nil, ErrUserNotFound
+	}
+
+	doc, err := applyPatch(in.PatchType, in.Patch, userDocument{
+		Name:       before.Name(),
+		Email:      before.Email(),
+		Attributes: attributesDocument(before.Attributes()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := uc.newUser(before.ID(), doc.Name, doc.Email, before.Status())
+	if err != nil {
+		return nil, errors.Join(ErrInvalidUserInput, err)
+	}
+	u.ChangeAttributes(doc.Attributes)
+	if err := validateAttributes(uc.r, model.AttributeTargetUser, u.Attributes(), ErrInvalidUserInput); err != nil {
+		return nil, err
+	}
+
+	if err := uc.update(in.Meta, before, u); err != nil {
+		return nil, err
+	}
+
+	return &dto.PatchUserOutput{}, nil
+}
+
+// newUser returns the user of the input, whose name the policy allows.
+func (uc *userUsecase) newUser(uID model.UserID, name, email string, status model.UserStatus) (*model.User, error) {
+	u, err := model.NewUserWithStatus(uID, name, email, status)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.p.User.ValidateName(u.Name()); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// update stores the updated user stamped by the actor with its audit event and domain events.
+func (uc *userUsecase) update(meta dto.Meta, before, u *model.User) error {
+	at := now(uc.c)
+	u.ChangeTimestamps(before.Timestamps().Updated(meta.Actor, at))
+
+	e, err := uc.af.Create(
+		meta.Actor,
+		meta.RequestID,
+		model.AuditActionUpdateUser,
+		model.NewUserAuditTarget(u.ID()),
+		model.DiffUser(before, u),
+		at,
+	)
+	if err != nil {
+		return err
+	}
+
+	u.RecordUpdated()
+	ms, err := uc.of.Create(pullEvents(u), at)
+	if err != nil {
+		return err
+	}
+
+	return uc.r.RunTransaction(func(tx repository.Transaction) error {
+		if err := tx.User().Update(u); err != nil {
+			return err
+		}
+		if _, err := tx.AuditEvent().Create(e); err != nil {
+			return err
+		}
+		if err := tx.OutboxMessage().Create(ms); err != nil {
+			return err
+		}
+		if err := enqueueWebhookDeliveries(tx, uc.wf, ms); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
 func (uc *userUsecase) DeleteUser(in *dto.DeleteUserInput) (*dto.DeleteUserOutput, error) {
 	uID := model.UserID(in.UserID)
 
-	isExisted, err := uc.us.Exists(uID)
+	u, err := uc.r.User().Find(uID)
 	if err != nil {
 		return nil, err
 	}
-	if !isExisted {
+	if u == nil {
 		return nil, ErrUserNotFound
 	}
 
-	hasGroupUser, err := uc.gs.HasUsersAny([]model.UserID{uID})
+	at := now(uc.c)
+	e, err := uc.af.Create(
+		in.Actor,
+		in.RequestID,
+		model.AuditActionDeleteUser,
+		model.NewUserAuditTarget(uID),
+		model.DiffUser(u, nil),
+		at,
+	)
 	if err != nil {
 		return nil, err
 	}
 
+	u.RecordDeleted()
+
 	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
-		if hasGroupUser {
-			if err := tx.Group().RemoveUsersFromAll([]model.UserID{uID}); err != nil {
-				return err
-			}
+		l, err := uc.leaveGroups(tx, in.Meta, u, at)
+		if err != nil {
+			return err
+		}
+		ms, err := uc.of.Create(append(pullEvents(l.gs...), pullEvents(u)...), at)
+		if err != nil {
+			return err
 		}
 
+		if err := l.store(tx); err != nil {
+			return err
+		}
 		if err := tx.User().Delete(uID); err != nil {
 			return err
 		}
+		if _, err := tx.AuditEvent().Create(e); err != nil {
+			return err
+		}
+		if err := tx.OutboxMessage().Create(ms); err != nil {
+			return err
+		}
+		if err := enqueueWebhookDeliveries(tx, uc.wf, ms); err != nil {
+			return err
+		}
 		return nil
 	}); err != nil {
 		return nil, err
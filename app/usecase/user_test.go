@@ -2,7 +2,10 @@ package usecase_test
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"go.uber.org/mock/gomock"
@@ -34,9 +37,41 @@ func TestUserUsecase_CreateUser(t *testing.T) {
 			},
 			want: &dto.CreateUserOutput{
 				User: dto.User{
-					UserID: "TEST_USER_ID",
-					Name:   "TEST_USER_NAME",
-					Email:  "TEST_USER_EMAIL",
+					UserID:     "TEST_USER_ID",
+					Name:       "TEST_USER_NAME",
+					Email:      "TEST_USER_EMAIL",
+					Status:     "ACTIVE",
+					Timestamps: dto.Timestamps{CreatedAt: testNow, UpdatedAt: testNow},
+				},
+			},
+			wantErr: nil,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+			newMockFactory: func(ctrl *gomock.Controller) factory.UserFactory {
+				f := mockfactory.NewMockUserFactory(ctrl)
+				f.EXPECT().
+					Create("TEST_USER_NAME", "TEST_USER_EMAIL").
+					Return(model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"), nil)
+				return f
+			},
+		},
+		{
+			name: "Creates a new invited user",
+			in: &dto.CreateUserInput{
+				Name:   "TEST_USER_NAME",
+				Email:  "TEST_USER_EMAIL",
+				Status: "INVITED",
+			},
+			want: &dto.CreateUserOutput{
+				User: dto.User{
+					UserID:     "TEST_USER_ID",
+					Name:       "TEST_USER_NAME",
+					Email:      "TEST_USER_EMAIL",
+					Status:     "INVITED",
+					Timestamps: dto.Timestamps{CreatedAt: testNow, UpdatedAt: testNow},
 				},
 			},
 			wantErr: nil,
@@ -53,6 +88,23 @@ func TestUserUsecase_CreateUser(t *testing.T) {
 				return f
 			},
 		},
+		{
+			name: "Returns error if the status is not an initial one",
+			in: &dto.CreateUserInput{
+				Name:   "TEST_USER_NAME",
+				Email:  "TEST_USER_EMAIL",
+				Status: "SUSPENDED",
+			},
+			wantErr: usecase.ErrInvalidUserInput,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+			newMockFactory: func(ctrl *gomock.Controller) factory.UserFactory {
+				return mockfactory.NewMockUserFactory(ctrl)
+			},
+		},
 		{
 			name: "Returns error if any of user inputs are invalid",
 			in: &dto.CreateUserInput{
@@ -64,6 +116,7 @@ func TestUserUsecase_CreateUser(t *testing.T) {
 					UserID: "TEST_USER_ID",
 					Name:   "TEST_USER_NAME",
 					Email:  "TEST_USER_EMAIL",
+					Status: "ACTIVE",
 				},
 			},
 			wantErr: usecase.ErrInvalidUserInput,
@@ -90,7 +143,14 @@ func TestUserUsecase_CreateUser(t *testing.T) {
 			r := tt.newMemoryRepository()
 			us := domainservice.NewUserService(r)
 			gs := domainservice.NewGroupService(r)
-			uc := usecase.NewUserUsecase(r, tt.newMockFactory(ctrl), us, gs)
+			uc := usecase.NewUserUsecase(
+				r, tt.newMockFactory(ctrl), us, gs,
+				factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+				factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+				factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+				model.DefaultPolicy(),
+				testClock,
+			)
 
 			got, err := uc.CreateUser(tt.in)
 
@@ -114,6 +174,12 @@ func TestUserUsecase_CreateUser(t *testing.T) {
 						tt.in, got, tt.want, diff,
 					)
 				}
+				assertAuditEvent(
+					t, r, tt.in.Meta,
+					model.AuditActionCreateUser,
+					model.NewUserAuditTarget(model.UserID(got.User.UserID)),
+				)
+				assertOutboxMessages(t, r, model.DomainEventTypeUserCreated)
 			}
 		})
 	}
@@ -137,6 +203,7 @@ func TestUserUsecase_GetUser(t *testing.T) {
 					UserID: "TEST_USER_ID",
 					Name:   "TEST_USER_NAME",
 					Email:  "TEST_USER_EMAIL",
+					Status: "ACTIVE",
 				},
 			},
 			wantErr: nil,
@@ -170,7 +237,14 @@ func TestUserUsecase_GetUser(t *testing.T) {
 			r := tt.newMemoryRepository()
 			us := domainservice.NewUserService(r)
 			gs := domainservice.NewGroupService(r)
-			uc := usecase.NewUserUsecase(r, mockfactory.NewMockUserFactory(ctrl), us, gs)
+			uc := usecase.NewUserUsecase(
+				r, mockfactory.NewMockUserFactory(ctrl), us, gs,
+				factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+				factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+				factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+				model.DefaultPolicy(),
+				testClock,
+			)
 
 			got, err := uc.GetUser(tt.in)
 			if tt.wantErr != nil {
@@ -216,16 +290,19 @@ func TestUserUsecase_GetUsers(t *testing.T) {
 						UserID: "TEST_USER_ID_1",
 						Name:   "TEST_USER_NAME_1",
 						Email:  "TEST_USER_EMAIL_1",
+						Status: "ACTIVE",
 					},
 					{
 						UserID: "TEST_USER_ID_2",
 						Name:   "TEST_USER_NAME_2",
 						Email:  "TEST_USER_EMAIL_2",
+						Status: "ACTIVE",
 					},
 					{
 						UserID: "TEST_USER_ID_3",
 						Name:   "TEST_USER_NAME_3",
 						Email:  "TEST_USER_EMAIL_3",
+						Status: "ACTIVE",
 					},
 				},
 			},
@@ -241,6 +318,173 @@ func TestUserUsecase_GetUsers(t *testing.T) {
 				return r
 			},
 		},
+		{
+			name: "Returns users filtered by user ids",
+			in:   &dto.GetUsersInput{UserIDs: []string{"TEST_USER_ID_2"}},
+			want: &dto.GetUsersOutput{
+				Users: []dto.User{
+					{
+						UserID: "TEST_USER_ID_2",
+						Name:   "TEST_USER_NAME_2",
+						Email:  "TEST_USER_EMAIL_2",
+						Status: "ACTIVE",
+					},
+				},
+			},
+			wantErr: nil,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+					model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+				)
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Returns users in no group created after the time",
+			in: &dto.GetUsersInput{
+				NoGroup:      true,
+				CreatedAfter: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			want: &dto.GetUsersOutput{
+				Users: []dto.User{
+					{
+						UserID: "TEST_USER_ID_3",
+						Name:   "TEST_USER_NAME_3",
+						Email:  "TEST_USER_EMAIL_3",
+						Status: "ACTIVE",
+						Timestamps: dto.Timestamps{
+							CreatedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+							UpdatedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+						},
+					},
+				},
+			},
+			wantErr: nil,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsersCreatedAt(
+					time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC),
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+				)
+				s.AddUsersCreatedAt(
+					time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+					model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+					model.MustNewUser("TEST_USER_ID_3", "TEST_USER_NAME_3", "TEST_USER_EMAIL_3"),
+				)
+				s.AddGroups(
+					model.MustNewGroup("TEST_GROUP_ID_1", "TEST_GROUP_NAME_1", []model.UserID{"TEST_USER_ID_2"}),
+				)
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Returns users searched by the query with highlights",
+			in:   &dto.GetUsersInput{Query: " AN "},
+			want: &dto.GetUsersOutput{
+				Users: []dto.User{
+					{
+						UserID: "TEST_USER_ID_2",
+						Name:   "Anna",
+						Email:  "anna@example.com",
+						Status: "ACTIVE",
+					},
+					{
+						UserID: "TEST_USER_ID_1",
+						Name:   "Joan",
+						Email:  "joan@example.com",
+						Status: "ACTIVE",
+					},
+				},
+				Highlights: map[string][]dto.Highlight{
+					"TEST_USER_ID_1": {
+						{Field: "name", Start: 2, End: 4},
+						{Field: "email", Start: 2, End: 4},
+					},
+					"TEST_USER_ID_2": {
+						{Field: "name", Start: 0, End: 2},
+						{Field: "email", Start: 0, End: 2},
+					},
+				},
+			},
+			wantErr: nil,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_1", "Joan", "joan@example.com"),
+					model.MustNewUser("TEST_USER_ID_2", "Anna", "anna@example.com"),
+					model.MustNewUser("TEST_USER_ID_3", "Bob", "bob@example.com"),
+				)
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Returns users sorted by the updated time in descending order",
+			in:   &dto.GetUsersInput{Sort: "-updatedAt"},
+			want: &dto.GetUsersOutput{
+				Users: []dto.User{
+					{
+						UserID: "TEST_USER_ID_2",
+						Name:   "TEST_USER_NAME_2",
+						Email:  "TEST_USER_EMAIL_2",
+						Status: "ACTIVE",
+						Timestamps: dto.Timestamps{
+							CreatedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+							UpdatedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+						},
+					},
+					{
+						UserID: "TEST_USER_ID_1",
+						Name:   "TEST_USER_NAME_1",
+						Email:  "TEST_USER_EMAIL_1",
+						Status: "ACTIVE",
+						Timestamps: dto.Timestamps{
+							CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+							UpdatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+						},
+					},
+				},
+			},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsersCreatedAt(
+					time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+				)
+				s.AddUsersCreatedAt(
+					time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+					model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+				)
+				return memory.NewMemoryRepository(s)
+			},
+		},
+		{
+			name: "Error unknown sort field",
+			in:   &dto.GetUsersInput{Sort: "name"},
+			want: nil,
+			wantErr: errors.Join(
+				usecase.ErrInvalidUserInput,
+				fmt.Errorf("unknown sort field %q: %w", "name", model.ErrInvalidSortOrder),
+			),
+			newMemoryRepository: func() repository.Repository {
+				return memory.NewMemoryRepository(memory.NewStore())
+			},
+		},
+		{
+			name: "Error too long query",
+			in:   &dto.GetUsersInput{Query: strings.Repeat("a", model.MaxSearchQueryLength+1)},
+			want: nil,
+			wantErr: fmt.Errorf(
+				"query must be at most %d characters: %w", model.MaxSearchQueryLength, usecase.ErrInvalidUserInput,
+			),
+			newMemoryRepository: func() repository.Repository {
+				return memory.NewMemoryRepository(memory.NewStore())
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -251,7 +495,14 @@ func TestUserUsecase_GetUsers(t *testing.T) {
 			r := tt.newMemoryRepository()
 			us := domainservice.NewUserService(r)
 			gs := domainservice.NewGroupService(r)
-			uc := usecase.NewUserUsecase(r, mockfactory.NewMockUserFactory(ctrl), us, gs)
+			uc := usecase.NewUserUsecase(
+				r, mockfactory.NewMockUserFactory(ctrl), us, gs,
+				factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+				factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+				factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+				model.DefaultPolicy(),
+				testClock,
+			)
 
 			got, err := uc.GetUsers(tt.in)
 			if tt.wantErr != nil {
@@ -286,23 +537,38 @@ func TestUserUsecase_UpdateUser(t *testing.T) {
 		in                  *dto.UpdateUserInput
 		wantUser            *model.User
 		newMemoryRepository func() repository.Repository
+		reservedNames       []string
 		wantErr             error
 	}{
 		{
 			name: "Update a user",
 			in: &dto.UpdateUserInput{
+				Meta: dto.Meta{
+					Actor:     "TEST_ACTOR",
+					RequestID: "TEST_REQUEST_ID",
+				},
 				UserID: "TEST_USER_ID",
 				Name:   "TEST_USER_NAME_UPDATED",
 				Email:  "TEST_USER_EMAIL_UPDATED",
 			},
-			wantUser: model.MustNewUser(
-				"TEST_USER_ID",
-				"TEST_USER_NAME_UPDATED",
-				"TEST_USER_EMAIL_UPDATED",
+			wantUser: stamped(
+				model.MustNewUser(
+					"TEST_USER_ID",
+					"TEST_USER_NAME_UPDATED",
+					"TEST_USER_EMAIL_UPDATED",
+				),
+				model.Timestamps{
+					CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+					UpdatedAt: testNow,
+					UpdatedBy: "TEST_ACTOR",
+				},
 			),
 			newMemoryRepository: func() repository.Repository {
 				s := memory.NewStore()
-				s.AddUsers(model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"))
+				s.AddUsersCreatedAt(
+					time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+					model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"),
+				)
 				r := memory.NewMemoryRepository(s)
 				return r
 			},
@@ -322,6 +588,23 @@ func TestUserUsecase_UpdateUser(t *testing.T) {
 				return r
 			},
 		},
+		{
+			name: "Returns error if the policy reserves the name",
+			in: &dto.UpdateUserInput{
+				UserID: "TEST_USER_ID",
+				Name:   "admin",
+				Email:  "TEST_USER_EMAIL_UPDATED",
+			},
+			wantUser: nil,
+			wantErr:  usecase.ErrInvalidUserInput,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+			reservedNames: []string{"Admin"},
+		},
 		{
 			name: "Returns error if the user does not exist",
 			in: &dto.UpdateUserInput{
@@ -348,7 +631,16 @@ func TestUserUsecase_UpdateUser(t *testing.T) {
 			r := tt.newMemoryRepository()
 			us := domainservice.NewUserService(r)
 			gs := domainservice.NewGroupService(r)
-			uc := usecase.NewUserUsecase(r, f, us, gs)
+			p := model.DefaultPolicy()
+			p.User.Name.Reserved = tt.reservedNames
+			uc := usecase.NewUserUsecase(
+				r, f, us, gs,
+				factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+				factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+				factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+				p,
+				testClock,
+			)
 
 			_, err := uc.UpdateUser(tt.in)
 			if tt.wantErr != nil {
@@ -373,16 +665,181 @@ func TestUserUsecase_UpdateUser(t *testing.T) {
 						uID, got, tt.wantUser, diff,
 					)
 				}
+				assertAuditEvent(t, r, tt.in.Meta, model.AuditActionUpdateUser, model.NewUserAuditTarget(uID))
+				assertOutboxMessages(t, r, model.DomainEventTypeUserUpdated)
 			}
 		})
 	}
 }
 
+func TestUserUsecase_PatchUser(t *testing.T) {
+	tests := []struct {
+		name                string
+		in                  *dto.PatchUserInput
+		wantUser            *model.User
+		newMemoryRepository func() repository.Repository
+		wantErr             error
+	}{
+		{
+			name: "Patch a user with a JSON Merge Patch",
+			in: &dto.PatchUserInput{
+				Meta: dto.Meta{
+					Actor:     "TEST_ACTOR",
+					RequestID: "TEST_REQUEST_ID",
+				},
+				UserID:    "TEST_USER_ID",
+				PatchType: dto.PatchTypeMergePatch,
+				Patch:     []byte(`{"name":"TEST_USER_NAME_UPDATED"}`),
+			},
+			wantUser: stamped(
+				model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME_UPDATED", "TEST_USER_EMAIL"),
+				model.Timestamps{UpdatedAt: testNow, UpdatedBy: "TEST_ACTOR"},
+			),
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Patch a user with a JSON Patch",
+			in: &dto.PatchUserInput{
+				Meta: dto.Meta{
+					Actor:     "TEST_ACTOR",
+					RequestID: "TEST_REQUEST_ID",
+				},
+				UserID:    "TEST_USER_ID",
+				PatchType: dto.PatchTypeJSONPatch,
+				Patch: []byte(`[
+					{"op":"test","path":"/email","value":"TEST_USER_EMAIL"},
+					{"op":"replace","path":"/email","value":"TEST_USER_EMAIL_UPDATED"}
+				]`),
+			},
+			wantUser: stamped(
+				model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL_UPDATED"),
+				model.Timestamps{UpdatedAt: testNow, UpdatedBy: "TEST_ACTOR"},
+			),
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Returns error if the patched user is invalid",
+			in: &dto.PatchUserInput{
+				UserID:    "TEST_USER_ID",
+				PatchType: dto.PatchTypeMergePatch,
+				Patch:     []byte(`{"name":null}`),
+			},
+			wantErr: usecase.ErrInvalidUserInput,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Returns error if the test of a JSON Patch fails",
+			in: &dto.PatchUserInput{
+				UserID:    "TEST_USER_ID",
+				PatchType: dto.PatchTypeJSONPatch,
+				Patch:     []byte(`[{"op":"test","path":"/name","value":"TEST_USER_NAME_OTHER"}]`),
+			},
+			wantErr: usecase.ErrInvalidPatch,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Returns error if the patch adds an unknown field",
+			in: &dto.PatchUserInput{
+				UserID:    "TEST_USER_ID",
+				PatchType: dto.PatchTypeMergePatch,
+				Patch:     []byte(`{"userId":"TEST_USER_ID_OTHER"}`),
+			},
+			wantErr: usecase.ErrInvalidPatch,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"))
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+		{
+			name: "Returns error if the user does not exist",
+			in: &dto.PatchUserInput{
+				UserID:    "TEST_USER_ID",
+				PatchType: dto.PatchTypeMergePatch,
+				Patch:     []byte(`{"name":"TEST_USER_NAME_UPDATED"}`),
+			},
+			wantErr: usecase.ErrUserNotFound,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := mockfactory.NewMockUserFactory(ctrl)
+			r := tt.newMemoryRepository()
+			us := domainservice.NewUserService(r)
+			gs := domainservice.NewGroupService(r)
+			uc := usecase.NewUserUsecase(
+				r, f, us, gs,
+				factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+				factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+				factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+				model.DefaultPolicy(),
+				testClock,
+			)
+
+			_, err := uc.PatchUser(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf(
+						"uc.PatchUser(%v)=_, %v; want _, %v",
+						tt.in, err, tt.wantErr,
+					)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			uID := model.UserID(tt.in.UserID)
+			got, _ := r.User().Find(uID)
+			if diff := cmp.Diff(got, tt.wantUser, cmp.AllowUnexported(model.User{})); diff != "" {
+				t.Errorf(
+					"r.User().Find(%s)=%v, _; want %v, nil\ndiffers: (-got +want)\n%s",
+					uID, got, tt.wantUser, diff,
+				)
+			}
+			assertAuditEvent(t, r, tt.in.Meta, model.AuditActionUpdateUser, model.NewUserAuditTarget(uID))
+			assertOutboxMessages(t, r, model.DomainEventTypeUserUpdated)
+		})
+	}
+}
+
 func TestUserUsecase_DeleteUser(t *testing.T) {
 	tests := []struct {
 		name                string
 		in                  *dto.DeleteUserInput
 		newMemoryRepository func() repository.Repository
+		wantWaitlists       map[model.GroupID][]model.UserID
+		wantEventTypes      []model.DomainEventType
 		wantErr             error
 	}{
 		{
@@ -396,6 +853,7 @@ func TestUserUsecase_DeleteUser(t *testing.T) {
 				r := memory.NewMemoryRepository(s)
 				return r
 			},
+			wantEventTypes: []model.DomainEventType{model.DomainEventTypeUserDeleted},
 		},
 		{
 			name: "Delete a user and remove from all groups",
@@ -424,6 +882,71 @@ func TestUserUsecase_DeleteUser(t *testing.T) {
 				r := memory.NewMemoryRepository(s)
 				return r
 			},
+			wantEventTypes: []model.DomainEventType{model.DomainEventTypeUserDeleted},
+		},
+		{
+			name: "Delete a user who belongs to groups",
+			in: &dto.DeleteUserInput{
+				UserID: "TEST_USER_ID",
+			},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"))
+				s.AddGroups(model.MustNewGroup(
+					"TEST_GROUP_ID",
+					"TEST_GROUP_NAME",
+					[]model.UserID{
+						"TEST_USER_ID",
+						"TEST_USER_ID_2",
+					}),
+				)
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+			wantEventTypes: []model.DomainEventType{
+				model.DomainEventTypeGroupMembershipChanged,
+				model.DomainEventTypeUserDeleted,
+			},
+		},
+		{
+			name: "Delete a user and promote the waitlisted users to the groups the user leaves",
+			in: &dto.DeleteUserInput{
+				UserID: "TEST_USER_ID",
+			},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"))
+				s.AddGroups(
+					model.MustNewGroup(
+						"TEST_GROUP_ID_1",
+						"TEST_GROUP_NAME_1",
+						[]model.UserID{
+							"TEST_USER_ID",
+							"TEST_USER_ID_2",
+							"TEST_USER_ID_3",
+							"TEST_USER_ID_4",
+							"TEST_USER_ID_5",
+						},
+					),
+					model.MustNewGroup("TEST_GROUP_ID_2", "TEST_GROUP_NAME_2", []model.UserID{"TEST_USER_ID_2"}),
+				)
+				s.AddGroupWaitlists(
+					model.MustNewGroupWaitlist("TEST_GROUP_ID_1", []model.UserID{"TEST_USER_ID_6", "TEST_USER_ID_7"}),
+					model.MustNewGroupWaitlist("TEST_GROUP_ID_2", []model.UserID{"TEST_USER_ID"}),
+				)
+				r := memory.NewMemoryRepository(s)
+				return r
+			},
+			wantWaitlists: map[model.GroupID][]model.UserID{
+				"TEST_GROUP_ID_1": {"TEST_USER_ID_7"},
+				"TEST_GROUP_ID_2": nil,
+			},
+			wantEventTypes: []model.DomainEventType{
+				model.DomainEventTypeGroupMembershipChanged,
+				model.DomainEventTypeGroupMembershipChanged,
+				model.DomainEventTypeGroupWaitlistPromoted,
+				model.DomainEventTypeUserDeleted,
+			},
 		},
 		{
 			name: "Returns error if the user does not exist",
@@ -448,7 +971,14 @@ func TestUserUsecase_DeleteUser(t *testing.T) {
 			r := tt.newMemoryRepository()
 			us := domainservice.NewUserService(r)
 			gs := domainservice.NewGroupService(r)
-			uc := usecase.NewUserUsecase(r, f, us, gs)
+			uc := usecase.NewUserUsecase(
+				r, f, us, gs,
+				factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+				factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+				factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+				model.DefaultPolicy(),
+				testClock,
+			)
 
 			_, err := uc.DeleteUser(tt.in)
 			if tt.wantErr != nil {
@@ -476,6 +1006,12 @@ func TestUserUsecase_DeleteUser(t *testing.T) {
 				if hasGroupTargetUser {
 					t.Errorf("any of groups have the target user")
 				}
+
+				for gID, want := range tt.wantWaitlists {
+					assertGroupWaitlist(t, r, gID, want...)
+				}
+				assertAuditEvent(t, r, tt.in.Meta, model.AuditActionDeleteUser, model.NewUserAuditTarget(uID))
+				assertOutboxMessages(t, r, tt.wantEventTypes...)
 			}
 		})
 	}
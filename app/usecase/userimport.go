@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// MaxImportBatchSize is the limit of the number of the rows committed in a transaction.
+const MaxImportBatchSize = 1000
+
+// importChange is a user to be created or updated by a row.
+type importChange struct {
+	result *dto.ImportUserResult
+	before *model.User
+	user   *model.User
+}
+
+// ImportUsers imports the rows, each of which is validated as CreateUser does, in batches of the batch size.
+// A row never stops the import, but its result reports why it is skipped or failed.
+func (uc *userUsecase) ImportUsers(in *dto.ImportUsersInput) (*dto.ImportUsersOutput, error) {
+	mode := in.Mode
+	if mode == "" {
+		mode = dto.ImportModeCreate
+	}
+	if mode != dto.ImportModeCreate && mode != dto.ImportModeUpsert {
+		return nil, fmt.Errorf("unknown mode %q: %w", in.Mode, ErrInvalidImportInput)
+	}
+	if in.BatchSize <= 0 || in.BatchSize > MaxImportBatchSize {
+		return nil, fmt.Errorf(
+			"batch size must be between 1 and %d: %w", MaxImportBatchSize, ErrInvalidImportInput,
+		)
+	}
+
+	results := make([]dto.ImportUserResult, len(in.Rows))
+	// The lines of the rows by email, to skip the later rows of the same email.
+	lines := map[string]int{}
+	for start := 0; start < len(in.Rows); start += in.BatchSize {
+		end := start + in.BatchSize
+		if end > len(in.Rows) {
+			end = len(in.Rows)
+		}
+
+		changes, err := uc.planImport(in.Rows[start:end], results[start:end], mode, lines)
+		if err != nil {
+			return nil, err
+		}
+		if in.DryRun || len(changes) == 0 {
+			continue
+		}
+
+		if err := uc.commitImport(in.Meta, changes); err != nil {
+			for _, c := range changes {
+				c.result.Status = dto.ImportStatusFailed
+				c.result.Reason = err.Error()
+			}
+		}
+	}
+
+	return &dto.ImportUsersOutput{Results: results}, nil
+}
+
+// planImport fills the results of the rows and returns the changes to be committed.
+func (uc *userUsecase) planImport(
+	rows []dto.ImportUserRow,
+	results []dto.ImportUserResult,
+	mode dto.ImportMode,
+	lines map[string]int,
+) ([]importChange, error) {
+	var emails []string
+	for _, row := range rows {
+		if row.Err == nil && row.Email != "" {
+			emails = append(emails, row.Email)
+		}
+	}
+	existing := map[string]*model.User{}
+	if len(emails) > 0 {
+		us, err := uc.r.User().List(repository.UserListFilter{Emails: emails})
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range us {
+			existing[u.Email()] = u
+		}
+	}
+
+	// The imported users have no attributes, so the users are not created if the schemas require any.
+	ss, err := uc.r.AttributeSchema().List(repository.AttributeSchemaListFilter{Target: model.AttributeTargetUser})
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []importChange
+	for i, row := range rows {
+		res := &results[i]
+		res.Line = row.Line
+		res.Email = row.Email
+
+		if row.Err != nil {
+			res.Status = dto.ImportStatusFailed
+			res.Reason = row.Err.Error()
+			continue
+		}
+
+		u, err := uc.f.Create(row.Name, row.Email)
+		if err != nil {
+			if !errors.Is(err, model.ErrInvalidUser) {
+				return nil, err
+			}
+			res.Status = dto.ImportStatusFailed
+			res.Reason = err.Error()
+			continue
+		}
+
+		if line, ok := lines[row.Email]; ok {
+			res.Status = dto.ImportStatusSkipped
+			res.Reason = fmt.Sprintf("the email is imported by line %d", line)
+			continue
+		}
+		lines[row.Email] = row.Line
+
+		before, ok := existing[row.Email]
+		switch {
+		case !ok:
+			if err := ss.Validate(u.Attributes()); err != nil {
+				res.Status = dto.ImportStatusFailed
+				res.Reason = err.Error()
+				continue
+			}
+			res.Status = dto.ImportStatusCreated
+			res.UserID = string(u.ID())
+			changes = append(changes, importChange{result: res, user: u})
+		case mode == dto.ImportModeCreate:
+			res.Status = dto.ImportStatusFailed
+			res.UserID = string(before.ID())
+			res.Reason = "a user of the email already exists"
+		case before.Name() == u.Name():
+			res.Status = dto.ImportStatusSkipped
+			res.UserID = string(before.ID())
+			res.Reason = "the user is unchanged"
+		default:
+			after, err := model.NewUserWithStatus(before.ID(), u.Name(), u.Email(), before.Status())
+			if err != nil {
+				return nil, err
+			}
+			after.ChangeAttributes(before.Attributes())
+			res.Status = dto.ImportStatusUpdated
+			res.UserID = string(after.ID())
+			changes = append(changes, importChange{result: res, before: before, user: after})
+		}
+	}
+	return changes, nil
+}
+
+// commitImport stores the changes stamped by the actor with their audit events and domain events in a transaction.
+func (uc *userUsecase) commitImport(meta dto.Meta, changes []importChange) error {
+	var (
+		es model.AuditEvents
+		ms model.OutboxMessages
+	)
+	at := now(uc.c)
+	for _, c := range changes {
+		action := model.AuditActionCreateUser
+		if c.before != nil {
+			action = model.AuditActionUpdateUser
+			c.user.ChangeTimestamps(c.before.Timestamps().Updated(meta.Actor, at))
+		} else {
+			c.user.ChangeTimestamps(model.NewTimestamps(meta.Actor, at))
+		}
+		e, err := uc.af.Create(
+			meta.Actor,
+			meta.RequestID,
+			action,
+			model.NewUserAuditTarget(c.user.ID()),
+			model.DiffUser(c.before, c.user),
+			at,
+		)
+		if err != nil {
+			return err
+		}
+		es = append(es, e)
+
+		if c.before == nil {
+			c.user.RecordCreated()
+		} else {
+			c.user.RecordUpdated()
+		}
+		m, err := uc.of.Create(pullEvents(c.user), at)
+		if err != nil {
+			return err
+		}
+		ms = append(ms, m...)
+	}
+
+	return uc.r.RunTransaction(func(tx repository.Transaction) error {
+		for _, c := range changes {
+			if c.before == nil {
+				if _, err := tx.User().Create(c.user); err != nil {
+					return err
+				}
+			} else if err := tx.User().Update(c.user); err != nil {
+				return err
+			}
+		}
+		for _, e := range es {
+			if _, err := tx.AuditEvent().Create(e); err != nil {
+				return err
+			}
+		}
+		if err := tx.OutboxMessage().Create(ms); err != nil {
+			return err
+		}
+		return enqueueWebhookDeliveries(tx, uc.wf, ms)
+	})
+}
@@ -0,0 +1,265 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/domainservice"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/memory"
+	mockfactory "github.com/toshiykst/go-layerd-architecture/app/mock/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func TestUserUsecase_ImportUsers(t *testing.T) {
+	tests := []struct {
+		name                string
+		in                  *dto.ImportUsersInput
+		want                *dto.ImportUsersOutput
+		wantUsers           model.Users
+		wantEventTypes      []model.DomainEventType
+		wantErr             error
+		newMemoryRepository func() repository.Repository
+	}{
+		{
+			name: "Creates the users of the valid rows",
+			in: &dto.ImportUsersInput{
+				Meta: dto.Meta{
+					Actor:     "TEST_ACTOR",
+					RequestID: "TEST_REQUEST_ID",
+				},
+				Rows: []dto.ImportUserRow{
+					{Line: 2, Name: "TEST_USER_NAME_1", Email: "TEST_USER_EMAIL_1"},
+					{Line: 3, Name: "TEST_USER_NAME_XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX", Email: "TEST_USER_EMAIL_2"},
+					{Line: 4, Err: errors.New("TEST_ROW_ERROR")},
+					{Line: 5, Name: "TEST_USER_NAME_3", Email: "TEST_USER_EMAIL_1"},
+					{Line: 6, Name: "TEST_USER_NAME_4", Email: "TEST_USER_EMAIL_EXISTING"},
+				},
+				BatchSize: 100,
+			},
+			want: &dto.ImportUsersOutput{
+				Results: []dto.ImportUserResult{
+					{
+						Line:   2,
+						Status: dto.ImportStatusCreated,
+						UserID: "TEST_USER_ID_TEST_USER_EMAIL_1",
+						Email:  "TEST_USER_EMAIL_1",
+					},
+					{
+						Line:   3,
+						Status: dto.ImportStatusFailed,
+						Email:  "TEST_USER_EMAIL_2",
+						Reason: "exceeds the max user name length: invalid user",
+					},
+					{
+						Line:   4,
+						Status: dto.ImportStatusFailed,
+						Reason: "TEST_ROW_ERROR",
+					},
+					{
+						Line:   5,
+						Status: dto.ImportStatusSkipped,
+						Email:  "TEST_USER_EMAIL_1",
+						Reason: "the email is imported by line 2",
+					},
+					{
+						Line:   6,
+						Status: dto.ImportStatusFailed,
+						UserID: "TEST_USER_ID_EXISTING",
+						Email:  "TEST_USER_EMAIL_EXISTING",
+						Reason: "a user of the email already exists",
+					},
+				},
+			},
+			wantUsers: model.Users{
+				model.MustNewUser("TEST_USER_ID_EXISTING", "TEST_USER_NAME_EXISTING", "TEST_USER_EMAIL_EXISTING"),
+				stamped(
+					model.MustNewUser("TEST_USER_ID_TEST_USER_EMAIL_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+					model.NewTimestamps("TEST_ACTOR", testNow),
+				),
+			},
+			wantEventTypes: []model.DomainEventType{model.DomainEventTypeUserCreated},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_EXISTING", "TEST_USER_NAME_EXISTING", "TEST_USER_EMAIL_EXISTING"),
+				)
+				return memory.NewMemoryRepository(s)
+			},
+		},
+		{
+			name: "Upserts the users by email in batches",
+			in: &dto.ImportUsersInput{
+				Rows: []dto.ImportUserRow{
+					{Line: 1, Name: "TEST_USER_NAME_UPDATED", Email: "TEST_USER_EMAIL_1"},
+					{Line: 2, Name: "TEST_USER_NAME_2", Email: "TEST_USER_EMAIL_2"},
+					{Line: 3, Name: "TEST_USER_NAME_3", Email: "TEST_USER_EMAIL_3"},
+				},
+				Mode:      dto.ImportModeUpsert,
+				BatchSize: 1,
+			},
+			want: &dto.ImportUsersOutput{
+				Results: []dto.ImportUserResult{
+					{
+						Line:   1,
+						Status: dto.ImportStatusUpdated,
+						UserID: "TEST_USER_ID_1",
+						Email:  "TEST_USER_EMAIL_1",
+					},
+					{
+						Line:   2,
+						Status: dto.ImportStatusSkipped,
+						UserID: "TEST_USER_ID_2",
+						Email:  "TEST_USER_EMAIL_2",
+						Reason: "the user is unchanged",
+					},
+					{
+						Line:   3,
+						Status: dto.ImportStatusCreated,
+						UserID: "TEST_USER_ID_TEST_USER_EMAIL_3",
+						Email:  "TEST_USER_EMAIL_3",
+					},
+				},
+			},
+			wantUsers: model.Users{
+				stamped(
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_UPDATED", "TEST_USER_EMAIL_1"),
+					model.Timestamps{UpdatedAt: testNow},
+				),
+				model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+				stamped(
+					model.MustNewUser("TEST_USER_ID_TEST_USER_EMAIL_3", "TEST_USER_NAME_3", "TEST_USER_EMAIL_3"),
+					model.NewTimestamps("", testNow),
+				),
+			},
+			wantEventTypes: []model.DomainEventType{
+				model.DomainEventTypeUserUpdated,
+				model.DomainEventTypeUserCreated,
+			},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				s.AddUsers(
+					model.MustNewUser("TEST_USER_ID_1", "TEST_USER_NAME_1", "TEST_USER_EMAIL_1"),
+					model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+				)
+				return memory.NewMemoryRepository(s)
+			},
+		},
+		{
+			name: "Reports the results without importing the users in a dry run",
+			in: &dto.ImportUsersInput{
+				Rows: []dto.ImportUserRow{
+					{Line: 2, Name: "TEST_USER_NAME_1", Email: "TEST_USER_EMAIL_1"},
+				},
+				DryRun:    true,
+				BatchSize: 100,
+			},
+			want: &dto.ImportUsersOutput{
+				Results: []dto.ImportUserResult{
+					{
+						Line:   2,
+						Status: dto.ImportStatusCreated,
+						UserID: "TEST_USER_ID_TEST_USER_EMAIL_1",
+						Email:  "TEST_USER_EMAIL_1",
+					},
+				},
+			},
+			wantUsers:      nil,
+			wantEventTypes: []model.DomainEventType{},
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				return memory.NewMemoryRepository(s)
+			},
+		},
+		{
+			name: "Returns error if the mode is unknown",
+			in: &dto.ImportUsersInput{
+				Mode:      "TEST_MODE",
+				BatchSize: 100,
+			},
+			wantErr: usecase.ErrInvalidImportInput,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				return memory.NewMemoryRepository(s)
+			},
+		},
+		{
+			name: "Returns error if the batch size exceeds the limit",
+			in: &dto.ImportUsersInput{
+				BatchSize: usecase.MaxImportBatchSize + 1,
+			},
+			wantErr: usecase.ErrInvalidImportInput,
+			newMemoryRepository: func() repository.Repository {
+				s := memory.NewStore()
+				return memory.NewMemoryRepository(s)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := mockfactory.NewMockUserFactory(ctrl)
+			f.EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(name, email string) (*model.User, error) {
+					if err := model.DefaultPolicy().User.ValidateName(name); err != nil {
+						return nil, err
+					}
+					return model.NewUser(model.UserID("TEST_USER_ID_"+email), name, email)
+				}).
+				AnyTimes()
+			r := tt.newMemoryRepository()
+			us := domainservice.NewUserService(r)
+			gs := domainservice.NewGroupService(r)
+			uc := usecase.NewUserUsecase(
+				r, f, us, gs,
+				factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+				factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+				factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+				model.DefaultPolicy(),
+				testClock,
+			)
+
+			got, err := uc.ImportUsers(tt.in)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Error("want an error, but has no error")
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf(
+						"uc.ImportUsers(%v)=_, %v; want _, %v",
+						tt.in, err, tt.wantErr,
+					)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no err, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(
+					"uc.ImportUsers(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+					tt.in, got, tt.want, diff,
+				)
+			}
+
+			gotUsers, _ := r.User().List(repository.UserListFilter{})
+			if diff := cmp.Diff(gotUsers, tt.wantUsers, cmp.AllowUnexported(model.User{})); diff != "" {
+				t.Errorf(
+					"r.User().List()=%v, _; want %v, nil\ndiffers: (-got +want)\n%s",
+					gotUsers, tt.wantUsers, diff,
+				)
+			}
+			assertOutboxMessages(t, r, tt.wantEventTypes...)
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// ActivateUser activates the invited user.
+func (uc *userUsecase) ActivateUser(in *dto.ActivateUserInput) (*dto.ActivateUserOutput, error) {
+	before, err := uc.findUser(model.UserID(in.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := uc.changeStatus(in.Meta, before, now(uc.c), (*model.User).Activate, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ActivateUserOutput{User: dto.ToUserFromModel(u)}, nil
+}
+
+// SuspendUser suspends the active user, who keeps their memberships but leaves the waitlists of the groups and can
+// join no group until reactivated.
+func (uc *userUsecase) SuspendUser(in *dto.SuspendUserInput) (*dto.SuspendUserOutput, error) {
+	before, err := uc.findUser(model.UserID(in.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := uc.changeStatus(
+		in.Meta, before, now(uc.c), (*model.User).Suspend,
+		func(tx repository.Transaction) (model.Groups, error) {
+			return nil, tx.GroupWaitlist().RemoveUsersFromAll([]model.UserID{before.ID()})
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.SuspendUserOutput{User: dto.ToUserFromModel(u)}, nil
+}
+
+// ReactivateUser reactivates the suspended or deactivated user. The memberships the user lost are not restored.
+func (uc *userUsecase) ReactivateUser(in *dto.ReactivateUserInput) (*dto.ReactivateUserOutput, error) {
+	before, err := uc.findUser(model.UserID(in.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := uc.changeStatus(in.Meta, before, now(uc.c), (*model.User).Reactivate, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ReactivateUserOutput{User: dto.ToUserFromModel(u)}, nil
+}
+
+// DeactivateUser deactivates the user, who leaves their groups and their waitlists and whose pending invitations and
+// join requests are cancelled as DeleteUser does, but whose record is kept.
+func (uc *userUsecase) DeactivateUser(in *dto.DeactivateUserInput) (*dto.DeactivateUserOutput, error) {
+	before, err := uc.findUser(model.UserID(in.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	at := now(uc.c)
+	u, err := uc.changeStatus(
+		in.Meta, before, at, (*model.User).Deactivate,
+		func(tx repository.Transaction) (model.Groups, error) {
+			l, err := uc.leaveGroups(tx, in.Meta, before, at)
+			if err != nil {
+				return nil, err
+			}
+			return l.gs, l.store(tx)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.DeactivateUserOutput{User: dto.ToUserFromModel(u)}, nil
+}
+
+// changeStatus applies the transition to a copy of the user and stores it, stamped as updated at the time, with its
+// audit event and domain events. The store, if any, stores the other changes in the same transaction and returns
+// the groups changed with them, whose events are stored with the ones of the user.
+func (uc *userUsecase) changeStatus(
+	meta dto.Meta,
+	before *model.User,
+	at time.Time,
+	transition func(u *model.User) error,
+	store func(tx repository.Transaction) (model.Groups, error),
+) (*model.User, error) {
+	u, err := model.NewUserWithStatus(before.ID(), before.Name(), before.Email(), before.Status())
+	if err != nil {
+		return nil, err
+	}
+	u.ChangeAttributes(before.Attributes())
+	if err := transition(u); err != nil {
+		if errors.Is(err, model.ErrUserStatusTransition) {
+			return nil, errors.Join(ErrInvalidUserStatusTransition, err)
+		}
+		return nil, err
+	}
+	u.ChangeTimestamps(before.Timestamps().Updated(meta.Actor, at))
+
+	e, err := uc.af.Create(
+		meta.Actor,
+		meta.RequestID,
+		model.AuditActionUpdateUser,
+		model.NewUserAuditTarget(u.ID()),
+		model.DiffUser(before, u),
+		at,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		var gs model.Groups
+		if store != nil {
+			if gs, err = store(tx); err != nil {
+				return err
+			}
+		}
+		ms, err := uc.of.Create(append(pullEvents(gs...), pullEvents(u)...), at)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.User().Update(u); err != nil {
+			return err
+		}
+		if _, err := tx.AuditEvent().Create(e); err != nil {
+			return err
+		}
+		if err := tx.OutboxMessage().Create(ms); err != nil {
+			return err
+		}
+		if err := enqueueWebhookDeliveries(tx, uc.wf, ms); err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+func (uc *userUsecase) findUser(uID model.UserID) (*model.User, error) {
+	u, err := uc.r.User().Find(uID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, ErrUserNotFound
+	}
+	return u, nil
+}
+
+// groupLeave is the user leaving the groups they belong to and all the waitlists, where the waitlisted users are
+// promoted to the room made in the groups, and cancelling their pending invitations and join requests.
+type groupLeave struct {
+	uID      model.UserID
+	gs       model.Groups
+	ws       map[model.GroupID]*model.GroupWaitlist
+	promoted map[model.GroupID][]model.UserID
+	invs     model.GroupInvitations
+	jrs      model.GroupJoinRequests
+}
+
+// leaveGroups removes the user from copies of the groups they belong to and promotes the waitlisted users, recording
+// the events in the copies and stamping them as updated by the actor at the time, and revokes the pending invitations
+// for the user and cancels the pending join requests of the user at the time.
+// The groups are read again locked in the transaction, in the order of id, before their waitlists are read.
+func (uc *userUsecase) leaveGroups(
+	tx repository.Transaction,
+	meta dto.Meta,
+	u *model.User,
+	at time.Time,
+) (*groupLeave, error) {
+	uID := u.ID()
+	gs, err := uc.r.Group().List(repository.GroupListFilter{
+		UserIDs: []model.UserID{uID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	l := &groupLeave{
+		uID:      uID,
+		gs:       make(model.Groups, 0, len(gs)),
+		ws:       make(map[model.GroupID]*model.GroupWaitlist, len(gs)),
+		promoted: make(map[model.GroupID][]model.UserID, len(gs)),
+	}
+	for _, listed := range gs {
+		before, err := tx.Group().FindForUpdate(listed.ID())
+		if err != nil {
+			return nil, err
+		}
+		if before == nil || !before.HasUser(uID) {
+			// The group was deleted or the user left it after it was listed.
+			continue
+		}
+		g, err := copyGroup(before)
+		if err != nil {
+			return nil, err
+		}
+		g.RemoveUsers([]model.UserID{uID})
+		g.ChangeTimestamps(g.Timestamps().Updated(meta.Actor, at))
+
+		w, err := tx.GroupWaitlist().Find(g.ID())
+		if err != nil {
+			return nil, err
+		}
+		w.Remove([]model.UserID{uID})
+		if l.promoted[g.ID()], err = g.PromoteWaitlisted(uc.p.GroupPolicyFor(g), w); err != nil {
+			return nil, err
+		}
+		l.gs = append(l.gs, g)
+		l.ws[g.ID()] = w
+	}
+
+	if l.invs, err = uc.r.GroupInvitation().List(repository.GroupInvitationListFilter{
+		InviteeUserID: uID,
+		InviteeEmail:  u.Email(),
+		Status:        model.GroupInvitationStatusPending,
+		ExpiresAfter:  at,
+	}); err != nil {
+		return nil, err
+	}
+	for _, inv := range l.invs {
+		if err := inv.Revoke(at); err != nil {
+			return nil, err
+		}
+	}
+
+	if l.jrs, err = uc.r.GroupJoinRequest().List(repository.GroupJoinRequestListFilter{
+		UserID: uID,
+		Status: model.GroupJoinRequestStatusPending,
+	}); err != nil {
+		return nil, err
+	}
+	for _, jr := range l.jrs {
+		if err := jr.Cancel(meta.Actor, at); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// store stores the leave in the transaction.
+func (l *groupLeave) store(tx repository.Transaction) error {
+	if err := tx.GroupWaitlist().RemoveUsersFromAll([]model.UserID{l.uID}); err != nil {
+		return err
+	}
+	if len(l.gs) > 0 {
+		if err := tx.Group().RemoveUsersFromAll([]model.UserID{l.uID}); err != nil {
+			return err
+		}
+	}
+	for _, g := range l.gs {
+		if err := promoteWaitlisted(tx, g.ID(), l.promoted[g.ID()], l.ws[g.ID()]); err != nil {
+			return err
+		}
+		if err := tx.Group().Update(g); err != nil {
+			return err
+		}
+	}
+	for _, inv := range l.invs {
+		if err := tx.GroupInvitation().Update(inv); err != nil {
+			return err
+		}
+	}
+	for _, jr := range l.jrs {
+		if err := tx.GroupJoinRequest().Update(jr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,439 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/domainservice"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/memory"
+	mockfactory "github.com/toshiykst/go-layerd-architecture/app/mock/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// newUserStatusMemoryRepository returns the repository with the user in the status, who belongs to the first group
+// of capacity 2 and is waitlisted in the second group. Another user is waitlisted in the first group.
+func newUserStatusMemoryRepository(status model.UserStatus) repository.Repository {
+	s := memory.NewStore()
+	s.AddUsers(
+		model.MustNewUserWithStatus("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL", status),
+		model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "TEST_USER_EMAIL_2"),
+		model.MustNewUser("TEST_USER_ID_3", "TEST_USER_NAME_3", "TEST_USER_EMAIL_3"),
+	)
+	s.AddGroups(
+		model.MustNewGroup("TEST_GROUP_ID_1", "TEST_GROUP_NAME_1", []model.UserID{"TEST_USER_ID", "TEST_USER_ID_2"}),
+		model.MustNewGroup("TEST_GROUP_ID_2", "TEST_GROUP_NAME_2", []model.UserID{"TEST_USER_ID_2", "TEST_USER_ID_3"}),
+	)
+	s.AddGroupWaitlists(
+		model.MustNewGroupWaitlist("TEST_GROUP_ID_1", []model.UserID{"TEST_USER_ID_3"}),
+		model.MustNewGroupWaitlist("TEST_GROUP_ID_2", []model.UserID{"TEST_USER_ID"}),
+	)
+	return memory.NewMemoryRepository(s)
+}
+
+func newUserStatusUsecase(ctrl *gomock.Controller, r repository.Repository) usecase.UserUsecase {
+	p := model.DefaultPolicy()
+	p.Group.MaxUsers = 2
+	return usecase.NewUserUsecase(
+		r,
+		mockfactory.NewMockUserFactory(ctrl),
+		domainservice.NewUserService(r),
+		domainservice.NewGroupService(r),
+		factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+		factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+		factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+		p,
+		testClock,
+	)
+}
+
+func TestUserUsecase_changeStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         model.UserStatus
+		change         func(uc usecase.UserUsecase) (*dto.User, error)
+		wantStatus     model.UserStatus
+		wantGroupIDs   []model.GroupID
+		wantWaitlists  map[model.GroupID][]model.UserID
+		wantEventTypes []model.DomainEventType
+		wantErr        error
+	}{
+		{
+			name:   "Activates the invited user",
+			status: model.UserStatusInvited,
+			change: func(uc usecase.UserUsecase) (*dto.User, error) {
+				out, err := uc.ActivateUser(&dto.ActivateUserInput{UserID: "TEST_USER_ID"})
+				if err != nil {
+					return nil, err
+				}
+				return &out.User, nil
+			},
+			wantStatus:   model.UserStatusActive,
+			wantGroupIDs: []model.GroupID{"TEST_GROUP_ID_1"},
+			wantWaitlists: map[model.GroupID][]model.UserID{
+				"TEST_GROUP_ID_1": {"TEST_USER_ID_3"},
+				"TEST_GROUP_ID_2": {"TEST_USER_ID"},
+			},
+			wantEventTypes: []model.DomainEventType{model.DomainEventTypeUserStatusChanged},
+		},
+		{
+			name:   "Suspends the user, who keeps the memberships but leaves the waitlists",
+			status: model.UserStatusActive,
+			change: func(uc usecase.UserUsecase) (*dto.User, error) {
+				out, err := uc.SuspendUser(&dto.SuspendUserInput{UserID: "TEST_USER_ID"})
+				if err != nil {
+					return nil, err
+				}
+				return &out.User, nil
+			},
+			wantStatus:   model.UserStatusSuspended,
+			wantGroupIDs: []model.GroupID{"TEST_GROUP_ID_1"},
+			wantWaitlists: map[model.GroupID][]model.UserID{
+				"TEST_GROUP_ID_1": {"TEST_USER_ID_3"},
+				"TEST_GROUP_ID_2": nil,
+			},
+			wantEventTypes: []model.DomainEventType{model.DomainEventTypeUserStatusChanged},
+		},
+		{
+			name:   "Reactivates the suspended user",
+			status: model.UserStatusSuspended,
+			change: func(uc usecase.UserUsecase) (*dto.User, error) {
+				out, err := uc.ReactivateUser(&dto.ReactivateUserInput{UserID: "TEST_USER_ID"})
+				if err != nil {
+					return nil, err
+				}
+				return &out.User, nil
+			},
+			wantStatus:     model.UserStatusActive,
+			wantGroupIDs:   []model.GroupID{"TEST_GROUP_ID_1"},
+			wantEventTypes: []model.DomainEventType{model.DomainEventTypeUserStatusChanged},
+		},
+		{
+			name:   "Deactivates the user, who leaves the groups and the waitlists",
+			status: model.UserStatusActive,
+			change: func(uc usecase.UserUsecase) (*dto.User, error) {
+				out, err := uc.DeactivateUser(&dto.DeactivateUserInput{UserID: "TEST_USER_ID"})
+				if err != nil {
+					return nil, err
+				}
+				return &out.User, nil
+			},
+			wantStatus:   model.UserStatusDeactivated,
+			wantGroupIDs: nil,
+			wantWaitlists: map[model.GroupID][]model.UserID{
+				"TEST_GROUP_ID_1": nil,
+				"TEST_GROUP_ID_2": nil,
+			},
+			wantEventTypes: []model.DomainEventType{
+				model.DomainEventTypeGroupMembershipChanged,
+				model.DomainEventTypeGroupMembershipChanged,
+				model.DomainEventTypeGroupWaitlistPromoted,
+				model.DomainEventTypeUserStatusChanged,
+			},
+		},
+		{
+			name:   "Returns error if the user cannot be suspended in the status",
+			status: model.UserStatusDeactivated,
+			change: func(uc usecase.UserUsecase) (*dto.User, error) {
+				out, err := uc.SuspendUser(&dto.SuspendUserInput{UserID: "TEST_USER_ID"})
+				if err != nil {
+					return nil, err
+				}
+				return &out.User, nil
+			},
+			wantErr: usecase.ErrInvalidUserStatusTransition,
+		},
+		{
+			name:   "Returns error if the user cannot be reactivated in the status",
+			status: model.UserStatusActive,
+			change: func(uc usecase.UserUsecase) (*dto.User, error) {
+				out, err := uc.ReactivateUser(&dto.ReactivateUserInput{UserID: "TEST_USER_ID"})
+				if err != nil {
+					return nil, err
+				}
+				return &out.User, nil
+			},
+			wantErr: usecase.ErrInvalidUserStatusTransition,
+		},
+		{
+			name:   "Returns error if the user does not exist",
+			status: model.UserStatusActive,
+			change: func(uc usecase.UserUsecase) (*dto.User, error) {
+				out, err := uc.DeactivateUser(&dto.DeactivateUserInput{UserID: "TEST_USER_ID_4"})
+				if err != nil {
+					return nil, err
+				}
+				return &out.User, nil
+			},
+			wantErr: usecase.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			r := newUserStatusMemoryRepository(tt.status)
+			uc := newUserStatusUsecase(ctrl, r)
+
+			got, err := tt.change(uc)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("change=_, %v; want _, %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				u, _ := r.User().Find("TEST_USER_ID")
+				if u.Status() != tt.status {
+					t.Errorf("u.Status()=%s; want %s", u.Status(), tt.status)
+				}
+				assertOutboxMessages(t, r)
+				return
+			}
+
+			if got.Status != string(tt.wantStatus) {
+				t.Errorf("got.Status=%s; want %s", got.Status, tt.wantStatus)
+			}
+			u, _ := r.User().Find("TEST_USER_ID")
+			if u.Status() != tt.wantStatus {
+				t.Errorf("u.Status()=%s; want %s", u.Status(), tt.wantStatus)
+			}
+
+			gs, _ := r.Group().List(repository.GroupListFilter{UserIDs: []model.UserID{"TEST_USER_ID"}})
+			if diff := cmp.Diff(gs.IDs(), tt.wantGroupIDs); diff != "" {
+				t.Errorf("the groups of the user differ: (-got +want)\n%s", diff)
+			}
+			for gID, want := range tt.wantWaitlists {
+				assertGroupWaitlist(t, r, gID, want...)
+			}
+			assertAuditEvent(t, r, dto.Meta{}, model.AuditActionUpdateUser, model.NewUserAuditTarget("TEST_USER_ID"))
+			assertOutboxMessages(t, r, tt.wantEventTypes...)
+		})
+	}
+}
+
+func TestGroupUsecase_AddGroupUsers_deactivated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := newUserStatusMemoryRepository(model.UserStatusActive)
+	if _, err := newUserStatusUsecase(ctrl, r).DeactivateUser(&dto.DeactivateUserInput{UserID: "TEST_USER_ID"}); err != nil {
+		t.Fatalf("want no err, but has error %v", err)
+	}
+
+	uc := usecase.NewGroupUsecase(
+		r, mockfactory.NewMockGroupFactory(ctrl), domainservice.NewGroupService(r), domainservice.NewUserService(r),
+		factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+		factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+		factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+		model.DefaultPolicy(),
+		testClock,
+	)
+	in := &dto.AddGroupUsersInput{GroupID: "TEST_GROUP_ID_1", UserIDs: []string{"TEST_USER_ID"}}
+	if _, err := uc.AddGroupUsers(in); !errors.Is(err, usecase.ErrUserInactive) {
+		t.Errorf("uc.AddGroupUsers(%v)=_, %v; want _, %v", in, err, usecase.ErrUserInactive)
+	}
+}
+
+func TestUserUsecase_leaveGroups_cancelsPending(t *testing.T) {
+	createdAt := testNow.Add(-time.Hour)
+	expiresAt := testNow.Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		leave func(uc usecase.UserUsecase) error
+	}{
+		{
+			name: "Deactivating the user",
+			leave: func(uc usecase.UserUsecase) error {
+				_, err := uc.DeactivateUser(&dto.DeactivateUserInput{Meta: dto.Meta{Actor: "TEST_ACTOR"}, UserID: "TEST_USER_ID"})
+				return err
+			},
+		},
+		{
+			name: "Deleting the user",
+			leave: func(uc usecase.UserUsecase) error {
+				_, err := uc.DeleteUser(&dto.DeleteUserInput{Meta: dto.Meta{Actor: "TEST_ACTOR"}, UserID: "TEST_USER_ID"})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := memory.NewStore()
+			s.AddUsers(
+				model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "test@example.com"),
+				model.MustNewUser("TEST_USER_ID_2", "TEST_USER_NAME_2", "test2@example.com"),
+			)
+			s.AddGroups(model.MustNewGroup("TEST_GROUP_ID", "TEST_GROUP_NAME", []model.UserID{}))
+			s.AddGroupInvitations(
+				model.MustNewGroupInvitation(
+					"TEST_GROUP_INVITATION_ID_USER", "TEST_GROUP_ID", "TEST_INVITER",
+					model.GroupInvitee{UserID: "TEST_USER_ID"}, createdAt, expiresAt, model.GroupInvitationState{},
+				),
+				model.MustNewGroupInvitation(
+					"TEST_GROUP_INVITATION_ID_EMAIL", "TEST_GROUP_ID", "TEST_INVITER",
+					model.GroupInvitee{Email: "TEST@example.com"}, createdAt, expiresAt, model.GroupInvitationState{},
+				),
+				model.MustNewGroupInvitation(
+					"TEST_GROUP_INVITATION_ID_OTHER", "TEST_GROUP_ID", "TEST_INVITER",
+					model.GroupInvitee{UserID: "TEST_USER_ID_2"}, createdAt, expiresAt, model.GroupInvitationState{},
+				),
+			)
+			s.AddGroupJoinRequests(
+				model.MustNewGroupJoinRequest(
+					"TEST_GROUP_JOIN_REQUEST_ID", "TEST_GROUP_ID", "TEST_USER_ID", createdAt, model.GroupJoinRequestState{},
+				),
+				model.MustNewGroupJoinRequest(
+					"TEST_GROUP_JOIN_REQUEST_ID_OTHER", "TEST_GROUP_ID", "TEST_USER_ID_2", createdAt, model.GroupJoinRequestState{},
+				),
+			)
+			r := memory.NewMemoryRepository(s)
+
+			if err := tt.leave(newUserStatusUsecase(ctrl, r)); err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+
+			for id, want := range map[model.GroupInvitationID]model.GroupInvitationStatus{
+				"TEST_GROUP_INVITATION_ID_USER":  model.GroupInvitationStatusRevoked,
+				"TEST_GROUP_INVITATION_ID_EMAIL": model.GroupInvitationStatusRevoked,
+				"TEST_GROUP_INVITATION_ID_OTHER": model.GroupInvitationStatusPending,
+			} {
+				inv, err := r.GroupInvitation().Find(id)
+				if err != nil {
+					t.Fatalf("want no error, but has error %v", err)
+				}
+				if got := inv.State().Status; got != want {
+					t.Errorf("the status of the invitation %s=%s; want %s", id, got, want)
+				}
+			}
+			for id, want := range map[model.GroupJoinRequestID]model.GroupJoinRequestState{
+				"TEST_GROUP_JOIN_REQUEST_ID": {
+					Status:      model.GroupJoinRequestStatusCancelled,
+					RespondedAt: testNow,
+					RespondedBy: "TEST_ACTOR",
+				},
+				"TEST_GROUP_JOIN_REQUEST_ID_OTHER": {Status: model.GroupJoinRequestStatusPending},
+			} {
+				jr, err := r.GroupJoinRequest().Find(id)
+				if err != nil {
+					t.Fatalf("want no error, but has error %v", err)
+				}
+				if diff := cmp.Diff(jr.State(), want); diff != "" {
+					t.Errorf("the state of the join request %s differs: (-got +want)\n%s", id, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestUserUsecase_DeactivateUser_stampsOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := newUserStatusMemoryRepository(model.UserStatusActive)
+	// The clock ticks at every reading, so that reading it more than once stamps different times.
+	at := testNow
+	tick := usecase.ClockFunc(func() time.Time {
+		at = at.Add(time.Second)
+		return at
+	})
+	p := model.DefaultPolicy()
+	p.Group.MaxUsers = 2
+	uc := usecase.NewUserUsecase(
+		r,
+		mockfactory.NewMockUserFactory(ctrl),
+		domainservice.NewUserService(r),
+		domainservice.NewGroupService(r),
+		factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+		factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+		factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+		p,
+		tick,
+	)
+
+	in := &dto.DeactivateUserInput{Meta: dto.Meta{Actor: "TEST_ACTOR"}, UserID: "TEST_USER_ID"}
+	out, err := uc.DeactivateUser(in)
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+
+	want := out.User.UpdatedAt
+	g, err := r.Group().Find("TEST_GROUP_ID_1")
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	if got := g.Timestamps().UpdatedAt; !got.Equal(want) {
+		t.Errorf("g.Timestamps().UpdatedAt=%v; want the time the user is updated %v", got, want)
+	}
+	es, err := r.AuditEvent().List(repository.AuditEventListFilter{})
+	if err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+	for _, e := range es {
+		if !e.OccurredAt().Equal(want) {
+			t.Errorf("e.OccurredAt()=%v; want the time the user is updated %v", e.OccurredAt(), want)
+		}
+	}
+}
+
+func TestUserUsecase_leaveGroups_keepsConcurrentWaitlistChange(t *testing.T) {
+	tests := []struct {
+		name  string
+		leave func(uc usecase.UserUsecase) error
+	}{
+		{
+			name: "Deactivating the user",
+			leave: func(uc usecase.UserUsecase) error {
+				_, err := uc.DeactivateUser(&dto.DeactivateUserInput{Meta: dto.Meta{Actor: "TEST_ACTOR"}, UserID: "TEST_USER_ID"})
+				return err
+			},
+		},
+		{
+			name: "Deleting the user",
+			leave: func(uc usecase.UserUsecase) error {
+				_, err := uc.DeleteUser(&dto.DeleteUserInput{Meta: dto.Meta{Actor: "TEST_ACTOR"}, UserID: "TEST_USER_ID"})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// The waitlisted user leaves the waitlist of the first group concurrently, so that nobody is promoted.
+			r := &interleavedRepository{
+				Repository: newUserStatusMemoryRepository(model.UserStatusActive),
+				concurrent: func(tx repository.Transaction) error {
+					return tx.GroupWaitlist().Delete("TEST_GROUP_ID_1")
+				},
+			}
+
+			if err := tt.leave(newUserStatusUsecase(ctrl, r)); err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+
+			g, err := r.Group().Find("TEST_GROUP_ID_1")
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			want := []model.UserID{"TEST_USER_ID_2"}
+			if diff := cmp.Diff(g.UserIDs(), want); diff != "" {
+				t.Errorf("g.UserIDs()=%v; want %v\ndiffers: (-got +want)\n%s", g.UserIDs(), want, diff)
+			}
+			assertGroupWaitlist(t, r, "TEST_GROUP_ID_1")
+		})
+	}
+}
@@ -0,0 +1,285 @@
+//go:generate mockgen -source=$GOFILE -package=mock$GOPACKAGE -destination=../mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/labstack/gommon/log"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+const (
+	defaultWebhookBatchSize = 100
+	// maxWebhookAttempts is the number of attempts after which a delivery is dead-lettered.
+	maxWebhookAttempts = 8
+)
+
+// WebhookSender sends a delivery to the url of the subscription.
+// It returns the status code of the response if any, and an error unless the status code is 2xx.
+type WebhookSender interface {
+	Send(s *model.WebhookSubscription, d *model.WebhookDelivery) (int, error)
+}
+
+type WebhookUsecase interface {
+	CreateWebhookSubscription(in *dto.CreateWebhookSubscriptionInput) (*dto.CreateWebhookSubscriptionOutput, error)
+	GetWebhookSubscription(in *dto.GetWebhookSubscriptionInput) (*dto.GetWebhookSubscriptionOutput, error)
+	GetWebhookSubscriptions(in *dto.GetWebhookSubscriptionsInput) (*dto.GetWebhookSubscriptionsOutput, error)
+	UpdateWebhookSubscription(in *dto.UpdateWebhookSubscriptionInput) (*dto.UpdateWebhookSubscriptionOutput, error)
+	DeleteWebhookSubscription(in *dto.DeleteWebhookSubscriptionInput) (*dto.DeleteWebhookSubscriptionOutput, error)
+	GetWebhookDeliveries(in *dto.GetWebhookDeliveriesInput) (*dto.GetWebhookDeliveriesOutput, error)
+	RedeliverWebhook(in *dto.RedeliverWebhookInput) (*dto.RedeliverWebhookOutput, error)
+	DeliverWebhooks(in *dto.DeliverWebhooksInput) (*dto.DeliverWebhooksOutput, error)
+}
+
+type webhookUsecase struct {
+	r  repository.Repository
+	f  factory.WebhookSubscriptionFactory
+	ws WebhookSender
+	c  Clock
+}
+
+func NewWebhookUsecase(
+	r repository.Repository,
+	f factory.WebhookSubscriptionFactory,
+	ws WebhookSender,
+	c Clock,
+) WebhookUsecase {
+	return &webhookUsecase{r: r, f: f, ws: ws, c: c}
+}
+
+func (uc *webhookUsecase) CreateWebhookSubscription(
+	in *dto.CreateWebhookSubscriptionInput,
+) (*dto.CreateWebhookSubscriptionOutput, error) {
+	s, err := uc.f.Create(in.URL, dto.ToModelDomainEventTypes(in.EventTypes), in.Secret)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidWebhookSubscription) {
+			return nil, errors.Join(ErrInvalidWebhookSubscriptionInput, err)
+		}
+		return nil, err
+	}
+
+	var created *model.WebhookSubscription
+	if err = uc.r.RunTransaction(func(tx repository.Transaction) error {
+		if created, err = tx.WebhookSubscription().Create(s); err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.CreateWebhookSubscriptionOutput{
+		WebhookSubscription: dto.ToWebhookSubscriptionFromModel(created),
+	}, nil
+}
+
+func (uc *webhookUsecase) GetWebhookSubscription(
+	in *dto.GetWebhookSubscriptionInput,
+) (*dto.GetWebhookSubscriptionOutput, error) {
+	s, err := uc.findSubscription(model.WebhookSubscriptionID(in.WebhookSubscriptionID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.GetWebhookSubscriptionOutput{
+		WebhookSubscription: dto.ToWebhookSubscriptionFromModel(s),
+	}, nil
+}
+
+func (uc *webhookUsecase) GetWebhookSubscriptions(
+	_ *dto.GetWebhookSubscriptionsInput,
+) (*dto.GetWebhookSubscriptionsOutput, error) {
+	ss, err := uc.r.WebhookSubscription().List()
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.GetWebhookSubscriptionsOutput{
+		WebhookSubscriptions: dto.ToWebhookSubscriptionsFromModel(ss),
+	}, nil
+}
+
+func (uc *webhookUsecase) UpdateWebhookSubscription(
+	in *dto.UpdateWebhookSubscriptionInput,
+) (*dto.UpdateWebhookSubscriptionOutput, error) {
+	before, err := uc.findSubscription(model.WebhookSubscriptionID(in.WebhookSubscriptionID))
+	if err != nil {
+		return nil, err
+	}
+
+	secret := in.Secret
+	if secret == "" {
+		secret = before.Secret()
+	}
+
+	s, err := model.NewWebhookSubscription(before.ID(), in.URL, dto.ToModelDomainEventTypes(in.EventTypes), secret)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidWebhookSubscriptionInput, err)
+	}
+
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		return tx.WebhookSubscription().Update(s)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.UpdateWebhookSubscriptionOutput{}, nil
+}
+
+func (uc *webhookUsecase) DeleteWebhookSubscription(
+	in *dto.DeleteWebhookSubscriptionInput,
+) (*dto.DeleteWebhookSubscriptionOutput, error) {
+	s, err := uc.findSubscription(model.WebhookSubscriptionID(in.WebhookSubscriptionID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		return tx.WebhookSubscription().Delete(s.ID())
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.DeleteWebhookSubscriptionOutput{}, nil
+}
+
+func (uc *webhookUsecase) GetWebhookDeliveries(
+	in *dto.GetWebhookDeliveriesInput,
+) (*dto.GetWebhookDeliveriesOutput, error) {
+	status := model.WebhookDeliveryStatus(in.Status)
+	if status != "" && !status.IsValid() {
+		return nil, fmt.Errorf("unknown status %q: %w", in.Status, ErrInvalidWebhookDeliveryInput)
+	}
+
+	s, err := uc.findSubscription(model.WebhookSubscriptionID(in.WebhookSubscriptionID))
+	if err != nil {
+		return nil, err
+	}
+
+	ds, err := uc.r.WebhookDelivery().List(repository.WebhookDeliveryListFilter{
+		SubscriptionID: s.ID(),
+		Status:         status,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.GetWebhookDeliveriesOutput{
+		WebhookDeliveries: dto.ToWebhookDeliveriesFromModel(ds),
+	}, nil
+}
+
+func (uc *webhookUsecase) RedeliverWebhook(in *dto.RedeliverWebhookInput) (*dto.RedeliverWebhookOutput, error) {
+	dID := model.WebhookDeliveryID(in.WebhookDeliveryID)
+
+	d, err := uc.r.WebhookDelivery().Find(dID)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil || d.SubscriptionID() != model.WebhookSubscriptionID(in.WebhookSubscriptionID) {
+		log.Warnf("the webhook delivery is not found; webhookDeliveryID=%s", dID)
+		return nil, ErrWebhookDeliveryNotFound
+	}
+
+	d.Redeliver(now(uc.c))
+
+	if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+		return tx.WebhookDelivery().Update(d)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.RedeliverWebhookOutput{
+		WebhookDelivery: dto.ToWebhookDeliveryFromModel(d),
+	}, nil
+}
+
+func (uc *webhookUsecase) DeliverWebhooks(in *dto.DeliverWebhooksInput) (*dto.DeliverWebhooksOutput, error) {
+	limit := in.BatchSize
+	if limit <= 0 {
+		limit = defaultWebhookBatchSize
+	}
+
+	ds, err := uc.r.WebhookDelivery().List(repository.WebhookDeliveryListFilter{
+		Status:      model.WebhookDeliveryStatusPending,
+		AvailableAt: now(uc.c),
+		Limit:       limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &dto.DeliverWebhooksOutput{}
+	ss := map[model.WebhookSubscriptionID]*model.WebhookSubscription{}
+	for _, d := range ds {
+		s, ok := ss[d.SubscriptionID()]
+		if !ok {
+			if s, err = uc.r.WebhookSubscription().Find(d.SubscriptionID()); err != nil {
+				return nil, err
+			}
+			ss[d.SubscriptionID()] = s
+		}
+		if s == nil {
+			// The subscription was deleted after the delivery was listed.
+			continue
+		}
+
+		statusCode, err := uc.ws.Send(s, d)
+		switch {
+		case err == nil:
+			d.MarkSucceeded(statusCode, now(uc.c))
+			out.Succeeded++
+		case d.State().Attempts+1 >= maxWebhookAttempts:
+			d.MarkDead(statusCode, err)
+			out.Dead++
+		default:
+			d.MarkFailed(statusCode, err, now(uc.c).Add(retryDelay(d.State().Attempts)))
+			out.Failed++
+		}
+
+		if err := uc.r.RunTransaction(func(tx repository.Transaction) error {
+			return tx.WebhookDelivery().Update(d)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+func (uc *webhookUsecase) findSubscription(id model.WebhookSubscriptionID) (*model.WebhookSubscription, error) {
+	s, err := uc.r.WebhookSubscription().Find(id)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		log.Warnf("the webhook subscription is not found; webhookSubscriptionID=%s", id)
+		return nil, ErrWebhookSubscriptionNotFound
+	}
+	return s, nil
+}
+
+// enqueueWebhookDeliveries creates a delivery of the messages for every subscription subscribing to them,
+// so that they are committed or rolled back together with the change that raised the messages.
+func enqueueWebhookDeliveries(
+	tx repository.Transaction,
+	f factory.WebhookDeliveryFactory,
+	ms model.OutboxMessages,
+) error {
+	ss, err := tx.WebhookSubscription().List()
+	if err != nil {
+		return err
+	}
+
+	ds, err := f.Create(ss, ms)
+	if err != nil {
+		return err
+	}
+
+	return tx.WebhookDelivery().Create(ds)
+}
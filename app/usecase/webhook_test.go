@@ -0,0 +1,357 @@
+package usecase_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/toshiykst/go-layerd-architecture/app/domain/domainservice"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/model"
+	"github.com/toshiykst/go-layerd-architecture/app/domain/repository"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/memory"
+	"github.com/toshiykst/go-layerd-architecture/app/infrastructure/webhook"
+	mockfactory "github.com/toshiykst/go-layerd-architecture/app/mock/domain/factory"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+func newWebhookUsecase(r repository.Repository, ws usecase.WebhookSender) usecase.WebhookUsecase {
+	return usecase.NewWebhookUsecase(
+		r, factory.NewWebhookSubscriptionFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_SUBSCRIPTION_ID_")), ws, testClock,
+	)
+}
+
+func TestWebhookUsecase_CreateWebhookSubscription(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      *dto.CreateWebhookSubscriptionInput
+		want    *dto.CreateWebhookSubscriptionOutput
+		wantErr error
+	}{
+		{
+			name: "Create a webhook subscription",
+			in: &dto.CreateWebhookSubscriptionInput{
+				URL:        "https://example.com/hooks",
+				EventTypes: []string{"UserCreated", "UserDeleted"},
+				Secret:     "TEST_SECRET",
+			},
+			want: &dto.CreateWebhookSubscriptionOutput{
+				WebhookSubscription: dto.WebhookSubscription{
+					URL:        "https://example.com/hooks",
+					EventTypes: []string{"UserCreated", "UserDeleted"},
+				},
+			},
+		},
+		{
+			name: "Returns error if the event type is unknown",
+			in: &dto.CreateWebhookSubscriptionInput{
+				URL:        "https://example.com/hooks",
+				EventTypes: []string{"UserRenamed"},
+				Secret:     "TEST_SECRET",
+			},
+			wantErr: usecase.ErrInvalidWebhookSubscriptionInput,
+		},
+		{
+			name: "Returns error if the url is not http(s)",
+			in: &dto.CreateWebhookSubscriptionInput{
+				URL:        "ftp://example.com/hooks",
+				EventTypes: []string{"UserCreated"},
+				Secret:     "TEST_SECRET",
+			},
+			wantErr: usecase.ErrInvalidWebhookSubscriptionInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := memory.NewMemoryRepository(memory.NewStore())
+			uc := newWebhookUsecase(r, webhook.NewSender(nil))
+
+			got, err := uc.CreateWebhookSubscription(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("uc.CreateWebhookSubscription(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if got.WebhookSubscription.WebhookSubscriptionID == "" {
+				t.Error("got.WebhookSubscription.WebhookSubscriptionID is empty")
+			}
+			got.WebhookSubscription.WebhookSubscriptionID = ""
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf(
+					"uc.CreateWebhookSubscription(%v)=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s",
+					tt.in, got, tt.want, diff,
+				)
+			}
+		})
+	}
+}
+
+func TestWebhookUsecase_UpdateWebhookSubscription(t *testing.T) {
+	s := model.MustNewWebhookSubscription(
+		"TEST_WEBHOOK_SUBSCRIPTION_ID",
+		"https://example.com/hooks",
+		[]model.DomainEventType{model.DomainEventTypeUserCreated},
+		"TEST_SECRET",
+	)
+	store := memory.NewStore()
+	store.AddWebhookSubscriptions(s)
+	r := memory.NewMemoryRepository(store)
+	uc := newWebhookUsecase(r, webhook.NewSender(nil))
+
+	in := &dto.UpdateWebhookSubscriptionInput{
+		WebhookSubscriptionID: "TEST_WEBHOOK_SUBSCRIPTION_ID",
+		URL:                   "https://example.com/hooks/v2",
+		EventTypes:            []string{"GroupCreated"},
+	}
+	if _, err := uc.UpdateWebhookSubscription(in); err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+
+	got, _ := r.WebhookSubscription().Find("TEST_WEBHOOK_SUBSCRIPTION_ID")
+	want := model.MustNewWebhookSubscription(
+		"TEST_WEBHOOK_SUBSCRIPTION_ID",
+		"https://example.com/hooks/v2",
+		[]model.DomainEventType{model.DomainEventTypeGroupCreated},
+		"TEST_SECRET",
+	)
+	if diff := cmp.Diff(got, want, cmp.AllowUnexported(model.WebhookSubscription{})); diff != "" {
+		t.Errorf("r.WebhookSubscription().Find()=%v; want %v\ndiffers: (-got +want)\n%s", got, want, diff)
+	}
+
+	in.WebhookSubscriptionID = "UNKNOWN"
+	if _, err := uc.UpdateWebhookSubscription(in); !errors.Is(err, usecase.ErrWebhookSubscriptionNotFound) {
+		t.Errorf("uc.UpdateWebhookSubscription(%v)=_, %v; want _, %v", in, err, usecase.ErrWebhookSubscriptionNotFound)
+	}
+}
+
+func TestWebhookUsecase_DeliverWebhooks(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		status       int
+		attempts     int
+		want         *dto.DeliverWebhooksOutput
+		wantStatus   model.WebhookDeliveryStatus
+		wantAttempts int
+		// wantDelay is the backoff before the next attempt of a failed delivery.
+		wantDelay time.Duration
+	}{
+		{
+			name:         "Delivers a webhook",
+			status:       http.StatusOK,
+			want:         &dto.DeliverWebhooksOutput{Succeeded: 1},
+			wantStatus:   model.WebhookDeliveryStatusSucceeded,
+			wantAttempts: 1,
+		},
+		{
+			name:         "Schedules a retry when the receiver fails",
+			status:       http.StatusInternalServerError,
+			want:         &dto.DeliverWebhooksOutput{Failed: 1},
+			wantStatus:   model.WebhookDeliveryStatusPending,
+			wantAttempts: 1,
+			wantDelay:    time.Second,
+		},
+		{
+			name:         "Doubles the backoff for every failed attempt",
+			status:       http.StatusInternalServerError,
+			attempts:     3,
+			want:         &dto.DeliverWebhooksOutput{Failed: 1},
+			wantStatus:   model.WebhookDeliveryStatusPending,
+			wantAttempts: 4,
+			wantDelay:    8 * time.Second,
+		},
+		{
+			name:         "Dead-letters the delivery after the last attempt",
+			status:       http.StatusInternalServerError,
+			attempts:     7,
+			want:         &dto.DeliverWebhooksOutput{Dead: 1},
+			wantStatus:   model.WebhookDeliveryStatusDead,
+			wantAttempts: 8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&received, 1)
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			s := memory.NewStore()
+			s.AddWebhookSubscriptions(model.MustNewWebhookSubscription(
+				"TEST_WEBHOOK_SUBSCRIPTION_ID",
+				srv.URL,
+				[]model.DomainEventType{model.DomainEventTypeUserCreated},
+				"TEST_SECRET",
+			))
+			s.AddWebhookDeliveries(model.MustNewWebhookDelivery(
+				"TEST_WEBHOOK_DELIVERY_ID",
+				"TEST_WEBHOOK_SUBSCRIPTION_ID",
+				"TEST_EVENT_ID",
+				model.DomainEventTypeUserCreated,
+				[]byte(`{}`),
+				occurredAt,
+				model.WebhookDeliveryState{Attempts: tt.attempts},
+			))
+			r := memory.NewMemoryRepository(s)
+			uc := newWebhookUsecase(r, webhook.NewSender(srv.Client()))
+
+			got, err := uc.DeliverWebhooks(&dto.DeliverWebhooksInput{})
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("uc.DeliverWebhooks()=%v, nil; want %v, nil\ndiffers: (-got +want)\n%s", got, tt.want, diff)
+			}
+			if received != 1 {
+				t.Errorf("received=%d; want 1", received)
+			}
+
+			d, _ := r.WebhookDelivery().Find("TEST_WEBHOOK_DELIVERY_ID")
+			if d.State().Status != tt.wantStatus {
+				t.Errorf("d.State().Status=%s; want %s", d.State().Status, tt.wantStatus)
+			}
+			if d.State().Attempts != tt.wantAttempts {
+				t.Errorf("d.State().Attempts=%d; want %d", d.State().Attempts, tt.wantAttempts)
+			}
+			if d.State().LastStatusCode != tt.status {
+				t.Errorf("d.State().LastStatusCode=%d; want %d", d.State().LastStatusCode, tt.status)
+			}
+			if tt.wantDelay > 0 {
+				if next, want := d.State().NextAttemptAt, testNow.Add(tt.wantDelay); !next.Equal(want) {
+					t.Errorf("d.State().NextAttemptAt=%v; want %v", next, want)
+				}
+			}
+
+			// A failed delivery is not retried before its backoff has elapsed.
+			if _, err := uc.DeliverWebhooks(&dto.DeliverWebhooksInput{}); err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if received != 1 {
+				t.Errorf("received=%d after the second run; want 1", received)
+			}
+		})
+	}
+}
+
+func TestWebhookUsecase_RedeliverWebhook(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	newMemoryRepository := func() repository.Repository {
+		s := memory.NewStore()
+		s.AddWebhookDeliveries(model.MustNewWebhookDelivery(
+			"TEST_WEBHOOK_DELIVERY_ID",
+			"TEST_WEBHOOK_SUBSCRIPTION_ID",
+			"TEST_EVENT_ID",
+			model.DomainEventTypeUserCreated,
+			[]byte(`{}`),
+			occurredAt,
+			model.WebhookDeliveryState{Status: model.WebhookDeliveryStatusDead, Attempts: 8},
+		))
+		return memory.NewMemoryRepository(s)
+	}
+
+	tests := []struct {
+		name    string
+		in      *dto.RedeliverWebhookInput
+		wantErr error
+	}{
+		{
+			name: "Makes a dead-lettered delivery pending again",
+			in: &dto.RedeliverWebhookInput{
+				WebhookSubscriptionID: "TEST_WEBHOOK_SUBSCRIPTION_ID",
+				WebhookDeliveryID:     "TEST_WEBHOOK_DELIVERY_ID",
+			},
+		},
+		{
+			name: "Returns error if the delivery belongs to another subscription",
+			in: &dto.RedeliverWebhookInput{
+				WebhookSubscriptionID: "OTHER_WEBHOOK_SUBSCRIPTION_ID",
+				WebhookDeliveryID:     "TEST_WEBHOOK_DELIVERY_ID",
+			},
+			wantErr: usecase.ErrWebhookDeliveryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newMemoryRepository()
+			uc := newWebhookUsecase(r, webhook.NewSender(nil))
+
+			got, err := uc.RedeliverWebhook(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("uc.RedeliverWebhook(%v)=_, %v; want _, %v", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but has error %v", err)
+			}
+			if got.WebhookDelivery.Status != string(model.WebhookDeliveryStatusPending) {
+				t.Errorf("got.WebhookDelivery.Status=%s; want PENDING", got.WebhookDelivery.Status)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_CreateUser_EnqueuesWebhookDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := memory.NewStore()
+	s.AddWebhookSubscriptions(
+		model.MustNewWebhookSubscription(
+			"TEST_WEBHOOK_SUBSCRIPTION_ID_1",
+			"https://example.com/hooks",
+			[]model.DomainEventType{model.DomainEventTypeUserCreated},
+			"TEST_SECRET",
+		),
+		model.MustNewWebhookSubscription(
+			"TEST_WEBHOOK_SUBSCRIPTION_ID_2",
+			"https://example.com/hooks",
+			[]model.DomainEventType{model.DomainEventTypeGroupCreated},
+			"TEST_SECRET",
+		),
+	)
+	r := memory.NewMemoryRepository(s)
+
+	f := mockfactory.NewMockUserFactory(ctrl)
+	f.EXPECT().
+		Create("TEST_USER_NAME", "TEST_USER_EMAIL").
+		Return(model.MustNewUser("TEST_USER_ID", "TEST_USER_NAME", "TEST_USER_EMAIL"), nil)
+
+	uc := usecase.NewUserUsecase(
+		r, f, domainservice.NewUserService(r), domainservice.NewGroupService(r),
+		factory.NewAuditEventFactory(factory.NewSequenceIDGenerator("TEST_NEW_AUDIT_EVENT_ID_")),
+		factory.NewOutboxMessageFactory(factory.NewSequenceIDGenerator("TEST_NEW_OUTBOX_MESSAGE_ID_")),
+		factory.NewWebhookDeliveryFactory(factory.NewSequenceIDGenerator("TEST_NEW_WEBHOOK_DELIVERY_ID_")),
+		model.DefaultPolicy(),
+		testClock,
+	)
+	if _, err := uc.CreateUser(&dto.CreateUserInput{Name: "TEST_USER_NAME", Email: "TEST_USER_EMAIL"}); err != nil {
+		t.Fatalf("want no error, but has error %v", err)
+	}
+
+	ds, _ := r.WebhookDelivery().List(repository.WebhookDeliveryListFilter{})
+	if len(ds) != 1 {
+		t.Fatalf("len(r.WebhookDelivery().List())=%d; want 1", len(ds))
+	}
+	if ds[0].SubscriptionID() != "TEST_WEBHOOK_SUBSCRIPTION_ID_1" || ds[0].EventType() != model.DomainEventTypeUserCreated {
+		t.Errorf("ds[0]=%v; want a UserCreated delivery to TEST_WEBHOOK_SUBSCRIPTION_ID_1", ds[0])
+	}
+}
@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/gommon/log"
+
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// GroupInvitationSweeper periodically marks the pending group invitations which have expired as expired.
+type GroupInvitationSweeper struct {
+	uc       usecase.GroupInvitationUsecase
+	interval time.Duration
+}
+
+func NewGroupInvitationSweeper(uc usecase.GroupInvitationUsecase, interval time.Duration) *GroupInvitationSweeper {
+	return &GroupInvitationSweeper{uc: uc, interval: interval}
+}
+
+// Run expires the group invitations until ctx is done.
+func (w *GroupInvitationSweeper) Run(ctx context.Context) {
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if _, err := w.uc.ExpireGroupInvitations(&dto.ExpireGroupInvitationsInput{}); err != nil {
+				log.Errorf("failed to expire group invitations: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/gommon/log"
+
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// IdempotencyKeyPurger periodically deletes the expired idempotency keys.
+type IdempotencyKeyPurger struct {
+	uc       usecase.IdempotencyUsecase
+	interval time.Duration
+}
+
+func NewIdempotencyKeyPurger(uc usecase.IdempotencyUsecase, interval time.Duration) *IdempotencyKeyPurger {
+	return &IdempotencyKeyPurger{uc: uc, interval: interval}
+}
+
+// Run purges the expired idempotency keys until ctx is done.
+func (w *IdempotencyKeyPurger) Run(ctx context.Context) {
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if _, err := w.uc.PurgeIdempotencyKeys(&dto.PurgeIdempotencyKeysInput{}); err != nil {
+				log.Errorf("failed to purge idempotency keys: %v", err)
+			}
+		}
+	}
+}
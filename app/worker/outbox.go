@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/gommon/log"
+
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// OutboxRelay periodically publishes the pending outbox messages.
+type OutboxRelay struct {
+	uc        usecase.OutboxUsecase
+	interval  time.Duration
+	batchSize int
+}
+
+func NewOutboxRelay(uc usecase.OutboxUsecase, interval time.Duration, batchSize int) *OutboxRelay {
+	return &OutboxRelay{uc: uc, interval: interval, batchSize: batchSize}
+}
+
+// Run relays the outbox until ctx is done.
+func (w *OutboxRelay) Run(ctx context.Context) {
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			out, err := w.uc.RelayOutbox(&dto.RelayOutboxInput{BatchSize: w.batchSize})
+			if err != nil {
+				log.Errorf("failed to relay outbox: %v", err)
+				continue
+			}
+			if out.Failed > 0 {
+				log.Warnf("outbox relay: %d published, %d failed", out.Published, out.Failed)
+			}
+		}
+	}
+}
+
+// OutboxPurger periodically deletes the outbox messages published longer ago than the retention.
+type OutboxPurger struct {
+	uc        usecase.OutboxUsecase
+	interval  time.Duration
+	retention time.Duration
+}
+
+func NewOutboxPurger(uc usecase.OutboxUsecase, interval, retention time.Duration) *OutboxPurger {
+	return &OutboxPurger{uc: uc, interval: interval, retention: retention}
+}
+
+// Run purges the published outbox messages until ctx is done.
+func (w *OutboxPurger) Run(ctx context.Context) {
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if _, err := w.uc.PurgeOutbox(&dto.PurgeOutboxInput{Retention: w.retention}); err != nil {
+				log.Errorf("failed to purge outbox: %v", err)
+			}
+		}
+	}
+}
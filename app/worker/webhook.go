@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/gommon/log"
+
+	"github.com/toshiykst/go-layerd-architecture/app/usecase"
+	"github.com/toshiykst/go-layerd-architecture/app/usecase/dto"
+)
+
+// WebhookDispatcher periodically sends the pending webhook deliveries.
+type WebhookDispatcher struct {
+	uc        usecase.WebhookUsecase
+	interval  time.Duration
+	batchSize int
+}
+
+func NewWebhookDispatcher(uc usecase.WebhookUsecase, interval time.Duration, batchSize int) *WebhookDispatcher {
+	return &WebhookDispatcher{uc: uc, interval: interval, batchSize: batchSize}
+}
+
+// Run dispatches the webhook deliveries until ctx is done.
+func (w *WebhookDispatcher) Run(ctx context.Context) {
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			out, err := w.uc.DeliverWebhooks(&dto.DeliverWebhooksInput{BatchSize: w.batchSize})
+			if err != nil {
+				log.Errorf("failed to deliver webhooks: %v", err)
+				continue
+			}
+			if out.Failed > 0 || out.Dead > 0 {
+				log.Warnf(
+					"webhook dispatcher: %d succeeded, %d failed, %d dead-lettered",
+					out.Succeeded, out.Failed, out.Dead,
+				)
+			}
+		}
+	}
+}
@@ -1,19 +1,35 @@
 CREATE TABLE IF NOT EXISTS `users`
 (
     `id`         VARCHAR(255) PRIMARY KEY NOT NULL,
-    `name`       VARCHAR(255)             NOT NULL,
-    `email`      VARCHAR(255)             NOT NULL,
-    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
-    `updated_at` TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+    `name`       VARCHAR(255) COLLATE utf8mb4_0900_as_ci NOT NULL,
+    `email`      VARCHAR(255) COLLATE utf8mb4_0900_as_ci NOT NULL,
+    `status`     VARCHAR(255)             NOT NULL DEFAULT 'ACTIVE',
+    `attributes` JSON                     NULL,
+    `created_at` TIMESTAMP                NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `created_by` VARCHAR(255)             NOT NULL DEFAULT '',
+    `updated_at` TIMESTAMP                NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_by` VARCHAR(255)             NOT NULL DEFAULT '',
+    INDEX `idx_users_email` (`email`),
+    FULLTEXT INDEX `ftx_users_name_email` (`name`, `email`) WITH PARSER ngram,
+    INDEX `idx_users_status` (`status`),
+    INDEX `idx_users_created_at` (`created_at`),
+    INDEX `idx_users_updated_at` (`updated_at`)
 ) ENGINE = InnoDB
   DEFAULT CHARSET = utf8mb4;
 
 CREATE TABLE IF NOT EXISTS `groups`
 (
-    `id`         VARCHAR(255) PRIMARY KEY NOT NULL,
-    `name`       VARCHAR(255)             NOT NULL,
-    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
-    `updated_at` TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+    `id`          VARCHAR(255) PRIMARY KEY NOT NULL,
+    `name`        VARCHAR(255) COLLATE utf8mb4_0900_as_ci NOT NULL,
+    `join_policy` VARCHAR(255)             NOT NULL DEFAULT 'OPEN',
+    `attributes`  JSON                     NULL,
+    `created_at`  TIMESTAMP                NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `created_by`  VARCHAR(255)             NOT NULL DEFAULT '',
+    `updated_at`  TIMESTAMP                NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_by`  VARCHAR(255)             NOT NULL DEFAULT '',
+    FULLTEXT INDEX `ftx_groups_name` (`name`) WITH PARSER ngram,
+    INDEX `idx_groups_created_at` (`created_at`),
+    INDEX `idx_groups_updated_at` (`updated_at`)
 ) ENGINE = InnoDB
   DEFAULT CHARSET = utf8mb4;
 
@@ -27,3 +43,162 @@ CREATE TABLE IF NOT EXISTS `group_users`
     CONSTRAINT `fk_group_users_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
 ) ENGINE = InnoDB
   DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `group_labels`
+(
+    `group_id` VARCHAR(255) NOT NULL,
+    `key`      VARCHAR(317) NOT NULL,
+    `value`    VARCHAR(63)  NOT NULL,
+    PRIMARY KEY (`group_id`, `key`),
+    INDEX `idx_group_labels_key_value` (`key`, `value`),
+    CONSTRAINT `fk_group_labels_group_id` FOREIGN KEY (`group_id`) REFERENCES `groups` (`id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `subgroups`
+(
+    `parent_group_id` VARCHAR(255) NOT NULL,
+    `child_group_id`  VARCHAR(255) NOT NULL,
+    `created_at`      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`parent_group_id`, `child_group_id`),
+    INDEX `idx_subgroups_child_group_id` (`child_group_id`),
+    CONSTRAINT `fk_subgroups_parent_group_id` FOREIGN KEY (`parent_group_id`) REFERENCES `groups` (`id`),
+    CONSTRAINT `fk_subgroups_child_group_id` FOREIGN KEY (`child_group_id`) REFERENCES `groups` (`id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `group_waitlist_entries`
+(
+    `group_id`   VARCHAR(255) NOT NULL,
+    `user_id`    VARCHAR(255) NOT NULL,
+    `position`   INT          NOT NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`group_id`, `user_id`),
+    INDEX `idx_group_waitlist_entries_group_id_position` (`group_id`, `position`),
+    CONSTRAINT `fk_group_waitlist_entries_group_id` FOREIGN KEY (`group_id`) REFERENCES `groups` (`id`),
+    CONSTRAINT `fk_group_waitlist_entries_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `audit_events`
+(
+    `id`          VARCHAR(255) PRIMARY KEY NOT NULL,
+    `actor`       VARCHAR(255)             NOT NULL,
+    `action`      VARCHAR(255)             NOT NULL,
+    `target_type` VARCHAR(255)             NOT NULL,
+    `target_id`   VARCHAR(255)             NOT NULL,
+    `changes`     JSON                     NOT NULL,
+    `request_id`  VARCHAR(255)             NOT NULL,
+    `occurred_at` TIMESTAMP(6)             NOT NULL,
+    INDEX `idx_audit_events_actor` (`actor`),
+    INDEX `idx_audit_events_target` (`target_type`, `target_id`),
+    INDEX `idx_audit_events_occurred_at` (`occurred_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `outbox_messages`
+(
+    `id`              VARCHAR(255) PRIMARY KEY NOT NULL,
+    `seq`             BIGINT                   NOT NULL AUTO_INCREMENT UNIQUE,
+    `event_type`      VARCHAR(255)             NOT NULL,
+    `aggregate_type`  VARCHAR(255)             NOT NULL,
+    `aggregate_id`    VARCHAR(255)             NOT NULL,
+    `payload`         JSON                     NOT NULL,
+    `occurred_at`     TIMESTAMP(6)             NOT NULL,
+    `attempts`        INT                      NOT NULL DEFAULT 0,
+    `last_error`      TEXT                     NOT NULL,
+    `next_attempt_at` TIMESTAMP(6)             NOT NULL,
+    `published_at`    TIMESTAMP(6)             NULL,
+    `published_seq`   BIGINT                   NULL UNIQUE,
+    INDEX `idx_outbox_messages_pending` (`published_at`, `next_attempt_at`),
+    INDEX `idx_outbox_messages_aggregate` (`aggregate_type`, `aggregate_id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `webhook_subscriptions`
+(
+    `id`          VARCHAR(255) PRIMARY KEY NOT NULL,
+    `url`         VARCHAR(2048)            NOT NULL,
+    `event_types` JSON                     NOT NULL,
+    `secret`      VARCHAR(255)             NOT NULL
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `webhook_deliveries`
+(
+    `id`               VARCHAR(255) PRIMARY KEY NOT NULL,
+    `subscription_id`  VARCHAR(255)             NOT NULL,
+    `event_id`         VARCHAR(255)             NOT NULL,
+    `event_type`       VARCHAR(255)             NOT NULL,
+    `payload`          JSON                     NOT NULL,
+    `occurred_at`      TIMESTAMP(6)             NOT NULL,
+    `status`           VARCHAR(255)             NOT NULL,
+    `attempts`         INT                      NOT NULL DEFAULT 0,
+    `last_status_code` INT                      NOT NULL DEFAULT 0,
+    `last_error`       TEXT                     NOT NULL,
+    `next_attempt_at`  TIMESTAMP(6)             NOT NULL,
+    `delivered_at`     TIMESTAMP(6)             NULL,
+    INDEX `idx_webhook_deliveries_subscription_id` (`subscription_id`, `occurred_at`),
+    INDEX `idx_webhook_deliveries_pending` (`status`, `next_attempt_at`),
+    CONSTRAINT `fk_webhook_deliveries_subscription_id` FOREIGN KEY (`subscription_id`) REFERENCES `webhook_subscriptions` (`id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `idempotency_keys`
+(
+    `key`           VARCHAR(255) PRIMARY KEY NOT NULL,
+    `fingerprint`   VARCHAR(255)             NOT NULL,
+    `status_code`   INT                      NOT NULL DEFAULT 0,
+    `content_type`  VARCHAR(255)             NOT NULL,
+    `response_body` MEDIUMBLOB               NULL,
+    `created_at`    TIMESTAMP(6)             NOT NULL,
+    `expires_at`    TIMESTAMP(6)             NOT NULL,
+    INDEX `idx_idempotency_keys_expires_at` (`expires_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `group_invitations`
+(
+    `id`              VARCHAR(255) PRIMARY KEY NOT NULL,
+    `group_id`        VARCHAR(255)             NOT NULL,
+    `inviter`         VARCHAR(255)             NOT NULL,
+    `invitee_user_id` VARCHAR(255)             NOT NULL DEFAULT '',
+    `invitee_email`   VARCHAR(255)             NOT NULL DEFAULT '',
+    `created_at`      TIMESTAMP(6)             NOT NULL,
+    `expires_at`      TIMESTAMP(6)             NOT NULL,
+    `status`          VARCHAR(255)             NOT NULL,
+    `responded_at`    TIMESTAMP(6)             NULL,
+    INDEX `idx_group_invitations_group_id` (`group_id`, `created_at`),
+    INDEX `idx_group_invitations_invitee_user_id` (`invitee_user_id`),
+    INDEX `idx_group_invitations_invitee_email` (`invitee_email`),
+    INDEX `idx_group_invitations_pending` (`status`, `expires_at`),
+    CONSTRAINT `fk_group_invitations_group_id` FOREIGN KEY (`group_id`) REFERENCES `groups` (`id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `group_join_requests`
+(
+    `id`           VARCHAR(255) PRIMARY KEY NOT NULL,
+    `group_id`     VARCHAR(255)             NOT NULL,
+    `user_id`      VARCHAR(255)             NOT NULL,
+    `created_at`   TIMESTAMP(6)             NOT NULL,
+    `status`       VARCHAR(255)             NOT NULL,
+    `responded_at` TIMESTAMP(6)             NULL,
+    `responded_by` VARCHAR(255)             NOT NULL DEFAULT '',
+    INDEX `idx_group_join_requests_group_id` (`group_id`, `status`, `created_at`),
+    INDEX `idx_group_join_requests_user_id` (`user_id`),
+    CONSTRAINT `fk_group_join_requests_group_id` FOREIGN KEY (`group_id`) REFERENCES `groups` (`id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `attribute_schemas`
+(
+    `target`   VARCHAR(255)  NOT NULL,
+    `key`      VARCHAR(64)   NOT NULL,
+    `type`     VARCHAR(255)  NOT NULL,
+    `required` BOOLEAN       NOT NULL DEFAULT FALSE,
+    `enum`     JSON          NULL,
+    `pattern`  VARCHAR(1024) NOT NULL DEFAULT '',
+    PRIMARY KEY (`target`, `key`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4;